@@ -99,3 +99,17 @@ type Project struct {
 type ProjectSpec struct {
 	Description string `json:"description,omitempty"`
 }
+
+// ProjectList is the response of a list request of Projects
+type ProjectList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []Project `json:"items"`
+}
+
+// SupervisorNamespaceList is the response of a list request of SupervisorNamespaces
+type SupervisorNamespaceList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []SupervisorNamespace `json:"items"`
+}
@@ -27,7 +27,7 @@ func (client Client) GetAccessControl(href, entityType, entityName string, heade
 
 	acUrl, err := url.ParseRequestURI(href)
 	if err != nil {
-		return nil, fmt.Errorf("[client.GetAccessControl] error parsing HREF %s: %s", href, err)
+		return nil, fmt.Errorf("[client.GetAccessControl] error parsing HREF %s: %w", href, err)
 	}
 	var additionalHeader = make(http.Header)
 
@@ -48,13 +48,13 @@ func (client Client) GetAccessControl(href, entityType, entityName string, heade
 
 	resp, err := checkResp(client.Http.Do(req))
 	if err != nil {
-		return nil, fmt.Errorf("[client.GetAccessControl] error checking response to request %s: %s", href, err)
+		return nil, fmt.Errorf("[client.GetAccessControl] error checking response to request %s: %w", href, err)
 	}
 	if resp == nil {
 		return nil, fmt.Errorf("[client.GetAccessControl] nil response received")
 	}
 	if err = decodeBody(types.BodyTypeXML, resp, &controlAccess); err != nil {
-		return nil, fmt.Errorf("[client.GetAccessControl] error decoding response: %s", err)
+		return nil, fmt.Errorf("[client.GetAccessControl] error decoding response: %w", err)
 	}
 
 	return &controlAccess, nil
@@ -97,7 +97,7 @@ func (client *Client) setAccessControlWithHttpMethod(httpMethod string, accessCo
 	accessControl.Xmlns = types.XMLNamespaceVCloud
 	queryUrl, err := url.ParseRequestURI(href)
 	if err != nil {
-		return fmt.Errorf("[client.SetAccessControl] error parsing HREF %s: %s", href, err)
+		return fmt.Errorf("[client.SetAccessControl] error parsing HREF %s: %w", href, err)
 	}
 
 	var header = make(http.Header)
@@ -109,7 +109,7 @@ func (client *Client) setAccessControlWithHttpMethod(httpMethod string, accessCo
 
 	marshaledXml, err := xml.MarshalIndent(accessControl, "  ", "    ")
 	if err != nil {
-		return fmt.Errorf("[client.SetAccessControl] error marshalling xml data: %s", err)
+		return fmt.Errorf("[client.SetAccessControl] error marshalling xml data: %w", err)
 	}
 	body := bytes.NewBufferString(xml.Header + string(marshaledXml))
 
@@ -126,7 +126,7 @@ func (client *Client) setAccessControlWithHttpMethod(httpMethod string, accessCo
 	resp, err := checkResp(client.Http.Do(req))
 
 	if err != nil {
-		return fmt.Errorf("[client.SetAccessControl] error checking response to HREF %s: %s", href, err)
+		return fmt.Errorf("[client.SetAccessControl] error checking response to HREF %s: %w", href, err)
 	}
 	if resp == nil {
 		return fmt.Errorf("[client.SetAccessControl] nil response received")
@@ -241,7 +241,7 @@ func (adminCatalog AdminCatalog) IsShared(useTenantContext bool) (bool, error) {
 func (vdc *Vdc) GetVappAccessControl(vappIdentifier string, useTenantContext bool) (*types.ControlAccessParams, error) {
 	vapp, err := vdc.GetVAppByNameOrId(vappIdentifier, true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving vApp %s: %s", vappIdentifier, err)
+		return nil, fmt.Errorf("error retrieving vApp %s: %w", vappIdentifier, err)
 	}
 	return vapp.GetAccessControl(useTenantContext)
 }
@@ -252,7 +252,7 @@ func (vdc *Vdc) GetVappAccessControl(vappIdentifier string, useTenantContext boo
 func (org *AdminOrg) GetCatalogAccessControl(catalogIdentifier string, useTenantContext bool) (*types.ControlAccessParams, error) {
 	catalog, err := org.GetAdminCatalogByNameOrId(catalogIdentifier, true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving catalog %s: %s", catalogIdentifier, err)
+		return nil, fmt.Errorf("error retrieving catalog %s: %w", catalogIdentifier, err)
 	}
 	return catalog.GetAccessControl(useTenantContext)
 }
@@ -263,7 +263,7 @@ func (org *AdminOrg) GetCatalogAccessControl(catalogIdentifier string, useTenant
 func (org *Org) GetCatalogAccessControl(catalogIdentifier string, useTenantContext bool) (*types.ControlAccessParams, error) {
 	catalog, err := org.GetCatalogByNameOrId(catalogIdentifier, true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving catalog %s: %s", catalogIdentifier, err)
+		return nil, fmt.Errorf("error retrieving catalog %s: %w", catalogIdentifier, err)
 	}
 	return catalog.GetAccessControl(useTenantContext)
 }
@@ -373,7 +373,7 @@ func (vdc *Vdc) GetControlAccess(useTenantContext bool) (*types.ControlAccessPar
 	if useTenantContext {
 		tenantContext, err := vdc.getTenantContext()
 		if err != nil {
-			return nil, fmt.Errorf("error getting the tenant context - %s", err)
+			return nil, fmt.Errorf("error getting the tenant context - %w", err)
 		}
 
 		tenantContextHeaders = getTenantContextHeader(tenantContext)
@@ -381,7 +381,7 @@ func (vdc *Vdc) GetControlAccess(useTenantContext bool) (*types.ControlAccessPar
 
 	controlAccessParams, err := vdc.client.GetAccessControl(vdc.Vdc.HREF, "vdc", vdc.Vdc.Name, tenantContextHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("there was an error when retrieving VDC control access params - %s", err)
+		return nil, fmt.Errorf("there was an error when retrieving VDC control access params - %w", err)
 	}
 
 	return controlAccessParams, nil
@@ -426,7 +426,7 @@ func (vdc *Vdc) SetControlAccess(isSharedToEveryOne bool, everyoneAccessLevel st
 	if useTenantContext {
 		tenantContext, err := vdc.getTenantContext()
 		if err != nil {
-			return nil, fmt.Errorf("error getting the tenant context - %s", err)
+			return nil, fmt.Errorf("error getting the tenant context - %w", err)
 		}
 
 		tenantContextHeaders = getTenantContextHeader(tenantContext)
@@ -434,7 +434,7 @@ func (vdc *Vdc) SetControlAccess(isSharedToEveryOne bool, everyoneAccessLevel st
 
 	err = vdc.client.setAccessControlWithHttpMethod(http.MethodPut, accessControl, vdc.Vdc.HREF, "vdc", vdc.Vdc.Name, tenantContextHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("there was an error when setting VDC control access params - %s", err)
+		return nil, fmt.Errorf("there was an error when setting VDC control access params - %w", err)
 	}
 
 	return vdc.GetControlAccess(useTenantContext)
@@ -508,7 +508,7 @@ func (cat *Catalog) publish(isPublished bool) error {
 
 	tenantContext, err := cat.getTenantContext()
 	if err != nil {
-		return fmt.Errorf("cannot publish catalog, tenant context error: %s", err)
+		return fmt.Errorf("cannot publish catalog, tenant context error: %w", err)
 	}
 
 	publishParameters := types.PublishCatalogParams{
@@ -536,7 +536,7 @@ func (cat *AdminCatalog) publish(isPublished bool) error {
 
 	tenantContext, err := cat.getTenantContext()
 	if err != nil {
-		return fmt.Errorf("cannot publish catalog, tenant context error: %s", err)
+		return fmt.Errorf("cannot publish catalog, tenant context error: %w", err)
 	}
 
 	publishParameters := types.PublishCatalogParams{
@@ -565,7 +565,7 @@ func (cat *Catalog) SetReadOnlyAccessControl(isPublished bool) error {
 		EveryoneAccessLevel: addrOf(types.ControlAccessReadOnly),
 	}, true)
 	if err != nil {
-		return fmt.Errorf("error resetting access control record for catalog %s: %s", cat.Catalog.Name, err)
+		return fmt.Errorf("error resetting access control record for catalog %s: %w", cat.Catalog.Name, err)
 	}
 	return cat.publish(isPublished)
 }
@@ -119,7 +119,7 @@ func (cat *AdminCatalog) PublishToExternalOrganizations(publishExternalCatalog t
 
 	tenantContext, err := cat.getTenantContext()
 	if err != nil {
-		return fmt.Errorf("cannot publish to external organization, tenant context error: %s", err)
+		return fmt.Errorf("cannot publish to external organization, tenant context error: %w", err)
 	}
 
 	err = publishToExternalOrganizations(cat.client, url, tenantContext, publishExternalCatalog)
@@ -185,7 +185,7 @@ func (org *AdminOrg) CreateCatalogFromSubscriptionAsync(subscription types.Exter
 	// Before returning, check that there are no failing tasks
 	err = adminCatalog.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing subscribed catalog %s: %s", catalogName, err)
+		return nil, fmt.Errorf("error refreshing subscribed catalog %s: %w", catalogName, err)
 	}
 	if adminCatalog.AdminCatalog.Tasks != nil {
 		msg := ""
@@ -397,7 +397,7 @@ func launchSynchronisationVappTemplates(cat *AdminCatalog, nameList []string, ch
 		} else {
 			queryResultCatalogItem, err = cat.QueryCatalogItem(element)
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving catalog item %s: %s", element, err)
+				return nil, fmt.Errorf("error retrieving catalog item %s: %w", element, err)
 			}
 		}
 		task, err := queryResultCatalogItemToCatalogItem(cat.client, queryResultCatalogItem).LaunchSync()
@@ -631,7 +631,7 @@ func (client *Client) GetAdminCatalogByHref(catalogHref string) (*AdminCatalog,
 			_, err = client.ExecuteRequest(link.HREF, http.MethodGet,
 				"", "error retrieving parent Org: %s", nil, org.AdminOrg)
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving catalog parent: %s", err)
+				return nil, fmt.Errorf("error retrieving catalog parent: %w", err)
 			}
 			break
 		}
@@ -86,7 +86,7 @@ func (adminOrg *AdminOrg) GetAllVDCs(refresh bool) ([]*Vdc, error) {
 	for vdcIndex, vdc := range adminOrg.AdminOrg.Vdcs.Vdcs {
 		vdc, err := adminOrg.GetVDCByHref(vdc.HREF)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving VDC '%s': %s", vdc.Vdc.Name, err)
+			return nil, fmt.Errorf("error retrieving VDC '%s': %w", vdc.Vdc.Name, err)
 		}
 		allVdcs[vdcIndex] = vdc
 
@@ -107,7 +107,7 @@ func (adminOrg *AdminOrg) GetAllStorageProfileReferences(refresh bool) ([]*types
 
 	allVdcs, err := adminOrg.GetAllVDCs(refresh)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve storage profile references: %s", err)
+		return nil, fmt.Errorf("could not retrieve storage profile references: %w", err)
 	}
 
 	allStorageProfileReferences := make([]*types.Reference, 0)
@@ -124,7 +124,7 @@ func (adminOrg *AdminOrg) GetAllStorageProfileReferences(refresh bool) ([]*types
 func (adminOrg *AdminOrg) GetStorageProfileReferenceById(id string, refresh bool) (*types.Reference, error) {
 	allStorageProfiles, err := adminOrg.GetAllStorageProfileReferences(refresh)
 	if err != nil {
-		return nil, fmt.Errorf("error getting all storage profiles: %s", err)
+		return nil, fmt.Errorf("error getting all storage profiles: %w", err)
 	}
 
 	for _, storageProfileReference := range allStorageProfiles {
@@ -133,7 +133,7 @@ func (adminOrg *AdminOrg) GetStorageProfileReferenceById(id string, refresh bool
 		}
 	}
 
-	return nil, fmt.Errorf("%s: storage profile with ID '%s' not found in Org '%s'",
+	return nil, fmt.Errorf("%w: storage profile with ID '%s' not found in Org '%s'",
 		ErrorEntityNotFound, id, adminOrg.AdminOrg.Name)
 }
 
@@ -144,38 +144,38 @@ func (adminOrg *AdminOrg) Delete(force bool, recursive bool) error {
 		//undeploys vapps
 		err := adminOrg.undeployAllVApps()
 		if err != nil {
-			return fmt.Errorf("error could not undeploy: %s", err)
+			return fmt.Errorf("error could not undeploy: %w", err)
 		}
 		//removes vapps
 		err = adminOrg.removeAllVApps()
 		if err != nil {
-			return fmt.Errorf("error could not remove vapp: %s", err)
+			return fmt.Errorf("error could not remove vapp: %w", err)
 		}
 		//removes catalogs
 		err = adminOrg.removeCatalogs()
 		if err != nil {
-			return fmt.Errorf("error could not remove all catalogs: %s", err)
+			return fmt.Errorf("error could not remove all catalogs: %w", err)
 		}
 		//removes networks
 		err = adminOrg.removeAllOrgNetworks()
 		if err != nil {
-			return fmt.Errorf("error could not remove all networks: %s", err)
+			return fmt.Errorf("error could not remove all networks: %w", err)
 		}
 		//removes org vdcs
 		err = adminOrg.removeAllOrgVDCs()
 		if err != nil {
-			return fmt.Errorf("error could not remove all vdcs: %s", err)
+			return fmt.Errorf("error could not remove all vdcs: %w", err)
 		}
 	}
 	// Disable org
 	err := adminOrg.Disable()
 	if err != nil {
-		return fmt.Errorf("error disabling Org %s: %s", adminOrg.AdminOrg.Name, err)
+		return fmt.Errorf("error disabling Org %s: %w", adminOrg.AdminOrg.Name, err)
 	}
 	// Get admin HREF
 	orgHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting AdminOrg HREF %s : %s", adminOrg.AdminOrg.HREF, err)
+		return fmt.Errorf("error getting AdminOrg HREF %s : %w", adminOrg.AdminOrg.HREF, err)
 	}
 	req := adminOrg.client.NewRequest(map[string]string{
 		"force":     strconv.FormatBool(force),
@@ -183,12 +183,12 @@ func (adminOrg *AdminOrg) Delete(force bool, recursive bool) error {
 	}, http.MethodDelete, *orgHREF, nil)
 	resp, err := checkResp(adminOrg.client.Http.Do(req))
 	if err != nil {
-		return fmt.Errorf("error deleting Org %s: %s", adminOrg.AdminOrg.ID, err)
+		return fmt.Errorf("error deleting Org %s: %w", adminOrg.AdminOrg.ID, err)
 	}
 
 	task := NewTask(adminOrg.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return fmt.Errorf("error decoding task response: %s", err)
+		return fmt.Errorf("error decoding task response: %w", err)
 	}
 	return task.WaitTaskCompletion()
 }
@@ -198,7 +198,7 @@ func (adminOrg *AdminOrg) Delete(force bool, recursive bool) error {
 func (adminOrg *AdminOrg) Disable() error {
 	orgHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting AdminOrg HREF %s : %s", adminOrg.AdminOrg.HREF, err)
+		return fmt.Errorf("error getting AdminOrg HREF %s : %w", adminOrg.AdminOrg.HREF, err)
 	}
 	orgHREF.Path += "/action/disable"
 
@@ -239,11 +239,11 @@ func (adminOrg *AdminOrg) undeployAllVApps() error {
 		}
 		vdc, err := adminOrg.getVdcByAdminHREF(adminVdcHREF)
 		if err != nil {
-			return fmt.Errorf("error retrieving vapp with url: %s and with error %s", adminVdcHREF.Path, err)
+			return fmt.Errorf("error retrieving vapp with url: %s and with error %w", adminVdcHREF.Path, err)
 		}
 		err = vdc.undeployAllVdcVApps()
 		if err != nil {
-			return fmt.Errorf("error deleting vapp: %s", err)
+			return fmt.Errorf("error deleting vapp: %w", err)
 		}
 	}
 	return nil
@@ -258,11 +258,11 @@ func (adminOrg *AdminOrg) removeAllVApps() error {
 		}
 		vdc, err := adminOrg.getVdcByAdminHREF(adminVdcHREF)
 		if err != nil {
-			return fmt.Errorf("error retrieving vapp with url: %s and with error %s", adminVdcHREF.Path, err)
+			return fmt.Errorf("error retrieving vapp with url: %s and with error %w", adminVdcHREF.Path, err)
 		}
 		err = vdc.removeAllVdcVApps()
 		if err != nil {
-			return fmt.Errorf("error deleting vapp: %s", err)
+			return fmt.Errorf("error deleting vapp: %w", err)
 		}
 	}
 	return nil
@@ -323,7 +323,7 @@ func (adminOrg *AdminOrg) removeAllOrgVDCs() error {
 		req := adminOrg.client.NewRequest(map[string]string{}, http.MethodPost, adminVdcUrl, nil)
 		_, err := checkResp(adminOrg.client.Http.Do(req))
 		if err != nil {
-			return fmt.Errorf("error disabling vdc: %s", err)
+			return fmt.Errorf("error disabling vdc: %w", err)
 		}
 		// Get admin vdc HREF for normal deletion
 		adminVdcUrl.Path = strings.Split(adminVdcUrl.Path, "/action/disable")[0]
@@ -333,18 +333,18 @@ func (adminOrg *AdminOrg) removeAllOrgVDCs() error {
 		}, http.MethodDelete, adminVdcUrl, nil)
 		resp, err := checkResp(adminOrg.client.Http.Do(req))
 		if err != nil {
-			return fmt.Errorf("error deleting vdc: %s", err)
+			return fmt.Errorf("error deleting vdc: %w", err)
 		}
 		task := NewTask(adminOrg.client)
 		if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-			return fmt.Errorf("error decoding task response: %s", err)
+			return fmt.Errorf("error decoding task response: %w", err)
 		}
 		if task.Task.Status == "error" {
 			return fmt.Errorf("vdc not properly destroyed")
 		}
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return fmt.Errorf("couldn't finish removing vdc %s", err)
+			return fmt.Errorf("couldn't finish removing vdc %w", err)
 		}
 
 	}
@@ -370,7 +370,7 @@ func (adminOrg *AdminOrg) removeAllOrgNetworks() error {
 		}
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return fmt.Errorf("couldn't finish removing network %s", err)
+			return fmt.Errorf("couldn't finish removing network %w", err)
 		}
 	}
 	return nil
@@ -381,7 +381,7 @@ func (adminOrg *AdminOrg) removeCatalogs() error {
 	for _, catalog := range adminOrg.AdminOrg.Catalogs.Catalog {
 		isCatalogFromSameOrg, err := isCatalogFromSameOrg(adminOrg, catalog.Name)
 		if err != nil {
-			return fmt.Errorf("error deleting catalog: %s", err)
+			return fmt.Errorf("error deleting catalog: %w", err)
 		}
 		if isCatalogFromSameOrg {
 			// Get Catalog HREF
@@ -393,7 +393,7 @@ func (adminOrg *AdminOrg) removeCatalogs() error {
 			}, http.MethodDelete, catalogHREF, nil)
 			_, err := checkResp(adminOrg.client.Http.Do(req))
 			if err != nil {
-				return fmt.Errorf("error deleting catalog: %s, %s", err, catalogHREF.Path)
+				return fmt.Errorf("error deleting catalog: %w, %s", err, catalogHREF.Path)
 			}
 		}
 	}
@@ -25,12 +25,12 @@ func (adminOrg *AdminOrg) LdapConfigure(settings *types.OrgLdapSettingsType) (*t
 	_, err := adminOrg.client.ExecuteRequest(href, http.MethodPut, types.MimeOrgLdapSettings,
 		"error updating LDAP settings: %s", settings, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating LDAP mode for Org name '%s': %s", adminOrg.AdminOrg.Name, err)
+		return nil, fmt.Errorf("error updating LDAP mode for Org name '%s': %w", adminOrg.AdminOrg.Name, err)
 	}
 
 	ldapSettings, err := adminOrg.GetLdapConfiguration()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving LDAP configuration:  %s", err)
+		return nil, fmt.Errorf("error retrieving LDAP configuration:  %w", err)
 	}
 
 	return ldapSettings, nil
@@ -159,7 +159,7 @@ func (adminOrg *AdminOrg) CreateVdc(vdcConfiguration *types.VdcConfiguration) (T
 
 	vdcCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing admin org url: %s", err)
+		return Task{}, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	vdcCreateHREF.Path += "/vdcsparams"
 
@@ -189,7 +189,7 @@ func (adminOrg *AdminOrg) CreateVdcWait(vdcDefinition *types.VdcConfiguration) e
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("couldn't finish creating VDC %s", err)
+		return fmt.Errorf("couldn't finish creating VDC %w", err)
 	}
 	return nil
 }
@@ -341,7 +341,7 @@ func createVdcV97(adminOrg *AdminOrg, vdcConfiguration *types.VdcConfiguration)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("couldn't finish creating VDC %s", err)
+		return nil, fmt.Errorf("couldn't finish creating VDC %w", err)
 	}
 
 	vdc, err := adminOrg.GetVDCByName(vdcConfiguration.Name, true)
@@ -364,7 +364,7 @@ func createVdcAsyncV97(adminOrg *AdminOrg, vdcConfiguration *types.VdcConfigurat
 
 	vdcCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing admin org url: %s", err)
+		return Task{}, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	vdcCreateHREF.Path += "/vdcsparams"
 
@@ -429,7 +429,7 @@ func (vdc *AdminVdc) UpdateStorageProfile(storageProfileId string, storageProfil
 	_, err := vdc.client.ExecuteRequest(queryUrl.String(), http.MethodPut,
 		types.MimeStorageProfile, "error updating VDC storage profile: %s", storageProfile, updateAdminVdcStorageProfile)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update VDC storage profile, error: %s", err)
+		return nil, fmt.Errorf("cannot update VDC storage profile, error: %w", err)
 	}
 
 	return updateAdminVdcStorageProfile, err
@@ -454,7 +454,7 @@ func (vdc *AdminVdc) AddStorageProfile(storageProfile *types.VdcStorageProfileCo
 	task, err := vdc.client.ExecuteTaskRequest(href, http.MethodPost,
 		types.MimeUpdateVdcStorageProfiles, "error adding VDC storage profile: %s", &updateStorageProfile)
 	if err != nil {
-		return Task{}, fmt.Errorf("cannot add VDC storage profile, error: %s", err)
+		return Task{}, fmt.Errorf("cannot add VDC storage profile, error: %w", err)
 	}
 
 	return task, nil
@@ -491,7 +491,7 @@ func (vdc *AdminVdc) RemoveStorageProfile(storageProfileName string) (Task, erro
 
 	vdcStorageProfileDetails, err := vdc.client.GetStorageProfileByHref(storageProfile.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %s", storageProfileName, err)
+		return Task{}, fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %w", storageProfileName, err)
 	}
 	if vdcStorageProfileDetails.Enabled != nil && *vdcStorageProfileDetails.Enabled {
 		_, err = vdc.UpdateStorageProfile(extractUuid(storageProfile.HREF), &types.AdminVdcStorageProfile{
@@ -506,7 +506,7 @@ func (vdc *AdminVdc) RemoveStorageProfile(storageProfileName string) (Task, erro
 		},
 		)
 		if err != nil {
-			return Task{}, fmt.Errorf("cannot disable VDC storage profile '%s': %s", storageProfileName, err)
+			return Task{}, fmt.Errorf("cannot disable VDC storage profile '%s': %w", storageProfileName, err)
 		}
 	}
 
@@ -522,7 +522,7 @@ func (vdc *AdminVdc) RemoveStorageProfile(storageProfileName string) (Task, erro
 	task, err := vdc.client.ExecuteTaskRequest(href, http.MethodPost,
 		types.MimeUpdateVdcStorageProfiles, "error removing VDC storage profile: %s", &updateStorageProfile)
 	if err != nil {
-		return Task{}, fmt.Errorf("cannot remove VDC storage profile, error: %s", err)
+		return Task{}, fmt.Errorf("cannot remove VDC storage profile, error: %w", err)
 	}
 
 	return task, nil
@@ -560,7 +560,7 @@ func (vdc *AdminVdc) SetDefaultStorageProfile(storageProfileName string) error {
 
 	vdcStorageProfileDetails, err := vdc.client.GetStorageProfileByHref(storageProfile.HREF)
 	if err != nil {
-		return fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %s", storageProfileName, err)
+		return fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %w", storageProfileName, err)
 	}
 	_, err = vdc.UpdateStorageProfile(extractUuid(storageProfile.HREF), &types.AdminVdcStorageProfile{
 		Name:    vdcStorageProfileDetails.Name,
@@ -574,7 +574,7 @@ func (vdc *AdminVdc) SetDefaultStorageProfile(storageProfileName string) error {
 	},
 	)
 	if err != nil {
-		return fmt.Errorf("cannot set VDC default storage profile '%s': %s", storageProfileName, err)
+		return fmt.Errorf("cannot set VDC default storage profile '%s': %w", storageProfileName, err)
 	}
 	return vdc.Refresh()
 }
@@ -588,7 +588,7 @@ func (adminVdc *AdminVdc) GetDefaultStorageProfileReference() (*types.Reference,
 	for _, sp := range adminVdc.AdminVdc.VdcStorageProfiles.VdcStorageProfile {
 		fullSp, err := adminVdc.client.GetStorageProfileByHref(sp.HREF)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving storage profile %s for VDC %s: %s", sp.Name, adminVdc.AdminVdc.Name, err)
+			return nil, fmt.Errorf("error retrieving storage profile %s for VDC %s: %w", sp.Name, adminVdc.AdminVdc.Name, err)
 		}
 		if fullSp.Default {
 			if defaultSp != nil {
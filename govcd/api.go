@@ -7,11 +7,13 @@ package govcd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -69,6 +71,22 @@ type Client struct {
 
 	supportedVersions SupportedVersions // Versions from /api/versions endpoint
 	customHeader      http.Header
+
+	// retryPolicy is set by WithRetryPolicy
+	retryPolicy *RetryPolicy
+	// rateLimiter, taskPollingRateLimiter and concurrencyLimiter are set by WithRateLimit,
+	// WithTaskPollingRateLimit and WithMaxConcurrentRequests
+	rateLimiter            *tokenBucket
+	taskPollingRateLimiter *tokenBucket
+	concurrencyLimiter     chan struct{}
+	// logger is set by WithLogger
+	logger *slog.Logger
+	// instrumentation is set by WithInstrumentation
+	instrumentation Instrumentation
+	// reauth is set by WithAutoReauthenticate
+	reauth *reauthenticator
+	// cassette is set by WithCassette
+	cassette *cassetteTransport
 }
 
 func (client *Client) rootVcdHref() string {
@@ -174,20 +192,20 @@ func debugShowResponse(resp *http.Response, body []byte) {
 }
 
 // IsNotFound is a convenience function, similar to os.IsNotExist that checks whether a given error
-// is a "Not found" error, such as
+// is or wraps a "Not found" error (including API responses with HTTP status 404), such as
 //
 //	if isNotFound(err) {
 //	   // do what is needed in case of not found
 //	}
 func IsNotFound(err error) bool {
-	return err != nil && err == ErrorEntityNotFound
+	return errors.Is(err, ErrorEntityNotFound)
 }
 
 // ContainsNotFound is a convenience function, similar to os.IsNotExist that checks whether a given error
-// contains a "Not found" error. It is almost the same as `IsNotFound` but checks if an error contains substring
-// ErrorEntityNotFound
+// contains a "Not found" error. It is almost the same as `IsNotFound` but also checks if an error contains substring
+// ErrorEntityNotFound for errors that were formatted without wrapping
 func ContainsNotFound(err error) bool {
-	return err != nil && strings.Contains(err.Error(), ErrorEntityNotFound.Error())
+	return errors.Is(err, ErrorEntityNotFound) || (err != nil && strings.Contains(err.Error(), ErrorEntityNotFound.Error()))
 }
 
 // NewRequestWitNotEncodedParams allows passing complex values params that shouldn't be encoded like for queries. e.g. /query?filter=name=foo
@@ -209,6 +227,12 @@ func (client *Client) NewRequestWitNotEncodedParamsWithApiVersion(params map[str
 // newRequest is the parent of many "specific" "NewRequest" functions.
 // Note. It is kept private to avoid breaking public API on every new field addition.
 func (client *Client) newRequest(params map[string]string, notEncodedParams map[string]string, method string, reqUrl url.URL, body io.Reader, apiVersion string, additionalHeader http.Header) *http.Request {
+	return client.newRequestCtx(context.Background(), params, notEncodedParams, method, reqUrl, body, apiVersion, additionalHeader)
+}
+
+// newRequestCtx is the context aware version of newRequest. The returned request is bound to
+// given context so that it can be cancelled or given a deadline by the caller.
+func (client *Client) newRequestCtx(ctx context.Context, params map[string]string, notEncodedParams map[string]string, method string, reqUrl url.URL, body io.Reader, apiVersion string, additionalHeader http.Header) *http.Request {
 	reqValues := url.Values{}
 
 	// Build up our request parameters
@@ -237,25 +261,26 @@ func (client *Client) newRequest(params map[string]string, notEncodedParams map[
 		body = bytes.NewReader(readBody)
 	}
 
-	req, err := http.NewRequest(method, reqUrl.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl.String(), body)
 	if err != nil {
 		util.Logger.Printf("[DEBUG - newRequest] error getting new request: %s", err)
 	}
 
-	if client.VCDAuthHeader != "" && client.VCDToken != "" {
+	authHeader, token := client.authorization()
+	if authHeader != "" && token != "" {
 		// Add the authorization header
-		req.Header.Add(client.VCDAuthHeader, client.VCDToken)
+		req.Header.Add(authHeader, token)
 	}
-	if (client.VCDAuthHeader != "" && client.VCDToken != "") ||
+	if (authHeader != "" && token != "") ||
 		(additionalHeader != nil && additionalHeader.Get("Authorization") != "") {
 		// Add the Accept header for VCD
 		req.Header.Add("Accept", "application/*+xml;version="+apiVersion)
 	}
 	// The deprecated authorization token is 32 characters long
 	// The bearer token is 612 characters long
-	if len(client.VCDToken) > 32 {
+	if len(token) > 32 {
 		req.Header.Add("X-Vmware-Vcloud-Token-Type", "Bearer")
-		req.Header.Add("Authorization", "bearer "+client.VCDToken)
+		req.Header.Add("Authorization", "bearer "+token)
 	}
 
 	// Merge in additional headers before logging if anywhere specified in additionalHeader
@@ -303,21 +328,34 @@ func (client *Client) NewRequestWithApiVersion(params map[string]string, method
 	return client.NewRequestWitNotEncodedParamsWithApiVersion(params, nil, method, reqUrl, body, apiVersion)
 }
 
+// NewRequestCtx creates a new HTTP request bound to the given context and applies necessary auth
+// headers if set.
+func (client *Client) NewRequestCtx(ctx context.Context, params map[string]string, method string, reqUrl url.URL, body io.Reader) *http.Request {
+	return client.newRequestCtx(ctx, params, nil, method, reqUrl, body, client.APIVersion, nil)
+}
+
+// NewRequestWithApiVersionCtx creates a new HTTP request bound to the given context and applies
+// necessary auth headers if set. Allows to override default request API Version
+func (client *Client) NewRequestWithApiVersionCtx(ctx context.Context, params map[string]string, method string, reqUrl url.URL, body io.Reader, apiVersion string) *http.Request {
+	return client.newRequestCtx(ctx, params, nil, method, reqUrl, body, apiVersion, nil)
+}
+
 // ParseErr takes an error XML resp, error interface for unmarshalling and returns a single string for
-// use in error messages.
+// use in error messages. The returned error is an *ApiError wrapping parsed `errType`.
 func ParseErr(bodyType types.BodyType, resp *http.Response, errType error) error {
 	// if there was an error decoding the body, just return that
 	if err := decodeBody(bodyType, resp, errType); err != nil {
 		util.Logger.Printf("[ParseErr]: unhandled response <--\n%+v\n-->\n", resp)
-		return fmt.Errorf("[ParseErr]: error parsing error body for non-200 request: %s (%+v)", err, resp)
+		return newApiError(resp, fmt.Errorf("[ParseErr]: error parsing error body for non-200 request: %w (%+v)", err, resp))
 	}
 
+	apiError := newApiError(resp, errType)
 	// response body maybe empty for some error, such like 416, 400
 	if errType.Error() == "API Error: 0: " {
-		errType = fmt.Errorf(resp.Status)
+		apiError.Err = errors.New(resp.Status)
 	}
 
-	return errType
+	return apiError
 }
 
 // decodeBody is used to decode a response body of types.BodyType
@@ -364,7 +402,7 @@ func indentJsonBody(body []byte) ([]byte, error) {
 	var prettyJSON bytes.Buffer
 	err := json.Indent(&prettyJSON, body, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("error indenting response JSON: %s", err)
+		return nil, fmt.Errorf("error indenting response JSON: %w", err)
 	}
 	body = prettyJSON.Bytes()
 	return body, nil
@@ -429,7 +467,7 @@ func checkRespWithErrType(bodyType types.BodyType, resp *http.Response, err, err
 		return nil, ParseErr(bodyType, resp, errType)
 	// Unhandled response.
 	default:
-		return nil, fmt.Errorf("unhandled API response, please report this issue, status code: %s", resp.Status)
+		return nil, newApiError(resp, fmt.Errorf("unhandled API response, please report this issue, status code: %s", resp.Status))
 	}
 }
 
@@ -441,7 +479,13 @@ func checkRespWithErrType(bodyType types.BodyType, resp *http.Response, err, err
 // payload - XML struct which will be marshalled and added as body/payload
 // E.g. client.ExecuteTaskRequest(updateDiskLink.HREF, http.MethodPut, updateDiskLink.Type, "error updating disk: %s", xmlPayload)
 func (client *Client) ExecuteTaskRequest(pathURL, requestType, contentType, errorMessage string, payload interface{}) (Task, error) {
-	return client.executeTaskRequest(pathURL, requestType, contentType, errorMessage, payload, client.APIVersion)
+	return client.executeTaskRequest(context.Background(), pathURL, requestType, contentType, errorMessage, payload, client.APIVersion)
+}
+
+// ExecuteTaskRequestCtx is the context aware version of ExecuteTaskRequest. The request is
+// cancelled when the given context is done.
+func (client *Client) ExecuteTaskRequestCtx(ctx context.Context, pathURL, requestType, contentType, errorMessage string, payload interface{}) (Task, error) {
+	return client.executeTaskRequest(ctx, pathURL, requestType, contentType, errorMessage, payload, client.APIVersion)
 }
 
 // ExecuteTaskRequestWithApiVersion helper function creates request, runs it, checks response and parses task from response.
@@ -453,7 +497,7 @@ func (client *Client) ExecuteTaskRequest(pathURL, requestType, contentType, erro
 // apiVersion - api version which will be used in request
 // E.g. client.ExecuteTaskRequest(updateDiskLink.HREF, http.MethodPut, updateDiskLink.Type, "error updating disk: %s", xmlPayload)
 func (client *Client) ExecuteTaskRequestWithApiVersion(pathURL, requestType, contentType, errorMessage string, payload interface{}, apiVersion string) (Task, error) {
-	return client.executeTaskRequest(pathURL, requestType, contentType, errorMessage, payload, apiVersion)
+	return client.executeTaskRequest(context.Background(), pathURL, requestType, contentType, errorMessage, payload, apiVersion)
 }
 
 // Helper function creates request, runs it, checks response and parses task from response.
@@ -464,26 +508,26 @@ func (client *Client) ExecuteTaskRequestWithApiVersion(pathURL, requestType, con
 // payload - XML struct which will be marshalled and added as body/payload
 // apiVersion - api version which will be used in request
 // E.g. client.ExecuteTaskRequest(updateDiskLink.HREF, http.MethodPut, updateDiskLink.Type, "error updating disk: %s", xmlPayload)
-func (client *Client) executeTaskRequest(pathURL, requestType, contentType, errorMessage string, payload interface{}, apiVersion string) (Task, error) {
+func (client *Client) executeTaskRequest(ctx context.Context, pathURL, requestType, contentType, errorMessage string, payload interface{}, apiVersion string) (Task, error) {
 
 	if !isMessageWithPlaceHolder(errorMessage) {
 		return Task{}, fmt.Errorf("error message has to include place holder for error")
 	}
 
-	resp, err := executeRequestWithApiVersion(pathURL, requestType, contentType, payload, client, apiVersion)
+	resp, err := executeRequestWithApiVersion(ctx, pathURL, requestType, contentType, payload, client, apiVersion)
 	if err != nil {
-		return Task{}, fmt.Errorf(errorMessage, err)
+		return Task{}, wrapErrorMessage(errorMessage, err)
 	}
 
 	task := NewTask(client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return Task{}, fmt.Errorf(errorMessage, err)
+		return Task{}, wrapErrorMessage(errorMessage, err)
 	}
 
 	// The request was successful
@@ -498,7 +542,13 @@ func (client *Client) executeTaskRequest(pathURL, requestType, contentType, erro
 // payload - XML struct which will be marshalled and added as body/payload
 // E.g. client.ExecuteRequestWithoutResponse(catalogItemHREF.String(), http.MethodDelete, "", "error deleting Catalog item: %s", nil)
 func (client *Client) ExecuteRequestWithoutResponse(pathURL, requestType, contentType, errorMessage string, payload interface{}) error {
-	return client.executeRequestWithoutResponse(pathURL, requestType, contentType, errorMessage, payload, client.APIVersion)
+	return client.executeRequestWithoutResponse(context.Background(), pathURL, requestType, contentType, errorMessage, payload, client.APIVersion)
+}
+
+// ExecuteRequestWithoutResponseCtx is the context aware version of ExecuteRequestWithoutResponse.
+// The request is cancelled when the given context is done.
+func (client *Client) ExecuteRequestWithoutResponseCtx(ctx context.Context, pathURL, requestType, contentType, errorMessage string, payload interface{}) error {
+	return client.executeRequestWithoutResponse(ctx, pathURL, requestType, contentType, errorMessage, payload, client.APIVersion)
 }
 
 // ExecuteRequestWithoutResponseWithApiVersion helper function creates request, runs it, checks response and do not expect any values from it.
@@ -510,7 +560,7 @@ func (client *Client) ExecuteRequestWithoutResponse(pathURL, requestType, conten
 // apiVersion - api version which will be used in request
 // E.g. client.ExecuteRequestWithoutResponse(catalogItemHREF.String(), http.MethodDelete, "", "error deleting Catalog item: %s", nil)
 func (client *Client) ExecuteRequestWithoutResponseWithApiVersion(pathURL, requestType, contentType, errorMessage string, payload interface{}, apiVersion string) error {
-	return client.executeRequestWithoutResponse(pathURL, requestType, contentType, errorMessage, payload, apiVersion)
+	return client.executeRequestWithoutResponse(context.Background(), pathURL, requestType, contentType, errorMessage, payload, apiVersion)
 }
 
 // Helper function creates request, runs it, checks response and do not expect any values from it.
@@ -521,15 +571,15 @@ func (client *Client) ExecuteRequestWithoutResponseWithApiVersion(pathURL, reque
 // payload - XML struct which will be marshalled and added as body/payload
 // apiVersion - api version which will be used in request
 // E.g. client.ExecuteRequestWithoutResponse(catalogItemHREF.String(), http.MethodDelete, "", "error deleting Catalog item: %s", nil)
-func (client *Client) executeRequestWithoutResponse(pathURL, requestType, contentType, errorMessage string, payload interface{}, apiVersion string) error {
+func (client *Client) executeRequestWithoutResponse(ctx context.Context, pathURL, requestType, contentType, errorMessage string, payload interface{}, apiVersion string) error {
 
 	if !isMessageWithPlaceHolder(errorMessage) {
 		return fmt.Errorf("error message has to include place holder for error")
 	}
 
-	resp, err := executeRequestWithApiVersion(pathURL, requestType, contentType, payload, client, apiVersion)
+	resp, err := executeRequestWithApiVersion(ctx, pathURL, requestType, contentType, payload, client, apiVersion)
 	if err != nil {
-		return fmt.Errorf(errorMessage, err)
+		return wrapErrorMessage(errorMessage, err)
 	}
 
 	// log response explicitly because decodeBody() was not triggered
@@ -538,7 +588,7 @@ func (client *Client) executeRequestWithoutResponse(pathURL, requestType, conten
 	debugShowResponse(resp, []byte("SKIPPED RESPONSE"))
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// The request was successful
@@ -555,7 +605,13 @@ func (client *Client) executeRequestWithoutResponse(pathURL, requestType, conten
 // E.g. 	unmarshalledAdminOrg := &types.AdminOrg{}
 // client.ExecuteRequest(adminOrg.AdminOrg.HREF, http.MethodGet, "", "error refreshing organization: %s", nil, unmarshalledAdminOrg)
 func (client *Client) ExecuteRequest(pathURL, requestType, contentType, errorMessage string, payload, out interface{}) (*http.Response, error) {
-	return client.executeRequest(pathURL, requestType, contentType, errorMessage, payload, out, client.APIVersion)
+	return client.executeRequest(context.Background(), pathURL, requestType, contentType, errorMessage, payload, out, client.APIVersion)
+}
+
+// ExecuteRequestCtx is the context aware version of ExecuteRequest. The request is cancelled when
+// the given context is done.
+func (client *Client) ExecuteRequestCtx(ctx context.Context, pathURL, requestType, contentType, errorMessage string, payload, out interface{}) (*http.Response, error) {
+	return client.executeRequest(ctx, pathURL, requestType, contentType, errorMessage, payload, out, client.APIVersion)
 }
 
 // ExecuteRequestWithApiVersion helper function creates request, runs it, check responses and parses out interface from response.
@@ -569,7 +625,7 @@ func (client *Client) ExecuteRequest(pathURL, requestType, contentType, errorMes
 // E.g. 	unmarshalledAdminOrg := &types.AdminOrg{}
 // client.ExecuteRequest(adminOrg.AdminOrg.HREF, http.MethodGet, "", "error refreshing organization: %s", nil, unmarshalledAdminOrg)
 func (client *Client) ExecuteRequestWithApiVersion(pathURL, requestType, contentType, errorMessage string, payload, out interface{}, apiVersion string) (*http.Response, error) {
-	return client.executeRequest(pathURL, requestType, contentType, errorMessage, payload, out, apiVersion)
+	return client.executeRequest(context.Background(), pathURL, requestType, contentType, errorMessage, payload, out, apiVersion)
 }
 
 // Helper function creates request, runs it, check responses and parses out interface from response.
@@ -582,24 +638,24 @@ func (client *Client) ExecuteRequestWithApiVersion(pathURL, requestType, content
 // apiVersion - api version which will be used in request
 // E.g. 	unmarshalledAdminOrg := &types.AdminOrg{}
 // client.ExecuteRequest(adminOrg.AdminOrg.HREF, http.MethodGet, "", "error refreshing organization: %s", nil, unmarshalledAdminOrg)
-func (client *Client) executeRequest(pathURL, requestType, contentType, errorMessage string, payload, out interface{}, apiVersion string) (*http.Response, error) {
+func (client *Client) executeRequest(ctx context.Context, pathURL, requestType, contentType, errorMessage string, payload, out interface{}, apiVersion string) (*http.Response, error) {
 
 	if !isMessageWithPlaceHolder(errorMessage) {
 		return &http.Response{}, fmt.Errorf("error message has to include place holder for error")
 	}
 
-	resp, err := executeRequestWithApiVersion(pathURL, requestType, contentType, payload, client, apiVersion)
+	resp, err := executeRequestWithApiVersion(ctx, pathURL, requestType, contentType, payload, client, apiVersion)
 	if err != nil {
-		return resp, fmt.Errorf(errorMessage, err)
+		return resp, wrapErrorMessage(errorMessage, err)
 	}
 
 	if err = decodeBody(types.BodyTypeXML, resp, out); err != nil {
-		return resp, fmt.Errorf("error decoding response: %s", err)
+		return resp, fmt.Errorf("error decoding response: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return resp, fmt.Errorf("error closing response body: %s", err)
+		return resp, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// The request was successful
@@ -623,9 +679,9 @@ func (client *Client) ExecuteParamRequestWithCustomError(pathURL string, params
 		return &http.Response{}, fmt.Errorf("error message has to include place holder for error")
 	}
 
-	resp, err := executeRequestCustomErr(pathURL, params, requestType, contentType, payload, client, errType, client.APIVersion)
+	resp, err := executeRequestCustomErr(context.Background(), pathURL, params, requestType, contentType, payload, client, errType, client.APIVersion)
 	if err != nil {
-		return &http.Response{}, fmt.Errorf(errorMessage, err)
+		return &http.Response{}, wrapErrorMessage(errorMessage, err)
 	}
 
 	// read from resp.Body io.Reader for debug output if it has body
@@ -633,7 +689,7 @@ func (client *Client) ExecuteParamRequestWithCustomError(pathURL string, params
 	if resp.Body != nil {
 		bodyBytes, err = io.ReadAll(resp.Body)
 		if err != nil {
-			return &http.Response{}, fmt.Errorf("could not read response body: %s", err)
+			return &http.Response{}, fmt.Errorf("could not read response body: %w", err)
 		}
 		// Restore the io.ReadCloser to its original state with no-op closer
 		resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
@@ -646,15 +702,15 @@ func (client *Client) ExecuteParamRequestWithCustomError(pathURL string, params
 }
 
 // executeRequest does executeRequestCustomErr and checks for vCD errors in API response
-func executeRequestWithApiVersion(pathURL, requestType, contentType string, payload interface{}, client *Client, apiVersion string) (*http.Response, error) {
-	return executeRequestCustomErr(pathURL, map[string]string{}, requestType, contentType, payload, client, &types.Error{}, apiVersion)
+func executeRequestWithApiVersion(ctx context.Context, pathURL, requestType, contentType string, payload interface{}, client *Client, apiVersion string) (*http.Response, error) {
+	return executeRequestCustomErr(ctx, pathURL, map[string]string{}, requestType, contentType, payload, client, &types.Error{}, apiVersion)
 }
 
 // executeRequestCustomErr performs request and unmarshals API error to errType if not 2xx status was returned
-func executeRequestCustomErr(pathURL string, params map[string]string, requestType, contentType string, payload interface{}, client *Client, errType error, apiVersion string) (*http.Response, error) {
+func executeRequestCustomErr(ctx context.Context, pathURL string, params map[string]string, requestType, contentType string, payload interface{}, client *Client, errType error, apiVersion string) (*http.Response, error) {
 	requestURI, err := url.ParseRequestURI(pathURL)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't parse path request URI '%s': %s", pathURL, err)
+		return nil, fmt.Errorf("couldn't parse path request URI '%s': %w", pathURL, err)
 	}
 
 	var req *http.Request
@@ -664,14 +720,14 @@ func executeRequestCustomErr(pathURL string, params map[string]string, requestTy
 	case payload != nil:
 		marshaledXml, err := xml.MarshalIndent(payload, "  ", "    ")
 		if err != nil {
-			return &http.Response{}, fmt.Errorf("error marshalling xml data %s", err)
+			return &http.Response{}, fmt.Errorf("error marshalling xml data %w", err)
 		}
 		body := bytes.NewBufferString(xml.Header + string(marshaledXml))
 
-		req = client.NewRequestWithApiVersion(params, requestType, *requestURI, body, apiVersion)
+		req = client.NewRequestWithApiVersionCtx(ctx, params, requestType, *requestURI, body, apiVersion)
 
 	default:
-		req = client.NewRequestWithApiVersion(params, requestType, *requestURI, nil, apiVersion)
+		req = client.NewRequestWithApiVersionCtx(ctx, params, requestType, *requestURI, nil, apiVersion)
 	}
 
 	if contentType != "" {
@@ -716,16 +772,17 @@ func isMessageWithPlaceHolder(message string) bool {
 	return !strings.Contains(err.Error(), "%!(EXTRA")
 }
 
-// combinedTaskErrorMessage is a general purpose function
+// combinedTaskError is a general purpose function
 // that returns the contents of the operation error and, if found, the error
-// returned by the associated task
-func combinedTaskErrorMessage(task *types.Task, err error) string {
+// returned by the associated task. The operation error is wrapped, so that errors.Is and errors.As
+// still match it
+func combinedTaskError(task *types.Task, err error) error {
 	extendedError := err.Error()
 	if task.Error != nil {
 		extendedError = fmt.Sprintf("operation error: %s - task error: [%d - %s] %s",
 			err, task.Error.MajorErrorCode, task.Error.MinorErrorCode, task.Error.Message)
 	}
-	return extendedError
+	return &messageError{message: extendedError, err: err}
 }
 
 // addrOf is a generic function to return the address of a variable
@@ -848,7 +905,7 @@ func (client *Client) TestConnection(testConnection types.TestConnection) (*type
 
 	err = client.OpenApiPostItem(apiVersion, urlRef, nil, testConnection, returnTestConnectionResult, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error performing test connection: %s", err)
+		return nil, fmt.Errorf("error performing test connection: %w", err)
 	}
 
 	return returnTestConnectionResult, nil
@@ -863,7 +920,7 @@ func (client *Client) TestConnectionWithDefaults(subscriptionURL string) (bool,
 
 	url, err := url.Parse(subscriptionURL)
 	if err != nil {
-		return false, fmt.Errorf("unable to parse URL - %s", err)
+		return false, fmt.Errorf("unable to parse URL - %w", err)
 	}
 
 	// Get port
@@ -871,7 +928,7 @@ func (client *Client) TestConnectionWithDefaults(subscriptionURL string) (bool,
 	if v := url.Port(); v != "" {
 		port, err = strconv.Atoi(v)
 		if err != nil {
-			return false, fmt.Errorf("couldn't parse port provided - %s", err)
+			return false, fmt.Errorf("couldn't parse port provided - %w", err)
 		}
 	} else {
 		switch url.Scheme {
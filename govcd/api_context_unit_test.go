@@ -0,0 +1,116 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// testContextClient returns a VCDClient pointing to the given mock server. Supported versions are
+// pre-populated so that no call to /api/versions is performed
+func testContextClient(t *testing.T, serverUrl string, options ...VCDClientOption) *VCDClient {
+	vcdUrl, err := url.Parse(serverUrl + "/api")
+	if err != nil {
+		t.Fatalf("error parsing mock server URL: %s", err)
+	}
+	vcdClient := NewVCDClient(*vcdUrl, true, options...)
+	vcdClient.Client.supportedVersions = SupportedVersions{
+		VersionInfos: VersionInfos{{Version: "37.0"}, {Version: "40.0"}},
+	}
+	return vcdClient
+}
+
+func TestClient_ExecuteRequestCtxDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Block until the client gives up
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := vcdClient.Client.ExecuteRequestCtx(ctx, server.URL+"/api/org", http.MethodGet, "",
+		"error retrieving org list: %s", nil, &types.OrgList{})
+	if err == nil {
+		t.Fatalf("expected an error after context deadline")
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline to be exceeded, got %v", ctx.Err())
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("request was not interrupted by context deadline")
+	}
+}
+
+func TestClient_OpenApiGetAllItemsCtxCancelled(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", types.JSONMime)
+		_, _ = w.Write([]byte(`{"resultTotal":0,"pageCount":0,"page":1,"pageSize":128,"values":[]}`))
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var roles []*types.Role
+	err = vcdClient.Client.OpenApiGetAllItemsCtx(ctx, "37.0", urlRef, nil, &roles, nil)
+	if err == nil {
+		t.Fatalf("expected an error for cancelled context")
+	}
+	if requestCount != 0 {
+		t.Fatalf("expected no request to reach the server, got %d", requestCount)
+	}
+
+	// The same call without cancellation must succeed
+	err = vcdClient.Client.OpenApiGetAllItemsCtx(context.Background(), "37.0", urlRef, nil, &roles, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestTask_WaitTaskCompletionCtxCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="running" name="task" ` +
+			`href="` + "http://" + r.Host + r.URL.Path + `"></Task>`))
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	task := NewTask(&vcdClient.Client)
+	task.Task.HREF = server.URL + "/api/task/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := task.WaitTaskCompletionCtx(ctx)
+	if err == nil {
+		t.Fatalf("expected an error when context is done before task completion")
+	}
+	// WaitTaskCompletion sleeps for 3 seconds between refreshes. Context cancellation must
+	// interrupt the sleep
+	if time.Since(start) >= 3*time.Second {
+		t.Fatalf("waiting was not interrupted by context deadline")
+	}
+}
@@ -6,6 +6,7 @@ package govcd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 	"github.com/vmware/go-vcloud-director/v3/util"
@@ -38,7 +39,7 @@ func (client Client) executeJsonRequest(href, httpMethod string, inputStructure
 	request := client.newRequest(nil, nil, httpMethod, *requestHref, body, apiVersion, headAccept)
 	resp, err = client.Http.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf(errorMessage, err)
+		return nil, wrapErrorMessage(errorMessage, err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -47,14 +48,15 @@ func (client Client) executeJsonRequest(href, httpMethod string, inputStructure
 		var jsonError types.OpenApiError
 		err = json.Unmarshal(body, &jsonError)
 		// By default, we return the whole response body as error message. This may also contain the stack trace
-		message := string(body)
+		apiError := newApiError(resp, errors.New(string(body)))
 		// if the body contains a valid JSON representation of the error, we return a more agile message, using the
 		// exposed fields, and hiding the stack trace from view
 		if err == nil {
-			message = fmt.Sprintf("%s - %s", jsonError.MinorErrorCode, jsonError.Message)
+			apiError.MinorErrorCode = jsonError.MinorErrorCode
+			apiError.Err = fmt.Errorf("%s - %s", jsonError.MinorErrorCode, jsonError.Message)
 		}
 		util.ProcessResponseOutput(util.CallFuncName(), resp, string(body))
-		return resp, fmt.Errorf(errorMessage, message)
+		return resp, wrapErrorMessage(errorMessage, apiError)
 	}
 
 	return checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.Error{})
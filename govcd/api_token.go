@@ -35,17 +35,17 @@ func (vcdClient *VCDClient) CreateToken(org, tokenName string) (*Token, error) {
 
 	newTokenParams, err := vcdClient.RegisterToken(org, apiTokenParams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register API token: %s", err)
+		return nil, fmt.Errorf("failed to register API token: %w", err)
 	}
 
 	tokenUrn, err := BuildUrnWithUuid("urn:vcloud:token:", newTokenParams.ClientID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build URN: %s", err)
+		return nil, fmt.Errorf("failed to build URN: %w", err)
 	}
 
 	token, err := vcdClient.GetTokenById(tokenUrn)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get token: %s", err)
+		return nil, fmt.Errorf("failed to get token: %w", err)
 	}
 
 	return token, nil
@@ -73,7 +73,7 @@ func (vcdClient *VCDClient) GetTokenById(tokenId string) (*Token, error) {
 
 	err = client.OpenApiGetItem(apiVersion, urlRef, nil, apiToken.Token, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get token: %s", err)
+		return nil, fmt.Errorf("failed to get token: %w", err)
 	}
 
 	return apiToken, nil
@@ -97,7 +97,7 @@ func (vcdClient *VCDClient) GetAllTokens(queryParameters url.Values) ([]*Token,
 	typeResponses := []*types.Token{{}}
 	err = client.OpenApiGetAllItems(apiVersion, urlRef, queryParameters, &typeResponses, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tokens: %s", err)
+		return nil, fmt.Errorf("failed to get tokens: %w", err)
 	}
 
 	results := make([]*Token, len(typeResponses))
@@ -118,7 +118,7 @@ func (vcdClient *VCDClient) GetTokenByNameAndUsername(tokenName, userName string
 
 	tokens, err := vcdClient.GetAllTokens(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get token by name and owner: %s", err)
+		return nil, fmt.Errorf("failed to get token by name and owner: %w", err)
 	}
 
 	token, err := oneOrError("name", tokenName, tokens)
@@ -153,7 +153,7 @@ func (vcdClient *VCDClient) RegisterToken(org string, tokenParams *types.ApiToke
 	// Create the URL for the register endpoint
 	urlRef, err := url.ParseRequestURI(fmt.Sprintf("%s/oauth/%s/%s", client.rootVcdHref(), userDef, "register"))
 	if err != nil {
-		return nil, fmt.Errorf("error getting request URL from %s : %s", urlRef.String(), err)
+		return nil, fmt.Errorf("error getting request URL from %s : %w", urlRef.String(), err)
 	}
 
 	newTokenParams := &types.ApiTokenParams{}
@@ -162,7 +162,7 @@ func (vcdClient *VCDClient) RegisterToken(org string, tokenParams *types.ApiToke
 	// API version
 	err = client.OpenApiPostItemSync("", urlRef, nil, tokenParams, newTokenParams)
 	if err != nil {
-		return nil, fmt.Errorf("error registering token: %s", err)
+		return nil, fmt.Errorf("error registering token: %w", err)
 	}
 
 	return newTokenParams, nil
@@ -178,7 +178,7 @@ func (client *Client) getAccessToken(org, funcName string, payloadMap map[string
 	endpoint := fmt.Sprintf("%s/oauth/%s/token", client.rootVcdHref(), userDef)
 	urlRef, err := url.ParseRequestURI(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error getting request url from %s: %s", urlRef.String(), err)
+		return nil, fmt.Errorf("error getting request url from %s: %w", urlRef.String(), err)
 	}
 
 	newToken := &types.ApiTokenRefresh{}
@@ -186,7 +186,7 @@ func (client *Client) getAccessToken(org, funcName string, payloadMap map[string
 	// Not an OpenAPI endpoint so hardcoding the API token minimal version
 	err = client.OpenApiPostUrlEncoded(client.APIVersion, urlRef, nil, payloadMap, &newToken, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error authorizing service account: %s", err)
+		return nil, fmt.Errorf("error authorizing service account: %w", err)
 	}
 
 	return newToken, nil
@@ -196,15 +196,16 @@ func (client *Client) getAccessToken(org, funcName string, payloadMap map[string
 func (token *Token) GetInitialApiToken() (*types.ApiTokenRefresh, error) {
 	client := token.client
 	uuid := extractUuid(token.Token.ID)
+	_, assertion := client.authorization()
 	data := map[string]string{
 		"grant_type": "urn:ietf:params:oauth:grant-type:jwt-bearer",
-		"assertion":  client.VCDToken,
+		"assertion":  assertion,
 		"client_id":  uuid,
 	}
 
 	refreshToken, err := client.getAccessToken(token.Token.Org.Name, "CreateApiToken", data)
 	if err != nil {
-		return nil, fmt.Errorf("error getting token: %s", err)
+		return nil, fmt.Errorf("error getting token: %w", err)
 	}
 
 	return refreshToken, nil
@@ -244,6 +245,10 @@ func (vcdClient *VCDClient) SetApiToken(org, apiToken string) (*types.ApiTokenRe
 	if err != nil {
 		return nil, err
 	}
+	vcdClient.recordAuthentication(func(authClient *VCDClient) error {
+		_, err := authClient.SetApiToken(org, apiToken)
+		return err
+	})
 	return tokenRefresh, nil
 }
 
@@ -256,7 +261,7 @@ func (vcdClient *VCDClient) GetBearerTokenFromApiToken(org, token string) (*type
 	}
 	tokenDef, err := vcdClient.Client.getAccessToken(org, "GetBearerTokenFromApiToken", data)
 	if err != nil {
-		return nil, fmt.Errorf("error getting bearer token: %s", err)
+		return nil, fmt.Errorf("error getting bearer token: %w", err)
 	}
 
 	return tokenDef, nil
@@ -271,7 +276,15 @@ func (vcdClient *VCDClient) SetApiTokenFromFile(org, apiTokenFile string) (*type
 		return nil, err
 	}
 
-	return vcdClient.SetApiToken(org, apiToken.RefreshToken)
+	tokenRefresh, err := vcdClient.SetApiToken(org, apiToken.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	vcdClient.recordAuthentication(func(authClient *VCDClient) error {
+		_, err := authClient.SetApiTokenFromFile(org, apiTokenFile)
+		return err
+	})
+	return tokenRefresh, nil
 }
 
 func SaveApiTokenToFile(filename, userAgent string, apiToken *types.ApiTokenRefresh) error {
@@ -308,12 +321,12 @@ func saveTokenToFile(filename, tokenType, userAgent string, token *types.ApiToke
 func readFileAndUnmarshalJSON(filename string, object any) error {
 	data, err := os.ReadFile(path.Clean(filename))
 	if err != nil {
-		return fmt.Errorf("failed to read from file: %s", err)
+		return fmt.Errorf("failed to read from file: %w", err)
 	}
 
 	err = json.Unmarshal(data, object)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal file contents to the object: %s", err)
+		return fmt.Errorf("failed to unmarshal file contents to the object: %w", err)
 	}
 
 	return nil
@@ -324,12 +337,12 @@ func readFileAndUnmarshalJSON(filename string, object any) error {
 func marshalJSONAndWriteToFile(filename string, object any, permissions int) error {
 	data, err := json.MarshalIndent(object, " ", " ")
 	if err != nil {
-		return fmt.Errorf("error marshalling object to JSON: %s", err)
+		return fmt.Errorf("error marshalling object to JSON: %w", err)
 	}
 
 	err = os.WriteFile(filename, data, fs.FileMode(permissions))
 	if err != nil {
-		return fmt.Errorf("error writing to the file: %s", err)
+		return fmt.Errorf("error writing to the file: %w", err)
 	}
 
 	return nil
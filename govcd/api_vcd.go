@@ -44,7 +44,7 @@ type VCDClient struct {
 
 func (vcdClient *VCDClient) vcdloginurl() error {
 	if err := vcdClient.Client.validateAPIVersion(); err != nil {
-		return fmt.Errorf("could not find valid version for login: %s", err)
+		return fmt.Errorf("could not find valid version for login: %w", err)
 	}
 
 	// find login address matching the API version
@@ -186,9 +186,62 @@ func NewVCDClient(vcdEndpoint url.URL, insecure bool, options ...VCDClientOption
 			panic(fmt.Sprintf("unable to initialize VCD client: %s", err))
 		}
 	}
+
+	vcdClient.Client.wrapHttpTransport()
 	return vcdClient
 }
 
+// wrapHttpTransport wraps HTTP transport of the client with the layers that were enabled by
+// VCDClientOption functions so that they apply to every request performed by the client
+func (client *Client) wrapHttpTransport() {
+	transport := client.Http.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// Cassettes record what is sent to and received from the network or replace the network
+	// entirely, therefore they are the innermost layer
+	if client.cassette != nil {
+		client.cassette.next = transport
+		transport = client.cassette
+	}
+
+	// Each attempt of a retried request is logged separately, therefore the logging layer is the
+	// closest one to the network
+	if client.logger != nil {
+		transport = &loggingTransport{next: transport, logger: client.logger}
+	}
+
+	// Limits are applied to each attempt of a retried request, therefore the limiting layer must
+	// be wrapped by the retry layer
+	if client.rateLimiter != nil || client.taskPollingRateLimiter != nil || client.concurrencyLimiter != nil {
+		transport = &limitTransport{
+			next:                   transport,
+			rateLimiter:            client.rateLimiter,
+			taskPollingRateLimiter: client.taskPollingRateLimiter,
+			concurrencyLimiter:     client.concurrencyLimiter,
+		}
+	}
+
+	if client.retryPolicy != nil {
+		transport = &retryTransport{next: transport, policy: *client.retryPolicy, logger: client.logger}
+	}
+
+	// Instrumentation reports a retried request once, together with the number of retries
+	if client.instrumentation != nil {
+		transport = &instrumentationTransport{next: transport, instrumentation: client.instrumentation}
+	}
+
+	// Re-authentication uses all the layers above, but not itself. The replayed request is
+	// reported separately to instrumentation
+	if client.reauth != nil {
+		client.reauth.transport = transport
+		transport = &reauthTransport{next: transport, reauth: client.reauth}
+	}
+
+	client.Http.Transport = transport
+}
+
 func overrideApiVersion() {
 	userDefinedApiVersion := os.Getenv("GOVCD_API_VERSION")
 	if userDefinedApiVersion != "" {
@@ -215,7 +268,7 @@ func (vcdClient *VCDClient) GetAuthResponse(username, password, org string) (*ht
 	// LoginUrl
 	err := vcdClient.vcdloginurl()
 	if err != nil {
-		return nil, fmt.Errorf("error finding LoginUrl: %s", err)
+		return nil, fmt.Errorf("error finding LoginUrl: %w", err)
 	}
 
 	// Choose correct auth mechanism based on what type of authentication is used. The end result
@@ -225,17 +278,21 @@ func (vcdClient *VCDClient) GetAuthResponse(username, password, org string) (*ht
 	case vcdClient.Client.UseSamlAdfs:
 		err = vcdClient.authorizeSamlAdfs(username, password, org, vcdClient.Client.CustomAdfsRptId)
 		if err != nil {
-			return nil, fmt.Errorf("error authorizing SAML: %s", err)
+			return nil, fmt.Errorf("error authorizing SAML: %w", err)
 		}
 	default:
 		// Authorize
 		resp, err = vcdClient.vcdCloudApiAuthorize(username, password, org)
 		if err != nil {
-			return nil, fmt.Errorf("error authorizing: %s", err)
+			return nil, fmt.Errorf("error authorizing: %w", err)
 		}
 	}
 
 	vcdClient.LogSessionInfo()
+	vcdClient.recordAuthentication(func(authClient *VCDClient) error {
+		_, err := authClient.GetAuthResponse(username, password, org)
+		return err
+	})
 	return resp, nil
 }
 
@@ -244,6 +301,7 @@ func (vcdClient *VCDClient) GetAuthResponse(username, password, org string) (*ht
 // In version 30+ it also uses X-Vmware-Vcloud-Access-Token:TOKEN coupled with
 // X-Vmware-Vcloud-Token-Type:"bearer"
 func (vcdClient *VCDClient) SetToken(org, authHeader, token string) error {
+	originalAuthHeader, originalToken := authHeader, token
 	if authHeader == ApiTokenHeader {
 		util.Logger.Printf("[DEBUG] Attempt authentication using API token")
 		apiToken, err := vcdClient.GetBearerTokenFromApiToken(org, token)
@@ -264,7 +322,7 @@ func (vcdClient *VCDClient) SetToken(org, authHeader, token string) error {
 
 	err := vcdClient.vcdloginurl()
 	if err != nil {
-		return fmt.Errorf("error finding LoginUrl: %s", err)
+		return fmt.Errorf("error finding LoginUrl: %w", err)
 	}
 
 	vcdClient.Client.IsSysAdmin = strings.EqualFold(org, "system")
@@ -288,21 +346,31 @@ func (vcdClient *VCDClient) SetToken(org, authHeader, token string) error {
 		return err
 	}
 	vcdClient.LogSessionInfo()
+
+	// A bearer token cannot be renewed without credentials
+	var authenticate func(*VCDClient) error
+	if originalAuthHeader == ApiTokenHeader {
+		authenticate = func(authClient *VCDClient) error {
+			return authClient.SetToken(org, originalAuthHeader, originalToken)
+		}
+	}
+	vcdClient.recordAuthentication(authenticate)
 	return nil
 }
 
 // Disconnect performs a disconnection from the VMware Cloud Director API endpoint.
 func (vcdClient *VCDClient) Disconnect() error {
-	if vcdClient.Client.VCDToken == "" && vcdClient.Client.VCDAuthHeader == "" {
+	authHeader, token := vcdClient.Client.authorization()
+	if token == "" && authHeader == "" {
 		return fmt.Errorf("cannot disconnect, client is not authenticated")
 	}
 	req := vcdClient.Client.NewRequest(map[string]string{}, http.MethodDelete, vcdClient.sessionHREF, nil)
 	// Add the Accept header for vCA
 	req.Header.Add("Accept", "application/xml;version="+vcdClient.Client.APIVersion)
 	// Set Authorization Header
-	req.Header.Add(vcdClient.Client.VCDAuthHeader, vcdClient.Client.VCDToken)
+	req.Header.Add(authHeader, token)
 	if _, err := checkResp(vcdClient.Client.Http.Do(req)); err != nil {
-		return fmt.Errorf("error processing session delete for VMware Cloud Director: %s", err)
+		return fmt.Errorf("error processing session delete for VMware Cloud Director: %w", err)
 	}
 	return nil
 }
@@ -453,9 +521,7 @@ type apiRequestCount uint64
 // inc increments counter by one and returns new value
 func (c *apiRequestCount) inc() uint64 {
 	// prevent overflowing counter
-	if *c == math.MaxUint64 {
-		*c = 0
-	}
+	atomic.CompareAndSwapUint64((*uint64)(c), math.MaxUint64, 0)
 	return atomic.AddUint64((*uint64)(c), 1)
 }
 
@@ -464,7 +530,7 @@ func rewrapRespBodyNoopCloser(resp *http.Response) ([]byte, error) {
 	if resp.Body != nil {
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return bodyBytes, fmt.Errorf("could not read response body: %s", err)
+			return bodyBytes, fmt.Errorf("could not read response body: %w", err)
 		}
 		// Restore the io.ReadCloser to its original state with no-op closer
 		resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
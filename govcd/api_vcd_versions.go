@@ -156,7 +156,7 @@ func (client *Client) MaxSupportedVersion() (string, error) {
 	for index, versionInfo := range client.supportedVersions.VersionInfos {
 		version, err := semver.NewVersion(versionInfo.Version)
 		if err != nil {
-			return "", fmt.Errorf("error parsing version %s: %s", versionInfo.Version, err)
+			return "", fmt.Errorf("error parsing version %s: %w", versionInfo.Version, err)
 		}
 		versions[index] = version
 	}
@@ -186,7 +186,7 @@ func (client *Client) checkSupportedVersionConstraint(versionConstraint string)
 	for _, versionInfo := range client.supportedVersions.VersionInfos {
 		versionMatch, err := client.apiVersionMatchesConstraint(versionInfo.Version, versionConstraint)
 		if err != nil {
-			return fmt.Errorf("cannot match version: %s", err)
+			return fmt.Errorf("cannot match version: %w", err)
 		}
 
 		if versionMatch {
@@ -196,7 +196,7 @@ func (client *Client) checkSupportedVersionConstraint(versionConstraint string)
 		// TODO: TM: Improve this as feels odd and out of place
 		isVcfa, err = client.apiVersionMatchesConstraint(versionInfo.Version, fmt.Sprintf(">= %s", minVcfaApiVersion))
 		if err != nil {
-			return fmt.Errorf("cannot match VCFA version: %s", err)
+			return fmt.Errorf("cannot match VCFA version: %w", err)
 		}
 		if isVcfa {
 			client.APIVersion = minVcfaApiVersion
@@ -211,12 +211,12 @@ func (client *Client) apiVersionMatchesConstraint(version, versionConstraint str
 
 	checkVer, err := semver.NewVersion(version)
 	if err != nil {
-		return false, fmt.Errorf("[ERROR] unable to parse version %s : %s", version, err)
+		return false, fmt.Errorf("[ERROR] unable to parse version %s : %w", version, err)
 	}
 	// Create a provided constraint to check against current max version
 	constraints, err := semver.NewConstraint(versionConstraint)
 	if err != nil {
-		return false, fmt.Errorf("[ERROR] unable to parse given version constraint '%s' : %s", versionConstraint, err)
+		return false, fmt.Errorf("[ERROR] unable to parse given version constraint '%s' : %w", versionConstraint, err)
 	}
 	if constraints.Check(checkVer) {
 		util.Logger.Printf("[INFO] API version %s satisfies constraints '%s'", checkVer, constraints)
@@ -231,13 +231,13 @@ func (client *Client) apiVersionMatchesConstraint(version, versionConstraint str
 func (client *Client) validateAPIVersion() error {
 	err := client.vcdFetchSupportedVersions()
 	if err != nil {
-		return fmt.Errorf("could not retrieve supported versions: %s", err)
+		return fmt.Errorf("could not retrieve supported versions: %w", err)
 	}
 
 	// Check if version is supported
 	err = client.vcdCheckSupportedVersion(client.APIVersion)
 	if err != nil {
-		return fmt.Errorf("API version %s is not supported: %s", client.APIVersion, err)
+		return fmt.Errorf("API version %s is not supported: %w", client.APIVersion, err)
 	}
 
 	return nil
@@ -293,7 +293,7 @@ func (client *Client) GetVcdVersion() (string, time.Time, error) {
 	versionDate := versionList[0][2]
 	versionTime, err := dateparse.ParseStrict(versionDate)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("[version %s] could not convert date %s to formal date: %s", version, versionDate, err)
+		return "", time.Time{}, fmt.Errorf("[version %s] could not convert date %s to formal date: %w", version, versionDate, err)
 	}
 
 	return version, versionTime, nil
@@ -304,7 +304,7 @@ func (client *Client) GetVcdShortVersion() (string, error) {
 
 	vcdVersion, err := client.GetVcdFullVersion()
 	if err != nil {
-		return "", fmt.Errorf("error getting version digits: %s", err)
+		return "", fmt.Errorf("error getting version digits: %w", err)
 	}
 	digits := vcdVersion.Version.Segments()
 	return fmt.Sprintf("%d.%d.%d", digits[0], digits[1], digits[2]), nil
@@ -0,0 +1,124 @@
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+const labelAuditTrailEvent = "Audit Trail Event"
+
+// GetAllAuditTrailEvents retrieves all audit trail events matching the given query parameters.
+//
+// Note. Audit trail can contain a very large number of events. It is advisable to limit the
+// query with a time window (see AuditTrailFilterTimeWindow) or use IterateAuditTrailEvents which
+// does not keep all events in memory
+func (vcdClient *VCDClient) GetAllAuditTrailEvents(queryParameters url.Values) ([]*types.AuditTrailEvent, error) {
+	c := crudConfig{
+		entityLabel:     labelAuditTrailEvent,
+		endpoint:        types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAuditTrail,
+		queryParameters: queryParameters,
+	}
+
+	return getAllInnerEntities[types.AuditTrailEvent](&vcdClient.Client, c)
+}
+
+// GetAuditTrailEventById retrieves a single audit trail event by its ID
+// (e.g. urn:vcloud:audit:a1b0cc89-9d6e-4a7f-9b1e-8b5b0f2b9d1c)
+func (vcdClient *VCDClient) GetAuditTrailEventById(id string) (*types.AuditTrailEvent, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%s lookup requires ID", labelAuditTrailEvent)
+	}
+
+	queryParams := queryParameterFilterAnd("eventId=="+id, nil)
+	events, err := vcdClient.GetAllAuditTrailEvents(queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneOrError("eventId", id, events)
+}
+
+// IterateAuditTrailEvents returns an iterator over audit trail events matching the given query
+// parameters. Pages are retrieved one by one while iterating so that only a single page is kept
+// in memory. Iteration stops after the first error, which is yielded together with a nil event.
+// Cancelling the context stops the iteration before the next page is retrieved.
+//
+// Example:
+//
+//	queryParams := AuditTrailFilterTimeWindow(time.Now().Add(-time.Hour), time.Time{}, nil)
+//	for event, err := range vcdClient.IterateAuditTrailEvents(ctx, queryParams) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(event.EventType)
+//	}
+func (vcdClient *VCDClient) IterateAuditTrailEvents(ctx context.Context, queryParameters url.Values) iter.Seq2[*types.AuditTrailEvent, error] {
+	c := crudConfig{
+		entityLabel:     labelAuditTrailEvent,
+		endpoint:        types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAuditTrail,
+		queryParameters: queryParameters,
+	}
+
+	return iterateInnerEntities[types.AuditTrailEvent](ctx, &vcdClient.Client, c)
+}
+
+// AuditTrailFilterTimeWindow adds a FIQL filter that limits audit trail events to the given time
+// window. Either of `from` or `to` can be left as zero value to leave that side of the window
+// open. Any existing filter in queryParameters is preserved and AND'ed with the new one.
+func AuditTrailFilterTimeWindow(from, to time.Time, queryParameters url.Values) url.Values {
+	newParameters := copyOrNewUrlValues(queryParameters)
+	if !from.IsZero() {
+		newParameters = queryParameterFilterAnd("timestamp=ge="+from.UTC().Format(types.FiqlQueryTimestampFormat), newParameters)
+	}
+	if !to.IsZero() {
+		newParameters = queryParameterFilterAnd("timestamp=le="+to.UTC().Format(types.FiqlQueryTimestampFormat), newParameters)
+	}
+	return newParameters
+}
+
+// AuditTrailFilterEventTypes adds a FIQL filter that limits audit trail events to any of the given
+// event types (e.g. "com/vmware/cloud/event/vapp/create"). Any existing filter in queryParameters
+// is preserved and AND'ed with the new one. An empty list of event types adds no filter.
+func AuditTrailFilterEventTypes(eventTypes []string, queryParameters url.Values) url.Values {
+	if len(eventTypes) == 0 {
+		return copyOrNewUrlValues(queryParameters)
+	}
+	return queryParameterFilterAnd(fiqlFilterAnyOf("eventType", eventTypes), queryParameters)
+}
+
+// AuditTrailFilterActor adds a FIQL filter that limits audit trail events to the ones triggered
+// by a given user. The user can be specified either by URN (e.g. urn:vcloud:user:...) or by name.
+// Any existing filter in queryParameters is preserved and AND'ed with the new one.
+func AuditTrailFilterActor(user string, queryParameters url.Values) url.Values {
+	if isUrn(user) {
+		return queryParameterFilterAnd("user.id=="+user, queryParameters)
+	}
+	return queryParameterFilterAnd("user.name=="+user, queryParameters)
+}
+
+// AuditTrailFilterTargetEntity adds a FIQL filter that limits audit trail events to the ones that
+// target an entity with a given URN (e.g. urn:vcloud:vm:...). Any existing filter in
+// queryParameters is preserved and AND'ed with the new one.
+func AuditTrailFilterTargetEntity(entityUrn string, queryParameters url.Values) url.Values {
+	return queryParameterFilterAnd("eventEntity.id=="+entityUrn, queryParameters)
+}
+
+// fiqlFilterAnyOf builds a FIQL filter that matches any of the given values for a field
+// (e.g. "(eventType==a,eventType==b)")
+func fiqlFilterAnyOf(field string, values []string) string {
+	if len(values) == 1 {
+		return field + "==" + values[0]
+	}
+
+	expressions := make([]string, len(values))
+	for i, value := range values {
+		expressions[i] = field + "==" + value
+	}
+	return "(" + strings.Join(expressions, ",") + ")"
+}
@@ -0,0 +1,59 @@
+//go:build functional || openapi || ALL
+
+package govcd
+
+import (
+	"context"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_AuditTrailEvents(check *C) {
+	skipOpenApiEndpointTest(vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAuditTrail)
+
+	// Login events are always present in the last hours of a test run
+	queryParams := AuditTrailFilterTimeWindow(time.Now().Add(-6*time.Hour), time.Time{}, nil)
+	queryParams.Set("pageSize", "5") // small page size to enforce pagination
+	queryParams.Set("sortDesc", "timestamp")
+
+	iteratedCount := 0
+	var lastEvent *types.AuditTrailEvent
+	for event, err := range vcd.client.IterateAuditTrailEvents(context.Background(), queryParams) {
+		check.Assert(err, IsNil)
+		check.Assert(event.EventID, Not(Equals), "")
+		lastEvent = event
+		iteratedCount++
+		if iteratedCount == 12 {
+			break
+		}
+	}
+	check.Assert(iteratedCount > 0, Equals, true)
+
+	eventById, err := vcd.client.GetAuditTrailEventById(lastEvent.EventID)
+	check.Assert(err, IsNil)
+	check.Assert(eventById.EventID, Equals, lastEvent.EventID)
+	check.Assert(eventById.EventType, Equals, lastEvent.EventType)
+
+	// Filter by event type of a known event
+	filteredEvents, err := vcd.client.GetAllAuditTrailEvents(AuditTrailFilterEventTypes([]string{lastEvent.EventType}, queryParams))
+	check.Assert(err, IsNil)
+	check.Assert(len(filteredEvents) > 0, Equals, true)
+	for _, event := range filteredEvents {
+		check.Assert(event.EventType, Equals, lastEvent.EventType)
+	}
+
+	if lastEvent.User != nil && lastEvent.User.ID != "" {
+		filteredEvents, err = vcd.client.GetAllAuditTrailEvents(AuditTrailFilterActor(lastEvent.User.ID, queryParams))
+		check.Assert(err, IsNil)
+		check.Assert(len(filteredEvents) > 0, Equals, true)
+		for _, event := range filteredEvents {
+			check.Assert(event.User, NotNil)
+			check.Assert(event.User.ID, Equals, lastEvent.User.ID)
+		}
+	}
+
+	_, err = vcd.client.GetAuditTrailEventById("urn:vcloud:audit:00000000-0000-0000-0000-000000000000")
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
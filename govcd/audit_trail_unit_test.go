@@ -0,0 +1,175 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// auditTrailMockServer serves 3 pages of audit trail events with 2 events in each page. The first
+// page relies on 'resultTotal' and 'pageSize' fields only (to mimic an API bug where 'nextPage'
+// link is missing) while the second one contains a 'nextPage' Link header
+func auditTrailMockServer(t *testing.T, requestCount *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requestCount++
+		if r.URL.Path != "/cloudapi/1.0.0/auditTrail/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page == "2" {
+			nextPage := fmt.Sprintf("http://%s/cloudapi/1.0.0/auditTrail/?page=3&pageSize=2&filter=%s",
+				r.Host, url.QueryEscape(r.URL.Query().Get("filter")))
+			w.Header().Set("Link", `<`+nextPage+`>;rel="nextPage";type="application/json;version=37.0"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"resultTotal":6,"page":%s,"pageSize":2,"values":[`+
+			`{"eventId":"urn:vcloud:audit:%s-1","eventType":"com/vmware/cloud/event/vm/create","user":{"name":"admin","id":"urn:vcloud:user:1"}},`+
+			`{"eventId":"urn:vcloud:audit:%s-2","eventType":"com/vmware/cloud/event/vm/delete","additionalProperties":{"user.roles":"System Administrator"}}]}`,
+			page, page, page)
+	}))
+}
+
+func TestVCDClient_IterateAuditTrailEvents(t *testing.T) {
+	requestCount := 0
+	server := auditTrailMockServer(t, &requestCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+
+	var eventIds []string
+	for event, err := range vcdClient.IterateAuditTrailEvents(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		eventIds = append(eventIds, event.EventID)
+	}
+	if len(eventIds) != 6 {
+		t.Fatalf("expected 6 events, got %d: %v", len(eventIds), eventIds)
+	}
+	if eventIds[5] != "urn:vcloud:audit:3-2" {
+		t.Fatalf("expected last event to be from page 3, got %s", eventIds[5])
+	}
+
+	// Breaking out of the loop must not retrieve further pages
+	requestCount = 0
+	for event, err := range vcdClient.IterateAuditTrailEvents(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if event.EventID == "urn:vcloud:audit:1-1" {
+			break
+		}
+	}
+	if requestCount != 1 {
+		t.Fatalf("expected a single request, got %d", requestCount)
+	}
+
+	// GetAllAuditTrailEvents must return the same events
+	events, err := vcdClient.GetAllAuditTrailEvents(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(events) != 6 {
+		t.Fatalf("expected 6 events, got %d", len(events))
+	}
+	if events[0].User == nil || events[0].User.Name != "admin" {
+		t.Fatalf("expected user to be populated, got %#v", events[0].User)
+	}
+	if events[1].AdditionalProperties["user.roles"] != "System Administrator" {
+		t.Fatalf("expected additional properties to be populated, got %#v", events[1].AdditionalProperties)
+	}
+}
+
+func TestVCDClient_IterateAuditTrailEventsCancelled(t *testing.T) {
+	requestCount := 0
+	server := auditTrailMockServer(t, &requestCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for event, err := range vcdClient.IterateAuditTrailEvents(ctx, nil) {
+		if err == nil {
+			t.Fatalf("expected an error for cancelled context, got event %s", event.EventID)
+		}
+	}
+	if requestCount != 0 {
+		t.Fatalf("expected no request to reach the server, got %d", requestCount)
+	}
+}
+
+func TestAuditTrailFilters(t *testing.T) {
+	from := time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	to := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		queryParams    url.Values
+		expectedFilter string
+	}{
+		{
+			name:           "TimeWindow",
+			queryParams:    AuditTrailFilterTimeWindow(from, to, nil),
+			expectedFilter: "timestamp=ge=2024-05-01T08:00:00.000Z;timestamp=le=2024-05-01T12:30:00.000Z",
+		},
+		{
+			name:           "TimeWindowOpenEnd",
+			queryParams:    AuditTrailFilterTimeWindow(from, time.Time{}, nil),
+			expectedFilter: "timestamp=ge=2024-05-01T08:00:00.000Z",
+		},
+		{
+			name:           "SingleEventType",
+			queryParams:    AuditTrailFilterEventTypes([]string{"com/vmware/cloud/event/vm/create"}, nil),
+			expectedFilter: "eventType==com/vmware/cloud/event/vm/create",
+		},
+		{
+			name:           "MultipleEventTypes",
+			queryParams:    AuditTrailFilterEventTypes([]string{"a", "b"}, nil),
+			expectedFilter: "(eventType==a,eventType==b)",
+		},
+		{
+			name:           "NoEventTypes",
+			queryParams:    AuditTrailFilterEventTypes(nil, nil),
+			expectedFilter: "",
+		},
+		{
+			name:           "ActorByName",
+			queryParams:    AuditTrailFilterActor("admin", nil),
+			expectedFilter: "user.name==admin",
+		},
+		{
+			name:           "ActorByUrn",
+			queryParams:    AuditTrailFilterActor("urn:vcloud:user:7d5e1b6a-9a40-4a4d-9f8b-0d5a7a2a1e01", nil),
+			expectedFilter: "user.id==urn:vcloud:user:7d5e1b6a-9a40-4a4d-9f8b-0d5a7a2a1e01",
+		},
+		{
+			name: "Composed",
+			queryParams: AuditTrailFilterTargetEntity("urn:vcloud:vm:1",
+				AuditTrailFilterEventTypes([]string{"a", "b"},
+					url.Values{"filter": []string{"eventStatus==SUCCESS"}, "pageSize": []string{"10"}})),
+			expectedFilter: "eventStatus==SUCCESS;(eventType==a,eventType==b);eventEntity.id==urn:vcloud:vm:1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.queryParams.Get("filter"); got != tt.expectedFilter {
+				t.Errorf("expected filter '%s', got '%s'", tt.expectedFilter, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package govcd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// CassetteMode defines whether HTTP interactions are recorded to a cassette or replayed from it
+type CassetteMode int
+
+const (
+	// CassetteRecord sends requests to VCD and saves every request and response to the cassette
+	CassetteRecord CassetteMode = iota + 1
+	// CassetteReplay does not contact VCD. Responses are returned from the cassette
+	CassetteReplay
+)
+
+// CassetteMatch selects the parts of a request that must be equal to a recorded request for its
+// response to be replayed. Values can be combined, e.g. CassetteMatchMethod | CassetteMatchPath
+type CassetteMatch int
+
+const (
+	// CassetteMatchMethod matches HTTP methods
+	CassetteMatchMethod CassetteMatch = 1 << iota
+	// CassetteMatchPath matches URL paths
+	CassetteMatchPath
+	// CassetteMatchQuery matches URL query parameters regardless of their order
+	CassetteMatchQuery
+	// CassetteMatchBody matches SHA-256 hashes of request bodies
+	CassetteMatchBody
+
+	// CassetteMatchDefault matches method, path and query
+	CassetteMatchDefault = CassetteMatchMethod | CassetteMatchPath | CassetteMatchQuery
+)
+
+// ErrCassetteNoMatch is returned during replay for requests that do not match any recorded
+// interaction
+var ErrCassetteNoMatch = errors.New("no matching interaction in cassette")
+
+// CassetteConfig configures WithCassette
+type CassetteConfig struct {
+	// Path is the path of the cassette file
+	Path string
+	// Mode is CassetteRecord or CassetteReplay
+	Mode CassetteMode
+	// Match selects the parts of a request that are compared during replay (default
+	// CassetteMatchDefault)
+	Match CassetteMatch
+}
+
+// WithCassette records HTTP interactions of the client to a cassette file or replays them from
+// it. It allows to record a session against a real VCD once and replay it in tests that run
+// without VCD.
+//
+// Recorded data is scrubbed before it is saved:
+// * tokens and passwords are masked using the rules of util.ScrubbedHeader and util.ScrubbedText
+// * the scheme and host of VCD are replaced with a placeholder in URLs, headers and bodies, so that
+// a cassette can be replayed against any endpoint (e.g. 'Link' headers used for paging point to
+// the endpoint of the replaying client)
+// * 'X-Vmware-Vcloud-Client-Request-Id' header is not saved, because it differs in every run
+//
+// During replay, a request gets the response of the first recorded interaction that matches it
+// and was not replayed yet. When all matching interactions were replayed, the last one is
+// repeated, so that polling (e.g. of tasks) can take a different number of requests. Responses
+// carry 'X-Vmware-Vcloud-Request-Id' built from the client request ID of the replayed request like
+// VCD does. Requests that do not match any interaction fail with ErrCassetteNoMatch.
+//
+// The cassette is saved after every recorded interaction, therefore it is complete even if the
+// program stops unexpectedly.
+func WithCassette(config CassetteConfig) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if config.Path == "" {
+			return fmt.Errorf("cassette path cannot be empty")
+		}
+		if config.Match == 0 {
+			config.Match = CassetteMatchDefault
+		}
+		transport := &cassetteTransport{config: config, cassette: &cassette{Version: cassetteVersion}}
+		switch config.Mode {
+		case CassetteRecord:
+		case CassetteReplay:
+			if err := transport.load(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid cassette mode %d", config.Mode)
+		}
+		vcdClient.Client.cassette = transport
+		return nil
+	}
+}
+
+// cassetteVersion is the version of the cassette file format
+const cassetteVersion = 1
+
+// cassetteEndpointPlaceholder replaces the scheme and host of VCD in recorded data
+const cassetteEndpointPlaceholder = "{{endpoint}}"
+
+// clientRequestIdHeader is sent by the client to correlate requests with VCD logs
+const clientRequestIdHeader = "X-Vmware-Vcloud-Client-Request-Id"
+
+// cassette is the content of a cassette file
+type cassette struct {
+	Version      int                    `json:"version"`
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+// cassetteInteraction is a recorded request together with its response
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+	// replayed is true when the interaction was already replayed
+	replayed bool
+}
+
+type cassetteRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	cassetteBody
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	cassetteBody
+}
+
+// cassetteBody is a recorded body. Bodies which are not valid UTF-8 text are base64 encoded
+type cassetteBody struct {
+	Body         string `json:"body,omitempty"`
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+	BodyHash     string `json:"bodyHash,omitempty"`
+}
+
+func newCassetteBody(body []byte, endpoint string) cassetteBody {
+	if len(body) == 0 {
+		return cassetteBody{}
+	}
+	if !utf8.Valid(body) {
+		hash := sha256.Sum256(body)
+		return cassetteBody{
+			Body:         base64.StdEncoding.EncodeToString(body),
+			BodyEncoding: "base64",
+			BodyHash:     hex.EncodeToString(hash[:]),
+		}
+	}
+	text := scrubCassetteText(string(body), endpoint)
+	hash := sha256.Sum256([]byte(text))
+	return cassetteBody{Body: text, BodyHash: hex.EncodeToString(hash[:])}
+}
+
+// bytes returns the body with the endpoint placeholder replaced by the given endpoint
+func (body cassetteBody) bytes(endpoint string) ([]byte, error) {
+	if body.BodyEncoding == "base64" {
+		return base64.StdEncoding.DecodeString(body.Body)
+	}
+	return []byte(strings.ReplaceAll(body.Body, cassetteEndpointPlaceholder, endpoint)), nil
+}
+
+// scrubCassetteText masks sensitive data and replaces the endpoint with a placeholder
+func scrubCassetteText(text, endpoint string) string {
+	return strings.ReplaceAll(util.ScrubbedText(text), endpoint, cassetteEndpointPlaceholder)
+}
+
+// scrubCassetteUrl replaces the endpoint with a placeholder in the URL including its query (e.g.
+// filters with HREFs)
+func scrubCassetteUrl(rawUrl, endpoint string) string {
+	rawUrl = strings.ReplaceAll(rawUrl, endpoint, cassetteEndpointPlaceholder)
+	return strings.ReplaceAll(rawUrl, url.QueryEscape(endpoint), url.QueryEscape(cassetteEndpointPlaceholder))
+}
+
+// scrubCassetteHeader masks sensitive headers and replaces the endpoint with a placeholder
+func scrubCassetteHeader(header http.Header, endpoint string) http.Header {
+	scrubbed := make(http.Header)
+	for key, values := range util.ScrubbedHeader(header) {
+		key = http.CanonicalHeaderKey(key)
+		if key == clientRequestIdHeader || key == "Content-Length" {
+			continue
+		}
+		for _, value := range values {
+			scrubbed.Add(key, strings.ReplaceAll(value, endpoint, cassetteEndpointPlaceholder))
+		}
+	}
+	return scrubbed
+}
+
+// cassetteTransport is an http.RoundTripper that records or replays HTTP interactions
+type cassetteTransport struct {
+	next   http.RoundTripper
+	config CassetteConfig
+
+	// mu guards cassette
+	mu       sync.Mutex
+	cassette *cassette
+}
+
+// requestEndpoint returns the scheme and host of the request URL
+func requestEndpoint(req *http.Request) string {
+	return req.URL.Scheme + "://" + req.URL.Host
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := requestEndpoint(req)
+	request := cassetteRequest{
+		Method:       req.Method,
+		URL:          scrubCassetteUrl(req.URL.String(), endpoint),
+		Header:       scrubCassetteHeader(req.Header, endpoint),
+		cassetteBody: newCassetteBody(requestBody, endpoint),
+	}
+
+	if transport.config.Mode == CassetteReplay {
+		return transport.replay(req, request)
+	}
+	return transport.record(req, request)
+}
+
+// readRequestBody reads the body of the request and makes it readable again
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func (transport *cassetteTransport) record(req *http.Request, request cassetteRequest) (*http.Response, error) {
+	resp, err := transport.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	endpoint := requestEndpoint(req)
+	interaction := &cassetteInteraction{
+		Request: request,
+		Response: cassetteResponse{
+			StatusCode:   resp.StatusCode,
+			Header:       scrubCassetteHeader(resp.Header, endpoint),
+			cassetteBody: newCassetteBody(responseBody, endpoint),
+		},
+	}
+
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	transport.cassette.Interactions = append(transport.cassette.Interactions, interaction)
+	if err := transport.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (transport *cassetteTransport) replay(req *http.Request, request cassetteRequest) (*http.Response, error) {
+	transport.mu.Lock()
+	var match *cassetteInteraction
+	for _, interaction := range transport.cassette.Interactions {
+		if !transport.matches(interaction.Request, request) {
+			continue
+		}
+		match = interaction
+		if !interaction.replayed {
+			break
+		}
+	}
+	if match != nil {
+		match.replayed = true
+	}
+	transport.mu.Unlock()
+
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteNoMatch, req.Method, request.URL)
+	}
+
+	endpoint := requestEndpoint(req)
+	body, err := match.Response.bytes(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding recorded response body: %w", err)
+	}
+	header := make(http.Header)
+	for key, values := range match.Response.Header {
+		for _, value := range values {
+			header.Add(key, strings.ReplaceAll(value, cassetteEndpointPlaceholder, endpoint))
+		}
+	}
+	// VCD builds the request ID from the client request ID and a UUID
+	recordedRequestId := header.Get("X-Vmware-Vcloud-Request-Id")
+	if clientRequestId := req.Header.Get(clientRequestIdHeader); clientRequestId != "" && len(recordedRequestId) >= 36 {
+		header.Set("X-Vmware-Vcloud-Request-Id", clientRequestId+"-"+recordedRequestId[len(recordedRequestId)-36:])
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Response.StatusCode, http.StatusText(match.Response.StatusCode)),
+		StatusCode:    match.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// matches returns true if the recorded request matches the current one in all parts selected by
+// CassetteConfig.Match
+func (transport *cassetteTransport) matches(recorded, current cassetteRequest) bool {
+	match := transport.config.Match
+	if match&CassetteMatchMethod != 0 && recorded.Method != current.Method {
+		return false
+	}
+	if match&(CassetteMatchPath|CassetteMatchQuery) != 0 {
+		recordedUrl, err := url.Parse(recorded.URL)
+		if err != nil {
+			return false
+		}
+		currentUrl, err := url.Parse(current.URL)
+		if err != nil {
+			return false
+		}
+		if match&CassetteMatchPath != 0 && recordedUrl.Path != currentUrl.Path {
+			return false
+		}
+		if match&CassetteMatchQuery != 0 && !equalQueries(recordedUrl.RawQuery, currentUrl.RawQuery) {
+			return false
+		}
+	}
+	if match&CassetteMatchBody != 0 && recorded.BodyHash != current.BodyHash {
+		return false
+	}
+	return true
+}
+
+// equalQueries compares query strings regardless of the order of parameters. The conditions of
+// filters that are only joined by AND (;) are compared regardless of their order too, as the SDK
+// builds some filters from maps
+func equalQueries(a, b string) bool {
+	split := func(query string) []string {
+		params := strings.FieldsFunc(query, func(r rune) bool { return r == '&' })
+		for i, param := range params {
+			params[i] = normalizeQueryFilter(param)
+		}
+		slices.Sort(params)
+		return params
+	}
+	return slices.Equal(split(a), split(b))
+}
+
+// normalizeQueryFilter sorts the conditions of a filter parameter that only uses AND (;)
+func normalizeQueryFilter(param string) string {
+	value, found := strings.CutPrefix(param, "filter=")
+	if !found {
+		return param
+	}
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+	if strings.ContainsAny(value, "(),") {
+		return param
+	}
+	conditions := strings.Split(value, ";")
+	slices.Sort(conditions)
+	return "filter=" + strings.Join(conditions, ";")
+}
+
+// load reads the cassette file
+func (transport *cassetteTransport) load() error {
+	content, err := os.ReadFile(transport.config.Path)
+	if err != nil {
+		return fmt.Errorf("error reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(content, transport.cassette); err != nil {
+		return fmt.Errorf("error decoding cassette %s: %w", transport.config.Path, err)
+	}
+	if transport.cassette.Version != cassetteVersion {
+		return fmt.Errorf("unsupported version %d of cassette %s", transport.cassette.Version, transport.config.Path)
+	}
+	return nil
+}
+
+// save writes the cassette file. The file is replaced atomically, so that it is never partially
+// written
+func (transport *cassetteTransport) save() error {
+	content, err := json.MarshalIndent(transport.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(transport.config.Path), filepath.Base(transport.config.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err = tempFile.Write(content); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+	if err = tempFile.Close(); err != nil {
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+	if err = os.Rename(tempFile.Name(), transport.config.Path); err != nil {
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// cassetteVAppScenario authenticates, creates a vApp and waits for its task
+func cassetteVAppScenario(vcdClient *VCDClient) error {
+	if err := vcdClient.Authenticate("admin", "secret-password", "org1"); err != nil {
+		return fmt.Errorf("error authenticating: %w", err)
+	}
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		return fmt.Errorf("error retrieving org: %w", err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		return fmt.Errorf("error retrieving VDC: %w", err)
+	}
+	vapp, err := vdc.CreateRawVApp("vapp1", "")
+	if err != nil {
+		return fmt.Errorf("error creating vApp: %w", err)
+	}
+	task, err := vapp.PowerOn()
+	if err != nil {
+		return fmt.Errorf("error powering on vApp: %w", err)
+	}
+	return task.WaitTaskCompletion()
+}
+
+func TestWithCassette(t *testing.T) {
+	server := govcdtest.NewServer()
+	if _, err := server.AddOrg("org1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddVdc("org1", "vdc1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddUser("org1", "admin", "secret-password"); err != nil {
+		t.Fatal(err)
+	}
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+
+	recordingClient := NewVCDClient(server.Endpoint(), true,
+		WithCassette(CassetteConfig{Path: cassettePath, Mode: CassetteRecord}))
+	if err := cassetteVAppScenario(recordingClient); err != nil {
+		t.Fatalf("error recording: %s", err)
+	}
+	token := recordingClient.Client.VCDToken
+	server.Close()
+
+	content, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("error reading cassette: %s", err)
+	}
+	for _, secret := range []string{token, server.URL, "secret-password"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains '%s'", secret)
+		}
+	}
+
+	// The cassette is replayed against another endpoint that does not exist
+	replayEndpoint, err := url.Parse("https://vcd.example.com/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayingClient := NewVCDClient(*replayEndpoint, true,
+		WithCassette(CassetteConfig{Path: cassettePath, Mode: CassetteReplay}))
+	if err := cassetteVAppScenario(replayingClient); err != nil {
+		t.Fatalf("error replaying: %s", err)
+	}
+
+	_, err = replayingClient.GetAdminOrgByName("org1")
+	if err == nil {
+		t.Errorf("expected an error for a request that was not recorded")
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://vcd.example.com/api/unknown", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = replayingClient.Client.Http.Transport.RoundTrip(req); !errors.Is(err, ErrCassetteNoMatch) {
+		t.Errorf("expected ErrCassetteNoMatch, got %v", err)
+	}
+}
+
+func TestWithCassette_PagingAndRequestId(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.JSONMime)
+		w.Header().Set("X-Vmware-Vcloud-Request-Id", r.Header.Get("X-Vmware-Vcloud-Client-Request-Id")+
+			"-3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"resultTotal":2,"pageCount":2,"page":2,"pageSize":1,"values":[{"name":"role2"}]}`))
+			return
+		}
+		// The link to the next page has a cursor instead of a page number, therefore it must be
+		// followed to get the second page
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2&cursor=abc>;rel="nextPage";type="application/json"`,
+			r.Host, r.URL.Path))
+		_, _ = w.Write([]byte(`{"resultTotal":2,"pageCount":2,"page":1,"values":[{"name":"role1"}]}`))
+	}))
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+
+	getRoles := func(vcdClient *VCDClient) ([]*types.Role, error) {
+		urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+		if err != nil {
+			return nil, err
+		}
+		var roles []*types.Role
+		err = vcdClient.Client.OpenApiGetAllItems("37.0", urlRef, nil, &roles, nil)
+		return roles, err
+	}
+
+	recordingClient := testContextClient(t, server.URL,
+		WithCassette(CassetteConfig{Path: cassettePath, Mode: CassetteRecord}))
+	if _, err := getRoles(recordingClient); err != nil {
+		t.Fatalf("error recording: %s", err)
+	}
+	server.Close()
+
+	replayingClient := testContextClient(t, "https://vcd.example.com",
+		WithCassette(CassetteConfig{Path: cassettePath, Mode: CassetteReplay, Match: CassetteMatchDefault | CassetteMatchBody}),
+		WithVcloudRequestIdFunc(func() string { return "replayed-request" }))
+	roles, err := getRoles(replayingClient)
+	if err != nil {
+		t.Fatalf("error replaying: %s", err)
+	}
+	if len(roles) != 2 || roles[0].Name != "role1" || roles[1].Name != "role2" {
+		t.Errorf("expected 2 roles, got %#v", roles)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://vcd.example.com/cloudapi/1.0.0/roles/?page=2&cursor=abc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Vmware-Vcloud-Client-Request-Id", "my-request")
+	resp, err := replayingClient.Client.Http.Transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("error replaying request: %s", err)
+	}
+	_ = resp.Body.Close()
+	if requestId := resp.Header.Get("X-Vmware-Vcloud-Request-Id"); requestId != "my-request-3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6" {
+		t.Errorf("unexpected request ID %s", requestId)
+	}
+}
+
+func Test_cassetteTransport_matches(t *testing.T) {
+	recorded := cassetteRequest{Method: http.MethodGet, URL: "{{endpoint}}/api/query?type=vm&filter=name==a;x==b", cassetteBody: cassetteBody{BodyHash: "1"}}
+	tests := []struct {
+		match    CassetteMatch
+		current  cassetteRequest
+		expected bool
+	}{
+		{CassetteMatchDefault, cassetteRequest{Method: http.MethodGet, URL: "{{endpoint}}/api/query?filter=x==b;name==a&type=vm"}, true},
+		{CassetteMatchDefault, cassetteRequest{Method: http.MethodGet, URL: "{{endpoint}}/api/query?filter=x%3D%3Db%3Bname%3D%3Da&type=vm"}, true},
+		{CassetteMatchDefault, cassetteRequest{Method: http.MethodPost, URL: recorded.URL}, false},
+		{CassetteMatchDefault, cassetteRequest{Method: http.MethodGet, URL: "{{endpoint}}/api/query?type=vm"}, false},
+		{CassetteMatchDefault, cassetteRequest{Method: http.MethodGet, URL: "{{endpoint}}/api/query?type=vm&filter=name==a"}, false},
+		{CassetteMatchMethod | CassetteMatchPath, cassetteRequest{Method: http.MethodGet, URL: "{{endpoint}}/api/query?type=vm"}, true},
+		{CassetteMatchPath | CassetteMatchBody, cassetteRequest{URL: recorded.URL, cassetteBody: cassetteBody{BodyHash: "2"}}, false},
+	}
+	for i, test := range tests {
+		transport := &cassetteTransport{config: CassetteConfig{Match: test.match}}
+		if got := transport.matches(recorded, test.current); got != test.expected {
+			t.Errorf("test %d: expected %t, got %t", i, test.expected, got)
+		}
+	}
+}
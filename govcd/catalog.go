@@ -59,7 +59,7 @@ func (catalog *Catalog) Delete(force, recursive bool) error {
 		// A catalog cannot be removed if it has active tasks, or if any of its items have active tasks
 		err = catalog.consumeTasks()
 		if err != nil {
-			return fmt.Errorf("error while consuming tasks from catalog %s: %s", catalog.Catalog.Name, err)
+			return fmt.Errorf("error while consuming tasks from catalog %s: %w", catalog.Catalog.Name, err)
 		}
 	}
 
@@ -70,14 +70,14 @@ func (catalog *Catalog) Delete(force, recursive bool) error {
 
 	resp, err := checkResp(catalog.client.Http.Do(req))
 	if err != nil {
-		return fmt.Errorf("error deleting Catalog %s: %s", catalog.Catalog.Name, err)
+		return fmt.Errorf("error deleting Catalog %s: %w", catalog.Catalog.Name, err)
 	}
 	task := NewTask(catalog.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return fmt.Errorf("error decoding task response: %s", err)
+		return fmt.Errorf("error decoding task response: %w", err)
 	}
 	if task.Task.Status == "error" {
-		return errors.New(combinedTaskErrorMessage(task.Task, fmt.Errorf("catalog %s not properly destroyed", catalog.Catalog.Name)))
+		return combinedTaskError(task.Task, fmt.Errorf("catalog %s not properly destroyed", catalog.Catalog.Name))
 	}
 	return task.WaitTaskCompletion()
 }
@@ -94,7 +94,7 @@ func (catalog *Catalog) consumeTasks() error {
 		"status": "running,preRunning,queued",
 	})
 	if err != nil {
-		return fmt.Errorf("error getting task list from catalog %s: %s", catalog.Catalog.Name, err)
+		return fmt.Errorf("error getting task list from catalog %s: %w", catalog.Catalog.Name, err)
 	}
 	var taskList []string
 	addTask := func(status, href string) {
@@ -119,7 +119,7 @@ func (catalog *Catalog) consumeTasks() error {
 	}
 	catalogItemRefs, err := catalog.QueryCatalogItemList()
 	if err != nil {
-		return fmt.Errorf("error getting catalog %s items list: %s", catalog.Catalog.Name, err)
+		return fmt.Errorf("error getting catalog %s items list: %w", catalog.Catalog.Name, err)
 	}
 	for _, task := range allTasks {
 		for _, ref := range catalogItemRefs {
@@ -132,7 +132,7 @@ func (catalog *Catalog) consumeTasks() error {
 	}
 	_, err = catalog.client.WaitTaskListCompletion(taskList, true)
 	if err != nil {
-		return fmt.Errorf("error while waiting for task list completion for catalog %s: %s", catalog.Catalog.Name, err)
+		return fmt.Errorf("error while waiting for task list completion for catalog %s: %w", catalog.Catalog.Name, err)
 	}
 	return nil
 }
@@ -214,23 +214,23 @@ func (cat *Catalog) UploadOvf(ovaFileName, itemName, description string, uploadP
 	if !isOvf {
 		filesAbsPaths, tmpDir, err = util.Unpack(ovaFileName)
 		if err != nil {
-			return UploadTask{}, fmt.Errorf("%s. Unpacked files for checking are accessible in: %s", err, tmpDir)
+			return UploadTask{}, fmt.Errorf("%w. Unpacked files for checking are accessible in: %s", err, tmpDir)
 		}
 		ovfFilePath, err = getOvfPath(filesAbsPaths)
 		if err != nil {
-			return UploadTask{}, fmt.Errorf("%s. Unpacked files for checking are accessible in: %s", err, tmpDir)
+			return UploadTask{}, fmt.Errorf("%w. Unpacked files for checking are accessible in: %s", err, tmpDir)
 		}
 	}
 
 	ovfFileDesc, err := getOvf(ovfFilePath)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("%s. OVF/Unpacked files for checking are accessible in: %s", err, tmpDir)
+		return UploadTask{}, fmt.Errorf("%w. OVF/Unpacked files for checking are accessible in: %s", err, tmpDir)
 	}
 
 	if !isOvf {
 		err = validateOvaContent(filesAbsPaths, &ovfFileDesc, tmpDir)
 		if err != nil {
-			return UploadTask{}, fmt.Errorf("%s. Unpacked files for checking are accessible in: %s", err, tmpDir)
+			return UploadTask{}, fmt.Errorf("%w. Unpacked files for checking are accessible in: %s", err, tmpDir)
 		}
 	} else {
 		dir := path.Dir(ovfFilePath)
@@ -301,7 +301,7 @@ func (cat *Catalog) UploadOvf(ovaFileName, itemName, description string, uploadP
 		}
 		if task.Task.Status == "error" {
 			removeCatalogItemOnError(cat.client, vappTemplateUrl, itemName)
-			return UploadTask{}, fmt.Errorf("task did not complete succesfully: %s", task.Task.Description)
+			return UploadTask{}, newTaskError(task.Task, "task did not complete succesfully: "+task.Task.Description)
 		}
 	}
 
@@ -351,7 +351,7 @@ func (cat *Catalog) UploadOvfByLink(ovfUrl, itemName, description string) (Task,
 		}
 		if task.Task.Status == "error" {
 			removeCatalogItemOnError(cat.client, vappTemplateUrl, itemName)
-			return Task{}, fmt.Errorf("task did not complete succesfully: %s", task.Task.Description)
+			return Task{}, newTaskError(task.Task, "task did not complete succesfully: "+task.Task.Description)
 		}
 	}
 
@@ -1052,7 +1052,7 @@ func getVAppTemplateById(client *Client, vAppTemplateId string) (*VAppTemplate,
 
 	vappTemplate, err := getVAppTemplateByHref(client, vappTemplateHref.String())
 	if err != nil {
-		return nil, fmt.Errorf("could not find vApp Template with ID %s: %s", vAppTemplateId, err)
+		return nil, fmt.Errorf("could not find vApp Template with ID %s: %w", vAppTemplateId, err)
 	}
 	return vappTemplate, nil
 }
@@ -1093,7 +1093,7 @@ func (catalog *Catalog) QueryMediaList() ([]*types.MediaRecordType, error) {
 	filter := fmt.Sprintf("catalog==%s", url.QueryEscape(catalog.Catalog.HREF))
 	results, err := catalog.client.QueryWithNotEncodedParams(nil, map[string]string{"type": typeMedia, "filter": filter, "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias: %s", err)
+		return nil, fmt.Errorf("error querying medias: %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -1175,7 +1175,7 @@ func queryMediaList(client *Client, catalogHref string) ([]*types.MediaRecordTyp
 	filter := fmt.Sprintf("catalog==%s", url.QueryEscape(catalogHref))
 	results, err := client.QueryWithNotEncodedParams(nil, map[string]string{"type": typeMedia, "filter": filter, "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias: %s", err)
+		return nil, fmt.Errorf("error querying medias: %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -1237,7 +1237,7 @@ func (client *Client) GetCatalogByHref(catalogHref string) (*Catalog, error) {
 			_, err = client.ExecuteRequest(link.HREF, http.MethodGet,
 				"", "error retrieving parent Org: %s", nil, org.Org)
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving catalog parent: %s", err)
+				return nil, fmt.Errorf("error retrieving catalog parent: %w", err)
 			}
 			break
 		}
@@ -65,7 +65,7 @@ func queryCatalogItemList(client *Client, parentField, parentValue string) ([]*t
 		"filter": filterText,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error querying catalog items %s", err)
+		return nil, fmt.Errorf("error querying catalog items %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -116,7 +116,7 @@ func queryVappTemplateListWithFilter(client *Client, filter map[string]string) (
 		"filter": filterEncoded,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error querying vApp templates %s", err)
+		return nil, fmt.Errorf("error querying vApp templates %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -233,7 +233,7 @@ func queryCatalogItemFilteredList(client *Client, filter map[string]string) ([]*
 	}
 	results, err := client.cumulativeQuery(catalogItemType, nil, notEncodedParams)
 	if err != nil {
-		return nil, fmt.Errorf("error querying catalog items %s", err)
+		return nil, fmt.Errorf("error querying catalog items %w", err)
 	}
 
 	if client.IsSysAdmin {
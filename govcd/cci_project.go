@@ -0,0 +1,173 @@
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+)
+
+const labelCciProject = "CCI Project"
+
+// cciListPageSize is the number of entities retrieved by each request of the list functions, when
+// the "limit" query parameter is not set
+const cciListPageSize = 100
+
+// CciProject is a Project of the Cloud Consumption Interface (CCI) of VCF Automation. It groups
+// Supervisor Namespaces
+type CciProject struct {
+	CciProject *ccitypes.Project
+	vcdClient  *VCDClient
+}
+
+// CreateCciProject creates a CCI Project. Kind and APIVersion are set when empty
+func (vcdClient *VCDClient) CreateCciProject(config *ccitypes.Project) (*CciProject, error) {
+	if config == nil || config.Name == "" {
+		return nil, fmt.Errorf("%s creation requires a name", labelCciProject)
+	}
+	if config.Kind == "" {
+		config.Kind = ccitypes.ProjectKind
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = ccitypes.ProjectAPI + "/" + ccitypes.ProjectVersion
+	}
+
+	urlRef, err := vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s URL: %w", labelCciProject, err)
+	}
+	result := &CciProject{CciProject: &ccitypes.Project{}, vcdClient: vcdClient}
+	err = vcdClient.Client.PostEntity(urlRef, nil, config, result.CciProject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s %s: %w", labelCciProject, config.Name, err)
+	}
+	return result, nil
+}
+
+// GetAllCciProjects retrieves all CCI Projects, following the pages of the list. The optional
+// queryParameters can contain a "labelSelector" or "fieldSelector", and a "limit" that sets the
+// page size
+func (vcdClient *VCDClient) GetAllCciProjects(queryParameters url.Values) ([]*CciProject, error) {
+	urlRef, err := vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s URL: %w", labelCciProject, err)
+	}
+
+	var results []*CciProject
+	err = getAllCciPages(&vcdClient.Client, urlRef, queryParameters, func(list *ccitypes.ProjectList) string {
+		for i := range list.Items {
+			results = append(results, &CciProject{CciProject: &list.Items[i], vcdClient: vcdClient})
+		}
+		return list.Continue
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all %ss: %w", labelCciProject, err)
+	}
+	return results, nil
+}
+
+// WatchCciProjects sends the changes of CCI Projects to the returned channel until ctx is done.
+// See Client.WatchEntity for the meaning of resourceVersion and for the handling of failures
+func (vcdClient *VCDClient) WatchCciProjects(ctx context.Context, resourceVersion string) (<-chan CciWatchEvent[ccitypes.Project], error) {
+	urlRef, err := vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s URL: %w", labelCciProject, err)
+	}
+	return watchCciEntities[ccitypes.Project](ctx, &vcdClient.Client, urlRef, resourceVersion)
+}
+
+// GetCciProjectByName retrieves a CCI Project by name
+func (vcdClient *VCDClient) GetCciProjectByName(name string) (*CciProject, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%s lookup requires name", labelCciProject)
+	}
+	urlRef, err := vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL, "/", name)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s URL: %w", labelCciProject, err)
+	}
+	result := &CciProject{CciProject: &ccitypes.Project{}, vcdClient: vcdClient}
+	err = vcdClient.Client.GetEntity(urlRef, nil, result.CciProject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s %s: %w", labelCciProject, name, err)
+	}
+	return result, nil
+}
+
+// Update changes the CCI Project. When config has no resource version, the one of the retrieved
+// Project is used, so that the update fails if the Project was changed in the meantime
+func (p *CciProject) Update(config *ccitypes.Project) (*CciProject, error) {
+	if config == nil {
+		return nil, fmt.Errorf("%s update requires a configuration", labelCciProject)
+	}
+	name := p.CciProject.Name
+	if config.Name == "" {
+		config.Name = name
+	}
+	if config.ResourceVersion == "" {
+		config.ResourceVersion = p.CciProject.ResourceVersion
+	}
+	if config.Kind == "" {
+		config.Kind = ccitypes.ProjectKind
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = ccitypes.ProjectAPI + "/" + ccitypes.ProjectVersion
+	}
+
+	urlRef, err := p.vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL, "/", name)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s URL: %w", labelCciProject, err)
+	}
+	result := &CciProject{CciProject: &ccitypes.Project{}, vcdClient: p.vcdClient}
+	err = p.vcdClient.Client.PutEntity(urlRef, nil, config, result.CciProject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating %s %s: %w", labelCciProject, name, err)
+	}
+	return result, nil
+}
+
+// Delete removes the CCI Project. Its Supervisor Namespaces must be deleted first
+func (p *CciProject) Delete() error {
+	urlRef, err := p.vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL, "/", p.CciProject.Name)
+	if err != nil {
+		return fmt.Errorf("error building %s URL: %w", labelCciProject, err)
+	}
+	err = p.vcdClient.Client.DeleteEntity(urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s %s: %w", labelCciProject, p.CciProject.Name, err)
+	}
+	return nil
+}
+
+// getAllCciPages retrieves all the pages of a Kubernetes style list. addPage collects the items of
+// a page and returns its continue token, which is empty for the last page
+func getAllCciPages[L any](client *Client, urlRef *url.URL, queryParameters url.Values, addPage func(*L) string) error {
+	params := url.Values{}
+	for key, values := range queryParameters {
+		params[key] = values
+	}
+	if params.Get("limit") == "" {
+		params.Set("limit", strconv.Itoa(cciListPageSize))
+	}
+
+	continueToken := ""
+	for {
+		if continueToken != "" {
+			params.Set("continue", continueToken)
+		}
+		page := new(L)
+		err := client.GetEntity(urlRef, params, page, nil)
+		if err != nil {
+			return err
+		}
+		nextToken := addPage(page)
+		if nextToken == "" {
+			return nil
+		}
+		if nextToken == continueToken {
+			return fmt.Errorf("the list returned the same continue token twice")
+		}
+		continueToken = nextToken
+	}
+}
@@ -0,0 +1,261 @@
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+const labelSupervisorNamespace = "Supervisor Namespace"
+
+// cciStatePollInterval is the time between two checks of the state of a Supervisor Namespace
+var cciStatePollInterval = 10 * time.Second
+
+// SupervisorNamespace is a Supervisor Namespace of a CCI Project
+type SupervisorNamespace struct {
+	SupervisorNamespace *ccitypes.SupervisorNamespace
+	vcdClient           *VCDClient
+}
+
+// supervisorNamespaceUrl returns the URL of the Supervisor Namespaces of a CCI Project or, when
+// name is given, of a single Supervisor Namespace
+func (vcdClient *VCDClient) supervisorNamespaceUrl(projectName, name string) (*url.URL, error) {
+	if projectName == "" {
+		return nil, fmt.Errorf("%s requires a Project name", labelSupervisorNamespace)
+	}
+	endpoint := []string{fmt.Sprintf(ccitypes.SupervisorNamespacesURL, projectName)}
+	if name != "" {
+		endpoint = append(endpoint, "/", name)
+	}
+	urlRef, err := vcdClient.Client.GetEntityUrl(endpoint...)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s URL: %w", labelSupervisorNamespace, err)
+	}
+	return urlRef, nil
+}
+
+// CreateSupervisorNamespace creates a Supervisor Namespace in a CCI Project. The namespace is
+// created asynchronously: use WaitForSupervisorNamespaceReady to wait until it can be used.
+// config must have either a name or a GenerateName prefix. Kind, APIVersion and Namespace are set
+// when empty
+func (vcdClient *VCDClient) CreateSupervisorNamespace(projectName string, config *ccitypes.SupervisorNamespace) (*SupervisorNamespace, error) {
+	if config == nil || (config.Name == "" && config.GenerateName == "") {
+		return nil, fmt.Errorf("%s creation requires a name or a generate name", labelSupervisorNamespace)
+	}
+	if config.Kind == "" {
+		config.Kind = ccitypes.SupervisorNamespaceKind
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = ccitypes.SupervisorNamespaceAPI + "/" + ccitypes.SupervisorNamespaceVersion
+	}
+	if config.Namespace == "" {
+		config.Namespace = projectName
+	}
+
+	urlRef, err := vcdClient.supervisorNamespaceUrl(projectName, "")
+	if err != nil {
+		return nil, err
+	}
+	result := &SupervisorNamespace{SupervisorNamespace: &ccitypes.SupervisorNamespace{}, vcdClient: vcdClient}
+	err = vcdClient.Client.PostEntity(urlRef, nil, config, result.SupervisorNamespace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s %s%s: %w", labelSupervisorNamespace, config.Name, config.GenerateName, err)
+	}
+	return result, nil
+}
+
+// GetAllSupervisorNamespaces retrieves all Supervisor Namespaces of a CCI Project, following the
+// pages of the list. The optional queryParameters can contain a "labelSelector" or
+// "fieldSelector", and a "limit" that sets the page size
+func (vcdClient *VCDClient) GetAllSupervisorNamespaces(projectName string, queryParameters url.Values) ([]*SupervisorNamespace, error) {
+	urlRef, err := vcdClient.supervisorNamespaceUrl(projectName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*SupervisorNamespace
+	err = getAllCciPages(&vcdClient.Client, urlRef, queryParameters, func(list *ccitypes.SupervisorNamespaceList) string {
+		for i := range list.Items {
+			results = append(results, &SupervisorNamespace{SupervisorNamespace: &list.Items[i], vcdClient: vcdClient})
+		}
+		return list.Continue
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all %ss of Project %s: %w", labelSupervisorNamespace, projectName, err)
+	}
+	return results, nil
+}
+
+// GetSupervisorNamespaceByName retrieves a Supervisor Namespace of a CCI Project by name
+func (vcdClient *VCDClient) GetSupervisorNamespaceByName(projectName, name string) (*SupervisorNamespace, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%s lookup requires name", labelSupervisorNamespace)
+	}
+	urlRef, err := vcdClient.supervisorNamespaceUrl(projectName, name)
+	if err != nil {
+		return nil, err
+	}
+	result := &SupervisorNamespace{SupervisorNamespace: &ccitypes.SupervisorNamespace{}, vcdClient: vcdClient}
+	err = vcdClient.Client.GetEntity(urlRef, nil, result.SupervisorNamespace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s %s: %w", labelSupervisorNamespace, name, err)
+	}
+	return result, nil
+}
+
+// WatchSupervisorNamespaces sends the changes of the Supervisor Namespaces of a CCI Project to the
+// returned channel until ctx is done. See Client.WatchEntity for the meaning of resourceVersion and
+// for the handling of failures
+func (vcdClient *VCDClient) WatchSupervisorNamespaces(ctx context.Context, projectName, resourceVersion string) (<-chan CciWatchEvent[ccitypes.SupervisorNamespace], error) {
+	urlRef, err := vcdClient.supervisorNamespaceUrl(projectName, "")
+	if err != nil {
+		return nil, err
+	}
+	return watchCciEntities[ccitypes.SupervisorNamespace](ctx, &vcdClient.Client, urlRef, resourceVersion)
+}
+
+// WaitForSupervisorNamespaceReady waits until the Supervisor Namespace is ready, checking its
+// status conditions every few seconds, and returns it. It returns an error when a condition reports
+// an error, when the namespace is in the ERROR phase, or when timeout expires
+func (vcdClient *VCDClient) WaitForSupervisorNamespaceReady(projectName, name string, timeout time.Duration) (*SupervisorNamespace, error) {
+	endTime := time.Now().Add(timeout)
+	for attempt := 1; ; attempt++ {
+		supervisorNamespace, err := vcdClient.GetSupervisorNamespaceByName(projectName, name)
+		if err != nil {
+			return nil, err
+		}
+		ready, err := supervisorNamespace.IsReady()
+		if err != nil {
+			return supervisorNamespace, err
+		}
+		if ready {
+			return supervisorNamespace, nil
+		}
+		util.Logger.Printf("[DEBUG] %s %s is not ready at attempt %d: %s", labelSupervisorNamespace, name, attempt,
+			supervisorNamespace.statusSummary())
+		if time.Now().Add(cciStatePollInterval).After(endTime) {
+			return supervisorNamespace, fmt.Errorf("timeout of %s reached waiting for %s %s to be ready: %s",
+				timeout, labelSupervisorNamespace, name, supervisorNamespace.statusSummary())
+		}
+		time.Sleep(cciStatePollInterval)
+	}
+}
+
+// WaitForSupervisorNamespaceDeleted waits until the Supervisor Namespace no longer exists
+func (vcdClient *VCDClient) WaitForSupervisorNamespaceDeleted(projectName, name string, timeout time.Duration) error {
+	endTime := time.Now().Add(timeout)
+	for {
+		supervisorNamespace, err := vcdClient.GetSupervisorNamespaceByName(projectName, name)
+		if ContainsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if time.Now().Add(cciStatePollInterval).After(endTime) {
+			return fmt.Errorf("timeout of %s reached waiting for the deletion of %s %s: %s",
+				timeout, labelSupervisorNamespace, name, supervisorNamespace.statusSummary())
+		}
+		time.Sleep(cciStatePollInterval)
+	}
+}
+
+// IsReady checks the status of the Supervisor Namespace. It returns true when the condition of type
+// "Ready" is true or, for namespaces that report no conditions, when the phase is CREATED. It returns
+// an error when a condition with severity "Error" is false, or when the phase is ERROR
+func (ns *SupervisorNamespace) IsReady() (bool, error) {
+	status := ns.SupervisorNamespace.Status
+	if status == nil {
+		return false, nil
+	}
+	if strings.EqualFold(status.Phase, "ERROR") {
+		return false, fmt.Errorf("%s %s is in ERROR phase: %s", labelSupervisorNamespace, ns.SupervisorNamespace.Name, ns.statusSummary())
+	}
+	readyFound := false
+	for _, condition := range status.Conditions {
+		if strings.EqualFold(condition.Status, "False") && strings.EqualFold(condition.Severity, "Error") {
+			return false, fmt.Errorf("%s %s condition %s failed: %s %s", labelSupervisorNamespace,
+				ns.SupervisorNamespace.Name, condition.Type, condition.Reason, condition.Message)
+		}
+		if strings.EqualFold(condition.Type, "Ready") {
+			readyFound = true
+			if !strings.EqualFold(condition.Status, "True") {
+				return false, nil
+			}
+		}
+	}
+	if readyFound {
+		return true, nil
+	}
+	return len(status.Conditions) == 0 && strings.EqualFold(status.Phase, "CREATED"), nil
+}
+
+// statusSummary returns the phase and the conditions of the Supervisor Namespace that are not true
+func (ns *SupervisorNamespace) statusSummary() string {
+	status := ns.SupervisorNamespace.Status
+	if status == nil {
+		return "no status"
+	}
+	summary := []string{"phase " + status.Phase}
+	for _, condition := range status.Conditions {
+		if !strings.EqualFold(condition.Status, "True") {
+			summary = append(summary, fmt.Sprintf("%s=%s (%s)", condition.Type, condition.Status, condition.Reason))
+		}
+	}
+	return strings.Join(summary, ", ")
+}
+
+// Update changes the Supervisor Namespace. When config has no resource version, the one of the
+// retrieved namespace is used, so that the update fails if the namespace was changed in the meantime
+func (ns *SupervisorNamespace) Update(config *ccitypes.SupervisorNamespace) (*SupervisorNamespace, error) {
+	if config == nil {
+		return nil, fmt.Errorf("%s update requires a configuration", labelSupervisorNamespace)
+	}
+	name := ns.SupervisorNamespace.Name
+	projectName := ns.SupervisorNamespace.Namespace
+	if config.Name == "" {
+		config.Name = name
+	}
+	if config.Namespace == "" {
+		config.Namespace = projectName
+	}
+	if config.ResourceVersion == "" {
+		config.ResourceVersion = ns.SupervisorNamespace.ResourceVersion
+	}
+	if config.Kind == "" {
+		config.Kind = ccitypes.SupervisorNamespaceKind
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = ccitypes.SupervisorNamespaceAPI + "/" + ccitypes.SupervisorNamespaceVersion
+	}
+
+	urlRef, err := ns.vcdClient.supervisorNamespaceUrl(projectName, name)
+	if err != nil {
+		return nil, err
+	}
+	result := &SupervisorNamespace{SupervisorNamespace: &ccitypes.SupervisorNamespace{}, vcdClient: ns.vcdClient}
+	err = ns.vcdClient.Client.PutEntity(urlRef, nil, config, result.SupervisorNamespace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating %s %s: %w", labelSupervisorNamespace, name, err)
+	}
+	return result, nil
+}
+
+// Delete starts the deletion of the Supervisor Namespace. Use WaitForSupervisorNamespaceDeleted to
+// wait until it is removed
+func (ns *SupervisorNamespace) Delete() error {
+	urlRef, err := ns.vcdClient.supervisorNamespaceUrl(ns.SupervisorNamespace.Namespace, ns.SupervisorNamespace.Name)
+	if err != nil {
+		return err
+	}
+	err = ns.vcdClient.Client.DeleteEntity(urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s %s: %w", labelSupervisorNamespace, ns.SupervisorNamespace.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVCDClient_CciProject(t *testing.T) {
+	_, vcdClient := testServerClient(t)
+
+	for _, name := range []string{"project1", "project2", "project3"} {
+		project, err := vcdClient.CreateCciProject(&ccitypes.Project{ObjectMeta: v1.ObjectMeta{Name: name}})
+		if err != nil {
+			t.Fatalf("error creating project %s: %s", name, err)
+		}
+		if project.CciProject.Kind != ccitypes.ProjectKind || project.CciProject.ResourceVersion == "" {
+			t.Errorf("expected kind and resource version to be set, got %#v", project.CciProject.TypeMeta)
+		}
+	}
+
+	projects, err := vcdClient.GetAllCciProjects(url.Values{"limit": []string{"1"}})
+	if err != nil {
+		t.Fatalf("error retrieving projects: %s", err)
+	}
+	if len(projects) != 3 || projects[2].CciProject.Name != "project3" {
+		t.Errorf("expected 3 projects from 3 pages, got %d", len(projects))
+	}
+
+	project, err := vcdClient.GetCciProjectByName("project2")
+	if err != nil {
+		t.Fatalf("error retrieving project: %s", err)
+	}
+	_, err = vcdClient.GetCciProjectByName("missing")
+	if !ContainsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	updated, err := project.Update(&ccitypes.Project{Spec: ccitypes.ProjectSpec{Description: "updated"}})
+	if err != nil {
+		t.Fatalf("error updating project: %s", err)
+	}
+	if updated.CciProject.Spec.Description != "updated" || updated.CciProject.Name != "project2" {
+		t.Errorf("unexpected updated project %#v", updated.CciProject)
+	}
+	// The old resource version is rejected
+	_, err = project.Update(&ccitypes.Project{Spec: ccitypes.ProjectSpec{Description: "stale"}})
+	if err == nil || !strings.Contains(err.Error(), "Conflict") {
+		t.Errorf("expected a conflict updating a stale project, got %v", err)
+	}
+
+	err = updated.Delete()
+	if err != nil {
+		t.Fatalf("error deleting project: %s", err)
+	}
+	_, err = vcdClient.GetCciProjectByName("project2")
+	if !ContainsNotFound(err) {
+		t.Errorf("expected the project to be deleted, got %v", err)
+	}
+}
+
+func TestVCDClient_SupervisorNamespace(t *testing.T) {
+	server, vcdClient := testServerClient(t)
+	defer func(interval time.Duration) { cciStatePollInterval = interval }(cciStatePollInterval)
+	cciStatePollInterval = time.Millisecond
+
+	_, err := vcdClient.CreateCciProject(&ccitypes.Project{ObjectMeta: v1.ObjectMeta{Name: "project1"}})
+	if err != nil {
+		t.Fatalf("error creating project: %s", err)
+	}
+	_, err = vcdClient.CreateSupervisorNamespace("project1", &ccitypes.SupervisorNamespace{})
+	if err == nil {
+		t.Errorf("expected an error creating a namespace without name")
+	}
+	namespace, err := vcdClient.CreateSupervisorNamespace("project1", &ccitypes.SupervisorNamespace{
+		ObjectMeta: v1.ObjectMeta{GenerateName: "ns-"},
+		Spec:       ccitypes.SupervisorNamespaceSpec{ClassName: "small"},
+	})
+	if err != nil {
+		t.Fatalf("error creating namespace: %s", err)
+	}
+	name := namespace.SupervisorNamespace.Name
+	if !strings.HasPrefix(name, "ns-") || namespace.SupervisorNamespace.Namespace != "project1" {
+		t.Errorf("unexpected namespace %s in %s", name, namespace.SupervisorNamespace.Namespace)
+	}
+
+	ready, err := vcdClient.WaitForSupervisorNamespaceReady("project1", name, time.Minute)
+	if err != nil {
+		t.Fatalf("error waiting for namespace: %s", err)
+	}
+	if ready.SupervisorNamespace.Status.Phase != "CREATED" {
+		t.Errorf("expected the namespace to be created, got phase %s", ready.SupervisorNamespace.Status.Phase)
+	}
+
+	namespaces, err := vcdClient.GetAllSupervisorNamespaces("project1", nil)
+	if err != nil || len(namespaces) != 1 {
+		t.Errorf("expected one namespace, got %d and error %v", len(namespaces), err)
+	}
+
+	err = server.SetSupervisorNamespaceStatus("project1", name, ccitypes.SupervisorNamespaceStatus{
+		Phase:      "CREATING",
+		Conditions: []ccitypes.SupervisorNamespaceStatusConditions{{Type: "Realized", Status: "False", Severity: "Error", Reason: "NoCapacity"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = vcdClient.WaitForSupervisorNamespaceReady("project1", name, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "NoCapacity") {
+		t.Errorf("expected an error condition, got %v", err)
+	}
+	err = server.SetSupervisorNamespaceStatus("project1", name, ccitypes.SupervisorNamespaceStatus{
+		Phase:      "CREATING",
+		Conditions: []ccitypes.SupervisorNamespaceStatusConditions{{Type: "Ready", Status: "False", Reason: "Creating"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = vcdClient.WaitForSupervisorNamespaceReady("project1", name, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+
+	err = ready.Delete()
+	if err != nil {
+		t.Fatalf("error deleting namespace: %s", err)
+	}
+	err = vcdClient.WaitForSupervisorNamespaceDeleted("project1", name, time.Minute)
+	if err != nil {
+		t.Errorf("error waiting for deletion: %s", err)
+	}
+}
@@ -285,7 +285,7 @@ func (certificate *Certificate) Update() (*Certificate, error) {
 	err = certificate.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, certificate.CertificateLibrary,
 		returnCertificate.CertificateLibrary, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating certificate: %s", err)
+		return nil, fmt.Errorf("error updating certificate: %w", err)
 	}
 
 	return returnCertificate, nil
@@ -314,7 +314,7 @@ func (certificate *Certificate) Delete() error {
 	err = certificate.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting certificate: %s", err)
+		return fmt.Errorf("error deleting certificate: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,52 @@
+package govcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigurationChangeAction is the type of a ConfigurationChange
+type ConfigurationChangeAction string
+
+const (
+	ConfigurationChangeCreate  ConfigurationChangeAction = "create"
+	ConfigurationChangeUpdate  ConfigurationChangeAction = "update"
+	ConfigurationChangeDelete  ConfigurationChangeAction = "delete"
+	ConfigurationChangeReorder ConfigurationChangeAction = "reorder"
+)
+
+// ConfigurationChange is a single change made, or to be made in a dry run, by functions which apply
+// a configuration document to existing entities, such as NsxtEdgeGateway.ImportFirewallPolicy,
+// NsxtEdgeGateway.ApplyNsxvMigrationPlan and NsxtEdgeGateway.Restore
+type ConfigurationChange struct {
+	Action ConfigurationChangeAction
+	// Kind is the label of the changed entity, e.g. "NSX-T Firewall Group"
+	Kind string
+	// Name is the name of the changed entity. It is empty for ConfigurationChangeReorder
+	Name string
+	// Fields contains the JSON names of the changed fields of a ConfigurationChangeUpdate
+	Fields []string
+}
+
+// String returns the change as a line of a diff, e.g. "~ NSX-T Firewall Group web (ipAddresses)"
+func (change ConfigurationChange) String() string {
+	switch change.Action {
+	case ConfigurationChangeCreate:
+		return fmt.Sprintf("+ %s %s", change.Kind, change.Name)
+	case ConfigurationChangeDelete:
+		return fmt.Sprintf("- %s %s", change.Kind, change.Name)
+	case ConfigurationChangeReorder:
+		return fmt.Sprintf("~ order of %ss", change.Kind)
+	default:
+		return fmt.Sprintf("~ %s %s (%s)", change.Kind, change.Name, strings.Join(change.Fields, ", "))
+	}
+}
+
+// configurationChangesString returns the changes as a diff, one line per change
+func configurationChangesString(changes []ConfigurationChange) string {
+	lines := make([]string, len(changes))
+	for index, change := range changes {
+		lines[index] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
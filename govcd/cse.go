@@ -46,7 +46,7 @@ func (org *Org) CseCreateKubernetesClusterAsync(clusterSettings CseClusterSettin
 
 	tenantContext, err := org.getTenantContext()
 	if err != nil {
-		return "", fmt.Errorf("error creating the CSE Kubernetes cluster: %s", err)
+		return "", fmt.Errorf("error creating the CSE Kubernetes cluster: %w", err)
 	}
 
 	cseSubcomponents, err := getCseComponentsVersions(clusterSettings.CseVersion)
@@ -56,7 +56,7 @@ func (org *Org) CseCreateKubernetesClusterAsync(clusterSettings CseClusterSettin
 
 	internalSettings, err := clusterSettings.toCseClusterSettingsInternal(*org)
 	if err != nil {
-		return "", fmt.Errorf("error creating the CSE Kubernetes cluster: %s", err)
+		return "", fmt.Errorf("error creating the CSE Kubernetes cluster: %w", err)
 	}
 
 	payload, err := internalSettings.getUnmarshalledRdePayload()
@@ -71,7 +71,7 @@ func (org *Org) CseCreateKubernetesClusterAsync(clusterSettings CseClusterSettin
 			Entity:     payload,
 		}, tenantContext)
 	if err != nil {
-		return "", fmt.Errorf("error creating the CSE Kubernetes cluster: %s", err)
+		return "", fmt.Errorf("error creating the CSE Kubernetes cluster: %w", err)
 	}
 
 	return rde.DefinedEntity.ID, nil
@@ -121,7 +121,7 @@ func getCseKubernetesClusterById(client *Client, clusterId string) (*CseKubernet
 func (cluster *CseKubernetesCluster) Refresh() error {
 	refreshed, err := getCseKubernetesClusterById(cluster.client, cluster.ID)
 	if err != nil {
-		return fmt.Errorf("failed refreshing the CSE Kubernetes Cluster: %s", err)
+		return fmt.Errorf("failed refreshing the CSE Kubernetes Cluster: %w", err)
 	}
 	*cluster = *refreshed
 	return nil
@@ -163,7 +163,7 @@ func (cluster *CseKubernetesCluster) GetKubeconfig(refresh bool) (string, error)
 
 	err = rde.InvokeBehaviorAndMarshal(fmt.Sprintf("urn:vcloud:behavior-interface:getFullEntity:cse:capvcd:%s", versions.CseInterfaceVersion), types.BehaviorInvocation{}, &result)
 	if err != nil {
-		return "", fmt.Errorf("could not retrieve the Kubeconfig, the Behavior invocation failed: %s", err)
+		return "", fmt.Errorf("could not retrieve the Kubeconfig, the Behavior invocation failed: %w", err)
 	}
 	if result.Capvcd.Status.Capvcd.Private == nil {
 		return "", fmt.Errorf("could not retrieve the Kubeconfig, the Behavior invocation succeeded but the Kubeconfig is nil")
@@ -220,7 +220,7 @@ func (cluster *CseKubernetesCluster) GetSupportedUpgrades(refreshOvas bool) ([]*
 
 	vAppTemplates, err := queryVappTemplateListWithFilter(cluster.client, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not get vApp Templates: %s", err)
+		return nil, fmt.Errorf("could not get vApp Templates: %w", err)
 	}
 	for _, template := range vAppTemplates {
 		// We can only know if the vApp Template is a TKGm OVA by inspecting its internals, hence we need to retrieve every one
@@ -362,7 +362,7 @@ func (cluster *CseKubernetesCluster) Delete(timeout time.Duration) error {
 			if ContainsNotFound(err) {
 				return nil // The RDE is gone, so the process is completed and there's nothing more to do
 			}
-			return fmt.Errorf("could not retrieve the Kubernetes cluster with ID '%s': %s", cluster.ID, err)
+			return fmt.Errorf("could not retrieve the Kubernetes cluster with ID '%s': %w", cluster.ID, err)
 		}
 
 		markForDelete = traverseMapAndGet[bool](rde.DefinedEntity.Entity, "spec.vcdKe.markForDelete", ".")
@@ -376,7 +376,7 @@ func (cluster *CseKubernetesCluster) Delete(timeout time.Duration) error {
 			if err != nil {
 				// We ignore any ETag error. This just means a clash with the CSE Server, we just try again
 				if !strings.Contains(strings.ToLower(err.Error()), "etag") {
-					return fmt.Errorf("could not mark the Kubernetes cluster with ID '%s' to be deleted: %s", cluster.ID, err)
+					return fmt.Errorf("could not mark the Kubernetes cluster with ID '%s' to be deleted: %w", cluster.ID, err)
 				}
 			}
 		}
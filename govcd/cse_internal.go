@@ -55,13 +55,13 @@ func (clusterSettings *cseClusterSettingsInternal) getUnmarshalledRdePayload() (
 	rdePayload := template.Must(template.New(clusterSettings.Name).Parse(rdeTemplate))
 	buf := &bytes.Buffer{}
 	if err := rdePayload.Execute(buf, templateArgs); err != nil {
-		return nil, fmt.Errorf("could not render the Go template with the RDE JSON: %s", err)
+		return nil, fmt.Errorf("could not render the Go template with the RDE JSON: %w", err)
 	}
 
 	var result interface{}
 	err = json.Unmarshal(buf.Bytes(), &result)
 	if err != nil {
-		return nil, fmt.Errorf("could not generate a correct RDE payload: %s", err)
+		return nil, fmt.Errorf("could not generate a correct RDE payload: %w", err)
 	}
 
 	return result.(map[string]interface{}), nil
@@ -139,7 +139,7 @@ func (clusterSettings *cseClusterSettingsInternal) generateCapiYamlAsJsonString(
 
 	buf := &bytes.Buffer{}
 	if err := capiYaml.Execute(buf, templateArgs); err != nil {
-		return "", fmt.Errorf("could not generate a correct CAPI YAML: %s", err)
+		return "", fmt.Errorf("could not generate a correct CAPI YAML: %w", err)
 	}
 
 	// The final "pretty" YAML. To embed it in the final payload it must be marshaled into a one-line JSON string
@@ -158,7 +158,7 @@ func (clusterSettings *cseClusterSettingsInternal) generateCapiYamlAsJsonString(
 	enc.SetEscapeHTML(false)
 	err = enc.Encode(prettyYaml)
 	if err != nil {
-		return "", fmt.Errorf("could not encode the CAPI YAML into a JSON string: %s", err)
+		return "", fmt.Errorf("could not encode the CAPI YAML into a JSON string: %w", err)
 	}
 
 	// Removes trailing quotes from the final JSON string
@@ -216,7 +216,7 @@ func (clusterSettings *cseClusterSettingsInternal) generateWorkerPoolsYaml() (st
 		}
 
 		if err := workerPools.Execute(buf, args); err != nil {
-			return "", fmt.Errorf("could not generate a correct Worker Pool '%s' YAML block: %s", wp.Name, err)
+			return "", fmt.Errorf("could not generate a correct Worker Pool '%s' YAML block: %w", wp.Name, err)
 		}
 		resultYaml += fmt.Sprintf("%s\n", buf.String())
 		if i < len(clusterSettings.WorkerPools)-1 {
@@ -259,7 +259,7 @@ func (clusterSettings *cseClusterSettingsInternal) generateMachineHealthCheckYam
 		"NodeUnknownTimeout":  fmt.Sprintf("%ss", strings.ReplaceAll(clusterSettings.VcdKeConfig.NodeUnknownTimeout, "s", "")),
 		"NodeNotReadyTimeout": fmt.Sprintf("%ss", strings.ReplaceAll(clusterSettings.VcdKeConfig.NodeNotReadyTimeout, "s", "")),
 	}); err != nil {
-		return "", fmt.Errorf("could not generate a correct Machine Health Check YAML: %s", err)
+		return "", fmt.Errorf("could not generate a correct Machine Health Check YAML: %w", err)
 	}
 	return fmt.Sprintf("%s\n", buf.String()), nil
 
@@ -291,7 +291,7 @@ func (clusterSettings *cseClusterSettingsInternal) generateAutoscalerYaml() (str
 		"AutoscalerReplicas": "1",
 		"AutoscalerVersion":  fmt.Sprintf("v%d.%d.0", k8sVersionSegments[0], k8sVersionSegments[1]), // Autoscaler version matches the Kubernetes minor
 	}); err != nil {
-		return "", fmt.Errorf("could not generate a correct Autoscaler YAML block: %s", err)
+		return "", fmt.Errorf("could not generate a correct Autoscaler YAML block: %w", err)
 	}
 	resultYaml += fmt.Sprintf("%s\n", buf.String())
 
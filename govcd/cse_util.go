@@ -65,13 +65,13 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 
 	entityBytes, err := json.Marshal(rde.DefinedEntity.Entity)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal the RDE contents to create a capvcdType instance: %s", err)
+		return nil, fmt.Errorf("could not marshal the RDE contents to create a capvcdType instance: %w", err)
 	}
 
 	capvcd := &types.Capvcd{}
 	err = json.Unmarshal(entityBytes, &capvcd)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal the RDE contents to create a Capvcd instance: %s", err)
+		return nil, fmt.Errorf("could not unmarshal the RDE contents to create a Capvcd instance: %w", err)
 	}
 
 	result := &CseKubernetesCluster{
@@ -199,7 +199,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	if capvcd.Status.Capvcd.CapvcdVersion != "" {
 		version, err := semver.NewVersion(capvcd.Status.Capvcd.CapvcdVersion)
 		if err != nil {
-			return nil, fmt.Errorf("could not read Capvcd version: %s", err)
+			return nil, fmt.Errorf("could not read Capvcd version: %w", err)
 		}
 		result.CapvcdVersion = *version
 	}
@@ -207,7 +207,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	if capvcd.Status.Cpi.Version != "" {
 		version, err := semver.NewVersion(strings.TrimSpace(capvcd.Status.Cpi.Version)) // Note: We use trim as the version comes with spacing characters
 		if err != nil {
-			return nil, fmt.Errorf("could not read CPI version: %s", err)
+			return nil, fmt.Errorf("could not read CPI version: %w", err)
 		}
 		result.CpiVersion = *version
 	}
@@ -215,7 +215,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	if capvcd.Status.Csi.Version != "" {
 		version, err := semver.NewVersion(capvcd.Status.Csi.Version)
 		if err != nil {
-			return nil, fmt.Errorf("could not read CSI version: %s", err)
+			return nil, fmt.Errorf("could not read CSI version: %w", err)
 		}
 		result.CsiVersion = *version
 	}
@@ -223,14 +223,14 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	if capvcd.Status.VcdKe.VcdKeVersion != "" {
 		cseVersion, err := semver.NewVersion(capvcd.Status.VcdKe.VcdKeVersion)
 		if err != nil {
-			return nil, fmt.Errorf("could not read the CSE Version that the cluster uses: %s", err)
+			return nil, fmt.Errorf("could not read the CSE Version that the cluster uses: %w", err)
 		}
 		// Remove the possible version suffixes as we just want MAJOR.MINOR.PATCH
 		// TODO: This can be replaced with (*cseVersion).Core() in newer versions of the library
 		cseVersionSegs := (*cseVersion).Segments()
 		cseVersion, err = semver.NewVersion(fmt.Sprintf("%d.%d.%d", cseVersionSegs[0], cseVersionSegs[1], cseVersionSegs[2]))
 		if err != nil {
-			return nil, fmt.Errorf("could not read the CSE Version that the cluster uses: %s", err)
+			return nil, fmt.Errorf("could not read the CSE Version that the cluster uses: %w", err)
 		}
 		result.CseVersion = *cseVersion
 	}
@@ -268,7 +268,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	if result.VdcId == result.capvcdType.Status.Capvcd.VcdProperties.OrgVdcs[0].Name {
 		vdcs, err := queryOrgVdcList(rde.client, map[string]string{})
 		if err != nil {
-			return nil, fmt.Errorf("could not get VDC IDs as no VDC was found: %s", err)
+			return nil, fmt.Errorf("could not get VDC IDs as no VDC was found: %w", err)
 		}
 		found := false
 		for _, vdc := range vdcs {
@@ -290,7 +290,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	params = queryParameterFilterAnd("_context==includeAccessible", params)
 	networks, err := getAllOpenApiOrgVdcNetworks(rde.client, params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not read Org VDC Network from Capvcd type: %s", err)
+		return nil, fmt.Errorf("could not read Org VDC Network from Capvcd type: %w", err)
 	}
 	if len(networks) != 1 {
 		return nil, fmt.Errorf("expected one Org VDC Network from Capvcd type, but got %d", len(networks))
@@ -302,7 +302,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	if rde.client.IsSysAdmin {
 		allSp, err := queryAdminOrgVdcStorageProfilesByVdcId(rde.client, result.VdcId)
 		if err != nil {
-			return nil, fmt.Errorf("could not get all the Storage Profiles: %s", err)
+			return nil, fmt.Errorf("could not get all the Storage Profiles: %w", err)
 		}
 		for _, recordType := range allSp {
 			storageProfiles[recordType.Name] = fmt.Sprintf("urn:vcloud:vdcstorageProfile:%s", extractUuid(recordType.HREF))
@@ -310,7 +310,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 	} else {
 		allSp, err := queryOrgVdcStorageProfilesByVdcId(rde.client, result.VdcId)
 		if err != nil {
-			return nil, fmt.Errorf("could not get all the Storage Profiles: %s", err)
+			return nil, fmt.Errorf("could not get all the Storage Profiles: %w", err)
 		}
 		for _, recordType := range allSp {
 			storageProfiles[recordType.Name] = fmt.Sprintf("urn:vcloud:vdcstorageProfile:%s", extractUuid(recordType.HREF))
@@ -319,7 +319,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 
 	computePolicies, err := getAllVdcComputePoliciesV2(rde.client, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not get all the Compute Policies: %s", err)
+		return nil, fmt.Errorf("could not get all the Compute Policies: %w", err)
 	}
 
 	if result.capvcdType.Spec.VcdKe.DefaultStorageClassOptions.K8SStorageClassName != "" { // This would mean there is a Default Storage Class defined
@@ -365,7 +365,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 
 			version, err := semver.NewVersion(traverseMapAndGet[string](yamlDocument, "spec.version", "."))
 			if err != nil {
-				return nil, fmt.Errorf("could not read Kubernetes version: %s", err)
+				return nil, fmt.Errorf("could not read Kubernetes version: %w", err)
 			}
 			result.KubernetesVersion = *version
 
@@ -404,7 +404,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 					"name":        vAppTemplateName,
 				})
 				if err != nil {
-					return nil, fmt.Errorf("could not find any vApp Template with name '%s' in Catalog '%s': %s", vAppTemplateName, catalogName, err)
+					return nil, fmt.Errorf("could not find any vApp Template with name '%s' in Catalog '%s': %w", vAppTemplateName, catalogName, err)
 				}
 				if len(vAppTemplates) == 0 {
 					return nil, fmt.Errorf("could not find any vApp Template with name '%s' in Catalog '%s'", vAppTemplateName, catalogName)
@@ -454,11 +454,11 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 			if autoscalerMax != "" && autoscalerMin != "" {
 				maxSize, err := strconv.Atoi(autoscalerMax)
 				if err != nil {
-					return nil, fmt.Errorf("error reading Autoscaler max size for pool '%s': %s", name, err)
+					return nil, fmt.Errorf("error reading Autoscaler max size for pool '%s': %w", name, err)
 				}
 				minSize, err := strconv.Atoi(autoscalerMin)
 				if err != nil {
-					return nil, fmt.Errorf("error reading Autoscaler min size for pool '%s': %s", name, err)
+					return nil, fmt.Errorf("error reading Autoscaler min size for pool '%s': %w", name, err)
 				}
 				workerPool.Autoscaler = &CseWorkerPoolAutoscaler{
 					MaxSize: maxSize,
@@ -471,7 +471,7 @@ func cseConvertToCseKubernetesClusterType(rde *DefinedEntity) (*CseKubernetesClu
 		case "Cluster":
 			version, err := semver.NewVersion(traverseMapAndGet[string](yamlDocument, "metadata.annotations.TKGVERSION", "."))
 			if err != nil {
-				return nil, fmt.Errorf("could not read TKG version: %s", err)
+				return nil, fmt.Errorf("could not read TKG version: %w", err)
 			}
 			result.TkgVersion = *version
 
@@ -521,11 +521,11 @@ func waitUntilClusterIsProvisioned(client *Client, clusterId string, timeout tim
 		// Here we don't use cseConvertToCseKubernetesClusterType to avoid calling VCD. We only need the state.
 		entityBytes, err := json.Marshal(rde.DefinedEntity.Entity)
 		if err != nil {
-			return fmt.Errorf("could not check the Kubernetes cluster state: %s", err)
+			return fmt.Errorf("could not check the Kubernetes cluster state: %w", err)
 		}
 		err = json.Unmarshal(entityBytes, &capvcd)
 		if err != nil {
-			return fmt.Errorf("could not check the Kubernetes cluster state: %s", err)
+			return fmt.Errorf("could not check the Kubernetes cluster state: %w", err)
 		}
 
 		switch capvcd.Status.VcdKe.State {
@@ -560,7 +560,7 @@ func (input *CseClusterSettings) validate() error {
 	// Names must contain only lowercase alphanumeric characters or '-', start with an alphabetic character, end with an alphanumeric, and contain at most 31 characters.
 	cseNamesRegex, err := regexp.Compile(`^[a-z](?:[a-z0-9-]{0,29}[a-z0-9])?$`)
 	if err != nil {
-		return fmt.Errorf("could not compile regular expression '%s'", err)
+		return fmt.Errorf("could not compile regular expression '%w'", err)
 	}
 
 	_, err = getCseComponentsVersions(input.CseVersion)
@@ -642,17 +642,17 @@ func (input *CseClusterSettings) validate() error {
 		return fmt.Errorf("the Pod CIDR is required")
 	}
 	if _, _, err := net.ParseCIDR(input.PodCidr); err != nil {
-		return fmt.Errorf("the Pod CIDR is malformed: %s", err)
+		return fmt.Errorf("the Pod CIDR is malformed: %w", err)
 	}
 	if input.ServiceCidr == "" {
 		return fmt.Errorf("the Service CIDR is required")
 	}
 	if _, _, err := net.ParseCIDR(input.ServiceCidr); err != nil {
-		return fmt.Errorf("the Service CIDR is malformed: %s", err)
+		return fmt.Errorf("the Service CIDR is malformed: %w", err)
 	}
 	if input.VirtualIpSubnet != "" {
 		if _, _, err := net.ParseCIDR(input.VirtualIpSubnet); err != nil {
-			return fmt.Errorf("the Virtual IP Subnet is malformed: %s", err)
+			return fmt.Errorf("the Virtual IP Subnet is malformed: %w", err)
 		}
 	}
 	if input.ControlPlane.Ip != "" {
@@ -683,31 +683,31 @@ func (input *CseClusterSettings) toCseClusterSettingsInternal(org Org) (*cseClus
 
 	vdc, err := org.GetVDCById(input.VdcId, true)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve the VDC with ID '%s': %s", input.VdcId, err)
+		return nil, fmt.Errorf("could not retrieve the VDC with ID '%s': %w", input.VdcId, err)
 	}
 	output.VdcName = vdc.Vdc.Name
 
 	vAppTemplate, err := getVAppTemplateById(org.client, input.KubernetesTemplateOvaId)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve the Kubernetes Template OVA with ID '%s': %s", input.KubernetesTemplateOvaId, err)
+		return nil, fmt.Errorf("could not retrieve the Kubernetes Template OVA with ID '%s': %w", input.KubernetesTemplateOvaId, err)
 	}
 	output.KubernetesTemplateOvaName = vAppTemplate.VAppTemplate.Name
 
 	tkgVersions, err := getTkgVersionBundleFromVAppTemplate(vAppTemplate.VAppTemplate)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve the required information from the Kubernetes Template OVA: %s", err)
+		return nil, fmt.Errorf("could not retrieve the required information from the Kubernetes Template OVA: %w", err)
 	}
 	output.TkgVersionBundle = tkgVersions
 
 	catalogName, err := vAppTemplate.GetCatalogName()
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve the Catalog name where the the Kubernetes Template OVA '%s' (%s) is hosted: %s", input.KubernetesTemplateOvaId, vAppTemplate.VAppTemplate.Name, err)
+		return nil, fmt.Errorf("could not retrieve the Catalog name where the the Kubernetes Template OVA '%s' (%s) is hosted: %w", input.KubernetesTemplateOvaId, vAppTemplate.VAppTemplate.Name, err)
 	}
 	output.CatalogName = catalogName
 
 	network, err := vdc.GetOrgVdcNetworkById(input.NetworkId, true)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve the Org VDC Network with ID '%s': %s", input.NetworkId, err)
+		return nil, fmt.Errorf("could not retrieve the Org VDC Network with ID '%s': %w", input.NetworkId, err)
 	}
 	output.NetworkName = network.OrgVDCNetwork.Name
 
@@ -790,7 +790,7 @@ func (input *CseClusterSettings) toCseClusterSettingsInternal(org Org) (*cseClus
 	if input.Owner == "" {
 		sessionInfo, err := org.client.GetSessionInfo()
 		if err != nil {
-			return nil, fmt.Errorf("error getting the Owner: %s", err)
+			return nil, fmt.Errorf("error getting the Owner: %w", err)
 		}
 		output.Owner = sessionInfo.User.Name
 	}
@@ -837,13 +837,13 @@ func getTkgVersionBundleFromVAppTemplate(template *types.VAppTemplate) (tkgVersi
 	tkgVersionsMap := "cse/tkg_versions.json"
 	cseTkgVersionsJson, err := cseFiles.ReadFile(tkgVersionsMap)
 	if err != nil {
-		return result, fmt.Errorf("failed reading %s: %s", tkgVersionsMap, err)
+		return result, fmt.Errorf("failed reading %s: %w", tkgVersionsMap, err)
 	}
 
 	versionsMap := map[string]interface{}{}
 	err = json.Unmarshal(cseTkgVersionsJson, &versionsMap)
 	if err != nil {
-		return result, fmt.Errorf("failed unmarshalling %s: %s", tkgVersionsMap, err)
+		return result, fmt.Errorf("failed unmarshalling %s: %w", tkgVersionsMap, err)
 	}
 	versionMap, ok := versionsMap[id]
 	if !ok {
@@ -981,7 +981,7 @@ func idToNames(client *Client, computePolicyIds, storageProfileIds []string) (ma
 		if _, alreadyPresent := result[id]; !alreadyPresent {
 			storageProfile, err := getStorageProfileById(client, id)
 			if err != nil {
-				return nil, fmt.Errorf("could not retrieve Storage Profile with ID '%s': %s", id, err)
+				return nil, fmt.Errorf("could not retrieve Storage Profile with ID '%s': %w", id, err)
 			}
 			result[id] = storageProfile.Name
 		}
@@ -990,7 +990,7 @@ func idToNames(client *Client, computePolicyIds, storageProfileIds []string) (ma
 		if _, alreadyPresent := result[id]; !alreadyPresent {
 			computePolicy, err := getVdcComputePolicyV2ById(client, id)
 			if err != nil {
-				return nil, fmt.Errorf("could not retrieve Compute Policy with ID '%s': %s", id, err)
+				return nil, fmt.Errorf("could not retrieve Compute Policy with ID '%s': %w", id, err)
 			}
 			result[id] = computePolicy.VdcComputePolicyV2.Name
 		}
@@ -26,7 +26,7 @@ func (cluster *CseKubernetesCluster) updateCapiYaml(input CseClusterUpdateInput)
 	// document it finds.
 	yamlDocs, err := unmarshalMultipleYamlDocuments(cluster.capvcdType.Spec.CapiYaml)
 	if err != nil {
-		return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("error unmarshalling YAML: %s", err)
+		return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("error unmarshalling YAML: %w", err)
 	}
 
 	if input.ControlPlane != nil {
@@ -74,12 +74,12 @@ func (cluster *CseKubernetesCluster) updateCapiYaml(input CseClusterUpdateInput)
 	if input.KubernetesTemplateOvaId != nil {
 		vAppTemplate, err := getVAppTemplateById(cluster.client, *input.KubernetesTemplateOvaId)
 		if err != nil {
-			return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("could not retrieve the Kubernetes Template OVA with ID '%s': %s", *input.KubernetesTemplateOvaId, err)
+			return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("could not retrieve the Kubernetes Template OVA with ID '%s': %w", *input.KubernetesTemplateOvaId, err)
 		}
 		// Check the versions of the selected OVA before upgrading
 		versions, err := getTkgVersionBundleFromVAppTemplate(vAppTemplate.VAppTemplate)
 		if err != nil {
-			return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("could not retrieve the TKG versions of OVA '%s': %s", *input.KubernetesTemplateOvaId, err)
+			return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("could not retrieve the TKG versions of OVA '%s': %w", *input.KubernetesTemplateOvaId, err)
 		}
 		if versions.compareTkgVersion(cluster.capvcdType.Status.Capvcd.Upgrade.Current.TkgVersion) < 0 || !versions.kubernetesVersionIsUpgradeableFrom(cluster.capvcdType.Status.Capvcd.Upgrade.Current.KubernetesVersion) {
 			return cluster.capvcdType.Spec.CapiYaml, fmt.Errorf("cannot perform an OVA change as the new one '%s' has an older TKG/Kubernetes version (%s/%s)", vAppTemplate.VAppTemplate.Name, versions.TkgVersion, versions.KubernetesVersion)
@@ -445,7 +445,7 @@ func marshalMultipleYamlDocuments(yamlDocuments []map[string]interface{}) (strin
 	for i, yamlDoc := range yamlDocuments {
 		updatedSingleDoc, err := yaml.Marshal(yamlDoc)
 		if err != nil {
-			return "", fmt.Errorf("error marshaling the updated CAPVCD YAML '%v': %s", yamlDoc, err)
+			return "", fmt.Errorf("error marshaling the updated CAPVCD YAML '%v': %w", yamlDoc, err)
 		}
 		result += fmt.Sprintf("%s\n", updatedSingleDoc)
 		if i < len(yamlDocuments)-1 { // The last document doesn't need the YAML separator
@@ -467,7 +467,7 @@ func unmarshalMultipleYamlDocuments(yamlDocuments string) ([]map[string]interfac
 	for i, yamlDoc := range splitYamlDocs {
 		err := yaml.Unmarshal([]byte(yamlDoc), &result[i])
 		if err != nil {
-			return nil, fmt.Errorf("could not unmarshal document %s: %s", yamlDoc, err)
+			return nil, fmt.Errorf("could not unmarshal document %s: %w", yamlDoc, err)
 		}
 	}
 
@@ -95,7 +95,7 @@ func getRdeType(client *Client, vendor, nss, version string) (*DefinedEntityType
 	}
 
 	if len(rdeTypes) == 0 {
-		return nil, fmt.Errorf("%s could not find the Runtime Defined Entity Type with vendor %s, nss %s and version %s", ErrorEntityNotFound, vendor, nss, version)
+		return nil, fmt.Errorf("%w could not find the Runtime Defined Entity Type with vendor %s, nss %s and version %s", ErrorEntityNotFound, vendor, nss, version)
 	}
 
 	if len(rdeTypes) > 1 {
@@ -198,7 +198,7 @@ func (rdeType *DefinedEntityType) GetBehaviorById(id string) (*types.Behavior, e
 func (rdeType *DefinedEntityType) GetBehaviorByName(name string) (*types.Behavior, error) {
 	behaviors, err := rdeType.GetAllBehaviors(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not get the Behaviors of the Defined Entity Type with ID '%s': %s", rdeType.DefinedEntityType.ID, err)
+		return nil, fmt.Errorf("could not get the Behaviors of the Defined Entity Type with ID '%s': %w", rdeType.DefinedEntityType.ID, err)
 	}
 	label := fmt.Sprintf("Defined Entity Behavior with name '%s' in Defined Entity Type with ID '%s': %s", name, rdeType.DefinedEntityType.ID, ErrorEntityNotFound)
 	return localFilterOneOrError(label, behaviors, "Name", name)
@@ -252,7 +252,7 @@ func (det *DefinedEntityType) SetBehaviorAccessControls(acls []*types.BehaviorAc
 	// Wrap it in OpenAPI pages, this endpoint requires it
 	rawMessage, err := json.Marshal(sanitizedAcls)
 	if err != nil {
-		return fmt.Errorf("error setting Access controls in payload: %s", err)
+		return fmt.Errorf("error setting Access controls in payload: %w", err)
 	}
 	payload := types.OpenApiPages{
 		Values: rawMessage,
@@ -330,7 +330,7 @@ func getRdesByName(client *Client, vendor, nss, version, name string) ([]*Define
 	}
 
 	if len(rdeTypes) == 0 {
-		return nil, fmt.Errorf("%s could not find the Runtime Defined Entity with name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w could not find the Runtime Defined Entity with name '%s'", ErrorEntityNotFound, name)
 	}
 
 	return rdeTypes, nil
@@ -500,7 +500,7 @@ func (rde *DefinedEntity) Refresh() error {
 
 	refreshedRde, err := getRdeById(client, rde.DefinedEntity.ID)
 	if err != nil {
-		return fmt.Errorf("error refreshing RDE: %s", err)
+		return fmt.Errorf("error refreshing RDE: %w", err)
 	}
 	rde.DefinedEntity = refreshedRde.DefinedEntity
 
@@ -611,7 +611,7 @@ func (rde *DefinedEntity) InvokeBehaviorAndMarshal(behaviorId string, invocation
 
 	err = json.Unmarshal([]byte(result), &output)
 	if err != nil {
-		return fmt.Errorf("error marshaling the invocation result '%s': %s", result, err)
+		return fmt.Errorf("error marshaling the invocation result '%s': %w", result, err)
 	}
 
 	return nil
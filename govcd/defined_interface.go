@@ -65,7 +65,7 @@ func (vcdClient *VCDClient) GetDefinedInterface(vendor, nss, version string) (*D
 	}
 
 	if len(interfaces) == 0 {
-		return nil, fmt.Errorf("%s could not find the Defined Interface with vendor %s, nss %s and version %s", ErrorEntityNotFound, vendor, nss, version)
+		return nil, fmt.Errorf("%w could not find the Defined Interface with vendor %s, nss %s and version %s", ErrorEntityNotFound, vendor, nss, version)
 	}
 
 	if len(interfaces) > 1 {
@@ -190,7 +190,7 @@ func (di *DefinedInterface) GetBehaviorById(id string) (*types.Behavior, error)
 func (di *DefinedInterface) GetBehaviorByName(name string) (*types.Behavior, error) {
 	behaviors, err := di.GetAllBehaviors(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not get the Behaviors of the Defined Interface with ID '%s': %s", di.DefinedInterface.ID, err)
+		return nil, fmt.Errorf("could not get the Behaviors of the Defined Interface with ID '%s': %w", di.DefinedInterface.ID, err)
 	}
 	label := fmt.Sprintf("Defined Interface Behavior with name '%s' in Defined Interface with ID '%s': %s", name, di.DefinedInterface.ID, ErrorEntityNotFound)
 	return localFilterOneOrError(label, behaviors, "Name", name)
@@ -231,7 +231,7 @@ func (di *DefinedInterface) DeleteBehavior(behaviorId string) error {
 // when the defined interface does not exist.
 func amendRdeApiError(client *Client, err error) error {
 	if client.APIClientVersionIs("<= 36.0") && err != nil && strings.Contains(err.Error(), "does not exist") {
-		return fmt.Errorf("%s: %s", ErrorEntityNotFound.Error(), err)
+		return fmt.Errorf("%s: %w", ErrorEntityNotFound.Error(), err)
 	}
 	return err
 }
@@ -127,7 +127,7 @@ func (disk *Disk) Update(newDiskInfo *types.Disk) (Task, error) {
 	// Verify the independent disk is not connected to any VM
 	vmRef, err := disk.AttachedVM()
 	if err != nil {
-		return Task{}, fmt.Errorf("error find attached VM: %s", err)
+		return Task{}, fmt.Errorf("error find attached VM: %w", err)
 	}
 	if vmRef != nil {
 		return Task{}, errors.New("error disk is attached")
@@ -184,7 +184,7 @@ func (disk *Disk) Delete() (Task, error) {
 	// Verify the independent disk is not connected to any VM
 	vmRef, err := disk.AttachedVM()
 	if err != nil {
-		return Task{}, fmt.Errorf("error find attached VM: %s", err)
+		return Task{}, fmt.Errorf("error find attached VM: %w", err)
 	}
 	if vmRef != nil {
 		return Task{}, errors.New("error disk is attached")
@@ -322,7 +322,7 @@ func (vdc *Vdc) QueryDisk(diskName string) (DiskRecord, error) {
 	results, err := vdc.QueryWithNotEncodedParams(nil, map[string]string{"type": typeMedia,
 		"filter": "name==" + url.QueryEscape(diskName) + ";vdc==" + vdc.vdcId(), "filterEncoded": "true"})
 	if err != nil {
-		return DiskRecord{}, fmt.Errorf("error querying disk %s", err)
+		return DiskRecord{}, fmt.Errorf("error querying disk %w", err)
 	}
 
 	diskResults := results.Results.DiskRecord
@@ -356,7 +356,7 @@ func (vdc *Vdc) QueryDisks(diskName string) (*[]*types.DiskRecordType, error) {
 	results, err := vdc.QueryWithNotEncodedParams(nil, map[string]string{"type": typeMedia,
 		"filter": "name==" + url.QueryEscape(diskName) + ";vdc==" + vdc.vdcId(), "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying disks %s", err)
+		return nil, fmt.Errorf("error querying disks %w", err)
 	}
 
 	diskResults := results.Results.DiskRecord
@@ -0,0 +1,244 @@
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// DownloadProgressFunc receives the number of bytes downloaded so far and the total size of the
+// download, to let the caller monitor progress. totalSize is -1 when the size is not known
+type DownloadProgressFunc func(bytesDownloaded, totalSize int64)
+
+// DownloadProgressPercentage returns the progress of a download as a percentage with two decimals,
+// the same format as UploadTask.GetUploadProgress. It returns an empty string when the total size
+// is not known
+func DownloadProgressPercentage(bytesDownloaded, totalSize int64) string {
+	if totalSize < 0 {
+		return ""
+	}
+	if totalSize == 0 {
+		return "100.00"
+	}
+	return fmt.Sprintf("%.2f", float64(bytesDownloaded)*100/float64(totalSize))
+}
+
+// downloadMaxAttempts is the number of consecutive attempts without receiving any byte after which
+// a download is given up. Interrupted downloads are resumed from the last byte received, after the
+// backoff of the retry policy of the client (or of DefaultRetryPolicy)
+const downloadMaxAttempts = 5
+
+// downloadDetails
+// href - URL of the file to download
+// expectedSize - the size of the file, or -1 if it is not known
+// offset - how many bytes of the file are already at the destination
+// progressOffset - bytes downloaded for previous files, when several files make up a download
+// totalSize - overall size of all the files of a download, or -1 if it is not known
+// callBack - function that receives the progress of the download. It can be nil
+type downloadDetails struct {
+	href                                            string
+	expectedSize, offset, progressOffset, totalSize int64
+	callBack                                        DownloadProgressFunc
+}
+
+// errDownloadInterrupted wraps errors that happened while receiving the body of a download, which
+// can be resumed
+var errDownloadInterrupted = errors.New("download interrupted")
+
+// progressWriter is an io.Writer that reports the bytes written to a DownloadProgressFunc
+type progressWriter struct {
+	writer  io.Writer
+	written int64
+	details downloadDetails
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.written += int64(n)
+	if w.details.callBack != nil && n > 0 {
+		w.details.callBack(w.details.progressOffset+w.written, w.details.totalSize)
+	}
+	return n, err
+}
+
+// downloadToWriter streams the file at dDetails.href to writer, which already contains
+// dDetails.offset bytes of the file. Interrupted downloads are resumed with HTTP Range requests. It
+// returns the number of bytes of the file at the destination, including the offset
+func downloadToWriter(client *Client, writer io.Writer, dDetails downloadDetails) (int64, error) {
+	util.Logger.Printf("[TRACE] Starting download: %s, offset: %d, expected size: %d\n", dDetails.href, dDetails.offset, dDetails.expectedSize)
+
+	downloadUrl, err := url.ParseRequestURI(dDetails.href)
+	if err != nil {
+		return dDetails.offset, fmt.Errorf("error parsing download URL %s: %w", dDetails.href, err)
+	}
+
+	retryPolicy := DefaultRetryPolicy()
+	if client.retryPolicy != nil {
+		retryPolicy = *client.retryPolicy
+	}
+
+	pWriter := &progressWriter{writer: writer, written: dDetails.offset, details: dDetails}
+	failedAttempts := 0
+	for {
+		received := pWriter.written
+		err = downloadRange(client, pWriter, *downloadUrl, dDetails.expectedSize)
+		if err == nil || !errors.Is(err, errDownloadInterrupted) {
+			break
+		}
+		// Only consecutive attempts that did not receive anything count towards the limit, so that a
+		// large download over an unstable connection can complete
+		if pWriter.written > received {
+			failedAttempts = 0
+		}
+		failedAttempts++
+		if failedAttempts == downloadMaxAttempts {
+			break
+		}
+		delay := retryPolicy.backoff(failedAttempts)
+		util.Logger.Printf("[DEBUG] download of %s interrupted at byte %d (attempt %d of %d without progress), resuming in %s: %s\n",
+			dDetails.href, pWriter.written, failedAttempts, downloadMaxAttempts, delay, err)
+		time.Sleep(delay)
+	}
+	if err != nil {
+		return pWriter.written, err
+	}
+
+	if dDetails.expectedSize >= 0 && pWriter.written != dDetails.expectedSize {
+		return pWriter.written, fmt.Errorf("downloaded %d bytes of %s, expected %d", pWriter.written, dDetails.href, dDetails.expectedSize)
+	}
+	return pWriter.written, nil
+}
+
+// downloadRange downloads the file at downloadUrl, starting at pWriter.written.
+//
+// The timeout of the HTTP client (600s by default) would interrupt large downloads, therefore it
+// is applied to the time spent without receiving any data instead of the whole request
+func downloadRange(client *Client, pWriter *progressWriter, downloadUrl url.URL, expectedSize int64) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body := &idleTimeoutReader{timeout: client.Http.Timeout}
+	if body.timeout > 0 {
+		body.timer = time.AfterFunc(body.timeout, cancel)
+		defer body.timer.Stop()
+	}
+
+	offset := pWriter.written
+	request := client.NewRequestCtx(ctx, map[string]string{}, http.MethodGet, downloadUrl, nil)
+	if offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	httpClient := client.Http
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errDownloadInterrupted, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			util.Logger.Printf("Error closing response body: %s\n", err)
+		}
+	}()
+
+	body.reader = resp.Body
+
+	switch {
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset == expectedSize:
+		// The file was already complete
+		return nil
+	case resp.StatusCode == http.StatusOK && offset > 0:
+		// The server ignored the Range header and is sending the whole file: the bytes that are
+		// already at the destination are skipped
+		if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+			return fmt.Errorf("%w: %w", errDownloadInterrupted, err)
+		}
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		// Appending a range that does not start at the end of the data already written would
+		// corrupt the file
+		start, err := parseContentRangeStart(resp.Header.Get("Content-Range"))
+		if err != nil || start != offset {
+			return fmt.Errorf("error downloading %s: requested range from byte %d, got Content-Range '%s'",
+				downloadUrl.String(), offset, resp.Header.Get("Content-Range"))
+		}
+	case resp.StatusCode == http.StatusOK:
+	default:
+		return fmt.Errorf("error downloading %s: %s", downloadUrl.String(), resp.Status)
+	}
+
+	if _, err := io.Copy(pWriter, body); err != nil {
+		return fmt.Errorf("%w: %w", errDownloadInterrupted, err)
+	}
+	return nil
+}
+
+// parseContentRangeStart returns the first byte of a Content-Range header such as
+// "bytes 100-999/1000"
+func parseContentRangeStart(contentRange string) (int64, error) {
+	byteRange, found := strings.CutPrefix(contentRange, "bytes ")
+	if !found {
+		return 0, fmt.Errorf("unsupported Content-Range '%s'", contentRange)
+	}
+	start, _, found := strings.Cut(byteRange, "-")
+	if !found {
+		return 0, fmt.Errorf("unsupported Content-Range '%s'", contentRange)
+	}
+	return strconv.ParseInt(start, 10, 64)
+}
+
+// idleTimeoutReader restarts the timer which cancels a download each time data is received
+type idleTimeoutReader struct {
+	reader  io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.timer != nil {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+// downloadToFile downloads the file at dDetails.href to filePath. When filePath already contains
+// part of the file, the download resumes from its end. dDetails.offset is ignored
+func downloadToFile(client *Client, filePath string, dDetails downloadDetails) (int64, error) {
+	file, err := os.OpenFile(filepath.Clean(filePath), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer safeClose(file)
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	dDetails.offset = fileInfo.Size()
+	if dDetails.expectedSize < 0 || dDetails.offset > dDetails.expectedSize {
+		// Without a size to check against, or with a file bigger than expected, the existing file
+		// cannot be trusted
+		if err = file.Truncate(0); err != nil {
+			return 0, err
+		}
+		dDetails.offset = 0
+	}
+	if dDetails.offset > 0 && dDetails.offset == dDetails.expectedSize {
+		util.Logger.Printf("[TRACE] File %s was already downloaded\n", filePath)
+		if dDetails.callBack != nil {
+			dDetails.callBack(dDetails.progressOffset+dDetails.offset, dDetails.totalSize)
+		}
+		return dDetails.offset, nil
+	}
+
+	return downloadToWriter(client, file, dDetails)
+}
@@ -0,0 +1,303 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// downloadTestServer serves a vApp template and a media item that can be downloaded. The first
+// request for each file is interrupted halfway, so that the download must be resumed
+type downloadTestServer struct {
+	*httptest.Server
+	files     map[string][]byte
+	mediaSize int
+
+	sync.Mutex
+	interrupted map[string]bool
+	disabled    bool
+}
+
+func newDownloadTestServer(t *testing.T) *downloadTestServer {
+	disk1 := make([]byte, 100000)
+	for i := range disk1 {
+		disk1[i] = byte(i % 251)
+	}
+	disk2 := []byte("abcdefghij")
+	descriptor := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<ovf:Envelope xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <ovf:References>
+    <ovf:File ovf:href="disk1.vmdk" ovf:id="file1" ovf:size="%d"/>
+    <ovf:File ovf:href="disk2.vmdk" ovf:id="file2" ovf:size="%d" ovf:chunkSize="4"/>
+  </ovf:References>
+</ovf:Envelope>`, len(disk1), len(disk2))
+
+	server := &downloadTestServer{
+		files: map[string][]byte{
+			"/transfer/1/descriptor.ovf":       []byte(descriptor),
+			"/transfer/1/disk1.vmdk":           disk1,
+			"/transfer/1/disk2.vmdk.000000000": disk2[:4],
+			"/transfer/1/disk2.vmdk.000000001": disk2[4:8],
+			"/transfer/1/disk2.vmdk.000000002": disk2[8:],
+			"/transfer/2/file":                 bytes.Repeat([]byte("iso"), 5000),
+		},
+		interrupted: map[string]bool{},
+	}
+	server.mediaSize = len(server.files["/transfer/2/file"])
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (server *downloadTestServer) handle(w http.ResponseWriter, r *http.Request) {
+	server.Lock()
+	defer server.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/vAppTemplate/vappTemplate-1":
+		w.Header().Set("Content-Type", types.MimeVAppTemplate)
+		_, _ = fmt.Fprintf(w, `<VAppTemplate xmlns="http://www.vmware.com/vcloud/v1.5" name="template" href="%[1]s/api/vAppTemplate/vappTemplate-1">
+  <Link rel="enable" href="%[1]s/api/vAppTemplate/vappTemplate-1/action/enableDownload"/>
+  <Link rel="disable" href="%[1]s/api/vAppTemplate/vappTemplate-1/action/disableDownload"/>
+  <Link rel="download:default" href="%[1]s/transfer/1/descriptor.ovf"/>
+</VAppTemplate>`, server.URL)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/media/media-1":
+		w.Header().Set("Content-Type", types.MimeMediaItem)
+		_, _ = fmt.Fprintf(w, `<Media xmlns="http://www.vmware.com/vcloud/v1.5" name="media" size="%[2]d" href="%[1]s/api/media/media-1">
+  <Link rel="enable" href="%[1]s/api/media/media-1/action/enableDownload"/>
+  <Files><File name="file" size="%[2]d"><Link rel="download:default" href="%[1]s/transfer/2/file"/></File></Files>
+</Media>`, server.URL, server.mediaSize)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/vAppTemplate/vappTemplate-1/action/enableDownload",
+		r.Method == http.MethodPost && r.URL.Path == "/api/media/media-1/action/enableDownload",
+		r.Method == http.MethodGet && r.URL.Path == "/api/task/1":
+		w.Header().Set("Content-Type", types.MimeTask)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" operationName="vdcEnableDownload" href="%s/api/task/1"/>`, server.URL)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/vAppTemplate/vappTemplate-1/action/disableDownload":
+		server.disabled = true
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && server.files[r.URL.Path] != nil:
+		server.serveFile(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (server *downloadTestServer) serveFile(w http.ResponseWriter, r *http.Request) {
+	content := server.files[r.URL.Path]
+	start := 0
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		var err error
+		start, err = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+		if err != nil || start >= len(content) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+	if start > 0 {
+		w.WriteHeader(http.StatusPartialContent)
+	}
+
+	if !server.interrupted[r.URL.Path] && !strings.HasSuffix(r.URL.Path, ".ovf") && len(content)-start > 1 {
+		server.interrupted[r.URL.Path] = true
+		_, _ = w.Write(content[start : start+(len(content)-start)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	_, _ = w.Write(content[start:])
+}
+
+func TestMedia_DownloadToWriter(t *testing.T) {
+	server := newDownloadTestServer(t)
+	vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+	media := NewMedia(&vcdClient.Client)
+	media.Media.HREF = server.URL + "/api/media/media-1"
+	if err := media.Refresh(); err != nil {
+		t.Fatalf("error retrieving media: %s", err)
+	}
+	expected := server.files["/transfer/2/file"]
+
+	var buffer bytes.Buffer
+	var progress []string
+	err := media.DownloadToWriter(&buffer, func(bytesDownloaded, totalSize int64) {
+		progress = append(progress, DownloadProgressPercentage(bytesDownloaded, totalSize))
+	})
+	if err != nil {
+		t.Fatalf("error downloading media: %s", err)
+	}
+	if !bytes.Equal(buffer.Bytes(), expected) {
+		t.Errorf("unexpected content downloaded: %d bytes instead of %d", buffer.Len(), len(expected))
+	}
+	if len(progress) < 2 || progress[len(progress)-1] != "100.00" {
+		t.Errorf("expected progress to reach 100.00, got %v", progress)
+	}
+
+	// A partial file is resumed
+	filePath := filepath.Join(t.TempDir(), "media.iso")
+	if err = os.WriteFile(filePath, expected[:100], 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = media.DownloadToFile(filePath); err != nil {
+		t.Fatalf("error downloading media to file: %s", err)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, expected) {
+		t.Errorf("unexpected content downloaded to file: %d bytes instead of %d", len(content), len(expected))
+	}
+
+	// The size is checked against the media item
+	server.Lock()
+	server.files["/transfer/2/file"] = expected[:10]
+	server.Unlock()
+	if err = media.DownloadToWriter(&bytes.Buffer{}, nil); err == nil || !strings.Contains(err.Error(), "expected 15000") {
+		t.Errorf("expected size mismatch error, got %v", err)
+	}
+}
+
+func TestDownloadToWriter_UnstableConnection(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	var mu sync.Mutex
+	requestCount := 0
+	chunkSize := 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestCount++
+		size := chunkSize
+		mu.Unlock()
+
+		start := 0
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			start, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.(http.Flusher).Flush()
+		// Each response is sent slowly and dropped after `size` bytes
+		end := min(start+size, len(content))
+		for position := start; position < end; position += 25 {
+			_, _ = w.Write(content[position:min(position+25, end)])
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond)
+		}
+		if end < len(content) {
+			panic(http.ErrAbortHandler)
+		}
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+	// A response takes longer than the timeout of the HTTP client, which is only applied to the
+	// time without receiving any data
+	vcdClient.Client.Http.Timeout = 50 * time.Millisecond
+	details := downloadDetails{href: server.URL + "/file", expectedSize: int64(len(content)), totalSize: int64(len(content))}
+
+	// The connection drops more than downloadMaxAttempts times, but each attempt makes progress
+	var buffer bytes.Buffer
+	size, err := downloadToWriter(&vcdClient.Client, &buffer, details)
+	if err != nil {
+		t.Fatalf("error downloading: %s", err)
+	}
+	if size != int64(len(content)) || !bytes.Equal(buffer.Bytes(), content) {
+		t.Errorf("unexpected content downloaded: %d bytes instead of %d", buffer.Len(), len(content))
+	}
+	if requestCount != 10 {
+		t.Errorf("expected 10 requests, got %d", requestCount)
+	}
+
+	// Attempts without progress are limited
+	mu.Lock()
+	requestCount = 0
+	chunkSize = 0
+	mu.Unlock()
+	buffer.Reset()
+	_, err = downloadToWriter(&vcdClient.Client, &buffer, details)
+	if !errors.Is(err, errDownloadInterrupted) {
+		t.Errorf("expected interrupted download error, got %v", err)
+	}
+	if requestCount != downloadMaxAttempts {
+		t.Errorf("expected %d requests, got %d", downloadMaxAttempts, requestCount)
+	}
+}
+
+func TestDownloadToWriter_ContentRange(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server sends a range that starts 10 bytes before the requested one
+		start := 0
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			start, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			start -= 10
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		_, _ = w.Write(content[start:])
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+	details := downloadDetails{href: server.URL + "/file", expectedSize: int64(len(content)), totalSize: int64(len(content))}
+	filePath := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(filePath, content[:100], 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := downloadToFile(&vcdClient.Client, filePath, details)
+	if err == nil || !strings.Contains(err.Error(), "requested range from byte 100, got Content-Range 'bytes 90-999/1000'") {
+		t.Errorf("expected a Content-Range error, got %v", err)
+	}
+}
+
+func Test_parseContentRangeStart(t *testing.T) {
+	tests := []struct {
+		contentRange string
+		start        int64
+		wantErr      bool
+	}{
+		{"bytes 100-999/1000", 100, false},
+		{"bytes 0-0/*", 0, false},
+		{"bytes */1000", 0, true},
+		{"items 100-999/1000", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		start, err := parseContentRangeStart(test.contentRange)
+		if (err != nil) != test.wantErr || start != test.start {
+			t.Errorf("parseContentRangeStart(%q) = %d, %v", test.contentRange, start, err)
+		}
+	}
+}
+
+func TestDownloadProgressPercentage(t *testing.T) {
+	tests := []struct {
+		downloaded, total int64
+		expected          string
+	}{
+		{0, 200, "0.00"},
+		{1, 3, "33.33"},
+		{200, 200, "100.00"},
+		{0, 0, "100.00"},
+		{10, -1, ""},
+	}
+	for _, test := range tests {
+		if got := DownloadProgressPercentage(test.downloaded, test.total); got != test.expected {
+			t.Errorf("expected %s for %d of %d, got %s", test.expected, test.downloaded, test.total, got)
+		}
+	}
+}
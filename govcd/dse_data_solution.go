@@ -31,14 +31,14 @@ type DataSolution struct {
 func (vcdClient *VCDClient) GetAllDataSolutions(queryParameters url.Values) ([]*DataSolution, error) {
 	allDseInstances, err := vcdClient.GetAllRdes(dataSolutionRdeType[0], dataSolutionRdeType[1], dataSolutionRdeType[2], queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Data Solutions: %s", err)
+		return nil, fmt.Errorf("error retrieving all Data Solutions: %w", err)
 	}
 
 	results := make([]*DataSolution, len(allDseInstances))
 	for index, rde := range allDseInstances {
 		dseConfig, err := convertRdeEntityToAny[types.DataSolution](rde.DefinedEntity.Entity)
 		if err != nil {
-			return nil, fmt.Errorf("error converting RDE to Data Solution: %s", err)
+			return nil, fmt.Errorf("error converting RDE to Data Solution: %w", err)
 		}
 
 		results[index] = &DataSolution{
@@ -58,7 +58,7 @@ func (vcdClient *VCDClient) GetDataSolutionById(id string) (*DataSolution, error
 	}
 	rde, err := getRdeById(&vcdClient.Client, id)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Data Solution by ID: %s", err)
+		return nil, fmt.Errorf("error retrieving Data Solution by ID: %w", err)
 	}
 
 	result, err := convertRdeEntityToAny[types.DataSolution](rde.DefinedEntity.Entity)
@@ -79,7 +79,7 @@ func (vcdClient *VCDClient) GetDataSolutionById(id string) (*DataSolution, error
 func (vcdClient *VCDClient) GetDataSolutionByName(name string) (*DataSolution, error) {
 	dseEntities, err := vcdClient.GetAllDataSolutions(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Data Solution with name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving Data Solution with name '%s': %w", name, err)
 	}
 
 	for _, instance := range dseEntities {
@@ -87,7 +87,7 @@ func (vcdClient *VCDClient) GetDataSolutionByName(name string) (*DataSolution, e
 			return instance, nil
 		}
 	}
-	return nil, fmt.Errorf("%s Data Solution by name '%s' not found", ErrorEntityNotFound, name)
+	return nil, fmt.Errorf("%w Data Solution by name '%s' not found", ErrorEntityNotFound, name)
 }
 
 // Update Data Solution with given configuration
@@ -137,13 +137,13 @@ func (ds *DataSolution) Publish(tenantId string) (*types.DefinedEntityAccess, *t
 	// The operation is idempotent and can be run multiple times which is what the UI does
 	err := ds.PublishRightsBundle([]string{tenantId})
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error publishing Rights Bundle: %s", err)
+		return nil, nil, nil, fmt.Errorf("error publishing Rights Bundle: %w", err)
 	}
 
 	// Publish ACLs to a given Data Solution
 	mainAcl, err := ds.PublishAccessControls(tenantId)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error publishing Access Controls to '%s': %s", ds.Name(), err)
+		return nil, nil, nil, fmt.Errorf("error publishing Access Controls to '%s': %w", ds.Name(), err)
 	}
 
 	// Additionally set the same ACL for Data Solutions Operator (DSO)
@@ -154,13 +154,13 @@ func (ds *DataSolution) Publish(tenantId string) (*types.DefinedEntityAccess, *t
 
 	dsoAcl, err := dso.PublishAccessControls(tenantId)
 	if err != nil {
-		return mainAcl, nil, nil, fmt.Errorf("error publishing Access Controls to '%s': %s", dso.Name(), err)
+		return mainAcl, nil, nil, fmt.Errorf("error publishing Access Controls to '%s': %w", dso.Name(), err)
 	}
 
 	// PublishAllInstanceTemplates
 	templateAcls, err := ds.PublishAllInstanceTemplates(tenantId)
 	if err != nil {
-		return mainAcl, dsoAcl, nil, fmt.Errorf("error publishing all Data Solution Instance Templates: %s", err)
+		return mainAcl, dsoAcl, nil, fmt.Errorf("error publishing all Data Solution Instance Templates: %w", err)
 	}
 
 	return mainAcl, dsoAcl, templateAcls, nil
@@ -184,7 +184,7 @@ func (ds *DataSolution) Unpublish(tenantId string) error {
 	// ACLs
 	err := ds.UnpublishAccessControls(tenantId)
 	if err != nil {
-		return fmt.Errorf("failed unpublishing Access Controls for %s: %s", ds.Name(), err)
+		return fmt.Errorf("failed unpublishing Access Controls for %s: %w", ds.Name(), err)
 	}
 
 	return nil
@@ -194,13 +194,13 @@ func (ds *DataSolution) Unpublish(tenantId string) error {
 func (ds *DataSolution) PublishRightsBundle(tenantIds []string) error {
 	rightsBundle, err := ds.vcdClient.Client.GetRightsBundleByName(dseRightsBundleName)
 	if err != nil {
-		return fmt.Errorf("error retrieving Rights Bundle %s: %s", dseRightsBundleName, err)
+		return fmt.Errorf("error retrieving Rights Bundle %s: %w", dseRightsBundleName, err)
 	}
 
 	references := convertSliceOfStringsToOpenApiReferenceIds(tenantIds)
 	err = rightsBundle.PublishTenants(references)
 	if err != nil {
-		return fmt.Errorf("error publishing Rights Bundle '%s' to Tenants '%s': %s",
+		return fmt.Errorf("error publishing Rights Bundle '%s' to Tenants '%s': %w",
 			dseRightsBundleName, strings.Join(tenantIds, ","), err)
 	}
 
@@ -211,13 +211,13 @@ func (ds *DataSolution) PublishRightsBundle(tenantIds []string) error {
 func (ds *DataSolution) UnpublishRightsBundle(tenantIds []string) error {
 	rightsBundle, err := ds.vcdClient.Client.GetRightsBundleByName(dseRightsBundleName)
 	if err != nil {
-		return fmt.Errorf("error retrieving Rights Bundle %s: %s", dseRightsBundleName, err)
+		return fmt.Errorf("error retrieving Rights Bundle %s: %w", dseRightsBundleName, err)
 	}
 
 	references := convertSliceOfStringsToOpenApiReferenceIds(tenantIds)
 	err = rightsBundle.UnpublishTenants(references)
 	if err != nil {
-		return fmt.Errorf("error unpublishing %s for Tenants '%s': %s",
+		return fmt.Errorf("error unpublishing %s for Tenants '%s': %w",
 			dseRightsBundleName, strings.Join(tenantIds, ","), err)
 	}
 
@@ -235,7 +235,7 @@ func (ds *DataSolution) PublishAccessControls(tenantId string) (*types.DefinedEn
 
 	accessControl, err := ds.DefinedEntity.SetAccessControl(acl)
 	if err != nil {
-		return nil, fmt.Errorf("error setting Access Control for Data Solution '%s', Org ID %s: %s", tenantId, ds.Name(), err)
+		return nil, fmt.Errorf("error setting Access Control for Data Solution '%s', Org ID %s: %w", tenantId, ds.Name(), err)
 	}
 
 	return accessControl, nil
@@ -245,13 +245,13 @@ func (ds *DataSolution) PublishAccessControls(tenantId string) (*types.DefinedEn
 func (ds *DataSolution) UnpublishAccessControls(tenantId string) error {
 	acls, err := ds.GetAllAccessControlsForTenant(tenantId)
 	if err != nil {
-		return fmt.Errorf("error retrieving all Access Controls for Tenant '%s': %s", tenantId, err)
+		return fmt.Errorf("error retrieving all Access Controls for Tenant '%s': %w", tenantId, err)
 	}
 
 	for _, acl := range acls {
 		err = ds.DefinedEntity.DeleteAccessControl(acl)
 		if err != nil {
-			return fmt.Errorf("error deleting Access Control: %s", err)
+			return fmt.Errorf("error deleting Access Control: %w", err)
 		}
 	}
 
@@ -262,7 +262,7 @@ func (ds *DataSolution) UnpublishAccessControls(tenantId string) error {
 func (ds *DataSolution) GetAllAccessControls(queryParameters url.Values) ([]*types.DefinedEntityAccess, error) {
 	allAcls, err := ds.DefinedEntity.GetAllAccessControls(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Access Controls for Data Solution %s: %s", ds.Name(), err)
+		return nil, fmt.Errorf("error retrieving all Access Controls for Data Solution %s: %w", ds.Name(), err)
 	}
 
 	return localFilter("Data Solution ACL", allAcls, "ObjectId", ds.RdeId())
@@ -278,7 +278,7 @@ func (ds *DataSolution) GetAllAccessControlsForTenant(tenantId string) ([]*types
 	util.Logger.Printf("[TRACE] Data Solution '%s' getting Access Controls for tenant '%s'", ds.Name(), tenantId)
 	allAcls, err := ds.GetAllAccessControls(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Access Controls for Data Solution: %s", err)
+		return nil, fmt.Errorf("error retrieving all Access Controls for Data Solution: %w", err)
 	}
 
 	foundAcls := make([]*types.DefinedEntityAccess, 0)
@@ -26,14 +26,14 @@ type DataSolutionInstanceTemplate struct {
 func (vcdClient *VCDClient) GetAllInstanceTemplates(queryParameters url.Values) ([]*DataSolutionInstanceTemplate, error) {
 	allDseInstanceTemplates, err := vcdClient.GetAllRdes(dataSolutionTemplateInstanceRdeType[0], dataSolutionTemplateInstanceRdeType[1], dataSolutionTemplateInstanceRdeType[2], queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Data Solution Instance Templates: %s", err)
+		return nil, fmt.Errorf("error retrieving all Data Solution Instance Templates: %w", err)
 	}
 
 	results := make([]*DataSolutionInstanceTemplate, len(allDseInstanceTemplates))
 	for index, rde := range allDseInstanceTemplates {
 		dseConfig, err := convertRdeEntityToAny[types.DataSolutionInstanceTemplate](rde.DefinedEntity.Entity)
 		if err != nil {
-			return nil, fmt.Errorf("error converting RDE to Data Solution Instance Template: %s", err)
+			return nil, fmt.Errorf("error converting RDE to Data Solution Instance Template: %w", err)
 		}
 
 		results[index] = &DataSolutionInstanceTemplate{
@@ -63,14 +63,14 @@ func (ds *DataSolution) GetAllInstanceTemplates() ([]*DataSolutionInstanceTempla
 func (ds *DataSolution) PublishAllInstanceTemplates(tenantId string) ([]*types.DefinedEntityAccess, error) {
 	allTemplates, err := ds.GetAllInstanceTemplates()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Data Solution Instance Templates: %s", err)
+		return nil, fmt.Errorf("error retrieving all Data Solution Instance Templates: %w", err)
 	}
 
 	definedEntityAccess := make([]*types.DefinedEntityAccess, len(allTemplates))
 	for templateIndex, template := range allTemplates {
 		access, err := template.Publish(tenantId)
 		if err != nil {
-			return nil, fmt.Errorf("error setting ACL for Data Solution Instance Template '%s': %s",
+			return nil, fmt.Errorf("error setting ACL for Data Solution Instance Template '%s': %w",
 				template.DefinedEntity.DefinedEntity.Name, err)
 		}
 
@@ -84,13 +84,13 @@ func (ds *DataSolution) PublishAllInstanceTemplates(tenantId string) ([]*types.D
 func (ds *DataSolution) UnPublishAllInstanceTemplates(tenantId string) error {
 	allTemplates, err := ds.GetAllInstanceTemplates()
 	if err != nil {
-		return fmt.Errorf("error retrieving all Data Solution Instance Templates: %s", err)
+		return fmt.Errorf("error retrieving all Data Solution Instance Templates: %w", err)
 	}
 
 	for _, template := range allTemplates {
 		err := template.Unpublish(tenantId)
 		if err != nil {
-			return fmt.Errorf("error removing ACL for Data Solution Instance Template '%s': %s",
+			return fmt.Errorf("error removing ACL for Data Solution Instance Template '%s': %w",
 				template.DefinedEntity.DefinedEntity.Name, err)
 		}
 
@@ -118,7 +118,7 @@ func (dst *DataSolutionInstanceTemplate) GetAllAccessControlsForTenant(tenantId
 	util.Logger.Printf("[TRACE] Data Solution Instance Template '%s' getting Access Controls for tenant '%s'", dst.Name(), tenantId)
 	allAcls, err := dst.GetAllAccessControls(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Access Controls for Data Solution Solution Instance Template: %s", err)
+		return nil, fmt.Errorf("error retrieving all Access Controls for Data Solution Solution Instance Template: %w", err)
 	}
 
 	foundAcls := make([]*types.DefinedEntityAccess, 0)
@@ -145,7 +145,7 @@ func (dst *DataSolutionInstanceTemplate) Publish(tenantId string) (*types.Define
 
 	accessControl, err := dst.DefinedEntity.SetAccessControl(acl)
 	if err != nil {
-		return nil, fmt.Errorf("error setting Access Control for Data Solution %s: %s", dst.DefinedEntity.DefinedEntity.Name, err)
+		return nil, fmt.Errorf("error setting Access Control for Data Solution %s: %w", dst.DefinedEntity.DefinedEntity.Name, err)
 	}
 
 	return accessControl, nil
@@ -157,13 +157,13 @@ func (dst *DataSolutionInstanceTemplate) Unpublish(tenantId string) error {
 	queryParams = queryParameterFilterAnd(fmt.Sprintf("tenant.id==%s", tenantId), queryParams)
 	acls, err := dst.DefinedEntity.GetAllAccessControls(queryParams)
 	if err != nil {
-		return fmt.Errorf("error getting Access Control for Data Solution Instance Template %s: %s", dst.DefinedEntity.DefinedEntity.Name, err)
+		return fmt.Errorf("error getting Access Control for Data Solution Instance Template %s: %w", dst.DefinedEntity.DefinedEntity.Name, err)
 	}
 
 	for _, acl := range acls {
 		err = dst.DefinedEntity.DeleteAccessControl(acl)
 		if err != nil {
-			return fmt.Errorf("error deleting Access Control: %s", err)
+			return fmt.Errorf("error deleting Access Control: %w", err)
 		}
 	}
 
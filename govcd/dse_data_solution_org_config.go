@@ -28,7 +28,7 @@ type DataSolutionOrgConfig struct {
 func (vcdClient *VCDClient) CreateDataSolutionOrgConfig(orgId string, cfg *types.DataSolutionOrgConfig) (*DataSolutionOrgConfig, error) {
 	rdeType, err := vcdClient.GetRdeType(dataSolutionOrgConfig[0], dataSolutionOrgConfig[1], dataSolutionOrgConfig[2])
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving RDE Type for VCD Data Solution Org Configuration: %s", err)
+		return nil, fmt.Errorf("error retrieving RDE Type for VCD Data Solution Org Configuration: %w", err)
 	}
 
 	// 2. Convert more precise structure to fit DefinedEntity.DefinedEntity.Entity
@@ -48,19 +48,19 @@ func (vcdClient *VCDClient) CreateDataSolutionOrgConfig(orgId string, cfg *types
 	// 4. Create RDE
 	createdRdeEntity, err := rdeType.CreateRde(*entityCfg, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating RDE entity: %s", err)
+		return nil, fmt.Errorf("error creating RDE entity: %w", err)
 	}
 
 	// 5. Resolve RDE
 	err = createdRdeEntity.Resolve()
 	if err != nil {
-		return nil, fmt.Errorf("error resolving Solutions add-on after creating: %s", err)
+		return nil, fmt.Errorf("error resolving Solutions add-on after creating: %w", err)
 	}
 
 	// 6. Reload RDE
 	err = createdRdeEntity.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing RDE after resolving: %s", err)
+		return nil, fmt.Errorf("error refreshing RDE after resolving: %w", err)
 	}
 
 	result, err := convertRdeEntityToAny[types.DataSolutionOrgConfig](createdRdeEntity.DefinedEntity.Entity)
@@ -81,14 +81,14 @@ func (vcdClient *VCDClient) CreateDataSolutionOrgConfig(orgId string, cfg *types
 func (vcdClient *VCDClient) GetAllDataSolutionOrgConfigs(queryParameters url.Values) ([]*DataSolutionOrgConfig, error) {
 	allDseInstances, err := vcdClient.GetAllRdes(dataSolutionOrgConfig[0], dataSolutionOrgConfig[1], dataSolutionOrgConfig[2], queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Data Solution Org Configs: %s", err)
+		return nil, fmt.Errorf("error retrieving all Data Solution Org Configs: %w", err)
 	}
 
 	results := make([]*DataSolutionOrgConfig, len(allDseInstances))
 	for index, rde := range allDseInstances {
 		dsOrgConfig, err := convertRdeEntityToAny[types.DataSolutionOrgConfig](rde.DefinedEntity.Entity)
 		if err != nil {
-			return nil, fmt.Errorf("error converting RDE to Solution Add-on Instance: %s", err)
+			return nil, fmt.Errorf("error converting RDE to Solution Add-on Instance: %w", err)
 		}
 
 		results[index] = &DataSolutionOrgConfig{
@@ -128,7 +128,7 @@ func (ds *DataSolution) GetDataSolutionOrgConfigForTenant(tenantId string) (*Dat
 
 	allOrgConfigs, err := ds.GetAllDataSolutionOrgConfigs()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Data Solution Org Configs: %s", err)
+		return nil, fmt.Errorf("error retrieving all Data Solution Org Configs: %w", err)
 	}
 
 	var foundOrgCfg *DataSolutionOrgConfig
@@ -141,7 +141,7 @@ func (ds *DataSolution) GetDataSolutionOrgConfigForTenant(tenantId string) (*Dat
 	}
 
 	if foundOrgCfg == nil {
-		return nil, fmt.Errorf("%s: could not find Data Solution '%s' Org Config for a given tenant '%s'",
+		return nil, fmt.Errorf("%w: could not find Data Solution '%s' Org Config for a given tenant '%s'",
 			ErrorEntityNotFound, ds.Name(), tenantId)
 	}
 
@@ -8,7 +8,6 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/xml"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -114,7 +113,7 @@ func (egw *EdgeGateway) AddDhcpPool(network *types.OrgVDCNetwork, dhcppool []int
 
 	output, err := xml.MarshalIndent(newRules, "  ", "    ")
 	if err != nil {
-		return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %s", err)
+		return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %w", err)
 	}
 
 	var resp *http.Response
@@ -136,7 +135,7 @@ func (egw *EdgeGateway) AddDhcpPool(network *types.OrgVDCNetwork, dhcppool []int
 				time.Sleep(3 * time.Second)
 				continue
 			}
-			return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %s", err)
+			return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %w", err)
 		}
 		break
 	}
@@ -144,7 +143,7 @@ func (egw *EdgeGateway) AddDhcpPool(network *types.OrgVDCNetwork, dhcppool []int
 	task := NewTask(egw.client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
@@ -213,11 +212,11 @@ func (egw *EdgeGateway) RemoveNATPortMapping(natType, externalIP, externalPort,
 func (egw *EdgeGateway) RemoveNATRule(id string) error {
 	task, err := egw.RemoveNATRuleAsync(id)
 	if err != nil {
-		return fmt.Errorf("error removing DNAT rule: %s", err)
+		return fmt.Errorf("error removing DNAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return combinedTaskError(task.Task, err)
 	}
 
 	return nil
@@ -233,7 +232,7 @@ func (egw *EdgeGateway) RemoveNATRuleAsync(id string) (Task, error) {
 
 	err := egw.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return Task{}, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	natServiceToUpdate := egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService
@@ -289,18 +288,18 @@ func (egw *EdgeGateway) AddDNATRule(ruleDetails NatRule) (*types.NatRule, error)
 	ruleDetails.NatType = "DNAT"
 	task, err := egw.AddNATRuleAsync(ruleDetails)
 	if err != nil {
-		return nil, fmt.Errorf("error creating DNAT rule: %s", err)
+		return nil, fmt.Errorf("error creating DNAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	var createdNatRule *types.NatRule
 
 	err = egw.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	for _, natRule := range egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService.NatRule {
@@ -339,18 +338,18 @@ func (egw *EdgeGateway) AddSNATRule(networkHref, externalIP, internalIP, descrip
 		ExternalPort: "any", InternalIP: internalIP, InternalPort: "any",
 		IcmpSubType: "", Protocol: "any", Description: mappingId})
 	if err != nil {
-		return nil, fmt.Errorf("error creating SNAT rule: %s", err)
+		return nil, fmt.Errorf("error creating SNAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	var createdNatRule *types.NatRule
 
 	err = egw.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	for _, natRule := range egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService.NatRule {
@@ -387,11 +386,11 @@ func getPseudoUuid() (string, error) {
 func (egw *EdgeGateway) UpdateNatRule(natRule *types.NatRule) (*types.NatRule, error) {
 	task, err := egw.UpdateNatRuleAsync(natRule)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NAT rule: %s", err)
+		return nil, fmt.Errorf("error updating NAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	return egw.GetNatRule(natRule.ID)
@@ -409,7 +408,7 @@ func (egw *EdgeGateway) UpdateNatRuleAsync(natRule *types.NatRule) (Task, error)
 
 	err := egw.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return Task{}, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	natServiceToUpdate := egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService
@@ -441,7 +440,7 @@ func (egw *EdgeGateway) UpdateNatRuleAsync(natRule *types.NatRule) (Task, error)
 func (egw *EdgeGateway) GetNatRule(id string) (*types.NatRule, error) {
 	err := egw.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	if egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService != nil {
@@ -666,7 +665,7 @@ func (egw *EdgeGateway) AddNATPortMappingWithUplink(network *types.OrgVDCNetwork
 func (egw *EdgeGateway) CreateFirewallRules(defaultAction string, rules []*types.FirewallRule) (Task, error) {
 	err := egw.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error: %s", err)
+		return Task{}, fmt.Errorf("error: %w", err)
 	}
 
 	newRules := &types.EdgeGatewayServiceConfiguration{
@@ -681,7 +680,7 @@ func (egw *EdgeGateway) CreateFirewallRules(defaultAction string, rules []*types
 
 	output, err := xml.MarshalIndent(newRules, "  ", "    ")
 	if err != nil {
-		return Task{}, fmt.Errorf("error: %s", err)
+		return Task{}, fmt.Errorf("error: %w", err)
 	}
 
 	var resp *http.Response
@@ -703,7 +702,7 @@ func (egw *EdgeGateway) CreateFirewallRules(defaultAction string, rules []*types
 				time.Sleep(3 * time.Second)
 				continue
 			}
-			return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %s", err)
+			return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %w", err)
 		}
 		break
 	}
@@ -711,7 +710,7 @@ func (egw *EdgeGateway) CreateFirewallRules(defaultAction string, rules []*types
 	task := NewTask(egw.client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
@@ -986,7 +985,7 @@ func (egw *EdgeGateway) DeleteAsync(force bool, recursive bool) (Task, error) {
 
 	egwUrl, err := url.ParseRequestURI(egw.EdgeGateway.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing edge gateway url: %s", err)
+		return Task{}, fmt.Errorf("error parsing edge gateway url: %w", err)
 	}
 
 	req := egw.client.NewRequest(map[string]string{
@@ -995,11 +994,11 @@ func (egw *EdgeGateway) DeleteAsync(force bool, recursive bool) (Task, error) {
 	}, http.MethodDelete, *egwUrl, nil)
 	resp, err := checkResp(egw.client.Http.Do(req))
 	if err != nil {
-		return Task{}, fmt.Errorf("error deleting edge gateway: %s", err)
+		return Task{}, fmt.Errorf("error deleting edge gateway: %w", err)
 	}
 	task := NewTask(egw.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding task response: %w", err)
 	}
 	return *task, err
 }
@@ -1013,12 +1012,12 @@ func (egw *EdgeGateway) Delete(force bool, recursive bool) error {
 		return err
 	}
 	if task.Task.Status == "error" {
-		return errors.New(combinedTaskErrorMessage(task.Task, fmt.Errorf("edge gateway not properly destroyed")))
+		return combinedTaskError(task.Task, fmt.Errorf("edge gateway not properly destroyed"))
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return errors.New(combinedTaskErrorMessage(task.Task, err))
+		return combinedTaskError(task.Task, err)
 	}
 
 	return nil
@@ -1078,7 +1077,7 @@ func (egw *EdgeGateway) HasAdvancedNetworking() bool {
 func (egw *EdgeGateway) buildProxiedEdgeEndpointURL(optionalSuffix string) (string, error) {
 	apiEndpoint, err := url.ParseRequestURI(egw.EdgeGateway.HREF)
 	if err != nil {
-		return "", fmt.Errorf("unable to process edge gateway URL: %s", err)
+		return "", fmt.Errorf("unable to process edge gateway URL: %w", err)
 	}
 	edgeID := strings.Split(egw.EdgeGateway.ID, ":")
 	if len(edgeID) != 4 {
@@ -1104,7 +1103,7 @@ func (egw *EdgeGateway) GetLBGeneralParams() (*types.LbGeneralParamsWithXml, err
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	loadBalancerConfig := &types.LbGeneralParamsWithXml{}
@@ -1134,7 +1133,7 @@ func (egw *EdgeGateway) UpdateLBGeneralParams(enabled, accelerationEnabled, logg
 	// Retrieve load balancer to work on latest configuration
 	currentLb, err := egw.GetLBGeneralParams()
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve load balancer before update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve load balancer before update: %w", err)
 	}
 
 	// Check if change is needed. If not - return early.
@@ -1157,7 +1156,7 @@ func (egw *EdgeGateway) UpdateLBGeneralParams(enabled, accelerationEnabled, logg
 	// Push updated configuration
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPut, types.AnyXMLMime,
 		"error while updating load balancer config: %s", currentLb, &types.NSXError{})
@@ -1168,7 +1167,7 @@ func (egw *EdgeGateway) UpdateLBGeneralParams(enabled, accelerationEnabled, logg
 	// Retrieve configuration after update
 	updatedLb, err := egw.GetLBGeneralParams()
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve load balancer config after update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve load balancer config after update: %w", err)
 	}
 
 	return updatedLb, nil
@@ -1185,7 +1184,7 @@ func (egw *EdgeGateway) GetFirewallConfig() (*types.FirewallConfigWithXml, error
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	firewallConfig := &types.FirewallConfigWithXml{}
@@ -1216,7 +1215,7 @@ func (egw *EdgeGateway) UpdateFirewallConfig(enabled, defaultLoggingEnabled bool
 	// Retrieve firewall latest configuration
 	currentFw, err := egw.GetFirewallConfig()
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve firewall config before update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve firewall config before update: %w", err)
 	}
 
 	// Check if change is needed. If not - return early.
@@ -1236,7 +1235,7 @@ func (egw *EdgeGateway) UpdateFirewallConfig(enabled, defaultLoggingEnabled bool
 	// Push updated configuration
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPut, types.AnyXMLMime,
 		"error while updating firewall configuration : %s", currentFw, &types.NSXError{})
@@ -1247,7 +1246,7 @@ func (egw *EdgeGateway) UpdateFirewallConfig(enabled, defaultLoggingEnabled bool
 	// Retrieve configuration after update
 	updatedFw, err := egw.GetFirewallConfig()
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve firewall after update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve firewall after update: %w", err)
 	}
 
 	return updatedFw, nil
@@ -1272,7 +1271,7 @@ func (egw *EdgeGateway) getVdcNetworks() (*types.EdgeGatewayInterfaces, error) {
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL("/vdcNetworks")
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	vnicConfig := &types.EdgeGatewayInterfaces{}
@@ -1292,7 +1291,7 @@ func (egw *EdgeGateway) getVdcNetworks() (*types.EdgeGatewayInterfaces, error) {
 func (egw *EdgeGateway) GetVnicIndexByNetworkNameAndType(networkName, networkType string) (*int, error) {
 	vnics, err := egw.getVdcNetworks()
 	if err != nil {
-		return nil, fmt.Errorf("cannot retrieve vNic configuration: %s", err)
+		return nil, fmt.Errorf("cannot retrieve vNic configuration: %w", err)
 	}
 	return getVnicIndexByNetworkNameAndType(networkName, networkType, vnics)
 }
@@ -1307,7 +1306,7 @@ func (egw *EdgeGateway) GetVnicIndexByNetworkNameAndType(networkName, networkTyp
 func (egw *EdgeGateway) GetAnyVnicIndexByNetworkName(networkName string) (*int, string, error) {
 	vnics, err := egw.getVdcNetworks()
 	if err != nil {
-		return nil, "", fmt.Errorf("cannot retrieve vNic configuration: %s", err)
+		return nil, "", fmt.Errorf("cannot retrieve vNic configuration: %w", err)
 	}
 
 	var foundVnicIndex *int
@@ -1336,7 +1335,7 @@ func (egw *EdgeGateway) GetAnyVnicIndexByNetworkName(networkName string) (*int,
 func (egw *EdgeGateway) GetNetworkNameAndTypeByVnicIndex(vNicIndex int) (string, string, error) {
 	vnics, err := egw.getVdcNetworks()
 	if err != nil {
-		return "", "", fmt.Errorf("cannot retrieve vNic configuration: %s", err)
+		return "", "", fmt.Errorf("cannot retrieve vNic configuration: %w", err)
 	}
 	return getNetworkNameAndTypeByVnicIndex(vNicIndex, vnics)
 }
@@ -47,13 +47,13 @@ func (ejectTask *EjectTask) WaitInspectTaskCompletion(isAnswerYes bool, delay ti
 	for {
 		err := ejectTask.Refresh()
 		if err != nil {
-			return fmt.Errorf("error retrieving task: %s", err)
+			return fmt.Errorf("error retrieving task: %w", err)
 		}
 
 		// If task is not in a waiting status we're done, check if there's an error and return it.
 		if ejectTask.Task.Task.Status != "queued" && ejectTask.Task.Task.Status != "preRunning" && ejectTask.Task.Task.Status != "running" {
 			if ejectTask.Task.Task.Status == "error" {
-				return fmt.Errorf("task did not complete succesfully: %s", ejectTask.Task.Task.Error.Message)
+				return newTaskError(ejectTask.Task.Task, "task did not complete succesfully: "+ejectTask.Task.Task.Error.Message)
 			}
 			return nil
 		}
@@ -39,12 +39,40 @@ func (client *Client) PostEntity(urlRef *url.URL, params url.Values, payload, ou
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after POST: %s", err)
+		return fmt.Errorf("error decoding JSON response after POST: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
+	}
+
+	return nil
+}
+
+func (client *Client) PutEntity(urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) error {
+	// copy passed in URL ref so that it is not mutated
+	urlRefCopy := copyUrlRef(urlRef)
+
+	util.Logger.Printf("[TRACE] Putting %s item to endpoint %s with expected response of type %s",
+		reflect.TypeOf(payload), urlRefCopy.String(), reflect.TypeOf(outType))
+
+	if !client.IsTm() {
+		return fmt.Errorf("Client is not supported on this version")
+	}
+
+	resp, err := client.performEntityPostPut(http.MethodPut, urlRefCopy, params, payload, additionalHeader)
+	if err != nil {
+		return err
+	}
+
+	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
+		return fmt.Errorf("error decoding JSON response after PUT: %w", err)
+	}
+
+	err = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -64,7 +92,7 @@ func (client *Client) GetEntity(urlRef *url.URL, params url.Values, outType inte
 	req := client.newEntityRequest(params, http.MethodGet, urlRefCopy, nil, additionalHeader)
 	resp, err := client.Http.Do(req)
 	if err != nil {
-		return fmt.Errorf("error performing GET request to %s: %s", urlRefCopy.String(), err)
+		return fmt.Errorf("error performing GET request to %s: %w", urlRefCopy.String(), err)
 	}
 	defer resp.Body.Close()
 
@@ -73,7 +101,7 @@ func (client *Client) GetEntity(urlRef *url.URL, params url.Values, outType inte
 	if resp.StatusCode == http.StatusNotFound {
 		err := ParseErr(types.BodyTypeJSON, resp, &ccitypes.ApiError{})
 		closeErr := resp.Body.Close()
-		return fmt.Errorf("%s: %s [body close error: %s]", ErrorEntityNotFound, err, closeErr)
+		return fmt.Errorf("%w: %w [body close error: %w]", ErrorEntityNotFound, err, closeErr)
 	}
 
 	// resp is ignored below because it is the same as above
@@ -81,15 +109,15 @@ func (client *Client) GetEntity(urlRef *url.URL, params url.Values, outType inte
 
 	// Any other error occurred
 	if err != nil {
-		return fmt.Errorf("error in HTTP GET request for: %s", err)
+		return fmt.Errorf("error in HTTP GET request for: %w", err)
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after GET: %s", err)
+		return fmt.Errorf("error decoding JSON response after GET: %w", err)
 	}
 
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -122,12 +150,12 @@ func (client *Client) DeleteEntity(urlRef *url.URL, params url.Values, additiona
 	// resp is ignored below because it would be the same as above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &ccitypes.ApiError{})
 	if err != nil {
-		return fmt.Errorf("error in HTTP DELETE request: %s", err)
+		return fmt.Errorf("error in HTTP DELETE request: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -139,7 +167,7 @@ func (client *Client) performEntityPostPut(httpMethod string, urlRef *url.URL, p
 	if payload != nil {
 		marshaledJson, err := json.MarshalIndent(payload, "", "  ")
 		if err != nil {
-			return nil, fmt.Errorf("error marshalling JSON data for %s request %s", httpMethod, err)
+			return nil, fmt.Errorf("error marshalling JSON data for %s request %w", httpMethod, err)
 		}
 		body = bytes.NewBuffer(marshaledJson)
 	}
@@ -153,7 +181,7 @@ func (client *Client) performEntityPostPut(httpMethod string, urlRef *url.URL, p
 	// resp is ignored below because it is the same the one above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &ccitypes.ApiError{})
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP %s request: %s", httpMethod, err)
+		return nil, fmt.Errorf("error in HTTP %s request: %w", httpMethod, err)
 	}
 	return resp, nil
 }
@@ -182,15 +210,8 @@ func (client *Client) newEntityRequest(params url.Values, method string, reqUrl
 		util.Logger.Printf("[DEBUG - newEntityRequest] error getting new request: %s", err)
 	}
 
-	if client.VCDAuthHeader != "" && client.VCDToken != "" {
-		// Add the authorization header
-		req.Header.Add(client.VCDAuthHeader, client.VCDToken)
-		// The deprecated authorization token is 32 characters long
-		// The bearer token is 612 characters long
-		if len(client.VCDToken) > 32 {
-			req.Header.Add("Authorization", "bearer "+client.VCDToken)
-			req.Header.Add("X-Vmware-Vcloud-Token-Type", "Bearer")
-		}
+	if authHeader, token := client.authorization(); authHeader != "" && token != "" {
+		addAuthorizationHeaders(req.Header, authHeader, token)
 	}
 
 	for k, v := range client.customHeader {
@@ -0,0 +1,321 @@
+package govcd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntityWatchEventType is the type of an EntityWatchEvent, as defined by the Kubernetes watch API
+type EntityWatchEventType string
+
+const (
+	EntityWatchAdded    EntityWatchEventType = "ADDED"
+	EntityWatchModified EntityWatchEventType = "MODIFIED"
+	EntityWatchDeleted  EntityWatchEventType = "DELETED"
+	// EntityWatchError is sent when the watch fails. It is the last event before the channel is
+	// closed
+	EntityWatchError EntityWatchEventType = "ERROR"
+
+	// entityWatchBookmark only updates the resource version of the watch: it is not sent to callers
+	entityWatchBookmark EntityWatchEventType = "BOOKMARK"
+)
+
+// entityWatchTimeoutSeconds is the duration of each watch request. It is shorter than the default
+// HTTP timeout of the client, so that the server ends the stream and the watch is resumed
+const entityWatchTimeoutSeconds = 300
+
+// entityWatchReconnectDelay is the pause before a watch is resumed after the stream ended
+var entityWatchReconnectDelay = time.Second
+
+// EntityWatchEvent is a change of an entity received by Client.WatchEntity
+type EntityWatchEvent struct {
+	Type EntityWatchEventType
+	// Object is the JSON representation of the entity. Use Decode to convert it
+	Object json.RawMessage
+	// Err is set for events of type EntityWatchError
+	Err error
+}
+
+// Decode unmarshals the entity of the event into outType, e.g. a *ccitypes.SupervisorNamespace
+func (event EntityWatchEvent) Decode(outType interface{}) error {
+	if len(event.Object) == 0 {
+		return fmt.Errorf("%s event has no object", event.Type)
+	}
+	return json.Unmarshal(event.Object, outType)
+}
+
+// CciWatchEvent is a change of a typed CCI entity, such as ccitypes.Project or
+// ccitypes.SupervisorNamespace
+type CciWatchEvent[T any] struct {
+	Type   EntityWatchEventType
+	Object *T
+	Err    error
+}
+
+// entityWatchFrame is a single event of the watch stream
+type entityWatchFrame struct {
+	Type   EntityWatchEventType `json:"type"`
+	Object json.RawMessage      `json:"object"`
+}
+
+// entityWatchList is a list response, decoded only as far as needed by the watch
+type entityWatchList struct {
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []json.RawMessage `json:"items"`
+}
+
+// entityWatchObject is an entity, decoded only as far as needed by the watch
+type entityWatchObject struct {
+	v1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// errEntityWatchExpired reports that the resource version of the watch is too old (HTTP 410 Gone)
+var errEntityWatchExpired = errors.New("resource version of the watch expired")
+
+// WatchEntity watches the collection of entities at urlRef, e.g. the URL of the Supervisor
+// Namespaces of a Project (see Client.GetEntityUrl), and sends their changes to the returned
+// channel.
+//
+// When resourceVersion is empty, the collection is listed first and an EntityWatchAdded event is
+// sent for each entity. Otherwise, only the changes after resourceVersion are sent.
+//
+// When the server reports that the resource version expired (HTTP 410 Gone), the collection is
+// listed again and the differences with the entities known to the watch are sent as added, modified
+// and deleted events. When the stream ends, the watch is resumed from the last resource version.
+//
+// The channel is closed when ctx is done. If the watch fails, an EntityWatchError event is sent
+// before the channel is closed
+func (client *Client) WatchEntity(ctx context.Context, urlRef *url.URL, resourceVersion string) (<-chan EntityWatchEvent, error) {
+	if !client.IsTm() {
+		return nil, fmt.Errorf("Client is not supported on this version")
+	}
+	if urlRef == nil {
+		return nil, fmt.Errorf("watch requires a URL")
+	}
+
+	watcher := &entityWatcher{
+		client:          client,
+		urlRef:          copyUrlRef(urlRef),
+		resourceVersion: resourceVersion,
+		known:           make(map[string]entityWatchKnown),
+		events:          make(chan EntityWatchEvent, 16),
+	}
+	go watcher.run(ctx)
+	return watcher.events, nil
+}
+
+// watchCciEntities converts the events of Client.WatchEntity to typed events
+func watchCciEntities[T any](ctx context.Context, client *Client, urlRef *url.URL, resourceVersion string) (<-chan CciWatchEvent[T], error) {
+	rawEvents, err := client.WatchEntity(ctx, urlRef, resourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan CciWatchEvent[T])
+	go func() {
+		defer close(events)
+		for rawEvent := range rawEvents {
+			event := CciWatchEvent[T]{Type: rawEvent.Type, Err: rawEvent.Err}
+			if rawEvent.Type != EntityWatchError {
+				event.Object = new(T)
+				err := rawEvent.Decode(event.Object)
+				if err != nil {
+					event = CciWatchEvent[T]{Type: EntityWatchError, Err: fmt.Errorf("error decoding %s event: %w", rawEvent.Type, err)}
+				}
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// entityWatchKnown is the last known state of an entity of the watch
+type entityWatchKnown struct {
+	resourceVersion string
+	object          json.RawMessage
+}
+
+// entityWatcher keeps the state of a running watch
+type entityWatcher struct {
+	client          *Client
+	urlRef          *url.URL
+	resourceVersion string
+	// known contains the entities seen by the watch, by namespace and name, to compute the
+	// differences when the collection is listed again
+	known  map[string]entityWatchKnown
+	events chan EntityWatchEvent
+}
+
+func (watcher *entityWatcher) run(ctx context.Context) {
+	defer close(watcher.events)
+
+	relist := watcher.resourceVersion == ""
+	for ctx.Err() == nil {
+		if relist {
+			err := watcher.relist(ctx)
+			if err != nil {
+				watcher.fail(ctx, err)
+				return
+			}
+			relist = false
+		}
+
+		err := watcher.watch(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case errors.Is(err, errEntityWatchExpired):
+			util.Logger.Printf("[DEBUG] watch of %s expired at resource version %s, listing again", watcher.urlRef, watcher.resourceVersion)
+			relist = true
+		case err != nil:
+			watcher.fail(ctx, err)
+			return
+		default:
+			util.Logger.Printf("[TRACE] watch stream of %s ended, resuming from resource version %s", watcher.urlRef, watcher.resourceVersion)
+			select {
+			case <-time.After(entityWatchReconnectDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// relist lists the collection and sends the differences with the known entities
+func (watcher *entityWatcher) relist(ctx context.Context) error {
+	list := &entityWatchList{}
+	err := watcher.client.GetEntity(watcher.urlRef, nil, list, nil)
+	if err != nil {
+		return fmt.Errorf("error listing %s: %w", watcher.urlRef, err)
+	}
+
+	current := make(map[string]bool)
+	for _, item := range list.Items {
+		key, itemVersion, err := entityWatchKey(item)
+		if err != nil {
+			return err
+		}
+		current[key] = true
+		previous, found := watcher.known[key]
+		watcher.known[key] = entityWatchKnown{resourceVersion: itemVersion, object: item}
+		switch {
+		case !found:
+			watcher.send(ctx, EntityWatchEvent{Type: EntityWatchAdded, Object: item})
+		case previous.resourceVersion != itemVersion:
+			watcher.send(ctx, EntityWatchEvent{Type: EntityWatchModified, Object: item})
+		}
+	}
+	for key, previous := range watcher.known {
+		if !current[key] {
+			delete(watcher.known, key)
+			watcher.send(ctx, EntityWatchEvent{Type: EntityWatchDeleted, Object: previous.object})
+		}
+	}
+	watcher.resourceVersion = list.ResourceVersion
+	return nil
+}
+
+// watch reads the watch stream until it ends. It returns nil when the stream ends normally, and
+// errEntityWatchExpired when the resource version is too old
+func (watcher *entityWatcher) watch(ctx context.Context) error {
+	params := url.Values{}
+	params.Set("watch", "true")
+	params.Set("allowWatchBookmarks", "true")
+	params.Set("timeoutSeconds", strconv.Itoa(entityWatchTimeoutSeconds))
+	if watcher.resourceVersion != "" {
+		params.Set("resourceVersion", watcher.resourceVersion)
+	}
+	req := watcher.client.newEntityRequest(params, http.MethodGet, watcher.urlRef, nil, nil).WithContext(ctx)
+	resp, err := watcher.client.Http.Do(req)
+	if err != nil {
+		return fmt.Errorf("error starting watch of %s: %w", watcher.urlRef, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusGone {
+		return errEntityWatchExpired
+	}
+	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, nil, &ccitypes.ApiError{})
+	if err != nil {
+		return fmt.Errorf("error starting watch of %s: %w", watcher.urlRef, err)
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(resp.Body))
+	for {
+		frame := entityWatchFrame{}
+		err := decoder.Decode(&frame)
+		if err != nil {
+			// The end of the stream, or a broken connection, resumes the watch
+			util.Logger.Printf("[TRACE] watch stream of %s stopped: %s", watcher.urlRef, err)
+			return nil
+		}
+
+		switch frame.Type {
+		case EntityWatchError:
+			status := &v1.Status{}
+			err = json.Unmarshal(frame.Object, status)
+			if err == nil && (status.Code == http.StatusGone || status.Reason == v1.StatusReasonExpired || status.Reason == v1.StatusReasonGone) {
+				return errEntityWatchExpired
+			}
+			return fmt.Errorf("watch of %s failed: %w", watcher.urlRef, ccitypes.ApiError{Status: *status})
+		case entityWatchBookmark:
+			object := &entityWatchObject{}
+			if json.Unmarshal(frame.Object, object) == nil && object.ResourceVersion != "" {
+				watcher.resourceVersion = object.ResourceVersion
+			}
+		case EntityWatchAdded, EntityWatchModified, EntityWatchDeleted:
+			key, itemVersion, err := entityWatchKey(frame.Object)
+			if err != nil {
+				return err
+			}
+			if frame.Type == EntityWatchDeleted {
+				delete(watcher.known, key)
+			} else {
+				watcher.known[key] = entityWatchKnown{resourceVersion: itemVersion, object: frame.Object}
+			}
+			if itemVersion != "" {
+				watcher.resourceVersion = itemVersion
+			}
+			watcher.send(ctx, EntityWatchEvent{Type: frame.Type, Object: frame.Object})
+		default:
+			util.Logger.Printf("[DEBUG] ignoring watch event of type %s for %s", frame.Type, watcher.urlRef)
+		}
+	}
+}
+
+// entityWatchKey returns the namespace and name of an entity, and its resource version
+func entityWatchKey(raw json.RawMessage) (string, string, error) {
+	object := &entityWatchObject{}
+	err := json.Unmarshal(raw, object)
+	if err != nil {
+		return "", "", fmt.Errorf("error decoding watched entity: %w", err)
+	}
+	return object.Namespace + "/" + object.Name, object.ResourceVersion, nil
+}
+
+func (watcher *entityWatcher) send(ctx context.Context, event EntityWatchEvent) {
+	select {
+	case watcher.events <- event:
+	case <-ctx.Done():
+	}
+}
+
+func (watcher *entityWatcher) fail(ctx context.Context, err error) {
+	util.Logger.Printf("[DEBUG] watch of %s failed: %s", watcher.urlRef, err)
+	watcher.send(ctx, EntityWatchEvent{Type: EntityWatchError, Err: err})
+}
@@ -0,0 +1,153 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func watchTestNamespace(name, resourceVersion string) ccitypes.SupervisorNamespace {
+	return ccitypes.SupervisorNamespace{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "project1", ResourceVersion: resourceVersion}}
+}
+
+func writeWatchFrame(w http.ResponseWriter, eventType string, object any) {
+	frame, _ := json.Marshal(map[string]any{"type": eventType, "object": object})
+	_, _ = fmt.Fprintf(w, "%s\n", frame)
+	w.(http.Flusher).Flush()
+}
+
+func TestVCDClient_WatchSupervisorNamespaces(t *testing.T) {
+	var lock sync.Mutex
+	var watchVersions []string
+	lists := []ccitypes.SupervisorNamespaceList{
+		{ListMeta: v1.ListMeta{ResourceVersion: "5"}, Items: []ccitypes.SupervisorNamespace{
+			watchTestNamespace("ns-a", "1"), watchTestNamespace("ns-b", "2"),
+		}},
+		{ListMeta: v1.ListMeta{ResourceVersion: "10"}, Items: []ccitypes.SupervisorNamespace{
+			watchTestNamespace("ns-a", "7"), watchTestNamespace("ns-b", "9"), watchTestNamespace("ns-d", "10"),
+		}},
+	}
+	listCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") != "true" {
+			list := lists[min(listCount, len(lists)-1)]
+			listCount++
+			lock.Unlock()
+			_ = json.NewEncoder(w).Encode(list)
+			return
+		}
+		watchVersions = append(watchVersions, r.URL.Query().Get("resourceVersion"))
+		watchCount := len(watchVersions)
+		lock.Unlock()
+
+		switch watchCount {
+		case 1:
+			writeWatchFrame(w, "ADDED", watchTestNamespace("ns-c", "6"))
+			writeWatchFrame(w, "MODIFIED", watchTestNamespace("ns-a", "7"))
+			writeWatchFrame(w, "BOOKMARK", watchTestNamespace("", "8"))
+			writeWatchFrame(w, "ERROR", v1.Status{Status: "Failure", Code: http.StatusGone, Reason: v1.StatusReasonExpired})
+		case 2:
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(v1.Status{Status: "Failure", Code: http.StatusGone, Reason: v1.StatusReasonGone})
+		default:
+			writeWatchFrame(w, "DELETED", watchTestNamespace("ns-d", "11"))
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+	vcdClient := testContextClient(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := vcdClient.WatchSupervisorNamespaces(ctx, "project1", "")
+	if err != nil {
+		t.Fatalf("error starting watch: %s", err)
+	}
+
+	expected := []string{
+		// Initial list
+		"ADDED ns-a", "ADDED ns-b",
+		// First watch
+		"ADDED ns-c", "MODIFIED ns-a",
+		// List after the expiration
+		"MODIFIED ns-b", "ADDED ns-d", "DELETED ns-c",
+		// Watch after the second list, which has no changes
+		"DELETED ns-d",
+	}
+	var received []string
+	timeout := time.After(10 * time.Second)
+	for len(received) < len(expected) {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("channel closed after events %v", received)
+			}
+			if event.Type == EntityWatchError {
+				t.Fatalf("unexpected error event: %s", event.Err)
+			}
+			received = append(received, string(event.Type)+" "+event.Object.Name)
+		case <-timeout:
+			t.Fatalf("timeout waiting for events, got %v", received)
+		}
+	}
+	if strings.Join(received, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected events %v, got %v", expected, received)
+	}
+
+	lock.Lock()
+	if strings.Join(watchVersions, ",") != "5,10,10" {
+		t.Errorf("unexpected resource versions of the watch requests %v", watchVersions)
+	}
+	lock.Unlock()
+
+	// The channel is closed when the context is cancelled
+	cancel()
+	select {
+	case event, ok := <-events:
+		if ok {
+			t.Errorf("expected the channel to be closed, got event %s", event.Type)
+		}
+	case <-time.After(10 * time.Second):
+		t.Errorf("channel not closed after cancellation")
+	}
+}
+
+func TestClient_WatchEntityError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(v1.Status{Status: "Failure", Code: http.StatusForbidden, Reason: v1.StatusReasonForbidden, Message: "no access"})
+	}))
+	defer server.Close()
+	vcdClient := testContextClient(t, server.URL)
+
+	urlRef, err := vcdClient.Client.GetEntityUrl(ccitypes.ProjectsURL)
+	if err != nil {
+		t.Fatalf("error building URL: %s", err)
+	}
+	events, err := vcdClient.Client.WatchEntity(context.Background(), urlRef, "42")
+	if err != nil {
+		t.Fatalf("error starting watch: %s", err)
+	}
+	event := <-events
+	if event.Type != EntityWatchError || event.Err == nil || !strings.Contains(event.Err.Error(), "no access") {
+		t.Errorf("expected an error event, got %s %v", event.Type, event.Err)
+	}
+	if _, ok := <-events; ok {
+		t.Errorf("expected the channel to be closed after the error")
+	}
+}
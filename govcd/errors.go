@@ -0,0 +1,171 @@
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// Sentinel errors that can be checked using errors.Is. Errors returned by API calls and task
+// waiting functions wrap them (using ApiError and TaskError) while preserving original error
+// messages. E.g.:
+//
+//	if errors.Is(err, ErrBusyEntity) {
+//	   // retry the operation later
+//	}
+var (
+	// ErrNotFound is the same error as ErrorEntityNotFound. It also matches API responses with HTTP
+	// status 404
+	ErrNotFound = ErrorEntityNotFound
+	// ErrConflict matches API responses with HTTP status 409 (Conflict) or 412 (Precondition Failed)
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized matches API responses with HTTP status 401
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrBusyEntity matches API responses and failed tasks with minor error code BUSY_ENTITY
+	ErrBusyEntity = errors.New("entity is busy")
+	// ErrTaskFailed matches errors of tasks that finished with status 'error'
+	ErrTaskFailed = errors.New("task failed")
+)
+
+// minorErrorCodeBusyEntity is the minor error code that VCD returns when an entity is busy with
+// another operation
+const minorErrorCodeBusyEntity = "BUSY_ENTITY"
+
+// ApiError is returned for API responses with unsuccessful HTTP status. Its message is the same as
+// the one of the parsed error body, but it additionally carries response details. It can be matched
+// using errors.Is against sentinel errors (ErrNotFound, ErrConflict, ErrUnauthorized, ErrBusyEntity)
+// and using errors.As against the parsed error body (e.g. *types.Error, *types.OpenApiError).
+//
+//	var apiErr *ApiError
+//	if errors.As(err, &apiErr) {
+//	   fmt.Printf("request %s failed with HTTP status %d", apiErr.RequestId, apiErr.HttpStatusCode)
+//	}
+type ApiError struct {
+	// HttpStatusCode of the API response (e.g. 404)
+	HttpStatusCode int
+	// MajorErrorCode as returned in the error body (it is only returned by XML API)
+	MajorErrorCode int
+	// MinorErrorCode as returned in the error body (e.g. BUSY_ENTITY)
+	MinorErrorCode string
+	// RequestId is the value of 'X-Vmware-Vcloud-Request-Id' response header which can be used to
+	// find the request in VCD logs
+	RequestId string
+	// Err is the parsed error body
+	Err error
+}
+
+// Error returns the message of the parsed error body
+func (apiError *ApiError) Error() string {
+	if apiError.Err == nil {
+		return fmt.Sprintf("API error: HTTP status %d", apiError.HttpStatusCode)
+	}
+	return apiError.Err.Error()
+}
+
+// Unwrap allows errors.Is and errors.As to match both the sentinel error and the parsed error body
+func (apiError *ApiError) Unwrap() []error {
+	var unwrapped []error
+	if sentinel := sentinelError(apiError.HttpStatusCode, apiError.MinorErrorCode); sentinel != nil {
+		unwrapped = append(unwrapped, sentinel)
+	}
+	if apiError.Err != nil {
+		unwrapped = append(unwrapped, apiError.Err)
+	}
+	return unwrapped
+}
+
+// newApiError wraps an error parsed from the body of unsuccessful API response into ApiError
+func newApiError(resp *http.Response, err error) *ApiError {
+	apiError := &ApiError{
+		HttpStatusCode: resp.StatusCode,
+		RequestId:      resp.Header.Get("X-Vmware-Vcloud-Request-Id"),
+		Err:            err,
+	}
+
+	switch typedErr := err.(type) {
+	case *types.Error:
+		apiError.MajorErrorCode = typedErr.MajorErrorCode
+		apiError.MinorErrorCode = typedErr.MinorErrorCode
+	case *types.OpenApiError:
+		apiError.MinorErrorCode = typedErr.MinorErrorCode
+	}
+
+	return apiError
+}
+
+// TaskError is returned when a task finishes with status 'error'. It can be matched using
+// errors.Is against ErrTaskFailed and, depending on the task error, other sentinel errors (e.g.
+// ErrBusyEntity)
+type TaskError struct {
+	// Task is the failed task
+	Task *types.Task
+	// MajorErrorCode of the task error (it usually matches HTTP status codes)
+	MajorErrorCode int
+	// MinorErrorCode of the task error (e.g. BUSY_ENTITY)
+	MinorErrorCode string
+
+	message string
+}
+
+// Error returns the error message including details of task error
+func (taskError *TaskError) Error() string {
+	return taskError.message
+}
+
+// Unwrap allows errors.Is to match ErrTaskFailed and other sentinel errors
+func (taskError *TaskError) Unwrap() []error {
+	unwrapped := []error{ErrTaskFailed}
+	if sentinel := sentinelError(taskError.MajorErrorCode, taskError.MinorErrorCode); sentinel != nil {
+		unwrapped = append(unwrapped, sentinel)
+	}
+	return unwrapped
+}
+
+// newTaskError creates a TaskError for a failed task
+func newTaskError(task *types.Task, message string) *TaskError {
+	taskError := &TaskError{Task: task, message: message}
+	if task != nil && task.Error != nil {
+		taskError.MajorErrorCode = task.Error.MajorErrorCode
+		taskError.MinorErrorCode = task.Error.MinorErrorCode
+	}
+	return taskError
+}
+
+// sentinelError returns a sentinel error matching given HTTP status code and minor error code or
+// nil if there is none
+func sentinelError(httpStatusCode int, minorErrorCode string) error {
+	switch {
+	case minorErrorCode == minorErrorCodeBusyEntity:
+		return ErrBusyEntity
+	case httpStatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case httpStatusCode == http.StatusConflict, httpStatusCode == http.StatusPreconditionFailed:
+		return ErrConflict
+	case httpStatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// messageError is an error with a custom message that still wraps the original error
+type messageError struct {
+	message string
+	err     error
+}
+
+func (messageError *messageError) Error() string {
+	return messageError.message
+}
+
+func (messageError *messageError) Unwrap() error {
+	return messageError.err
+}
+
+// wrapErrorMessage formats a caller supplied error message containing a single placeholder (e.g.
+// "error updating disk: %s") with a given error. The resulting message is the same as with
+// fmt.Errorf, but the original error is preserved for errors.Is and errors.As
+func wrapErrorMessage(errorMessage string, err error) error {
+	return &messageError{message: fmt.Sprintf(errorMessage, err), err: err}
+}
@@ -0,0 +1,186 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func TestParseErr(t *testing.T) {
+	tests := []struct {
+		name             string
+		bodyType         types.BodyType
+		statusCode       int
+		body             string
+		errType          error
+		expectedMessage  string
+		expectedMinor    string
+		expectedSentinel error
+	}{
+		{
+			name:             "XmlNotFound",
+			bodyType:         types.BodyTypeXML,
+			statusCode:       http.StatusNotFound,
+			body:             `<Error xmlns="http://www.vmware.com/vcloud/v1.5" minorErrorCode="RESOURCE_NOT_FOUND" message="[ abc ] No access to entity" majorErrorCode="404"></Error>`,
+			errType:          &types.Error{},
+			expectedMessage:  "API Error: 404: [ abc ] No access to entity",
+			expectedMinor:    "RESOURCE_NOT_FOUND",
+			expectedSentinel: ErrNotFound,
+		},
+		{
+			name:             "XmlBusyEntity",
+			bodyType:         types.BodyTypeXML,
+			statusCode:       http.StatusBadRequest,
+			body:             `<Error xmlns="http://www.vmware.com/vcloud/v1.5" minorErrorCode="BUSY_ENTITY" message="busy" majorErrorCode="400"></Error>`,
+			errType:          &types.Error{},
+			expectedMessage:  "API Error: 400: busy",
+			expectedMinor:    "BUSY_ENTITY",
+			expectedSentinel: ErrBusyEntity,
+		},
+		{
+			name:             "JsonConflict",
+			bodyType:         types.BodyTypeJSON,
+			statusCode:       http.StatusConflict,
+			body:             `{"minorErrorCode":"DUPLICATE_NAME","message":"name is already used"}`,
+			errType:          &types.OpenApiError{},
+			expectedMessage:  "DUPLICATE_NAME - name is already used",
+			expectedMinor:    "DUPLICATE_NAME",
+			expectedSentinel: ErrConflict,
+		},
+		{
+			name:             "JsonUnauthorized",
+			bodyType:         types.BodyTypeJSON,
+			statusCode:       http.StatusUnauthorized,
+			body:             `{"minorErrorCode":"UNAUTHORIZED","message":"not logged in"}`,
+			errType:          &types.OpenApiError{},
+			expectedMessage:  "UNAUTHORIZED - not logged in",
+			expectedMinor:    "UNAUTHORIZED",
+			expectedSentinel: ErrUnauthorized,
+		},
+		{
+			name:            "EmptyBody",
+			bodyType:        types.BodyTypeXML,
+			statusCode:      http.StatusRequestedRangeNotSatisfiable,
+			errType:         &types.Error{},
+			expectedMessage: "416 Requested Range Not Satisfiable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("X-Vmware-Vcloud-Request-Id", "request-id-1")
+			recorder.WriteHeader(tt.statusCode)
+			_, _ = recorder.WriteString(tt.body)
+
+			err := ParseErr(tt.bodyType, recorder.Result(), tt.errType)
+			if err.Error() != tt.expectedMessage {
+				t.Errorf("expected message '%s', got '%s'", tt.expectedMessage, err.Error())
+			}
+
+			var apiError *ApiError
+			if !errors.As(err, &apiError) {
+				t.Fatalf("expected *ApiError, got %T", err)
+			}
+			if apiError.HttpStatusCode != tt.statusCode || apiError.MinorErrorCode != tt.expectedMinor || apiError.RequestId != "request-id-1" {
+				t.Errorf("unexpected error details %#v", apiError)
+			}
+
+			for _, sentinel := range []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrBusyEntity, ErrTaskFailed} {
+				if errors.Is(err, sentinel) != (sentinel == tt.expectedSentinel) {
+					t.Errorf("unexpected result of errors.Is(err, %q)", sentinel)
+				}
+			}
+
+			// Wrapping must preserve the error type
+			wrappedErr := fmt.Errorf("error performing operation: %w", err)
+			if tt.expectedSentinel != nil && !errors.Is(wrappedErr, tt.expectedSentinel) {
+				t.Errorf("wrapped error does not match %q", tt.expectedSentinel)
+			}
+			var xmlError *types.Error
+			var jsonError *types.OpenApiError
+			if tt.body != "" && !errors.As(wrappedErr, &xmlError) && !errors.As(wrappedErr, &jsonError) {
+				t.Errorf("wrapped error does not match parsed error body of type %T", tt.errType)
+			}
+		})
+	}
+}
+
+func TestClient_ExecuteRequestNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.MimeError)
+		w.Header().Set("X-Vmware-Vcloud-Request-Id", "request-id-2")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error xmlns="http://www.vmware.com/vcloud/v1.5" minorErrorCode="RESOURCE_NOT_FOUND" message="not found" majorErrorCode="404"></Error>`))
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	_, err := vcdClient.Client.ExecuteRequest(server.URL+"/api/vdc/1", http.MethodGet, "",
+		"error retrieving VDC: %s", nil, &types.Vdc{})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if err.Error() != "error retrieving VDC: API Error: 404: not found" {
+		t.Errorf("unexpected error message '%s'", err)
+	}
+	if !errors.Is(err, ErrNotFound) || !IsNotFound(err) || !ContainsNotFound(err) {
+		t.Errorf("expected a not found error, got %s", err)
+	}
+
+	var apiError *ApiError
+	if !errors.As(err, &apiError) || apiError.RequestId != "request-id-2" {
+		t.Errorf("expected *ApiError with request ID, got %#v", apiError)
+	}
+}
+
+func TestTask_WaitTaskCompletionFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="error" name="task" ` +
+			`href="` + "http://" + r.Host + r.URL.Path + `">` +
+			`<Error minorErrorCode="BUSY_ENTITY" message="entity is busy" majorErrorCode="400"></Error></Task>`))
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	task := NewTask(&vcdClient.Client)
+	task.Task.HREF = server.URL + "/api/task/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6"
+
+	err := task.WaitTaskCompletion()
+	if err == nil {
+		t.Fatalf("expected an error for a failed task")
+	}
+	if !strings.Contains(err.Error(), "task did not complete successfully") {
+		t.Errorf("unexpected error message '%s'", err)
+	}
+	if !errors.Is(err, ErrTaskFailed) || !errors.Is(err, ErrBusyEntity) {
+		t.Errorf("expected error to match ErrTaskFailed and ErrBusyEntity, got %s", err)
+	}
+
+	var taskError *TaskError
+	if !errors.As(err, &taskError) || taskError.Task != task.Task || taskError.MinorErrorCode != "BUSY_ENTITY" {
+		t.Errorf("expected *TaskError with task details, got %#v", taskError)
+	}
+}
+
+func TestCombinedTaskError(t *testing.T) {
+	task := &types.Task{Error: &types.Error{MajorErrorCode: 400, MinorErrorCode: "BUSY_ENTITY", Message: "entity is busy"}}
+	taskError := newTaskError(task, "task did not complete successfully")
+
+	err := combinedTaskError(task, taskError)
+	if err.Error() != "operation error: task did not complete successfully - task error: [400 - BUSY_ENTITY] entity is busy" {
+		t.Errorf("unexpected error message '%s'", err)
+	}
+	var unwrapped *TaskError
+	if !errors.Is(err, ErrTaskFailed) || !errors.As(err, &unwrapped) || unwrapped != taskError {
+		t.Errorf("expected error to wrap the task error, got %#v", err)
+	}
+}
@@ -42,7 +42,7 @@ func CreateExternalNetworkV2(vcdClient *VCDClient, newExtNet *types.ExternalNetw
 
 	err = vcdClient.Client.OpenApiPostItem(apiVersion, urlRef, nil, newExtNet, returnExtNet.ExternalNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating external network: %s", err)
+		return nil, fmt.Errorf("error creating external network: %w", err)
 	}
 
 	return returnExtNet, nil
@@ -91,11 +91,11 @@ func GetExternalNetworkV2ByName(vcdClient *VCDClient, name string) (*ExternalNet
 
 	res, err := GetAllExternalNetworksV2(vcdClient, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("could not find external network by name: %s", err)
+		return nil, fmt.Errorf("could not find external network by name: %w", err)
 	}
 
 	if len(res) == 0 {
-		return nil, fmt.Errorf("%s: expected exactly one external network with name '%s'. Got %d", ErrorEntityNotFound, name, len(res))
+		return nil, fmt.Errorf("%w: expected exactly one external network with name '%s'. Got %d", ErrorEntityNotFound, name, len(res))
 	}
 
 	if len(res) > 1 {
@@ -161,7 +161,7 @@ func (extNet *ExternalNetworkV2) Update() (*ExternalNetworkV2, error) {
 
 	err = extNet.client.OpenApiPutItem(apiVersion, urlRef, nil, extNet.ExternalNetwork, returnExtNet.ExternalNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating external network: %s", err)
+		return nil, fmt.Errorf("error updating external network: %w", err)
 	}
 
 	return returnExtNet, nil
@@ -187,7 +187,7 @@ func (extNet *ExternalNetworkV2) Delete() error {
 	err = extNet.client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting extNet: %s", err)
+		return fmt.Errorf("error deleting extNet: %w", err)
 	}
 
 	return nil
@@ -213,10 +213,10 @@ func (vcdClient *VCDClient) GetAllTier0RouterInterfaces(externalNetworkId string
 func (vcdClient *VCDClient) GetTier0RouterInterfaceByName(externalNetworkId, displayName string) (*types.NsxtTier0RouterInterface, error) {
 	allT0Interfaces, err := vcdClient.GetAllTier0RouterInterfaces(externalNetworkId, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting %s by DisplayName '%s':%s", labelNsxtTier0RouterInterface, displayName, err)
+		return nil, fmt.Errorf("error getting %s by DisplayName '%s':%w", labelNsxtTier0RouterInterface, displayName, err)
 	}
 	if len(allT0Interfaces) == 0 {
-		return nil, fmt.Errorf("%s: error getting %s by DisplayName '%s'", ErrorEntityNotFound, labelNsxtTier0RouterInterface, displayName)
+		return nil, fmt.Errorf("%w: error getting %s by DisplayName '%s'", ErrorEntityNotFound, labelNsxtTier0RouterInterface, displayName)
 	}
 
 	return localFilterOneOrError(labelNsxtTier0RouterInterface, allT0Interfaces, "DisplayName", displayName)
@@ -72,7 +72,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 		case types.FilterNameRegex:
 			re, err := regexp.Compile(value)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", value, err)
+				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %w ", value, err)
 			}
 			conditions = append(conditions, conditionDef{key, nameCondition{re}})
 		case types.FilterDate:
@@ -80,7 +80,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 		case types.FilterIp:
 			re, err := regexp.Compile(value)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", value, err)
+				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %w ", value, err)
 			}
 			conditions = append(conditions, conditionDef{key, ipCondition{re}})
 		case types.FilterParent:
@@ -127,7 +127,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 				// The type must be one of the expected values
 				err := validateMetadataType(cond.Type)
 				if err != nil {
-					return nil, explanation, fmt.Errorf("type '%s' for metadata field '%s' is invalid. :%s", cond.Type, cond.Key, err)
+					return nil, explanation, fmt.Errorf("type '%s' for metadata field '%s' is invalid. :%w", cond.Type, cond.Key, err)
 				}
 				metadataFilter[cond.Key] = MetadataFilter{
 					Type:  cond.Type,
@@ -141,7 +141,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 				metadataFields = append(metadataFields, k)
 				re, err := regexp.Compile(v.(string))
 				if err != nil {
-					return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", v, err)
+					return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %w ", v, err)
 				}
 				conditions = append(conditions, conditionDef{"metadata", metadataRegexpCondition{k, re}})
 			}
@@ -162,7 +162,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 	}
 
 	if err != nil {
-		return nil, explanation, fmt.Errorf("[SearchByFilter] error retrieving query item list: %s", err)
+		return nil, explanation, fmt.Errorf("[SearchByFilter] error retrieving query item list: %w", err)
 	}
 	if dataInspectionRequested("QE1") {
 		util.Logger.Printf("[INSPECT-QE1-SearchByFilter] list of retrieved items %# v\n", pretty.Formatter(itemResult.Results))
@@ -172,7 +172,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 	// Converting the query result into a list of QueryItems
 	itemList, err = converter(queryType, itemResult)
 	if err != nil {
-		return nil, explanation, fmt.Errorf("[SearchByFilter] error converting QueryItem  item list: %s", err)
+		return nil, explanation, fmt.Errorf("[SearchByFilter] error converting QueryItem  item list: %w", err)
 	}
 	if dataInspectionRequested("QE2") {
 		util.Logger.Printf("[INSPECT-QE2-SearchByFilter] list of converted items %# v\n", pretty.Formatter(itemList))
@@ -189,7 +189,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 			}
 			result, definition, err := conditionMatches(condition.conditionType, condition.stored, item)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("[SearchByFilter] error applying condition %v: %s", condition, err)
+				return nil, explanation, fmt.Errorf("[SearchByFilter] error applying condition %v: %w", condition, err)
 			}
 
 			// Saves matching information, which will be consolidated in the final explanation text
@@ -244,7 +244,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 			util.Logger.Printf("[SearchByFilter] search latest: comparing %s to %s", latestDate, itemDate)
 			greater, err := compareDate(fmt.Sprintf("> %s", latestDate), itemDate)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("[SearchByFilter] error comparing dates %s > %s : %s",
+				return nil, explanation, fmt.Errorf("[SearchByFilter] error comparing dates %s > %s : %w",
 					candidate.GetDate(), latestDate, err)
 			}
 			util.Logger.Printf("[SearchByFilter] result %v: ", greater)
@@ -275,7 +275,7 @@ func searchByFilter(queryByMetadata queryByMetadataFunc, queryWithMetadataFields
 			util.Logger.Printf("[SearchByFilter] search earliest: comparing %s to %s", earliestDate, candidate.GetDate())
 			greater, err := compareDate(fmt.Sprintf("< %s", earliestDate), candidate.GetDate())
 			if err != nil {
-				return nil, explanation, fmt.Errorf("[SearchByFilter] error comparing dates %s > %s: %s",
+				return nil, explanation, fmt.Errorf("[SearchByFilter] error comparing dates %s > %s: %w",
 					candidate.GetDate(), earliestDate, err)
 			}
 			util.Logger.Printf("[SearchByFilter] result %v: ", greater)
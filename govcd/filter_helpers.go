@@ -155,7 +155,7 @@ func makeDateFilter(items []DateItem) ([]FilterMatch, error) {
 		exactFilter := NewFilterDef()
 		err = exactFilter.AddFilter(types.FilterDate, "=="+item.Date)
 		if err != nil {
-			return nil, fmt.Errorf("error adding filter '%s' '%s': %s", types.FilterDate, "=="+item.Date, err)
+			return nil, fmt.Errorf("error adding filter '%s' '%s': %w", types.FilterDate, "=="+item.Date, err)
 		}
 		filters = append(filters, FilterMatch{exactFilter, item.Name, item.Entity, item.EntityType})
 	}
@@ -377,7 +377,7 @@ func HelperCreateMultipleCatalogItems(catalog *Catalog, requestData []VappTempla
 			// If the item already exists, we skip the creation, and just retrieve the vapp template
 			vappTemplate, err = item.GetVAppTemplate()
 			if err != nil {
-				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error retrieving vApp template from catalog item %s : %s", item.CatalogItem.Name, err)
+				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error retrieving vApp template from catalog item %s : %w", item.CatalogItem.Name, err)
 			}
 		} else {
 
@@ -387,25 +387,25 @@ func HelperCreateMultipleCatalogItems(catalog *Catalog, requestData []VappTempla
 			}
 			task, err := catalog.UploadOvf(ova, name, "test "+name, 10)
 			if err != nil {
-				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error uploading OVA: %s", err)
+				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error uploading OVA: %w", err)
 			}
 			err = task.WaitTaskCompletion()
 			if err != nil {
-				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error completing task :%s", err)
+				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error completing task :%w", err)
 			}
 			item, err = catalog.GetCatalogItemByName(name, true)
 			if err != nil {
-				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error retrieving item %s: %s", name, err)
+				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error retrieving item %s: %w", name, err)
 			}
 			vappTemplate, err = item.GetVAppTemplate()
 			if err != nil {
-				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error retrieving vApp template: %s", err)
+				return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems] error retrieving vApp template: %w", err)
 			}
 
 			for k, v := range requested.Metadata {
 				_, err := vappTemplate.AddMetadata(k, v)
 				if err != nil {
-					return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems], error adding metadata: %s", err)
+					return nil, fmt.Errorf("[HelperCreateMultipleCatalogItems], error adding metadata: %w", err)
 				}
 			}
 			duration := time.Since(start)
@@ -35,7 +35,7 @@ func oneOrError[E any](key, value string, entitySlice []*E) (*E, error) {
 
 	if len(entitySlice) == 0 {
 		// No entity found - returning ErrorEntityNotFound as it must be wrapped in the returned error
-		return nil, fmt.Errorf("%s: got zero entities by %s '%s'", ErrorEntityNotFound, key, value)
+		return nil, fmt.Errorf("%w: got zero entities by %s '%s'", ErrorEntityNotFound, key, value)
 	}
 
 	return entitySlice[0], nil
@@ -102,13 +102,13 @@ func localFilterOneOrError[E any](entityLabel string, entities []*E, fieldName,
 func convertAnyToRdeEntity[E any](entityCfg *E) (map[string]interface{}, error) {
 	jsonText, err := json.Marshal(entityCfg)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling configuration :%s", err)
+		return nil, fmt.Errorf("error marshalling configuration :%w", err)
 	}
 
 	var unmarshalledRdeEntityJson map[string]interface{}
 	err = json.Unmarshal(jsonText, &unmarshalledRdeEntityJson)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling configuration :%s", err)
+		return nil, fmt.Errorf("error unmarshalling configuration :%w", err)
 	}
 
 	return unmarshalledRdeEntityJson, nil
@@ -117,13 +117,13 @@ func convertAnyToRdeEntity[E any](entityCfg *E) (map[string]interface{}, error)
 func convertRdeEntityToAny[E any](content map[string]interface{}) (*E, error) {
 	jsonText2, err := json.Marshal(content)
 	if err != nil {
-		return nil, fmt.Errorf("error converting entity to type: %s", err)
+		return nil, fmt.Errorf("error converting entity to type: %w", err)
 	}
 
 	result := new(E)
 	err = json.Unmarshal(jsonText2, result)
 	if err != nil {
-		return nil, fmt.Errorf("error converting entity to type: %s", err)
+		return nil, fmt.Errorf("error converting entity to type: %w", err)
 	}
 
 	return result, nil
@@ -130,7 +130,7 @@ func (client *Client) CreateGlobalRole(newGlobalRole *types.GlobalRole) (*Global
 
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, newGlobalRole, returnGlobalRole.GlobalRole, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating global role: %s", err)
+		return nil, fmt.Errorf("error creating global role: %w", err)
 	}
 
 	return returnGlobalRole, nil
@@ -160,7 +160,7 @@ func (globalRole *GlobalRole) Update() (*GlobalRole, error) {
 
 	err = globalRole.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, globalRole.GlobalRole, returnGlobalRole.GlobalRole, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating global role: %s", err)
+		return nil, fmt.Errorf("error updating global role: %w", err)
 	}
 
 	return returnGlobalRole, nil
@@ -186,7 +186,7 @@ func (globalRole *GlobalRole) Delete() error {
 	err = globalRole.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting global role: %s", err)
+		return fmt.Errorf("error deleting global role: %w", err)
 	}
 
 	return nil
@@ -270,7 +270,7 @@ func publishContainerToTenants(client *Client, containerType, name, id, endpoint
 	err = action(minimumApiVersion, urlRef, nil, &input, &pages, nil)
 
 	if err != nil {
-		return fmt.Errorf("error publishing %s %s to tenants: %s", containerType, name, err)
+		return fmt.Errorf("error publishing %s %s to tenants: %w", containerType, name, err)
 	}
 
 	return nil
@@ -308,7 +308,7 @@ func publishContainerToAllTenants(client *Client, containerType, name, id, endpo
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, &pages, &pages, nil)
 
 	if err != nil {
-		return fmt.Errorf("error publishing %s %s to tenants: %s", containerType, name, err)
+		return fmt.Errorf("error publishing %s %s to tenants: %w", containerType, name, err)
 	}
 
 	return nil
@@ -0,0 +1,52 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// testServerClient starts a govcdtest server with an org 'org1' that has a VDC 'vdc1', and returns
+// it with a client authenticated as System Administrator
+func testServerClient(t *testing.T, options ...VCDClientOption) (*govcdtest.Server, *VCDClient) {
+	server := govcdtest.NewServer()
+	t.Cleanup(server.Close)
+	if _, err := server.AddOrg("org1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddVdc("org1", "vdc1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddUser(govcdtest.SystemOrg, "admin", "password"); err != nil {
+		t.Fatal(err)
+	}
+	vcdClient := NewVCDClient(server.Endpoint(), true, options...)
+	if err := vcdClient.Authenticate("admin", "password", govcdtest.SystemOrg); err != nil {
+		t.Fatalf("error authenticating: %s", err)
+	}
+	return server, vcdClient
+}
+
+// testEdgeGateway creates an NSX-T Edge Gateway in 'vdc1' of the server started by testServerClient
+func testEdgeGateway(t *testing.T, vcdClient *VCDClient, name string) *NsxtEdgeGateway {
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := adminOrg.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	egw, err := adminOrg.CreateNsxtEdgeGateway(&types.OpenAPIEdgeGateway{
+		Name:               name,
+		OwnerRef:           &types.OpenApiReference{ID: vdc.Vdc.ID},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{UplinkID: "urn:vcloud:network:1", UplinkName: "uplink"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating Edge Gateway %s: %s", name, err)
+	}
+	return egw
+}
@@ -30,7 +30,7 @@ func (vcdClient *VCDClient) GetVcenterImportableDvpgByName(name string) (*Vcente
 
 	vcImportableDvpgs, err := vcdClient.GetAllVcenterImportableDvpgs(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not find Distributed Virtual Port Group with Name '%s' for vCenter with ID '%s': %s",
+		return nil, fmt.Errorf("could not find Distributed Virtual Port Group with Name '%s' for vCenter with ID '%s': %w",
 			name, "", err)
 	}
 
@@ -54,7 +54,7 @@ func (vdc *Vdc) GetVcenterImportableDvpgByName(name string) (*VcenterImportableD
 
 	vcImportableDvpgs, err := vdc.GetAllVcenterImportableDvpgs(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not find Distributed Virtual Port Group with name '%s': %s", name, err)
+		return nil, fmt.Errorf("could not find Distributed Virtual Port Group with name '%s': %w", name, err)
 	}
 
 	filteredVcImportableDvpgs := filterVcImportableDvpgsByName(name, vcImportableDvpgs)
@@ -0,0 +1,196 @@
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// Instrumentation receives events about API calls and task waits performed by the client. It can
+// be used to produce metrics and traces. It is set with WithInstrumentation and defaults to
+// NoopInstrumentation.
+//
+// Implementations must be safe for concurrent use.
+type Instrumentation interface {
+	// StartApiCall is called before an HTTP request is sent. The returned context is used for the
+	// request (e.g. it can carry a tracing span) and the returned function is called exactly once
+	// when the response headers are received or the request fails.
+	//
+	// Headers of ApiCall can be modified (e.g. to propagate trace context)
+	StartApiCall(ctx context.Context, call ApiCall) (context.Context, func(ApiCallResult))
+	// StartTaskWait is called when the client starts waiting for a task. The returned context is
+	// used for task polling requests and the returned function is called exactly once when the
+	// wait is over.
+	StartTaskWait(ctx context.Context, wait TaskWait) (context.Context, func(TaskWaitResult))
+}
+
+// ApiCall describes an HTTP request passed to Instrumentation.StartApiCall
+type ApiCall struct {
+	// Method is the HTTP method
+	Method string
+	// URL is the full request URL
+	URL *url.URL
+	// EndpointTemplate is the path of the URL with identifiers replaced by '{id}' (e.g.
+	// '/cloudapi/1.0.0/edgeGateways/{id}' or '/api/vApp/vm-{id}'). It has low cardinality and
+	// can be used as a metric label
+	EndpointTemplate string
+	// ClientRequestId is the value of 'X-Vmware-Vcloud-Client-Request-Id' header (see
+	// WithVcloudRequestIdFunc)
+	ClientRequestId string
+	// Header contains request headers
+	Header http.Header
+}
+
+// ApiCallResult describes the outcome of an HTTP request
+type ApiCallResult struct {
+	// StatusCode is the HTTP status code of the response. It is 0 when Err is set
+	StatusCode int
+	// RequestId is the value of 'X-Vmware-Vcloud-Request-Id' response header
+	RequestId string
+	// Duration is the time until response headers were received including all retries and the
+	// time spent waiting for rate limits
+	Duration time.Duration
+	// Retries is the number of retries performed according to RetryPolicy
+	Retries int
+	// Err is the error returned by HTTP transport, if any. API errors returned by VCD in the
+	// response body are not reflected here, but StatusCode shows them
+	Err error
+}
+
+// TaskWait describes a task that the client started waiting for
+type TaskWait struct {
+	// Task is the task as known before the wait. Its fields other than HREF may be empty
+	Task *types.Task
+}
+
+// TaskWaitResult describes the outcome of a task wait
+type TaskWaitResult struct {
+	// Task is the latest known state of the task
+	Task *types.Task
+	// Operation is the short name of the operation tracked by the task (e.g. 'vappDeploy')
+	Operation string
+	// Status is the latest known status of the task (e.g. 'success' or 'error')
+	Status string
+	// Duration is the time spent waiting for the task
+	Duration time.Duration
+	// Err is the error returned by the wait
+	Err error
+}
+
+// NoopInstrumentation is an Instrumentation that does nothing. It is the default one.
+type NoopInstrumentation struct{}
+
+// StartApiCall implements Instrumentation
+func (NoopInstrumentation) StartApiCall(ctx context.Context, _ ApiCall) (context.Context, func(ApiCallResult)) {
+	return ctx, func(ApiCallResult) {}
+}
+
+// StartTaskWait implements Instrumentation
+func (NoopInstrumentation) StartTaskWait(ctx context.Context, _ TaskWait) (context.Context, func(TaskWaitResult)) {
+	return ctx, func(TaskWaitResult) {}
+}
+
+// WithInstrumentation sets Instrumentation that is called for every HTTP round trip and every task
+// wait performed by the client
+func WithInstrumentation(instrumentation Instrumentation) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if instrumentation == nil {
+			return fmt.Errorf("instrumentation cannot be nil")
+		}
+		vcdClient.Client.instrumentation = instrumentation
+		return nil
+	}
+}
+
+// getInstrumentation returns Instrumentation of the client or NoopInstrumentation if it is not set
+func (client *Client) getInstrumentation() Instrumentation {
+	if client == nil || client.instrumentation == nil {
+		return NoopInstrumentation{}
+	}
+	return client.instrumentation
+}
+
+// apiCallStatsContextKey stores *apiCallStats in request context
+type apiCallStatsContextKey struct{}
+
+// apiCallStats collects details about an API call from inner transport layers
+type apiCallStats struct {
+	retries atomic.Int32
+}
+
+// recordRetry increments the number of retries of an API call when it is instrumented
+func recordRetry(ctx context.Context) {
+	if stats, ok := ctx.Value(apiCallStatsContextKey{}).(*apiCallStats); ok {
+		stats.retries.Add(1)
+	}
+}
+
+// instrumentationTransport is an http.RoundTripper that reports every request to Instrumentation
+type instrumentationTransport struct {
+	next            http.RoundTripper
+	instrumentation Instrumentation
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *instrumentationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The request must not be modified by RoundTripper, therefore instrumentation gets a copy of
+	// headers which is then sent instead of the original ones
+	header := req.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	ctx, end := transport.instrumentation.StartApiCall(req.Context(), ApiCall{
+		Method:           req.Method,
+		URL:              req.URL,
+		EndpointTemplate: endpointTemplate(req.URL.Path),
+		ClientRequestId:  req.Header.Get("X-Vmware-Vcloud-Client-Request-Id"),
+		Header:           header,
+	})
+
+	stats := &apiCallStats{}
+	instrumentedReq := req.WithContext(context.WithValue(ctx, apiCallStatsContextKey{}, stats))
+	instrumentedReq.Header = header
+
+	start := time.Now()
+	resp, err := transport.next.RoundTrip(instrumentedReq)
+
+	result := ApiCallResult{
+		Duration: time.Since(start),
+		Retries:  int(stats.retries.Load()),
+		Err:      err,
+	}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+		result.RequestId = resp.Header.Get("X-Vmware-Vcloud-Request-Id")
+	}
+	end(result)
+
+	return resp, err
+}
+
+// endpointTemplateIdRegexp matches URNs, UUIDs (optionally prefixed with entity type, e.g.
+// 'vm-<UUID>') and numeric path segments
+var endpointTemplateIdRegexp = regexp.MustCompile(
+	`(?i)/(urn:[^/]+|([a-z]+-)?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|[0-9]+)(/|$)`)
+
+// endpointTemplate replaces identifiers in URL path with '{id}' so that the result has low
+// cardinality
+func endpointTemplate(path string) string {
+	// Matches cannot overlap, therefore consecutive identifiers require more than one pass
+	for {
+		template := endpointTemplateIdRegexp.ReplaceAllStringFunc(path, func(segment string) string {
+			submatches := endpointTemplateIdRegexp.FindStringSubmatch(segment)
+			return "/" + submatches[2] + "{id}" + submatches[3]
+		})
+		if template == path {
+			return template
+		}
+		path = template
+	}
+}
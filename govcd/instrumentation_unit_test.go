@@ -0,0 +1,122 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// recordingInstrumentation records all events reported to Instrumentation
+type recordingInstrumentation struct {
+	mu          sync.Mutex
+	apiCalls    []ApiCall
+	apiResults  []ApiCallResult
+	taskWaits   []TaskWait
+	taskResults []TaskWaitResult
+}
+
+func (instrumentation *recordingInstrumentation) StartApiCall(ctx context.Context, call ApiCall) (context.Context, func(ApiCallResult)) {
+	instrumentation.mu.Lock()
+	defer instrumentation.mu.Unlock()
+	call.Header.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	instrumentation.apiCalls = append(instrumentation.apiCalls, call)
+	return ctx, func(result ApiCallResult) {
+		instrumentation.mu.Lock()
+		defer instrumentation.mu.Unlock()
+		instrumentation.apiResults = append(instrumentation.apiResults, result)
+	}
+}
+
+func (instrumentation *recordingInstrumentation) StartTaskWait(ctx context.Context, wait TaskWait) (context.Context, func(TaskWaitResult)) {
+	instrumentation.mu.Lock()
+	defer instrumentation.mu.Unlock()
+	instrumentation.taskWaits = append(instrumentation.taskWaits, wait)
+	return ctx, func(result TaskWaitResult) {
+		instrumentation.mu.Lock()
+		defer instrumentation.mu.Unlock()
+		instrumentation.taskResults = append(instrumentation.taskResults, result)
+	}
+}
+
+func TestWithInstrumentation(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Errorf("headers set by instrumentation were not sent")
+		}
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		w.Header().Set("X-Vmware-Vcloud-Request-Id", "request-id-4")
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" name="task" ` +
+			`operationName="vappDeploy" href="` + "http://" + r.Host + r.URL.Path + `"></Task>`))
+	}))
+	defer server.Close()
+
+	instrumentation := &recordingInstrumentation{}
+	vcdClient := testContextClient(t, server.URL, WithInstrumentation(instrumentation),
+		WithRetryPolicy(testRetryPolicy), WithVcloudRequestIdFunc(func() string { return "client-request-id-2" }))
+
+	task := NewTask(&vcdClient.Client)
+	task.Task.HREF = server.URL + "/api/task/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6"
+	if err := task.WaitTaskCompletion(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(instrumentation.apiCalls) != 1 || len(instrumentation.apiResults) != 1 {
+		t.Fatalf("expected 1 API call, got %d calls and %d results", len(instrumentation.apiCalls), len(instrumentation.apiResults))
+	}
+	call, result := instrumentation.apiCalls[0], instrumentation.apiResults[0]
+	if call.Method != http.MethodGet || call.EndpointTemplate != "/api/task/{id}" || call.ClientRequestId != "client-request-id-2" {
+		t.Errorf("unexpected API call %#v", call)
+	}
+	if result.StatusCode != http.StatusOK || result.Retries != 1 || result.RequestId != "request-id-4" || result.Err != nil {
+		t.Errorf("unexpected API call result %#v", result)
+	}
+
+	if len(instrumentation.taskWaits) != 1 || len(instrumentation.taskResults) != 1 {
+		t.Fatalf("expected 1 task wait, got %d waits and %d results", len(instrumentation.taskWaits), len(instrumentation.taskResults))
+	}
+	taskResult := instrumentation.taskResults[0]
+	if taskResult.Operation != "vappDeploy" || taskResult.Status != "success" || taskResult.Err != nil {
+		t.Errorf("unexpected task wait result %#v", taskResult)
+	}
+}
+
+func Test_endpointTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/api/org": "/api/org",
+		"/api/vApp/vm-3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6/power/action/powerOn":               "/api/vApp/vm-{id}/power/action/powerOn",
+		"/cloudapi/1.0.0/edgeGateways/urn:vcloud:gateway:3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6": "/cloudapi/1.0.0/edgeGateways/{id}",
+		"/api/admin/edgeGateway/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6":                          "/api/admin/edgeGateway/{id}",
+		"/network/edges/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6/firewall/config/rules/131074":     "/network/edges/{id}/firewall/config/rules/{id}",
+		"/api/query/1/2": "/api/query/{id}/{id}",
+	}
+	for path, expected := range tests {
+		if got := endpointTemplate(path); got != expected {
+			t.Errorf("expected template '%s' for '%s', got '%s'", expected, path, got)
+		}
+	}
+}
+
+func TestNoopInstrumentation(t *testing.T) {
+	ctx := context.Background()
+	var instrumentation Instrumentation = NoopInstrumentation{}
+	gotCtx, end := instrumentation.StartApiCall(ctx, ApiCall{})
+	end(ApiCallResult{})
+	if gotCtx != ctx {
+		t.Errorf("expected the same context")
+	}
+	if (&Client{}).getInstrumentation() != instrumentation {
+		t.Errorf("expected NoopInstrumentation to be the default")
+	}
+}
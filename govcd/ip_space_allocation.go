@@ -41,7 +41,7 @@ func (org *Org) GetIpSpaceAllocationByTypeAndValue(ipSpaceId string, allocationT
 	queryParams := queryParameterFilterAnd(fmt.Sprintf("value==%s;type==%s", value, allocationType), queryParameters)
 	results, err := getAllIpSpaceAllocations(org.client, ipSpaceId, org, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving IP allocations: %s", err)
+		return nil, fmt.Errorf("error retrieving IP allocations: %w", err)
 	}
 
 	singleResult, err := oneOrError("value", value, results)
@@ -158,7 +158,7 @@ func (ipSpaceAllocation *IpSpaceIpAllocation) Update(ipSpaceAllocationConfig *ty
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, ipSpaceAllocationConfig, returnIpSpaceAllocation.IpSpaceIpAllocation, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating IP Space IP Allocation: %s", err)
+		return nil, fmt.Errorf("error updating IP Space IP Allocation: %w", err)
 	}
 
 	return returnIpSpaceAllocation, nil
@@ -193,7 +193,7 @@ func (ipSpaceAllocation *IpSpaceIpAllocation) Delete() error {
 
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return fmt.Errorf("error deleting IP Space IP Allocation: %s", err)
+		return fmt.Errorf("error deleting IP Space IP Allocation: %w", err)
 	}
 
 	return nil
@@ -222,12 +222,12 @@ func allocateIpSpaceIp(client *Client, orgId, orgName, ipSpaceId string, ipAlloc
 
 	task, err := client.OpenApiPostItemAsyncWithHeaders(apiVersion, urlRef, nil, ipAllocationConfig, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error triggering IP Allocation task for IP Space '%s': %s", ipSpaceId, err)
+		return nil, fmt.Errorf("error triggering IP Allocation task for IP Space '%s': %w", ipSpaceId, err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for task completion: %s", err)
+		return nil, fmt.Errorf("error waiting for task completion: %w", err)
 	}
 
 	// Result of the task should contain a JSON with allocated IP details
@@ -239,7 +239,7 @@ func allocateIpSpaceIp(client *Client, orgId, orgName, ipSpaceId string, ipAlloc
 	unmarshalStorage := []types.IpSpaceIpAllocationRequestResult{}
 	err = json.Unmarshal([]byte(result), &unmarshalStorage)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling task result: %s", err)
+		return nil, fmt.Errorf("error unmarshalling task result: %w", err)
 	}
 
 	return unmarshalStorage, nil
@@ -259,7 +259,7 @@ func getAllIpSpaceAllocations(client *Client, ipSpaceId string, org *Org, queryP
 
 	tenantContext, err := org.getTenantContext()
 	if err != nil {
-		return nil, fmt.Errorf("error getting tenant context: %s", err)
+		return nil, fmt.Errorf("error getting tenant context: %w", err)
 	}
 
 	typeResponses := []*types.IpSpaceIpAllocation{{}}
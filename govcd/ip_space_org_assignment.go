@@ -100,7 +100,7 @@ func (ipSpace *IpSpace) GetOrgAssignmentByOrgName(orgName string) (*IpSpaceOrgAs
 	queryParams := queryParameterFilterAnd(fmt.Sprintf("orgRef.name==%s", orgName), nil)
 	results, err := ipSpace.GetAllOrgAssignments(queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving IP Space Org Assignments by Org Name: %s", err)
+		return nil, fmt.Errorf("error retrieving IP Space Org Assignments by Org Name: %w", err)
 	}
 
 	singleResult, err := oneOrError("Org Name", orgName, results)
@@ -119,7 +119,7 @@ func (ipSpace *IpSpace) GetOrgAssignmentByOrgId(orgId string) (*IpSpaceOrgAssign
 	queryParams := queryParameterFilterAnd(fmt.Sprintf("orgRef.id==%s", orgId), nil)
 	results, err := ipSpace.GetAllOrgAssignments(queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving IP Space Org Assignments by Org ID: %s", err)
+		return nil, fmt.Errorf("error retrieving IP Space Org Assignments by Org ID: %w", err)
 	}
 
 	singleResult, err := oneOrError("Org ID", orgId, results)
@@ -153,7 +153,7 @@ func (ipSpaceOrgAssignment *IpSpaceOrgAssignment) Update(ipSpaceOrgAssignmentCon
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, ipSpaceOrgAssignmentConfig, result.IpSpaceOrgAssignment, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating IP Space Org Assignment: %s", err)
+		return nil, fmt.Errorf("error updating IP Space Org Assignment: %w", err)
 	}
 
 	return result, nil
@@ -63,7 +63,7 @@ func (vcdClient *VCDClient) GetIpSpaceUplinkByName(externalNetworkId, name strin
 	queryParams := queryParameterFilterAnd(fmt.Sprintf("name==%s", name), nil)
 	allIpSpaceUplinks, err := vcdClient.GetAllIpSpaceUplinks(externalNetworkId, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error getting IP Space Uplink by Name '%s':%s", name, err)
+		return nil, fmt.Errorf("error getting IP Space Uplink by Name '%s':%w", name, err)
 	}
 
 	return oneOrError("name", name, allIpSpaceUplinks)
@@ -20,7 +20,7 @@ func (egw *EdgeGateway) CreateLbAppProfile(lbAppProfileConfig *types.LbAppProfil
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPost, types.AnyXMLMime,
@@ -38,7 +38,7 @@ func (egw *EdgeGateway) CreateLbAppProfile(lbAppProfileConfig *types.LbAppProfil
 
 	readAppProfile, err := egw.GetLbAppProfileById(lbAppProfileID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve application profile with ID (%s) after creation: %s",
+		return nil, fmt.Errorf("unable to retrieve application profile with ID (%s) after creation: %w",
 			lbAppProfileID, err)
 	}
 	return readAppProfile, nil
@@ -48,7 +48,7 @@ func (egw *EdgeGateway) CreateLbAppProfile(lbAppProfileConfig *types.LbAppProfil
 func (egw *EdgeGateway) GetLbAppProfiles() ([]*types.LbAppProfile, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap response
@@ -121,12 +121,12 @@ func (egw *EdgeGateway) UpdateLbAppProfile(lbAppProfileConfig *types.LbAppProfil
 
 	lbAppProfileConfig.ID, err = egw.getLbAppProfileIdByNameId(lbAppProfileConfig.Name, lbAppProfileConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer application profile: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer application profile: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath + lbAppProfileConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -138,7 +138,7 @@ func (egw *EdgeGateway) UpdateLbAppProfile(lbAppProfileConfig *types.LbAppProfil
 
 	readAppProfile, err := egw.GetLbAppProfileById(lbAppProfileConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve application profile with ID (%s) after update: %s",
+		return nil, fmt.Errorf("unable to retrieve application profile with ID (%s) after update: %w",
 			lbAppProfileConfig.ID, err)
 	}
 	return readAppProfile, nil
@@ -155,12 +155,12 @@ func (egw *EdgeGateway) DeleteLbAppProfile(lbAppProfileConfig *types.LbAppProfil
 
 	lbAppProfileConfig.ID, err = egw.getLbAppProfileIdByNameId(lbAppProfileConfig.Name, lbAppProfileConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer application profile: %s", err)
+		return fmt.Errorf("cannot delete load balancer application profile: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath + lbAppProfileConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -232,7 +232,7 @@ func (egw *EdgeGateway) getLbAppProfileIdByNameId(name, id string) (string, erro
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbAppProfile, err := egw.GetLbAppProfileByName(name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer application profile by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer application profile by name: %w", err)
 	}
 	return readlbAppProfile.ID, nil
 }
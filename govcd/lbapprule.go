@@ -20,7 +20,7 @@ func (egw *EdgeGateway) CreateLbAppRule(lbAppRuleConfig *types.LbAppRule) (*type
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPost, types.AnyXMLMime,
@@ -38,7 +38,7 @@ func (egw *EdgeGateway) CreateLbAppRule(lbAppRuleConfig *types.LbAppRule) (*type
 
 	readAppRule, err := egw.GetLbAppRuleById(lbAppRuleId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve application rule with ID (%s) after creation: %s",
+		return nil, fmt.Errorf("unable to retrieve application rule with ID (%s) after creation: %w",
 			lbAppRuleId, err)
 	}
 	return readAppRule, nil
@@ -49,7 +49,7 @@ func (egw *EdgeGateway) GetLbAppRules() ([]*types.LbAppRule, error) {
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap response
@@ -122,12 +122,12 @@ func (egw *EdgeGateway) UpdateLbAppRule(lbAppRuleConfig *types.LbAppRule) (*type
 
 	lbAppRuleConfig.ID, err = egw.getLbAppRuleIdByNameId(lbAppRuleConfig.Name, lbAppRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer application rule: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer application rule: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath + lbAppRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -139,7 +139,7 @@ func (egw *EdgeGateway) UpdateLbAppRule(lbAppRuleConfig *types.LbAppRule) (*type
 
 	readAppRule, err := egw.getLbAppRule(&types.LbAppRule{ID: lbAppRuleConfig.ID})
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve application rule with ID (%s) after update: %s",
+		return nil, fmt.Errorf("unable to retrieve application rule with ID (%s) after update: %w",
 			lbAppRuleConfig.ID, err)
 	}
 	return readAppRule, nil
@@ -156,12 +156,12 @@ func (egw *EdgeGateway) DeleteLbAppRule(lbAppRuleConfig *types.LbAppRule) error
 
 	lbAppRuleConfig.ID, err = egw.getLbAppRuleIdByNameId(lbAppRuleConfig.Name, lbAppRuleConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot update load balancer application rule: %s", err)
+		return fmt.Errorf("cannot update load balancer application rule: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath + lbAppRuleConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -233,7 +233,7 @@ func (egw *EdgeGateway) getLbAppRuleIdByNameId(name, id string) (string, error)
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbAppRule, err := egw.GetLbAppRuleByName(name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer application rule by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer application rule by name: %w", err)
 	}
 	return readlbAppRule.ID, nil
 }
@@ -21,7 +21,7 @@ func (egw *EdgeGateway) CreateLbServerPool(lbPoolConfig *types.LbPool) (*types.L
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPost, types.AnyXMLMime,
@@ -39,7 +39,7 @@ func (egw *EdgeGateway) CreateLbServerPool(lbPoolConfig *types.LbPool) (*types.L
 
 	readPool, err := egw.GetLbServerPoolById(lbPoolID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve lb server pool with ID (%s) after creation: %s", lbPoolID, err)
+		return nil, fmt.Errorf("unable to retrieve lb server pool with ID (%s) after creation: %w", lbPoolID, err)
 	}
 	return readPool, nil
 }
@@ -82,7 +82,7 @@ func (egw *EdgeGateway) getLbServerPool(lbPoolConfig *types.LbPool) (*types.LbPo
 func (egw *EdgeGateway) GetLbServerPools() ([]*types.LbPool, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap "server pool response"
@@ -122,12 +122,12 @@ func (egw *EdgeGateway) UpdateLbServerPool(lbPoolConfig *types.LbPool) (*types.L
 
 	lbPoolConfig.ID, err = egw.getLbServerPoolIdByNameId(lbPoolConfig.Name, lbPoolConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer server pool: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer server pool: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath + lbPoolConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -139,7 +139,7 @@ func (egw *EdgeGateway) UpdateLbServerPool(lbPoolConfig *types.LbPool) (*types.L
 
 	readPool, err := egw.GetLbServerPoolById(lbPoolConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve server pool with ID (%s) after update: %s", lbPoolConfig.ID, err)
+		return nil, fmt.Errorf("unable to retrieve server pool with ID (%s) after update: %w", lbPoolConfig.ID, err)
 	}
 	return readPool, nil
 }
@@ -155,12 +155,12 @@ func (egw *EdgeGateway) DeleteLbServerPool(lbPoolConfig *types.LbPool) error {
 
 	lbPoolConfig.ID, err = egw.getLbServerPoolIdByNameId(lbPoolConfig.Name, lbPoolConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer server pool: %s", err)
+		return fmt.Errorf("cannot delete load balancer server pool: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath + lbPoolConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -241,7 +241,7 @@ func (egw *EdgeGateway) getLbServerPoolIdByNameId(name, id string) (string, erro
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbServerPool, err := egw.GetLbServerPoolByName(name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer server pool by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer server pool by name: %w", err)
 	}
 	return readlbServerPool.ID, nil
 }
@@ -24,7 +24,7 @@ func (egw *EdgeGateway) CreateLbServiceMonitor(lbMonitorConfig *types.LbMonitor)
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPost, types.AnyXMLMime,
@@ -42,7 +42,7 @@ func (egw *EdgeGateway) CreateLbServiceMonitor(lbMonitorConfig *types.LbMonitor)
 
 	readMonitor, err := egw.GetLbServiceMonitorById(lbMonitorID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after creation: %s", lbMonitorID, err)
+		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after creation: %w", lbMonitorID, err)
 	}
 	return readMonitor, nil
 }
@@ -85,7 +85,7 @@ func (egw *EdgeGateway) getLbServiceMonitor(lbMonitorConfig *types.LbMonitor) (*
 func (egw *EdgeGateway) GetLbServiceMonitors() ([]*types.LbMonitor, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap "monitor response"
@@ -123,12 +123,12 @@ func (egw *EdgeGateway) UpdateLbServiceMonitor(lbMonitorConfig *types.LbMonitor)
 
 	lbMonitorConfig.ID, err = egw.getLbServiceMonitorIdByNameId(lbMonitorConfig.Name, lbMonitorConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer service monitor: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer service monitor: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath + lbMonitorConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -140,7 +140,7 @@ func (egw *EdgeGateway) UpdateLbServiceMonitor(lbMonitorConfig *types.LbMonitor)
 
 	readMonitor, err := egw.GetLbServiceMonitorById(lbMonitorConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after update: %s", lbMonitorConfig.ID, err)
+		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after update: %w", lbMonitorConfig.ID, err)
 	}
 	return readMonitor, nil
 }
@@ -156,12 +156,12 @@ func (egw *EdgeGateway) DeleteLbServiceMonitor(lbMonitorConfig *types.LbMonitor)
 
 	lbMonitorConfig.ID, err = egw.getLbServiceMonitorIdByNameId(lbMonitorConfig.Name, lbMonitorConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer service monitor: %s", err)
+		return fmt.Errorf("cannot delete load balancer service monitor: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath + lbMonitorConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -248,7 +248,7 @@ func (egw *EdgeGateway) getLbServiceMonitorIdByNameId(name, id string) (string,
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbServiceMonitor, err := egw.GetLbServiceMonitorByName(name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer service monitor by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer service monitor by name: %w", err)
 	}
 	return readlbServiceMonitor.ID, nil
 }
@@ -22,7 +22,7 @@ func (egw *EdgeGateway) CreateLbVirtualServer(lbVirtualServerConfig *types.LbVir
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPost, types.AnyXMLMime,
@@ -40,7 +40,7 @@ func (egw *EdgeGateway) CreateLbVirtualServer(lbVirtualServerConfig *types.LbVir
 
 	readVirtualServer, err := egw.GetLbVirtualServerById(lbVirtualServerId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve load balancer virtual server with ID (%s) after creation: %s",
+		return nil, fmt.Errorf("unable to retrieve load balancer virtual server with ID (%s) after creation: %w",
 			lbVirtualServerId, err)
 	}
 	return readVirtualServer, nil
@@ -85,7 +85,7 @@ func (egw *EdgeGateway) getLbVirtualServer(lbVirtualServerConfig *types.LbVirtua
 func (egw *EdgeGateway) GetLbVirtualServers() ([]*types.LbVirtualServer, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap "virtual server response"
@@ -125,12 +125,12 @@ func (egw *EdgeGateway) UpdateLbVirtualServer(lbVirtualServerConfig *types.LbVir
 
 	lbVirtualServerConfig.ID, err = egw.getLbVirtualServerIdByNameId(lbVirtualServerConfig.Name, lbVirtualServerConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer virtual server: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer virtual server: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath + lbVirtualServerConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -142,7 +142,7 @@ func (egw *EdgeGateway) UpdateLbVirtualServer(lbVirtualServerConfig *types.LbVir
 
 	readVirtualServer, err := egw.GetLbVirtualServerById(lbVirtualServerConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve virtual server with ID (%s) after update: %s",
+		return nil, fmt.Errorf("unable to retrieve virtual server with ID (%s) after update: %w",
 			lbVirtualServerConfig.ID, err)
 	}
 	return readVirtualServer, nil
@@ -159,12 +159,12 @@ func (egw *EdgeGateway) DeleteLbVirtualServer(lbVirtualServerConfig *types.LbVir
 
 	lbVirtualServerConfig.ID, err = egw.getLbVirtualServerIdByNameId(lbVirtualServerConfig.Name, lbVirtualServerConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer virtual server: %s", err)
+		return fmt.Errorf("cannot delete load balancer virtual server: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath + lbVirtualServerConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -248,7 +248,7 @@ func (egw *EdgeGateway) getLbVirtualServerIdByNameId(name, id string) (string, e
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readLbVirtualServer, err := egw.GetLbVirtualServerByName(name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer virtual server by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer virtual server by name: %w", err)
 	}
 	return readLbVirtualServer.ID, nil
 }
@@ -84,12 +84,12 @@ func (vdc *Vdc) UploadMediaImage(mediaName, mediaDescription, filePath string, u
 
 	isISOGood, err := verifyIso(mediaFilePath)
 	if err != nil || !isISOGood {
-		return UploadTask{}, fmt.Errorf("[ERROR] File %s isn't correct iso file: %s", mediaFilePath, err)
+		return UploadTask{}, fmt.Errorf("[ERROR] File %s isn't correct iso file: %w", mediaFilePath, err)
 	}
 
 	mediaList, err := getExistingMedia(vdc)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Checking existing media files failed: %s", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Checking existing media files failed: %w", err)
 	}
 
 	for _, media := range mediaList {
@@ -106,7 +106,7 @@ func (vdc *Vdc) UploadMediaImage(mediaName, mediaDescription, filePath string, u
 
 	media, err := createMedia(vdc.client, vdc.Vdc.HREF+"/media", mediaName, mediaDescription, fileSize)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue creating media: %s", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue creating media: %w", err)
 	}
 
 	return executeUpload(vdc.client, media, mediaFilePath, mediaName, fileSize, uploadPieceSize)
@@ -115,7 +115,7 @@ func (vdc *Vdc) UploadMediaImage(mediaName, mediaDescription, filePath string, u
 func executeUpload(client *Client, media *types.Media, mediaFilePath, mediaName string, fileSize, uploadPieceSize int64) (UploadTask, error) {
 	uploadLink, err := getUploadLink(media.Files)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue getting upload link: %s", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue getting upload link: %w", err)
 	}
 
 	callBack, uploadProgress := getProgressCallBackFunction()
@@ -154,7 +154,7 @@ func executeUpload(client *Client, media *types.Media, mediaFilePath, mediaName
 		}
 		if task.Task.Status == "error" {
 			removeImageOnError(client, media, mediaName)
-			return UploadTask{}, fmt.Errorf("task did not complete succesfully: %s", task.Task.Description)
+			return UploadTask{}, newTaskError(task.Task, "task did not complete succesfully: "+task.Task.Description)
 		}
 	}
 
@@ -169,7 +169,7 @@ func executeUpload(client *Client, media *types.Media, mediaFilePath, mediaName
 func createMedia(client *Client, link, mediaName, mediaDescription string, fileSize int64) (*types.Media, error) {
 	uploadUrl, err := url.ParseRequestURI(link)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vdc href: %s", err)
+		return nil, fmt.Errorf("error getting vdc href: %w", err)
 	}
 
 	reqBody := bytes.NewBufferString(
@@ -336,7 +336,7 @@ func queryMediaWithFilter(vdc *Vdc, filter string) ([]*types.MediaRecordType, er
 
 	results, err := vdc.QueryWithNotEncodedParams(nil, map[string]string{"type": typeMedia, "filter": filter, "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -416,12 +416,12 @@ func FindMediaAsCatalogItem(org *Org, catalogName, mediaName string) (CatalogIte
 
 	catalog, err := org.FindCatalog(catalogName)
 	if err != nil || catalog == (Catalog{}) {
-		return CatalogItem{}, fmt.Errorf("catalog not found or error %s", err)
+		return CatalogItem{}, fmt.Errorf("catalog not found or error %w", err)
 	}
 
 	media, err := catalog.FindCatalogItem(mediaName)
 	if err != nil || media == (CatalogItem{}) {
-		return CatalogItem{}, fmt.Errorf("media not found or error %s", err)
+		return CatalogItem{}, fmt.Errorf("media not found or error %w", err)
 	}
 	return media, nil
 }
@@ -518,7 +518,7 @@ func (catalog *Catalog) GetMediaById(mediaId string) (*Media, error) {
 		"filter":        fmt.Sprintf("catalogName==%s", url.QueryEscape(catalog.Catalog.Name)),
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -608,7 +608,7 @@ func (catalog *Catalog) QueryMedia(mediaName string) (*MediaRecord, error) {
 			url.QueryEscape(catalog.Catalog.Name)),
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 	newMediaRecord := NewMediaRecord(catalog.client)
 
@@ -656,7 +656,7 @@ func (vcdClient *VCDClient) QueryMediaById(mediaId string) (*MediaRecord, error)
 		"filter":        fmt.Sprintf("id==%s", url.QueryEscape(mediaId)),
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 	newMediaRecord := NewMediaRecord(&vcdClient.Client)
 
@@ -725,7 +725,7 @@ func (vdc *Vdc) QueryAllMedia(mediaName string) ([]*MediaRecord, error) {
 	results, err := vdc.client.QueryWithNotEncodedParams(nil, map[string]string{"type": typeMedia,
 		"filter": fmt.Sprintf("name==%s", url.QueryEscape(mediaName))})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -763,13 +763,12 @@ func (media *Media) enableDownload() (string, error) {
 	//        <Link rel="download:default" href="https://example.com/transfer/1638969a-06da-4f6c-b097-7796c1556c54/file"/>
 	//    </File>
 	//</Files>
-	task, err := media.client.executeTaskRequest(
+	task, err := media.client.ExecuteTaskRequest(
 		downloadUrl,
 		http.MethodPost,
 		types.MimeTask,
 		"error enabling download: %s",
-		nil,
-		media.client.APIVersion)
+		nil)
 	if err != nil {
 		return "", err
 	}
@@ -807,7 +806,8 @@ func (media *Media) enableDownload() (string, error) {
 }
 
 // Download gets the contents of a media item as a byte stream
-// NOTE: the whole item will be saved in local memory. Do not attempt this operation for very large items
+// NOTE: the whole item will be saved in local memory. Do not attempt this operation for very large items.
+// Use DownloadToWriter or DownloadToFile instead
 func (media *Media) Download() ([]byte, error) {
 
 	downloadHref, err := media.enableDownload()
@@ -817,13 +817,13 @@ func (media *Media) Download() ([]byte, error) {
 
 	downloadUrl, err := url.ParseRequestURI(downloadHref)
 	if err != nil {
-		return nil, fmt.Errorf("error getting download URL: %s", err)
+		return nil, fmt.Errorf("error getting download URL: %w", err)
 	}
 
 	request := media.client.NewRequest(map[string]string{}, http.MethodGet, *downloadUrl, nil)
 	resp, err := media.client.Http.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("error getting media download: %s", err)
+		return nil, fmt.Errorf("error getting media download: %w", err)
 	}
 
 	if !isSuccessStatus(resp.StatusCode) {
@@ -843,3 +843,63 @@ func (media *Media) Download() ([]byte, error) {
 	}
 	return body, nil
 }
+
+// DownloadToWriter streams the contents of a media item to writer, without keeping it in memory.
+// Interrupted transfers are resumed with HTTP Range requests, and the number of bytes received is
+// checked against the size of the media item. progressCallBack, when not nil, receives the bytes
+// downloaded and the total size, like the callback of uploads. DownloadProgressPercentage turns
+// them into the same format as UploadTask.GetUploadProgress.
+func (media *Media) DownloadToWriter(writer io.Writer, progressCallBack DownloadProgressFunc) error {
+	downloadHref, err := media.enableDownload()
+	if err != nil {
+		return err
+	}
+
+	size := media.downloadSize()
+	_, err = downloadToWriter(media.client, writer, downloadDetails{
+		href:         downloadHref,
+		expectedSize: size,
+		totalSize:    size,
+		callBack:     progressCallBack,
+	})
+	if err != nil {
+		return fmt.Errorf("error downloading media %s: %w", media.Media.Name, err)
+	}
+	return nil
+}
+
+// DownloadToFile saves the contents of a media item to filePath, without keeping it in memory.
+// When filePath contains part of the media item from a download that was interrupted, the download
+// is resumed from its end. The size of the file is checked against the size of the media item.
+func (media *Media) DownloadToFile(filePath string) error {
+	downloadHref, err := media.enableDownload()
+	if err != nil {
+		return err
+	}
+
+	size := media.downloadSize()
+	_, err = downloadToFile(media.client, filePath, downloadDetails{
+		href:         downloadHref,
+		expectedSize: size,
+		totalSize:    size,
+	})
+	if err != nil {
+		return fmt.Errorf("error downloading media %s to %s: %w", media.Media.Name, filePath, err)
+	}
+	return nil
+}
+
+// downloadSize returns the size of the media item to download, or -1 if it is not known
+func (media *Media) downloadSize() int64 {
+	if media.Media.Size > 0 {
+		return media.Media.Size
+	}
+	if media.Media.Files != nil {
+		for _, file := range media.Media.Files.File {
+			if file.Size > 0 {
+				return file.Size
+			}
+		}
+	}
+	return -1
+}
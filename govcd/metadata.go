@@ -788,7 +788,7 @@ func (adminOrg *AdminOrg) DeleteMetadataEntry(key string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for organization task: %s", err)
+		return fmt.Errorf("error completing delete metadata for organization task: %w", err)
 	}
 
 	return nil
@@ -844,7 +844,7 @@ func (disk *Disk) DeleteMetadataEntry(key string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for independent disk task: %s", err)
+		return fmt.Errorf("error completing delete metadata for independent disk task: %w", err)
 	}
 
 	return nil
@@ -1147,12 +1147,12 @@ func (vAppTemplate *VAppTemplate) AddMetadata(key string, value string) (*VAppTe
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for vApp template task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for vApp template task: %w", err)
 	}
 
 	err = vAppTemplate.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing vApp template: %s", err)
+		return nil, fmt.Errorf("error refreshing vApp template: %w", err)
 	}
 
 	return vAppTemplate, nil
@@ -1171,7 +1171,7 @@ func (vAppTemplate *VAppTemplate) DeleteMetadata(key string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for vApp template task: %s", err)
+		return fmt.Errorf("error completing delete metadata for vApp template task: %w", err)
 	}
 
 	return nil
@@ -1190,12 +1190,12 @@ func (media *Media) AddMetadata(key string, value string) (*Media, error) {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for media item task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for media item task: %w", err)
 	}
 
 	err = media.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing media item: %s", err)
+		return nil, fmt.Errorf("error refreshing media item: %w", err)
 	}
 
 	return media, nil
@@ -1214,7 +1214,7 @@ func (media *Media) DeleteMetadata(key string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for media item task: %s", err)
+		return fmt.Errorf("error completing delete metadata for media item task: %w", err)
 	}
 
 	return nil
@@ -1240,12 +1240,12 @@ func (mediaItem *MediaItem) AddMetadata(key string, value string) (*MediaItem, e
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for media item task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for media item task: %w", err)
 	}
 
 	err = mediaItem.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing media item: %s", err)
+		return nil, fmt.Errorf("error refreshing media item: %w", err)
 	}
 
 	return mediaItem, nil
@@ -1265,7 +1265,7 @@ func (mediaItem *MediaItem) DeleteMetadata(key string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for media item task: %s", err)
+		return fmt.Errorf("error completing delete metadata for media item task: %w", err)
 	}
 
 	return nil
@@ -1285,12 +1285,12 @@ func (mediaRecord *MediaRecord) AddMetadata(key string, value string) (*MediaRec
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for media item task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for media item task: %w", err)
 	}
 
 	err = mediaRecord.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing media item: %s", err)
+		return nil, fmt.Errorf("error refreshing media item: %w", err)
 	}
 
 	return mediaRecord, nil
@@ -1309,7 +1309,7 @@ func (mediaRecord *MediaRecord) DeleteMetadata(key string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for media item task: %s", err)
+		return fmt.Errorf("error completing delete metadata for media item task: %w", err)
 	}
 
 	return nil
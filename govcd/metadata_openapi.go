@@ -185,7 +185,7 @@ func getOpenApiMetadataByKey(client *Client, endpoint, objectId, objectName, obj
 	}
 
 	if len(metadata) == 0 {
-		return nil, fmt.Errorf("%s could not find the metadata associated to object %s", ErrorEntityNotFound, objectId)
+		return nil, fmt.Errorf("%w could not find the metadata associated to object %s", ErrorEntityNotFound, objectId)
 	}
 
 	// There's more than one entry with same key, the namespace and domain need to be compared to be able to filter.
@@ -815,7 +815,7 @@ func addMetadata(client *Client, requestUri, name, key, value, typedValue, visib
 
 	// Workaround for ugly error returned by VCD: "API Error: 500: [ <uuid> ] visibility"
 	if err != nil && strings.HasSuffix(err.Error(), "visibility") {
-		err = fmt.Errorf("error adding metadata with key %s: visibility cannot be %s when domain is %s: %s", key, visibility, domain, err)
+		err = fmt.Errorf("error adding metadata with key %s: visibility cannot be %s when domain is %s: %w", key, visibility, domain, err)
 	}
 	return task, err
 }
@@ -31,7 +31,7 @@ The associations come in two flavors:
 func (client Client) GetSite() (*types.Site, error) {
 	href, err := url.JoinPath(client.VCDHREF.String(), "site")
 	if err != nil {
-		return nil, fmt.Errorf("error setting the URL path for site: %s", err)
+		return nil, fmt.Errorf("error setting the URL path for site: %w", err)
 	}
 	var site types.Site
 	_, err = client.ExecuteRequest(href, http.MethodGet, "application/*+xml",
@@ -66,7 +66,7 @@ func (client Client) QueryAllSiteAssociations(params, notEncodedParams map[strin
 func (client Client) GetSiteAssociationData() (*types.SiteAssociationMember, error) {
 	href, err := url.JoinPath(client.VCDHREF.String(), "site", "associations", "localAssociationData")
 	if err != nil {
-		return nil, fmt.Errorf("error setting the URL path for localAssociationData: %s", err)
+		return nil, fmt.Errorf("error setting the URL path for localAssociationData: %w", err)
 	}
 	var associationData types.SiteAssociationMember
 	_, err = client.ExecuteRequest(href, http.MethodGet, types.MimeSiteAssociation,
@@ -83,7 +83,7 @@ func (client Client) GetSiteAssociationData() (*types.SiteAssociationMember, err
 func (client Client) GetSiteRawAssociationData() ([]byte, error) {
 	href, err := url.JoinPath(client.VCDHREF.String(), "site", "associations", "localAssociationData")
 	if err != nil {
-		return nil, fmt.Errorf("error setting the URL path for site/associations/localAssociationData: %s", err)
+		return nil, fmt.Errorf("error setting the URL path for site/associations/localAssociationData: %w", err)
 	}
 	return client.RetrieveRemoteDocument(href)
 }
@@ -94,7 +94,7 @@ func (client Client) GetSiteAssociations() ([]*types.SiteAssociationMember, erro
 
 	href, err := url.JoinPath(client.VCDHREF.String(), "site", "associations")
 	if err != nil {
-		return nil, fmt.Errorf("error setting the URL path for site/associations: %s", err)
+		return nil, fmt.Errorf("error setting the URL path for site/associations: %w", err)
 	}
 	var associations types.SiteAssociations
 	_, err = client.ExecuteRequest(href, http.MethodGet, types.MimeSiteAssociation,
@@ -111,7 +111,7 @@ func (client Client) GetSiteAssociations() ([]*types.SiteAssociationMember, erro
 func (client Client) GetSiteAssociationBySiteId(siteId string) (*types.SiteAssociationMember, error) {
 	associations, err := client.GetSiteAssociations()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving associations for current site: %s", err)
+		return nil, fmt.Errorf("error retrieving associations for current site: %w", err)
 	}
 
 	for _, a := range associations {
@@ -134,7 +134,7 @@ func (client Client) CheckSiteAssociation(siteId string, timeout time.Duration)
 		elapsed = time.Since(startTime)
 		siteAssociation, err := client.GetSiteAssociationBySiteId(siteId)
 		if err != nil {
-			return foundStatus, elapsed, fmt.Errorf("error getting site association by ID '%s': %s", siteId, err)
+			return foundStatus, elapsed, fmt.Errorf("error getting site association by ID '%s': %w", siteId, err)
 		}
 		foundStatus = siteAssociation.Status
 		if foundStatus == string(types.StatusActive) {
@@ -152,7 +152,7 @@ func (client Client) CheckSiteAssociation(siteId string, timeout time.Duration)
 func (client Client) SetSiteAssociationAsync(associationData types.SiteAssociationMember) (Task, error) {
 	href, err := url.JoinPath(client.VCDHREF.String(), "site", "associations")
 	if err != nil {
-		return Task{}, fmt.Errorf("error setting the URL path for site/associations: %s", err)
+		return Task{}, fmt.Errorf("error setting the URL path for site/associations: %w", err)
 	}
 	associationData.Xmlns = types.XMLNamespaceVCloud
 	task, err := client.ExecuteTaskRequest(href, http.MethodPost, "application/*+xml",
@@ -215,7 +215,7 @@ func (client Client) QueryAllOrgAssociations(params, notEncodedParams map[string
 func (org AdminOrg) GetOrgAssociations() ([]*types.OrgAssociationMember, error) {
 	href, err := org.getAssociationLink(false)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving association URL: %s", err)
+		return nil, fmt.Errorf("error retrieving association URL: %w", err)
 	}
 	var associations types.OrgAssociations
 	_, err = org.client.ExecuteRequest(href, http.MethodGet, types.MimeOrgAssociation,
@@ -232,7 +232,7 @@ func (org AdminOrg) GetOrgAssociations() ([]*types.OrgAssociationMember, error)
 func (org AdminOrg) GetOrgAssociationByOrgId(orgId string) (*types.OrgAssociationMember, error) {
 	associations, err := org.GetOrgAssociations()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving associations for org '%s': %s", org.AdminOrg.Name, err)
+		return nil, fmt.Errorf("error retrieving associations for org '%s': %w", org.AdminOrg.Name, err)
 	}
 
 	for _, a := range associations {
@@ -248,7 +248,7 @@ func (org AdminOrg) GetOrgAssociationByOrgId(orgId string) (*types.OrgAssociatio
 func (org AdminOrg) GetOrgAssociationData() (*types.OrgAssociationMember, error) {
 	href, err := org.getAssociationLink(true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving association URL: %s", err)
+		return nil, fmt.Errorf("error retrieving association URL: %w", err)
 	}
 	var associationData types.OrgAssociationMember
 	_, err = org.client.ExecuteRequest(href, http.MethodGet, types.MimeOrgAssociation,
@@ -265,7 +265,7 @@ func (org AdminOrg) GetOrgAssociationData() (*types.OrgAssociationMember, error)
 func (org AdminOrg) GetOrgRawAssociationData() ([]byte, error) {
 	href, err := org.getAssociationLink(true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving association URL: %s", err)
+		return nil, fmt.Errorf("error retrieving association URL: %w", err)
 	}
 	return org.client.RetrieveRemoteDocument(href)
 }
@@ -282,7 +282,7 @@ func (org AdminOrg) CheckOrgAssociation(orgId string, timeout time.Duration) (st
 		elapsed = time.Since(startTime)
 		orgAssociation, err := org.GetOrgAssociationByOrgId(orgId)
 		if err != nil {
-			return foundStatus, elapsed, fmt.Errorf("error getting org association by ID '%s': %s", orgId, err)
+			return foundStatus, elapsed, fmt.Errorf("error getting org association by ID '%s': %w", orgId, err)
 		}
 		foundStatus = orgAssociation.Status
 		if foundStatus == string(types.StatusActive) {
@@ -300,7 +300,7 @@ func (org AdminOrg) CheckOrgAssociation(orgId string, timeout time.Duration) (st
 func (org *AdminOrg) SetOrgAssociationAsync(associationData types.OrgAssociationMember) (Task, error) {
 	href, err := org.getAssociationLink(false)
 	if err != nil {
-		return Task{}, fmt.Errorf("error retrieving association URL: %s", err)
+		return Task{}, fmt.Errorf("error retrieving association URL: %w", err)
 	}
 	associationData.Xmlns = types.XMLNamespaceVCloud
 	task, err := org.client.ExecuteTaskRequest(href, http.MethodPost, "application/*+xml",
@@ -371,7 +371,7 @@ func (org AdminOrg) getAssociationLink(localData bool) (string, error) {
 func ReadXmlDataFromFile[dataType any](fileName string) (*dataType, error) {
 	contents, err := os.ReadFile(path.Clean(fileName))
 	if err != nil {
-		return nil, fmt.Errorf("error reading file '%s': %s", fileName, err)
+		return nil, fmt.Errorf("error reading file '%s': %w", fileName, err)
 	}
 	return RawDataToStructuredXml[dataType](contents)
 }
@@ -384,7 +384,7 @@ func RawDataToStructuredXml[dataType any](rawData []byte) (*dataType, error) {
 	var localData dataType
 	err := xml.Unmarshal(rawData, &localData)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding data: %s", err)
+		return nil, fmt.Errorf("error decoding data: %w", err)
 	}
 	return &localData, nil
 }
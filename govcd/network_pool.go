@@ -96,11 +96,11 @@ func (vcdClient *VCDClient) GetNetworkPoolByName(name string) (*NetworkPool, err
 
 	filteredNetworkPools, err := vcdClient.GetNetworkPoolSummaries(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error getting network pools: %s", err)
+		return nil, fmt.Errorf("error getting network pools: %w", err)
 	}
 
 	if len(filteredNetworkPools) == 0 {
-		return nil, fmt.Errorf("no network pool found with name '%s' - %s", name, ErrorEntityNotFound)
+		return nil, fmt.Errorf("no network pool found with name '%s' - %w", name, ErrorEntityNotFound)
 	}
 
 	if len(filteredNetworkPools) > 1 {
@@ -165,7 +165,7 @@ func (np *NetworkPool) Update() error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("error updating network pool '%s': %s", np.NetworkPool.Name, err)
+		return fmt.Errorf("error updating network pool '%s': %w", np.NetworkPool.Name, err)
 	}
 
 	return nil
@@ -198,7 +198,7 @@ func (np *NetworkPool) Delete() error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("error deleting network pool '%s': %s", np.NetworkPool.Name, err)
+		return fmt.Errorf("error deleting network pool '%s': %w", np.NetworkPool.Name, err)
 	}
 
 	return nil
@@ -293,7 +293,7 @@ func (vcdClient *VCDClient) CreateNetworkPoolGeneve(name, description, nsxtManag
 	managerId := "urn:vcloud:nsxtmanager:" + extractUuid(managers[0].HREF)
 	transportZones, err := vcdClient.GetAllNsxtTransportZones(managerId, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving transport zones for manager '%s': %s", manager.Name, err)
+		return nil, fmt.Errorf("error retrieving transport zones for manager '%s': %w", manager.Name, err)
 	}
 	transportZone, err := chooseBackingElement[types.TransportZone](
 		constraint,
@@ -337,13 +337,13 @@ func (vcdClient *VCDClient) CreateNetworkPoolGeneve(name, description, nsxtManag
 func (vcdClient *VCDClient) CreateNetworkPoolPortGroup(name, description, vCenterName string, portgroupNames []string, constraint types.BackingUseConstraint) (*NetworkPool, error) {
 	vCenter, err := vcdClient.GetVCenterByName(vCenterName)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving vCenter '%s': %s", vCenterName, err)
+		return nil, fmt.Errorf("error retrieving vCenter '%s': %w", vCenterName, err)
 	}
 	var params = make(url.Values)
 	params.Set("filter", "virtualCenter.id=="+vCenter.VSphereVCenter.VcId)
 	portgroups, err := vcdClient.GetAllVcenterImportableDvpgs(params)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving portgroups for vCenter '%s': %s", vCenterName, err)
+		return nil, fmt.Errorf("error retrieving portgroups for vCenter '%s': %w", vCenterName, err)
 	}
 
 	var chosenPortgroups []*VcenterImportableDvpg
@@ -402,12 +402,12 @@ func (vcdClient *VCDClient) CreateNetworkPoolPortGroup(name, description, vCente
 func (vcdClient *VCDClient) CreateNetworkPoolVlan(name, description, vCenterName, dsName string, ranges []types.VlanIdRange, constraint types.BackingUseConstraint) (*NetworkPool, error) {
 	vCenter, err := vcdClient.GetVCenterByName(vCenterName)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving vCenter '%s': %s", vCenterName, err)
+		return nil, fmt.Errorf("error retrieving vCenter '%s': %w", vCenterName, err)
 	}
 
 	dswitches, err := vcdClient.GetAllVcenterDistributedSwitches(vCenter.VSphereVCenter.VcId, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving distributed switches for vCenter '%s': %s", vCenterName, err)
+		return nil, fmt.Errorf("error retrieving distributed switches for vCenter '%s': %w", vCenterName, err)
 	}
 
 	dswitch, err := chooseBackingElement[types.VcenterDistributedSwitch](
@@ -62,11 +62,11 @@ func (vcdClient *VCDClient) GetAlbCloudByName(name string) (*NsxtAlbCloud, error
 
 	albClouds, err := vcdClient.GetAllAlbClouds(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with Name '%s': %w", name, err)
 	}
 
 	if len(albClouds) == 0 {
-		return nil, fmt.Errorf("%s could not find NSX-T ALB Cloud with Name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w could not find NSX-T ALB Cloud with Name '%s'", ErrorEntityNotFound, name)
 	}
 
 	if len(albClouds) > 1 {
@@ -87,11 +87,11 @@ func (vcdClient *VCDClient) GetAlbCloudById(id string) (*NsxtAlbCloud, error) {
 
 	albCloud, err := vcdClient.GetAllAlbClouds(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with ID '%s': %w", id, err)
 	}
 
 	if len(albCloud) == 0 {
-		return nil, fmt.Errorf("%s could not find NSX-T ALB Cloud by ID '%s'", ErrorEntityNotFound, id)
+		return nil, fmt.Errorf("%w could not find NSX-T ALB Cloud by ID '%s'", ErrorEntityNotFound, id)
 	}
 
 	return albCloud[0], nil
@@ -122,7 +122,7 @@ func (vcdClient *VCDClient) CreateAlbCloud(albCloudConfig *types.NsxtAlbCloud) (
 
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, albCloudConfig, returnObject.NsxtAlbCloud, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Cloud: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Cloud: %w", err)
 	}
 
 	return returnObject, nil
@@ -155,7 +155,7 @@ func (vcdClient *VCDClient) CreateAlbCloud(albCloudConfig *types.NsxtAlbCloud) (
 //
 //	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, albCloudConfig, responseAlbCloud.NsxtAlbCloud, nil)
 //	if err != nil {
-//		return nil, fmt.Errorf("error updating NSX-T ALB Cloud: %s", err)
+//		return nil, fmt.Errorf("error updating NSX-T ALB Cloud: %w", err)
 //	}
 //
 //	return responseAlbCloud, nil
@@ -181,7 +181,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) Delete() error {
 
 	err = client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Cloud: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Cloud: %w", err)
 	}
 
 	return nil
@@ -64,11 +64,11 @@ func (vcdClient *VCDClient) GetAlbControllerByName(name string) (*NsxtAlbControl
 
 	controllers, err := vcdClient.GetAllAlbControllers(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading ALB Controller with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error reading ALB Controller with Name '%s': %w", name, err)
 	}
 
 	if len(controllers) == 0 {
-		return nil, fmt.Errorf("%s: could not find ALB Controller with Name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find ALB Controller with Name '%s'", ErrorEntityNotFound, name)
 	}
 
 	if len(controllers) > 1 {
@@ -121,7 +121,7 @@ func (vcdClient *VCDClient) GetAlbControllerByUrl(url string) (*NsxtAlbControlle
 	// Ideally this function could filter on VCD side, but API does not support filtering on URL
 	controllers, err := vcdClient.GetAllAlbControllers(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading ALB Controller with Url '%s': %s", url, err)
+		return nil, fmt.Errorf("error reading ALB Controller with Url '%s': %w", url, err)
 	}
 
 	// Search for controllers
@@ -160,7 +160,7 @@ func (vcdClient *VCDClient) CreateNsxtAlbController(albControllerConfig *types.N
 
 	err = client.OpenApiPostItem(apiVersion, urlRef, nil, albControllerConfig, returnObject.NsxtAlbController, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Controller: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Controller: %w", err)
 	}
 
 	return returnObject, nil
@@ -191,7 +191,7 @@ func (nsxtAlbController *NsxtAlbController) Update(albControllerConfig *types.Ns
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, albControllerConfig, responseAlbController.NsxtAlbController, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Controller: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Controller: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -217,7 +217,7 @@ func (nsxtAlbController *NsxtAlbController) Delete() error {
 
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Controller: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Controller: %w", err)
 	}
 
 	return nil
@@ -64,7 +64,7 @@ func (vcdClient *VCDClient) GetAllAlbImportableClouds(parentAlbControllerUrn str
 func (vcdClient *VCDClient) GetAlbImportableCloudByName(parentAlbControllerUrn, name string) (*NsxtAlbImportableCloud, error) {
 	albImportableClouds, err := vcdClient.GetAllAlbImportableClouds(parentAlbControllerUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by Name '%s': %w", name, err)
 	}
 
 	// Filtering by Name is not supported by API therefore it must be filtered on client side
@@ -79,7 +79,7 @@ func (vcdClient *VCDClient) GetAlbImportableCloudByName(parentAlbControllerUrn,
 	}
 
 	if !foundResult {
-		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Cloud by Name %s", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find NSX-T ALB Importable Cloud by Name %s", ErrorEntityNotFound, name)
 	}
 
 	return foundAlbImportableCloud, nil
@@ -90,7 +90,7 @@ func (vcdClient *VCDClient) GetAlbImportableCloudByName(parentAlbControllerUrn,
 func (vcdClient *VCDClient) GetAlbImportableCloudById(parentAlbControllerUrn, id string) (*NsxtAlbImportableCloud, error) {
 	albImportableClouds, err := vcdClient.GetAllAlbImportableClouds(parentAlbControllerUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by ID '%s': %w", id, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -104,7 +104,7 @@ func (vcdClient *VCDClient) GetAlbImportableCloudById(parentAlbControllerUrn, id
 	}
 
 	if !foundResult {
-		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Cloud by ID %s", ErrorEntityNotFound, id)
+		return nil, fmt.Errorf("%w: could not find NSX-T ALB Importable Cloud by ID %s", ErrorEntityNotFound, id)
 	}
 
 	return foundAlbImportableCloud, nil
@@ -65,7 +65,7 @@ func (vcdClient *VCDClient) GetAllAlbImportableServiceEngineGroups(parentAlbClou
 func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupByName(parentAlbCloudUrn, name string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := vcdClient.GetAllAlbImportableServiceEngineGroups(parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %w", name, err)
 	}
 
 	// Filtering by Name is not supported by API therefore it must be filtered on client side
@@ -80,7 +80,7 @@ func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupByName(parentAlbCl
 	}
 
 	if !foundResult {
-		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Service Engine Group by Name %s", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find NSX-T ALB Importable Service Engine Group by Name %s", ErrorEntityNotFound, name)
 	}
 
 	return foundAlbCloud, nil
@@ -91,7 +91,7 @@ func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupByName(parentAlbCl
 func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupById(parentAlbCloudUrn, id string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := vcdClient.GetAllAlbImportableServiceEngineGroups(parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %w", id, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -105,7 +105,7 @@ func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupById(parentAlbClou
 	}
 
 	if !foundResult {
-		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Service Engine Group by ID %s", ErrorEntityNotFound, id)
+		return nil, fmt.Errorf("%w: could not find NSX-T ALB Importable Service Engine Group by ID %s", ErrorEntityNotFound, id)
 	}
 
 	return foundImportableSEGroups, nil
@@ -155,7 +155,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) GetAllAlbImportableServiceEngineGroups(parentA
 func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupByName(parentAlbCloudUrn, name string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := nsxtAlbCloud.vcdClient.GetAllAlbImportableServiceEngineGroups(parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %w", name, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -170,7 +170,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupByName(paren
 	}
 
 	if !foundResult {
-		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Service Engine Group by Name %s", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find NSX-T ALB Importable Service Engine Group by Name %s", ErrorEntityNotFound, name)
 	}
 
 	return foundAlbCloud, nil
@@ -181,7 +181,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupByName(paren
 func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupById(parentAlbCloudUrn, id string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := nsxtAlbCloud.vcdClient.GetAllAlbImportableServiceEngineGroups(parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %w", id, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -195,7 +195,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupById(parentA
 	}
 
 	if !foundResult {
-		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Service Engine Group by ID %s", ErrorEntityNotFound, id)
+		return nil, fmt.Errorf("%w: could not find NSX-T ALB Importable Service Engine Group by ID %s", ErrorEntityNotFound, id)
 	}
 
 	return foundImportableSEGroups, nil
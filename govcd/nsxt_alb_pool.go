@@ -22,8 +22,24 @@ type NsxtAlbPool struct {
 // GetAllAlbPoolSummaries retrieves partial information for type `NsxtAlbPool`, but it is the only way to retrieve all ALB
 // pools for Edge Gateway
 func (vcdClient *VCDClient) GetAllAlbPoolSummaries(edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbPool, error) {
-	client := vcdClient.Client
+	typeResponses, err := getAllAlbPoolSummaries(&vcdClient.Client, edgeGatewayId, queryParameters)
+	if err != nil {
+		return nil, err
+	}
 
+	// Wrap all typeResponses into NsxtAlbPool types with client
+	wrappedResponses := make([]*NsxtAlbPool, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbPool{
+			NsxtAlbPool: typeResponses[sliceIndex],
+			vcdClient:   vcdClient,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+func getAllAlbPoolSummaries(client *Client, edgeGatewayId string, queryParameters url.Values) ([]*types.NsxtAlbPool, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPoolSummaries
 	apiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
 	if err != nil {
@@ -41,16 +57,7 @@ func (vcdClient *VCDClient) GetAllAlbPoolSummaries(edgeGatewayId string, queryPa
 		return nil, err
 	}
 
-	// Wrap all typeResponses into NsxtAlbPool types with client
-	wrappedResponses := make([]*NsxtAlbPool, len(typeResponses))
-	for sliceIndex := range typeResponses {
-		wrappedResponses[sliceIndex] = &NsxtAlbPool{
-			NsxtAlbPool: typeResponses[sliceIndex],
-			vcdClient:   vcdClient,
-		}
-	}
-
-	return wrappedResponses, nil
+	return typeResponses, nil
 }
 
 // GetAllAlbPools uses GetAllAlbPoolSummaries behind the scenes and the fetches complete data for all ALB Pools. This
@@ -58,7 +65,7 @@ func (vcdClient *VCDClient) GetAllAlbPoolSummaries(edgeGatewayId string, queryPa
 func (vcdClient *VCDClient) GetAllAlbPools(edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbPool, error) {
 	allAlbPoolSummaries, err := vcdClient.GetAllAlbPoolSummaries(edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all ALB Pool summaries: %s", err)
+		return nil, fmt.Errorf("error retrieving all ALB Pool summaries: %w", err)
 	}
 
 	// Loop over all Summaries and retrieve complete information
@@ -67,7 +74,7 @@ func (vcdClient *VCDClient) GetAllAlbPools(edgeGatewayId string, queryParameters
 
 		allAlbPools[index], err = vcdClient.GetAlbPoolById(allAlbPoolSummaries[index].NsxtAlbPool.ID)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving complete ALB Pool: %s", err)
+			return nil, fmt.Errorf("error retrieving complete ALB Pool: %w", err)
 		}
 
 	}
@@ -82,11 +89,11 @@ func (vcdClient *VCDClient) GetAlbPoolByName(edgeGatewayId string, name string)
 
 	allAlbPools, err := vcdClient.GetAllAlbPools(edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving ALB Pool with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving ALB Pool with Name '%s': %w", name, err)
 	}
 
 	if len(allAlbPools) == 0 {
-		return nil, fmt.Errorf("%s: could not find ALB Pool with Name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find ALB Pool with Name '%s'", ErrorEntityNotFound, name)
 	}
 
 	if len(allAlbPools) > 1 {
@@ -131,8 +138,15 @@ func (vcdClient *VCDClient) GetAlbPoolById(id string) (*NsxtAlbPool, error) {
 
 // CreateNsxtAlbPool creates NSX-T ALB Pool based on supplied configuration
 func (vcdClient *VCDClient) CreateNsxtAlbPool(albPoolConfig *types.NsxtAlbPool) (*NsxtAlbPool, error) {
-	client := vcdClient.Client
+	typeResponse, err := createNsxtAlbPool(&vcdClient.Client, albPoolConfig)
+	if err != nil {
+		return nil, err
+	}
 
+	return &NsxtAlbPool{NsxtAlbPool: typeResponse, vcdClient: vcdClient}, nil
+}
+
+func createNsxtAlbPool(client *Client, albPoolConfig *types.NsxtAlbPool) (*types.NsxtAlbPool, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPools
 	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
 	if err != nil {
@@ -144,17 +158,13 @@ func (vcdClient *VCDClient) CreateNsxtAlbPool(albPoolConfig *types.NsxtAlbPool)
 		return nil, err
 	}
 
-	returnObject := &NsxtAlbPool{
-		NsxtAlbPool: &types.NsxtAlbPool{},
-		vcdClient:   vcdClient,
-	}
-
-	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, albPoolConfig, returnObject.NsxtAlbPool, nil)
+	typeResponse := &types.NsxtAlbPool{}
+	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, albPoolConfig, typeResponse, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Pool: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Pool: %w", err)
 	}
 
-	return returnObject, nil
+	return typeResponse, nil
 }
 
 // Update updates NSX-T ALB Pool based on supplied configuration
@@ -182,7 +192,7 @@ func (nsxtAlbPool *NsxtAlbPool) Update(albPoolConfig *types.NsxtAlbPool) (*NsxtA
 
 	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, albPoolConfig, responseAlbController.NsxtAlbPool, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Pool: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Pool: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -208,7 +218,7 @@ func (nsxtAlbPool *NsxtAlbPool) Delete() error {
 
 	err = client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Pool: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Pool: %w", err)
 	}
 
 	return nil
@@ -149,7 +149,7 @@ func (vcdClient *VCDClient) CreateAlbServiceEngineGroupAssignment(assignmentConf
 
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, assignmentConfig, returnObject.NsxtAlbServiceEngineGroupAssignment, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group Assignment: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group Assignment: %w", err)
 	}
 
 	return returnObject, nil
@@ -180,7 +180,7 @@ func (nsxtEdgeAlbServiceEngineGroup *NsxtAlbServiceEngineGroupAssignment) Update
 
 	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, assignmentConfig, responseAlbController.NsxtAlbServiceEngineGroupAssignment, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group Assignment: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group Assignment: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -206,7 +206,7 @@ func (nsxtEdgeAlbServiceEngineGroup *NsxtAlbServiceEngineGroupAssignment) Delete
 
 	err = client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group Assignment: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group Assignment: %w", err)
 	}
 
 	return nil
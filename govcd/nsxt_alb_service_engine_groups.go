@@ -87,11 +87,11 @@ func (vcdClient *VCDClient) GetAlbServiceEngineGroupByName(optionalContext, name
 
 	albSeGroups, err := vcdClient.GetAllAlbServiceEngineGroups("", queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T ALB Service Engine Group By Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving NSX-T ALB Service Engine Group By Name '%s': %w", name, err)
 	}
 
 	if len(albSeGroups) == 0 {
-		return nil, fmt.Errorf("%s", ErrorEntityNotFound)
+		return nil, fmt.Errorf("%w", ErrorEntityNotFound)
 	}
 
 	if len(albSeGroups) > 1 {
@@ -159,7 +159,7 @@ func (vcdClient *VCDClient) CreateNsxtAlbServiceEngineGroup(albServiceEngineGrou
 
 	err = client.OpenApiPostItem(apiVersion, urlRef, nil, albServiceEngineGroup, returnObject.NsxtAlbServiceEngineGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	return returnObject, nil
@@ -190,7 +190,7 @@ func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Update(albSEGroupCon
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, albSEGroupConfig, responseAlbController.NsxtAlbServiceEngineGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -216,7 +216,7 @@ func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Delete() error {
 
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	return nil
@@ -244,12 +244,12 @@ func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Sync() error {
 
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error syncing NSX-T ALB Service Engine Group: %s", err)
+		return fmt.Errorf("error syncing NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("sync task for NSX-T ALB Service Engine Group failed: %s", err)
+		return fmt.Errorf("sync task for NSX-T ALB Service Engine Group failed: %w", err)
 	}
 
 	return nil
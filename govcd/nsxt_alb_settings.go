@@ -59,7 +59,7 @@ func (egw *NsxtEdgeGateway) DisableAlb() error {
 	}
 	_, err := egw.UpdateAlbSettings(config)
 	if err != nil {
-		return fmt.Errorf("error disabling NSX-T ALB: %s", err)
+		return fmt.Errorf("error disabling NSX-T ALB: %w", err)
 	}
 
 	return nil
@@ -22,8 +22,24 @@ type NsxtAlbVirtualService struct {
 // query. To fetch complete information for ALB Virtual Services one can use GetAllAlbVirtualServices(), but it is slower
 // as it has to retrieve Virtual Services one by one.
 func (vcdClient *VCDClient) GetAllAlbVirtualServiceSummaries(edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbVirtualService, error) {
-	client := vcdClient.Client
+	typeResponses, err := getAllAlbVirtualServiceSummaries(&vcdClient.Client, edgeGatewayId, queryParameters)
+	if err != nil {
+		return nil, err
+	}
 
+	// Wrap all typeResponses into NsxtAlbPool types with client
+	wrappedResponses := make([]*NsxtAlbVirtualService, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbVirtualService{
+			NsxtAlbVirtualService: typeResponses[sliceIndex],
+			vcdClient:             vcdClient,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+func getAllAlbVirtualServiceSummaries(client *Client, edgeGatewayId string, queryParameters url.Values) ([]*types.NsxtAlbVirtualService, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServiceSummaries
 	apiVersion, err := client.getOpenApiHighestElevatedVersion(endpoint)
 	if err != nil {
@@ -41,16 +57,7 @@ func (vcdClient *VCDClient) GetAllAlbVirtualServiceSummaries(edgeGatewayId strin
 		return nil, err
 	}
 
-	// Wrap all typeResponses into NsxtAlbPool types with client
-	wrappedResponses := make([]*NsxtAlbVirtualService, len(typeResponses))
-	for sliceIndex := range typeResponses {
-		wrappedResponses[sliceIndex] = &NsxtAlbVirtualService{
-			NsxtAlbVirtualService: typeResponses[sliceIndex],
-			vcdClient:             vcdClient,
-		}
-	}
-
-	return wrappedResponses, nil
+	return typeResponses, nil
 }
 
 // GetAllAlbVirtualServices fetches ALB Virtual Services by at first listing all Virtual Services summaries and then
@@ -58,7 +65,7 @@ func (vcdClient *VCDClient) GetAllAlbVirtualServiceSummaries(edgeGatewayId strin
 func (vcdClient *VCDClient) GetAllAlbVirtualServices(edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbVirtualService, error) {
 	allAlbVirtualServiceSummaries, err := vcdClient.GetAllAlbVirtualServiceSummaries(edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all ALB Virtual Service summaries: %s", err)
+		return nil, fmt.Errorf("error retrieving all ALB Virtual Service summaries: %w", err)
 	}
 
 	// Loop over all Summaries and retrieve complete information
@@ -66,7 +73,7 @@ func (vcdClient *VCDClient) GetAllAlbVirtualServices(edgeGatewayId string, query
 	for index := range allAlbVirtualServiceSummaries {
 		allAlbVirtualServices[index], err = vcdClient.GetAlbVirtualServiceById(allAlbVirtualServiceSummaries[index].NsxtAlbVirtualService.ID)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving complete ALB Virtual Service: %s", err)
+			return nil, fmt.Errorf("error retrieving complete ALB Virtual Service: %w", err)
 		}
 
 	}
@@ -81,11 +88,11 @@ func (vcdClient *VCDClient) GetAlbVirtualServiceByName(edgeGatewayId string, nam
 
 	allAlbVirtualServices, err := vcdClient.GetAllAlbVirtualServices(edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading ALB Virtual Service with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error reading ALB Virtual Service with Name '%s': %w", name, err)
 	}
 
 	if len(allAlbVirtualServices) == 0 {
-		return nil, fmt.Errorf("%s: could not find ALB Virtual Service with Name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find ALB Virtual Service with Name '%s'", ErrorEntityNotFound, name)
 	}
 
 	if len(allAlbVirtualServices) > 1 {
@@ -130,8 +137,15 @@ func (vcdClient *VCDClient) GetAlbVirtualServiceById(id string) (*NsxtAlbVirtual
 
 // CreateNsxtAlbVirtualService creates NSX-T ALB Virtual Service based on supplied configuration
 func (vcdClient *VCDClient) CreateNsxtAlbVirtualService(albVirtualServiceConfig *types.NsxtAlbVirtualService) (*NsxtAlbVirtualService, error) {
-	client := vcdClient.Client
+	typeResponse, err := createNsxtAlbVirtualService(&vcdClient.Client, albVirtualServiceConfig)
+	if err != nil {
+		return nil, err
+	}
 
+	return &NsxtAlbVirtualService{NsxtAlbVirtualService: typeResponse, vcdClient: vcdClient}, nil
+}
+
+func createNsxtAlbVirtualService(client *Client, albVirtualServiceConfig *types.NsxtAlbVirtualService) (*types.NsxtAlbVirtualService, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServices
 	minimumApiVersion, err := client.getOpenApiHighestElevatedVersion(endpoint)
 	if err != nil {
@@ -143,17 +157,13 @@ func (vcdClient *VCDClient) CreateNsxtAlbVirtualService(albVirtualServiceConfig
 		return nil, err
 	}
 
-	returnObject := &NsxtAlbVirtualService{
-		NsxtAlbVirtualService: &types.NsxtAlbVirtualService{},
-		vcdClient:             vcdClient,
-	}
-
-	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, albVirtualServiceConfig, returnObject.NsxtAlbVirtualService, nil)
+	typeResponse := &types.NsxtAlbVirtualService{}
+	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, albVirtualServiceConfig, typeResponse, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Virtual Service: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Virtual Service: %w", err)
 	}
 
-	return returnObject, nil
+	return typeResponse, nil
 }
 
 // Update updates NSX-T ALB Virtual Service based on supplied configuration
@@ -181,7 +191,7 @@ func (nsxtAlbVirtualService *NsxtAlbVirtualService) Update(albVirtualServiceConf
 
 	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, albVirtualServiceConfig, responseAlbController.NsxtAlbVirtualService, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Virtual Service: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Virtual Service: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -207,7 +217,7 @@ func (nsxtAlbVirtualService *NsxtAlbVirtualService) Delete() error {
 
 	err = client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Virtual Service: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Virtual Service: %w", err)
 	}
 
 	return nil
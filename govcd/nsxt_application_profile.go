@@ -30,28 +30,7 @@ type NsxtAppPortProfile struct {
 // * TENANT (Create by tenant at Org level)
 // More details about scope in documentation for types.NsxtAppPortProfile
 func (org *Org) CreateNsxtAppPortProfile(appPortProfileConfig *types.NsxtAppPortProfile) (*NsxtAppPortProfile, error) {
-	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAppPortProfiles
-	minimumApiVersion, err := org.client.checkOpenApiEndpointCompatibility(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	urlRef, err := org.client.OpenApiBuildEndpoint(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	returnObject := &NsxtAppPortProfile{
-		NsxtAppPortProfile: &types.NsxtAppPortProfile{},
-		client:             org.client,
-	}
-
-	err = org.client.OpenApiPostItem(minimumApiVersion, urlRef, nil, appPortProfileConfig, returnObject.NsxtAppPortProfile, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Application Port Profile: %s", err)
-	}
-
-	return returnObject, nil
+	return createNsxtAppPortProfile(org.client, appPortProfileConfig)
 }
 
 // GetAllNsxtAppPortProfiles returns all NSX-T Application Port Profiles for specific scope
@@ -136,7 +115,7 @@ func (appPortProfile *NsxtAppPortProfile) Update(appPortProfileConfig *types.Nsx
 
 	err = appPortProfile.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, appPortProfileConfig, returnObject.NsxtAppPortProfile, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T Application Port Profile : %s", err)
+		return nil, fmt.Errorf("error updating NSX-T Application Port Profile : %w", err)
 	}
 
 	return returnObject, nil
@@ -162,7 +141,7 @@ func (appPortProfile *NsxtAppPortProfile) Delete() error {
 	err = appPortProfile.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Application Port Profile: %s", err)
+		return fmt.Errorf("error deleting NSX-T Application Port Profile: %w", err)
 	}
 
 	return nil
@@ -174,11 +153,11 @@ func getNsxtAppPortProfileByName(client *Client, name string, queryParameters ur
 
 	allAppPortProfiles, err := getAllNsxtAppPortProfiles(client, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("could not find NSX-T Application Port Profile with name '%s': %s", name, err)
+		return nil, fmt.Errorf("could not find NSX-T Application Port Profile with name '%s': %w", name, err)
 	}
 
 	if len(allAppPortProfiles) == 0 {
-		return nil, fmt.Errorf("%s: expected exactly one NSX-T Application Port Profile with name '%s'. Got %d", ErrorEntityNotFound, name, len(allAppPortProfiles))
+		return nil, fmt.Errorf("%w: expected exactly one NSX-T Application Port Profile with name '%s'. Got %d", ErrorEntityNotFound, name, len(allAppPortProfiles))
 	}
 
 	if len(allAppPortProfiles) > 1 {
@@ -246,3 +225,28 @@ func getAllNsxtAppPortProfiles(client *Client, queryParameters url.Values) ([]*N
 
 	return wrappedResponses, nil
 }
+
+func createNsxtAppPortProfile(client *Client, appPortProfileConfig *types.NsxtAppPortProfile) (*NsxtAppPortProfile, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAppPortProfiles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAppPortProfile{
+		NsxtAppPortProfile: &types.NsxtAppPortProfile{},
+		client:             client,
+	}
+
+	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, appPortProfileConfig, returnObject.NsxtAppPortProfile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Application Port Profile: %w", err)
+	}
+
+	return returnObject, nil
+}
@@ -117,7 +117,7 @@ func (vdcGroup *VdcGroup) GetDistributedFirewallRuleByName(name string) (*Distri
 
 	dfw, err := vdcGroup.GetDistributedFirewall()
 	if err != nil {
-		return nil, fmt.Errorf("error returning distributed firewall rules: %s", err)
+		return nil, fmt.Errorf("error returning distributed firewall rules: %w", err)
 	}
 
 	singleRuleByName, err := localFilterOneOrError(labelDistributedFirewallRule, dfw.DistributedFirewallRuleContainer.Values, "Name", name)
@@ -208,7 +208,7 @@ func (vdcGroup *VdcGroup) CreateDistributedFirewallRule(optionalAboveRuleId stri
 		// 'newRuleRawJson' into position 'newFwRuleSliceIndex' and shift other rules to the bottom
 		dfwRuleUpdatePayload, err = composeUpdatePayloadWithNewRulePosition(newFwRuleSliceIndex, rawJsonExistingFirewallRules, newRuleRawJson)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error creating update payload with optionalAboveRuleId '%s' :%s", optionalAboveRuleId, err)
+			return nil, nil, fmt.Errorf("error creating update payload with optionalAboveRuleId '%s' :%w", optionalAboveRuleId, err)
 		}
 	}
 	// 4. Perform a PUT (update) call to the "vdcGroups/%s/dfwPolicies/%s/rules" endpoint using the
@@ -298,7 +298,7 @@ func getFirewallRuleIndexById(dfwRules *types.DistributedFirewallRules, firewall
 func firewallRuleToRawJson(rule *types.DistributedFirewallRule) (json.RawMessage, error) {
 	ruleByteSlice, err := json.Marshal(rule)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling 'rule': %s", err)
+		return nil, fmt.Errorf("error marshalling 'rule': %w", err)
 	}
 	ruleJsonMessage := json.RawMessage(string(ruleByteSlice))
 	return ruleJsonMessage, nil
@@ -322,7 +322,7 @@ func convertRawJsonToFirewallRules(rawBodyStructure *distributedFirewallRulesRaw
 	dfwRules := &types.DistributedFirewallRules{}
 	// Unmarshal all accumulated responses into `dfwRules`
 	if err := json.Unmarshal([]byte(allResponses), &dfwRules.Values); err != nil {
-		return nil, fmt.Errorf("error decoding values into type types.DistributedFirewallRules: %s", err)
+		return nil, fmt.Errorf("error decoding values into type types.DistributedFirewallRules: %w", err)
 	}
 
 	return dfwRules, nil
@@ -35,7 +35,7 @@ func (vdc *Vdc) GetNsxtEdgeClusterByName(name string) (*NsxtEdgeCluster, error)
 
 	nsxtEdgeClusters, err := vdc.GetAllNsxtEdgeClusters(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not find NSX-T Edge Cluster with name '%s' for Org VDC with id '%s': %s",
+		return nil, fmt.Errorf("could not find NSX-T Edge Cluster with name '%s' for Org VDC with id '%s': %w",
 			name, vdc.Vdc.ID, err)
 	}
 
@@ -45,7 +45,7 @@ func (vdc *Vdc) GetNsxtEdgeClusterByName(name string) (*NsxtEdgeCluster, error)
 
 	if len(nsxtEdgeClusters) == 0 {
 		// ErrorEntityNotFound is injected here for the ability to validate problem using ContainsNotFound()
-		return nil, fmt.Errorf("%s: no NSX-T Tier-0 Edge Cluster with name '%s' for Org VDC with id '%s' found",
+		return nil, fmt.Errorf("%w: no NSX-T Tier-0 Edge Cluster with name '%s' for Org VDC with id '%s' found",
 			ErrorEntityNotFound, name, vdc.Vdc.ID)
 	}
 
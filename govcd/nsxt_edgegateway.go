@@ -40,7 +40,7 @@ func (vdc *Vdc) GetNsxtEdgeGatewayById(id string) (*NsxtEdgeGateway, error) {
 	}
 
 	if egw.EdgeGateway.OwnerRef.ID != vdc.Vdc.ID {
-		return nil, fmt.Errorf("%s: no NSX-T Edge Gateway with ID '%s' found in VDC '%s'",
+		return nil, fmt.Errorf("%w: no NSX-T Edge Gateway with ID '%s' found in VDC '%s'",
 			ErrorEntityNotFound, id, vdc.Vdc.ID)
 	}
 
@@ -54,7 +54,7 @@ func (adminOrg *AdminOrg) GetNsxtEdgeGatewayByName(name string) (*NsxtEdgeGatewa
 
 	allEdges, err := adminOrg.GetAllNsxtEdgeGateways(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	onlyNsxtEdges := filterOnlyNsxtEdges(allEdges)
@@ -69,7 +69,7 @@ func (org *Org) GetNsxtEdgeGatewayByName(name string) (*NsxtEdgeGateway, error)
 
 	allEdges, err := org.GetAllNsxtEdgeGateways(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	onlyNsxtEdges := filterOnlyNsxtEdges(allEdges)
@@ -89,7 +89,7 @@ func (org *Org) GetNsxtEdgeGatewayByNameAndOwnerId(edgeGatewayName, ownerId stri
 
 	allEdges, err := org.GetAllNsxtEdgeGateways(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", edgeGatewayName, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", edgeGatewayName, err)
 	}
 
 	onlyNsxtEdges := filterOnlyNsxtEdges(allEdges)
@@ -104,7 +104,7 @@ func (vdc *Vdc) GetNsxtEdgeGatewayByName(name string) (*NsxtEdgeGateway, error)
 
 	allEdges, err := vdc.GetAllNsxtEdgeGateways(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	return returnSingleNsxtEdgeGateway(name, allEdges)
@@ -121,7 +121,7 @@ func (vdcGroup *VdcGroup) GetNsxtEdgeGatewayByName(name string) (*NsxtEdgeGatewa
 
 	allEdges, err := vdcGroup.GetAllNsxtEdgeGateways(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	return returnSingleNsxtEdgeGateway(name, allEdges)
@@ -181,12 +181,12 @@ func (adminOrg *AdminOrg) CreateNsxtEdgeGateway(edgeGatewayConfig *types.OpenAPI
 
 	err = adminOrg.client.OpenApiPostItem(minimumApiVersion, urlRef, nil, edgeGatewayConfig, returnEgw.EdgeGateway, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error creating Edge Gateway: %w", err)
 	}
 
 	err = returnEgw.reorderUplinks()
 	if err != nil {
-		return nil, fmt.Errorf("error reordering Edge Gateway Uplinks after update operation: %s", err)
+		return nil, fmt.Errorf("error reordering Edge Gateway Uplinks after update operation: %w", err)
 	}
 
 	return returnEgw, nil
@@ -200,13 +200,13 @@ func (egw *NsxtEdgeGateway) Refresh() error {
 
 	refreshedEdge, err := getNsxtEdgeGatewayById(egw.client, egw.EdgeGateway.ID, nil)
 	if err != nil {
-		return fmt.Errorf("error refreshing NSX-T Edge Gateway: %s", err)
+		return fmt.Errorf("error refreshing NSX-T Edge Gateway: %w", err)
 	}
 	egw.EdgeGateway = refreshedEdge.EdgeGateway
 
 	err = egw.reorderUplinks()
 	if err != nil {
-		return fmt.Errorf("error reordering Edge Gateway Uplinks after refresh operation: %s", err)
+		return fmt.Errorf("error reordering Edge Gateway Uplinks after refresh operation: %w", err)
 	}
 
 	return nil
@@ -240,12 +240,12 @@ func (egw *NsxtEdgeGateway) Update(edgeGatewayConfig *types.OpenAPIEdgeGateway)
 
 	err = egw.client.OpenApiPutItem(apiVersion, urlRef, nil, edgeGatewayConfig, returnEgw.EdgeGateway, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error updating Edge Gateway: %w", err)
 	}
 
 	err = egw.reorderUplinks()
 	if err != nil {
-		return nil, fmt.Errorf("error reordering Edge Gateway Uplinks after update operation: %s", err)
+		return nil, fmt.Errorf("error reordering Edge Gateway Uplinks after update operation: %w", err)
 	}
 
 	return returnEgw, nil
@@ -275,7 +275,7 @@ func (egw *NsxtEdgeGateway) Delete() error {
 	err = egw.client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Edge Gateway: %s", err)
+		return fmt.Errorf("error deleting Edge Gateway: %w", err)
 	}
 
 	return nil
@@ -352,7 +352,7 @@ func getNsxtEdgeGatewayById(client *Client, id string, queryParameters url.Value
 	}
 
 	if egw.EdgeGateway.GatewayBacking.GatewayType != "NSXT_BACKED" {
-		return nil, fmt.Errorf("%s: this is not NSX-T Edge Gateway (%s)",
+		return nil, fmt.Errorf("%w: this is not NSX-T Edge Gateway (%s)",
 			ErrorEntityNotFound, egw.EdgeGateway.GatewayBacking.GatewayType)
 	}
 
@@ -372,7 +372,7 @@ func returnSingleNsxtEdgeGateway(name string, allEdges []*NsxtEdgeGateway) (*Nsx
 	}
 
 	if len(allEdges) < 1 {
-		return nil, fmt.Errorf("%s: got 0 Edge Gateways by name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: got 0 Edge Gateways by name '%s'", ErrorEntityNotFound, name)
 	}
 
 	return allEdges[0], nil
@@ -415,7 +415,7 @@ func getAllNsxtEdgeGateways(client *Client, queryParameters url.Values) ([]*Nsxt
 	for edgeIndex := range onlyNsxtEdges {
 		err := onlyNsxtEdges[edgeIndex].reorderUplinks()
 		if err != nil {
-			return nil, fmt.Errorf("error reordering NSX-T Edge Gateway Uplinks for gateway '%s' ('%s'): %s",
+			return nil, fmt.Errorf("error reordering NSX-T Edge Gateway Uplinks for gateway '%s' ('%s'): %w",
 				onlyNsxtEdges[edgeIndex].EdgeGateway.Name, onlyNsxtEdges[edgeIndex].EdgeGateway.ID, err)
 		}
 	}
@@ -500,12 +500,12 @@ func (egw *NsxtEdgeGateway) GetUnusedExternalIPAddresses(requiredIpCount int, op
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	return getUnusedExternalIPAddress(egw.EdgeGateway.EdgeGatewayUplinks, usedIpAddresses, requiredIpCount, optionalSubnet)
@@ -520,12 +520,12 @@ func (egw *NsxtEdgeGateway) GetAllUnusedExternalIPAddresses(refresh bool) ([]net
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	return getAllUnusedExternalIPAddresses(egw.EdgeGateway.EdgeGatewayUplinks, usedIpAddresses, netip.Prefix{}, 0)
@@ -539,17 +539,17 @@ func (egw *NsxtEdgeGateway) GetUsedAndUnusedExternalIPAddressCountWithLimit(refr
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return 0, 0, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return 0, 0, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(nil)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return 0, 0, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	assignedIpAddresses, err := flattenEdgeGatewayUplinkToIpSlice(egw.EdgeGateway.EdgeGatewayUplinks, limitTo)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error listing all IPs in Edge Gateway: %s", err)
+		return 0, 0, fmt.Errorf("error listing all IPs in Edge Gateway: %w", err)
 	}
 
 	usedIpCount := int64(len(usedIpAddresses))
@@ -565,7 +565,7 @@ func (egw *NsxtEdgeGateway) GetAllocatedIpCount(refresh bool) (int, error) {
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return 0, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return 0, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 
@@ -588,7 +588,7 @@ func (egw *NsxtEdgeGateway) GetPrimaryNetworkAllocatedIpCount(refresh bool) (int
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return 0, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return 0, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 
@@ -621,7 +621,7 @@ func (egw *NsxtEdgeGateway) GetAllocatedIpCountByUplinkType(refresh bool, uplink
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return 0, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return 0, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 
@@ -648,12 +648,12 @@ func (egw *NsxtEdgeGateway) GetUsedIpAddressSlice(refresh bool) ([]netip.Addr, e
 	if refresh {
 		err := egw.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	return flattenGatewayUsedIpAddressesToIpSlice(usedIpAddresses)
@@ -674,12 +674,12 @@ func (egw *NsxtEdgeGateway) QuickDeallocateIpCount(ipCount int) (*NsxtEdgeGatewa
 
 	err := egw.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 	}
 
 	err = egw.DeallocateIpCount(ipCount)
 	if err != nil {
-		return nil, fmt.Errorf("error deallocating IP count: %s", err)
+		return nil, fmt.Errorf("error deallocating IP count: %w", err)
 	}
 
 	return egw.Update(egw.EdgeGateway)
@@ -928,7 +928,7 @@ func getAllUnusedExternalIPAddresses(uplinks []types.EdgeGatewayUplinks, usedIpA
 	// operator)
 	assignedIpSlice, err := flattenEdgeGatewayUplinkToIpSlice(uplinks, limitTo)
 	if err != nil {
-		return nil, fmt.Errorf("error listing all IPs in Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error listing all IPs in Edge Gateway: %w", err)
 	}
 
 	if len(assignedIpSlice) == 0 {
@@ -939,14 +939,14 @@ func getAllUnusedExternalIPAddresses(uplinks []types.EdgeGatewayUplinks, usedIpA
 	if optionalSubnet != (netip.Prefix{}) {
 		assignedIpSlice, err = filterIpSlicesBySubnet(assignedIpSlice, optionalSubnet)
 		if err != nil {
-			return nil, fmt.Errorf("error filtering ranges for given subnet '%s': %s", optionalSubnet, err)
+			return nil, fmt.Errorf("error filtering ranges for given subnet '%s': %w", optionalSubnet, err)
 		}
 	}
 
 	// 3. Get Used IP addresses in Edge Gateway in the same slice format
 	usedIpSlice, err := flattenGatewayUsedIpAddressesToIpSlice(usedIpAddresses)
 	if err != nil {
-		return nil, fmt.Errorf("could not flatten Edge Gateway used IP addresses: %s", err)
+		return nil, fmt.Errorf("could not flatten Edge Gateway used IP addresses: %w", err)
 	}
 
 	// 4. Get all unused IPs
@@ -959,7 +959,7 @@ func getAllUnusedExternalIPAddresses(uplinks []types.EdgeGatewayUplinks, usedIpA
 func getUnusedExternalIPAddress(uplinks []types.EdgeGatewayUplinks, usedIpAddresses []*types.GatewayUsedIpAddress, requiredIpCount int, optionalSubnet netip.Prefix) ([]netip.Addr, error) {
 	unusedIps, err := getAllUnusedExternalIPAddresses(uplinks, usedIpAddresses, optionalSubnet, 0)
 	if err != nil {
-		return nil, fmt.Errorf("error getting all unused IPs: %s", err)
+		return nil, fmt.Errorf("error getting all unused IPs: %w", err)
 	}
 
 	// 5. Check if 'requiredIpCount' criteria is met
@@ -990,7 +990,7 @@ func flattenEdgeGatewayUplinkToIpSlice(uplinks []types.EdgeGatewayUplinks, limit
 				// Convert IPs to netip.Addr
 				startIp, err := netip.ParseAddr(r.StartAddress)
 				if err != nil {
-					return nil, fmt.Errorf("error parsing start IP address in range '%s': %s", r.StartAddress, err)
+					return nil, fmt.Errorf("error parsing start IP address in range '%s': %w", r.StartAddress, err)
 				}
 
 				// if we have end address specified - a range of IPs must be expanded into slice
@@ -998,7 +998,7 @@ func flattenEdgeGatewayUplinkToIpSlice(uplinks []types.EdgeGatewayUplinks, limit
 				if r.EndAddress != "" {
 					endIp, err := netip.ParseAddr(r.EndAddress)
 					if err != nil {
-						return nil, fmt.Errorf("error parsing end IP address in range '%s': %s", r.EndAddress, err)
+						return nil, fmt.Errorf("error parsing end IP address in range '%s': %w", r.EndAddress, err)
 					}
 
 					// Check if EndAddress is lower than StartAddress ant report an error if so
@@ -1129,7 +1129,7 @@ func flattenGatewayUsedIpAddressesToIpSlice(usedIpAddresses []*types.GatewayUsed
 	for usedIpIndex := range usedIpAddresses {
 		ip, err := netip.ParseAddr(usedIpAddresses[usedIpIndex].IPAddress)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing IP '%s' in Edge Gateway used IP address list: %s", usedIpAddresses[usedIpIndex].IPAddress, err)
+			return nil, fmt.Errorf("error parsing IP '%s' in Edge Gateway used IP address list: %w", usedIpAddresses[usedIpIndex].IPAddress, err)
 		}
 		usedIpSlice[usedIpIndex] = ip
 	}
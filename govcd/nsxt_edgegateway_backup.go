@@ -0,0 +1,1177 @@
+package govcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+const (
+	labelEdgeGatewayBackup          = "NSX-T Edge Gateway backup"
+	labelNsxtStaticRoute            = "NSX-T Static Route"
+	labelNsxtRouteAdvertisement     = "NSX-T Route Advertisement"
+	labelNsxtBgpConfiguration       = "NSX-T BGP Configuration"
+	labelNsxtBgpIpPrefixList        = "NSX-T BGP IP Prefix List"
+	labelNsxtBgpNeighbor            = "NSX-T BGP Neighbor"
+	labelNsxtDnsForwarder           = "NSX-T DNS Forwarder"
+	labelNsxtSlaacProfile           = "NSX-T SLAAC Profile"
+	labelNsxtQos                    = "NSX-T Rate Limiting"
+	labelNsxtIpSecVpnTunnel         = "NSX-T IPsec VPN Tunnel"
+	labelNsxtIpSecVpnTunnelSecurity = "NSX-T IPsec VPN Tunnel Security Profile"
+	labelNsxtL2VpnTunnel            = "NSX-T L2 VPN Tunnel"
+	labelNsxtAlbSettings            = "NSX-T ALB Settings"
+)
+
+// EdgeGatewayBackupVersion is the version of the EdgeGatewayBackup written by Backup. Documents
+// with a different version are rejected by Restore
+const EdgeGatewayBackupVersion = 1
+
+// EdgeGatewayBackup contains the configuration of the services of an NSX-T Edge Gateway, as
+// returned by NsxtEdgeGateway.Backup. It is meant to be stored as JSON.
+//
+// The firewall is stored as a FirewallPolicyDocument, which references Firewall Groups and
+// Application Port Profiles by name. All other entities are stored as returned by the API, with
+// the IDs of the source environment, which Restore replaces with the IDs of the target.
+//
+// A section is empty when the VCD version does not support it. BGP and Route Advertisement are
+// only included for Edge Gateways with a dedicated uplink. Secrets which are not returned by the
+// API, such as BGP neighbor passwords, are not included, while IPsec VPN pre-shared keys are, so
+// the document must be stored securely. ALB Pools, ALB Virtual Services and Service Engine Group
+// assignments are not included
+type EdgeGatewayBackup struct {
+	Version int `json:"version"`
+	// SourceId and SourceName identify the Edge Gateway that was backed up
+	SourceId   string `json:"sourceId"`
+	SourceName string `json:"sourceName"`
+	// OwnerId is the ID of the VDC or VDC Group which owned the Edge Gateway
+	OwnerId string `json:"ownerId"`
+
+	// Firewall also contains the TENANT Application Port Profiles used by NAT rules
+	Firewall *FirewallPolicyDocument `json:"firewall,omitempty"`
+	NatRules []*types.NsxtNatRule    `json:"natRules,omitempty"`
+	// StaticRoutes does not contain system owned routes
+	StaticRoutes       []*types.NsxtEdgeGatewayStaticRoute `json:"staticRoutes,omitempty"`
+	RouteAdvertisement *types.RouteAdvertisement           `json:"routeAdvertisement,omitempty"`
+	BgpConfiguration   *types.EdgeBgpConfig                `json:"bgpConfiguration,omitempty"`
+	BgpIpPrefixLists   []*types.EdgeBgpIpPrefixList        `json:"bgpIpPrefixLists,omitempty"`
+	BgpNeighbors       []*types.EdgeBgpNeighbor            `json:"bgpNeighbors,omitempty"`
+	DnsForwarder       *types.NsxtEdgeGatewayDns           `json:"dnsForwarder,omitempty"`
+	DhcpForwarder      *types.NsxtEdgeGatewayDhcpForwarder `json:"dhcpForwarder,omitempty"`
+	SlaacProfile       *types.NsxtEdgeGatewaySlaacProfile  `json:"slaacProfile,omitempty"`
+	Qos                *types.NsxtEdgeGatewayQos           `json:"qos,omitempty"`
+	IpSecVpnTunnels    []*types.NsxtIpSecVpnTunnel         `json:"ipSecVpnTunnels,omitempty"`
+	// IpSecVpnTunnelSecurityProfiles contains the security profiles of the IPsec VPN tunnels with
+	// CUSTOM security type, by tunnel name
+	IpSecVpnTunnelSecurityProfiles map[string]*types.NsxtIpSecVpnTunnelSecurityProfile `json:"ipSecVpnTunnelSecurityProfiles,omitempty"`
+	L2VpnTunnels                   []*types.NsxtL2VpnTunnel                            `json:"l2VpnTunnels,omitempty"`
+	AlbSettings                    *types.NsxtAlbConfig                                `json:"albSettings,omitempty"`
+}
+
+// EdgeGatewayRestoreOptions configures NsxtEdgeGateway.Restore
+type EdgeGatewayRestoreOptions struct {
+	// IdMapping maps IDs of the backup to IDs of the target for references which are not restored
+	// and cannot be resolved by name, such as QoS profiles, the ALB cloud and the certificates of
+	// IPsec VPN tunnels. IDs without a mapping are used as they are, which is correct when restoring
+	// in the same VCD
+	IdMapping map[string]string
+	// DryRun compares the backup with the target and reports the differences without restoring
+	// anything
+	DryRun bool
+}
+
+// EdgeGatewayRestoreResult contains the changes of NsxtEdgeGateway.Restore
+type EdgeGatewayRestoreResult struct {
+	Changes []ConfigurationChange
+	// IdMapping maps the IDs of the backup to the IDs of the matching entities of the target. It
+	// contains the restored entities and the Org VDC networks and Application Port Profiles they
+	// reference. In a dry run, entities to be created have no mapping
+	IdMapping map[string]string
+	// Applied is false when EdgeGatewayRestoreOptions.DryRun was set
+	Applied bool
+}
+
+// IsEmpty returns true when the target already matches the backup
+func (result *EdgeGatewayRestoreResult) IsEmpty() bool {
+	return len(result.Changes) == 0
+}
+
+// String returns the differences between the backup and the target, one line per entity
+func (result *EdgeGatewayRestoreResult) String() string {
+	return configurationChangesString(result.Changes)
+}
+
+// Backup returns the configuration of the firewall, NAT, routing, DNS and DHCP forwarders, SLAAC,
+// Rate Limiting, IPsec and L2 VPN tunnels and ALB settings of the Edge Gateway as one document,
+// which can be restored on this or another Edge Gateway with Restore
+func (egw *NsxtEdgeGateway) Backup() (*EdgeGatewayBackup, error) {
+	if egw.EdgeGateway == nil || egw.EdgeGateway.ID == "" || egw.EdgeGateway.OwnerRef == nil {
+		return nil, fmt.Errorf("cannot create %s without Edge Gateway ID and owner", labelEdgeGatewayBackup)
+	}
+	backup := &EdgeGatewayBackup{
+		Version:    EdgeGatewayBackupVersion,
+		SourceId:   egw.EdgeGateway.ID,
+		SourceName: egw.EdgeGateway.Name,
+		OwnerId:    egw.EdgeGateway.OwnerRef.ID,
+	}
+
+	var err error
+	backup.Firewall, err = egw.ExportFirewallPolicy()
+	if err != nil {
+		return nil, egw.backupError(labelFirewallPolicy, err)
+	}
+
+	allNatRules, err := egw.GetAllNatRules(nil)
+	if err != nil {
+		return nil, egw.backupError(labelNsxtNatRule, err)
+	}
+	for _, natRule := range allNatRules {
+		backup.NatRules = append(backup.NatRules, natRule.NsxtNatRule)
+	}
+	err = backup.addNatAppPortProfiles(egw.client)
+	if err != nil {
+		return nil, egw.backupError(labelNsxtNatRule, err)
+	}
+
+	if egw.supportsEndpoint(types.OpenApiEndpointEdgeGatewayStaticRoutes) {
+		allStaticRoutes, err := egw.GetAllStaticRoutes(nil)
+		if err != nil {
+			return nil, egw.backupError(labelNsxtStaticRoute, err)
+		}
+		for _, staticRoute := range allStaticRoutes {
+			if staticRoute.NsxtEdgeGatewayStaticRoute.SystemOwned != nil && *staticRoute.NsxtEdgeGatewayStaticRoute.SystemOwned {
+				continue
+			}
+			backup.StaticRoutes = append(backup.StaticRoutes, staticRoute.NsxtEdgeGatewayStaticRoute)
+		}
+	}
+
+	if egw.hasDedicatedUplink() {
+		backup.RouteAdvertisement, err = egw.GetNsxtRouteAdvertisement()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtRouteAdvertisement, err)
+		}
+		backup.BgpConfiguration, err = egw.GetBgpConfiguration()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtBgpConfiguration, err)
+		}
+		allPrefixLists, err := egw.GetAllBgpIpPrefixLists(nil)
+		if err != nil {
+			return nil, egw.backupError(labelNsxtBgpIpPrefixList, err)
+		}
+		for _, prefixList := range allPrefixLists {
+			backup.BgpIpPrefixLists = append(backup.BgpIpPrefixLists, prefixList.EdgeBgpIpPrefixList)
+		}
+		allNeighbors, err := egw.GetAllBgpNeighbors(nil)
+		if err != nil {
+			return nil, egw.backupError(labelNsxtBgpNeighbor, err)
+		}
+		for _, neighbor := range allNeighbors {
+			backup.BgpNeighbors = append(backup.BgpNeighbors, neighbor.EdgeBgpNeighbor)
+		}
+	}
+
+	if egw.supportsEndpoint(types.OpenApiEndpointEdgeGatewayDns) {
+		dns, err := egw.GetDnsConfig()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtDnsForwarder, err)
+		}
+		backup.DnsForwarder = dns.NsxtEdgeGatewayDns
+	}
+	if egw.supportsEndpoint(types.OpenApiEndpointEdgeGatewayDhcpForwarder) {
+		backup.DhcpForwarder, err = egw.GetDhcpForwarder()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtDhcpForwarder, err)
+		}
+	}
+	if egw.supportsEndpoint(types.OpenApiEndpointEdgeGatewaySlaacProfile) {
+		backup.SlaacProfile, err = egw.GetSlaacProfile()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtSlaacProfile, err)
+		}
+	}
+	if egw.supportsEndpoint(types.OpenApiEndpointEdgeGatewayQos) {
+		backup.Qos, err = egw.GetQoS()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtQos, err)
+		}
+	}
+
+	allIpSecVpnTunnels, err := egw.GetAllIpSecVpnTunnels(nil)
+	if err != nil {
+		return nil, egw.backupError(labelNsxtIpSecVpnTunnel, err)
+	}
+	for _, tunnel := range allIpSecVpnTunnels {
+		// Only retrieval by ID returns the pre-shared key
+		tunnel, err = egw.GetIpSecVpnTunnelById(tunnel.NsxtIpSecVpn.ID)
+		if err != nil {
+			return nil, egw.backupError(labelNsxtIpSecVpnTunnel, err)
+		}
+		backup.IpSecVpnTunnels = append(backup.IpSecVpnTunnels, tunnel.NsxtIpSecVpn)
+		if tunnel.NsxtIpSecVpn.SecurityType != "CUSTOM" {
+			continue
+		}
+		securityProfile, err := tunnel.GetTunnelConnectionProperties()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtIpSecVpnTunnelSecurity, err)
+		}
+		if backup.IpSecVpnTunnelSecurityProfiles == nil {
+			backup.IpSecVpnTunnelSecurityProfiles = make(map[string]*types.NsxtIpSecVpnTunnelSecurityProfile)
+		}
+		backup.IpSecVpnTunnelSecurityProfiles[tunnel.NsxtIpSecVpn.Name] = securityProfile
+	}
+
+	if egw.supportsEndpoint(types.OpenApiEndpointEdgeGatewayL2VpnTunnel) {
+		allL2VpnTunnels, err := egw.GetAllL2VpnTunnels(nil)
+		if err != nil {
+			return nil, egw.backupError(labelNsxtL2VpnTunnel, err)
+		}
+		for _, tunnel := range allL2VpnTunnels {
+			backup.L2VpnTunnels = append(backup.L2VpnTunnels, tunnel.NsxtL2VpnTunnel)
+		}
+	}
+
+	if egw.supportsEndpoint(types.OpenApiEndpointAlbEdgeGateway) {
+		backup.AlbSettings, err = egw.GetAlbSettings()
+		if err != nil {
+			return nil, egw.backupError(labelNsxtAlbSettings, err)
+		}
+	}
+	return backup, nil
+}
+
+func (egw *NsxtEdgeGateway) backupError(label string, err error) error {
+	return fmt.Errorf("error backing up %s of NSX-T Edge Gateway '%s': %w", label, egw.EdgeGateway.Name, err)
+}
+
+// supportsEndpoint returns true when the VCD version supports an OpenAPI endpoint, given without
+// version path
+func (egw *NsxtEdgeGateway) supportsEndpoint(endpoint string) bool {
+	_, err := egw.client.checkOpenApiEndpointCompatibility(types.OpenApiPathVersion1_0_0 + endpoint)
+	return err == nil
+}
+
+// hasDedicatedUplink returns true when the Edge Gateway has a dedicated uplink, which is required
+// for BGP and Route Advertisement
+func (egw *NsxtEdgeGateway) hasDedicatedUplink() bool {
+	for _, uplink := range egw.EdgeGateway.EdgeGatewayUplinks {
+		if uplink.Dedicated {
+			return true
+		}
+	}
+	return false
+}
+
+// addNatAppPortProfiles adds the TENANT Application Port Profiles used by NAT rules to the firewall
+// document, so that they are restored with the ones used by firewall rules
+func (backup *EdgeGatewayBackup) addNatAppPortProfiles(client *Client) error {
+	known := make(map[string]bool)
+	for _, profile := range backup.Firewall.ApplicationPortProfiles {
+		known[profile.Name] = true
+	}
+	for _, natRule := range backup.NatRules {
+		if natRule.ApplicationPortProfile == nil || natRule.ApplicationPortProfile.ID == "" || known[natRule.ApplicationPortProfile.Name] {
+			continue
+		}
+		profile, err := getNsxtAppPortProfileById(client, natRule.ApplicationPortProfile.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving %s '%s' of rule '%s': %w", labelNsxtAppPortProfile, natRule.ApplicationPortProfile.Name, natRule.Name, err)
+		}
+		if profile.NsxtAppPortProfile.Scope != types.ApplicationPortProfileScopeTenant {
+			continue
+		}
+		known[profile.NsxtAppPortProfile.Name] = true
+		backup.Firewall.ApplicationPortProfiles = append(backup.Firewall.ApplicationPortProfiles, FirewallPolicyAppPortProfile{
+			Name:             profile.NsxtAppPortProfile.Name,
+			Description:      profile.NsxtAppPortProfile.Description,
+			ApplicationPorts: profile.NsxtAppPortProfile.ApplicationPorts,
+		})
+	}
+	return nil
+}
+
+// validate checks the version of the backup and that the keys used to match the entities of the
+// target are unique
+func (backup *EdgeGatewayBackup) validate() error {
+	if backup == nil {
+		return fmt.Errorf("%s is empty", labelEdgeGatewayBackup)
+	}
+	if backup.Version != EdgeGatewayBackupVersion {
+		return fmt.Errorf("unsupported %s version %d, expected %d", labelEdgeGatewayBackup, backup.Version, EdgeGatewayBackupVersion)
+	}
+	if backup.Firewall != nil {
+		err := backup.Firewall.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := make(map[string]bool)
+	checkUnique := func(label, key string) error {
+		if key == "" || keys[label+"\x00"+key] {
+			return fmt.Errorf("%s without name or duplicate '%s': names must be unique", label, key)
+		}
+		keys[label+"\x00"+key] = true
+		return nil
+	}
+	var err error
+	for _, natRule := range backup.NatRules {
+		err = checkUnique(labelNsxtNatRule, natRule.Name)
+		if err != nil {
+			return err
+		}
+	}
+	for _, staticRoute := range backup.StaticRoutes {
+		err = checkUnique(labelNsxtStaticRoute, staticRoute.Name)
+		if err != nil {
+			return err
+		}
+	}
+	for _, prefixList := range backup.BgpIpPrefixLists {
+		err = checkUnique(labelNsxtBgpIpPrefixList, prefixList.Name)
+		if err != nil {
+			return err
+		}
+	}
+	for _, neighbor := range backup.BgpNeighbors {
+		err = checkUnique(labelNsxtBgpNeighbor, neighbor.NeighborAddress)
+		if err != nil {
+			return err
+		}
+	}
+	for _, tunnel := range backup.IpSecVpnTunnels {
+		err = checkUnique(labelNsxtIpSecVpnTunnel, tunnel.Name)
+		if err != nil {
+			return err
+		}
+	}
+	for _, tunnel := range backup.L2VpnTunnels {
+		err = checkUnique(labelNsxtL2VpnTunnel, tunnel.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore creates or updates the configuration of the Edge Gateway to match a backup made by
+// Backup. Entities are matched by name (BGP neighbors by address), and entities of the Edge
+// Gateway which are not in the backup are kept. The firewall is restored with
+// FirewallPolicyImportMerge.
+//
+// Entities are restored in dependency order: firewall (with the Application Port Profiles of NAT
+// rules), NAT rules, static routes, route advertisement, BGP configuration, IP prefix lists and
+// neighbors, DNS and DHCP forwarders, SLAAC, Rate Limiting, IPsec and L2 VPN tunnels and ALB
+// settings. References to restored entities, to Org VDC networks and to Application Port Profiles
+// are remapped to the IDs of the target. All these names are resolved before making any change.
+//
+// Restore can be used to recover an Edge Gateway, or to clone its configuration into another
+// Edge Gateway, e.g. after MoveToVdcOrVdcGroup
+func (egw *NsxtEdgeGateway) Restore(backup *EdgeGatewayBackup, options EdgeGatewayRestoreOptions) (*EdgeGatewayRestoreResult, error) {
+	err := backup.validate()
+	if err != nil {
+		return nil, err
+	}
+	if egw.EdgeGateway == nil || egw.EdgeGateway.ID == "" || egw.EdgeGateway.OwnerRef == nil {
+		return nil, fmt.Errorf("cannot restore %s without Edge Gateway ID and owner", labelEdgeGatewayBackup)
+	}
+
+	restorer := &edgeGatewayRestorer{
+		egw:            egw,
+		backup:         backup,
+		options:        options,
+		contextId:      egw.EdgeGateway.OwnerRef.ID,
+		result:         &EdgeGatewayRestoreResult{IdMapping: make(map[string]string), Applied: !options.DryRun},
+		networkIds:     make(map[string]string),
+		profileIds:     make(map[string]string),
+		tenantProfiles: make(map[string]bool),
+	}
+	if backup.Firewall != nil {
+		for _, profile := range backup.Firewall.ApplicationPortProfiles {
+			restorer.tenantProfiles[profile.Name] = true
+		}
+	}
+
+	err = restorer.resolveNames()
+	if err != nil {
+		return nil, fmt.Errorf("error restoring %s into NSX-T Edge Gateway '%s': %w", labelEdgeGatewayBackup, egw.EdgeGateway.Name, err)
+	}
+
+	steps := []struct {
+		label   string
+		restore func() error
+	}{
+		{labelFirewallPolicy, restorer.restoreFirewall},
+		{labelNsxtNatRule, restorer.restoreNatRules},
+		{labelNsxtStaticRoute, restorer.restoreStaticRoutes},
+		{labelNsxtRouteAdvertisement, restorer.restoreRouteAdvertisement},
+		{labelNsxtBgpConfiguration, restorer.restoreBgpConfiguration},
+		{labelNsxtBgpIpPrefixList, restorer.restoreBgpIpPrefixLists},
+		{labelNsxtBgpNeighbor, restorer.restoreBgpNeighbors},
+		{labelNsxtDnsForwarder, restorer.restoreDnsForwarder},
+		{labelNsxtDhcpForwarder, restorer.restoreDhcpForwarder},
+		{labelNsxtSlaacProfile, restorer.restoreSlaacProfile},
+		{labelNsxtQos, restorer.restoreQos},
+		{labelNsxtIpSecVpnTunnel, restorer.restoreIpSecVpnTunnels},
+		{labelNsxtL2VpnTunnel, restorer.restoreL2VpnTunnels},
+		{labelNsxtAlbSettings, restorer.restoreAlbSettings},
+	}
+	for _, step := range steps {
+		err = step.restore()
+		if err != nil {
+			return restorer.result, fmt.Errorf("error restoring %s into NSX-T Edge Gateway '%s': %w", step.label, egw.EdgeGateway.Name, err)
+		}
+	}
+	return restorer.result, nil
+}
+
+// edgeGatewayRestorer restores an EdgeGatewayBackup into an Edge Gateway
+type edgeGatewayRestorer struct {
+	egw       *NsxtEdgeGateway
+	backup    *EdgeGatewayBackup
+	options   EdgeGatewayRestoreOptions
+	contextId string
+	result    *EdgeGatewayRestoreResult
+	// networkIds contains the IDs of the Org VDC networks of the target by name
+	networkIds map[string]string
+	// profileIds contains the IDs of the Application Port Profiles used by NAT rules by name
+	profileIds map[string]string
+	// tenantProfiles contains the names of the TENANT Application Port Profiles of the backup
+	tenantProfiles map[string]bool
+}
+
+// resolveNames looks up the Org VDC networks and the SYSTEM and PROVIDER Application Port Profiles
+// referenced by the backup, which must exist in the target
+func (restorer *edgeGatewayRestorer) resolveNames() error {
+	for _, staticRoute := range restorer.backup.StaticRoutes {
+		for _, nextHop := range staticRoute.NextHops {
+			if nextHop.Scope == nil || nextHop.Scope.ScopeType != "NETWORK" {
+				continue
+			}
+			_, err := restorer.networkId(nextHop.Scope.ID, nextHop.Scope.Name)
+			if err != nil {
+				return fmt.Errorf("error resolving next hop of %s '%s': %w", labelNsxtStaticRoute, staticRoute.Name, err)
+			}
+		}
+	}
+	for _, tunnel := range restorer.backup.L2VpnTunnels {
+		for _, network := range tunnel.StretchedNetworks {
+			_, err := restorer.networkId(network.NetworkRef.ID, network.NetworkRef.Name)
+			if err != nil {
+				return fmt.Errorf("error resolving stretched network of %s '%s': %w", labelNsxtL2VpnTunnel, tunnel.Name, err)
+			}
+		}
+	}
+
+	for _, natRule := range restorer.backup.NatRules {
+		profileRef := natRule.ApplicationPortProfile
+		if profileRef == nil || profileRef.ID == "" || restorer.tenantProfiles[profileRef.Name] {
+			continue
+		}
+		if _, found := restorer.profileIds[profileRef.Name]; found {
+			continue
+		}
+		var profile *NsxtAppPortProfile
+		var err error
+		for _, scope := range []string{types.ApplicationPortProfileScopeSystem, types.ApplicationPortProfileScopeProvider} {
+			profile, err = restorer.getAppPortProfile(profileRef.Name, scope)
+			if !ContainsNotFound(err) {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("error retrieving %s '%s' of rule '%s': %w", labelNsxtAppPortProfile, profileRef.Name, natRule.Name, err)
+		}
+		restorer.profileIds[profileRef.Name] = profile.NsxtAppPortProfile.ID
+		restorer.result.IdMapping[profileRef.ID] = profile.NsxtAppPortProfile.ID
+	}
+	return nil
+}
+
+// networkId returns the ID of the Org VDC network with the given name available to the target,
+// and records the mapping from the ID of the backup
+func (restorer *edgeGatewayRestorer) networkId(backupId, name string) (string, error) {
+	if networkId, found := restorer.networkIds[name]; found {
+		return networkId, nil
+	}
+	queryParameters := queryParameterFilterAnd(fmt.Sprintf("name==%s;ownerRef.id==%s", name, restorer.contextId), url.Values{})
+	allNetworks, err := getAllOpenApiOrgVdcNetworks(restorer.egw.client, queryParameters, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
+	}
+	network, err := returnSingleOpenApiOrgVdcNetwork(name, allNetworks)
+	if err != nil {
+		return "", err
+	}
+	restorer.networkIds[name] = network.OpenApiOrgVdcNetwork.ID
+	restorer.result.IdMapping[backupId] = network.OpenApiOrgVdcNetwork.ID
+	return network.OpenApiOrgVdcNetwork.ID, nil
+}
+
+func (restorer *edgeGatewayRestorer) getAppPortProfile(name, scope string) (*NsxtAppPortProfile, error) {
+	queryParameters := queryParameterFilterAnd(fmt.Sprintf("_context==%s;scope==%s", restorer.contextId, scope), url.Values{})
+	return getNsxtAppPortProfileByName(restorer.egw.client, name, queryParameters)
+}
+
+// mapId returns the ID of the target for an ID of the backup. IDs given in the options take
+// precedence over the ones found by Restore
+func (restorer *edgeGatewayRestorer) mapId(id string) string {
+	if targetId, found := restorer.options.IdMapping[id]; found {
+		return targetId
+	}
+	if targetId, found := restorer.result.IdMapping[id]; found {
+		return targetId
+	}
+	return id
+}
+
+// mapReference returns a copy of reference with the ID of the target
+func (restorer *edgeGatewayRestorer) mapReference(reference *types.OpenApiReference) *types.OpenApiReference {
+	if reference == nil {
+		return nil
+	}
+	mapped := *reference
+	mapped.ID = restorer.mapId(reference.ID)
+	return &mapped
+}
+
+func (restorer *edgeGatewayRestorer) addChange(change ConfigurationChange) {
+	restorer.result.Changes = append(restorer.result.Changes, change)
+}
+
+// planCreate records the creation of an entity and returns true when it must be created
+func (restorer *edgeGatewayRestorer) planCreate(kind, name string) bool {
+	restorer.addChange(ConfigurationChange{Action: ConfigurationChangeCreate, Kind: kind, Name: name})
+	return !restorer.options.DryRun
+}
+
+// planUpdate compares the current and desired configuration of an entity, records the update when
+// they differ and returns true when the entity must be updated
+func (restorer *edgeGatewayRestorer) planUpdate(kind, name string, current, desired any) (bool, error) {
+	fields, err := edgeGatewayConfigChanges(current, desired)
+	if err != nil {
+		return false, err
+	}
+	if len(fields) == 0 {
+		return false, nil
+	}
+	restorer.addChange(ConfigurationChange{Action: ConfigurationChangeUpdate, Kind: kind, Name: name, Fields: fields})
+	return !restorer.options.DryRun, nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreFirewall() error {
+	if restorer.backup.Firewall == nil {
+		return nil
+	}
+	importResult, err := restorer.egw.ImportFirewallPolicy(restorer.backup.Firewall,
+		FirewallPolicyImportOptions{Mode: FirewallPolicyImportMerge, DryRun: restorer.options.DryRun})
+	if importResult != nil {
+		restorer.result.Changes = append(restorer.result.Changes, importResult.Changes...)
+	}
+	return err
+}
+
+// natProfileId returns the ID of the Application Port Profile of a NAT rule in the target. TENANT
+// profiles are only looked up now, because the firewall step may have created them
+func (restorer *edgeGatewayRestorer) natProfileId(profileRef *types.OpenApiReference) (string, error) {
+	if profileId, found := restorer.profileIds[profileRef.Name]; found {
+		return profileId, nil
+	}
+	profile, err := restorer.getAppPortProfile(profileRef.Name, types.ApplicationPortProfileScopeTenant)
+	if ContainsNotFound(err) && restorer.options.DryRun {
+		return "pending:" + types.ApplicationPortProfileScopeTenant + ":" + profileRef.Name, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error retrieving %s '%s': %w", labelNsxtAppPortProfile, profileRef.Name, err)
+	}
+	restorer.profileIds[profileRef.Name] = profile.NsxtAppPortProfile.ID
+	restorer.result.IdMapping[profileRef.ID] = profile.NsxtAppPortProfile.ID
+	return profile.NsxtAppPortProfile.ID, nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreNatRules() error {
+	if len(restorer.backup.NatRules) == 0 {
+		return nil
+	}
+	allNatRules, err := restorer.egw.GetAllNatRules(nil)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*NsxtNatRule)
+	for _, natRule := range allNatRules {
+		existingByName[natRule.NsxtNatRule.Name] = natRule
+	}
+
+	for _, backupRule := range restorer.backup.NatRules {
+		desired := *backupRule
+		desired.ID = ""
+		desired.Version = nil
+		if backupRule.ApplicationPortProfile != nil && backupRule.ApplicationPortProfile.ID != "" {
+			profileId, err := restorer.natProfileId(backupRule.ApplicationPortProfile)
+			if err != nil {
+				return err
+			}
+			desired.ApplicationPortProfile = &types.OpenApiReference{ID: profileId, Name: backupRule.ApplicationPortProfile.Name}
+		}
+
+		existing := existingByName[backupRule.Name]
+		if existing == nil {
+			if !restorer.planCreate(labelNsxtNatRule, backupRule.Name) {
+				continue
+			}
+			created, err := restorer.egw.CreateNatRule(&desired)
+			if err != nil {
+				return err
+			}
+			restorer.result.IdMapping[backupRule.ID] = created.NsxtNatRule.ID
+			continue
+		}
+		restorer.result.IdMapping[backupRule.ID] = existing.NsxtNatRule.ID
+		update, err := restorer.planUpdate(labelNsxtNatRule, backupRule.Name, existing.NsxtNatRule, &desired)
+		if err != nil {
+			return err
+		}
+		if !update {
+			continue
+		}
+		desired.ID = existing.NsxtNatRule.ID
+		desired.Version = existing.NsxtNatRule.Version
+		_, err = existing.Update(&desired)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreStaticRoutes() error {
+	if len(restorer.backup.StaticRoutes) == 0 {
+		return nil
+	}
+	allStaticRoutes, err := restorer.egw.GetAllStaticRoutes(nil)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*NsxtEdgeGatewayStaticRoute)
+	for _, staticRoute := range allStaticRoutes {
+		existingByName[staticRoute.NsxtEdgeGatewayStaticRoute.Name] = staticRoute
+	}
+
+	for _, backupRoute := range restorer.backup.StaticRoutes {
+		desired := *backupRoute
+		desired.ID = ""
+		desired.Version = ""
+		desired.SystemOwned = nil
+		desired.NextHops = make([]types.NsxtEdgeGatewayStaticRouteNextHops, len(backupRoute.NextHops))
+		for index, nextHop := range backupRoute.NextHops {
+			desired.NextHops[index] = nextHop
+			if nextHop.Scope != nil {
+				scope := *nextHop.Scope
+				scope.ID = restorer.mapId(scope.ID)
+				desired.NextHops[index].Scope = &scope
+			}
+		}
+
+		existing := existingByName[backupRoute.Name]
+		if existing == nil {
+			if !restorer.planCreate(labelNsxtStaticRoute, backupRoute.Name) {
+				continue
+			}
+			created, err := restorer.egw.CreateStaticRoute(&desired)
+			if err != nil {
+				return err
+			}
+			restorer.result.IdMapping[backupRoute.ID] = created.NsxtEdgeGatewayStaticRoute.ID
+			continue
+		}
+		restorer.result.IdMapping[backupRoute.ID] = existing.NsxtEdgeGatewayStaticRoute.ID
+		current := *existing.NsxtEdgeGatewayStaticRoute
+		current.SystemOwned = nil
+		update, err := restorer.planUpdate(labelNsxtStaticRoute, backupRoute.Name, &current, &desired)
+		if err != nil {
+			return err
+		}
+		if !update {
+			continue
+		}
+		desired.ID = existing.NsxtEdgeGatewayStaticRoute.ID
+		desired.Version = existing.NsxtEdgeGatewayStaticRoute.Version
+		_, err = existing.Update(&desired)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreRouteAdvertisement() error {
+	desired := restorer.backup.RouteAdvertisement
+	if desired == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetNsxtRouteAdvertisement()
+	if err != nil {
+		return err
+	}
+	// The order of subnets is not relevant
+	update, err := restorer.planUpdate(labelNsxtRouteAdvertisement, restorer.egw.EdgeGateway.Name,
+		&types.RouteAdvertisement{Enable: current.Enable, Subnets: sortedStrings(current.Subnets)},
+		&types.RouteAdvertisement{Enable: desired.Enable, Subnets: sortedStrings(desired.Subnets)})
+	if err != nil || !update {
+		return err
+	}
+	_, err = restorer.egw.UpdateNsxtRouteAdvertisement(desired.Enable, desired.Subnets)
+	return err
+}
+
+func (restorer *edgeGatewayRestorer) restoreBgpConfiguration() error {
+	if restorer.backup.BgpConfiguration == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetBgpConfiguration()
+	if err != nil {
+		return err
+	}
+	desired := *restorer.backup.BgpConfiguration
+	desired.Version = current.Version
+	update, err := restorer.planUpdate(labelNsxtBgpConfiguration, restorer.egw.EdgeGateway.Name, current, &desired)
+	if err != nil || !update {
+		return err
+	}
+	_, err = restorer.egw.UpdateBgpConfiguration(&desired)
+	return err
+}
+
+func (restorer *edgeGatewayRestorer) restoreBgpIpPrefixLists() error {
+	if len(restorer.backup.BgpIpPrefixLists) == 0 {
+		return nil
+	}
+	allPrefixLists, err := restorer.egw.GetAllBgpIpPrefixLists(nil)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*EdgeBgpIpPrefixList)
+	for _, prefixList := range allPrefixLists {
+		existingByName[prefixList.EdgeBgpIpPrefixList.Name] = prefixList
+	}
+
+	for _, backupPrefixList := range restorer.backup.BgpIpPrefixLists {
+		desired := *backupPrefixList
+		desired.ID = ""
+
+		existing := existingByName[backupPrefixList.Name]
+		if existing == nil {
+			if !restorer.planCreate(labelNsxtBgpIpPrefixList, backupPrefixList.Name) {
+				continue
+			}
+			created, err := restorer.egw.CreateBgpIpPrefixList(&desired)
+			if err != nil {
+				return err
+			}
+			restorer.result.IdMapping[backupPrefixList.ID] = created.EdgeBgpIpPrefixList.ID
+			continue
+		}
+		restorer.result.IdMapping[backupPrefixList.ID] = existing.EdgeBgpIpPrefixList.ID
+		update, err := restorer.planUpdate(labelNsxtBgpIpPrefixList, backupPrefixList.Name, existing.EdgeBgpIpPrefixList, &desired)
+		if err != nil {
+			return err
+		}
+		if !update {
+			continue
+		}
+		desired.ID = existing.EdgeBgpIpPrefixList.ID
+		_, err = existing.Update(&desired)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreBgpNeighbors() error {
+	if len(restorer.backup.BgpNeighbors) == 0 {
+		return nil
+	}
+	allNeighbors, err := restorer.egw.GetAllBgpNeighbors(nil)
+	if err != nil {
+		return err
+	}
+	existingByAddress := make(map[string]*EdgeBgpNeighbor)
+	for _, neighbor := range allNeighbors {
+		existingByAddress[neighbor.EdgeBgpNeighbor.NeighborAddress] = neighbor
+	}
+
+	for _, backupNeighbor := range restorer.backup.BgpNeighbors {
+		desired := *backupNeighbor
+		desired.ID = ""
+		desired.InRoutesFilterRef = restorer.mapReference(backupNeighbor.InRoutesFilterRef)
+		desired.OutRoutesFilterRef = restorer.mapReference(backupNeighbor.OutRoutesFilterRef)
+
+		existing := existingByAddress[backupNeighbor.NeighborAddress]
+		if existing == nil {
+			if !restorer.planCreate(labelNsxtBgpNeighbor, backupNeighbor.NeighborAddress) {
+				continue
+			}
+			created, err := restorer.egw.CreateBgpNeighbor(&desired)
+			if err != nil {
+				return err
+			}
+			restorer.result.IdMapping[backupNeighbor.ID] = created.EdgeBgpNeighbor.ID
+			continue
+		}
+		restorer.result.IdMapping[backupNeighbor.ID] = existing.EdgeBgpNeighbor.ID
+		update, err := restorer.planUpdate(labelNsxtBgpNeighbor, backupNeighbor.NeighborAddress, existing.EdgeBgpNeighbor, &desired)
+		if err != nil {
+			return err
+		}
+		if !update {
+			continue
+		}
+		desired.ID = existing.EdgeBgpNeighbor.ID
+		_, err = existing.Update(&desired)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreDnsForwarder() error {
+	if restorer.backup.DnsForwarder == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetDnsConfig()
+	if err != nil {
+		return err
+	}
+	// Forwarder zones are matched by name, and the ones without ID are created
+	desired := *restorer.backup.DnsForwarder
+	desired.Version = current.NsxtEdgeGatewayDns.Version
+	currentZoneIds := make(map[string]string)
+	for _, zone := range current.NsxtEdgeGatewayDns.ConditionalForwarderZones {
+		currentZoneIds[zone.DisplayName] = zone.ID
+	}
+	if desired.DefaultForwarderZone != nil {
+		zone := *desired.DefaultForwarderZone
+		zone.ID = ""
+		if current.NsxtEdgeGatewayDns.DefaultForwarderZone != nil {
+			zone.ID = current.NsxtEdgeGatewayDns.DefaultForwarderZone.ID
+		}
+		desired.DefaultForwarderZone = &zone
+	}
+	desired.ConditionalForwarderZones = nil
+	for _, backupZone := range restorer.backup.DnsForwarder.ConditionalForwarderZones {
+		zone := *backupZone
+		zone.ID = currentZoneIds[zone.DisplayName]
+		desired.ConditionalForwarderZones = append(desired.ConditionalForwarderZones, &zone)
+	}
+
+	update, err := restorer.planUpdate(labelNsxtDnsForwarder, restorer.egw.EdgeGateway.Name, current.NsxtEdgeGatewayDns, &desired)
+	if err != nil || !update {
+		return err
+	}
+	_, err = current.Update(&desired)
+	return err
+}
+
+func (restorer *edgeGatewayRestorer) restoreDhcpForwarder() error {
+	if restorer.backup.DhcpForwarder == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetDhcpForwarder()
+	if err != nil {
+		return err
+	}
+	desired := *restorer.backup.DhcpForwarder
+	desired.Version = current.Version
+	update, err := restorer.planUpdate(labelNsxtDhcpForwarder, restorer.egw.EdgeGateway.Name, current, &desired)
+	if err != nil || !update {
+		return err
+	}
+	_, err = restorer.egw.UpdateDhcpForwarder(&desired)
+	return err
+}
+
+func (restorer *edgeGatewayRestorer) restoreSlaacProfile() error {
+	if restorer.backup.SlaacProfile == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetSlaacProfile()
+	if err != nil {
+		return err
+	}
+	update, err := restorer.planUpdate(labelNsxtSlaacProfile, restorer.egw.EdgeGateway.Name, current, restorer.backup.SlaacProfile)
+	if err != nil || !update {
+		return err
+	}
+	_, err = restorer.egw.UpdateSlaacProfile(restorer.backup.SlaacProfile)
+	return err
+}
+
+func (restorer *edgeGatewayRestorer) restoreQos() error {
+	if restorer.backup.Qos == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetQoS()
+	if err != nil {
+		return err
+	}
+	desired := &types.NsxtEdgeGatewayQos{
+		EgressProfile:  restorer.mapReference(restorer.backup.Qos.EgressProfile),
+		IngressProfile: restorer.mapReference(restorer.backup.Qos.IngressProfile),
+	}
+	update, err := restorer.planUpdate(labelNsxtQos, restorer.egw.EdgeGateway.Name, current, desired)
+	if err != nil || !update {
+		return err
+	}
+	_, err = restorer.egw.UpdateQoS(desired)
+	return err
+}
+
+func (restorer *edgeGatewayRestorer) restoreIpSecVpnTunnels() error {
+	if len(restorer.backup.IpSecVpnTunnels) == 0 {
+		return nil
+	}
+	allTunnels, err := restorer.egw.GetAllIpSecVpnTunnels(nil)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*NsxtIpSecVpnTunnel)
+	for _, tunnel := range allTunnels {
+		existingByName[tunnel.NsxtIpSecVpn.Name] = tunnel
+	}
+
+	for _, backupTunnel := range restorer.backup.IpSecVpnTunnels {
+		desired := *backupTunnel
+		desired.ID = ""
+		desired.Version = nil
+		desired.CertificateRef = restorer.mapReference(backupTunnel.CertificateRef)
+		desired.CaCertificateRef = restorer.mapReference(backupTunnel.CaCertificateRef)
+		securityProfile := restorer.backup.IpSecVpnTunnelSecurityProfiles[backupTunnel.Name]
+		// A CUSTOM security type is the result of setting the security profile, which is restored
+		// after the tunnel
+		if securityProfile != nil {
+			desired.SecurityType = ""
+		}
+
+		existing := existingByName[backupTunnel.Name]
+		if existing == nil {
+			if !restorer.planCreate(labelNsxtIpSecVpnTunnel, backupTunnel.Name) {
+				if securityProfile != nil {
+					restorer.planCreate(labelNsxtIpSecVpnTunnelSecurity, backupTunnel.Name)
+				}
+				continue
+			}
+			created, err := restorer.egw.CreateIpSecVpnTunnel(&desired)
+			if err != nil {
+				return err
+			}
+			restorer.result.IdMapping[backupTunnel.ID] = created.NsxtIpSecVpn.ID
+			if securityProfile != nil {
+				restorer.planCreate(labelNsxtIpSecVpnTunnelSecurity, backupTunnel.Name)
+				_, err = created.UpdateTunnelConnectionProperties(securityProfile)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		// Only retrieval by ID returns the pre-shared key
+		existing, err = restorer.egw.GetIpSecVpnTunnelById(existing.NsxtIpSecVpn.ID)
+		if err != nil {
+			return err
+		}
+		restorer.result.IdMapping[backupTunnel.ID] = existing.NsxtIpSecVpn.ID
+		if securityProfile != nil {
+			desired.SecurityType = existing.NsxtIpSecVpn.SecurityType
+		}
+		update, err := restorer.planUpdate(labelNsxtIpSecVpnTunnel, backupTunnel.Name, existing.NsxtIpSecVpn, &desired)
+		if err != nil {
+			return err
+		}
+		if update {
+			desired.ID = existing.NsxtIpSecVpn.ID
+			desired.Version = existing.NsxtIpSecVpn.Version
+			_, err = existing.Update(&desired)
+			if err != nil {
+				return err
+			}
+		}
+		if securityProfile == nil {
+			continue
+		}
+		currentProfile, err := existing.GetTunnelConnectionProperties()
+		if err != nil {
+			return err
+		}
+		update, err = restorer.planUpdate(labelNsxtIpSecVpnTunnelSecurity, backupTunnel.Name, currentProfile, securityProfile)
+		if err != nil {
+			return err
+		}
+		if update {
+			_, err = existing.UpdateTunnelConnectionProperties(securityProfile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreL2VpnTunnels() error {
+	if len(restorer.backup.L2VpnTunnels) == 0 {
+		return nil
+	}
+	allTunnels, err := restorer.egw.GetAllL2VpnTunnels(nil)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*NsxtL2VpnTunnel)
+	for _, tunnel := range allTunnels {
+		existingByName[tunnel.NsxtL2VpnTunnel.Name] = tunnel
+	}
+
+	for _, backupTunnel := range restorer.backup.L2VpnTunnels {
+		desired := *backupTunnel
+		desired.ID = ""
+		desired.Version = types.VersionField{}
+		desired.StretchedNetworks = nil
+		for _, network := range backupTunnel.StretchedNetworks {
+			network.NetworkRef.ID = restorer.mapId(network.NetworkRef.ID)
+			desired.StretchedNetworks = append(desired.StretchedNetworks, network)
+		}
+
+		existing := existingByName[backupTunnel.Name]
+		if existing == nil {
+			if !restorer.planCreate(labelNsxtL2VpnTunnel, backupTunnel.Name) {
+				continue
+			}
+			created, err := restorer.egw.CreateL2VpnTunnel(&desired)
+			if err != nil {
+				return err
+			}
+			restorer.result.IdMapping[backupTunnel.ID] = created.NsxtL2VpnTunnel.ID
+			continue
+		}
+		restorer.result.IdMapping[backupTunnel.ID] = existing.NsxtL2VpnTunnel.ID
+		update, err := restorer.planUpdate(labelNsxtL2VpnTunnel, backupTunnel.Name, existing.NsxtL2VpnTunnel, &desired)
+		if err != nil {
+			return err
+		}
+		if !update {
+			continue
+		}
+		desired.ID = existing.NsxtL2VpnTunnel.ID
+		desired.Version = existing.NsxtL2VpnTunnel.Version
+		_, err = existing.Update(&desired)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (restorer *edgeGatewayRestorer) restoreAlbSettings() error {
+	if restorer.backup.AlbSettings == nil {
+		return nil
+	}
+	current, err := restorer.egw.GetAlbSettings()
+	if err != nil {
+		return err
+	}
+	desired := *restorer.backup.AlbSettings
+	desired.LoadBalancerCloudRef = restorer.mapReference(restorer.backup.AlbSettings.LoadBalancerCloudRef)
+	update, err := restorer.planUpdate(labelNsxtAlbSettings, restorer.egw.EdgeGateway.Name, current, &desired)
+	if err != nil || !update {
+		return err
+	}
+	_, err = restorer.egw.UpdateAlbSettings(&desired)
+	return err
+}
+
+// edgeGatewayConfigChanges returns the sorted JSON names of the top level fields that differ
+// between two configurations of the same type. IDs and versions are ignored, and missing, null and
+// empty values are equivalent
+func edgeGatewayConfigChanges(current, desired any) ([]string, error) {
+	currentFields, err := normalizedJsonFields(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredFields, err := normalizedJsonFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for _, values := range []map[string]any{currentFields, desiredFields} {
+		for field := range values {
+			if field == "id" || field == "version" || contains(field, fields) {
+				continue
+			}
+			if !reflect.DeepEqual(currentFields[field], desiredFields[field]) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// normalizedJsonFields returns the fields of the JSON representation of value, without empty values
+func normalizedJsonFields(value any) (map[string]any, error) {
+	text, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %T: %w", value, err)
+	}
+	var fields map[string]any
+	err = json.Unmarshal(text, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling %T: %w", value, err)
+	}
+	normalized, _ := normalizeJsonValue(fields).(map[string]any)
+	return normalized, nil
+}
+
+// normalizeJsonValue removes null, empty and zero values from a decoded JSON value, and returns nil
+// when nothing is left
+func normalizeJsonValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		normalized := make(map[string]any)
+		for key, item := range typed {
+			if item = normalizeJsonValue(item); item != nil {
+				normalized[key] = item
+			}
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return normalized
+	case []any:
+		if len(typed) == 0 {
+			return nil
+		}
+		normalized := make([]any, len(typed))
+		for index, item := range typed {
+			normalized[index] = normalizeJsonValue(item)
+		}
+		return normalized
+	case string:
+		if typed == "" {
+			return nil
+		}
+	case bool:
+		if !typed {
+			return nil
+		}
+	case float64:
+		if typed == 0 {
+			return nil
+		}
+	}
+	return value
+}
@@ -0,0 +1,372 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// newEdgeGatewayBackupTestEdgeGateway returns the client of a new govcdtest server with an Edge
+// Gateway with a dedicated uplink in 'vdc1', and the ID of the Org VDC network 'app' of 'vdc1'
+func newEdgeGatewayBackupTestEdgeGateway(t *testing.T, name string) (*VCDClient, *NsxtEdgeGateway, string) {
+	vcdClient, egw, networkId := newFirewallPolicyTestEdgeGateway(t, name)
+	egw.EdgeGateway.EdgeGatewayUplinks[0].Dedicated = true
+	egw, err := egw.Update(egw.EdgeGateway)
+	if err != nil {
+		t.Fatalf("error dedicating the uplink of Edge Gateway %s: %s", name, err)
+	}
+	return vcdClient, egw, networkId
+}
+
+// edgeGatewayBackupTestConfigs returns the configurations of the Edge Gateway which are not
+// collections of entities, by label
+func edgeGatewayBackupTestConfigs(t *testing.T, egw *NsxtEdgeGateway) map[string]any {
+	t.Helper()
+	getters := map[string]func() (any, error){
+		labelNsxtRouteAdvertisement: func() (any, error) { return egw.GetNsxtRouteAdvertisement() },
+		labelNsxtBgpConfiguration:   func() (any, error) { return egw.GetBgpConfiguration() },
+		labelNsxtDnsForwarder: func() (any, error) {
+			dns, err := egw.GetDnsConfig()
+			if err != nil {
+				return nil, err
+			}
+			return dns.NsxtEdgeGatewayDns, nil
+		},
+		labelNsxtDhcpForwarder: func() (any, error) { return egw.GetDhcpForwarder() },
+		labelNsxtSlaacProfile:  func() (any, error) { return egw.GetSlaacProfile() },
+		labelNsxtQos:           func() (any, error) { return egw.GetQoS() },
+		labelNsxtAlbSettings:   func() (any, error) { return egw.GetAlbSettings() },
+	}
+	configs := make(map[string]any)
+	for label, get := range getters {
+		config, err := get()
+		if err != nil {
+			t.Fatalf("error retrieving %s of Edge Gateway %s: %s", label, egw.EdgeGateway.Name, err)
+		}
+		configs[label] = config
+	}
+	return configs
+}
+
+func TestNsxtEdgeGateway_BackupRestore(t *testing.T) {
+	// Source Edge Gateway
+	sourceClient, sourceEgw, sourceNetworkId := newEdgeGatewayBackupTestEdgeGateway(t, "edge-source")
+	webId := firewallPolicyTestGroup(t, sourceEgw.client, &types.NsxtFirewallGroup{
+		Name: "web-servers", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.0.10"},
+		OwnerRef: &types.OpenApiReference{ID: sourceEgw.EdgeGateway.ID},
+	})
+	httpId := firewallPolicyTestProfile(t, sourceClient, "HTTP", types.ApplicationPortProfileScopeSystem)
+	customId := firewallPolicyTestProfile(t, sourceClient, "app-8080", types.ApplicationPortProfileScopeTenant,
+		types.NsxtAppPortProfilePort{Protocol: "TCP", DestinationPorts: []string{"8080"}})
+	firewallPolicyTestEdgeRules(t, sourceEgw,
+		&types.NsxtFirewallRule{Name: "allow-web", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT",
+			DestinationFirewallGroups: simulatorTestRefs(webId), ApplicationPortProfiles: simulatorTestRefs(httpId)},
+	)
+	for _, natRule := range []*types.NsxtNatRule{
+		{Name: "dnat-web", RuleType: types.NsxtNatRuleTypeDnat, Enabled: true, ExternalAddresses: "203.0.113.10",
+			InternalAddresses: "10.0.0.10", ApplicationPortProfile: &types.OpenApiReference{ID: httpId}},
+		{Name: "dnat-app", RuleType: types.NsxtNatRuleTypeDnat, Enabled: true, ExternalAddresses: "203.0.113.11",
+			InternalAddresses: "10.0.0.11", ApplicationPortProfile: &types.OpenApiReference{ID: customId}},
+	} {
+		if _, err := sourceEgw.CreateNatRule(natRule); err != nil {
+			t.Fatalf("error creating NAT rule %s: %s", natRule.Name, err)
+		}
+	}
+	_, err := sourceEgw.CreateStaticRoute(&types.NsxtEdgeGatewayStaticRoute{
+		Name: "to-app", NetworkCidr: "172.16.0.0/24",
+		NextHops: []types.NsxtEdgeGatewayStaticRouteNextHops{{IPAddress: "10.0.0.254", AdminDistance: 1,
+			Scope: &types.NsxtEdgeGatewayStaticRouteNextHopScope{ID: sourceNetworkId, ScopeType: "NETWORK"}}},
+	})
+	if err != nil {
+		t.Fatalf("error creating static route: %s", err)
+	}
+	prefixList, err := sourceEgw.CreateBgpIpPrefixList(&types.EdgeBgpIpPrefixList{
+		Name: "pl-in", Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{{Network: "10.0.0.0/8", Action: "PERMIT"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating BGP IP Prefix List: %s", err)
+	}
+	prefixListId := prefixList.EdgeBgpIpPrefixList.ID
+	_, err = sourceEgw.CreateBgpNeighbor(&types.EdgeBgpNeighbor{
+		NeighborAddress: "192.0.2.1", RemoteASNumber: "65001", InRoutesFilterRef: &types.OpenApiReference{ID: prefixListId},
+	})
+	if err != nil {
+		t.Fatalf("error creating BGP neighbor: %s", err)
+	}
+	tunnel, err := sourceEgw.CreateIpSecVpnTunnel(&types.NsxtIpSecVpnTunnel{
+		Name: "to-dc", Enabled: true, PreSharedKey: "secret",
+		LocalEndpoint:  types.NsxtIpSecVpnTunnelLocalEndpoint{LocalAddress: "203.0.113.12", LocalNetworks: []string{"10.0.0.0/24"}},
+		RemoteEndpoint: types.NsxtIpSecVpnTunnelRemoteEndpoint{RemoteAddress: "198.51.100.1", RemoteNetworks: []string{"10.1.0.0/24"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating IPsec VPN tunnel: %s", err)
+	}
+	_, err = tunnel.UpdateTunnelConnectionProperties(&types.NsxtIpSecVpnTunnelSecurityProfile{
+		SecurityType: "CUSTOM", IkeConfiguration: types.NsxtIpSecVpnTunnelProfileIkeConfiguration{IkeVersion: "IKE_V2"},
+	})
+	if err != nil {
+		t.Fatalf("error updating connection properties of IPsec VPN tunnel: %s", err)
+	}
+	_, err = sourceEgw.CreateL2VpnTunnel(&types.NsxtL2VpnTunnel{
+		Name: "stretch", SessionMode: "SERVER", Enabled: true, LocalEndpointIp: "203.0.113.13", RemoteEndpointIp: "198.51.100.2",
+		ConnectorInitiationMode: "INITIATOR", PreSharedKey: "secret",
+		StretchedNetworks: []types.EdgeL2VpnStretchedNetwork{{NetworkRef: types.OpenApiReference{ID: sourceNetworkId}, TunnelID: 1}},
+	})
+	if err != nil {
+		t.Fatalf("error creating L2 VPN tunnel: %s", err)
+	}
+	if _, err = sourceEgw.UpdateNsxtRouteAdvertisement(true, []string{"10.0.0.0/24"}); err != nil {
+		t.Fatalf("error updating route advertisement: %s", err)
+	}
+	bgpConfiguration, err := sourceEgw.GetBgpConfiguration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bgpConfiguration.Enabled, bgpConfiguration.Ecmp, bgpConfiguration.LocalASNumber = true, true, "65000"
+	if _, err = sourceEgw.UpdateBgpConfiguration(bgpConfiguration); err != nil {
+		t.Fatalf("error updating BGP configuration: %s", err)
+	}
+	dns, err := sourceEgw.GetDnsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = dns.Update(&types.NsxtEdgeGatewayDns{
+		Enabled: true, ListenerIp: "10.0.0.1", Version: dns.NsxtEdgeGatewayDns.Version,
+		DefaultForwarderZone: &types.NsxtDnsForwarderZoneConfig{DisplayName: "default", UpstreamServers: []string{"8.8.8.8"}},
+		ConditionalForwarderZones: []*types.NsxtDnsForwarderZoneConfig{
+			{DisplayName: "corp", UpstreamServers: []string{"10.1.0.53"}, DnsDomainNames: []string{"corp.example.com"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error updating DNS forwarder: %s", err)
+	}
+	if _, err = sourceEgw.UpdateDhcpForwarder(&types.NsxtEdgeGatewayDhcpForwarder{Enabled: true, DhcpServers: []string{"10.0.0.2"}}); err != nil {
+		t.Fatalf("error updating DHCP forwarder: %s", err)
+	}
+	if _, err = sourceEgw.UpdateSlaacProfile(&types.NsxtEdgeGatewaySlaacProfile{Enabled: true, Mode: "SLAAC"}); err != nil {
+		t.Fatalf("error updating SLAAC profile: %s", err)
+	}
+	_, err = sourceEgw.UpdateQoS(&types.NsxtEdgeGatewayQos{
+		EgressProfile: &types.OpenApiReference{ID: "urn:vcloud:gatewayQosProfile:source", Name: "10mbps"},
+	})
+	if err != nil {
+		t.Fatalf("error updating Rate Limiting: %s", err)
+	}
+	_, err = sourceEgw.UpdateAlbSettings(&types.NsxtAlbConfig{
+		Enabled: true, SupportedFeatureSet: "STANDARD", LoadBalancerCloudRef: &types.OpenApiReference{ID: "urn:vcloud:loadBalancerCloud:source"},
+	})
+	if err != nil {
+		t.Fatalf("error updating ALB settings: %s", err)
+	}
+
+	backup, err := sourceEgw.Backup()
+	if err != nil {
+		t.Fatalf("error backing up Edge Gateway: %s", err)
+	}
+	if backup.Version != EdgeGatewayBackupVersion || backup.SourceName != "edge-source" || backup.OwnerId != sourceEgw.EdgeGateway.OwnerRef.ID {
+		t.Errorf("unexpected backup header %+v", backup)
+	}
+	if len(backup.Firewall.Rules) != 1 || len(backup.Firewall.ApplicationPortProfiles) != 1 || backup.Firewall.ApplicationPortProfiles[0].Name != "app-8080" {
+		t.Errorf("expected the TENANT profile of NAT rules in the firewall document, got %+v", backup.Firewall)
+	}
+	if len(backup.NatRules) != 2 || len(backup.StaticRoutes) != 1 || len(backup.BgpIpPrefixLists) != 1 || len(backup.BgpNeighbors) != 1 ||
+		len(backup.IpSecVpnTunnels) != 1 || len(backup.L2VpnTunnels) != 1 || backup.IpSecVpnTunnelSecurityProfiles["to-dc"] == nil {
+		t.Errorf("unexpected backup %+v", backup)
+	}
+	if len(backup.IpSecVpnTunnels) == 1 && backup.IpSecVpnTunnels[0].PreSharedKey != "secret" {
+		t.Errorf("expected the pre-shared key of the IPsec VPN tunnel in the backup")
+	}
+	if backup.RouteAdvertisement == nil || backup.BgpConfiguration == nil || backup.DnsForwarder == nil || backup.DhcpForwarder == nil ||
+		backup.SlaacProfile == nil || backup.Qos == nil || backup.AlbSettings == nil {
+		t.Errorf("expected all configurations in backup %+v", backup)
+	}
+
+	// The backup is stored as JSON
+	text, err := json.Marshal(backup)
+	if err != nil {
+		t.Fatalf("error marshalling backup: %s", err)
+	}
+	backup = &EdgeGatewayBackup{}
+	err = json.Unmarshal(text, backup)
+	if err != nil {
+		t.Fatalf("error unmarshalling backup: %s", err)
+	}
+
+	// Target Edge Gateway, in another VCD, with an outdated NAT rule
+	targetClient, targetEgw, targetNetworkId := newEdgeGatewayBackupTestEdgeGateway(t, "edge-target")
+	targetHttpId := firewallPolicyTestProfile(t, targetClient, "HTTP", types.ApplicationPortProfileScopeSystem)
+	existingNat, err := targetEgw.CreateNatRule(&types.NsxtNatRule{
+		Name: "dnat-web", RuleType: types.NsxtNatRuleTypeDnat, Enabled: false, ExternalAddresses: "203.0.113.10",
+		InternalAddresses: "10.0.0.10", ApplicationPortProfile: &types.OpenApiReference{ID: targetHttpId},
+	})
+	if err != nil {
+		t.Fatalf("error creating NAT rule: %s", err)
+	}
+	options := EdgeGatewayRestoreOptions{
+		IdMapping: map[string]string{
+			"urn:vcloud:gatewayQosProfile:source": "urn:vcloud:gatewayQosProfile:target",
+			"urn:vcloud:loadBalancerCloud:source": "urn:vcloud:loadBalancerCloud:target",
+		},
+		DryRun: true,
+	}
+
+	// Dry run
+	_, rulesEtag, err := getNsxtFirewallRulesRaw(targetEgw.client, targetEgw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs := edgeGatewayBackupTestConfigs(t, targetEgw)
+	result, err := targetEgw.Restore(backup, options)
+	if err != nil {
+		t.Fatalf("error in dry run: %s", err)
+	}
+	expectedDiff := strings.Join([]string{
+		"+ NSX-T Firewall Group web-servers",
+		"+ NSX-T Application Port Profile app-8080",
+		"+ NSX-T Edge Gateway Firewall Rule allow-web",
+		"~ NSX-T NAT Rule dnat-web (enabled)",
+		"+ NSX-T NAT Rule dnat-app",
+		"+ NSX-T Static Route to-app",
+		"~ NSX-T Route Advertisement edge-target (enable, subnets)",
+		"~ NSX-T BGP Configuration edge-target (ecmp, enabled, localASNumber)",
+		"+ NSX-T BGP IP Prefix List pl-in",
+		"+ NSX-T BGP Neighbor 192.0.2.1",
+		"~ NSX-T DNS Forwarder edge-target (conditionalForwarderZones, defaultForwarderZone, enabled, listenerIp)",
+		"~ NSX-T DHCP Forwarder edge-target (dhcpServers, enabled)",
+		"~ NSX-T SLAAC Profile edge-target (enabled, mode)",
+		"~ NSX-T Rate Limiting edge-target (egressProfile)",
+		"+ NSX-T IPsec VPN Tunnel to-dc",
+		"+ NSX-T IPsec VPN Tunnel Security Profile to-dc",
+		"+ NSX-T L2 VPN Tunnel stretch",
+		"~ NSX-T ALB Settings edge-target (enabled, loadBalancerCloudRef, supportedFeatureSet)",
+	}, "\n")
+	if result.String() != expectedDiff || result.Applied {
+		t.Errorf("unexpected dry run result (applied %t):\n%s\nexpected:\n%s", result.Applied, result, expectedDiff)
+	}
+	natRules, err := targetEgw.GetAllNatRules(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups, err := targetEgw.GetAllNsxtFirewallGroups(nil, types.FirewallGroupTypeIpSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, dryRunEtag, err := getNsxtFirewallRulesRaw(targetEgw.client, targetEgw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(natRules) != 1 || len(groups) != 0 || dryRunEtag != rulesEtag {
+		t.Errorf("expected no change in a dry run")
+	}
+	if dryRunConfigs := edgeGatewayBackupTestConfigs(t, targetEgw); !reflect.DeepEqual(dryRunConfigs, configs) {
+		t.Errorf("expected no change of configurations in a dry run, got %+v", dryRunConfigs)
+	}
+
+	// Restore
+	options.DryRun = false
+	result, err = targetEgw.Restore(backup, options)
+	if err != nil {
+		t.Fatalf("error restoring backup: %s", err)
+	}
+	if result.String() != expectedDiff || !result.Applied {
+		t.Errorf("unexpected result:\n%s", result)
+	}
+	targetCustomId := result.IdMapping[customId]
+	customProfile, err := getNsxtAppPortProfileById(targetEgw.client, targetCustomId)
+	if err != nil || customProfile.NsxtAppPortProfile.Name != "app-8080" {
+		t.Fatalf("expected the restored profile app-8080 in the ID mapping %v (%v)", result.IdMapping, err)
+	}
+	natRules, err = targetEgw.GetAllNatRules(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(natRules) != 2 || !natRules[0].NsxtNatRule.Enabled || *natRules[0].NsxtNatRule.Version.Version != 1 ||
+		natRules[1].NsxtNatRule.ApplicationPortProfile.ID != targetCustomId {
+		t.Errorf("unexpected NAT rules %+v", natRules)
+	}
+	staticRoute, err := targetEgw.GetStaticRouteByName("to-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if staticRoute.NsxtEdgeGatewayStaticRoute.NextHops[0].Scope.ID != targetNetworkId {
+		t.Errorf("expected next hop scope of the target network, got %+v", staticRoute.NsxtEdgeGatewayStaticRoute)
+	}
+	targetPrefixList, err := targetEgw.GetBgpIpPrefixListByName("pl-in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	neighbor, err := targetEgw.GetBgpNeighborByIp("192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetPrefixListId := targetPrefixList.EdgeBgpIpPrefixList.ID
+	if neighbor.EdgeBgpNeighbor.InRoutesFilterRef.ID != targetPrefixListId || result.IdMapping[prefixListId] != targetPrefixListId {
+		t.Errorf("expected the restored prefix list in neighbor %+v", neighbor.EdgeBgpNeighbor)
+	}
+	l2VpnTunnel, err := targetEgw.GetL2VpnTunnelByName("stretch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l2VpnTunnel.NsxtL2VpnTunnel.StretchedNetworks[0].NetworkRef.ID != targetNetworkId {
+		t.Errorf("expected the target network in L2 VPN tunnel %+v", l2VpnTunnel.NsxtL2VpnTunnel)
+	}
+	targetTunnel, err := targetEgw.GetIpSecVpnTunnelByName("to-dc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	securityProfile, err := targetTunnel.GetTunnelConnectionProperties()
+	if err != nil || securityProfile.SecurityType != "CUSTOM" || securityProfile.IkeConfiguration.IkeVersion != "IKE_V2" {
+		t.Errorf("expected the security profile of the IPsec VPN tunnel to be restored, got %+v (%v)", securityProfile, err)
+	}
+	qos, err := targetEgw.GetQoS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	albSettings, err := targetEgw.GetAlbSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qos.EgressProfile.ID != "urn:vcloud:gatewayQosProfile:target" || albSettings.LoadBalancerCloudRef.ID != "urn:vcloud:loadBalancerCloud:target" {
+		t.Errorf("expected IDs of the options to be used, got %+v and %+v", qos, albSettings)
+	}
+	if result.IdMapping[sourceNetworkId] != targetNetworkId || result.IdMapping[httpId] != targetHttpId {
+		t.Errorf("unexpected ID mapping %v", result.IdMapping)
+	}
+	firewall, err := targetEgw.GetNsxtFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules := firewall.NsxtFirewallRuleContainer.UserDefinedRules; len(rules) != 1 || rules[0].Name != "allow-web" {
+		t.Errorf("unexpected firewall rules %+v", rules)
+	}
+	if result.IdMapping[backup.NatRules[0].ID] != existingNat.NsxtNatRule.ID {
+		t.Errorf("expected the existing NAT rule to be matched by name, got %v", result.IdMapping)
+	}
+
+	// Restoring again makes no change
+	result, err = targetEgw.Restore(backup, options)
+	if err != nil {
+		t.Fatalf("error restoring backup again: %s", err)
+	}
+	if !result.IsEmpty() {
+		t.Errorf("expected no changes restoring the backup again, got:\n%s", result)
+	}
+
+	// Invalid backups are rejected
+	backup.Version = 2
+	_, err = targetEgw.Restore(backup, options)
+	if err == nil || !strings.Contains(err.Error(), "unsupported NSX-T Edge Gateway backup version 2") {
+		t.Errorf("expected version error, got %v", err)
+	}
+	backup.Version = EdgeGatewayBackupVersion
+	backup.BgpNeighbors = append(backup.BgpNeighbors, backup.BgpNeighbors[0])
+	_, err = targetEgw.Restore(backup, options)
+	if err == nil || !strings.Contains(err.Error(), "duplicate '192.0.2.1'") {
+		t.Errorf("expected duplicate neighbor error, got %v", err)
+	}
+}
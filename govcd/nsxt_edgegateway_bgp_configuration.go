@@ -29,7 +29,7 @@ func (egw *NsxtEdgeGateway) GetBgpConfiguration() (*types.EdgeBgpConfig, error)
 
 	err = client.OpenApiGetItem(apiVersion, urlRef, nil, returnObject, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Configuration: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -56,7 +56,7 @@ func (egw *NsxtEdgeGateway) UpdateBgpConfiguration(bgpConfig *types.EdgeBgpConfi
 	// Update of BGP config requires version to be specified. This function automatically handles it.
 	existingBgpConfig, err := egw.GetBgpConfiguration()
 	if err != nil {
-		return nil, fmt.Errorf("error getting NSX-T Edge Gateway BGP Configuration: %s", err)
+		return nil, fmt.Errorf("error getting NSX-T Edge Gateway BGP Configuration: %w", err)
 	}
 	bgpConfig.Version = existingBgpConfig.Version
 
@@ -64,7 +64,7 @@ func (egw *NsxtEdgeGateway) UpdateBgpConfiguration(bgpConfig *types.EdgeBgpConfi
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, bgpConfig, returnObject, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Configuration: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -76,7 +76,7 @@ func (egw *NsxtEdgeGateway) DisableBgpConfiguration() error {
 	// Get existing BGP configuration so that when disabling it - other settings remain as they are
 	bgpConfig, err := egw.GetBgpConfiguration()
 	if err != nil {
-		return fmt.Errorf("error retrieving BGP configuration: %s", err)
+		return fmt.Errorf("error retrieving BGP configuration: %w", err)
 	}
 	bgpConfig.Enabled = false
 
@@ -46,12 +46,12 @@ func (egw *NsxtEdgeGateway) CreateBgpIpPrefixList(bgpIpPrefixList *types.EdgeBgp
 
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, bgpIpPrefixList)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	// API is not consistent across different versions therefore explicit manual handling is
@@ -74,14 +74,14 @@ func (egw *NsxtEdgeGateway) CreateBgpIpPrefixList(bgpIpPrefixList *types.EdgeBgp
 		}
 		err = client.OpenApiGetItem(apiVersion, getUrlRef, nil, returnObject.EdgeBgpIpPrefixList, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %w", err)
 		}
 	} else {
 		// ID after object creation was not returned therefore retrieving the entity by Name to lookup ID
 		// This has a risk of duplicate items, but is the only way to find the object when ID is not returned
 		bgpIpPrefixList, err := egw.GetBgpIpPrefixListByName(bgpIpPrefixList.Name)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %w", err)
 		}
 		returnObject = bgpIpPrefixList
 	}
@@ -139,7 +139,7 @@ func (egw *NsxtEdgeGateway) GetBgpIpPrefixListByName(name string) (*EdgeBgpIpPre
 
 	allBgpIpPrefixLists, err := egw.GetAllBgpIpPrefixLists(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	var filteredBgpIpPrefixLists []*EdgeBgpIpPrefixList
@@ -154,7 +154,7 @@ func (egw *NsxtEdgeGateway) GetBgpIpPrefixListByName(name string) (*EdgeBgpIpPre
 	}
 
 	if len(filteredBgpIpPrefixLists) == 0 {
-		return nil, fmt.Errorf("%s: no NSX-T Edge Gateway BGP IP Prefix List found with name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: no NSX-T Edge Gateway BGP IP Prefix List found with name '%s'", ErrorEntityNotFound, name)
 	}
 
 	return filteredBgpIpPrefixLists[0], nil
@@ -187,7 +187,7 @@ func (egw *NsxtEdgeGateway) GetBgpIpPrefixListById(id string) (*EdgeBgpIpPrefixL
 
 	err = client.OpenApiGetItem(apiVersion, urlRef, nil, returnObject.EdgeBgpIpPrefixList, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	return returnObject, nil
@@ -216,7 +216,7 @@ func (bgpIpPrefixListCfg *EdgeBgpIpPrefixList) Update(bgpIpPrefixList *types.Edg
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, bgpIpPrefixList, returnObject.EdgeBgpIpPrefixList, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	return returnObject, nil
@@ -239,7 +239,7 @@ func (bgpIpPrefixListCfg *EdgeBgpIpPrefixList) Delete() error {
 
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	return nil
@@ -42,12 +42,12 @@ func (egw *NsxtEdgeGateway) CreateBgpNeighbor(bgpNeighborConfig *types.EdgeBgpNe
 
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, bgpNeighborConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	// API has problems therefore explicit manual handling is required to lookup newly created object
@@ -67,14 +67,14 @@ func (egw *NsxtEdgeGateway) CreateBgpNeighbor(bgpNeighborConfig *types.EdgeBgpNe
 		}
 		err = client.OpenApiGetItem(apiVersion, getUrlRef, nil, returnObject.EdgeBgpNeighbor, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %w", err)
 		}
 	} else {
 		// ID after object creation was not returned therefore retrieving the entity by Name to lookup ID
 		// This has a risk of duplicate items, but is the only way to find the object when ID is not returned
 		bgpNeighbor, err := egw.GetBgpNeighborByIp(bgpNeighborConfig.NeighborAddress)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %w", err)
 		}
 		returnObject = bgpNeighbor
 	}
@@ -132,7 +132,7 @@ func (egw *NsxtEdgeGateway) GetBgpNeighborByIp(neighborIpAddress string) (*EdgeB
 
 	allBgpNeighbors, err := egw.GetAllBgpNeighbors(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	var filteredBgpNeighbors []*EdgeBgpNeighbor
@@ -147,7 +147,7 @@ func (egw *NsxtEdgeGateway) GetBgpNeighborByIp(neighborIpAddress string) (*EdgeB
 	}
 
 	if len(filteredBgpNeighbors) == 0 {
-		return nil, fmt.Errorf("%s: no NSX-T Edge Gateway BGP Neighbor found with IP Address '%s'", ErrorEntityNotFound, neighborIpAddress)
+		return nil, fmt.Errorf("%w: no NSX-T Edge Gateway BGP Neighbor found with IP Address '%s'", ErrorEntityNotFound, neighborIpAddress)
 	}
 
 	return filteredBgpNeighbors[0], nil
@@ -180,7 +180,7 @@ func (egw *NsxtEdgeGateway) GetBgpNeighborById(id string) (*EdgeBgpNeighbor, err
 
 	err = client.OpenApiGetItem(apiVersion, urlRef, nil, returnObject.EdgeBgpNeighbor, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	return returnObject, nil
@@ -209,7 +209,7 @@ func (bgpNeighbor *EdgeBgpNeighbor) Update(bgpNeighborConfig *types.EdgeBgpNeigh
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, bgpNeighborConfig, returnObject.EdgeBgpNeighbor, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	return returnObject, nil
@@ -232,7 +232,7 @@ func (bgpNeighbor *EdgeBgpNeighbor) Delete() error {
 
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	return nil
@@ -74,7 +74,7 @@ func (vcdClient *VCDClient) GetNsxtEdgeGatewayQosProfileByDisplayName(nsxtManage
 	*/
 	nsxtEdgeClusters, err := vcdClient.GetAllNsxtEdgeGatewayQosProfiles(nsxtManagerId, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not find QoS profile with DisplayName '%s' for NSX-T Manager with ID '%s': %s",
+		return nil, fmt.Errorf("could not find QoS profile with DisplayName '%s' for NSX-T Manager with ID '%s': %w",
 			displayName, nsxtManagerId, err)
 	}
 
@@ -84,7 +84,7 @@ func (vcdClient *VCDClient) GetNsxtEdgeGatewayQosProfileByDisplayName(nsxtManage
 
 	if len(nsxtEdgeClusters) == 0 {
 		// ErrorEntityNotFound is injected here for the ability to validate problem using ContainsNotFound()
-		return nil, fmt.Errorf("%s: no NSX-T QoS profiles with DisplayName '%s' for NSX-T Manager with ID '%s' found",
+		return nil, fmt.Errorf("%w: no NSX-T QoS profiles with DisplayName '%s' for NSX-T Manager with ID '%s' found",
 			ErrorEntityNotFound, displayName, nsxtManagerId)
 	}
 
@@ -43,12 +43,12 @@ func (egw *NsxtEdgeGateway) CreateStaticRoute(staticRouteConfig *types.NsxtEdgeG
 	// Non standard behavior of entity - `Details` field in task contains ID of newly created object while the Owner is Edge Gateway
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, staticRouteConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway Static Route: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway Static Route: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	// API does not return an ID for created object - we know that it is expected to be in
@@ -62,7 +62,7 @@ func (egw *NsxtEdgeGateway) CreateStaticRoute(staticRouteConfig *types.NsxtEdgeG
 		}
 		err = client.OpenApiGetItem(apiVersion, getUrlRef, nil, returnObject.NsxtEdgeGatewayStaticRoute, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route after creation: %w", err)
 		}
 	} else {
 		// ID was not present in response, therefore Static Route needs to be found manually. Using
@@ -70,7 +70,7 @@ func (egw *NsxtEdgeGateway) CreateStaticRoute(staticRouteConfig *types.NsxtEdgeG
 		// exist, but but it should be a good enough combination for finding unique entry until VCD API is fixed
 		allStaticRoutes, err := egw.GetAllStaticRoutes(nil)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route after creation: %w", err)
 		}
 
 		var foundStaticRoute bool
@@ -137,7 +137,7 @@ func (egw *NsxtEdgeGateway) GetStaticRouteByNetworkCidr(networkCidr string) (*Ns
 
 	allStaticRoutes, err := egw.GetAllStaticRoutes(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	filteredByNetworkCidr := make([]*NsxtEdgeGatewayStaticRoute, 0)
@@ -165,7 +165,7 @@ func (egw *NsxtEdgeGateway) GetStaticRouteByName(name string) (*NsxtEdgeGatewayS
 
 	allStaticRoutes, err := egw.GetAllStaticRoutes(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	filteredByNetworkName := make([]*NsxtEdgeGatewayStaticRoute, 0)
@@ -211,7 +211,7 @@ func (egw *NsxtEdgeGateway) GetStaticRouteById(id string) (*NsxtEdgeGatewayStati
 
 	err = client.OpenApiGetItem(apiVersion, urlRef, nil, returnObject.NsxtEdgeGatewayStaticRoute, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	return returnObject, nil
@@ -240,7 +240,7 @@ func (staticRoute *NsxtEdgeGatewayStaticRoute) Update(StaticRouteConfig *types.N
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, StaticRouteConfig, returnObject.NsxtEdgeGatewayStaticRoute, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway Static Route: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	return returnObject, nil
@@ -263,7 +263,7 @@ func (staticRoute *NsxtEdgeGatewayStaticRoute) Delete() error {
 
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Edge Gateway Static Route: %s", err)
+		return fmt.Errorf("error deleting NSX-T Edge Gateway Static Route: %w", err)
 	}
 
 	return nil
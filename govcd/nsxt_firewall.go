@@ -44,7 +44,7 @@ func (egw *NsxtEdgeGateway) UpdateNsxtFirewall(firewallRules *types.NsxtFirewall
 
 	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, firewallRules, returnObject.NsxtFirewallRuleContainer, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Firewall: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Firewall: %w", err)
 	}
 
 	return returnObject, nil
@@ -73,7 +73,7 @@ func (egw *NsxtEdgeGateway) GetNsxtFirewall() (*NsxtFirewall, error) {
 
 	err = client.OpenApiGetItem(minimumApiVersion, urlRef, nil, returnObject.NsxtFirewallRuleContainer, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Firewall rules: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Firewall rules: %w", err)
 	}
 
 	// Store Edge Gateway ID for later operations
@@ -103,7 +103,7 @@ func (firewall *NsxtFirewall) DeleteAllRules() error {
 	err = firewall.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting all NSX-T Firewall Rules: %s", err)
+		return fmt.Errorf("error deleting all NSX-T Firewall Rules: %w", err)
 	}
 
 	return nil
@@ -129,7 +129,7 @@ func (firewall *NsxtFirewall) DeleteRuleById(id string) error {
 	err = firewall.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Firewall Rule with ID '%s': %s", id, err)
+		return fmt.Errorf("error deleting NSX-T Firewall Rule with ID '%s': %w", id, err)
 	}
 
 	return nil
@@ -216,7 +216,7 @@ func (firewallGroup *NsxtFirewallGroup) Update(firewallGroupConfig *types.NsxtFi
 
 	err = firewallGroup.client.OpenApiPutItem(apiVersion, urlRef, nil, firewallGroupConfig, returnObject.NsxtFirewallGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T firewall group: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T firewall group: %w", err)
 	}
 
 	return returnObject, nil
@@ -242,7 +242,7 @@ func (firewallGroup *NsxtFirewallGroup) Delete() error {
 	err = firewallGroup.client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Firewall Group: %s", err)
+		return fmt.Errorf("error deleting NSX-T Firewall Group: %w", err)
 	}
 
 	return nil
@@ -278,7 +278,7 @@ func (firewallGroup *NsxtFirewallGroup) GetAssociatedVms() ([]*types.NsxtFirewal
 	err = firewallGroup.client.OpenApiGetAllItems(apiVersion, urlRef, nil, &associatedVms, nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving associated VMs: %s", err)
+		return nil, fmt.Errorf("error retrieving associated VMs: %w", err)
 	}
 
 	return associatedVms, nil
@@ -305,11 +305,11 @@ func getNsxtFirewallGroupByName(client *Client, name string, queryParameters url
 
 	allGroups, err := getAllNsxtFirewallGroups(client, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("could not find NSX-T Firewall Group with name '%s': %s", name, err)
+		return nil, fmt.Errorf("could not find NSX-T Firewall Group with name '%s': %w", name, err)
 	}
 
 	if len(allGroups) == 0 {
-		return nil, fmt.Errorf("%s: expected exactly one NSX-T Firewall Group with name '%s'. Got %d", ErrorEntityNotFound, name, len(allGroups))
+		return nil, fmt.Errorf("%w: expected exactly one NSX-T Firewall Group with name '%s'. Got %d", ErrorEntityNotFound, name, len(allGroups))
 	}
 
 	if len(allGroups) > 1 {
@@ -406,7 +406,7 @@ func createNsxtFirewallGroup(client *Client, firewallGroupConfig *types.NsxtFire
 
 	err = client.OpenApiPostItem(apiVersion, urlRef, nil, firewallGroupConfig, returnObject.NsxtFirewallGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Firewall Group: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Firewall Group: %w", err)
 	}
 
 	return returnObject, nil
@@ -0,0 +1,1108 @@
+package govcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	labelFirewallPolicy        = "NSX-T Firewall Policy"
+	labelNsxtFirewallGroup     = "NSX-T Firewall Group"
+	labelNsxtAppPortProfile    = "NSX-T Application Port Profile"
+	labelNetworkContextProfile = "NSX-T Network Context Profile"
+)
+
+// FirewallPolicyDocumentVersion is the version of the FirewallPolicyDocument written by
+// ExportFirewallPolicy. Documents with a different version are rejected on import
+const FirewallPolicyDocumentVersion = 1
+
+// Source types of a FirewallPolicyDocument
+const (
+	FirewallPolicySourceEdgeGateway = "edgeGateway"
+	FirewallPolicySourceVdcGroup    = "vdcGroup"
+)
+
+// FirewallPolicyDocument is a portable representation of the firewall rules of an NSX-T Edge
+// Gateway or of the Distributed Firewall of a VDC Group. Firewall Groups, Application Port Profiles
+// and Network Context Profiles are referenced by name instead of URN, so that a document exported
+// from one environment (e.g. staging) can be imported in another one (e.g. production). It is meant
+// to be stored as JSON or, with ToYaml and FirewallPolicyDocumentFromYaml, as YAML
+type FirewallPolicyDocument struct {
+	Version int `json:"version"`
+	// SourceType is FirewallPolicySourceEdgeGateway or FirewallPolicySourceVdcGroup
+	SourceType string `json:"sourceType"`
+	// SourceName is the name of the exported Edge Gateway or VDC Group
+	SourceName string `json:"sourceName,omitempty"`
+	// FirewallGroups contains the IP Sets and Security Groups used by the rules
+	FirewallGroups []FirewallPolicyGroup `json:"firewallGroups,omitempty"`
+	// ApplicationPortProfiles contains the TENANT Application Port Profiles used by the rules.
+	// SYSTEM and PROVIDER profiles are only referenced by name and must exist in the target
+	ApplicationPortProfiles []FirewallPolicyAppPortProfile `json:"applicationPortProfiles,omitempty"`
+	// Rules in the order in which they are evaluated
+	Rules []FirewallPolicyRule `json:"rules"`
+}
+
+// ToYaml returns the document as YAML. Its keys are the same as the ones of the JSON document
+func (doc *FirewallPolicyDocument) ToYaml() ([]byte, error) {
+	text, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling firewall policy document to YAML: %w", err)
+	}
+	return text, nil
+}
+
+// FirewallPolicyDocumentFromYaml parses a document written by FirewallPolicyDocument.ToYaml. As
+// YAML is a superset of JSON, it also parses JSON documents
+func FirewallPolicyDocumentFromYaml(text []byte) (*FirewallPolicyDocument, error) {
+	doc := &FirewallPolicyDocument{}
+	err := yaml.Unmarshal(text, doc)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling firewall policy document from YAML: %w", err)
+	}
+	return doc, nil
+}
+
+// FirewallPolicyGroup is an IP Set or a Security Group of a FirewallPolicyDocument
+type FirewallPolicyGroup struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Type is one of types.FirewallGroupTypeIpSet, types.FirewallGroupTypeSecurityGroup or
+	// types.FirewallGroupTypeVmCriteria
+	Type        string   `json:"type"`
+	IpAddresses []string `json:"ipAddresses,omitempty"`
+	// Members contains the names of the Org VDC networks of a static Security Group
+	Members    []string                            `json:"members,omitempty"`
+	VmCriteria []types.NsxtFirewallGroupVmCriteria `json:"vmCriteria,omitempty"`
+}
+
+// FirewallPolicyAppPortProfile is a TENANT Application Port Profile of a FirewallPolicyDocument
+type FirewallPolicyAppPortProfile struct {
+	Name             string                         `json:"name"`
+	Description      string                         `json:"description,omitempty"`
+	ApplicationPorts []types.NsxtAppPortProfilePort `json:"applicationPorts"`
+}
+
+// FirewallPolicyGroupRef references a FirewallPolicyGroup. Names are unique per type of group
+type FirewallPolicyGroupRef struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// FirewallPolicyProfileRef references an Application Port Profile. Names are unique per scope
+type FirewallPolicyProfileRef struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+// FirewallPolicyRule is a rule of a FirewallPolicyDocument. Rule names must be unique in the
+// document, because they are used to match the rules of the target on import.
+//
+// Description, Comments, the exclusion of groups and NetworkContextProfiles are only supported by
+// the Distributed Firewall. Description and Comments are ignored when importing into an Edge
+// Gateway, while the other fields cause the import to fail
+type FirewallPolicyRule struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Comments    string `json:"comments,omitempty"`
+	// ActionValue is one of ALLOW, DROP or REJECT
+	ActionValue string `json:"actionValue"`
+	Enabled     bool   `json:"enabled"`
+	// Direction is one of IN_OUT, IN or OUT
+	Direction string `json:"direction"`
+	// IpProtocol is one of IPV4, IPV6 or IPV4_IPV6
+	IpProtocol string `json:"ipProtocol"`
+	Logging    bool   `json:"logging"`
+
+	// SourceFirewallGroups is empty for 'Any'
+	SourceFirewallGroups []FirewallPolicyGroupRef `json:"sourceFirewallGroups,omitempty"`
+	SourceGroupsExcluded bool                     `json:"sourceGroupsExcluded,omitempty"`
+	// DestinationFirewallGroups is empty for 'Any'
+	DestinationFirewallGroups []FirewallPolicyGroupRef `json:"destinationFirewallGroups,omitempty"`
+	DestinationGroupsExcluded bool                     `json:"destinationGroupsExcluded,omitempty"`
+	// ApplicationPortProfiles is empty for 'Any'
+	ApplicationPortProfiles []FirewallPolicyProfileRef `json:"applicationPortProfiles,omitempty"`
+	// NetworkContextProfiles contains the names of Network Context Profiles, which must exist in the
+	// target
+	NetworkContextProfiles []string `json:"networkContextProfiles,omitempty"`
+}
+
+// FirewallPolicyImportMode defines what happens to the rules of the target on import
+type FirewallPolicyImportMode string
+
+const (
+	// FirewallPolicyImportReplace replaces all rules of the target with the rules of the document
+	FirewallPolicyImportReplace FirewallPolicyImportMode = "replace"
+	// FirewallPolicyImportMerge updates the rules of the target that have the same name as a rule of
+	// the document, adds the other rules of the document at the bottom and keeps all other rules
+	FirewallPolicyImportMerge FirewallPolicyImportMode = "merge"
+)
+
+// FirewallPolicyImportOptions configures ImportFirewallPolicy
+type FirewallPolicyImportOptions struct {
+	// Mode is mandatory
+	Mode FirewallPolicyImportMode
+	// DryRun only computes the changes without making them. All names of the document are still
+	// resolved, so that missing networks and profiles are reported
+	DryRun bool
+}
+
+// FirewallPolicyImportResult contains the changes of ImportFirewallPolicy
+type FirewallPolicyImportResult struct {
+	Changes []ConfigurationChange
+	// Applied is false for a dry run
+	Applied bool
+}
+
+// IsEmpty returns true when the target already matches the document
+func (result *FirewallPolicyImportResult) IsEmpty() bool {
+	return len(result.Changes) == 0
+}
+
+// String returns the changes as a diff, one line per change
+func (result *FirewallPolicyImportResult) String() string {
+	return configurationChangesString(result.Changes)
+}
+
+// ExportFirewallPolicy returns the user defined firewall rules of the Edge Gateway, with the
+// Firewall Groups and TENANT Application Port Profiles they use, as a portable document
+func (egw *NsxtEdgeGateway) ExportFirewallPolicy() (*FirewallPolicyDocument, error) {
+	return exportFirewallPolicy(firewallPolicyTarget{egw: egw})
+}
+
+// ExportFirewallPolicy returns the rules of the default Distributed Firewall policy of the VDC
+// Group, with the Firewall Groups and TENANT Application Port Profiles they use, as a portable
+// document
+func (vdcGroup *VdcGroup) ExportFirewallPolicy() (*FirewallPolicyDocument, error) {
+	return exportFirewallPolicy(firewallPolicyTarget{vdcGroup: vdcGroup})
+}
+
+// ImportFirewallPolicy applies a document created by ExportFirewallPolicy, from an Edge Gateway or
+// a VDC Group, to the user defined firewall rules of the Edge Gateway.
+//
+// Firewall Groups and TENANT Application Port Profiles of the document are looked up by name: the
+// missing ones are created and the ones that differ are updated. Member networks of Security
+// Groups, SYSTEM and PROVIDER Application Port Profiles must exist. Rules are matched by name
+// and applied according to options.Mode. The rules are replaced with a single request, which fails
+// with ErrConflict if they were changed since they were retrieved.
+//
+// With options.DryRun, the changes are returned but not made
+func (egw *NsxtEdgeGateway) ImportFirewallPolicy(doc *FirewallPolicyDocument, options FirewallPolicyImportOptions) (*FirewallPolicyImportResult, error) {
+	return importFirewallPolicy(firewallPolicyTarget{egw: egw}, doc, options)
+}
+
+// ImportFirewallPolicy applies a document created by ExportFirewallPolicy, from an Edge Gateway or
+// a VDC Group, to the default Distributed Firewall policy of the VDC Group. Network Context Profiles
+// used by the rules must exist.
+//
+// See NsxtEdgeGateway.ImportFirewallPolicy for the handling of the other references and of
+// options
+func (vdcGroup *VdcGroup) ImportFirewallPolicy(doc *FirewallPolicyDocument, options FirewallPolicyImportOptions) (*FirewallPolicyImportResult, error) {
+	return importFirewallPolicy(firewallPolicyTarget{vdcGroup: vdcGroup}, doc, options)
+}
+
+// firewallPolicyTarget is the Edge Gateway or the VDC Group of an export or an import. Rules of
+// both are handled as types.DistributedFirewallRule, which contains all the fields of
+// types.NsxtFirewallRule
+type firewallPolicyTarget struct {
+	egw      *NsxtEdgeGateway
+	vdcGroup *VdcGroup
+}
+
+func (target firewallPolicyTarget) client() *Client {
+	if target.egw != nil {
+		return target.egw.client
+	}
+	return target.vdcGroup.client
+}
+
+func (target firewallPolicyTarget) id() string {
+	if target.egw != nil {
+		return target.egw.EdgeGateway.ID
+	}
+	return target.vdcGroup.VdcGroup.Id
+}
+
+func (target firewallPolicyTarget) name() string {
+	if target.egw != nil {
+		return target.egw.EdgeGateway.Name
+	}
+	return target.vdcGroup.VdcGroup.Name
+}
+
+func (target firewallPolicyTarget) sourceType() string {
+	if target.egw != nil {
+		return FirewallPolicySourceEdgeGateway
+	}
+	return FirewallPolicySourceVdcGroup
+}
+
+func (target firewallPolicyTarget) ruleLabel() string {
+	if target.egw != nil {
+		return labelNsxtFirewallRule
+	}
+	return labelDistributedFirewallRule
+}
+
+// contextId returns the ID of the VDC or VDC Group that owns the networks and the TENANT profiles
+// available to the target
+func (target firewallPolicyTarget) contextId() (string, error) {
+	if target.vdcGroup != nil {
+		return target.vdcGroup.VdcGroup.Id, nil
+	}
+	if target.egw.EdgeGateway.OwnerRef == nil || target.egw.EdgeGateway.OwnerRef.ID == "" {
+		return "", fmt.Errorf("NSX-T Edge Gateway '%s' has no owner", target.egw.EdgeGateway.Name)
+	}
+	return target.egw.EdgeGateway.OwnerRef.ID, nil
+}
+
+func (target firewallPolicyTarget) orgId() (string, error) {
+	if target.vdcGroup != nil {
+		return target.vdcGroup.VdcGroup.OrgId, nil
+	}
+	if target.egw.EdgeGateway.Org == nil || target.egw.EdgeGateway.Org.ID == "" {
+		return "", fmt.Errorf("NSX-T Edge Gateway '%s' has no Org", target.egw.EdgeGateway.Name)
+	}
+	return target.egw.EdgeGateway.Org.ID, nil
+}
+
+// getRawRules returns the rules of the target and, for Edge Gateways, their ETag
+func (target firewallPolicyTarget) getRawRules() ([]json.RawMessage, string, error) {
+	if target.egw != nil {
+		return getNsxtFirewallRulesRaw(target.egw.client, target.egw.EdgeGateway.ID)
+	}
+	rawRules, err := getInnerEntity[distributedFirewallRulesRaw](target.vdcGroup.client, target.dfwCrudConfig())
+	if err != nil {
+		return nil, "", err
+	}
+	return rawRules.Values, "", nil
+}
+
+func (target firewallPolicyTarget) putRawRules(rawRules []json.RawMessage, etag string) error {
+	if target.egw != nil {
+		return putNsxtFirewallRulesRaw(target.egw.client, target.egw.EdgeGateway.ID, rawRules, etag)
+	}
+	_, err := updateInnerEntity(target.vdcGroup.client, target.dfwCrudConfig(), &distributedFirewallRulesRaw{Values: rawRules})
+	return err
+}
+
+func (target firewallPolicyTarget) dfwCrudConfig() crudConfig {
+	return crudConfig{
+		entityLabel:    labelDistributedFirewall,
+		endpoint:       types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcGroupsDfwRules,
+		endpointParams: []string{target.vdcGroup.VdcGroup.Id, types.DistributedFirewallPolicyDefault},
+	}
+}
+
+func (target firewallPolicyTarget) decodeRule(rawRule json.RawMessage) (*types.DistributedFirewallRule, error) {
+	if target.egw != nil {
+		edgeRule, err := decodeRawFirewallRule(rawRule)
+		if err != nil {
+			return nil, err
+		}
+		return edgeFirewallRuleToDistributed(edgeRule), nil
+	}
+	rule := &types.DistributedFirewallRule{}
+	err := json.Unmarshal(rawRule, rule)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", labelDistributedFirewallRule, err)
+	}
+	if rule.ActionValue == "" {
+		rule.ActionValue = rule.Action
+	}
+	return rule, nil
+}
+
+// encodeRule converts rule to the JSON of the target. When existing is not nil, the ID and the
+// version of the existing rule are kept
+func (target firewallPolicyTarget) encodeRule(rule *types.DistributedFirewallRule, existing json.RawMessage) (json.RawMessage, error) {
+	var payload any
+	if target.egw != nil {
+		edgeRule := distributedFirewallRuleToEdge(rule)
+		if existing != nil {
+			existingRule, err := decodeRawFirewallRule(existing)
+			if err != nil {
+				return nil, err
+			}
+			edgeRule.ID = existingRule.ID
+			edgeRule.Version = existingRule.Version
+		}
+		payload = edgeRule
+	} else {
+		dfwRule := *rule
+		if existing != nil {
+			existingRule, err := target.decodeRule(existing)
+			if err != nil {
+				return nil, err
+			}
+			dfwRule.ID = existingRule.ID
+			dfwRule.Version = existingRule.Version
+		}
+		payload = &dfwRule
+	}
+	rawRule, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %s '%s': %w", target.ruleLabel(), rule.Name, err)
+	}
+	return rawRule, nil
+}
+
+func (target firewallPolicyTarget) getFirewallGroupByName(name, firewallGroupType string) (*NsxtFirewallGroup, error) {
+	if target.egw != nil {
+		return target.egw.GetNsxtFirewallGroupByName(name, firewallGroupType)
+	}
+	return target.vdcGroup.GetNsxtFirewallGroupByName(name, firewallGroupType)
+}
+
+// edgeFirewallRuleToDistributed converts an Edge Gateway rule to the common representation
+func edgeFirewallRuleToDistributed(rule *types.NsxtFirewallRule) *types.DistributedFirewallRule {
+	actionValue := rule.ActionValue
+	if actionValue == "" {
+		actionValue = rule.Action
+	}
+	return &types.DistributedFirewallRule{
+		ID:                        rule.ID,
+		Name:                      rule.Name,
+		ActionValue:               actionValue,
+		Enabled:                   rule.Enabled,
+		SourceFirewallGroups:      rule.SourceFirewallGroups,
+		DestinationFirewallGroups: rule.DestinationFirewallGroups,
+		ApplicationPortProfiles:   rule.ApplicationPortProfiles,
+		IpProtocol:                rule.IpProtocol,
+		Logging:                   rule.Logging,
+		Direction:                 rule.Direction,
+	}
+}
+
+// distributedFirewallRuleToEdge converts the common representation to an Edge Gateway rule. The
+// fields that only exist in Distributed Firewall rules are dropped
+func distributedFirewallRuleToEdge(rule *types.DistributedFirewallRule) *types.NsxtFirewallRule {
+	return &types.NsxtFirewallRule{
+		ID:                        rule.ID,
+		Name:                      rule.Name,
+		ActionValue:               rule.ActionValue,
+		Enabled:                   rule.Enabled,
+		SourceFirewallGroups:      rule.SourceFirewallGroups,
+		DestinationFirewallGroups: rule.DestinationFirewallGroups,
+		ApplicationPortProfiles:   rule.ApplicationPortProfiles,
+		IpProtocol:                rule.IpProtocol,
+		Logging:                   rule.Logging,
+		Direction:                 rule.Direction,
+	}
+}
+
+// firewallPolicyExporter builds a FirewallPolicyDocument, retrieving each referenced entity once
+type firewallPolicyExporter struct {
+	target   firewallPolicyTarget
+	doc      *FirewallPolicyDocument
+	groups   map[string]FirewallPolicyGroupRef
+	profiles map[string]FirewallPolicyProfileRef
+}
+
+func exportFirewallPolicy(target firewallPolicyTarget) (*FirewallPolicyDocument, error) {
+	rawRules, _, err := target.getRawRules()
+	if err != nil {
+		return nil, fmt.Errorf("error exporting %s of '%s': %w", labelFirewallPolicy, target.name(), err)
+	}
+
+	exporter := &firewallPolicyExporter{
+		target: target,
+		doc: &FirewallPolicyDocument{
+			Version:    FirewallPolicyDocumentVersion,
+			SourceType: target.sourceType(),
+			SourceName: target.name(),
+			Rules:      []FirewallPolicyRule{},
+		},
+		groups:   make(map[string]FirewallPolicyGroupRef),
+		profiles: make(map[string]FirewallPolicyProfileRef),
+	}
+	for _, rawRule := range rawRules {
+		rule, err := target.decodeRule(rawRule)
+		if err != nil {
+			return nil, err
+		}
+		docRule, err := exporter.exportRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("error exporting %s '%s': %w", target.ruleLabel(), rule.Name, err)
+		}
+		exporter.doc.Rules = append(exporter.doc.Rules, docRule)
+	}
+	return exporter.doc, nil
+}
+
+func (exporter *firewallPolicyExporter) exportRule(rule *types.DistributedFirewallRule) (FirewallPolicyRule, error) {
+	docRule := FirewallPolicyRule{
+		Name:                      rule.Name,
+		Description:               rule.Description,
+		Comments:                  rule.Comments,
+		ActionValue:               rule.ActionValue,
+		Enabled:                   rule.Enabled,
+		Direction:                 rule.Direction,
+		IpProtocol:                rule.IpProtocol,
+		Logging:                   rule.Logging,
+		SourceGroupsExcluded:      rule.SourceGroupsExcluded != nil && *rule.SourceGroupsExcluded,
+		DestinationGroupsExcluded: rule.DestinationGroupsExcluded != nil && *rule.DestinationGroupsExcluded,
+	}
+	var err error
+	docRule.SourceFirewallGroups, err = exporter.groupRefs(rule.SourceFirewallGroups)
+	if err != nil {
+		return docRule, err
+	}
+	docRule.DestinationFirewallGroups, err = exporter.groupRefs(rule.DestinationFirewallGroups)
+	if err != nil {
+		return docRule, err
+	}
+	for _, reference := range rule.ApplicationPortProfiles {
+		profileRef, err := exporter.profileRef(reference)
+		if err != nil {
+			return docRule, err
+		}
+		docRule.ApplicationPortProfiles = append(docRule.ApplicationPortProfiles, profileRef)
+	}
+	for _, reference := range rule.NetworkContextProfiles {
+		if reference.Name == "" {
+			return docRule, fmt.Errorf("%s '%s' has no name", labelNetworkContextProfile, reference.ID)
+		}
+		docRule.NetworkContextProfiles = append(docRule.NetworkContextProfiles, reference.Name)
+	}
+	return docRule, nil
+}
+
+// groupRefs converts the references to Firewall Groups, adding the groups to the document
+func (exporter *firewallPolicyExporter) groupRefs(references []types.OpenApiReference) ([]FirewallPolicyGroupRef, error) {
+	var groupRefs []FirewallPolicyGroupRef
+	for _, reference := range references {
+		groupRef, found := exporter.groups[reference.ID]
+		if !found {
+			fwGroup, err := getNsxtFirewallGroupById(exporter.target.client(), reference.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving %s '%s': %w", labelNsxtFirewallGroup, reference.ID, err)
+			}
+			group := FirewallPolicyGroup{
+				Name:        fwGroup.NsxtFirewallGroup.Name,
+				Description: fwGroup.NsxtFirewallGroup.Description,
+				Type:        firewallGroupType(fwGroup.NsxtFirewallGroup),
+				IpAddresses: fwGroup.NsxtFirewallGroup.IpAddresses,
+				VmCriteria:  fwGroup.NsxtFirewallGroup.VmCriteria,
+			}
+			for _, member := range fwGroup.NsxtFirewallGroup.Members {
+				group.Members = append(group.Members, member.Name)
+			}
+			exporter.doc.FirewallGroups = append(exporter.doc.FirewallGroups, group)
+			groupRef = FirewallPolicyGroupRef{Name: group.Name, Type: group.Type}
+			exporter.groups[reference.ID] = groupRef
+		}
+		groupRefs = append(groupRefs, groupRef)
+	}
+	return groupRefs, nil
+}
+
+// profileRef converts a reference to an Application Port Profile, adding TENANT profiles to the
+// document
+func (exporter *firewallPolicyExporter) profileRef(reference types.OpenApiReference) (FirewallPolicyProfileRef, error) {
+	profileRef, found := exporter.profiles[reference.ID]
+	if found {
+		return profileRef, nil
+	}
+	profile, err := getNsxtAppPortProfileById(exporter.target.client(), reference.ID)
+	if err != nil {
+		return profileRef, fmt.Errorf("error retrieving %s '%s': %w", labelNsxtAppPortProfile, reference.ID, err)
+	}
+	profileRef = FirewallPolicyProfileRef{Name: profile.NsxtAppPortProfile.Name, Scope: profile.NsxtAppPortProfile.Scope}
+	if profileRef.Scope == types.ApplicationPortProfileScopeTenant {
+		exporter.doc.ApplicationPortProfiles = append(exporter.doc.ApplicationPortProfiles, FirewallPolicyAppPortProfile{
+			Name:             profile.NsxtAppPortProfile.Name,
+			Description:      profile.NsxtAppPortProfile.Description,
+			ApplicationPorts: profile.NsxtAppPortProfile.ApplicationPorts,
+		})
+	}
+	exporter.profiles[reference.ID] = profileRef
+	return profileRef, nil
+}
+
+// validate checks the version of the document and that all its references can be resolved
+func (doc *FirewallPolicyDocument) validate() error {
+	if doc == nil {
+		return fmt.Errorf("%s document is empty", labelFirewallPolicy)
+	}
+	if doc.Version != FirewallPolicyDocumentVersion {
+		return fmt.Errorf("unsupported %s document version %d, expected %d", labelFirewallPolicy, doc.Version, FirewallPolicyDocumentVersion)
+	}
+
+	groups := make(map[FirewallPolicyGroupRef]bool)
+	for _, group := range doc.FirewallGroups {
+		groupRef := FirewallPolicyGroupRef{Name: group.Name, Type: group.Type}
+		switch {
+		case group.Name == "":
+			return fmt.Errorf("%s without name", labelNsxtFirewallGroup)
+		case !contains(group.Type, []string{types.FirewallGroupTypeIpSet, types.FirewallGroupTypeSecurityGroup, types.FirewallGroupTypeVmCriteria}):
+			return fmt.Errorf("%s '%s' has invalid type '%s'", labelNsxtFirewallGroup, group.Name, group.Type)
+		case groups[groupRef]:
+			return fmt.Errorf("duplicate %s '%s' of type %s", labelNsxtFirewallGroup, group.Name, group.Type)
+		}
+		groups[groupRef] = true
+	}
+
+	profiles := make(map[string]bool)
+	for _, profile := range doc.ApplicationPortProfiles {
+		if profile.Name == "" || profiles[profile.Name] {
+			return fmt.Errorf("%s without name or duplicate '%s'", labelNsxtAppPortProfile, profile.Name)
+		}
+		profiles[profile.Name] = true
+	}
+
+	rules := make(map[string]bool)
+	for _, rule := range doc.Rules {
+		if rule.Name == "" || rules[rule.Name] {
+			return fmt.Errorf("rule without name or duplicate rule '%s': rule names must be unique", rule.Name)
+		}
+		rules[rule.Name] = true
+		for _, groupRef := range append(append([]FirewallPolicyGroupRef{}, rule.SourceFirewallGroups...), rule.DestinationFirewallGroups...) {
+			if !groups[groupRef] {
+				return fmt.Errorf("rule '%s' references %s '%s' of type %s, which is not in the document",
+					rule.Name, labelNsxtFirewallGroup, groupRef.Name, groupRef.Type)
+			}
+		}
+		for _, profileRef := range rule.ApplicationPortProfiles {
+			if profileRef.Scope == "" {
+				return fmt.Errorf("rule '%s' references %s '%s' without scope", rule.Name, labelNsxtAppPortProfile, profileRef.Name)
+			}
+			if profileRef.Scope == types.ApplicationPortProfileScopeTenant && !profiles[profileRef.Name] {
+				return fmt.Errorf("rule '%s' references TENANT %s '%s', which is not in the document",
+					rule.Name, labelNsxtAppPortProfile, profileRef.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// unsupportedOnEdgeGateway returns the names of the rules that use fields of the Distributed
+// Firewall which have no equivalent in Edge Gateway rules
+func (doc *FirewallPolicyDocument) unsupportedOnEdgeGateway() []string {
+	var names []string
+	for _, rule := range doc.Rules {
+		if len(rule.NetworkContextProfiles) > 0 || rule.SourceGroupsExcluded || rule.DestinationGroupsExcluded {
+			names = append(names, rule.Name)
+		}
+	}
+	return names
+}
+
+// firewallPolicyImporter resolves the names of a FirewallPolicyDocument in the target
+type firewallPolicyImporter struct {
+	target    firewallPolicyTarget
+	doc       *FirewallPolicyDocument
+	options   FirewallPolicyImportOptions
+	contextId string
+	result    *FirewallPolicyImportResult
+
+	groupPlans   []*firewallPolicyGroupPlan
+	profilePlans []*firewallPolicyProfilePlan
+	// groupIds, profileIds and contextProfileIds contain the IDs of the referenced entities in the
+	// target. Entities that are not created yet in a dry run have a placeholder ID
+	groupIds          map[FirewallPolicyGroupRef]string
+	profileIds        map[FirewallPolicyProfileRef]string
+	contextProfileIds map[string]string
+	networkIds        map[string]string
+}
+
+// firewallPolicyGroupPlan is the change of a Firewall Group of the document
+type firewallPolicyGroupPlan struct {
+	config *types.NsxtFirewallGroup
+	// existing is nil when the group must be created
+	existing *NsxtFirewallGroup
+	fields   []string
+}
+
+// firewallPolicyProfilePlan is the change of an Application Port Profile of the document
+type firewallPolicyProfilePlan struct {
+	config *types.NsxtAppPortProfile
+	// existing is nil when the profile must be created
+	existing *NsxtAppPortProfile
+	fields   []string
+}
+
+func importFirewallPolicy(target firewallPolicyTarget, doc *FirewallPolicyDocument, options FirewallPolicyImportOptions) (*FirewallPolicyImportResult, error) {
+	if options.Mode != FirewallPolicyImportReplace && options.Mode != FirewallPolicyImportMerge {
+		return nil, fmt.Errorf("invalid %s import mode '%s'", labelFirewallPolicy, options.Mode)
+	}
+	err := doc.validate()
+	if err != nil {
+		return nil, err
+	}
+	if target.egw != nil {
+		unsupported := doc.unsupportedOnEdgeGateway()
+		if len(unsupported) > 0 {
+			return nil, fmt.Errorf("rules %s use Network Context Profiles or excluded groups, which are not supported by NSX-T Edge Gateway '%s'",
+				strings.Join(unsupported, ", "), target.name())
+		}
+	}
+	contextId, err := target.contextId()
+	if err != nil {
+		return nil, err
+	}
+
+	importer := &firewallPolicyImporter{
+		target:            target,
+		doc:               doc,
+		options:           options,
+		contextId:         contextId,
+		result:            &FirewallPolicyImportResult{Applied: !options.DryRun},
+		groupIds:          make(map[FirewallPolicyGroupRef]string),
+		profileIds:        make(map[FirewallPolicyProfileRef]string),
+		contextProfileIds: make(map[string]string),
+		networkIds:        make(map[string]string),
+	}
+
+	// All names are resolved before any change is made, so that a missing reference does not leave
+	// the target partially updated
+	err = importer.planGroupsAndProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("error importing %s into '%s': %w", labelFirewallPolicy, target.name(), err)
+	}
+	err = importer.resolveExternalReferences()
+	if err != nil {
+		return nil, fmt.Errorf("error importing %s into '%s': %w", labelFirewallPolicy, target.name(), err)
+	}
+	rawRules, etag, err := target.getRawRules()
+	if err != nil {
+		return nil, fmt.Errorf("error importing %s into '%s': %w", labelFirewallPolicy, target.name(), err)
+	}
+
+	if !options.DryRun {
+		err = importer.applyGroupsAndProfiles()
+		if err != nil {
+			return importer.result, fmt.Errorf("error importing %s into '%s': %w", labelFirewallPolicy, target.name(), err)
+		}
+	}
+
+	desiredRules := make([]*types.DistributedFirewallRule, len(doc.Rules))
+	for index := range doc.Rules {
+		desiredRules[index] = importer.resolveRule(doc.Rules[index])
+	}
+	finalRules, ruleChanges, err := planFirewallPolicyRules(target, options.Mode, rawRules, desiredRules)
+	if err != nil {
+		return importer.result, err
+	}
+	importer.result.Changes = append(importer.result.Changes, ruleChanges...)
+
+	if !options.DryRun && len(ruleChanges) > 0 {
+		util.Logger.Printf("[DEBUG] applying %d rules of %s to '%s'", len(finalRules), labelFirewallPolicy, target.name())
+		err = target.putRawRules(finalRules, etag)
+		if err != nil {
+			return importer.result, fmt.Errorf("error applying rules of %s to '%s': %w", labelFirewallPolicy, target.name(), err)
+		}
+	}
+	return importer.result, nil
+}
+
+// planGroupsAndProfiles looks up the Firewall Groups and TENANT Application Port Profiles of the
+// document in the target and records the ones to create or update
+func (importer *firewallPolicyImporter) planGroupsAndProfiles() error {
+	for _, group := range importer.doc.FirewallGroups {
+		config := &types.NsxtFirewallGroup{
+			Name:        group.Name,
+			Description: group.Description,
+			TypeValue:   group.Type,
+			IpAddresses: group.IpAddresses,
+			VmCriteria:  group.VmCriteria,
+			OwnerRef:    &types.OpenApiReference{ID: importer.target.id()},
+		}
+		for _, networkName := range group.Members {
+			networkId, err := importer.networkId(networkName)
+			if err != nil {
+				return fmt.Errorf("error resolving member of %s '%s': %w", labelNsxtFirewallGroup, group.Name, err)
+			}
+			config.Members = append(config.Members, types.OpenApiReference{ID: networkId, Name: networkName})
+		}
+
+		groupRef := FirewallPolicyGroupRef{Name: group.Name, Type: group.Type}
+		plan := &firewallPolicyGroupPlan{config: config}
+		existing, err := importer.target.getFirewallGroupByName(group.Name, group.Type)
+		switch {
+		case ContainsNotFound(err):
+			importer.groupIds[groupRef] = "pending:" + group.Type + ":" + group.Name
+			importer.addChange(ConfigurationChange{Action: ConfigurationChangeCreate, Kind: labelNsxtFirewallGroup, Name: group.Name})
+		case err != nil:
+			return fmt.Errorf("error retrieving %s '%s': %w", labelNsxtFirewallGroup, group.Name, err)
+		default:
+			plan.existing = existing
+			importer.groupIds[groupRef] = existing.NsxtFirewallGroup.ID
+			plan.fields = firewallGroupChanges(existing.NsxtFirewallGroup, config)
+			if len(plan.fields) == 0 {
+				continue
+			}
+			importer.addChange(ConfigurationChange{Action: ConfigurationChangeUpdate, Kind: labelNsxtFirewallGroup, Name: group.Name, Fields: plan.fields})
+		}
+		importer.groupPlans = append(importer.groupPlans, plan)
+	}
+
+	if len(importer.doc.ApplicationPortProfiles) == 0 {
+		return nil
+	}
+	orgId, err := importer.target.orgId()
+	if err != nil {
+		return err
+	}
+	for _, profile := range importer.doc.ApplicationPortProfiles {
+		config := &types.NsxtAppPortProfile{
+			Name:             profile.Name,
+			Description:      profile.Description,
+			ApplicationPorts: profile.ApplicationPorts,
+			OrgRef:           &types.OpenApiReference{ID: orgId},
+			ContextEntityId:  importer.contextId,
+			Scope:            types.ApplicationPortProfileScopeTenant,
+		}
+		profileRef := FirewallPolicyProfileRef{Name: profile.Name, Scope: types.ApplicationPortProfileScopeTenant}
+		plan := &firewallPolicyProfilePlan{config: config}
+		existing, err := importer.getAppPortProfile(profileRef)
+		switch {
+		case ContainsNotFound(err):
+			importer.profileIds[profileRef] = "pending:" + profileRef.Scope + ":" + profile.Name
+			importer.addChange(ConfigurationChange{Action: ConfigurationChangeCreate, Kind: labelNsxtAppPortProfile, Name: profile.Name})
+		case err != nil:
+			return fmt.Errorf("error retrieving %s '%s': %w", labelNsxtAppPortProfile, profile.Name, err)
+		default:
+			plan.existing = existing
+			importer.profileIds[profileRef] = existing.NsxtAppPortProfile.ID
+			plan.fields = appPortProfileChanges(existing.NsxtAppPortProfile, config)
+			if len(plan.fields) == 0 {
+				continue
+			}
+			importer.addChange(ConfigurationChange{Action: ConfigurationChangeUpdate, Kind: labelNsxtAppPortProfile, Name: profile.Name, Fields: plan.fields})
+		}
+		importer.profilePlans = append(importer.profilePlans, plan)
+	}
+	return nil
+}
+
+// resolveExternalReferences looks up the SYSTEM and PROVIDER Application Port Profiles and the
+// Network Context Profiles used by the rules, which must exist in the target
+func (importer *firewallPolicyImporter) resolveExternalReferences() error {
+	for _, rule := range importer.doc.Rules {
+		for _, profileRef := range rule.ApplicationPortProfiles {
+			if _, found := importer.profileIds[profileRef]; found {
+				continue
+			}
+			profile, err := importer.getAppPortProfile(profileRef)
+			if err != nil {
+				return fmt.Errorf("error retrieving %s %s '%s' of rule '%s': %w", profileRef.Scope, labelNsxtAppPortProfile, profileRef.Name, rule.Name, err)
+			}
+			importer.profileIds[profileRef] = profile.NsxtAppPortProfile.ID
+		}
+		for _, name := range rule.NetworkContextProfiles {
+			if _, found := importer.contextProfileIds[name]; found {
+				continue
+			}
+			queryParameters := queryParameterFilterAnd(fmt.Sprintf("name==%s;_context==%s", name, importer.contextId), url.Values{})
+			allProfiles, err := GetAllNetworkContextProfiles(importer.target.client(), queryParameters)
+			if err != nil {
+				return fmt.Errorf("error retrieving %s '%s' of rule '%s': %w", labelNetworkContextProfile, name, rule.Name, err)
+			}
+			contextProfile, err := returnSingleNetworkContextProfile(allProfiles)
+			if err != nil {
+				return fmt.Errorf("error retrieving %s '%s' of rule '%s': %w", labelNetworkContextProfile, name, rule.Name, err)
+			}
+			importer.contextProfileIds[name] = contextProfile.ID
+		}
+	}
+	return nil
+}
+
+// applyGroupsAndProfiles creates and updates the planned Firewall Groups and Application Port
+// Profiles, and records the IDs of the created ones
+func (importer *firewallPolicyImporter) applyGroupsAndProfiles() error {
+	for _, plan := range importer.groupPlans {
+		groupRef := FirewallPolicyGroupRef{Name: plan.config.Name, Type: plan.config.TypeValue}
+		if plan.existing == nil {
+			created, err := createNsxtFirewallGroup(importer.target.client(), plan.config)
+			if err != nil {
+				return err
+			}
+			importer.groupIds[groupRef] = created.NsxtFirewallGroup.ID
+			continue
+		}
+		updateConfig := *plan.existing.NsxtFirewallGroup
+		updateConfig.Description = plan.config.Description
+		updateConfig.IpAddresses = plan.config.IpAddresses
+		updateConfig.Members = plan.config.Members
+		updateConfig.VmCriteria = plan.config.VmCriteria
+		_, err := plan.existing.Update(&updateConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, plan := range importer.profilePlans {
+		profileRef := FirewallPolicyProfileRef{Name: plan.config.Name, Scope: plan.config.Scope}
+		if plan.existing == nil {
+			created, err := createNsxtAppPortProfile(importer.target.client(), plan.config)
+			if err != nil {
+				return err
+			}
+			importer.profileIds[profileRef] = created.NsxtAppPortProfile.ID
+			continue
+		}
+		updateConfig := *plan.existing.NsxtAppPortProfile
+		updateConfig.Description = plan.config.Description
+		updateConfig.ApplicationPorts = plan.config.ApplicationPorts
+		_, err := plan.existing.Update(&updateConfig)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveRule converts a rule of the document to the target, replacing names with IDs. All
+// references were resolved by the previous steps
+func (importer *firewallPolicyImporter) resolveRule(docRule FirewallPolicyRule) *types.DistributedFirewallRule {
+	rule := &types.DistributedFirewallRule{
+		Name:        docRule.Name,
+		ActionValue: docRule.ActionValue,
+		Enabled:     docRule.Enabled,
+		Direction:   docRule.Direction,
+		IpProtocol:  docRule.IpProtocol,
+		Logging:     docRule.Logging,
+	}
+	for _, groupRef := range docRule.SourceFirewallGroups {
+		rule.SourceFirewallGroups = append(rule.SourceFirewallGroups, types.OpenApiReference{ID: importer.groupIds[groupRef], Name: groupRef.Name})
+	}
+	for _, groupRef := range docRule.DestinationFirewallGroups {
+		rule.DestinationFirewallGroups = append(rule.DestinationFirewallGroups, types.OpenApiReference{ID: importer.groupIds[groupRef], Name: groupRef.Name})
+	}
+	for _, profileRef := range docRule.ApplicationPortProfiles {
+		rule.ApplicationPortProfiles = append(rule.ApplicationPortProfiles, types.OpenApiReference{ID: importer.profileIds[profileRef], Name: profileRef.Name})
+	}
+	// Only the Distributed Firewall supports the fields below
+	if importer.target.vdcGroup != nil {
+		rule.Description = docRule.Description
+		rule.Comments = docRule.Comments
+		if docRule.SourceGroupsExcluded {
+			rule.SourceGroupsExcluded = addrOf(true)
+		}
+		if docRule.DestinationGroupsExcluded {
+			rule.DestinationGroupsExcluded = addrOf(true)
+		}
+		for _, name := range docRule.NetworkContextProfiles {
+			rule.NetworkContextProfiles = append(rule.NetworkContextProfiles, types.OpenApiReference{ID: importer.contextProfileIds[name], Name: name})
+		}
+	}
+	return rule
+}
+
+func (importer *firewallPolicyImporter) addChange(change ConfigurationChange) {
+	importer.result.Changes = append(importer.result.Changes, change)
+}
+
+func (importer *firewallPolicyImporter) getAppPortProfile(profileRef FirewallPolicyProfileRef) (*NsxtAppPortProfile, error) {
+	queryParameters := queryParameterFilterAnd(fmt.Sprintf("_context==%s;scope==%s", importer.contextId, profileRef.Scope), url.Values{})
+	return getNsxtAppPortProfileByName(importer.target.client(), profileRef.Name, queryParameters)
+}
+
+// networkId returns the ID of the Org VDC network with the given name available to the target
+func (importer *firewallPolicyImporter) networkId(name string) (string, error) {
+	if networkId, found := importer.networkIds[name]; found {
+		return networkId, nil
+	}
+	queryParameters := queryParameterFilterAnd(fmt.Sprintf("name==%s;ownerRef.id==%s", name, importer.contextId), url.Values{})
+	allNetworks, err := getAllOpenApiOrgVdcNetworks(importer.target.client(), queryParameters, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
+	}
+	network, err := returnSingleOpenApiOrgVdcNetwork(name, allNetworks)
+	if err != nil {
+		return "", err
+	}
+	importer.networkIds[name] = network.OpenApiOrgVdcNetwork.ID
+	return network.OpenApiOrgVdcNetwork.ID, nil
+}
+
+// planFirewallPolicyRules matches the desired rules with the current rules of the target by name
+// and returns the rules to apply, with the changes they make. Current rules that do not change are
+// kept as they were retrieved
+func planFirewallPolicyRules(target firewallPolicyTarget, mode FirewallPolicyImportMode, currentRaw []json.RawMessage,
+	desired []*types.DistributedFirewallRule) ([]json.RawMessage, []ConfigurationChange, error) {
+	label := target.ruleLabel()
+	current := make([]*types.DistributedFirewallRule, len(currentRaw))
+	unmatched := make(map[string][]int)
+	for index, rawRule := range currentRaw {
+		rule, err := target.decodeRule(rawRule)
+		if err != nil {
+			return nil, nil, err
+		}
+		current[index] = rule
+		unmatched[rule.Name] = append(unmatched[rule.Name], index)
+	}
+
+	// matches contains, for each desired rule, the index of the current rule with the same name or -1
+	matches := make([]int, len(desired))
+	matched := make([]bool, len(current))
+	for index, rule := range desired {
+		matches[index] = -1
+		if candidates := unmatched[rule.Name]; len(candidates) > 0 {
+			matches[index] = candidates[0]
+			matched[candidates[0]] = true
+			unmatched[rule.Name] = candidates[1:]
+		}
+	}
+
+	var changes []ConfigurationChange
+	// encode returns the JSON of a desired rule, keeping the current one when there are no changes
+	encode := func(index int) (json.RawMessage, error) {
+		rule := desired[index]
+		currentIndex := matches[index]
+		if currentIndex < 0 {
+			changes = append(changes, ConfigurationChange{Action: ConfigurationChangeCreate, Kind: label, Name: rule.Name})
+			return target.encodeRule(rule, nil)
+		}
+		fields := firewallRuleChanges(current[currentIndex], rule)
+		if len(fields) == 0 {
+			return currentRaw[currentIndex], nil
+		}
+		changes = append(changes, ConfigurationChange{Action: ConfigurationChangeUpdate, Kind: label, Name: rule.Name, Fields: fields})
+		return target.encodeRule(rule, currentRaw[currentIndex])
+	}
+
+	var finalRules []json.RawMessage
+	switch mode {
+	case FirewallPolicyImportReplace:
+		reordered := false
+		previousIndex := -1
+		for index := range desired {
+			rawRule, err := encode(index)
+			if err != nil {
+				return nil, nil, err
+			}
+			finalRules = append(finalRules, rawRule)
+			if matches[index] >= 0 {
+				reordered = reordered || matches[index] < previousIndex
+				previousIndex = matches[index]
+			}
+		}
+		for index, rule := range current {
+			if !matched[index] {
+				changes = append(changes, ConfigurationChange{Action: ConfigurationChangeDelete, Kind: label, Name: rule.Name})
+			}
+		}
+		if reordered {
+			changes = append(changes, ConfigurationChange{Action: ConfigurationChangeReorder, Kind: label})
+		}
+	case FirewallPolicyImportMerge:
+		desiredIndexes := make(map[int]int)
+		for index, currentIndex := range matches {
+			if currentIndex >= 0 {
+				desiredIndexes[currentIndex] = index
+			}
+		}
+		for currentIndex, rawRule := range currentRaw {
+			if index, found := desiredIndexes[currentIndex]; found {
+				var err error
+				rawRule, err = encode(index)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			finalRules = append(finalRules, rawRule)
+		}
+		for index := range desired {
+			if matches[index] < 0 {
+				rawRule, err := encode(index)
+				if err != nil {
+					return nil, nil, err
+				}
+				finalRules = append(finalRules, rawRule)
+			}
+		}
+	}
+	if finalRules == nil {
+		finalRules = []json.RawMessage{}
+	}
+	return finalRules, changes, nil
+}
+
+// firewallRuleChanges returns the JSON names of the fields that differ between two rules, ignoring
+// the order of references
+func firewallRuleChanges(current, desired *types.DistributedFirewallRule) []string {
+	var fields []string
+	compare := func(field string, currentValue, desiredValue any) {
+		if !reflect.DeepEqual(currentValue, desiredValue) {
+			fields = append(fields, field)
+		}
+	}
+	compare("description", current.Description, desired.Description)
+	compare("comments", current.Comments, desired.Comments)
+	compare("actionValue", current.ActionValue, desired.ActionValue)
+	compare("enabled", current.Enabled, desired.Enabled)
+	compare("direction", current.Direction, desired.Direction)
+	compare("ipProtocol", current.IpProtocol, desired.IpProtocol)
+	compare("logging", current.Logging, desired.Logging)
+	compare("sourceFirewallGroups", referenceIds(current.SourceFirewallGroups), referenceIds(desired.SourceFirewallGroups))
+	compare("sourceGroupsExcluded", boolValue(current.SourceGroupsExcluded), boolValue(desired.SourceGroupsExcluded))
+	compare("destinationFirewallGroups", referenceIds(current.DestinationFirewallGroups), referenceIds(desired.DestinationFirewallGroups))
+	compare("destinationGroupsExcluded", boolValue(current.DestinationGroupsExcluded), boolValue(desired.DestinationGroupsExcluded))
+	compare("applicationPortProfiles", referenceIds(current.ApplicationPortProfiles), referenceIds(desired.ApplicationPortProfiles))
+	compare("networkContextProfiles", referenceIds(current.NetworkContextProfiles), referenceIds(desired.NetworkContextProfiles))
+	return fields
+}
+
+// firewallGroupChanges returns the JSON names of the fields that differ between two Firewall Groups
+func firewallGroupChanges(current, desired *types.NsxtFirewallGroup) []string {
+	var fields []string
+	if current.Description != desired.Description {
+		fields = append(fields, "description")
+	}
+	if !reflect.DeepEqual(sortedStrings(current.IpAddresses), sortedStrings(desired.IpAddresses)) {
+		fields = append(fields, "ipAddresses")
+	}
+	if !reflect.DeepEqual(referenceIds(current.Members), referenceIds(desired.Members)) {
+		fields = append(fields, "members")
+	}
+	if (len(current.VmCriteria) > 0 || len(desired.VmCriteria) > 0) && !reflect.DeepEqual(current.VmCriteria, desired.VmCriteria) {
+		fields = append(fields, "vmCriteria")
+	}
+	return fields
+}
+
+// appPortProfileChanges returns the JSON names of the fields that differ between two Application
+// Port Profiles
+func appPortProfileChanges(current, desired *types.NsxtAppPortProfile) []string {
+	var fields []string
+	if current.Description != desired.Description {
+		fields = append(fields, "description")
+	}
+	if (len(current.ApplicationPorts) > 0 || len(desired.ApplicationPorts) > 0) && !reflect.DeepEqual(current.ApplicationPorts, desired.ApplicationPorts) {
+		fields = append(fields, "applicationPorts")
+	}
+	return fields
+}
+
+// referenceIds returns the sorted IDs of references, or nil when there are none
+func referenceIds(references []types.OpenApiReference) []string {
+	var ids []string
+	for _, reference := range references {
+		ids = append(ids, reference.ID)
+	}
+	return sortedStrings(ids)
+}
+
+// sortedStrings returns a sorted copy of values, or nil when values is empty
+func sortedStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func boolValue(value *bool) bool {
+	return value != nil && *value
+}
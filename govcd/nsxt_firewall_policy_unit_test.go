@@ -0,0 +1,364 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// newFirewallPolicyTestEdgeGateway returns the client of a new govcdtest server with an Edge Gateway
+// in 'vdc1', and the ID of the Org VDC network 'app' of 'vdc1'
+func newFirewallPolicyTestEdgeGateway(t *testing.T, name string) (*VCDClient, *NsxtEdgeGateway, string) {
+	server, vcdClient := testServerClient(t)
+	networkId, err := server.AddOrgVdcNetwork("org1", "vdc1", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vcdClient, testEdgeGateway(t, vcdClient, name), networkId
+}
+
+// firewallPolicyTestGroup creates a Firewall Group and returns its ID
+func firewallPolicyTestGroup(t *testing.T, client *Client, group *types.NsxtFirewallGroup) string {
+	t.Helper()
+	created, err := createNsxtFirewallGroup(client, group)
+	if err != nil {
+		t.Fatalf("error creating Firewall Group %s: %s", group.Name, err)
+	}
+	return created.NsxtFirewallGroup.ID
+}
+
+// firewallPolicyTestProfile returns the ID of the Application Port Profile with the given name and
+// scope, creating TENANT profiles with the given ports in 'vdc1'
+func firewallPolicyTestProfile(t *testing.T, vcdClient *VCDClient, name, scope string, ports ...types.NsxtAppPortProfilePort) string {
+	t.Helper()
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scope != types.ApplicationPortProfileScopeTenant {
+		profile, err := org.GetNsxtAppPortProfileByName(name, scope)
+		if err != nil {
+			t.Fatalf("error retrieving Application Port Profile %s: %s", name, err)
+		}
+		return profile.NsxtAppPortProfile.ID
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := org.CreateNsxtAppPortProfile(&types.NsxtAppPortProfile{
+		Name:             name,
+		ApplicationPorts: ports,
+		OrgRef:           &types.OpenApiReference{ID: org.Org.ID},
+		ContextEntityId:  vdc.Vdc.ID,
+		Scope:            scope,
+	})
+	if err != nil {
+		t.Fatalf("error creating Application Port Profile %s: %s", name, err)
+	}
+	return profile.NsxtAppPortProfile.ID
+}
+
+// firewallPolicyTestEdgeRules replaces the rules of the Edge Gateway and returns the new rules
+func firewallPolicyTestEdgeRules(t *testing.T, egw *NsxtEdgeGateway, rules ...*types.NsxtFirewallRule) []*types.NsxtFirewallRule {
+	t.Helper()
+	firewall, err := egw.UpdateNsxtFirewall(&types.NsxtFirewallRuleContainer{UserDefinedRules: rules})
+	if err != nil {
+		t.Fatalf("error setting rules: %s", err)
+	}
+	return firewall.NsxtFirewallRuleContainer.UserDefinedRules
+}
+
+func TestNsxtEdgeGateway_FirewallPolicyExportImport(t *testing.T) {
+	// Staging environment, where the policy is exported
+	stagingClient, stagingEgw, stagingNetworkId := newFirewallPolicyTestEdgeGateway(t, "egw-staging")
+	stagingOwner := &types.OpenApiReference{ID: stagingEgw.EdgeGateway.ID}
+	webId := firewallPolicyTestGroup(t, stagingEgw.client, &types.NsxtFirewallGroup{
+		Name: "web-servers", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.0.2", "10.0.0.1"}, OwnerRef: stagingOwner,
+	})
+	appNetId := firewallPolicyTestGroup(t, stagingEgw.client, &types.NsxtFirewallGroup{
+		Name: "app-net", TypeValue: types.FirewallGroupTypeSecurityGroup, Members: simulatorTestRefs(stagingNetworkId), OwnerRef: stagingOwner,
+	})
+	httpsId := firewallPolicyTestProfile(t, stagingClient, "HTTPS", types.ApplicationPortProfileScopeSystem)
+	customId := firewallPolicyTestProfile(t, stagingClient, "custom-8443", types.ApplicationPortProfileScopeTenant,
+		types.NsxtAppPortProfilePort{Protocol: "TCP", DestinationPorts: []string{"8443"}})
+	firewallPolicyTestEdgeRules(t, stagingEgw,
+		&types.NsxtFirewallRule{Name: "allow-web", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT",
+			DestinationFirewallGroups: simulatorTestRefs(webId), ApplicationPortProfiles: simulatorTestRefs(httpsId, customId)},
+		&types.NsxtFirewallRule{Name: "app-out", ActionValue: "DROP", Enabled: true, IpProtocol: "IPV4", Direction: "OUT",
+			SourceFirewallGroups: simulatorTestRefs(appNetId)},
+	)
+
+	exported, err := stagingEgw.ExportFirewallPolicy()
+	if err != nil {
+		t.Fatalf("error exporting policy: %s", err)
+	}
+	if len(exported.FirewallGroups) != 2 || len(exported.ApplicationPortProfiles) != 1 || len(exported.Rules) != 2 {
+		t.Fatalf("unexpected exported document %+v", exported)
+	}
+	if exported.FirewallGroups[1].Members[0] != "app" || exported.Rules[0].ApplicationPortProfiles[0].Scope != types.ApplicationPortProfileScopeSystem {
+		t.Errorf("expected references by name, got %+v", exported)
+	}
+	// The document is portable as JSON
+	text, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("error marshalling document: %s", err)
+	}
+	if strings.Contains(string(text), "urn:") {
+		t.Errorf("expected no URN in the document, got %s", text)
+	}
+	doc := &FirewallPolicyDocument{}
+	err = json.Unmarshal(text, doc)
+	if err != nil {
+		t.Fatalf("error unmarshalling document: %s", err)
+	}
+	// and as YAML, with the same keys
+	yamlText, err := exported.ToYaml()
+	if err != nil {
+		t.Fatalf("error marshalling document to YAML: %s", err)
+	}
+	if !strings.Contains(string(yamlText), "firewallGroups:") || !strings.Contains(string(yamlText), "applicationPortProfiles:") {
+		t.Errorf("expected the JSON keys in the YAML document, got %s", yamlText)
+	}
+	yamlDoc, err := FirewallPolicyDocumentFromYaml(yamlText)
+	if err != nil {
+		t.Fatalf("error unmarshalling YAML document: %s", err)
+	}
+	if !reflect.DeepEqual(yamlDoc, doc) {
+		t.Errorf("expected the YAML document to match the JSON one\nYAML: %+v\nJSON: %+v", yamlDoc, doc)
+	}
+
+	// Production environment, where the IP Set differs and there are other rules
+	productionClient, productionEgw, _ := newFirewallPolicyTestEdgeGateway(t, "egw-production")
+	firewallPolicyTestGroup(t, productionEgw.client, &types.NsxtFirewallGroup{
+		Name: "web-servers", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.0.1"},
+		OwnerRef: &types.OpenApiReference{ID: productionEgw.EdgeGateway.ID},
+	})
+	productionRules := firewallPolicyTestEdgeRules(t, productionEgw,
+		&types.NsxtFirewallRule{Name: "app-out", ActionValue: "DROP", Enabled: true, IpProtocol: "IPV4", Direction: "OUT"},
+		&types.NsxtFirewallRule{Name: "legacy", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT"},
+	)
+	appOutId := productionRules[0].ID
+	_, rulesEtag, err := getNsxtFirewallRulesRaw(productionEgw.client, productionEgw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Dry run
+	result, err := productionEgw.ImportFirewallPolicy(doc, FirewallPolicyImportOptions{Mode: FirewallPolicyImportReplace, DryRun: true})
+	if err != nil {
+		t.Fatalf("error in dry run: %s", err)
+	}
+	expectedDiff := strings.Join([]string{
+		"~ NSX-T Firewall Group web-servers (ipAddresses)",
+		"+ NSX-T Firewall Group app-net",
+		"+ NSX-T Application Port Profile custom-8443",
+		"+ NSX-T Edge Gateway Firewall Rule allow-web",
+		"~ NSX-T Edge Gateway Firewall Rule app-out (sourceFirewallGroups)",
+		"- NSX-T Edge Gateway Firewall Rule legacy",
+	}, "\n")
+	if result.String() != expectedDiff || result.Applied {
+		t.Errorf("unexpected dry run result (applied %t):\n%s\nexpected:\n%s", result.Applied, result, expectedDiff)
+	}
+	groups, err := productionEgw.GetAllNsxtFirewallGroups(nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, etagAfterDryRun, err := getNsxtFirewallRulesRaw(productionEgw.client, productionEgw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etagAfterDryRun != rulesEtag || len(groups) != 1 {
+		t.Errorf("expected no change in a dry run")
+	}
+
+	// Import
+	result, err = productionEgw.ImportFirewallPolicy(doc, FirewallPolicyImportOptions{Mode: FirewallPolicyImportReplace})
+	if err != nil {
+		t.Fatalf("error importing policy: %s", err)
+	}
+	if result.String() != expectedDiff || !result.Applied {
+		t.Errorf("unexpected import result:\n%s", result)
+	}
+	firewall, err := productionEgw.GetNsxtFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := firewall.NsxtFirewallRuleContainer.UserDefinedRules
+	if firewallRuleTestNames(t, productionEgw) != "allow-web,app-out" || rules[1].ID != appOutId {
+		t.Fatalf("unexpected rules after import %+v", rules)
+	}
+	appNet, err := productionEgw.GetNsxtFirewallGroupByName("app-net", types.FirewallGroupTypeSecurityGroup)
+	if err != nil {
+		t.Fatalf("expected group app-net to be created: %s", err)
+	}
+	productionOrg, err := productionClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, err := productionOrg.GetNsxtAppPortProfileByName("custom-8443", types.ApplicationPortProfileScopeTenant)
+	if err != nil {
+		t.Fatalf("expected profile custom-8443 to be created: %s", err)
+	}
+	if custom.NsxtAppPortProfile.ContextEntityId != productionEgw.EdgeGateway.OwnerRef.ID ||
+		custom.NsxtAppPortProfile.OrgRef.ID != productionEgw.EdgeGateway.Org.ID {
+		t.Errorf("unexpected created profile %+v", custom.NsxtAppPortProfile)
+	}
+	if sources := rules[1].SourceFirewallGroups; len(sources) != 1 || sources[0].ID != appNet.NsxtFirewallGroup.ID {
+		t.Errorf("expected rule to reference the created group, got %+v", sources)
+	}
+	web, err := productionEgw.GetNsxtFirewallGroupByName("web-servers", types.FirewallGroupTypeIpSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(web.NsxtFirewallGroup.IpAddresses) != 2 {
+		t.Errorf("expected the IP Set to be updated, got %+v", web.NsxtFirewallGroup)
+	}
+
+	// The import is idempotent
+	_, rulesEtag, err = getNsxtFirewallRulesRaw(productionEgw.client, productionEgw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = productionEgw.ImportFirewallPolicy(doc, FirewallPolicyImportOptions{Mode: FirewallPolicyImportReplace})
+	if err != nil {
+		t.Fatalf("error importing policy again: %s", err)
+	}
+	_, etagAfterImport, err := getNsxtFirewallRulesRaw(productionEgw.client, productionEgw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsEmpty() || etagAfterImport != rulesEtag {
+		t.Errorf("expected no changes on second import, got:\n%s", result)
+	}
+
+	// Merge keeps the rules that are not in the document
+	firewallPolicyTestEdgeRules(t, productionEgw, append(rules,
+		&types.NsxtFirewallRule{Name: "legacy", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT"})...)
+	doc.Rules[1].ActionValue = "REJECT"
+	result, err = productionEgw.ImportFirewallPolicy(doc, FirewallPolicyImportOptions{Mode: FirewallPolicyImportMerge})
+	if err != nil {
+		t.Fatalf("error merging policy: %s", err)
+	}
+	if result.String() != "~ NSX-T Edge Gateway Firewall Rule app-out (actionValue)" {
+		t.Errorf("unexpected merge result:\n%s", result)
+	}
+	firewall, err = productionEgw.GetNsxtFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules = firewall.NsxtFirewallRuleContainer.UserDefinedRules
+	if firewallRuleTestNames(t, productionEgw) != "allow-web,app-out,legacy" || rules[1].ActionValue != "REJECT" || rules[1].ID != appOutId {
+		t.Errorf("unexpected rules after merge %+v", rules)
+	}
+}
+
+func TestVdcGroup_FirewallPolicyExportImport(t *testing.T) {
+	server, vcdClient := testServerClient(t)
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdcGroups := make(map[string]*VdcGroup)
+	for _, name := range []string{"source", "target"} {
+		id, err := server.AddVdcGroup("org1", name, "vdc1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vdcGroups[name], err = adminOrg.GetVdcGroupById(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	source, target := vdcGroups["source"], vdcGroups["target"]
+	dbId := firewallPolicyTestGroup(t, source.client, &types.NsxtFirewallGroup{
+		Name: "db", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.1.0.0/24"}, OwnerRef: &types.OpenApiReference{ID: source.VdcGroup.Id},
+	})
+	dns, err := GetNetworkContextProfilesByNameScopeAndContext(source.client, "DNS", types.ApplicationPortProfileScopeSystem, source.VdcGroup.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = source.UpdateDistributedFirewall(&types.DistributedFirewallRules{Values: []*types.DistributedFirewallRule{
+		{Name: "not-db", ActionValue: "DROP", Enabled: true, IpProtocol: "IPV4_IPV6", Direction: "IN_OUT", Comments: "keep db isolated",
+			SourceFirewallGroups: simulatorTestRefs(dbId), SourceGroupsExcluded: addrOf(true),
+			NetworkContextProfiles: []types.OpenApiReference{{ID: dns.ID, Name: dns.Name}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := source.ExportFirewallPolicy()
+	if err != nil {
+		t.Fatalf("error exporting policy: %s", err)
+	}
+	if doc.SourceType != FirewallPolicySourceVdcGroup || !doc.Rules[0].SourceGroupsExcluded || doc.Rules[0].NetworkContextProfiles[0] != "DNS" {
+		t.Fatalf("unexpected exported document %+v", doc)
+	}
+
+	// Distributed Firewall features can't be imported into an Edge Gateway
+	egw := testEdgeGateway(t, vcdClient, "egw")
+	_, err = egw.ImportFirewallPolicy(doc, FirewallPolicyImportOptions{Mode: FirewallPolicyImportReplace, DryRun: true})
+	if err == nil || !strings.Contains(err.Error(), "not-db") {
+		t.Errorf("expected an error for rules not supported by Edge Gateways, got %v", err)
+	}
+
+	result, err := target.ImportFirewallPolicy(doc, FirewallPolicyImportOptions{Mode: FirewallPolicyImportReplace})
+	if err != nil {
+		t.Fatalf("error importing policy: %s", err)
+	}
+	if len(result.Changes) != 2 {
+		t.Errorf("expected the creation of a group and a rule, got:\n%s", result)
+	}
+	created, err := target.GetNsxtFirewallGroupByName("db", types.FirewallGroupTypeIpSet)
+	if err != nil {
+		t.Fatalf("expected group db to be created in the target VDC Group: %s", err)
+	}
+	firewall, err := target.GetDistributedFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := firewall.DistributedFirewallRuleContainer.Values[0]
+	if rule.Comments != "keep db isolated" || rule.SourceGroupsExcluded == nil || !*rule.SourceGroupsExcluded ||
+		rule.SourceFirewallGroups[0].ID != created.NsxtFirewallGroup.ID || rule.NetworkContextProfiles[0].ID != dns.ID {
+		t.Errorf("unexpected imported rule %+v", rule)
+	}
+}
+
+func TestFirewallPolicyDocument_Validate(t *testing.T) {
+	validDocument := func() *FirewallPolicyDocument {
+		return &FirewallPolicyDocument{
+			Version:        FirewallPolicyDocumentVersion,
+			FirewallGroups: []FirewallPolicyGroup{{Name: "web", Type: types.FirewallGroupTypeIpSet}},
+			Rules: []FirewallPolicyRule{{Name: "web",
+				SourceFirewallGroups:    []FirewallPolicyGroupRef{{Name: "web", Type: types.FirewallGroupTypeIpSet}},
+				ApplicationPortProfiles: []FirewallPolicyProfileRef{{Name: "HTTP", Scope: types.ApplicationPortProfileScopeSystem}},
+			}},
+		}
+	}
+	if err := validDocument().validate(); err != nil {
+		t.Fatalf("unexpected error for a valid document: %s", err)
+	}
+
+	tests := map[string]func(doc *FirewallPolicyDocument){
+		"version":        func(doc *FirewallPolicyDocument) { doc.Version = 2 },
+		"group type":     func(doc *FirewallPolicyDocument) { doc.FirewallGroups[0].Type = "OTHER" },
+		"duplicate rule": func(doc *FirewallPolicyDocument) { doc.Rules = append(doc.Rules, doc.Rules[0]) },
+		"missing group": func(doc *FirewallPolicyDocument) {
+			doc.Rules[0].SourceFirewallGroups[0].Type = types.FirewallGroupTypeSecurityGroup
+		},
+		"missing profile": func(doc *FirewallPolicyDocument) {
+			doc.Rules[0].ApplicationPortProfiles[0].Scope = types.ApplicationPortProfileScopeTenant
+		},
+	}
+	for name, change := range tests {
+		doc := validDocument()
+		change(doc)
+		if err := doc.validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
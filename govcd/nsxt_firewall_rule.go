@@ -0,0 +1,365 @@
+package govcd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+const labelNsxtFirewallRule = "NSX-T Edge Gateway Firewall Rule"
+
+// NsxtFirewallRule is a single user defined firewall rule of an NSX-T Edge Gateway
+//
+// Changes made with NsxtFirewallRule and NsxtEdgeGateway.CreateNsxtFirewallRule use the ETag
+// returned by VCD for optimistic concurrency: when the rule (or, for creation and moves, the list
+// of rules) was changed by somebody else since it was retrieved, the change is rejected with an
+// error that matches ErrConflict. The rule must then be retrieved again before retrying.
+type NsxtFirewallRule struct {
+	Rule *types.NsxtFirewallRule
+	// Etag is the version of the rule returned by VCD. It is populated by
+	// NsxtEdgeGateway.GetNsxtFirewallRuleById and by all functions that change the rule
+	Etag string
+
+	client        *Client
+	edgeGatewayId string
+}
+
+// nsxtFirewallRulesRaw is a copy of types.NsxtFirewallRuleContainer which holds user defined rules
+// as json.RawMessage, so that rules are sent back unaltered, including fields unknown to the SDK
+type nsxtFirewallRulesRaw struct {
+	UserDefinedRules []json.RawMessage `json:"userDefinedRules"`
+}
+
+// CreateNsxtFirewallRule adds a user defined firewall rule above the rule with ID aboveRuleId or,
+// when aboveRuleId is empty, at the bottom of the user defined rules.
+//
+// There is no endpoint to create a single rule, therefore the whole list of user defined rules is
+// sent. The list is sent with the ETag of the retrieved list, so that the creation fails with
+// ErrConflict instead of overwriting rules changed concurrently by other editors. It returns an
+// error when VCD does not return the ETag of the list: use CreateNsxtFirewallRuleUnconditionally to
+// create the rule regardless of concurrent changes
+func (egw *NsxtEdgeGateway) CreateNsxtFirewallRule(aboveRuleId string, rule *types.NsxtFirewallRule) (*NsxtFirewallRule, error) {
+	return egw.createNsxtFirewallRule(aboveRuleId, rule, true)
+}
+
+// CreateNsxtFirewallRuleUnconditionally adds a user defined firewall rule like
+// CreateNsxtFirewallRule, but sends the list of rules without ETag, overwriting any change made
+// to the rules after they were retrieved
+func (egw *NsxtEdgeGateway) CreateNsxtFirewallRuleUnconditionally(aboveRuleId string, rule *types.NsxtFirewallRule) (*NsxtFirewallRule, error) {
+	return egw.createNsxtFirewallRule(aboveRuleId, rule, false)
+}
+
+func (egw *NsxtEdgeGateway) createNsxtFirewallRule(aboveRuleId string, rule *types.NsxtFirewallRule, conditional bool) (*NsxtFirewallRule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("%s cannot be empty", labelNsxtFirewallRule)
+	}
+	rawRules, etag, err := getNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID)
+	if err != nil {
+		return nil, err
+	}
+	etag, err = nsxtFirewallRulesEtag(etag, conditional)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s '%s': %w, use CreateNsxtFirewallRuleUnconditionally", labelNsxtFirewallRule, rule.Name, err)
+	}
+	newRuleJson, err := json.Marshal(rule)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %s: %w", labelNsxtFirewallRule, err)
+	}
+
+	position := len(rawRules)
+	if aboveRuleId != "" {
+		position, err = getNsxtFirewallRuleIndexById(rawRules, aboveRuleId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rawRules = insertRawFirewallRule(rawRules, position, newRuleJson)
+
+	err = putNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID, rawRules, etag)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s '%s': %w", labelNsxtFirewallRule, rule.Name, err)
+	}
+
+	// The new rule is the one at the same position in the updated list
+	updatedRules, _, err := getNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID)
+	if err != nil {
+		return nil, err
+	}
+	if position >= len(updatedRules) {
+		return nil, fmt.Errorf("%s '%s' not found at position %d after creation", labelNsxtFirewallRule, rule.Name, position)
+	}
+	created, err := decodeRawFirewallRule(updatedRules[position])
+	if err != nil {
+		return nil, err
+	}
+	if created.Name != rule.Name {
+		return nil, fmt.Errorf("expected %s '%s' at position %d after creation, found '%s'", labelNsxtFirewallRule,
+			rule.Name, position, created.Name)
+	}
+	return egw.GetNsxtFirewallRuleById(created.ID)
+}
+
+// GetNsxtFirewallRuleById retrieves a user defined firewall rule of the Edge Gateway by ID
+func (egw *NsxtEdgeGateway) GetNsxtFirewallRuleById(id string) (*NsxtFirewallRule, error) {
+	return getNsxtFirewallRuleById(egw.client, egw.EdgeGateway.ID, id)
+}
+
+// GetNsxtFirewallRuleByName retrieves a user defined firewall rule of the Edge Gateway by name. It
+// returns an error if more than one rule has the given name, as names are not unique
+func (egw *NsxtEdgeGateway) GetNsxtFirewallRuleByName(name string) (*NsxtFirewallRule, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name must be specified")
+	}
+	firewall, err := egw.GetNsxtFirewall()
+	if err != nil {
+		return nil, err
+	}
+	rule, err := localFilterOneOrError(labelNsxtFirewallRule, firewall.NsxtFirewallRuleContainer.UserDefinedRules, "Name", name)
+	if err != nil {
+		return nil, err
+	}
+	return egw.GetNsxtFirewallRuleById(rule.ID)
+}
+
+// Update changes the firewall rule. The ETag of the rule is sent, so that the update fails with
+// ErrConflict if the rule was changed after it was retrieved. It returns an error when the ETag is
+// unknown: use UpdateUnconditionally to overwrite the rule regardless of concurrent changes. The
+// position of the rule does not change: use Move for that
+func (fwRule *NsxtFirewallRule) Update(rule *types.NsxtFirewallRule) (*NsxtFirewallRule, error) {
+	if fwRule.Etag == "" {
+		return nil, fmt.Errorf("cannot update %s '%s' without ETag, retrieve it again or use UpdateUnconditionally",
+			labelNsxtFirewallRule, fwRule.Rule.Name)
+	}
+	return fwRule.update(rule, fwRule.Etag)
+}
+
+// UpdateUnconditionally changes the firewall rule without sending its ETag, overwriting any change
+// made after the rule was retrieved
+func (fwRule *NsxtFirewallRule) UpdateUnconditionally(rule *types.NsxtFirewallRule) (*NsxtFirewallRule, error) {
+	return fwRule.update(rule, "")
+}
+
+func (fwRule *NsxtFirewallRule) update(rule *types.NsxtFirewallRule, etag string) (*NsxtFirewallRule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("%s cannot be empty", labelNsxtFirewallRule)
+	}
+	rule.ID = fwRule.Rule.ID
+	if rule.Version == nil {
+		rule.Version = fwRule.Rule.Version
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtFirewallRules
+	minimumApiVersion, err := fwRule.client.checkOpenApiEndpointCompatibility(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	urlRef, err := fwRule.client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, fwRule.edgeGatewayId), "/", fwRule.Rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = fwRule.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, rule, &types.NsxtFirewallRule{}, etagHeader(etag))
+	if err != nil {
+		return nil, fmt.Errorf("error updating %s '%s': %w", labelNsxtFirewallRule, fwRule.Rule.Name, err)
+	}
+	return getNsxtFirewallRuleById(fwRule.client, fwRule.edgeGatewayId, fwRule.Rule.ID)
+}
+
+// Move places the firewall rule above the rule with ID aboveRuleId or, when aboveRuleId is empty,
+// at the bottom of the user defined rules. As with NsxtEdgeGateway.CreateNsxtFirewallRule, the
+// whole list of rules is sent with its ETag, and the move fails with ErrConflict if the list was
+// changed concurrently. It returns an error when VCD does not return the ETag of the list: use
+// MoveUnconditionally to move the rule regardless of concurrent changes
+func (fwRule *NsxtFirewallRule) Move(aboveRuleId string) (*NsxtFirewallRule, error) {
+	return fwRule.move(aboveRuleId, true)
+}
+
+// MoveUnconditionally places the firewall rule like Move, but sends the list of rules without ETag,
+// overwriting any change made to the rules after they were retrieved
+func (fwRule *NsxtFirewallRule) MoveUnconditionally(aboveRuleId string) (*NsxtFirewallRule, error) {
+	return fwRule.move(aboveRuleId, false)
+}
+
+func (fwRule *NsxtFirewallRule) move(aboveRuleId string, conditional bool) (*NsxtFirewallRule, error) {
+	if aboveRuleId == fwRule.Rule.ID {
+		return nil, fmt.Errorf("%s '%s' cannot be moved above itself", labelNsxtFirewallRule, fwRule.Rule.Name)
+	}
+	rawRules, etag, err := getNsxtFirewallRulesRaw(fwRule.client, fwRule.edgeGatewayId)
+	if err != nil {
+		return nil, err
+	}
+	etag, err = nsxtFirewallRulesEtag(etag, conditional)
+	if err != nil {
+		return nil, fmt.Errorf("cannot move %s '%s': %w, use MoveUnconditionally", labelNsxtFirewallRule, fwRule.Rule.Name, err)
+	}
+	currentPosition, err := getNsxtFirewallRuleIndexById(rawRules, fwRule.Rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	ruleJson := rawRules[currentPosition]
+	rawRules = append(rawRules[:currentPosition:currentPosition], rawRules[currentPosition+1:]...)
+
+	position := len(rawRules)
+	if aboveRuleId != "" {
+		position, err = getNsxtFirewallRuleIndexById(rawRules, aboveRuleId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if position == currentPosition {
+		util.Logger.Printf("[DEBUG] %s '%s' is already at position %d", labelNsxtFirewallRule, fwRule.Rule.Name, position)
+		return getNsxtFirewallRuleById(fwRule.client, fwRule.edgeGatewayId, fwRule.Rule.ID)
+	}
+	rawRules = insertRawFirewallRule(rawRules, position, ruleJson)
+
+	err = putNsxtFirewallRulesRaw(fwRule.client, fwRule.edgeGatewayId, rawRules, etag)
+	if err != nil {
+		return nil, fmt.Errorf("error moving %s '%s': %w", labelNsxtFirewallRule, fwRule.Rule.Name, err)
+	}
+	return getNsxtFirewallRuleById(fwRule.client, fwRule.edgeGatewayId, fwRule.Rule.ID)
+}
+
+// Delete removes the firewall rule. The ETag of the rule is sent, so that the deletion fails with
+// ErrConflict if the rule was changed after it was retrieved. It returns an error when the ETag is
+// unknown: use DeleteUnconditionally to remove the rule regardless of concurrent changes
+func (fwRule *NsxtFirewallRule) Delete() error {
+	if fwRule.Etag == "" {
+		return fmt.Errorf("cannot delete %s '%s' without ETag, retrieve it again or use DeleteUnconditionally",
+			labelNsxtFirewallRule, fwRule.Rule.Name)
+	}
+	return fwRule.delete(fwRule.Etag)
+}
+
+// DeleteUnconditionally removes the firewall rule without sending its ETag, even if it was changed
+// after it was retrieved
+func (fwRule *NsxtFirewallRule) DeleteUnconditionally() error {
+	return fwRule.delete("")
+}
+
+func (fwRule *NsxtFirewallRule) delete(etag string) error {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtFirewallRules
+	minimumApiVersion, err := fwRule.client.checkOpenApiEndpointCompatibility(endpoint)
+	if err != nil {
+		return err
+	}
+	urlRef, err := fwRule.client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, fwRule.edgeGatewayId), "/", fwRule.Rule.ID)
+	if err != nil {
+		return err
+	}
+	err = fwRule.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, etagHeader(etag))
+	if err != nil {
+		return fmt.Errorf("error deleting %s '%s': %w", labelNsxtFirewallRule, fwRule.Rule.Name, err)
+	}
+	return nil
+}
+
+func getNsxtFirewallRuleById(client *Client, edgeGatewayId, id string) (*NsxtFirewallRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty ID specified")
+	}
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtFirewallRules
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, edgeGatewayId), "/", id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NsxtFirewallRule{Rule: &types.NsxtFirewallRule{}, client: client, edgeGatewayId: edgeGatewayId}
+	headers, err := client.OpenApiGetItemAndHeaders(minimumApiVersion, urlRef, nil, result.Rule, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s with ID '%s': %w", labelNsxtFirewallRule, id, err)
+	}
+	result.Etag = headers.Get("Etag")
+	return result, nil
+}
+
+// getNsxtFirewallRulesRaw retrieves the user defined rules of an Edge Gateway, unaltered, and the
+// ETag of the list
+func getNsxtFirewallRulesRaw(client *Client, edgeGatewayId string) ([]json.RawMessage, string, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtFirewallRules
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, edgeGatewayId))
+	if err != nil {
+		return nil, "", err
+	}
+	rawRules := &nsxtFirewallRulesRaw{}
+	headers, err := client.OpenApiGetItemAndHeaders(minimumApiVersion, urlRef, nil, rawRules, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error retrieving NSX-T Firewall rules: %w", err)
+	}
+	return rawRules.UserDefinedRules, headers.Get("Etag"), nil
+}
+
+// putNsxtFirewallRulesRaw replaces the user defined rules of an Edge Gateway. When etag is not
+// empty, the request fails with ErrConflict if the rules changed since the ETag was retrieved
+func putNsxtFirewallRulesRaw(client *Client, edgeGatewayId string, rawRules []json.RawMessage, etag string) error {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtFirewallRules
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
+	if err != nil {
+		return err
+	}
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, edgeGatewayId))
+	if err != nil {
+		return err
+	}
+	return client.OpenApiPutItem(minimumApiVersion, urlRef, nil, &nsxtFirewallRulesRaw{UserDefinedRules: rawRules}, &nsxtFirewallRulesRaw{}, etagHeader(etag))
+}
+
+// nsxtFirewallRulesEtag returns the ETag to send when replacing the list of rules. Conditional
+// changes need the ETag of the retrieved list, while unconditional ones are sent without ETag
+func nsxtFirewallRulesEtag(etag string, conditional bool) (string, error) {
+	if !conditional {
+		return "", nil
+	}
+	if etag == "" {
+		return "", fmt.Errorf("no ETag was returned for the list of rules")
+	}
+	return etag, nil
+}
+
+// getNsxtFirewallRuleIndexById returns the position of the rule with the given ID
+func getNsxtFirewallRuleIndexById(rawRules []json.RawMessage, id string) (int, error) {
+	for index, rawRule := range rawRules {
+		rule, err := decodeRawFirewallRule(rawRule)
+		if err != nil {
+			return 0, err
+		}
+		if rule.ID == id {
+			return index, nil
+		}
+	}
+	return 0, fmt.Errorf("%s with ID '%s' not found: %w", labelNsxtFirewallRule, id, ErrorEntityNotFound)
+}
+
+func decodeRawFirewallRule(rawRule json.RawMessage) (*types.NsxtFirewallRule, error) {
+	rule := &types.NsxtFirewallRule{}
+	err := json.Unmarshal(rawRule, rule)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", labelNsxtFirewallRule, err)
+	}
+	return rule, nil
+}
+
+// insertRawFirewallRule returns a new slice with rawRule inserted at position
+func insertRawFirewallRule(rawRules []json.RawMessage, position int, rawRule json.RawMessage) []json.RawMessage {
+	result := make([]json.RawMessage, 0, len(rawRules)+1)
+	result = append(result, rawRules[:position]...)
+	result = append(result, rawRule)
+	return append(result, rawRules[position:]...)
+}
+
+// etagHeader returns the If-Match header for the given ETag, or nil when it is empty, which makes
+// the request unconditional
+func etagHeader(etag string) map[string]string {
+	if etag == "" {
+		return nil
+	}
+	return map[string]string{"If-Match": etag}
+}
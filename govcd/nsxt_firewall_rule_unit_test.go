@@ -0,0 +1,235 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// newFirewallRuleTestEdgeGateway returns an Edge Gateway of a govcdtest server with user defined
+// rules that have the given names. Each rule has a field 'comments' that is unknown to the SDK
+func newFirewallRuleTestEdgeGateway(t *testing.T, names ...string) (*govcdtest.Server, *NsxtEdgeGateway) {
+	server, vcdClient := testServerClient(t)
+	egw := testEdgeGateway(t, vcdClient, "edge1")
+	var rawRules []json.RawMessage
+	for _, name := range names {
+		rawRules = append(rawRules, json.RawMessage(fmt.Sprintf(
+			`{"name":%q,"actionValue":"ALLOW","enabled":true,"ipProtocol":"IPV4","direction":"IN_OUT","comments":"comment of %s"}`, name, name)))
+	}
+	if err := putNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID, rawRules, ""); err != nil {
+		t.Fatalf("error adding rules: %s", err)
+	}
+	return server, egw
+}
+
+// firewallRuleTestNames returns the names of the user defined rules of the Edge Gateway, in order
+func firewallRuleTestNames(t *testing.T, egw *NsxtEdgeGateway) string {
+	t.Helper()
+	firewall, err := egw.GetNsxtFirewall()
+	if err != nil {
+		t.Fatalf("error retrieving rules: %s", err)
+	}
+	var names []string
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		names = append(names, rule.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestNsxtEdgeGateway_FirewallRules(t *testing.T) {
+	_, egw := newFirewallRuleTestEdgeGateway(t, "web", "db")
+
+	newRule := func(name string) *types.NsxtFirewallRule {
+		return &types.NsxtFirewallRule{Name: name, ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT"}
+	}
+	db, err := egw.GetNsxtFirewallRuleByName("db")
+	if err != nil {
+		t.Fatalf("error retrieving rule by name: %s", err)
+	}
+
+	// Create at the bottom and above an existing rule
+	ssh, err := egw.CreateNsxtFirewallRule("", newRule("ssh"))
+	if err != nil {
+		t.Fatalf("error creating rule: %s", err)
+	}
+	if ssh.Rule.Name != "ssh" || ssh.Rule.ID == "" || ssh.Etag == "" {
+		t.Errorf("expected rule ssh with ID and ETag, got %#v and '%s'", ssh.Rule, ssh.Etag)
+	}
+	_, err = egw.CreateNsxtFirewallRule(db.Rule.ID, newRule("dns"))
+	if err != nil {
+		t.Fatalf("error creating rule above another one: %s", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "web,dns,db,ssh" {
+		t.Errorf("unexpected order of rules %s", names)
+	}
+	rawRules, _, err := getNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstRule := map[string]any{}
+	if err = json.Unmarshal(rawRules[0], &firstRule); err != nil {
+		t.Fatal(err)
+	}
+	if firstRule["comments"] != "comment of web" {
+		t.Errorf("expected unknown fields of existing rules to be preserved, got %s", rawRules[0])
+	}
+	_, err = egw.CreateNsxtFirewallRule("missing", newRule("ntp"))
+	if !errors.Is(err, ErrorEntityNotFound) {
+		t.Errorf("expected a not found error for an unknown above rule, got %v", err)
+	}
+
+	// Retrieve
+	web, err := egw.GetNsxtFirewallRuleByName("web")
+	if err != nil {
+		t.Fatalf("error retrieving rule by name: %s", err)
+	}
+	if web.Etag == "" {
+		t.Errorf("expected rule web to have an ETag")
+	}
+	_, err = egw.GetNsxtFirewallRuleByName("missing")
+	if !ContainsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	// Update, then a concurrent update with the stale ETag is rejected
+	staleWeb := *web
+	updated, err := web.Update(newRule("web-https"))
+	if err != nil {
+		t.Fatalf("error updating rule: %s", err)
+	}
+	if updated.Rule.Name != "web-https" || updated.Etag == "" || updated.Etag == web.Etag {
+		t.Errorf("unexpected updated rule %s with ETag %s", updated.Rule.Name, updated.Etag)
+	}
+	_, err = staleWeb.Update(newRule("web-http"))
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict updating with a stale ETag, got %v", err)
+	}
+	err = staleWeb.Delete()
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict deleting with a stale ETag, got %v", err)
+	}
+
+	// Without ETag, only the explicit unconditional changes are accepted
+	noEtag := *updated
+	noEtag.Etag = ""
+	_, err = noEtag.Update(newRule("web-http"))
+	if err == nil || !strings.Contains(err.Error(), "UpdateUnconditionally") {
+		t.Errorf("expected an error updating without ETag, got %v", err)
+	}
+	err = noEtag.Delete()
+	if err == nil || !strings.Contains(err.Error(), "DeleteUnconditionally") {
+		t.Errorf("expected an error deleting without ETag, got %v", err)
+	}
+	unconditional, err := staleWeb.UpdateUnconditionally(newRule("web-https"))
+	if err != nil {
+		t.Fatalf("error updating rule unconditionally: %s", err)
+	}
+	if unconditional.Etag == updated.Etag {
+		t.Errorf("expected the unconditional update to ignore the stale ETag and change the rule")
+	}
+
+	// Move to the bottom and above another rule
+	_, err = unconditional.Move("")
+	if err != nil {
+		t.Fatalf("error moving rule: %s", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "dns,db,ssh,web-https" {
+		t.Errorf("unexpected order of rules after move %s", names)
+	}
+	dns, err := egw.GetNsxtFirewallRuleByName("dns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ssh.Move(dns.Rule.ID)
+	if err != nil {
+		t.Fatalf("error moving rule: %s", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "ssh,dns,db,web-https" {
+		t.Errorf("unexpected order of rules after move %s", names)
+	}
+
+	// Delete
+	db, err = egw.GetNsxtFirewallRuleById(db.Rule.ID)
+	if err != nil {
+		t.Fatalf("error retrieving rule by ID: %s", err)
+	}
+	err = db.Delete()
+	if err != nil {
+		t.Fatalf("error deleting rule: %s", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "ssh,dns,web-https" {
+		t.Errorf("unexpected rules after deletion %s", names)
+	}
+	ssh.Etag = ""
+	err = ssh.DeleteUnconditionally()
+	if err != nil {
+		t.Fatalf("error deleting rule unconditionally: %s", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "dns,web-https" {
+		t.Errorf("unexpected rules after unconditional deletion %s", names)
+	}
+}
+
+func TestNsxtEdgeGateway_CreateNsxtFirewallRuleConflict(t *testing.T) {
+	_, egw := newFirewallRuleTestEdgeGateway(t, "web")
+
+	rawRules, etag, err := getNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID)
+	if err != nil {
+		t.Fatalf("error retrieving rules: %s", err)
+	}
+	// Another editor adds a rule after the rules were retrieved
+	_, err = egw.CreateNsxtFirewallRule("", &types.NsxtFirewallRule{Name: "other", ActionValue: "DROP", IpProtocol: "IPV4", Direction: "IN_OUT"})
+	if err != nil {
+		t.Fatalf("error creating rule: %s", err)
+	}
+
+	err = putNsxtFirewallRulesRaw(egw.client, egw.EdgeGateway.ID, rawRules, etag)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict replacing rules with a stale ETag, got %v", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "web,other" {
+		t.Errorf("expected the rules of the other editor to be kept, got %s", names)
+	}
+}
+
+func TestNsxtEdgeGateway_FirewallRulesWithoutListEtag(t *testing.T) {
+	server, egw := newFirewallRuleTestEdgeGateway(t, "web", "db")
+	web, err := egw.GetNsxtFirewallRuleByName("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.OmitEtags(true)
+	ssh := &types.NsxtFirewallRule{Name: "ssh", ActionValue: "ALLOW", IpProtocol: "IPV4", Direction: "IN_OUT"}
+
+	// Changes of the list of rules are not sent without the ETag of the list, unless requested
+	_, err = egw.CreateNsxtFirewallRule("", ssh)
+	if err == nil || !strings.Contains(err.Error(), "CreateNsxtFirewallRuleUnconditionally") {
+		t.Errorf("expected an error creating a rule without ETag, got %v", err)
+	}
+	_, err = web.Move("")
+	if err == nil || !strings.Contains(err.Error(), "MoveUnconditionally") {
+		t.Errorf("expected an error moving a rule without ETag, got %v", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "web,db" {
+		t.Errorf("expected the rules not to change, got %s", names)
+	}
+
+	_, err = egw.CreateNsxtFirewallRuleUnconditionally(web.Rule.ID, ssh)
+	if err != nil {
+		t.Fatalf("error creating rule unconditionally: %s", err)
+	}
+	_, err = web.MoveUnconditionally("")
+	if err != nil {
+		t.Fatalf("error moving rule unconditionally: %s", err)
+	}
+	if names := firewallRuleTestNames(t, egw); names != "ssh,db,web" {
+		t.Errorf("unexpected rules after unconditional changes %s", names)
+	}
+}
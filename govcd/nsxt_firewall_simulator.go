@@ -0,0 +1,422 @@
+package govcd
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// FirewallVerdictNoMatch is the verdict of a firewall layer when no rule matches the flow
+const FirewallVerdictNoMatch = "NO_MATCH"
+
+// FirewallSnapshot contains the firewall rules of an NSX-T Edge Gateway and of the Distributed
+// Firewall of a VDC Group, together with the Firewall Groups and Application Port Profiles they use.
+// It is created by LoadFirewallSnapshot, or built by hand, and evaluated offline with Evaluate. All
+// fields can be stored as JSON
+type FirewallSnapshot struct {
+	// EdgeGatewayRules is nil when the snapshot has no Edge Gateway. Rules are evaluated in the
+	// order system, user defined and default rules
+	EdgeGatewayRules *types.NsxtFirewallRuleContainer `json:"edgeGatewayRules,omitempty"`
+	// DistributedFirewallRules is nil when the snapshot has no VDC Group
+	DistributedFirewallRules *types.DistributedFirewallRules `json:"distributedFirewallRules,omitempty"`
+	// FirewallGroups contains the IP Sets and Security Groups used by the rules, by ID
+	FirewallGroups map[string]*types.NsxtFirewallGroup `json:"firewallGroups"`
+	// GroupVms contains the IDs of the VMs that are members of static and dynamic Security Groups, by
+	// group ID
+	GroupVms map[string][]string `json:"groupVms"`
+	// ApplicationPortProfiles contains the Application Port Profiles used by the rules, by ID
+	ApplicationPortProfiles map[string]*types.NsxtAppPortProfile `json:"applicationPortProfiles"`
+}
+
+// FirewallFlowEndpoint is the source or the destination of a FirewallFlow. IpAddress is used to
+// match IP Sets and VmId to match Security Groups, so both should be set for VMs
+type FirewallFlowEndpoint struct {
+	VmId      string
+	IpAddress string
+}
+
+// FirewallFlow is the traffic evaluated by FirewallSnapshot.Evaluate
+type FirewallFlow struct {
+	Source      FirewallFlowEndpoint
+	Destination FirewallFlowEndpoint
+	// Protocol is one of TCP, UDP, ICMPv4 or ICMPv6
+	Protocol string
+	// Port is the destination port for TCP and UDP
+	Port int
+	// Direction is IN or OUT. When empty, rules of all directions can match
+	Direction string
+}
+
+// FirewallRuleMatch is a rule that matches a FirewallFlow
+type FirewallRuleMatch struct {
+	RuleId   string
+	RuleName string
+	// Action is one of ALLOW, DROP or REJECT
+	Action string
+	// Conditional is true when the rule also requires Network Context Profiles, which can't be
+	// evaluated offline. Conditional matches never decide the verdict
+	Conditional bool
+	// Decisive is true for the rule that decides the verdict. The rules after it are shadowed
+	Decisive bool
+}
+
+// FirewallLayerResult is the evaluation of a FirewallFlow by the Edge Gateway or by the Distributed
+// Firewall
+type FirewallLayerResult struct {
+	// Matches contains all enabled rules that match the flow, in evaluation order
+	Matches []FirewallRuleMatch
+	// Verdict is the action of the first rule that matches unconditionally, or
+	// FirewallVerdictNoMatch
+	Verdict string
+}
+
+// FirewallFlowResult is the result of FirewallSnapshot.Evaluate. A layer is nil when the snapshot
+// has no rules for it
+type FirewallFlowResult struct {
+	EdgeGateway         *FirewallLayerResult
+	DistributedFirewall *FirewallLayerResult
+}
+
+// Allowed returns true when all evaluated layers allow the flow
+func (result *FirewallFlowResult) Allowed() bool {
+	for _, layer := range []*FirewallLayerResult{result.EdgeGateway, result.DistributedFirewall} {
+		if layer != nil && layer.Verdict != "ALLOW" {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadFirewallSnapshot retrieves the firewall rules of the Edge Gateway and of the Distributed
+// Firewall of the VDC Group, the Firewall Groups they use with the VMs of Security Groups, and the
+// Application Port Profiles they use. Either egw or vdcGroup can be nil
+func LoadFirewallSnapshot(egw *NsxtEdgeGateway, vdcGroup *VdcGroup) (*FirewallSnapshot, error) {
+	if egw == nil && vdcGroup == nil {
+		return nil, fmt.Errorf("firewall snapshot requires an Edge Gateway or a VDC Group")
+	}
+	snapshot := &FirewallSnapshot{
+		FirewallGroups:          make(map[string]*types.NsxtFirewallGroup),
+		GroupVms:                make(map[string][]string),
+		ApplicationPortProfiles: make(map[string]*types.NsxtAppPortProfile),
+	}
+
+	var client *Client
+	var groupRefs, profileRefs []types.OpenApiReference
+	if egw != nil {
+		client = egw.client
+		firewall, err := egw.GetNsxtFirewall()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.EdgeGatewayRules = firewall.NsxtFirewallRuleContainer
+		for _, rule := range snapshot.edgeGatewayRules() {
+			groupRefs = append(append(groupRefs, rule.SourceFirewallGroups...), rule.DestinationFirewallGroups...)
+			profileRefs = append(profileRefs, rule.ApplicationPortProfiles...)
+		}
+	}
+	if vdcGroup != nil {
+		client = vdcGroup.client
+		firewall, err := vdcGroup.GetDistributedFirewall()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.DistributedFirewallRules = firewall.DistributedFirewallRuleContainer
+		for _, rule := range snapshot.DistributedFirewallRules.Values {
+			groupRefs = append(append(groupRefs, rule.SourceFirewallGroups...), rule.DestinationFirewallGroups...)
+			profileRefs = append(profileRefs, rule.ApplicationPortProfiles...)
+		}
+	}
+
+	for _, reference := range groupRefs {
+		if _, found := snapshot.FirewallGroups[reference.ID]; found {
+			continue
+		}
+		fwGroup, err := getNsxtFirewallGroupById(client, reference.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving %s '%s': %w", labelNsxtFirewallGroup, reference.ID, err)
+		}
+		snapshot.FirewallGroups[reference.ID] = fwGroup.NsxtFirewallGroup
+		if firewallGroupType(fwGroup.NsxtFirewallGroup) == types.FirewallGroupTypeIpSet {
+			continue
+		}
+		vms, err := fwGroup.GetAssociatedVms()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VMs of %s '%s': %w", labelNsxtFirewallGroup, fwGroup.NsxtFirewallGroup.Name, err)
+		}
+		vmIds := []string{}
+		for _, vm := range vms {
+			if vm.VmRef != nil {
+				vmIds = append(vmIds, vm.VmRef.ID)
+			}
+		}
+		snapshot.GroupVms[reference.ID] = vmIds
+	}
+
+	for _, reference := range profileRefs {
+		if _, found := snapshot.ApplicationPortProfiles[reference.ID]; found {
+			continue
+		}
+		profile, err := getNsxtAppPortProfileById(client, reference.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving %s '%s': %w", labelNsxtAppPortProfile, reference.ID, err)
+		}
+		snapshot.ApplicationPortProfiles[reference.ID] = profile.NsxtAppPortProfile
+	}
+	return snapshot, nil
+}
+
+// Evaluate returns the rules of the snapshot that match the flow and the verdict of each firewall
+// layer. It doesn't make any API call
+func (snapshot *FirewallSnapshot) Evaluate(flow FirewallFlow) (*FirewallFlowResult, error) {
+	evaluation, err := newFirewallFlowEvaluation(snapshot, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FirewallFlowResult{}
+	if snapshot.EdgeGatewayRules != nil {
+		var rules []*types.DistributedFirewallRule
+		for _, rule := range snapshot.edgeGatewayRules() {
+			rules = append(rules, edgeFirewallRuleToDistributed(rule))
+		}
+		result.EdgeGateway, err = evaluation.evaluateLayer(rules)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating %s: %w", labelNsxtFirewallRule, err)
+		}
+	}
+	if snapshot.DistributedFirewallRules != nil {
+		result.DistributedFirewall, err = evaluation.evaluateLayer(snapshot.DistributedFirewallRules.Values)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating %s: %w", labelDistributedFirewallRule, err)
+		}
+	}
+	return result, nil
+}
+
+// edgeGatewayRules returns the rules of the Edge Gateway in evaluation order
+func (snapshot *FirewallSnapshot) edgeGatewayRules() []*types.NsxtFirewallRule {
+	if snapshot.EdgeGatewayRules == nil {
+		return nil
+	}
+	var rules []*types.NsxtFirewallRule
+	rules = append(rules, snapshot.EdgeGatewayRules.SystemRules...)
+	rules = append(rules, snapshot.EdgeGatewayRules.UserDefinedRules...)
+	return append(rules, snapshot.EdgeGatewayRules.DefaultRules...)
+}
+
+// firewallFlowEvaluation contains a validated flow
+type firewallFlowEvaluation struct {
+	snapshot    *FirewallSnapshot
+	flow        FirewallFlow
+	source      netip.Addr
+	destination netip.Addr
+}
+
+func newFirewallFlowEvaluation(snapshot *FirewallSnapshot, flow FirewallFlow) (*firewallFlowEvaluation, error) {
+	evaluation := &firewallFlowEvaluation{snapshot: snapshot, flow: flow}
+	var err error
+	if flow.Source.IpAddress != "" {
+		evaluation.source, err = netip.ParseAddr(flow.Source.IpAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source IP address: %w", err)
+		}
+	}
+	if flow.Destination.IpAddress != "" {
+		evaluation.destination, err = netip.ParseAddr(flow.Destination.IpAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination IP address: %w", err)
+		}
+	}
+	if evaluation.source.IsValid() && evaluation.destination.IsValid() && evaluation.source.Is4() != evaluation.destination.Is4() {
+		return nil, fmt.Errorf("source and destination IP addresses are of different families")
+	}
+	switch {
+	case strings.EqualFold(flow.Protocol, "TCP"), strings.EqualFold(flow.Protocol, "UDP"):
+		if flow.Port < 1 || flow.Port > 65535 {
+			return nil, fmt.Errorf("invalid port %d for protocol %s", flow.Port, flow.Protocol)
+		}
+	case strings.EqualFold(flow.Protocol, "ICMPv4"), strings.EqualFold(flow.Protocol, "ICMPv6"):
+	default:
+		return nil, fmt.Errorf("invalid protocol '%s': must be one of TCP, UDP, ICMPv4 or ICMPv6", flow.Protocol)
+	}
+	if flow.Direction != "" && flow.Direction != "IN" && flow.Direction != "OUT" {
+		return nil, fmt.Errorf("invalid direction '%s': must be IN, OUT or empty", flow.Direction)
+	}
+	return evaluation, nil
+}
+
+func (evaluation *firewallFlowEvaluation) evaluateLayer(rules []*types.DistributedFirewallRule) (*FirewallLayerResult, error) {
+	result := &FirewallLayerResult{Verdict: FirewallVerdictNoMatch}
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		matched, err := evaluation.matchRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': %w", rule.Name, err)
+		}
+		if !matched {
+			continue
+		}
+		match := FirewallRuleMatch{
+			RuleId:      rule.ID,
+			RuleName:    rule.Name,
+			Action:      rule.ActionValue,
+			Conditional: len(rule.NetworkContextProfiles) > 0,
+		}
+		if match.Action == "" {
+			match.Action = rule.Action
+		}
+		if !match.Conditional && result.Verdict == FirewallVerdictNoMatch {
+			match.Decisive = true
+			result.Verdict = match.Action
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	return result, nil
+}
+
+// matchRule checks all the criteria of the rule except Network Context Profiles
+func (evaluation *firewallFlowEvaluation) matchRule(rule *types.DistributedFirewallRule) (bool, error) {
+	flow := evaluation.flow
+	if rule.Direction != "" && rule.Direction != "IN_OUT" && flow.Direction != "" && rule.Direction != flow.Direction {
+		return false, nil
+	}
+	address := evaluation.source
+	if !address.IsValid() {
+		address = evaluation.destination
+	}
+	if address.IsValid() && ((rule.IpProtocol == "IPV4" && !address.Is4()) || (rule.IpProtocol == "IPV6" && address.Is4())) {
+		return false, nil
+	}
+
+	matched, err := evaluation.matchGroups(rule.SourceFirewallGroups, boolValue(rule.SourceGroupsExcluded), flow.Source, evaluation.source)
+	if err != nil || !matched {
+		return false, err
+	}
+	matched, err = evaluation.matchGroups(rule.DestinationFirewallGroups, boolValue(rule.DestinationGroupsExcluded), flow.Destination, evaluation.destination)
+	if err != nil || !matched {
+		return false, err
+	}
+	return evaluation.matchProfiles(rule.ApplicationPortProfiles)
+}
+
+// matchGroups checks if the endpoint is a member of one of the groups. No groups means 'Any'
+func (evaluation *firewallFlowEvaluation) matchGroups(references []types.OpenApiReference, excluded bool, endpoint FirewallFlowEndpoint, address netip.Addr) (bool, error) {
+	if len(references) == 0 {
+		return true, nil
+	}
+	member := false
+	for _, reference := range references {
+		group, found := evaluation.snapshot.FirewallGroups[reference.ID]
+		if !found {
+			return false, fmt.Errorf("%s '%s' is not in the snapshot", labelNsxtFirewallGroup, reference.ID)
+		}
+		if firewallGroupType(group) == types.FirewallGroupTypeIpSet {
+			if !address.IsValid() {
+				continue
+			}
+			for _, entry := range group.IpAddresses {
+				contained, err := ipSetEntryContains(entry, address)
+				if err != nil {
+					return false, fmt.Errorf("%s '%s': %w", labelNsxtFirewallGroup, group.Name, err)
+				}
+				member = member || contained
+			}
+			continue
+		}
+		if endpoint.VmId != "" && contains(endpoint.VmId, evaluation.snapshot.GroupVms[reference.ID]) {
+			member = true
+		}
+	}
+	return member != excluded, nil
+}
+
+// matchProfiles checks if the protocol and the port of the flow are in one of the Application Port
+// Profiles. No profiles means 'Any'
+func (evaluation *firewallFlowEvaluation) matchProfiles(references []types.OpenApiReference) (bool, error) {
+	if len(references) == 0 {
+		return true, nil
+	}
+	for _, reference := range references {
+		profile, found := evaluation.snapshot.ApplicationPortProfiles[reference.ID]
+		if !found {
+			return false, fmt.Errorf("%s '%s' is not in the snapshot", labelNsxtAppPortProfile, reference.ID)
+		}
+		for _, applicationPort := range profile.ApplicationPorts {
+			if !strings.EqualFold(applicationPort.Protocol, evaluation.flow.Protocol) {
+				continue
+			}
+			if len(applicationPort.DestinationPorts) == 0 {
+				return true, nil
+			}
+			for _, ports := range applicationPort.DestinationPorts {
+				contained, err := portRangeContains(ports, evaluation.flow.Port)
+				if err != nil {
+					return false, fmt.Errorf("%s '%s': %w", labelNsxtAppPortProfile, profile.Name, err)
+				}
+				if contained {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// firewallGroupType returns the type of the group for all API versions
+func firewallGroupType(group *types.NsxtFirewallGroup) string {
+	if group.TypeValue != "" {
+		return group.TypeValue
+	}
+	return group.Type
+}
+
+// ipSetEntryContains checks if address is in an IP Set entry, which can be a single address, a
+// range (e.g. 10.0.0.1-10.0.0.9) or a CIDR
+func ipSetEntryContains(entry string, address netip.Addr) (bool, error) {
+	if first, last, isRange := strings.Cut(entry, "-"); isRange {
+		firstAddress, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return false, fmt.Errorf("invalid IP range '%s': %w", entry, err)
+		}
+		lastAddress, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil {
+			return false, fmt.Errorf("invalid IP range '%s': %w", entry, err)
+		}
+		return firstAddress.Is4() == address.Is4() && firstAddress.Compare(address) <= 0 && address.Compare(lastAddress) <= 0, nil
+	}
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return false, fmt.Errorf("invalid CIDR '%s': %w", entry, err)
+		}
+		return prefix.Contains(address), nil
+	}
+	entryAddress, err := netip.ParseAddr(entry)
+	if err != nil {
+		return false, fmt.Errorf("invalid IP address '%s': %w", entry, err)
+	}
+	return entryAddress == address, nil
+}
+
+// portRangeContains checks if port is in a port definition of an Application Port Profile, which can
+// be a single port (e.g. 443) or a range (e.g. 8000-8080)
+func portRangeContains(ports string, port int) (bool, error) {
+	first, last, isRange := strings.Cut(ports, "-")
+	if !isRange {
+		last = first
+	}
+	firstPort, err := strconv.Atoi(strings.TrimSpace(first))
+	if err != nil {
+		return false, fmt.Errorf("invalid port '%s'", ports)
+	}
+	lastPort, err := strconv.Atoi(strings.TrimSpace(last))
+	if err != nil {
+		return false, fmt.Errorf("invalid port '%s'", ports)
+	}
+	return firstPort <= port && port <= lastPort, nil
+}
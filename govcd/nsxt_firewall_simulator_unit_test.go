@@ -0,0 +1,330 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func simulatorTestRefs(ids ...string) []types.OpenApiReference {
+	var references []types.OpenApiReference
+	for _, id := range ids {
+		references = append(references, types.OpenApiReference{ID: id})
+	}
+	return references
+}
+
+func simulatorTestSnapshot() *FirewallSnapshot {
+	return &FirewallSnapshot{
+		EdgeGatewayRules: &types.NsxtFirewallRuleContainer{
+			UserDefinedRules: []*types.NsxtFirewallRule{
+				{ID: "e1", Name: "web-in", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN",
+					DestinationFirewallGroups: simulatorTestRefs("web"), ApplicationPortProfiles: simulatorTestRefs("https")},
+				{ID: "e2", Name: "disabled", ActionValue: "ALLOW", Enabled: false, IpProtocol: "IPV4", Direction: "IN_OUT"},
+				{ID: "e3", Name: "v6-only", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV6", Direction: "IN_OUT"},
+			},
+			DefaultRules: []*types.NsxtFirewallRule{
+				{ID: "e4", Name: "default", ActionValue: "DROP", Enabled: true, IpProtocol: "IPV4_IPV6", Direction: "IN_OUT"},
+			},
+		},
+		DistributedFirewallRules: &types.DistributedFirewallRules{Values: []*types.DistributedFirewallRule{
+			{ID: "d1", Name: "app-to-db-dns", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT",
+				SourceFirewallGroups: simulatorTestRefs("app"), DestinationFirewallGroups: simulatorTestRefs("db"),
+				NetworkContextProfiles: simulatorTestRefs("dns")},
+			{ID: "d2", Name: "app-to-db", ActionValue: "DROP", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT",
+				SourceFirewallGroups: simulatorTestRefs("app"), DestinationFirewallGroups: simulatorTestRefs("db"),
+				ApplicationPortProfiles: simulatorTestRefs("range")},
+			{ID: "d3", Name: "not-app", ActionValue: "REJECT", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT",
+				SourceFirewallGroups: simulatorTestRefs("app"), SourceGroupsExcluded: addrOf(true), DestinationFirewallGroups: simulatorTestRefs("db")},
+			{ID: "d4", Name: "default-allow", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4_IPV6", Direction: "IN_OUT"},
+		}},
+		FirewallGroups: map[string]*types.NsxtFirewallGroup{
+			"web": {ID: "web", Name: "web", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.0.10-10.0.0.20", "10.0.1.0/24"}},
+			"app": {ID: "app", Name: "app", TypeValue: types.FirewallGroupTypeSecurityGroup},
+			"db":  {ID: "db", Name: "db", Type: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.2.5"}},
+		},
+		GroupVms: map[string][]string{"app": {"vm-a"}},
+		ApplicationPortProfiles: map[string]*types.NsxtAppPortProfile{
+			"https": {ID: "https", Name: "HTTPS", ApplicationPorts: []types.NsxtAppPortProfilePort{{Protocol: "TCP", DestinationPorts: []string{"443"}}}},
+			"range": {ID: "range", Name: "range", ApplicationPorts: []types.NsxtAppPortProfilePort{
+				{Protocol: "TCP", DestinationPorts: []string{"8000-8080"}}, {Protocol: "UDP"},
+			}},
+		},
+	}
+}
+
+// simulatorTestMatches summarizes the matches of a layer, e.g. "web-in!,default" where "!" marks
+// the decisive rule and "?" conditional rules
+func simulatorTestMatches(layer *FirewallLayerResult) string {
+	var matches []string
+	for _, match := range layer.Matches {
+		name := match.RuleName
+		if match.Decisive {
+			name += "!"
+		}
+		if match.Conditional {
+			name += "?"
+		}
+		matches = append(matches, name)
+	}
+	return layer.Verdict + " " + strings.Join(matches, ",")
+}
+
+func TestFirewallSnapshot_Evaluate(t *testing.T) {
+	tests := []struct {
+		name                string
+		flow                FirewallFlow
+		expectedEdgeGateway string
+		expectedDfw         string
+		expectedAllowed     bool
+	}{
+		{
+			name: "inbound HTTPS to web",
+			flow: FirewallFlow{
+				Source:      FirewallFlowEndpoint{IpAddress: "192.0.2.1"},
+				Destination: FirewallFlowEndpoint{IpAddress: "10.0.0.15"},
+				Protocol:    "TCP", Port: 443, Direction: "IN",
+			},
+			expectedEdgeGateway: "ALLOW web-in!,default",
+			expectedDfw:         "ALLOW default-allow!",
+			expectedAllowed:     true,
+		},
+		{
+			name: "outbound HTTPS does not match inbound rule",
+			flow: FirewallFlow{
+				Source:      FirewallFlowEndpoint{IpAddress: "10.0.0.15"},
+				Destination: FirewallFlowEndpoint{IpAddress: "10.0.0.16"},
+				Protocol:    "TCP", Port: 443, Direction: "OUT",
+			},
+			expectedEdgeGateway: "DROP default!",
+			expectedDfw:         "ALLOW default-allow!",
+		},
+		{
+			name: "app VM to db on a port of the range",
+			flow: FirewallFlow{
+				Source:      FirewallFlowEndpoint{VmId: "vm-a", IpAddress: "10.0.1.7"},
+				Destination: FirewallFlowEndpoint{IpAddress: "10.0.2.5"},
+				Protocol:    "tcp", Port: 8080,
+			},
+			expectedEdgeGateway: "DROP default!",
+			expectedDfw:         "DROP app-to-db-dns?,app-to-db!,default-allow",
+		},
+		{
+			name: "other VM to db is rejected",
+			flow: FirewallFlow{
+				Source:      FirewallFlowEndpoint{VmId: "vm-b", IpAddress: "10.0.1.8"},
+				Destination: FirewallFlowEndpoint{IpAddress: "10.0.2.5"},
+				Protocol:    "ICMPv4",
+			},
+			expectedEdgeGateway: "DROP default!",
+			expectedDfw:         "REJECT not-app!,default-allow",
+		},
+		{
+			name: "IPv6",
+			flow: FirewallFlow{
+				Source:      FirewallFlowEndpoint{IpAddress: "2001:db8::1"},
+				Destination: FirewallFlowEndpoint{IpAddress: "2001:db8::2"},
+				Protocol:    "UDP", Port: 53,
+			},
+			expectedEdgeGateway: "ALLOW v6-only!,default",
+			expectedDfw:         "ALLOW default-allow!",
+			expectedAllowed:     true,
+		},
+	}
+
+	snapshot := simulatorTestSnapshot()
+	// The snapshot can be stored and evaluated later
+	text, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("error marshalling snapshot: %s", err)
+	}
+	loaded := &FirewallSnapshot{}
+	err = json.Unmarshal(text, loaded)
+	if err != nil {
+		t.Fatalf("error unmarshalling snapshot: %s", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := loaded.Evaluate(test.flow)
+			if err != nil {
+				t.Fatalf("error evaluating flow: %s", err)
+			}
+			if got := simulatorTestMatches(result.EdgeGateway); got != test.expectedEdgeGateway {
+				t.Errorf("expected Edge Gateway result '%s', got '%s'", test.expectedEdgeGateway, got)
+			}
+			if got := simulatorTestMatches(result.DistributedFirewall); got != test.expectedDfw {
+				t.Errorf("expected Distributed Firewall result '%s', got '%s'", test.expectedDfw, got)
+			}
+			if result.Allowed() != test.expectedAllowed {
+				t.Errorf("expected allowed %t, got %t", test.expectedAllowed, result.Allowed())
+			}
+		})
+	}
+
+	// A layer that is not in the snapshot is not evaluated
+	snapshot.EdgeGatewayRules = nil
+	result, err := snapshot.Evaluate(FirewallFlow{Protocol: "ICMPv6"})
+	if err != nil {
+		t.Fatalf("error evaluating flow: %s", err)
+	}
+	if result.EdgeGateway != nil || !result.Allowed() {
+		t.Errorf("expected only the Distributed Firewall to be evaluated, got %+v", result)
+	}
+}
+
+func TestFirewallSnapshot_EvaluateErrors(t *testing.T) {
+	tests := map[string]FirewallFlow{
+		"invalid protocol":  {Protocol: "SCTP"},
+		"missing port":      {Protocol: "TCP"},
+		"invalid address":   {Protocol: "ICMPv4", Source: FirewallFlowEndpoint{IpAddress: "10.0.0"}},
+		"mixed families":    {Protocol: "ICMPv4", Source: FirewallFlowEndpoint{IpAddress: "10.0.0.1"}, Destination: FirewallFlowEndpoint{IpAddress: "::1"}},
+		"invalid direction": {Protocol: "ICMPv4", Direction: "IN_OUT"},
+	}
+	snapshot := simulatorTestSnapshot()
+	for name, flow := range tests {
+		_, err := snapshot.Evaluate(flow)
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	delete(snapshot.FirewallGroups, "db")
+	_, err := snapshot.Evaluate(FirewallFlow{Protocol: "ICMPv4", Source: FirewallFlowEndpoint{VmId: "vm-a"}})
+	if err == nil || !strings.Contains(err.Error(), "not in the snapshot") {
+		t.Errorf("expected an error for a group missing from the snapshot, got %v", err)
+	}
+}
+
+// firewallSnapshotTestVms creates a vApp in 'vdc1' with VMs connected to the Org VDC network and
+// returns their IDs
+func firewallSnapshotTestVms(t *testing.T, vcdClient *VCDClient, networkName string, vmNames ...string) []string {
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgNetwork, err := vdc.GetOrgVdcNetworkByName(networkName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapp, err := vdc.CreateRawVApp("vapp1", "")
+	if err != nil {
+		t.Fatalf("error creating vApp: %s", err)
+	}
+	if _, err = vapp.AddOrgNetwork(&VappNetworkSettings{}, orgNetwork.OrgVDCNetwork, false); err != nil {
+		t.Fatalf("error adding network to vApp: %s", err)
+	}
+	var vmIds []string
+	for _, name := range vmNames {
+		vm, err := vapp.AddEmptyVm(&types.RecomposeVAppParamsForEmptyVm{
+			CreateItem: &types.CreateItem{
+				Name: name,
+				VmSpecSection: &types.VmSpecSection{
+					OsType:           "debian10_64Guest",
+					NumCpus:          addrOf(1),
+					MemoryResourceMb: &types.MemoryResourceMb{Configured: 512},
+					HardwareVersion:  &types.HardwareVersion{Value: "vmx-19"},
+				},
+				NetworkConnectionSection: &types.NetworkConnectionSection{
+					NetworkConnection: []*types.NetworkConnection{
+						{Network: networkName, IsConnected: true, IPAddressAllocationMode: types.IPAllocationModePool},
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("error adding VM %s: %s", name, err)
+		}
+		vmIds = append(vmIds, vm.VM.ID)
+	}
+	return vmIds
+}
+
+func TestLoadFirewallSnapshot(t *testing.T) {
+	server, vcdClient := testServerClient(t)
+	networkId, err := server.AddOrgVdcNetwork("org1", "vdc1", "app-net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdcGroupId, err := server.AddVdcGroup("org1", "group1", "vdc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdcGroup, err := adminOrg.GetVdcGroupById(vdcGroupId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	egw := testEdgeGateway(t, vcdClient, "egw")
+	vmIds := firewallSnapshotTestVms(t, vcdClient, "app-net", "vm-1", "vm-2")
+
+	web, err := egw.CreateNsxtFirewallGroup(&types.NsxtFirewallGroup{
+		Name: "web", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.0.0/24"}, OwnerRef: &types.OpenApiReference{ID: egw.EdgeGateway.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := vdcGroup.CreateNsxtFirewallGroup(&types.NsxtFirewallGroup{
+		Name: "app", TypeValue: types.FirewallGroupTypeSecurityGroup, Members: simulatorTestRefs(networkId), OwnerRef: &types.OpenApiReference{ID: vdcGroupId},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	https, err := vdcGroup.GetNsxtAppPortProfileByName("HTTPS", types.ApplicationPortProfileScopeSystem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webId, appId, httpsId := web.NsxtFirewallGroup.ID, app.NsxtFirewallGroup.ID, https.NsxtAppPortProfile.ID
+	_, err = egw.UpdateNsxtFirewall(&types.NsxtFirewallRuleContainer{UserDefinedRules: []*types.NsxtFirewallRule{
+		{Name: "web-in", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN",
+			DestinationFirewallGroups: simulatorTestRefs(webId), ApplicationPortProfiles: simulatorTestRefs(httpsId)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = vdcGroup.UpdateDistributedFirewall(&types.DistributedFirewallRules{Values: []*types.DistributedFirewallRule{
+		{Name: "app-https", ActionValue: "ALLOW", Enabled: true, IpProtocol: "IPV4", Direction: "IN_OUT",
+			DestinationFirewallGroups: simulatorTestRefs(appId), ApplicationPortProfiles: simulatorTestRefs(httpsId)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := LoadFirewallSnapshot(egw, vdcGroup)
+	if err != nil {
+		t.Fatalf("error loading snapshot: %s", err)
+	}
+	if len(snapshot.FirewallGroups) != 2 || len(snapshot.ApplicationPortProfiles) != 1 ||
+		fmt.Sprint(snapshot.GroupVms) != fmt.Sprintf("map[%s:%v]", appId, vmIds) {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+
+	result, err := snapshot.Evaluate(FirewallFlow{
+		Source:      FirewallFlowEndpoint{IpAddress: "192.0.2.1"},
+		Destination: FirewallFlowEndpoint{VmId: vmIds[1], IpAddress: "10.0.0.5"},
+		Protocol:    "TCP", Port: 443, Direction: "IN",
+	})
+	if err != nil {
+		t.Fatalf("error evaluating flow: %s", err)
+	}
+	if !result.Allowed() || result.EdgeGateway.Matches[0].RuleName != "web-in" || result.DistributedFirewall.Matches[0].RuleName != "app-https" {
+		t.Errorf("unexpected result %+v %+v", result.EdgeGateway, result.DistributedFirewall)
+	}
+
+	_, err = LoadFirewallSnapshot(nil, nil)
+	if err == nil {
+		t.Errorf("expected an error without Edge Gateway and VDC Group")
+	}
+}
@@ -33,7 +33,7 @@ func (vdc *Vdc) GetNsxtImportableSwitchByName(name string) (*NsxtImportableSwitc
 
 	allNsxtImportableSwitches, err := vdc.GetAllNsxtImportableSwitches()
 	if err != nil {
-		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC '%s': %s", vdc.Vdc.Name, err)
+		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC '%s': %w", vdc.Vdc.Name, err)
 	}
 
 	var filteredNsxtImportableSwitches []*NsxtImportableSwitch
@@ -45,7 +45,7 @@ func (vdc *Vdc) GetNsxtImportableSwitchByName(name string) (*NsxtImportableSwitc
 
 	if len(filteredNsxtImportableSwitches) == 0 {
 		// ErrorEntityNotFound is injected here for the ability to validate problem using ContainsNotFound()
-		return nil, fmt.Errorf("%s: no NSX-T Importable Switch with name '%s' for Org VDC with ID '%s' found",
+		return nil, fmt.Errorf("%w: no NSX-T Importable Switch with name '%s' for Org VDC with ID '%s' found",
 			ErrorEntityNotFound, name, vdc.Vdc.ID)
 	}
 
@@ -69,7 +69,7 @@ func (vdcGroup *VdcGroup) GetNsxtImportableSwitchByName(name string) (*NsxtImpor
 
 	allNsxtImportableSwitches, err := vdcGroup.GetAllNsxtImportableSwitches()
 	if err != nil {
-		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC Group '%s': %s", vdcGroup.VdcGroup.Name, err)
+		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
 	}
 
 	var filteredNsxtImportableSwitches []*NsxtImportableSwitch
@@ -81,7 +81,7 @@ func (vdcGroup *VdcGroup) GetNsxtImportableSwitchByName(name string) (*NsxtImpor
 
 	if len(filteredNsxtImportableSwitches) == 0 {
 		// ErrorEntityNotFound is injected here for the ability to validate problem using ContainsNotFound()
-		return nil, fmt.Errorf("%s: no NSX-T Importable Switch with name '%s' for VDC Group with ID '%s' found",
+		return nil, fmt.Errorf("%w: no NSX-T Importable Switch with name '%s' for VDC Group with ID '%s' found",
 			ErrorEntityNotFound, name, vdcGroup.VdcGroup.Id)
 	}
 
@@ -106,7 +106,7 @@ func (vdcGroup *VdcGroup) GetAllNsxtImportableSwitches() ([]*NsxtImportableSwitc
 	// request requires Org VDC Group ID to be specified as UUID, not as URN
 	orgVdcGroupId, err := getBareEntityUuid(vdcGroup.VdcGroup.Id)
 	if err != nil {
-		return nil, fmt.Errorf("could not get UUID from URN '%s': %s", vdcGroup.VdcGroup.Id, err)
+		return nil, fmt.Errorf("could not get UUID from URN '%s': %w", vdcGroup.VdcGroup.Id, err)
 	}
 	filter := map[string]string{"vdcGroup": orgVdcGroupId}
 
@@ -126,7 +126,7 @@ func (vdc *Vdc) GetAllNsxtImportableSwitches() ([]*NsxtImportableSwitch, error)
 	// request requires Org VDC ID to be specified as UUID, not as URN
 	orgVdcId, err := getBareEntityUuid(vdc.Vdc.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get UUID from URN '%s': %s", vdc.Vdc.ID, err)
+		return nil, fmt.Errorf("could not get UUID from URN '%s': %w", vdc.Vdc.ID, err)
 	}
 	filter := map[string]string{"orgVdc": orgVdcId}
 
@@ -150,7 +150,7 @@ func (vcdClient *VCDClient) GetFilteredNsxtImportableSwitches(filter map[string]
 func (vcdClient *VCDClient) GetFilteredNsxtImportableSwitchesByName(filter map[string]string, name string) (*NsxtImportableSwitch, error) {
 	importableSwitches, err := getFilteredNsxtImportableSwitches(filter, &vcdClient.Client)
 	if err != nil {
-		return nil, fmt.Errorf("error getting list of filtered Importable Switches: %s", err)
+		return nil, fmt.Errorf("error getting list of filtered Importable Switches: %w", err)
 	}
 
 	var foundImportableSwitch bool
@@ -164,7 +164,7 @@ func (vcdClient *VCDClient) GetFilteredNsxtImportableSwitchesByName(filter map[s
 	}
 
 	if !foundImportableSwitch {
-		return nil, fmt.Errorf("%s: Importable Switch with name '%s' not found", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: Importable Switch with name '%s' not found", ErrorEntityNotFound, name)
 	}
 
 	if len(foundSwitches) > 1 {
@@ -102,7 +102,7 @@ func (egw *NsxtEdgeGateway) GetIpSecVpnTunnelByName(name string) (*NsxtIpSecVpnT
 
 	allVpns, err := egw.GetAllIpSecVpnTunnels(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configurations: %s", err)
+		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configurations: %w", err)
 	}
 
 	var allResults []*NsxtIpSecVpnTunnel
@@ -142,18 +142,18 @@ func (egw *NsxtEdgeGateway) CreateIpSecVpnTunnel(ipSecVpnConfig *types.NsxtIpSec
 
 	task, err := client.OpenApiPostItemAsync(minimumApiVersion, urlRef, nil, ipSecVpnConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("task failed while creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return nil, fmt.Errorf("task failed while creating NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	// filtering even by Name is not supported on VCD side
 	allVpns, err := egw.GetAllIpSecVpnTunnels(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configuration after creation: %s", err)
+		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configuration after creation: %w", err)
 	}
 
 	for index, singleConfig := range allVpns {
@@ -161,14 +161,14 @@ func (egw *NsxtEdgeGateway) CreateIpSecVpnTunnel(ipSecVpnConfig *types.NsxtIpSec
 			// retrieve exact value by ID, because only this endpoint includes private key
 			ipSecVpn, err := egw.GetIpSecVpnTunnelById(allVpns[index].NsxtIpSecVpn.ID)
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration: %s", err)
+				return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration: %w", err)
 			}
 
 			return ipSecVpn, nil
 		}
 	}
 
-	return nil, fmt.Errorf("error finding NSX-T IPsec VPN Tunnel configuration after creation: %s", ErrorEntityNotFound)
+	return nil, fmt.Errorf("error finding NSX-T IPsec VPN Tunnel configuration after creation: %w", ErrorEntityNotFound)
 }
 
 // Update updates NSX-T IPsec VPN Tunnel configuration with newly supplied data.
@@ -197,7 +197,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) Update(ipSecVpnConfig *types.NsxtIpSecVpnTun
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, ipSecVpnConfig, returnObject.NsxtIpSecVpn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -223,7 +223,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) Delete() error {
 
 	err = ipSecVpn.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return fmt.Errorf("error deleting NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	return nil
@@ -254,7 +254,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) GetStatus() (*types.NsxtIpSecVpnTunnelStatus
 
 	err = ipSecVpn.client.OpenApiGetItem(minimumApiVersion, urlRef, nil, ipSecVpnTunnelStatus, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting NSX-T IPsec VPN Tunnel status: %s", err)
+		return nil, fmt.Errorf("error getting NSX-T IPsec VPN Tunnel status: %w", err)
 	}
 
 	return ipSecVpnTunnelStatus, nil
@@ -282,7 +282,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) UpdateTunnelConnectionProperties(ipSecVpnTun
 	ipSecVpnTunnelProfile := &types.NsxtIpSecVpnTunnelSecurityProfile{}
 	err = ipSecVpn.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, ipSecVpnTunnelConnectionProperties, ipSecVpnTunnelProfile, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Connection Properties: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Connection Properties: %w", err)
 	}
 
 	return ipSecVpnTunnelProfile, nil
@@ -309,7 +309,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) GetTunnelConnectionProperties() (*types.Nsxt
 	ipSecVpnTunnelProfile := &types.NsxtIpSecVpnTunnelSecurityProfile{}
 	err = ipSecVpn.client.OpenApiGetItem(minimumApiVersion, urlRef, nil, ipSecVpnTunnelProfile, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Connection Properties: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Connection Properties: %w", err)
 	}
 
 	return ipSecVpnTunnelProfile, nil
@@ -44,17 +44,17 @@ func (egw *NsxtEdgeGateway) CreateL2VpnTunnel(tunnel *types.NsxtL2VpnTunnel) (*N
 	// so we need to fetch the newly created tunnel manually
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, tunnel)
 	if err != nil {
-		return nil, fmt.Errorf("error creating L2 VPN tunnel: %s", err)
+		return nil, fmt.Errorf("error creating L2 VPN tunnel: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for L2 VPN tunnel to be created: %s", err)
+		return nil, fmt.Errorf("error waiting for L2 VPN tunnel to be created: %w", err)
 	}
 
 	newTunnel, err := egw.GetL2VpnTunnelById(task.Task.Details)
 	if err != nil {
-		return nil, fmt.Errorf("error getting L2 VPN tunnel with id %s: %s", task.Task.Details, err)
+		return nil, fmt.Errorf("error getting L2 VPN tunnel with id %s: %w", task.Task.Details, err)
 	}
 
 	return newTunnel, nil
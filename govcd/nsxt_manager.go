@@ -21,7 +21,7 @@ type NsxtManager struct {
 func (vcdClient *VCDClient) GetNsxtManagerByName(name string) (*NsxtManager, error) {
 	nsxtManagers, err := vcdClient.QueryNsxtManagerByName(name)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Manager by name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving NSX-T Manager by name '%s': %w", name, err)
 	}
 
 	// Double check that exactly one NSX-T Manager is found and throw error otherwise
@@ -63,7 +63,7 @@ func (nsxtManager *NsxtManager) Urn() (string, error) {
 
 	nsxtManagerUrn, err := BuildUrnWithUuid("urn:vcloud:nsxtmanager:", nsxtManager.NsxtManager.ID)
 	if err != nil {
-		return "", fmt.Errorf("error building NSX-T Manager URN from ID '%s': %s", nsxtManager.NsxtManager.ID, err)
+		return "", fmt.Errorf("error building NSX-T Manager URN from ID '%s': %w", nsxtManager.NsxtManager.ID, err)
 	}
 	return nsxtManagerUrn, nil
 }
@@ -71,7 +71,7 @@ func (egw *NsxtEdgeGateway) GetNatRuleByName(name string) (*NsxtNatRule, error)
 	// support any filters - even ID. Therefore one must retrieve all items and look if there is an item with the same ID
 	allNatRules, err := egw.GetAllNatRules(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %s", err)
+		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %w", err)
 	}
 
 	var allResults []*NsxtNatRule
@@ -99,7 +99,7 @@ func (egw *NsxtEdgeGateway) GetNatRuleById(id string) (*NsxtNatRule, error) {
 	// support any filters - even ID. Therefore one must retrieve all items and look if there is an item with the same ID
 	allNatRules, err := egw.GetAllNatRules(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %s", err)
+		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %w", err)
 	}
 
 	for _, natRule := range allNatRules {
@@ -135,19 +135,19 @@ func (egw *NsxtEdgeGateway) CreateNatRule(natRuleConfig *types.NsxtNatRule) (*Ns
 	// items, and manually match it based on rule name, etc.
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, natRuleConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T NAT rule: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T NAT rule: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("task failed while creating NSX-T NAT rule: %s", err)
+		return nil, fmt.Errorf("task failed while creating NSX-T NAT rule: %w", err)
 	}
 
 	// queryParameters (API side filtering) are not used because pretty much nothing is accepted as filter (such fields as
 	// name, description, ruleType and even ID are not allowed
 	allNatRules, err := egw.GetAllNatRules(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching all NAT rules: %s", err)
+		return nil, fmt.Errorf("error fetching all NAT rules: %w", err)
 	}
 
 	for index, singleRule := range allNatRules {
@@ -186,7 +186,7 @@ func (nsxtNat *NsxtNatRule) Update(natRuleConfig *types.NsxtNatRule) (*NsxtNatRu
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, natRuleConfig, returnObject.NsxtNatRule, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T NAT Rule: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T NAT Rule: %w", err)
 	}
 
 	return returnObject, nil
@@ -213,7 +213,7 @@ func (nsxtNat *NsxtNatRule) Delete() error {
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T NAT Rule: %s", err)
+		return fmt.Errorf("error deleting NSX-T NAT Rule: %w", err)
 	}
 
 	return nil
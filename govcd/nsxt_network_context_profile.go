@@ -57,7 +57,7 @@ func GetNetworkContextProfilesByNameScopeAndContext(client *Client, name, scope,
 
 	allProfiles, err := GetAllNetworkContextProfiles(client, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Network Context Profiles by name '%s', scope '%s' and context ID '%s': %s ",
+		return nil, fmt.Errorf("error retrieving Network Context Profiles by name '%s', scope '%s' and context ID '%s': %w ",
 			name, scope, contextId, err)
 	}
 
@@ -70,7 +70,7 @@ func returnSingleNetworkContextProfile(allProfiles []*types.NsxtNetworkContextPr
 	}
 
 	if len(allProfiles) < 1 {
-		return nil, fmt.Errorf("%s: got 0 NSX-T Network Context Profiles", ErrorEntityNotFound)
+		return nil, fmt.Errorf("%w: got 0 NSX-T Network Context Profiles", ErrorEntityNotFound)
 	}
 
 	return allProfiles[0], nil
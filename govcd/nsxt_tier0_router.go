@@ -49,7 +49,7 @@ func (vcdClient *VCDClient) GetImportableNsxtTier0RouterByName(name, nsxtManager
 
 	nsxtTier0Routers, err := vcdClient.GetAllImportableNsxtTier0Routers(nsxtManagerId, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not find NSX-T Tier-0 router with name '%s' for NSX-T manager with id '%s': %s",
+		return nil, fmt.Errorf("could not find NSX-T Tier-0 router with name '%s' for NSX-T manager with id '%s': %w",
 			name, nsxtManagerId, err)
 	}
 
@@ -59,7 +59,7 @@ func (vcdClient *VCDClient) GetImportableNsxtTier0RouterByName(name, nsxtManager
 
 	if len(nsxtTier0Routers) == 0 {
 		// ErrorEntityNotFound is injected here for the ability to validate problem using ContainsNotFound()
-		return nil, fmt.Errorf("%s: no NSX-T Tier-0 router with name '%s' for NSX-T manager with id '%s' found",
+		return nil, fmt.Errorf("%w: no NSX-T Tier-0 router with name '%s' for NSX-T manager with id '%s' found",
 			ErrorEntityNotFound, name, nsxtManagerId)
 	}
 
@@ -48,7 +48,7 @@ func (egw *EdgeGateway) GetAllNsxvDhcpLeases() ([]*types.EdgeDhcpLeaseInfo, erro
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpLeasePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	dhcpLeases := &responseEdgeDhcpLeases{}
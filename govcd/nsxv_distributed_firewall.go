@@ -148,7 +148,7 @@ func (dfw *NsxvDistributedFirewall) Disable() error {
 				return nil
 			}
 		}
-		return fmt.Errorf("error deleting Distributed firewall: %s", err)
+		return fmt.Errorf("error deleting Distributed firewall: %w", err)
 
 	}
 	if resp != nil && resp.StatusCode != http.StatusNoContent {
@@ -371,7 +371,7 @@ func (dfw *NsxvDistributedFirewall) GetServicesByRegex(expression string) ([]typ
 	}
 	searchRegex, err := regexp.Compile(expression)
 	if err != nil {
-		return nil, fmt.Errorf("[GetServicesByRegex] error validating regular expression '%s': %s", expression, err)
+		return nil, fmt.Errorf("[GetServicesByRegex] error validating regular expression '%s': %w", expression, err)
 	}
 	var found []types.Application
 	for _, app := range services {
@@ -430,7 +430,7 @@ func (dfw *NsxvDistributedFirewall) GetServiceGroupsByRegex(expression string) (
 	}
 	searchRegex, err := regexp.Compile(expression)
 	if err != nil {
-		return nil, fmt.Errorf("[GetServiceGroupsByRegex] error validating regular expression '%s': %s", expression, err)
+		return nil, fmt.Errorf("[GetServiceGroupsByRegex] error validating regular expression '%s': %w", expression, err)
 	}
 	var found []types.ApplicationGroup
 	for _, appGroup := range serviceGroups {
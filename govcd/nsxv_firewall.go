@@ -47,7 +47,7 @@ func (egw *EdgeGateway) CreateNsxvFirewallRule(firewallRuleConfig *types.EdgeFir
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	// The query must be wrapped differently, depending if it mus specify the "aboveRuleId" parameter
@@ -73,7 +73,7 @@ func (egw *EdgeGateway) CreateNsxvFirewallRule(firewallRuleConfig *types.EdgeFir
 
 	readFirewallRule, err := egw.GetNsxvFirewallRuleById(firewallRuleId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve firewall rule with ID (%s) after creation: %s",
+		return nil, fmt.Errorf("unable to retrieve firewall rule with ID (%s) after creation: %w",
 			firewallRuleId, err)
 	}
 	return readFirewallRule, nil
@@ -89,7 +89,7 @@ func (egw *EdgeGateway) UpdateNsxvFirewallRule(firewallRuleConfig *types.EdgeFir
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateFirewallPath + "/" + firewallRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result is either 204 for success, or an error of type types.NSXError
@@ -101,7 +101,7 @@ func (egw *EdgeGateway) UpdateNsxvFirewallRule(firewallRuleConfig *types.EdgeFir
 
 	readFirewallRule, err := egw.GetNsxvFirewallRuleById(firewallRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve firewall rule with ID (%s) after update: %s",
+		return nil, fmt.Errorf("unable to retrieve firewall rule with ID (%s) after update: %w",
 			readFirewallRule.ID, err)
 	}
 	return readFirewallRule, nil
@@ -140,7 +140,7 @@ func (egw *EdgeGateway) GetAllNsxvFirewallRules() ([]*types.EdgeFirewallRule, er
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	firewallRuleResponse := &responseEdgeFirewallRules{}
@@ -170,7 +170,7 @@ func (egw *EdgeGateway) DeleteNsxvFirewallRuleById(id string) error {
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateFirewallPath + "/" + id)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// check if the rule exists and pass back the error at it may be 'ErrorEntityNotFound'
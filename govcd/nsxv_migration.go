@@ -0,0 +1,980 @@
+package govcd
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+const (
+	labelNsxvMigration         = "NSX-V migration"
+	labelNsxvFirewall          = "NSX-V Firewall"
+	labelNsxvFirewallRule      = "NSX-V Firewall Rule"
+	labelNsxvNatRule           = "NSX-V NAT Rule"
+	labelNsxvDhcpRelay         = "NSX-V DHCP Relay"
+	labelNsxvLbPool            = "NSX-V Load Balancer Pool"
+	labelNsxvLbVirtualServer   = "NSX-V Load Balancer Virtual Server"
+	labelNsxtNatRule           = "NSX-T NAT Rule"
+	labelNsxtDhcpForwarder     = "NSX-T DHCP Forwarder"
+	labelNsxtAlbPool           = "NSX-T ALB Pool"
+	labelNsxtAlbVirtualService = "NSX-T ALB Virtual Service"
+)
+
+// NsxvMigrationSource is the configuration of an NSX-V Edge Gateway which is translated to NSX-T by
+// Translate. It is created by LoadNsxvMigrationSource, or built by hand from a stored configuration
+type NsxvMigrationSource struct {
+	EdgeGatewayName string
+	// FirewallConfig contains the state and the default policy of the firewall. It is optional
+	FirewallConfig *types.FirewallConfigWithXml
+	FirewallRules  []*types.EdgeFirewallRule
+	// IpSets contains the IP Sets of the VDC, which can be referenced by firewall rules and by the
+	// DHCP relay
+	IpSets    []*types.EdgeIpSet
+	NatRules  []*types.EdgeNatRule
+	DhcpRelay *types.EdgeDhcpRelay
+
+	LbMonitors       []*types.LbMonitor
+	LbPools          []*types.LbPool
+	LbAppProfiles    []*types.LbAppProfile
+	LbVirtualServers []*types.LbVirtualServer
+}
+
+// NsxvMigrationIssue is an NSX-V construct that cannot be translated to NSX-T, or that is translated
+// with a loss of configuration
+type NsxvMigrationIssue struct {
+	// Kind is the label of the NSX-V construct, e.g. "NSX-V NAT Rule"
+	Kind string `json:"kind"`
+	// Name is the name of the NSX-V construct or, when it has none, its ID
+	Name string `json:"name"`
+	// Skipped is true when the construct is not in the plan and false when it is translated without
+	// the configuration described by Reason
+	Skipped bool   `json:"skipped"`
+	Reason  string `json:"reason"`
+}
+
+// String returns the issue as a line of a report, prefixed with "!" for skipped constructs and with
+// "~" for partially translated ones, e.g. "! NSX-V NAT Rule 196609: SNAT rules with ports are not supported"
+func (issue NsxvMigrationIssue) String() string {
+	prefix := "~"
+	if issue.Skipped {
+		prefix = "!"
+	}
+	return fmt.Sprintf("%s %s %s: %s", prefix, issue.Kind, issue.Name, issue.Reason)
+}
+
+// NsxvMigrationPlan contains the NSX-T translation of an NSX-V Edge Gateway and the constructs that
+// could not be translated. Entities reference each other by name, so that the plan can be stored as
+// JSON, reviewed and applied later to any NSX-T Edge Gateway with ApplyNsxvMigrationPlan
+type NsxvMigrationPlan struct {
+	SourceName string `json:"sourceName"`
+	// Firewall contains the firewall rules, the IP Sets they use as Firewall Groups and the TENANT
+	// Application Port Profiles of both firewall and NAT rules
+	Firewall *FirewallPolicyDocument `json:"firewall"`
+	// NatRules reference their Application Port Profile by name
+	NatRules      []*types.NsxtNatRule                `json:"natRules,omitempty"`
+	DhcpForwarder *types.NsxtEdgeGatewayDhcpForwarder `json:"dhcpForwarder,omitempty"`
+	// AlbPools do not have a GatewayRef, which is set when applying the plan
+	AlbPools []*types.NsxtAlbPool `json:"albPools,omitempty"`
+	// AlbVirtualServices reference their pool by name. GatewayRef and ServiceEngineGroupRef are set
+	// when applying the plan
+	AlbVirtualServices []*types.NsxtAlbVirtualService `json:"albVirtualServices,omitempty"`
+	Issues             []NsxvMigrationIssue           `json:"issues,omitempty"`
+	// SkippedDenyRules contains the names of the skipped DROP and REJECT firewall rules. Without
+	// them, the traffic they block is matched by the rules below, which makes the firewall more
+	// permissive than the NSX-V one
+	SkippedDenyRules []string `json:"skippedDenyRules,omitempty"`
+}
+
+// Report returns a summary of the translated entities followed by the issues, one per line
+func (plan *NsxvMigrationPlan) Report() string {
+	dhcpForwarder := "no"
+	if plan.DhcpForwarder != nil {
+		dhcpForwarder = "yes"
+	}
+	lines := []string{fmt.Sprintf("%s of NSX-V Edge Gateway '%s': %d firewall rules, %d Firewall Groups, "+
+		"%d Application Port Profiles, %d NAT rules, %d ALB Pools, %d ALB Virtual Services, DHCP forwarder: %s",
+		labelNsxvMigration, plan.SourceName, len(plan.Firewall.Rules), len(plan.Firewall.FirewallGroups),
+		len(plan.Firewall.ApplicationPortProfiles), len(plan.NatRules), len(plan.AlbPools),
+		len(plan.AlbVirtualServices), dhcpForwarder)}
+	for _, issue := range plan.Issues {
+		lines = append(lines, issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LoadNsxvMigrationSource retrieves the firewall, NAT, DHCP relay and load balancer configuration of
+// an advanced NSX-V Edge Gateway, and the IP Sets of its VDC
+func LoadNsxvMigrationSource(egw *EdgeGateway, vdc *Vdc) (*NsxvMigrationSource, error) {
+	if egw == nil || vdc == nil {
+		return nil, fmt.Errorf("%s requires an NSX-V Edge Gateway and its VDC", labelNsxvMigration)
+	}
+	if !egw.HasAdvancedNetworking() {
+		return nil, fmt.Errorf("only advanced edge gateways support %s", labelNsxvMigration)
+	}
+	source := &NsxvMigrationSource{EdgeGatewayName: egw.EdgeGateway.Name}
+	wrapError := func(what string, err error) error {
+		return fmt.Errorf("error retrieving %s of NSX-V Edge Gateway '%s': %w", what, source.EdgeGatewayName, err)
+	}
+
+	var err error
+	source.FirewallConfig, err = egw.GetFirewallConfig()
+	if err != nil {
+		return nil, wrapError("firewall configuration", err)
+	}
+	// The functions below return ErrorEntityNotFound when there are no entities
+	source.FirewallRules, err = egw.GetAllNsxvFirewallRules()
+	if err != nil && !ContainsNotFound(err) {
+		return nil, wrapError("firewall rules", err)
+	}
+	source.IpSets, err = vdc.GetAllNsxvIpSets()
+	if err != nil && !ContainsNotFound(err) {
+		return nil, wrapError("IP Sets", err)
+	}
+	source.NatRules, err = egw.GetNsxvNatRules()
+	if err != nil {
+		return nil, wrapError("NAT rules", err)
+	}
+	source.DhcpRelay, err = egw.GetDhcpRelay()
+	if err != nil {
+		return nil, wrapError("DHCP relay", err)
+	}
+	source.LbMonitors, err = egw.GetLbServiceMonitors()
+	if err != nil {
+		return nil, wrapError("load balancer service monitors", err)
+	}
+	source.LbPools, err = egw.GetLbServerPools()
+	if err != nil {
+		return nil, wrapError("load balancer server pools", err)
+	}
+	source.LbAppProfiles, err = egw.GetLbAppProfiles()
+	if err != nil {
+		return nil, wrapError("load balancer application profiles", err)
+	}
+	source.LbVirtualServers, err = egw.GetLbVirtualServers()
+	if err != nil {
+		return nil, wrapError("load balancer virtual servers", err)
+	}
+	return source, nil
+}
+
+// Translate converts the configuration to NSX-T and NSX-T ALB entities, without contacting VCD:
+//   - user defined firewall rules become Edge Gateway firewall rules. IP Sets and IP addresses
+//     become IP Set Firewall Groups and services become TENANT Application Port Profiles. An ALLOW
+//     rule is added at the bottom when the default policy accepts traffic, because the default rule
+//     of NSX-T Edge Gateways drops it
+//   - user defined SNAT and DNAT rules become NAT rules, which match the firewall on the same
+//     addresses as NSX-V
+//   - the DHCP relay becomes the DHCP forwarder
+//   - load balancer pools and virtual servers become ALB Pools and ALB Virtual Services
+//
+// Constructs without equivalent are reported in the Issues of the plan. Skipped DROP and REJECT
+// firewall rules are also listed in SkippedDenyRules
+func (source *NsxvMigrationSource) Translate() *NsxvMigrationPlan {
+	translator := &nsxvMigrationTranslator{
+		source: source,
+		plan: &NsxvMigrationPlan{
+			SourceName: source.EdgeGatewayName,
+			Firewall: &FirewallPolicyDocument{
+				Version:    FirewallPolicyDocumentVersion,
+				SourceType: FirewallPolicySourceEdgeGateway,
+				SourceName: source.EdgeGatewayName,
+				Rules:      []FirewallPolicyRule{},
+			},
+		},
+		ipSets:      make(map[string]*types.EdgeIpSet),
+		groupNames:  make(map[string]bool),
+		addedGroups: make(map[string]bool),
+		profiles:    make(map[string]bool),
+		ruleNames:   make(map[string]bool),
+		albPools:    make(map[string]*types.NsxtAlbPool),
+	}
+	for _, ipSet := range source.IpSets {
+		translator.ipSets[nsxvObjectId(ipSet.ID)] = ipSet
+		// Names of IP Sets are reserved, so that the groups of IP addresses do not take them
+		translator.groupNames[ipSet.Name] = true
+	}
+
+	translator.translateFirewall()
+	translator.translateNatRules()
+	translator.translateDhcpRelay()
+	translator.translateLoadBalancer()
+	return translator.plan
+}
+
+// nsxvMigrationTranslator keeps track of the entities added to a NsxvMigrationPlan
+type nsxvMigrationTranslator struct {
+	source *NsxvMigrationSource
+	plan   *NsxvMigrationPlan
+	// ipSets contains the IP Sets of the source by ID without the VDC scope, e.g. "ipset-2"
+	ipSets map[string]*types.EdgeIpSet
+	// groupNames contains the reserved names of Firewall Groups and addedGroups the ones in
+	// plan.Firewall. profiles and ruleNames contain the names used in plan.Firewall
+	groupNames  map[string]bool
+	addedGroups map[string]bool
+	profiles    map[string]bool
+	ruleNames   map[string]bool
+	// albPools contains the translated pools by NSX-V pool ID
+	albPools map[string]*types.NsxtAlbPool
+}
+
+func (translator *nsxvMigrationTranslator) addIssue(kind, name string, skipped bool, format string, args ...any) {
+	translator.plan.Issues = append(translator.plan.Issues, NsxvMigrationIssue{
+		Kind:    kind,
+		Name:    name,
+		Skipped: skipped,
+		Reason:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (translator *nsxvMigrationTranslator) translateFirewall() {
+	config := translator.source.FirewallConfig
+	if config != nil && !config.Enabled {
+		translator.addIssue(labelNsxvFirewall, translator.source.EdgeGatewayName, false,
+			"the firewall is disabled, while the rules of NSX-T Edge Gateways are always enforced")
+	}
+	for _, rule := range translator.source.FirewallRules {
+		// Internal and default policy rules are managed by NSX-V
+		if rule.RuleType != "" && rule.RuleType != "user" {
+			continue
+		}
+		translator.translateFirewallRule(rule)
+	}
+	if config != nil && config.DefaultPolicy.Action == "accept" {
+		translator.plan.Firewall.Rules = append(translator.plan.Firewall.Rules, FirewallPolicyRule{
+			Name:        uniqueNsxvMigrationName(translator.ruleNames, "default-accept"),
+			ActionValue: "ALLOW",
+			Enabled:     true,
+			Direction:   "IN_OUT",
+			IpProtocol:  "IPV4_IPV6",
+			Logging:     config.DefaultPolicy.LoggingEnabled,
+		})
+	}
+}
+
+func (translator *nsxvMigrationTranslator) translateFirewallRule(rule *types.EdgeFirewallRule) {
+	name := nsxvMigrationName(rule.Name, rule.ID)
+	actions := map[string]string{"accept": "ALLOW", "deny": "DROP", "reject": "REJECT"}
+	directions := map[string]string{"": "IN_OUT", "in": "IN", "out": "OUT"}
+	action, found := actions[strings.ToLower(rule.Action)]
+	if !found {
+		translator.addIssue(labelNsxvFirewallRule, name, true, "unsupported action '%s'", rule.Action)
+		return
+	}
+	direction, found := directions[strings.ToLower(rule.Direction)]
+	if !found {
+		translator.addIssue(labelNsxvFirewallRule, name, true, "unsupported direction '%s'", rule.Direction)
+		return
+	}
+
+	skip := func(reason string) {
+		translator.addIssue(labelNsxvFirewallRule, name, true, "%s", reason)
+		if action != "ALLOW" {
+			translator.plan.SkippedDenyRules = append(translator.plan.SkippedDenyRules, name)
+		}
+	}
+
+	ruleName := uniqueNsxvMigrationName(translator.ruleNames, name)
+	sourceGroups, reason := translator.firewallEndpointGroups(ruleName, "source", rule.Source)
+	if reason != "" {
+		skip(reason)
+		return
+	}
+	destinationGroups, reason := translator.firewallEndpointGroups(ruleName, "destination", rule.Destination)
+	if reason != "" {
+		skip(reason)
+		return
+	}
+	ports, losses, reason := nsxvFirewallApplicationPorts(rule.Application)
+	if reason != "" {
+		skip(reason)
+		return
+	}
+	if rule.MatchTranslated != nil && *rule.MatchTranslated {
+		losses = append(losses, "matching of translated addresses is replaced by the firewall match of NAT rules")
+	}
+	for _, loss := range losses {
+		translator.addIssue(labelNsxvFirewallRule, name, false, "%s", loss)
+	}
+
+	policyRule := FirewallPolicyRule{
+		Name:        ruleName,
+		ActionValue: action,
+		Enabled:     rule.Enabled,
+		Direction:   direction,
+		IpProtocol:  "IPV4_IPV6",
+		Logging:     rule.LoggingEnabled,
+	}
+	policyRule.SourceFirewallGroups = translator.addFirewallGroups(sourceGroups)
+	policyRule.DestinationFirewallGroups = translator.addFirewallGroups(destinationGroups)
+	if len(ports) > 0 {
+		policyRule.ApplicationPortProfiles = []FirewallPolicyProfileRef{translator.addAppPortProfile(ports)}
+	}
+	translator.plan.Firewall.Rules = append(translator.plan.Firewall.Rules, policyRule)
+}
+
+// firewallEndpointGroups returns the IP Set Firewall Groups matching the source or destination of a
+// rule, or the reason why they cannot be translated. IP addresses are grouped in a new IP Set named
+// after the rule. No groups means 'Any'
+func (translator *nsxvMigrationTranslator) firewallEndpointGroups(ruleName, side string, endpoint types.EdgeFirewallEndpoint) ([]FirewallPolicyGroup, string) {
+	if endpoint.Exclude {
+		return nil, fmt.Sprintf("the %s is excluded, which NSX-T Edge Gateway rules do not support", side)
+	}
+	if len(endpoint.VnicGroupIds) > 0 {
+		return nil, fmt.Sprintf("the %s contains interface groups %s, which have no NSX-T equivalent",
+			side, strings.Join(endpoint.VnicGroupIds, ", "))
+	}
+	var groups []FirewallPolicyGroup
+	for _, id := range endpoint.GroupingObjectIds {
+		ipSet, found := translator.ipSets[nsxvObjectId(id)]
+		if !found {
+			return nil, fmt.Sprintf("the %s contains grouping object '%s', which is not an IP Set", side, id)
+		}
+		groups = append(groups, FirewallPolicyGroup{
+			Name:        ipSet.Name,
+			Description: ipSet.Description,
+			Type:        types.FirewallGroupTypeIpSet,
+			IpAddresses: splitNsxvList(ipSet.IPAddresses),
+		})
+	}
+	var ipAddresses []string
+	for _, ipAddress := range endpoint.IpAddresses {
+		if strings.EqualFold(ipAddress, "any") {
+			return nil, ""
+		}
+		ipAddresses = append(ipAddresses, ipAddress)
+	}
+	if len(ipAddresses) > 0 {
+		groups = append(groups, FirewallPolicyGroup{
+			Name:        uniqueNsxvMigrationName(translator.groupNames, ruleName+"-"+side),
+			Description: fmt.Sprintf("IP addresses of the %s of %s %s", side, labelNsxvFirewallRule, ruleName),
+			Type:        types.FirewallGroupTypeIpSet,
+			IpAddresses: ipAddresses,
+		})
+	}
+	return groups, ""
+}
+
+// addFirewallGroups adds the groups that are not in the plan yet and returns references to all of
+// them
+func (translator *nsxvMigrationTranslator) addFirewallGroups(groups []FirewallPolicyGroup) []FirewallPolicyGroupRef {
+	var groupRefs []FirewallPolicyGroupRef
+	for _, group := range groups {
+		groupRefs = append(groupRefs, FirewallPolicyGroupRef{Name: group.Name, Type: group.Type})
+		if translator.addedGroups[group.Name] {
+			continue
+		}
+		translator.addedGroups[group.Name] = true
+		translator.plan.Firewall.FirewallGroups = append(translator.plan.Firewall.FirewallGroups, group)
+	}
+	return groupRefs
+}
+
+// addAppPortProfile adds a TENANT Application Port Profile with the given ports, unless a profile
+// with the same ports is already in the plan, and returns a reference to it. Profiles are named
+// after their ports, e.g. "nsxv-TCP_80_443_UDP_53", without characters that are operators of
+// filters
+func (translator *nsxvMigrationTranslator) addAppPortProfile(ports []types.NsxtAppPortProfilePort) FirewallPolicyProfileRef {
+	var signature []string
+	for _, port := range ports {
+		signature = append(append(signature, port.Protocol), port.DestinationPorts...)
+	}
+	name := "nsxv-" + strings.Join(signature, "_")
+	if !translator.profiles[name] {
+		translator.profiles[name] = true
+		translator.plan.Firewall.ApplicationPortProfiles = append(translator.plan.Firewall.ApplicationPortProfiles, FirewallPolicyAppPortProfile{
+			Name:             name,
+			Description:      "Translated from " + labelNsxvMigration,
+			ApplicationPorts: ports,
+		})
+	}
+	return FirewallPolicyProfileRef{Name: name, Scope: types.ApplicationPortProfileScopeTenant}
+}
+
+// nsxvFirewallApplicationPorts translates the services of a firewall rule. It returns the ports,
+// the lost configuration and the reason why the services cannot be translated. No ports means 'Any'
+func nsxvFirewallApplicationPorts(application types.EdgeFirewallApplication) ([]types.NsxtAppPortProfilePort, []string, string) {
+	if application.ID != "" {
+		return nil, nil, fmt.Sprintf("the rule uses NSX-V application '%s', which must be replaced by an Application Port Profile manually", application.ID)
+	}
+	var ports []types.NsxtAppPortProfilePort
+	var losses []string
+	for _, service := range application.Services {
+		protocol, found := nsxvProtocols[strings.ToLower(service.Protocol)]
+		if !found {
+			return nil, nil, fmt.Sprintf("unsupported service protocol '%s'", service.Protocol)
+		}
+		if protocol == "" {
+			// A service with any protocol matches all traffic
+			return nil, losses, ""
+		}
+		if !nsxvIsAny(service.SourcePort) {
+			losses = append(losses, fmt.Sprintf("source ports %s of %s services are not supported, all source ports are matched",
+				service.SourcePort, protocol))
+		}
+		port := types.NsxtAppPortProfilePort{Protocol: protocol}
+		switch {
+		case nsxvIsAny(service.Port):
+		case protocol == "ICMPv4":
+			losses = append(losses, fmt.Sprintf("ICMP type %s is not supported, all ICMP types are matched", service.Port))
+		default:
+			port.DestinationPorts = splitNsxvList(service.Port)
+		}
+		ports = append(ports, port)
+	}
+	return ports, losses, ""
+}
+
+func (translator *nsxvMigrationTranslator) translateNatRules() {
+	for _, rule := range translator.source.NatRules {
+		// Internal rules are created by NSX-V for its own services
+		if rule.RuleType != "" && rule.RuleType != "user" {
+			continue
+		}
+		translator.translateNatRule(rule)
+	}
+}
+
+func (translator *nsxvMigrationTranslator) translateNatRule(rule *types.EdgeNatRule) {
+	action := strings.ToLower(rule.Action)
+	name := fmt.Sprintf("%s-%s", action, rule.ID)
+	protocol, found := nsxvProtocols[strings.ToLower(rule.Protocol)]
+	if !found {
+		translator.addIssue(labelNsxvNatRule, name, true, "unsupported protocol '%s'", rule.Protocol)
+		return
+	}
+
+	natRule := &types.NsxtNatRule{
+		Name:        name,
+		Description: rule.Description,
+		Enabled:     rule.Enabled,
+		Logging:     rule.LoggingEnabled,
+	}
+	switch action {
+	case "snat":
+		if protocol != "" || !nsxvIsAny(rule.OriginalPort) || !nsxvIsAny(rule.TranslatedPort) {
+			translator.addIssue(labelNsxvNatRule, name, true, "SNAT rules with a protocol or ports are not supported by NSX-T")
+			return
+		}
+		natRule.RuleType = types.NsxtNatRuleTypeSnat
+		natRule.InternalAddresses = rule.OriginalAddress
+		natRule.ExternalAddresses = rule.TranslatedAddress
+		// NSX-V firewall rules match the original source address, which is the internal one
+		natRule.FirewallMatch = types.NsxtNatRuleFirewallMatchInternalAddress
+	case "dnat":
+		natRule.RuleType = types.NsxtNatRuleTypeDnat
+		natRule.ExternalAddresses = rule.OriginalAddress
+		natRule.InternalAddresses = rule.TranslatedAddress
+		// NSX-V firewall rules match the original destination address, which is the external one
+		natRule.FirewallMatch = types.NsxtNatRuleFirewallMatchExternalAddress
+		if protocol == "" {
+			if !nsxvIsAny(rule.OriginalPort) || !nsxvIsAny(rule.TranslatedPort) {
+				translator.addIssue(labelNsxvNatRule, name, true, "DNAT rules with ports but without protocol are not supported by NSX-T")
+				return
+			}
+			break
+		}
+		port := types.NsxtAppPortProfilePort{Protocol: protocol}
+		if protocol == "ICMPv4" {
+			if !nsxvIsAny(rule.IcmpType) {
+				translator.addIssue(labelNsxvNatRule, name, false, "ICMP type %s is not supported, all ICMP types are translated", rule.IcmpType)
+			}
+		} else {
+			// The Application Port Profile contains the internal port and DnatExternalPort the
+			// original one, when it is different
+			internalPort := rule.TranslatedPort
+			if nsxvIsAny(internalPort) {
+				internalPort = rule.OriginalPort
+			} else if nsxvIsAny(rule.OriginalPort) {
+				translator.addIssue(labelNsxvNatRule, name, true, "translating any port to port %s is not supported by NSX-T", internalPort)
+				return
+			}
+			if !nsxvIsAny(internalPort) {
+				port.DestinationPorts = []string{internalPort}
+			}
+			if internalPort != rule.OriginalPort {
+				natRule.DnatExternalPort = rule.OriginalPort
+			}
+		}
+		profileRef := translator.addAppPortProfile([]types.NsxtAppPortProfilePort{port})
+		natRule.ApplicationPortProfile = &types.OpenApiReference{Name: profileRef.Name}
+	default:
+		translator.addIssue(labelNsxvNatRule, name, true, "unsupported action '%s'", rule.Action)
+		return
+	}
+	if rule.Vnic != nil && *rule.Vnic != 0 {
+		translator.addIssue(labelNsxvNatRule, name, false, "the rule applies to vNic %d, while NSX-T NAT rules apply to the uplink of the Edge Gateway", *rule.Vnic)
+	}
+	translator.plan.NatRules = append(translator.plan.NatRules, natRule)
+}
+
+func (translator *nsxvMigrationTranslator) translateDhcpRelay() {
+	relay := translator.source.DhcpRelay
+	if relay == nil || relay.RelayServer == nil {
+		return
+	}
+	name := translator.source.EdgeGatewayName
+	servers := append([]string{}, relay.RelayServer.IpAddress...)
+	for _, id := range relay.RelayServer.GroupingObjectId {
+		ipSet, found := translator.ipSets[nsxvObjectId(id)]
+		if !found {
+			translator.addIssue(labelNsxvDhcpRelay, name, false, "unknown IP Set '%s' of DHCP servers", id)
+			continue
+		}
+		for _, ipAddress := range splitNsxvList(ipSet.IPAddresses) {
+			if _, err := netip.ParseAddr(ipAddress); err != nil {
+				translator.addIssue(labelNsxvDhcpRelay, name, false, "IP Set '%s' contains '%s', which is not a DHCP server address", ipSet.Name, ipAddress)
+				continue
+			}
+			servers = append(servers, ipAddress)
+		}
+	}
+	if len(relay.RelayServer.Fqdns) > 0 {
+		translator.addIssue(labelNsxvDhcpRelay, name, false, "DHCP servers %s are FQDNs, which are not supported by the DHCP forwarder",
+			strings.Join(relay.RelayServer.Fqdns, ", "))
+	}
+	if len(servers) == 0 {
+		translator.addIssue(labelNsxvDhcpRelay, name, true, "there are no DHCP server IP addresses")
+		return
+	}
+	if relay.RelayAgents != nil && len(relay.RelayAgents.Agents) > 0 {
+		translator.addIssue(labelNsxvDhcpRelay, name, false,
+			"relay agents are replaced by setting the DHCP mode of the Org VDC networks to RELAY")
+	}
+	translator.plan.DhcpForwarder = &types.NsxtEdgeGatewayDhcpForwarder{Enabled: true, DhcpServers: servers}
+}
+
+func (translator *nsxvMigrationTranslator) translateLoadBalancer() {
+	monitors := make(map[string]*types.LbMonitor)
+	for _, monitor := range translator.source.LbMonitors {
+		monitors[monitor.ID] = monitor
+	}
+	for _, pool := range translator.source.LbPools {
+		translator.translateLbPool(pool, monitors)
+	}
+
+	appProfiles := make(map[string]*types.LbAppProfile)
+	for _, appProfile := range translator.source.LbAppProfiles {
+		appProfiles[appProfile.ID] = appProfile
+	}
+	for _, virtualServer := range translator.source.LbVirtualServers {
+		translator.translateLbVirtualServer(virtualServer, appProfiles)
+	}
+}
+
+func (translator *nsxvMigrationTranslator) translateLbPool(pool *types.LbPool, monitors map[string]*types.LbMonitor) {
+	algorithms := map[string]string{"round-robin": "ROUND_ROBIN", "leastconn": "LEAST_CONNECTIONS", "ip-hash": "CONSISTENT_HASH"}
+	monitorTypes := map[string]string{"http": "HTTP", "https": "HTTPS", "tcp": "TCP", "udp": "UDP", "icmp": "PING"}
+
+	albPool := &types.NsxtAlbPool{
+		Name:        pool.Name,
+		Description: pool.Description,
+		Enabled:     addrOf(true),
+		Algorithm:   algorithms[pool.Algorithm],
+	}
+	if albPool.Algorithm == "" {
+		albPool.Algorithm = "LEAST_CONNECTIONS"
+		translator.addIssue(labelNsxvLbPool, pool.Name, false, "algorithm '%s' is not supported, LEAST_CONNECTIONS is used", pool.Algorithm)
+	}
+	if pool.Transparent {
+		translator.addIssue(labelNsxvLbPool, pool.Name, false, "transparent mode is not translated")
+	}
+	if pool.MonitorId != "" {
+		monitor, found := monitors[pool.MonitorId]
+		monitorType := ""
+		if found {
+			monitorType = monitorTypes[monitor.Type]
+		}
+		if monitorType == "" {
+			translator.addIssue(labelNsxvLbPool, pool.Name, false, "monitor '%s' is not translated", pool.MonitorId)
+		} else {
+			albPool.HealthMonitors = []types.NsxtAlbPoolHealthMonitor{{Type: monitorType}}
+			if monitor.URL != "" || monitor.Method != "" || monitor.Send != "" || monitor.Receive != "" || monitor.Expected != "" || monitor.Extension != "" {
+				translator.addIssue(labelNsxvLbPool, pool.Name, false,
+					"parameters of monitor '%s' are not translated, the system defined %s monitor is used", monitor.Name, monitorType)
+			}
+		}
+	}
+
+	memberLimits := false
+	for _, member := range pool.Members {
+		albMember := types.NsxtAlbPoolMember{
+			Enabled:   member.Condition != "disabled",
+			IpAddress: member.IpAddress,
+			Port:      member.Port,
+		}
+		switch {
+		case member.Weight > 20:
+			translator.addIssue(labelNsxvLbPool, pool.Name, false, "weight %d of member '%s' exceeds the maximum ratio 20", member.Weight, member.Name)
+			albMember.Ratio = addrOf(20)
+		case member.Weight > 0:
+			albMember.Ratio = addrOf(member.Weight)
+		}
+		memberLimits = memberLimits || member.MonitorPort != 0 || member.MaxConn != 0 || member.MinConn != 0
+		albPool.Members = append(albPool.Members, albMember)
+	}
+	if memberLimits {
+		translator.addIssue(labelNsxvLbPool, pool.Name, false, "monitor ports and connection limits of members are not translated")
+	}
+
+	translator.albPools[pool.ID] = albPool
+	translator.plan.AlbPools = append(translator.plan.AlbPools, albPool)
+}
+
+func (translator *nsxvMigrationTranslator) translateLbVirtualServer(virtualServer *types.LbVirtualServer, appProfiles map[string]*types.LbAppProfile) {
+	name := nsxvMigrationName(virtualServer.Name, virtualServer.ID)
+	albPool, found := translator.albPools[virtualServer.DefaultPoolId]
+	if !found {
+		translator.addIssue(labelNsxvLbVirtualServer, name, true, "there is no default pool, which ALB Virtual Services require")
+		return
+	}
+	appProfile := appProfiles[virtualServer.ApplicationProfileId]
+	servicePort := types.NsxtAlbVirtualServicePort{PortStart: addrOf(virtualServer.Port)}
+	albVirtualService := &types.NsxtAlbVirtualService{
+		Name:                name,
+		Description:         virtualServer.Description,
+		Enabled:             addrOf(virtualServer.Enabled),
+		LoadBalancerPoolRef: types.OpenApiReference{Name: albPool.Name},
+		VirtualIpAddress:    virtualServer.IpAddress,
+	}
+	switch strings.ToLower(virtualServer.Protocol) {
+	case "http":
+		albVirtualService.ApplicationProfile = types.NsxtAlbVirtualServiceApplicationProfile{SystemDefined: true, Type: "HTTP"}
+	case "https":
+		if appProfile == nil || !appProfile.SslPassthrough {
+			translator.addIssue(labelNsxvLbVirtualServer, name, true, "HTTPS requires a certificate, which must be configured manually")
+			return
+		}
+		albVirtualService.ApplicationProfile = types.NsxtAlbVirtualServiceApplicationProfile{SystemDefined: true, Type: "L4"}
+		translator.addIssue(labelNsxvLbVirtualServer, name, false, "SSL passthrough is translated to an L4 application profile")
+	case "tcp":
+		albVirtualService.ApplicationProfile = types.NsxtAlbVirtualServiceApplicationProfile{SystemDefined: true, Type: "L4"}
+	case "udp":
+		albVirtualService.ApplicationProfile = types.NsxtAlbVirtualServiceApplicationProfile{SystemDefined: true, Type: "L4"}
+		servicePort.TcpUdpProfile = &types.NsxtAlbVirtualServicePortTcpUdpProfile{SystemDefined: true, Type: "UDP_FAST_PATH"}
+	default:
+		translator.addIssue(labelNsxvLbVirtualServer, name, true, "unsupported protocol '%s'", virtualServer.Protocol)
+		return
+	}
+	albVirtualService.ServicePorts = []types.NsxtAlbVirtualServicePort{servicePort}
+
+	if len(virtualServer.ApplicationRuleIds) > 0 {
+		translator.addIssue(labelNsxvLbVirtualServer, name, false, "application rules %s are not translated",
+			strings.Join(virtualServer.ApplicationRuleIds, ", "))
+	}
+	if virtualServer.ConnectionLimit != 0 || virtualServer.ConnectionRateLimit != 0 {
+		translator.addIssue(labelNsxvLbVirtualServer, name, false, "connection limits are not translated")
+	}
+	if appProfile != nil {
+		translator.translateLbAppProfile(name, appProfile, albPool)
+	}
+	translator.plan.AlbVirtualServices = append(translator.plan.AlbVirtualServices, albVirtualService)
+}
+
+// translateLbAppProfile translates the application profile of a virtual server. Persistence is set
+// on the ALB Pool of the virtual server
+func (translator *nsxvMigrationTranslator) translateLbAppProfile(name string, appProfile *types.LbAppProfile, albPool *types.NsxtAlbPool) {
+	if appProfile.Persistence != nil && appProfile.Persistence.Method != "" {
+		var persistence *types.NsxtAlbPoolPersistenceProfile
+		switch appProfile.Persistence.Method {
+		case "cookie":
+			persistence = &types.NsxtAlbPoolPersistenceProfile{Type: "HTTP_COOKIE", Value: appProfile.Persistence.CookieName}
+		case "sourceip":
+			persistence = &types.NsxtAlbPoolPersistenceProfile{Type: "CLIENT_IP"}
+		default:
+			translator.addIssue(labelNsxvLbVirtualServer, name, false, "persistence method '%s' is not supported", appProfile.Persistence.Method)
+		}
+		switch {
+		case persistence == nil:
+		case albPool.PersistenceProfile != nil && *albPool.PersistenceProfile != *persistence:
+			translator.addIssue(labelNsxvLbVirtualServer, name, false,
+				"persistence is not translated, because pool '%s' has the persistence of another virtual server", albPool.Name)
+		default:
+			albPool.PersistenceProfile = persistence
+		}
+	}
+	if appProfile.HttpRedirect != nil && appProfile.HttpRedirect.To != "" {
+		translator.addIssue(labelNsxvLbVirtualServer, name, false, "HTTP redirection to '%s' is not translated", appProfile.HttpRedirect.To)
+	}
+	if appProfile.InsertXForwardedForHttpHeader {
+		translator.addIssue(labelNsxvLbVirtualServer, name, false, "insertion of the X-Forwarded-For header is not translated")
+	}
+	if appProfile.ServerSslEnabled {
+		translator.addIssue(labelNsxvLbVirtualServer, name, false, "SSL towards pool members is not translated")
+	}
+}
+
+// NsxvMigrationApplyOptions configures ApplyNsxvMigrationPlan
+type NsxvMigrationApplyOptions struct {
+	// ServiceEngineGroupId is the ALB Service Engine Group of the Virtual Services. It is mandatory
+	// when the plan contains ALB Virtual Services
+	ServiceEngineGroupId string
+	// DryRun reports the entities that applying the plan would create or update, without changing
+	// the Edge Gateway
+	DryRun bool
+	// AllowSkippedDenyRules applies a plan with SkippedDenyRules, accepting a firewall that is more
+	// permissive than the NSX-V one. Without it, such plans can only be applied in a dry run
+	AllowSkippedDenyRules bool
+}
+
+// NsxvMigrationApplyResult contains the changes of ApplyNsxvMigrationPlan
+type NsxvMigrationApplyResult struct {
+	Changes []ConfigurationChange
+	// Applied is false when NsxvMigrationApplyOptions.DryRun was set
+	Applied bool
+}
+
+// String returns the changes, one line per created or updated entity
+func (result *NsxvMigrationApplyResult) String() string {
+	return configurationChangesString(result.Changes)
+}
+
+// ApplyNsxvMigrationPlan creates the entities of a plan returned by NsxvMigrationSource.Translate
+// in the NSX-T Edge Gateway:
+//   - the firewall is imported with ImportFirewallPolicy in FirewallPolicyImportMerge mode
+//   - NAT rules, ALB Pools and ALB Virtual Services are created, unless an entity of the same kind
+//     with the same name exists, which is left unchanged
+//   - the DHCP forwarder is updated when it differs from the plan
+//
+// Plans with SkippedDenyRules are refused unless AllowSkippedDenyRules is set. Applying a plan again
+// only creates the missing entities, so that a failed migration can be resumed. ALB must be enabled
+// on the Edge Gateway when the plan contains ALB entities
+func (egw *NsxtEdgeGateway) ApplyNsxvMigrationPlan(plan *NsxvMigrationPlan, options NsxvMigrationApplyOptions) (*NsxvMigrationApplyResult, error) {
+	if plan == nil || plan.Firewall == nil {
+		return nil, fmt.Errorf("%s plan is empty", labelNsxvMigration)
+	}
+	if len(plan.AlbVirtualServices) > 0 && options.ServiceEngineGroupId == "" {
+		return nil, fmt.Errorf("%s plan contains ALB Virtual Services, which require a Service Engine Group", labelNsxvMigration)
+	}
+	if len(plan.SkippedDenyRules) > 0 && !options.DryRun && !options.AllowSkippedDenyRules {
+		return nil, fmt.Errorf("%s plan skipped the DROP and REJECT firewall rules %s and would allow "+
+			"the traffic they block, unless AllowSkippedDenyRules is set",
+			labelNsxvMigration, strings.Join(plan.SkippedDenyRules, ", "))
+	}
+	applier := &nsxvMigrationApplier{
+		egw:     egw,
+		plan:    plan,
+		options: options,
+		result:  &NsxvMigrationApplyResult{Applied: !options.DryRun},
+	}
+	wrapError := func(err error) error {
+		return fmt.Errorf("error applying %s of '%s' to NSX-T Edge Gateway '%s': %w",
+			labelNsxvMigration, plan.SourceName, egw.EdgeGateway.Name, err)
+	}
+
+	firewallResult, err := egw.ImportFirewallPolicy(plan.Firewall, FirewallPolicyImportOptions{Mode: FirewallPolicyImportMerge, DryRun: options.DryRun})
+	if firewallResult != nil {
+		applier.result.Changes = append(applier.result.Changes, firewallResult.Changes...)
+	}
+	if err != nil {
+		return applier.result, wrapError(err)
+	}
+	for _, apply := range []func() error{applier.applyNatRules, applier.applyDhcpForwarder, applier.applyAlb} {
+		err = apply()
+		if err != nil {
+			return applier.result, wrapError(err)
+		}
+	}
+	return applier.result, nil
+}
+
+type nsxvMigrationApplier struct {
+	egw     *NsxtEdgeGateway
+	plan    *NsxvMigrationPlan
+	options NsxvMigrationApplyOptions
+	result  *NsxvMigrationApplyResult
+}
+
+func (applier *nsxvMigrationApplier) addCreate(kind, name string) {
+	applier.result.Changes = append(applier.result.Changes, ConfigurationChange{Action: ConfigurationChangeCreate, Kind: kind, Name: name})
+}
+
+func (applier *nsxvMigrationApplier) applyNatRules() error {
+	if len(applier.plan.NatRules) == 0 {
+		return nil
+	}
+	existingRules, err := applier.egw.GetAllNatRules(nil)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, rule := range existingRules {
+		existing[rule.NsxtNatRule.Name] = true
+	}
+
+	for _, rule := range applier.plan.NatRules {
+		if existing[rule.Name] {
+			continue
+		}
+		applier.addCreate(labelNsxtNatRule, rule.Name)
+		if applier.options.DryRun {
+			continue
+		}
+		natRuleConfig := *rule
+		if rule.ApplicationPortProfile != nil {
+			// Profiles were created by the import of the firewall
+			profileId, err := applier.appPortProfileId(rule.ApplicationPortProfile.Name)
+			if err != nil {
+				return err
+			}
+			natRuleConfig.ApplicationPortProfile = &types.OpenApiReference{ID: profileId, Name: rule.ApplicationPortProfile.Name}
+		}
+		util.Logger.Printf("[DEBUG] creating %s '%s' of %s", labelNsxtNatRule, rule.Name, labelNsxvMigration)
+		_, err = applier.egw.CreateNatRule(&natRuleConfig)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (applier *nsxvMigrationApplier) appPortProfileId(name string) (string, error) {
+	contextId := ""
+	if applier.egw.EdgeGateway.OwnerRef != nil {
+		contextId = applier.egw.EdgeGateway.OwnerRef.ID
+	}
+	queryParameters := queryParameterFilterAnd(fmt.Sprintf("_context==%s;scope==%s", contextId, types.ApplicationPortProfileScopeTenant), url.Values{})
+	profile, err := getNsxtAppPortProfileByName(applier.egw.client, name, queryParameters)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving %s '%s': %w", labelNsxtAppPortProfile, name, err)
+	}
+	return profile.NsxtAppPortProfile.ID, nil
+}
+
+func (applier *nsxvMigrationApplier) applyDhcpForwarder() error {
+	desired := applier.plan.DhcpForwarder
+	if desired == nil {
+		return nil
+	}
+	current, err := applier.egw.GetDhcpForwarder()
+	if err != nil {
+		return err
+	}
+	if current.Enabled == desired.Enabled && strings.Join(sortedStrings(current.DhcpServers), ",") == strings.Join(sortedStrings(desired.DhcpServers), ",") {
+		return nil
+	}
+	applier.result.Changes = append(applier.result.Changes, ConfigurationChange{
+		Action: ConfigurationChangeUpdate,
+		Kind:   labelNsxtDhcpForwarder,
+		Name:   applier.egw.EdgeGateway.Name,
+		Fields: []string{"dhcpServers", "enabled"},
+	})
+	if applier.options.DryRun {
+		return nil
+	}
+	_, err = applier.egw.UpdateDhcpForwarder(&types.NsxtEdgeGatewayDhcpForwarder{Enabled: desired.Enabled, DhcpServers: desired.DhcpServers})
+	return err
+}
+
+func (applier *nsxvMigrationApplier) applyAlb() error {
+	if len(applier.plan.AlbPools) == 0 && len(applier.plan.AlbVirtualServices) == 0 {
+		return nil
+	}
+	client := applier.egw.client
+	edgeGatewayId := applier.egw.EdgeGateway.ID
+
+	existingPools, err := getAllAlbPoolSummaries(client, edgeGatewayId, nil)
+	if err != nil {
+		return err
+	}
+	poolIds := make(map[string]string)
+	for _, pool := range existingPools {
+		poolIds[pool.Name] = pool.ID
+	}
+	for _, pool := range applier.plan.AlbPools {
+		if _, found := poolIds[pool.Name]; found {
+			continue
+		}
+		applier.addCreate(labelNsxtAlbPool, pool.Name)
+		if applier.options.DryRun {
+			continue
+		}
+		poolConfig := *pool
+		poolConfig.GatewayRef = types.OpenApiReference{ID: edgeGatewayId}
+		created, err := createNsxtAlbPool(client, &poolConfig)
+		if err != nil {
+			return err
+		}
+		poolIds[pool.Name] = created.ID
+	}
+
+	existingVirtualServices, err := getAllAlbVirtualServiceSummaries(client, edgeGatewayId, nil)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, virtualService := range existingVirtualServices {
+		existing[virtualService.Name] = true
+	}
+	for _, virtualService := range applier.plan.AlbVirtualServices {
+		if existing[virtualService.Name] {
+			continue
+		}
+		applier.addCreate(labelNsxtAlbVirtualService, virtualService.Name)
+		if applier.options.DryRun {
+			continue
+		}
+		poolName := virtualService.LoadBalancerPoolRef.Name
+		poolId, found := poolIds[poolName]
+		if !found {
+			return fmt.Errorf("%s '%s' references unknown %s '%s'", labelNsxtAlbVirtualService, virtualService.Name, labelNsxtAlbPool, poolName)
+		}
+		virtualServiceConfig := *virtualService
+		virtualServiceConfig.GatewayRef = types.OpenApiReference{ID: edgeGatewayId}
+		virtualServiceConfig.LoadBalancerPoolRef = types.OpenApiReference{ID: poolId, Name: poolName}
+		virtualServiceConfig.ServiceEngineGroupRef = types.OpenApiReference{ID: applier.options.ServiceEngineGroupId}
+		_, err = createNsxtAlbVirtualService(client, &virtualServiceConfig)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nsxvProtocols maps NSX-V protocols to the protocols of Application Port Profiles. Any protocol
+// maps to an empty string
+var nsxvProtocols = map[string]string{"": "", "any": "", "tcp": "TCP", "udp": "UDP", "icmp": "ICMPv4"}
+
+func nsxvIsAny(value string) bool {
+	return value == "" || strings.EqualFold(value, "any")
+}
+
+// nsxvObjectId removes the VDC scope from NSX-V object IDs such as
+// 'f9daf2da-b4f9-4921-a2f4-d77a943a381c:ipset-2'
+func nsxvObjectId(id string) string {
+	return id[strings.LastIndex(id, ":")+1:]
+}
+
+// nsxvMigrationName returns the name of an NSX-V entity or, when it has none, its ID
+func nsxvMigrationName(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+// uniqueNsxvMigrationName returns name, or name with a numeric suffix when it is already used, and
+// marks it as used
+func uniqueNsxvMigrationName(used map[string]bool, name string) string {
+	unique := name
+	for index := 2; used[unique]; index++ {
+		unique = fmt.Sprintf("%s-%d", name, index)
+	}
+	used[unique] = true
+	return unique
+}
+
+// splitNsxvList splits comma separated values of NSX-V, such as the IP addresses of IP Sets
+func splitNsxvList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
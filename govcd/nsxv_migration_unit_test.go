@@ -0,0 +1,386 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+const nsxvMigrationTestVdcId = "f9daf2da-b4f9-4921-a2f4-d77a943a381c"
+
+func nsxvMigrationTestSource() *NsxvMigrationSource {
+	return &NsxvMigrationSource{
+		EdgeGatewayName: "edge-v",
+		FirewallConfig:  &types.FirewallConfigWithXml{Enabled: true, DefaultPolicy: types.FirewallDefaultPolicy{Action: "accept"}},
+		IpSets: []*types.EdgeIpSet{
+			{ID: nsxvMigrationTestVdcId + ":ipset-1", Name: "web-servers", IPAddresses: "10.0.0.10,10.0.0.11"},
+			{ID: nsxvMigrationTestVdcId + ":ipset-2", Name: "dhcp-servers", IPAddresses: "10.0.0.3,10.0.1.0/24"},
+		},
+		FirewallRules: []*types.EdgeFirewallRule{
+			{ID: "131073", Name: "firewall", RuleType: "internal_high", Action: "accept"},
+			{ID: "131074", Name: "allow-web", RuleType: "user", Action: "accept", Enabled: true, LoggingEnabled: true,
+				Source:      types.EdgeFirewallEndpoint{IpAddresses: []string{"192.168.0.0/24"}},
+				Destination: types.EdgeFirewallEndpoint{GroupingObjectIds: []string{"ipset-1"}},
+				Application: types.EdgeFirewallApplication{Services: []types.EdgeFirewallApplicationService{{Protocol: "tcp", Port: "80,443"}}}},
+			{ID: "131075", RuleType: "user", Action: "deny", Enabled: true,
+				Source: types.EdgeFirewallEndpoint{VnicGroupIds: []string{"vse"}}},
+			{ID: "131076", Name: "dns", RuleType: "user", Action: "accept", Enabled: true,
+				Application: types.EdgeFirewallApplication{Services: []types.EdgeFirewallApplicationService{{Protocol: "udp", Port: "53", SourcePort: "1024-65535"}}}},
+			{ID: "131077", Name: "allow-web", RuleType: "user", Action: "reject", Enabled: true,
+				Source: types.EdgeFirewallEndpoint{GroupingObjectIds: []string{"securitygroup-3"}}},
+			{ID: "131078", Name: "dns", RuleType: "user", Action: "deny", Direction: "in", Enabled: true,
+				Destination: types.EdgeFirewallEndpoint{IpAddresses: []string{"10.0.0.53"}}},
+		},
+		NatRules: []*types.EdgeNatRule{
+			{ID: "196609", RuleType: "user", Action: "dnat", Enabled: true, Vnic: addrOf(0), Description: "web",
+				OriginalAddress: "203.0.113.10", TranslatedAddress: "10.0.0.10", Protocol: "tcp", OriginalPort: "8080", TranslatedPort: "80"},
+			{ID: "196610", RuleType: "user", Action: "snat", Enabled: true, Vnic: addrOf(0),
+				OriginalAddress: "10.0.0.0/24", TranslatedAddress: "203.0.113.10", Protocol: "any", OriginalPort: "any", TranslatedPort: "any"},
+			{ID: "196611", RuleType: "user", Action: "snat", Enabled: true,
+				OriginalAddress: "10.0.0.10", TranslatedAddress: "203.0.113.10", Protocol: "tcp", OriginalPort: "80"},
+			{ID: "196612", RuleType: "internal_high", Action: "dnat"},
+			{ID: "196613", RuleType: "user", Action: "dnat", Enabled: true, Vnic: addrOf(1),
+				OriginalAddress: "203.0.113.11", TranslatedAddress: "10.0.0.11", Protocol: "icmp", IcmpType: "echo-request"},
+		},
+		DhcpRelay: &types.EdgeDhcpRelay{
+			RelayServer: &types.EdgeDhcpRelayServer{
+				IpAddress:        []string{"10.0.0.2"},
+				GroupingObjectId: []string{nsxvMigrationTestVdcId + ":ipset-2"},
+				Fqdns:            []string{"dhcp.example.com"},
+			},
+			RelayAgents: &types.EdgeDhcpRelayAgents{Agents: []types.EdgeDhcpRelayAgent{{VnicIndex: addrOf(1)}}},
+		},
+		LbMonitors: []*types.LbMonitor{{ID: "monitor-1", Name: "web-monitor", Type: "http", URL: "/health"}},
+		LbPools: []*types.LbPool{
+			{ID: "pool-1", Name: "web-pool", Algorithm: "round-robin", MonitorId: "monitor-1", Members: types.LbPoolMembers{
+				{Name: "web1", IpAddress: "10.0.0.10", Port: 80, Weight: 1, Condition: "enabled"},
+				{Name: "web2", IpAddress: "10.0.0.11", Port: 80, Weight: 50, Condition: "disabled"},
+			}},
+			{ID: "pool-2", Name: "uri-pool", Algorithm: "uri", Members: types.LbPoolMembers{{Name: "dns1", IpAddress: "10.0.0.53", Port: 53}}},
+		},
+		LbAppProfiles: []*types.LbAppProfile{
+			{ID: "applicationProfile-1", Template: "HTTP", InsertXForwardedForHttpHeader: true,
+				Persistence: &types.LbAppProfilePersistence{Method: "cookie", CookieName: "JSESSIONID"}},
+		},
+		LbVirtualServers: []*types.LbVirtualServer{
+			{ID: "virtualServer-1", Name: "web-vs", Enabled: true, IpAddress: "203.0.113.20", Protocol: "http", Port: 80,
+				ApplicationProfileId: "applicationProfile-1", DefaultPoolId: "pool-1", ApplicationRuleIds: []string{"applicationRule-1"}},
+			{ID: "virtualServer-2", Name: "secure-vs", Enabled: true, IpAddress: "203.0.113.20", Protocol: "https", Port: 443, DefaultPoolId: "pool-1"},
+			{ID: "virtualServer-3", Name: "dns-vs", Enabled: true, IpAddress: "203.0.113.21", Protocol: "udp", Port: 53, DefaultPoolId: "pool-2"},
+		},
+	}
+}
+
+func TestNsxvMigrationSource_Translate(t *testing.T) {
+	plan := nsxvMigrationTestSource().Translate()
+
+	expectedReport := strings.Join([]string{
+		"NSX-V migration of NSX-V Edge Gateway 'edge-v': 4 firewall rules, 3 Firewall Groups, 4 Application Port Profiles, " +
+			"3 NAT rules, 2 ALB Pools, 2 ALB Virtual Services, DHCP forwarder: yes",
+		"! NSX-V Firewall Rule 131075: the source contains interface groups vse, which have no NSX-T equivalent",
+		"~ NSX-V Firewall Rule dns: source ports 1024-65535 of UDP services are not supported, all source ports are matched",
+		"! NSX-V Firewall Rule allow-web: the source contains grouping object 'securitygroup-3', which is not an IP Set",
+		"! NSX-V NAT Rule snat-196611: SNAT rules with a protocol or ports are not supported by NSX-T",
+		"~ NSX-V NAT Rule dnat-196613: ICMP type echo-request is not supported, all ICMP types are translated",
+		"~ NSX-V NAT Rule dnat-196613: the rule applies to vNic 1, while NSX-T NAT rules apply to the uplink of the Edge Gateway",
+		"~ NSX-V DHCP Relay edge-v: IP Set 'dhcp-servers' contains '10.0.1.0/24', which is not a DHCP server address",
+		"~ NSX-V DHCP Relay edge-v: DHCP servers dhcp.example.com are FQDNs, which are not supported by the DHCP forwarder",
+		"~ NSX-V DHCP Relay edge-v: relay agents are replaced by setting the DHCP mode of the Org VDC networks to RELAY",
+		"~ NSX-V Load Balancer Pool web-pool: parameters of monitor 'web-monitor' are not translated, the system defined HTTP monitor is used",
+		"~ NSX-V Load Balancer Pool web-pool: weight 50 of member 'web2' exceeds the maximum ratio 20",
+		"~ NSX-V Load Balancer Pool uri-pool: algorithm 'uri' is not supported, LEAST_CONNECTIONS is used",
+		"~ NSX-V Load Balancer Virtual Server web-vs: application rules applicationRule-1 are not translated",
+		"~ NSX-V Load Balancer Virtual Server web-vs: insertion of the X-Forwarded-For header is not translated",
+		"! NSX-V Load Balancer Virtual Server secure-vs: HTTPS requires a certificate, which must be configured manually",
+	}, "\n")
+	if plan.Report() != expectedReport {
+		t.Errorf("unexpected report:\n%s\nexpected:\n%s", plan.Report(), expectedReport)
+	}
+
+	// Firewall
+	if strings.Join(plan.SkippedDenyRules, ",") != "131075,allow-web" {
+		t.Errorf("unexpected skipped deny rules %v", plan.SkippedDenyRules)
+	}
+	err := plan.Firewall.validate()
+	if err != nil {
+		t.Fatalf("expected a valid firewall document, got %s", err)
+	}
+	var ruleNames, groupNames, profileNames []string
+	for _, rule := range plan.Firewall.Rules {
+		ruleNames = append(ruleNames, rule.Name)
+	}
+	for _, group := range plan.Firewall.FirewallGroups {
+		groupNames = append(groupNames, group.Name)
+	}
+	for _, profile := range plan.Firewall.ApplicationPortProfiles {
+		profileNames = append(profileNames, profile.Name)
+	}
+	if strings.Join(ruleNames, ",") != "allow-web,dns,dns-2,default-accept" ||
+		strings.Join(groupNames, ",") != "allow-web-source,web-servers,dns-2-destination" ||
+		strings.Join(profileNames, ",") != "nsxv-TCP_80_443,nsxv-UDP_53,nsxv-TCP_80,nsxv-ICMPv4" {
+		t.Errorf("unexpected firewall rules %v, groups %v and profiles %v", ruleNames, groupNames, profileNames)
+	}
+	allowWeb := plan.Firewall.Rules[0]
+	if allowWeb.ActionValue != "ALLOW" || !allowWeb.Logging || allowWeb.Direction != "IN_OUT" ||
+		allowWeb.SourceFirewallGroups[0].Name != "allow-web-source" || allowWeb.DestinationFirewallGroups[0].Name != "web-servers" ||
+		allowWeb.ApplicationPortProfiles[0].Scope != types.ApplicationPortProfileScopeTenant {
+		t.Errorf("unexpected rule %+v", allowWeb)
+	}
+	if plan.Firewall.Rules[2].ActionValue != "DROP" || plan.Firewall.Rules[2].Direction != "IN" {
+		t.Errorf("unexpected rule %+v", plan.Firewall.Rules[2])
+	}
+	if webServers := plan.Firewall.FirewallGroups[1]; strings.Join(webServers.IpAddresses, ",") != "10.0.0.10,10.0.0.11" {
+		t.Errorf("unexpected IP Set %+v", webServers)
+	}
+
+	// NAT
+	dnat, snat, icmp := plan.NatRules[0], plan.NatRules[1], plan.NatRules[2]
+	if dnat.Name != "dnat-196609" || dnat.RuleType != types.NsxtNatRuleTypeDnat || dnat.ExternalAddresses != "203.0.113.10" ||
+		dnat.InternalAddresses != "10.0.0.10" || dnat.ApplicationPortProfile.Name != "nsxv-TCP_80" || dnat.DnatExternalPort != "8080" ||
+		dnat.FirewallMatch != types.NsxtNatRuleFirewallMatchExternalAddress || dnat.Description != "web" {
+		t.Errorf("unexpected DNAT rule %+v", dnat)
+	}
+	if snat.RuleType != types.NsxtNatRuleTypeSnat || snat.InternalAddresses != "10.0.0.0/24" || snat.ExternalAddresses != "203.0.113.10" ||
+		snat.ApplicationPortProfile != nil || snat.FirewallMatch != types.NsxtNatRuleFirewallMatchInternalAddress {
+		t.Errorf("unexpected SNAT rule %+v", snat)
+	}
+	if icmp.ApplicationPortProfile.Name != "nsxv-ICMPv4" || icmp.DnatExternalPort != "" {
+		t.Errorf("unexpected ICMP DNAT rule %+v", icmp)
+	}
+
+	// DHCP and ALB
+	if strings.Join(plan.DhcpForwarder.DhcpServers, ",") != "10.0.0.2,10.0.0.3" || !plan.DhcpForwarder.Enabled {
+		t.Errorf("unexpected DHCP forwarder %+v", plan.DhcpForwarder)
+	}
+	webPool := plan.AlbPools[0]
+	if webPool.Algorithm != "ROUND_ROBIN" || webPool.HealthMonitors[0].Type != "HTTP" || *webPool.Members[1].Ratio != 20 ||
+		webPool.Members[1].Enabled || !webPool.Members[0].Enabled || webPool.PersistenceProfile == nil ||
+		*webPool.PersistenceProfile != (types.NsxtAlbPoolPersistenceProfile{Type: "HTTP_COOKIE", Value: "JSESSIONID"}) {
+		t.Errorf("unexpected ALB Pool %+v", webPool)
+	}
+	webVs, dnsVs := plan.AlbVirtualServices[0], plan.AlbVirtualServices[1]
+	if webVs.LoadBalancerPoolRef.Name != "web-pool" || webVs.ApplicationProfile.Type != "HTTP" || *webVs.ServicePorts[0].PortStart != 80 {
+		t.Errorf("unexpected ALB Virtual Service %+v", webVs)
+	}
+	if dnsVs.ApplicationProfile.Type != "L4" || dnsVs.ServicePorts[0].TcpUdpProfile.Type != "UDP_FAST_PATH" || dnsVs.LoadBalancerPoolRef.Name != "uri-pool" {
+		t.Errorf("unexpected ALB Virtual Service %+v", dnsVs)
+	}
+
+	// The plan can be stored for review
+	text, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("error marshalling plan: %s", err)
+	}
+	stored := &NsxvMigrationPlan{}
+	err = json.Unmarshal(text, stored)
+	if err != nil {
+		t.Fatalf("error unmarshalling plan: %s", err)
+	}
+	if stored.Report() != expectedReport {
+		t.Errorf("unexpected report of the stored plan:\n%s", stored.Report())
+	}
+}
+
+// nsxvMigrationTestNatRules returns the NAT rules of the Edge Gateway by name
+func nsxvMigrationTestNatRules(t *testing.T, egw *NsxtEdgeGateway) map[string]*types.NsxtNatRule {
+	t.Helper()
+	rules, err := egw.GetAllNatRules(nil)
+	if err != nil {
+		t.Fatalf("error retrieving NAT rules: %s", err)
+	}
+	result := make(map[string]*types.NsxtNatRule)
+	for _, rule := range rules {
+		result[rule.NsxtNatRule.Name] = rule.NsxtNatRule
+	}
+	return result
+}
+
+func TestNsxtEdgeGateway_ApplyNsxvMigrationPlan(t *testing.T) {
+	_, vcdClient := testServerClient(t)
+	egw := testEdgeGateway(t, vcdClient, "edge-t")
+	egwId := egw.EdgeGateway.ID
+	existingPool, err := vcdClient.CreateNsxtAlbPool(&types.NsxtAlbPool{Name: "uri-pool", GatewayRef: types.OpenApiReference{ID: egwId}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rulesEtag, err := getNsxtFirewallRulesRaw(egw.client, egwId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := nsxvMigrationTestSource().Translate()
+
+	_, err = egw.ApplyNsxvMigrationPlan(plan, NsxvMigrationApplyOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Service Engine Group") {
+		t.Errorf("expected an error without Service Engine Group, got %v", err)
+	}
+
+	// Dry run
+	options := NsxvMigrationApplyOptions{ServiceEngineGroupId: "urn:vcloud:serviceEngineGroup:seg", DryRun: true}
+	result, err := egw.ApplyNsxvMigrationPlan(plan, options)
+	if err != nil {
+		t.Fatalf("error in dry run: %s", err)
+	}
+	expectedDiff := strings.Join([]string{
+		"+ NSX-T Firewall Group allow-web-source",
+		"+ NSX-T Firewall Group web-servers",
+		"+ NSX-T Firewall Group dns-2-destination",
+		"+ NSX-T Application Port Profile nsxv-TCP_80_443",
+		"+ NSX-T Application Port Profile nsxv-UDP_53",
+		"+ NSX-T Application Port Profile nsxv-TCP_80",
+		"+ NSX-T Application Port Profile nsxv-ICMPv4",
+		"+ NSX-T Edge Gateway Firewall Rule allow-web",
+		"+ NSX-T Edge Gateway Firewall Rule dns",
+		"+ NSX-T Edge Gateway Firewall Rule dns-2",
+		"+ NSX-T Edge Gateway Firewall Rule default-accept",
+		"+ NSX-T NAT Rule dnat-196609",
+		"+ NSX-T NAT Rule snat-196610",
+		"+ NSX-T NAT Rule dnat-196613",
+		"~ NSX-T DHCP Forwarder edge-t (dhcpServers, enabled)",
+		"+ NSX-T ALB Pool web-pool",
+		"+ NSX-T ALB Virtual Service web-vs",
+		"+ NSX-T ALB Virtual Service dns-vs",
+	}, "\n")
+	if result.String() != expectedDiff || result.Applied {
+		t.Errorf("unexpected dry run result (applied %t):\n%s\nexpected:\n%s", result.Applied, result, expectedDiff)
+	}
+	_, etagAfterDryRun, err := getNsxtFirewallRulesRaw(egw.client, egwId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pools, err := vcdClient.GetAllAlbPoolSummaries(egwId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etagAfterDryRun != rulesEtag || len(nsxvMigrationTestNatRules(t, egw)) != 0 || len(pools) != 1 {
+		t.Errorf("expected no change in a dry run")
+	}
+
+	// Apply
+	options.DryRun = false
+	_, err = egw.ApplyNsxvMigrationPlan(plan, options)
+	if err == nil || !strings.Contains(err.Error(), "131075, allow-web") {
+		t.Errorf("expected an error for the skipped deny rules, got %v", err)
+	}
+	_, etagAfterRefusal, err := getNsxtFirewallRulesRaw(egw.client, egwId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etagAfterRefusal != rulesEtag {
+		t.Errorf("expected no change when the plan is refused")
+	}
+	options.AllowSkippedDenyRules = true
+	result, err = egw.ApplyNsxvMigrationPlan(plan, options)
+	if err != nil {
+		t.Fatalf("error applying plan: %s", err)
+	}
+	if result.String() != expectedDiff || !result.Applied {
+		t.Errorf("unexpected result:\n%s", result)
+	}
+	firewall, err := egw.GetNsxtFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ruleNames []string
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		ruleNames = append(ruleNames, rule.Name)
+	}
+	if strings.Join(ruleNames, ",") != "allow-web,dns,dns-2,default-accept" {
+		t.Errorf("unexpected firewall rules %v", ruleNames)
+	}
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := org.GetNsxtAppPortProfileByName("nsxv-TCP_80", types.ApplicationPortProfileScopeTenant)
+	if err != nil {
+		t.Fatalf("expected the profile of the NAT rules to be created: %s", err)
+	}
+	natRules := nsxvMigrationTestNatRules(t, egw)
+	dnat := natRules["dnat-196609"]
+	if len(natRules) != 3 || dnat == nil {
+		t.Fatalf("expected NAT rules to be created, got %v", natRules)
+	}
+	if dnat.ApplicationPortProfile.ID != profile.NsxtAppPortProfile.ID || dnat.DnatExternalPort != "8080" {
+		t.Errorf("unexpected DNAT rule %+v", dnat)
+	}
+	forwarder, err := egw.GetDhcpForwarder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !forwarder.Enabled || len(forwarder.DhcpServers) != 2 {
+		t.Errorf("unexpected DHCP forwarder %+v", forwarder)
+	}
+	webPool, err := vcdClient.GetAlbPoolByName(egwId, "web-pool")
+	if err != nil {
+		t.Fatalf("expected ALB Pool to be created: %s", err)
+	}
+	dnsVs, err := vcdClient.GetAlbVirtualServiceByName(egwId, "dns-vs")
+	if err != nil {
+		t.Fatalf("expected ALB Virtual Service to be created: %s", err)
+	}
+	if webPool.NsxtAlbPool.GatewayRef.ID != egwId || dnsVs.NsxtAlbVirtualService.LoadBalancerPoolRef.ID != existingPool.NsxtAlbPool.ID ||
+		dnsVs.NsxtAlbVirtualService.ServiceEngineGroupRef.ID != options.ServiceEngineGroupId {
+		t.Errorf("unexpected ALB Pool %+v or Virtual Service %+v", webPool.NsxtAlbPool, dnsVs.NsxtAlbVirtualService)
+	}
+
+	// Applying again only creates what is missing
+	_, rulesEtag, err = getNsxtFirewallRulesRaw(egw.client, egwId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = egw.ApplyNsxvMigrationPlan(plan, options)
+	if err != nil {
+		t.Fatalf("error applying plan again: %s", err)
+	}
+	_, etagAfterReapply, err := getNsxtFirewallRulesRaw(egw.client, egwId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Changes) != 0 || len(nsxvMigrationTestNatRules(t, egw)) != 3 || etagAfterReapply != rulesEtag {
+		t.Errorf("expected no changes applying the plan again, got:\n%s", result)
+	}
+}
+
+func TestNsxtEdgeGateway_ApplyNsxvMigrationPlanSkippedDenyRule(t *testing.T) {
+	source := &NsxvMigrationSource{
+		EdgeGatewayName: "edge-v",
+		FirewallConfig:  &types.FirewallConfigWithXml{Enabled: true, DefaultPolicy: types.FirewallDefaultPolicy{Action: "deny"}},
+		FirewallRules: []*types.EdgeFirewallRule{
+			{ID: "131074", Name: "block-admin", RuleType: "user", Action: "deny", Enabled: true,
+				Source: types.EdgeFirewallEndpoint{IpAddresses: []string{"10.0.0.0/8"}, Exclude: true}},
+			{ID: "131075", Name: "allow-all", RuleType: "user", Action: "accept", Enabled: true},
+		},
+	}
+	plan := source.Translate()
+	if strings.Join(plan.SkippedDenyRules, ",") != "block-admin" || len(plan.Firewall.Rules) != 1 ||
+		plan.Firewall.Rules[0].ActionValue != "ALLOW" {
+		t.Fatalf("expected the DROP rule to be skipped and the ALLOW rule to be kept, got %v and %+v",
+			plan.SkippedDenyRules, plan.Firewall.Rules)
+	}
+
+	// The plan is refused before contacting VCD
+	egw := &NsxtEdgeGateway{EdgeGateway: &types.OpenAPIEdgeGateway{ID: "urn:vcloud:gateway:target", Name: "edge-t"}}
+	_, err := egw.ApplyNsxvMigrationPlan(plan, NsxvMigrationApplyOptions{})
+	if err == nil || !strings.Contains(err.Error(), "block-admin") || !strings.Contains(err.Error(), "AllowSkippedDenyRules") {
+		t.Errorf("expected an error for the skipped DROP rule, got %v", err)
+	}
+
+	// The stored plan keeps the skipped rules
+	text, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("error marshalling plan: %s", err)
+	}
+	stored := &NsxvMigrationPlan{}
+	err = json.Unmarshal(text, stored)
+	if err != nil {
+		t.Fatalf("error unmarshalling plan: %s", err)
+	}
+	_, err = egw.ApplyNsxvMigrationPlan(stored, NsxvMigrationApplyOptions{})
+	if err == nil || !strings.Contains(err.Error(), "block-admin") {
+		t.Errorf("expected an error for the skipped DROP rule of the stored plan, got %v", err)
+	}
+}
@@ -39,7 +39,7 @@ func (egw *EdgeGateway) CreateNsxvNatRule(natRuleConfig *types.EdgeNatRule) (*ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateNatPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(httpPath, http.MethodPost, types.AnyXMLMime,
@@ -57,7 +57,7 @@ func (egw *EdgeGateway) CreateNsxvNatRule(natRuleConfig *types.EdgeNatRule) (*ty
 
 	readNatRule, err := egw.GetNsxvNatRuleById(natRuleId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve NAT rule with ID (%s) after creation: %s",
+		return nil, fmt.Errorf("unable to retrieve NAT rule with ID (%s) after creation: %w",
 			natRuleId, err)
 	}
 	return readNatRule, nil
@@ -73,7 +73,7 @@ func (egw *EdgeGateway) UpdateNsxvNatRule(natRuleConfig *types.EdgeNatRule) (*ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateNatPath + "/" + natRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -85,7 +85,7 @@ func (egw *EdgeGateway) UpdateNsxvNatRule(natRuleConfig *types.EdgeNatRule) (*ty
 
 	readNatRule, err := egw.GetNsxvNatRuleById(natRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve NAT rule with ID (%s) after update: %s",
+		return nil, fmt.Errorf("unable to retrieve NAT rule with ID (%s) after update: %w",
 			readNatRule.ID, err)
 	}
 	return readNatRule, nil
@@ -95,7 +95,7 @@ func (egw *EdgeGateway) UpdateNsxvNatRule(natRuleConfig *types.EdgeNatRule) (*ty
 func (egw *EdgeGateway) GetNsxvNatRules() ([]*types.EdgeNatRule, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeNatPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	natRuleResponse := &responseEdgeNatRules{}
@@ -142,7 +142,7 @@ func (egw *EdgeGateway) DeleteNsxvNatRuleById(id string) error {
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateNatPath + "/" + id)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// check if the rule exists and pass back the error at it may be 'ErrorEntityNotFound'
@@ -6,9 +6,11 @@ package govcd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -61,7 +63,7 @@ func (client *Client) OpenApiBuildEndpoint(endpoint ...string) (*url.URL, error)
 	endpointString := client.rootVcdHref() + "/cloudapi/" + strings.Join(endpoint, "")
 	urlRef, err := url.ParseRequestURI(endpointString)
 	if err != nil {
-		return nil, fmt.Errorf("error formatting OpenAPI endpoint: %s", err)
+		return nil, fmt.Errorf("error formatting OpenAPI endpoint: %w", err)
 	}
 	return urlRef, nil
 }
@@ -73,6 +75,12 @@ func (client *Client) OpenApiBuildEndpoint(endpoint ...string) (*url.URL, error)
 //
 // Note. Query parameter 'pageSize' is defaulted to 128 (maximum supported) unless it is specified in queryParams
 func (client *Client) OpenApiGetAllItems(apiVersion string, urlRef *url.URL, queryParams url.Values, outType interface{}, additionalHeader map[string]string) error {
+	return client.OpenApiGetAllItemsCtx(context.Background(), apiVersion, urlRef, queryParams, outType, additionalHeader)
+}
+
+// OpenApiGetAllItemsCtx is the context aware version of OpenApiGetAllItems. Crawling of pages stops
+// as soon as the given context is done.
+func (client *Client) OpenApiGetAllItemsCtx(ctx context.Context, apiVersion string, urlRef *url.URL, queryParams url.Values, outType interface{}, additionalHeader map[string]string) error {
 	// copy passed in URL ref so that it is not mutated
 	urlRefCopy := copyUrlRef(urlRef)
 
@@ -88,11 +96,11 @@ func (client *Client) OpenApiGetAllItems(apiVersion string, urlRef *url.URL, que
 	newQueryParams := defaultPageSize(queryParams, "128")
 	util.Logger.Printf("[TRACE] Will use 'pageSize=%s'", newQueryParams.Get("pageSize"))
 
-	// Perform API call to initial endpoint. The function call recursively follows pages using Link headers "nextPage"
-	// until it crawls all results
-	responses, err := client.openApiGetAllPages(apiVersion, urlRefCopy, newQueryParams, outType, nil, additionalHeader)
+	// Perform API call to initial endpoint. The function follows pages using Link headers "nextPage" until it crawls
+	// all results
+	responses, err := client.openApiGetAllPages(ctx, apiVersion, urlRefCopy, newQueryParams, additionalHeader)
 	if err != nil {
-		return fmt.Errorf("error getting all pages for endpoint %s: %s", urlRefCopy.String(), err)
+		return fmt.Errorf("error getting all pages for endpoint %s: %w", urlRefCopy.String(), err)
 	}
 
 	// Create a slice of raw JSON messages in text so that they can be unmarshalled to specified `outType` after multiple
@@ -108,7 +116,7 @@ func (client *Client) OpenApiGetAllItems(apiVersion string, urlRef *url.URL, que
 
 	// Unmarshal all accumulated responses into `outType`
 	if err = json.Unmarshal([]byte(allResponses), &outType); err != nil {
-		return fmt.Errorf("error decoding values into type: %s", err)
+		return fmt.Errorf("error decoding values into type: %w", err)
 	}
 
 	return nil
@@ -120,7 +128,12 @@ func (client *Client) OpenApiGetAllItems(apiVersion string, urlRef *url.URL, que
 // returned this function returns "ErrorEntityNotFound: API_ERROR" so that one can use ContainsNotFound(err) to
 // differentiate when an object was not found from any other error.
 func (client *Client) OpenApiGetItem(apiVersion string, urlRef *url.URL, params url.Values, outType interface{}, additionalHeader map[string]string) error {
-	_, err := client.OpenApiGetItemAndHeaders(apiVersion, urlRef, params, outType, additionalHeader)
+	return client.OpenApiGetItemCtx(context.Background(), apiVersion, urlRef, params, outType, additionalHeader)
+}
+
+// OpenApiGetItemCtx is the context aware version of OpenApiGetItem
+func (client *Client) OpenApiGetItemCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, outType interface{}, additionalHeader map[string]string) error {
+	_, err := client.OpenApiGetItemAndHeadersCtx(ctx, apiVersion, urlRef, params, outType, additionalHeader)
 	return err
 }
 
@@ -130,6 +143,11 @@ func (client *Client) OpenApiGetItem(apiVersion string, urlRef *url.URL, params
 // returned this function returns "ErrorEntityNotFound: API_ERROR" so that one can use ContainsNotFound(err) to
 // differentiate when an object was not found from any other error.
 func (client *Client) OpenApiGetItemAndHeaders(apiVersion string, urlRef *url.URL, params url.Values, outType interface{}, additionalHeader map[string]string) (http.Header, error) {
+	return client.OpenApiGetItemAndHeadersCtx(context.Background(), apiVersion, urlRef, params, outType, additionalHeader)
+}
+
+// OpenApiGetItemAndHeadersCtx is the context aware version of OpenApiGetItemAndHeaders
+func (client *Client) OpenApiGetItemAndHeadersCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, outType interface{}, additionalHeader map[string]string) (http.Header, error) {
 	// copy passed in URL ref so that it is not mutated
 	urlRefCopy := copyUrlRef(urlRef)
 
@@ -140,10 +158,10 @@ func (client *Client) OpenApiGetItemAndHeaders(apiVersion string, urlRef *url.UR
 		return nil, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
-	req := client.newOpenApiRequest(apiVersion, params, http.MethodGet, urlRefCopy, nil, additionalHeader)
+	req := client.newOpenApiRequestCtx(ctx, apiVersion, params, http.MethodGet, urlRefCopy, nil, additionalHeader)
 	resp, err := client.Http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error performing GET request to %s: %s", urlRefCopy.String(), err)
+		return nil, fmt.Errorf("error performing GET request to %s: %w", urlRefCopy.String(), err)
 	}
 
 	// Bypassing the regular path using function checkRespWithErrType and returning parsed error directly
@@ -151,7 +169,7 @@ func (client *Client) OpenApiGetItemAndHeaders(apiVersion string, urlRef *url.UR
 	if resp.StatusCode == http.StatusForbidden {
 		err := ParseErr(types.BodyTypeJSON, resp, &types.OpenApiError{})
 		closeErr := resp.Body.Close()
-		return nil, fmt.Errorf("%s: %s [body close error: %s]", ErrorEntityNotFound, err, closeErr)
+		return nil, fmt.Errorf("%w: %w [body close error: %w]", ErrorEntityNotFound, err, closeErr)
 	}
 
 	// resp is ignored below because it is the same as above
@@ -159,16 +177,16 @@ func (client *Client) OpenApiGetItemAndHeaders(apiVersion string, urlRef *url.UR
 
 	// Any other error occurred
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP GET request: %s", err)
+		return nil, fmt.Errorf("error in HTTP GET request: %w", err)
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return nil, fmt.Errorf("error decoding JSON response after GET: %s", err)
+		return nil, fmt.Errorf("error decoding JSON response after GET: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing response body: %s", err)
+		return nil, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return resp.Header, nil
@@ -192,7 +210,7 @@ func (client *Client) OpenApiPostItemSync(apiVersion string, urlRef *url.URL, pa
 		return fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
-	resp, err := client.openApiPerformPostPut(http.MethodPost, apiVersion, urlRefCopy, params, payload, nil)
+	resp, err := client.openApiPerformPostPut(context.Background(), http.MethodPost, apiVersion, urlRefCopy, params, payload, nil)
 	if err != nil {
 		return err
 	}
@@ -204,12 +222,12 @@ func (client *Client) OpenApiPostItemSync(apiVersion string, urlRef *url.URL, pa
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after POST: %s", err)
+		return fmt.Errorf("error decoding JSON response after POST: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -242,7 +260,7 @@ func (client *Client) OpenApiPostItemAsyncWithHeaders(apiVersion string, urlRef
 		return Task{}, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
-	resp, err := client.openApiPerformPostPut(http.MethodPost, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	resp, err := client.openApiPerformPostPut(context.Background(), http.MethodPost, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return Task{}, err
 	}
@@ -257,7 +275,7 @@ func (client *Client) OpenApiPostItemAsyncWithHeaders(apiVersion string, urlRef
 
 	err = resp.Body.Close()
 	if err != nil {
-		return Task{}, fmt.Errorf("error closing response body: %s", err)
+		return Task{}, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// Asynchronous case returns "Location" header pointing to XML task
@@ -275,7 +293,13 @@ func (client *Client) OpenApiPostItemAsyncWithHeaders(apiVersion string, urlRef
 // asynchronous requests. The urlRef must point to POST endpoint (e.g. '/1.0.0/edgeGateways'). When a task is
 // synchronous - it will track task until it is finished and pick reference to marshal outType.
 func (client *Client) OpenApiPostItem(apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) error {
-	_, err := client.OpenApiPostItemAndGetHeaders(apiVersion, urlRef, params, payload, outType, additionalHeader)
+	return client.OpenApiPostItemCtx(context.Background(), apiVersion, urlRef, params, payload, outType, additionalHeader)
+}
+
+// OpenApiPostItemCtx is the context aware version of OpenApiPostItem. The given context is also
+// used while tracking the task of an asynchronous request.
+func (client *Client) OpenApiPostItemCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) error {
+	_, err := client.OpenApiPostItemAndGetHeadersCtx(ctx, apiVersion, urlRef, params, payload, outType, additionalHeader)
 	return err
 }
 
@@ -283,6 +307,11 @@ func (client *Client) OpenApiPostItem(apiVersion string, urlRef *url.URL, params
 // asynchronous requests, that returns also the response headers. The urlRef must point to POST endpoint (e.g. '/1.0.0/edgeGateways'). When a task is
 // synchronous - it will track task until it is finished and pick reference to marshal outType.
 func (client *Client) OpenApiPostItemAndGetHeaders(apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) (http.Header, error) {
+	return client.OpenApiPostItemAndGetHeadersCtx(context.Background(), apiVersion, urlRef, params, payload, outType, additionalHeader)
+}
+
+// OpenApiPostItemAndGetHeadersCtx is the context aware version of OpenApiPostItemAndGetHeaders
+func (client *Client) OpenApiPostItemAndGetHeadersCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) (http.Header, error) {
 	// copy passed in URL ref so that it is not mutated
 	urlRefCopy := copyUrlRef(urlRef)
 
@@ -293,7 +322,7 @@ func (client *Client) OpenApiPostItemAndGetHeaders(apiVersion string, urlRef *ur
 		return nil, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
-	resp, err := client.openApiPerformPostPut(http.MethodPost, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	resp, err := client.openApiPerformPostPut(ctx, http.MethodPost, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -307,9 +336,9 @@ func (client *Client) OpenApiPostItemAndGetHeaders(apiVersion string, urlRef *ur
 		util.Logger.Printf("[TRACE] Asynchronous task detected, tracking task with HREF: %s", taskUrl)
 		task := NewTask(client)
 		task.Task.HREF = taskUrl
-		err = task.WaitTaskCompletion()
+		err = task.WaitTaskCompletionCtx(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error waiting completion of task (%s): %s", taskUrl, err)
+			return nil, fmt.Errorf("error waiting completion of task (%s): %w", taskUrl, err)
 		}
 
 		// Here we have to find the resource once more to return it populated.
@@ -321,22 +350,22 @@ func (client *Client) OpenApiPostItemAndGetHeaders(apiVersion string, urlRef *ur
 		}
 		newObjectUrl := urlParseRequestURI(urlRefCopy.String() + task.Task.Owner.ID)
 
-		err = client.OpenApiGetItem(apiVersion, newObjectUrl, nil, outType, additionalHeader)
+		err = client.OpenApiGetItemCtx(ctx, apiVersion, newObjectUrl, nil, outType, additionalHeader)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving item after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving item after creation: %w", err)
 		}
 
 		// Synchronous task - new item body is returned in response of HTTP POST request
 	case http.StatusCreated, http.StatusOK:
 		util.Logger.Printf("[TRACE] Synchronous task detected (HTTP Status %d), marshalling outType '%s'", resp.StatusCode, reflect.TypeOf(outType))
 		if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-			return nil, fmt.Errorf("error decoding JSON response after POST: %s", err)
+			return nil, fmt.Errorf("error decoding JSON response after POST: %w", err)
 		}
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing response body: %s", err)
+		return nil, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return resp.Header, nil
@@ -373,7 +402,7 @@ func (client *Client) OpenApiPostUrlEncoded(apiVersion string, urlRef *url.URL,
 	// resp is ignored below because it is the same the one above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return fmt.Errorf("error in HTTP %s request: %s", http.MethodPost, err)
+		return fmt.Errorf("error in HTTP %s request: %w", http.MethodPost, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -381,12 +410,12 @@ func (client *Client) OpenApiPostUrlEncoded(apiVersion string, urlRef *url.URL,
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after POST: %s", err)
+		return fmt.Errorf("error decoding JSON response after POST: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -410,7 +439,7 @@ func (client *Client) OpenApiPutItemSync(apiVersion string, urlRef *url.URL, par
 		return fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
-	resp, err := client.openApiPerformPostPut(http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	resp, err := client.openApiPerformPostPut(context.Background(), http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return err
 	}
@@ -420,12 +449,12 @@ func (client *Client) OpenApiPutItemSync(apiVersion string, urlRef *url.URL, par
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after PUT: %s", err)
+		return fmt.Errorf("error decoding JSON response after PUT: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -447,7 +476,7 @@ func (client *Client) OpenApiPutItemAsync(apiVersion string, urlRef *url.URL, pa
 	if !client.OpenApiIsSupported() {
 		return Task{}, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
-	resp, err := client.openApiPerformPostPut(http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	resp, err := client.openApiPerformPostPut(context.Background(), http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return Task{}, err
 	}
@@ -458,7 +487,7 @@ func (client *Client) OpenApiPutItemAsync(apiVersion string, urlRef *url.URL, pa
 
 	err = resp.Body.Close()
 	if err != nil {
-		return Task{}, fmt.Errorf("error closing response body: %s", err)
+		return Task{}, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// Asynchronous case returns "Location" header pointing to XML task
@@ -476,7 +505,13 @@ func (client *Client) OpenApiPutItemAsync(apiVersion string, urlRef *url.URL, pa
 // The urlRef must point to ID of exact item (e.g. '/1.0.0/edgeGateways/{EDGE_ID}')
 // It handles synchronous and asynchronous tasks. When a task is synchronous - it will block until it is finished.
 func (client *Client) OpenApiPutItem(apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) error {
-	_, err := client.OpenApiPutItemAndGetHeaders(apiVersion, urlRef, params, payload, outType, additionalHeader)
+	return client.OpenApiPutItemCtx(context.Background(), apiVersion, urlRef, params, payload, outType, additionalHeader)
+}
+
+// OpenApiPutItemCtx is the context aware version of OpenApiPutItem. The given context is also
+// used while tracking the task of an asynchronous request.
+func (client *Client) OpenApiPutItemCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) error {
+	_, err := client.OpenApiPutItemAndGetHeadersCtx(ctx, apiVersion, urlRef, params, payload, outType, additionalHeader)
 	return err
 }
 
@@ -484,6 +519,11 @@ func (client *Client) OpenApiPutItem(apiVersion string, urlRef *url.URL, params
 // The urlRef must point to ID of exact item (e.g. '/1.0.0/edgeGateways/{EDGE_ID}')
 // It handles synchronous and asynchronous tasks. When a task is synchronous - it will block until it is finished.
 func (client *Client) OpenApiPutItemAndGetHeaders(apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) (http.Header, error) {
+	return client.OpenApiPutItemAndGetHeadersCtx(context.Background(), apiVersion, urlRef, params, payload, outType, additionalHeader)
+}
+
+// OpenApiPutItemAndGetHeadersCtx is the context aware version of OpenApiPutItemAndGetHeaders
+func (client *Client) OpenApiPutItemAndGetHeadersCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, payload, outType interface{}, additionalHeader map[string]string) (http.Header, error) {
 	// copy passed in URL ref so that it is not mutated
 	urlRefCopy := copyUrlRef(urlRef)
 
@@ -493,7 +533,7 @@ func (client *Client) OpenApiPutItemAndGetHeaders(apiVersion string, urlRef *url
 	if !client.OpenApiIsSupported() {
 		return nil, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
-	resp, err := client.openApiPerformPostPut(http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	resp, err := client.openApiPerformPostPut(ctx, http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
 
 	if err != nil {
 		return nil, err
@@ -508,28 +548,28 @@ func (client *Client) OpenApiPutItemAndGetHeaders(apiVersion string, urlRef *url
 		util.Logger.Printf("[TRACE] Asynchronous task detected, tracking task with HREF: %s", taskUrl)
 		task := NewTask(client)
 		task.Task.HREF = taskUrl
-		err = task.WaitTaskCompletion()
+		err = task.WaitTaskCompletionCtx(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error waiting completion of task (%s): %s", taskUrl, err)
+			return nil, fmt.Errorf("error waiting completion of task (%s): %w", taskUrl, err)
 		}
 
 		// Here we have to find the resource once more to return it populated. Provided params ir ignored for retrieval.
-		err = client.OpenApiGetItem(apiVersion, urlRefCopy, nil, outType, additionalHeader)
+		err = client.OpenApiGetItemCtx(ctx, apiVersion, urlRefCopy, nil, outType, additionalHeader)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving item after updating: %s", err)
+			return nil, fmt.Errorf("error retrieving item after updating: %w", err)
 		}
 
 		// Synchronous task - new item body is returned in response of HTTP PUT request
 	case http.StatusOK:
 		util.Logger.Printf("[TRACE] Synchronous task detected, marshalling outType '%s'", reflect.TypeOf(outType))
 		if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-			return nil, fmt.Errorf("error decoding JSON response after PUT: %s", err)
+			return nil, fmt.Errorf("error decoding JSON response after PUT: %w", err)
 		}
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing HTTP PUT response body: %s", err)
+		return nil, fmt.Errorf("error closing HTTP PUT response body: %w", err)
 	}
 
 	return resp.Header, nil
@@ -539,6 +579,12 @@ func (client *Client) OpenApiPutItemAndGetHeaders(apiVersion string, urlRef *url
 // The urlRef must point to ID of exact item (e.g. '/1.0.0/edgeGateways/{EDGE_ID}')
 // It handles synchronous and asynchronous tasks. When a task is synchronous - it will block until it is finished.
 func (client *Client) OpenApiDeleteItem(apiVersion string, urlRef *url.URL, params url.Values, additionalHeader map[string]string) error {
+	return client.OpenApiDeleteItemCtx(context.Background(), apiVersion, urlRef, params, additionalHeader)
+}
+
+// OpenApiDeleteItemCtx is the context aware version of OpenApiDeleteItem. The given context is also
+// used while tracking the task of an asynchronous request.
+func (client *Client) OpenApiDeleteItemCtx(ctx context.Context, apiVersion string, urlRef *url.URL, params url.Values, additionalHeader map[string]string) error {
 	// copy passed in URL ref so that it is not mutated
 	urlRefCopy := copyUrlRef(urlRef)
 
@@ -549,7 +595,7 @@ func (client *Client) OpenApiDeleteItem(apiVersion string, urlRef *url.URL, para
 	}
 
 	// Perform request
-	req := client.newOpenApiRequest(apiVersion, params, http.MethodDelete, urlRefCopy, nil, additionalHeader)
+	req := client.newOpenApiRequestCtx(ctx, apiVersion, params, http.MethodDelete, urlRefCopy, nil, additionalHeader)
 
 	resp, err := client.Http.Do(req)
 	if err != nil {
@@ -566,12 +612,12 @@ func (client *Client) OpenApiDeleteItem(apiVersion string, urlRef *url.URL, para
 	// resp is ignored below because it would be the same as above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return fmt.Errorf("error in HTTP DELETE request: %s", err)
+		return fmt.Errorf("error in HTTP DELETE request: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// OpenAPI may work synchronously or asynchronously. When working asynchronously - it will return HTTP 202 and
@@ -581,9 +627,9 @@ func (client *Client) OpenApiDeleteItem(apiVersion string, urlRef *url.URL, para
 		taskUrl := resp.Header.Get("Location")
 		task := NewTask(client)
 		task.Task.HREF = taskUrl
-		err = task.WaitTaskCompletion()
+		err = task.WaitTaskCompletionCtx(ctx)
 		if err != nil {
-			return fmt.Errorf("error waiting completion of task (%s): %s", taskUrl, err)
+			return fmt.Errorf("error waiting completion of task (%s): %w", taskUrl, err)
 		}
 	}
 
@@ -592,18 +638,18 @@ func (client *Client) OpenApiDeleteItem(apiVersion string, urlRef *url.URL, para
 
 // openApiPerformPostPut is a shared function for all public PUT and POST function parts - OpenApiPostItemSync,
 // OpenApiPostItemAsync, OpenApiPostItem, OpenApiPutItemSync, OpenApiPutItemAsync, OpenApiPutItem
-func (client *Client) openApiPerformPostPut(httpMethod string, apiVersion string, urlRef *url.URL, params url.Values, payload interface{}, additionalHeader map[string]string) (*http.Response, error) {
+func (client *Client) openApiPerformPostPut(ctx context.Context, httpMethod string, apiVersion string, urlRef *url.URL, params url.Values, payload interface{}, additionalHeader map[string]string) (*http.Response, error) {
 	// Marshal payload if we have one
 	body := new(bytes.Buffer)
 	if payload != nil {
 		marshaledJson, err := json.MarshalIndent(payload, "", "  ")
 		if err != nil {
-			return nil, fmt.Errorf("error marshalling JSON data for %s request %s", httpMethod, err)
+			return nil, fmt.Errorf("error marshalling JSON data for %s request %w", httpMethod, err)
 		}
 		body = bytes.NewBuffer(marshaledJson)
 	}
 
-	req := client.newOpenApiRequest(apiVersion, params, httpMethod, urlRef, body, additionalHeader)
+	req := client.newOpenApiRequestCtx(ctx, apiVersion, params, httpMethod, urlRef, body, additionalHeader)
 	resp, err := client.Http.Do(req)
 	if err != nil {
 		return nil, err
@@ -612,18 +658,117 @@ func (client *Client) openApiPerformPostPut(httpMethod string, apiVersion string
 	// resp is ignored below because it is the same the one above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP %s request: %s", httpMethod, err)
+		return nil, fmt.Errorf("error in HTTP %s request: %w", httpMethod, err)
 	}
 	return resp, nil
 }
 
-// openApiGetAllPages is a recursive function that helps to accumulate responses from multiple pages for GET query. It
-// works by at first crawling pages and accumulating all responses into []json.RawMessage (as strings). Because there is
-// no intermediate unmarshalling to exact `outType` for every page it can unmarshal into direct `outType` supplied.
-// outType must be a slice of object (e.g. []*types.OpenApiRole) because accumulated responses are in JSON list
+// openApiGetAllPages accumulates responses from multiple pages for GET query into []json.RawMessage. Because there is
+// no intermediate unmarshalling for every page, the caller can unmarshal all responses into the exact type in one go.
 //
-// It follows pages in two ways:
-// * Finds a 'nextPage' link and uses it to recursively crawl all pages (default for all, except for API bug)
+// Note. It keeps all responses in memory. Use openApiIterateRawItems or OpenApiIterateItems to process large
+// collections item by item.
+func (client *Client) openApiGetAllPages(ctx context.Context, apiVersion string, urlRef *url.URL, queryParams url.Values, additionalHeader map[string]string) ([]json.RawMessage, error) {
+	responses := []json.RawMessage{}
+	for value, err := range client.openApiIterateRawItems(ctx, apiVersion, urlRef, queryParams, additionalHeader) {
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, value)
+	}
+
+	return responses, nil
+}
+
+// OpenApiIterateItems returns an iterator over all items of a paginated OpenAPI GET endpoint. Pages are
+// retrieved lazily one by one while iterating, therefore only a single page is kept in memory and
+// processing can start as soon as the first page arrives. Breaking out of the loop stops retrieval
+// of further pages.
+//
+// Iteration stops after the first error, which is yielded together with a nil item.
+//
+// Note. Query parameter 'pageSize' is defaulted to 128 (maximum supported) unless it is specified in queryParams
+//
+// Example:
+//
+//	for role, err := range OpenApiIterateItems[types.Role](ctx, client, apiVersion, urlRef, nil, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(role.Name)
+//	}
+func OpenApiIterateItems[T any](ctx context.Context, client *Client, apiVersion string, urlRef *url.URL, queryParams url.Values, additionalHeader map[string]string) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		if !client.OpenApiIsSupported() {
+			yield(nil, fmt.Errorf("OpenAPI is not supported on this VCD version"))
+			return
+		}
+
+		newQueryParams := defaultPageSize(copyOrNewUrlValues(queryParams), "128")
+		for value, err := range client.openApiIterateRawItems(ctx, apiVersion, urlRef, newQueryParams, additionalHeader) {
+			if err != nil {
+				yield(nil, fmt.Errorf("error getting all pages for endpoint %s: %w", urlRef.String(), err))
+				return
+			}
+
+			item := new(T)
+			if err := json.Unmarshal(value, item); err != nil {
+				yield(nil, fmt.Errorf("error decoding value into type %T: %w", item, err))
+				return
+			}
+
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// openApiIterateRawItems returns an iterator over raw JSON values of all pages for GET query. The next page is only
+// retrieved once all values of the current one are consumed.
+func (client *Client) openApiIterateRawItems(ctx context.Context, apiVersion string, urlRef *url.URL, queryParams url.Values, additionalHeader map[string]string) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		pageUrlRef, pageQueryParams := urlRef, queryParams
+		previousPage := 0
+		for pageUrlRef != nil {
+			page, err := client.openApiGetPage(ctx, apiVersion, pageUrlRef, pageQueryParams, additionalHeader)
+			if err != nil {
+				if previousPage > 0 {
+					err = fmt.Errorf("got error on page %d: %w", previousPage+1, err)
+				}
+				yield(nil, err)
+				return
+			}
+
+			for _, value := range page.values {
+				if !yield(value, nil) {
+					return
+				}
+			}
+
+			pageUrlRef, pageQueryParams = page.nextPageUrlRef, page.nextPageQueryParams
+			previousPage++
+		}
+	}
+}
+
+// openApiPage contains values of a single page retrieved by openApiGetPage together with the
+// reference to the next page
+type openApiPage struct {
+	// page is the page number as reported by API (it may be 0 for endpoints that do not report it)
+	page int
+	// values contains raw JSON values of a single page
+	values []json.RawMessage
+	// nextPageUrlRef is nil when there are no more pages to retrieve
+	nextPageUrlRef *url.URL
+	// nextPageQueryParams must be used together with nextPageUrlRef
+	nextPageQueryParams url.Values
+}
+
+// openApiGetPage retrieves a single page for GET query and finds the reference to the next page.
+//
+// It finds the next page in two ways:
+// * Finds a 'nextPage' link (default for all, except for API bug)
 // * Uses fields 'resultTotal', 'page', and 'pageSize' to calculate if it should crawl further on. It is only done
 // because there is a BUG in API and in some endpoints it does not return 'nextPage' link as well as null 'pageCount'
 //
@@ -633,16 +778,12 @@ func (client *Client) openApiPerformPostPut(httpMethod string, apiVersion string
 // (e.g. ...importableTier0Routers?filter=_context==urn:vcloud:nsxtmanager:85aa2514-6a6f-4a32-8904-9695dc0f0298&
 // cursor=eyJORVRXT1JLSU5HX0NVUlNPUl9PRkZTRVQiOiIwIiwicGFnZVNpemUiOjEsIk5FVFdPUktJTkdfQ1VSU09SIjoiMDAwMTMifQ==)
 // The 'cursor' in example contains such values {"NETWORKING_CURSOR_OFFSET":"0","pageSize":1,"NETWORKING_CURSOR":"00013"}
-func (client *Client) openApiGetAllPages(apiVersion string, urlRef *url.URL, queryParams url.Values, outType interface{}, responses []json.RawMessage, additionalHeader map[string]string) ([]json.RawMessage, error) {
+func (client *Client) openApiGetPage(ctx context.Context, apiVersion string, urlRef *url.URL, queryParams url.Values, additionalHeader map[string]string) (*openApiPage, error) {
 	// copy passed in URL ref so that it is not mutated
 	urlRefCopy := copyUrlRef(urlRef)
 
-	if responses == nil {
-		responses = []json.RawMessage{}
-	}
-
 	// Perform request
-	req := client.newOpenApiRequest(apiVersion, queryParams, http.MethodGet, urlRefCopy, nil, additionalHeader)
+	req := client.newOpenApiRequestCtx(ctx, apiVersion, queryParams, http.MethodGet, urlRefCopy, nil, additionalHeader)
 
 	resp, err := client.Http.Do(req)
 	if err != nil {
@@ -652,48 +793,45 @@ func (client *Client) openApiGetAllPages(apiVersion string, urlRef *url.URL, que
 	// resp is ignored below because it is the same as above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP GET request: %s", err)
+		return nil, fmt.Errorf("error in HTTP GET request: %w", err)
 	}
 
 	// Pages will unwrap pagination and keep a slice of raw json message to marshal to specific types
 	pages := &types.OpenApiPages{}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, pages); err != nil {
-		return nil, fmt.Errorf("error decoding JSON page response: %s", err)
+		return nil, fmt.Errorf("error decoding JSON page response: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing response body: %s", err)
+		return nil, fmt.Errorf("error closing response body: %w", err)
 	}
 
-	// Accumulate all responses in a single page as JSON text using json.RawMessage
+	// Keep all responses in a single page as JSON text using json.RawMessage
 	// After pages are unwrapped one can marshal response into specified type
-	// singleQueryResponses := &json.RawMessage{}
-	var singleQueryResponses []json.RawMessage
-	if err = json.Unmarshal(pages.Values, &singleQueryResponses); err != nil {
-		return nil, fmt.Errorf("error decoding values into accumulation type: %s", err)
+	page := &openApiPage{page: pages.Page}
+	if err = json.Unmarshal(pages.Values, &page.values); err != nil {
+		return nil, fmt.Errorf("error decoding values into accumulation type: %w", err)
 	}
-	responses = append(responses, singleQueryResponses...)
 
-	// Check if there is still 'nextPage' linked and continue accumulating responses if so
+	// Check if there is still 'nextPage' linked
 	nextPageUrlRef, err := findRelLink("nextPage", resp.Header)
 	if err != nil && !IsNotFound(err) {
-		return nil, fmt.Errorf("error looking for 'nextPage' in 'Link' header: %s", err)
+		return nil, fmt.Errorf("error looking for 'nextPage' in 'Link' header: %w", err)
 	}
 
 	if nextPageUrlRef != nil {
-		responses, err = client.openApiGetAllPages(apiVersion, nextPageUrlRef, url.Values{}, outType, responses, additionalHeader)
-		if err != nil {
-			return nil, fmt.Errorf("got error on page %d: %s", pages.Page, err)
-		}
+		page.nextPageUrlRef = nextPageUrlRef
+		page.nextPageQueryParams = url.Values{}
+		return page, nil
 	}
 
 	// If nextPage header was not found, but we are not at the last page - the query URL should be forged manually to
 	// overcome OpenAPI BUG when it does not return 'nextPage' header
 	// Some API calls do not return `OpenApiPages` results at all (just values)
 	// In some endpoints the page field is returned as `null` and this code block cannot handle it.
-	if nextPageUrlRef == nil && pages.PageSize != 0 && pages.Page != 0 {
+	if pages.PageSize != 0 && pages.Page != 0 {
 		// Next URL page ref was not found therefore one must double-check if it is not an API BUG. There are endpoints which
 		// return only Total results and pageSize (not 'pageCount' and not 'nextPage' header)
 		pageCount := pages.ResultTotal / pages.PageSize // This division returns number of "full pages" (containing 'pageSize' amount of results)
@@ -705,26 +843,29 @@ func (client *Client) openApiGetAllPages(apiVersion string, urlRef *url.URL, que
 			urlQueryString := queryParams.Encode()
 			urlQuery, err := url.ParseQuery(urlQueryString)
 			if err != nil {
-				return nil, fmt.Errorf("error cloning queryParams: %s", err)
+				return nil, fmt.Errorf("error cloning queryParams: %w", err)
 			}
 
 			// Increase page query by one to fetch "next" page
 			urlQuery.Set("page", strconv.Itoa(pages.Page+1))
 
-			responses, err = client.openApiGetAllPages(apiVersion, urlRefCopy, urlQuery, outType, responses, additionalHeader)
-			if err != nil {
-				return nil, fmt.Errorf("got error on page %d: %s", pages.Page, err)
-			}
+			page.nextPageUrlRef = urlRefCopy
+			page.nextPageQueryParams = urlQuery
 		}
-
 	}
 
-	return responses, nil
+	return page, nil
 }
 
 // newOpenApiRequest is a low level function used in upstream OpenAPI functions which handles logging and
 // authentication for each API request
 func (client *Client) newOpenApiRequest(apiVersion string, params url.Values, method string, reqUrl *url.URL, body io.Reader, additionalHeader map[string]string) *http.Request {
+	return client.newOpenApiRequestCtx(context.Background(), apiVersion, params, method, reqUrl, body, additionalHeader)
+}
+
+// newOpenApiRequestCtx is the context aware version of newOpenApiRequest. The returned request is
+// bound to given context so that it can be cancelled or given a deadline by the caller.
+func (client *Client) newOpenApiRequestCtx(ctx context.Context, apiVersion string, params url.Values, method string, reqUrl *url.URL, body io.Reader, additionalHeader map[string]string) *http.Request {
 	// copy passed in URL ref so that it is not mutated
 	reqUrlCopy := copyUrlRef(reqUrl)
 
@@ -743,20 +884,13 @@ func (client *Client) newOpenApiRequest(apiVersion string, params url.Values, me
 		body = bytes.NewReader(readBody)
 	}
 
-	req, err := http.NewRequest(method, reqUrlCopy.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, reqUrlCopy.String(), body)
 	if err != nil {
 		util.Logger.Printf("[DEBUG - newOpenApiRequest] error getting new request: %s", err)
 	}
 
-	if client.VCDAuthHeader != "" && client.VCDToken != "" {
-		// Add the authorization header
-		req.Header.Add(client.VCDAuthHeader, client.VCDToken)
-		// The deprecated authorization token is 32 characters long
-		// The bearer token is 612 characters long
-		if len(client.VCDToken) > 32 {
-			req.Header.Add("Authorization", "bearer "+client.VCDToken)
-			req.Header.Add("X-Vmware-Vcloud-Token-Type", "Bearer")
-		}
+	if authHeader, token := client.authorization(); authHeader != "" && token != "" {
+		addAuthorizationHeaders(req.Header, authHeader, token)
 		// Add the Accept header for VCD
 		acceptMime := types.JSONMime + ";version=" + apiVersion
 		req.Header.Add("Accept", acceptMime)
@@ -290,7 +290,7 @@ func (client *Client) checkOpenApiEndpointCompatibility(endpoint string) (string
 	if client.APIVCDMaxVersionIs("< " + minimumApiVersion) {
 		maxSupportedVersion, err := client.MaxSupportedVersion()
 		if err != nil {
-			return "", fmt.Errorf("error reading maximum supported API version: %s", err)
+			return "", fmt.Errorf("error reading maximum supported API version: %w", err)
 		}
 		return "", fmt.Errorf("endpoint '%s' requires API version to support at least '%s'. Maximum supported version in this instance: '%s'",
 			endpoint, minimumApiVersion, maxSupportedVersion)
@@ -317,7 +317,7 @@ func (client *Client) getOpenApiHighestElevatedVersion(endpoint string) (string,
 	// At first get minimum API version and check if it can be supported
 	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(endpoint)
 	if err != nil {
-		return "", fmt.Errorf("error getting minimum required API version: %s", err)
+		return "", fmt.Errorf("error getting minimum required API version: %w", err)
 	}
 
 	// If no elevated versions are defined - return minimumApiVersion
@@ -337,7 +337,7 @@ func (client *Client) getOpenApiHighestElevatedVersion(endpoint string) (string,
 	for i, raw := range versionsRaw {
 		v, err := version.NewVersion(raw)
 		if err != nil {
-			return "", fmt.Errorf("error evaluating version %s: %s", raw, err)
+			return "", fmt.Errorf("error evaluating version %s: %w", raw, err)
 		}
 		versions[i] = v
 	}
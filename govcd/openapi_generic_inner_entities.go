@@ -1,7 +1,9 @@
 package govcd
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
@@ -81,23 +83,23 @@ func createInnerEntity[I any](client *Client, c crudConfig, innerConfig *I) (*I,
 
 	apiVersion, err := client.getOpenApiHighestElevatedVersion(c.endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error getting API version for creating entity '%s': %s", c.entityLabel, err)
+		return nil, fmt.Errorf("error getting API version for creating entity '%s': %w", c.entityLabel, err)
 	}
 
 	exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
 	if err != nil {
-		return nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %s", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
+		return nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %w", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
 	}
 
 	urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error building API endpoint for entity '%s' creation: %s", c.entityLabel, err)
+		return nil, fmt.Errorf("error building API endpoint for entity '%s' creation: %w", c.entityLabel, err)
 	}
 
 	createdInnerEntityConfig := new(I)
 	err = client.OpenApiPostItem(apiVersion, urlRef, c.queryParameters, innerConfig, createdInnerEntityConfig, c.additionalHeader)
 	if err != nil {
-		return nil, fmt.Errorf("error creating entity of type '%s': %s", c.entityLabel, err)
+		return nil, fmt.Errorf("error creating entity of type '%s': %w", c.entityLabel, err)
 	}
 
 	return createdInnerEntityConfig, nil
@@ -115,22 +117,22 @@ func createInnerEntityAsync[I any](client *Client, c crudConfig, innerConfig *I)
 
 	apiVersion, err := client.getOpenApiHighestElevatedVersion(c.endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error getting API version for creating entity '%s': %s", c.entityLabel, err)
+		return nil, fmt.Errorf("error getting API version for creating entity '%s': %w", c.entityLabel, err)
 	}
 
 	exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
 	if err != nil {
-		return nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %s", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
+		return nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %w", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
 	}
 
 	urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error building API endpoint for entity '%s' creation: %s", c.entityLabel, err)
+		return nil, fmt.Errorf("error building API endpoint for entity '%s' creation: %w", c.entityLabel, err)
 	}
 
 	task, err := client.OpenApiPostItemAsyncWithHeaders(apiVersion, urlRef, c.queryParameters, innerConfig, c.additionalHeader)
 	if err != nil {
-		return nil, fmt.Errorf("error creating entity of type '%s': %s", c.entityLabel, err)
+		return nil, fmt.Errorf("error creating entity of type '%s': %w", c.entityLabel, err)
 	}
 
 	return &task, nil
@@ -159,23 +161,23 @@ func updateInnerEntityWithHeaders[I any](client *Client, c crudConfig, innerConf
 
 	apiVersion, err := client.getOpenApiHighestElevatedVersion(c.endpoint)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error getting API version for updating entity '%s': %s", c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error getting API version for updating entity '%s': %w", c.entityLabel, err)
 	}
 
 	exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %s", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %w", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
 	}
 
 	urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error building API endpoint for entity '%s' update: %s", c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error building API endpoint for entity '%s' update: %w", c.entityLabel, err)
 	}
 
 	updatedInnerEntityConfig := new(I)
 	headers, err := client.OpenApiPutItemAndGetHeaders(apiVersion, urlRef, c.queryParameters, innerConfig, updatedInnerEntityConfig, c.additionalHeader)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error updating entity of type '%s': %s", c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error updating entity of type '%s': %w", c.entityLabel, err)
 	}
 
 	return updatedInnerEntityConfig, headers, nil
@@ -204,23 +206,23 @@ func getInnerEntityWithHeaders[I any](client *Client, c crudConfig) (*I, http.He
 
 	apiVersion, err := client.getOpenApiHighestElevatedVersion(c.endpoint)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error getting API version for entity '%s': %s", c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error getting API version for entity '%s': %w", c.entityLabel, err)
 	}
 
 	exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %s", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %w", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
 	}
 
 	urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error building API endpoint for entity '%s': %s", c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error building API endpoint for entity '%s': %w", c.entityLabel, err)
 	}
 
 	typeResponse := new(I)
 	headers, err := client.OpenApiGetItemAndHeaders(apiVersion, urlRef, c.queryParameters, typeResponse, c.additionalHeader)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error retrieving entity of type '%s': %s", c.entityLabel, err)
+		return nil, nil, fmt.Errorf("error retrieving entity of type '%s': %w", c.entityLabel, err)
 	}
 
 	return typeResponse, headers, nil
@@ -233,32 +235,60 @@ func getInnerEntityWithHeaders[I any](client *Client, c crudConfig) (*I, http.He
 // * `client` is a *Client
 // * `c` holds settings for performing API call
 func getAllInnerEntities[I any](client *Client, c crudConfig) ([]*I, error) {
-	if err := c.validate(client); err != nil {
-		return nil, err
+	typeResponses := make([]*I, 0)
+	for typeResponse, err := range iterateInnerEntities[I](context.Background(), client, c) {
+		if err != nil {
+			return nil, err
+		}
+		typeResponses = append(typeResponses, typeResponse)
 	}
 
-	apiVersion, err := client.getOpenApiHighestElevatedVersion(c.endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("error getting API version for entity '%s': %s", c.entityLabel, err)
-	}
+	return typeResponses, nil
+}
 
-	exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
-	if err != nil {
-		return nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %s", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
-	}
+// iterateInnerEntities returns an iterator over any inner entities in the OpenAPI endpoints that
+// are not nested in outer types. Pages are retrieved one by one while iterating so that only a
+// single page is kept in memory. Iteration stops after the first error.
+//
+// Parameters:
+// * `ctx` stops retrieval of further pages once it is done
+// * `client` is a *Client
+// * `c` holds settings for performing API call
+func iterateInnerEntities[I any](ctx context.Context, client *Client, c crudConfig) iter.Seq2[*I, error] {
+	return func(yield func(*I, error) bool) {
+		if err := c.validate(client); err != nil {
+			yield(nil, err)
+			return
+		}
 
-	urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
-	if err != nil {
-		return nil, fmt.Errorf("error building API endpoint for entity '%s': %s", c.entityLabel, err)
-	}
+		apiVersion, err := client.getOpenApiHighestElevatedVersion(c.endpoint)
+		if err != nil {
+			yield(nil, fmt.Errorf("error getting API version for entity '%s': %w", c.entityLabel, err))
+			return
+		}
 
-	typeResponses := make([]*I, 0)
-	err = client.OpenApiGetAllItems(apiVersion, urlRef, c.queryParameters, &typeResponses, c.additionalHeader)
-	if err != nil {
-		return nil, fmt.Errorf("error retrieving all entities of type '%s': %s", c.entityLabel, err)
-	}
+		exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
+		if err != nil {
+			yield(nil, fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %w", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err))
+			return
+		}
 
-	return typeResponses, nil
+		urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
+		if err != nil {
+			yield(nil, fmt.Errorf("error building API endpoint for entity '%s': %w", c.entityLabel, err))
+			return
+		}
+
+		for typeResponse, err := range OpenApiIterateItems[I](ctx, client, apiVersion, urlRef, c.queryParameters, c.additionalHeader) {
+			if err != nil {
+				yield(nil, fmt.Errorf("error retrieving all entities of type '%s': %w", c.entityLabel, err))
+				return
+			}
+			if !yield(typeResponse, nil) {
+				return
+			}
+		}
+	}
 }
 
 // deleteEntityById performs a common operation for OpenAPI endpoints that calls DELETE method for a
@@ -279,7 +309,7 @@ func deleteEntityById(client *Client, c crudConfig) error {
 
 	exactEndpoint, err := urlFromEndpoint(c.endpoint, c.endpointParams)
 	if err != nil {
-		return fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %s", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
+		return fmt.Errorf("error building endpoint '%s' with given params '%s' for entity '%s': %w", c.endpoint, strings.Join(c.endpointParams, ","), c.entityLabel, err)
 	}
 
 	urlRef, err := client.OpenApiBuildEndpoint(exactEndpoint)
@@ -290,7 +320,7 @@ func deleteEntityById(client *Client, c crudConfig) error {
 	err = client.OpenApiDeleteItem(apiVersion, urlRef, c.queryParameters, c.additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error deleting %s: %s", c.entityLabel, err)
+		return fmt.Errorf("error deleting %s: %w", c.entityLabel, err)
 	}
 
 	return nil
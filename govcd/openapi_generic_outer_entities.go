@@ -1,7 +1,9 @@
 package govcd
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -68,16 +70,30 @@ func getOuterEntityWithHeaders[O outerEntityWrapper[O, I], I any](client *Client
 
 // getAllOuterEntities retrieves all outer entities
 func getAllOuterEntities[O outerEntityWrapper[O, I], I any](client *Client, outerEntity O, c crudConfig) ([]*O, error) {
-	retrievedAllInnerEntities, err := getAllInnerEntities[I](client, c)
-	if err != nil {
-		return nil, err
-	}
-
-	wrappedOuterEntities := make([]*O, len(retrievedAllInnerEntities))
-	for index, singleInnerEntity := range retrievedAllInnerEntities {
-		// outerEntity.wrap() is a value receiver, therefore it creates a shallow copy for each call
-		wrappedOuterEntities[index] = outerEntity.wrap(singleInnerEntity)
+	wrappedOuterEntities := make([]*O, 0)
+	for wrappedOuterEntity, err := range iterateOuterEntities[O, I](context.Background(), client, outerEntity, c) {
+		if err != nil {
+			return nil, err
+		}
+		wrappedOuterEntities = append(wrappedOuterEntities, wrappedOuterEntity)
 	}
 
 	return wrappedOuterEntities, nil
 }
+
+// iterateOuterEntities returns an iterator over all outer entities. Pages are retrieved one by
+// one while iterating so that only a single page is kept in memory
+func iterateOuterEntities[O outerEntityWrapper[O, I], I any](ctx context.Context, client *Client, outerEntity O, c crudConfig) iter.Seq2[*O, error] {
+	return func(yield func(*O, error) bool) {
+		for singleInnerEntity, err := range iterateInnerEntities[I](ctx, client, c) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			// outerEntity.wrap() is a value receiver, therefore it creates a shallow copy for each call
+			if !yield(outerEntity.wrap(singleInnerEntity), nil) {
+				return
+			}
+		}
+	}
+}
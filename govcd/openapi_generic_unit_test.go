@@ -3,7 +3,13 @@
 package govcd
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 func Test_urlFromEndpoint(t *testing.T) {
@@ -73,3 +79,86 @@ func Test_urlFromEndpoint(t *testing.T) {
 		})
 	}
 }
+
+// usersMockServer serves `pageCount` pages containing a single user each. Every page except the
+// last one contains a 'nextPage' Link header
+func usersMockServer(t *testing.T, pageCount int, requestCount *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requestCount++
+		page := 1
+		if r.URL.Query().Get("page") != "" {
+			_, _ = fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		}
+		if page < pageCount {
+			nextPage := fmt.Sprintf("http://%s%s?page=%d&pageSize=1", r.Host, r.URL.Path, page+1)
+			w.Header().Set("Link", `<`+nextPage+`>;rel="nextPage";type="application/json;version=40.0"`)
+		}
+		w.Header().Set("Content-Type", types.JSONMime)
+		_, _ = fmt.Fprintf(w, `{"resultTotal":%d,"pageCount":%d,"page":%d,"pageSize":1,"values":[{"id":"urn:vcloud:user:%d","username":"user-%d"}]}`,
+			pageCount, pageCount, page, page, page)
+	}))
+}
+
+func Test_iterateOuterEntities(t *testing.T) {
+	requestCount := 0
+	server := usersMockServer(t, 5, &requestCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	c := crudConfig{
+		entityLabel: labelOpenApiUser,
+		endpoint:    types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointUsers,
+	}
+	outerType := OpenApiUser{vcdClient: vcdClient}
+
+	// Breaking out of the loop must stop retrieval of further pages
+	for user, err := range iterateOuterEntities[OpenApiUser, types.OpenApiUser](context.Background(), &vcdClient.Client, outerType, c) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if user.vcdClient != vcdClient {
+			t.Fatalf("outer entity was not wrapped")
+		}
+		if user.User.Username == "user-2" {
+			break
+		}
+	}
+	if requestCount != 2 {
+		t.Fatalf("expected 2 requests, got %d", requestCount)
+	}
+
+	requestCount = 0
+	allUsers, err := getAllOuterEntities[OpenApiUser, types.OpenApiUser](&vcdClient.Client, outerType, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(allUsers) != 5 || requestCount != 5 {
+		t.Fatalf("expected 5 users in 5 requests, got %d users in %d requests", len(allUsers), requestCount)
+	}
+	for index, user := range allUsers {
+		if user.User.Username != fmt.Sprintf("user-%d", index+1) {
+			t.Fatalf("expected user-%d at position %d, got %s", index+1, index, user.User.Username)
+		}
+	}
+
+	// Context cancellation must stop retrieval of further pages
+	requestCount = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var iterationErr error
+	for user, err := range iterateOuterEntities[OpenApiUser, types.OpenApiUser](ctx, &vcdClient.Client, outerType, c) {
+		if err != nil {
+			iterationErr = err
+			break
+		}
+		if user.User.Username == "user-3" {
+			cancel()
+		}
+	}
+	if iterationErr == nil {
+		t.Fatalf("expected an error after context cancellation")
+	}
+	if requestCount != 3 {
+		t.Fatalf("expected 3 requests, got %d", requestCount)
+	}
+}
@@ -37,7 +37,7 @@ func (org *Org) GetOpenApiOrgVdcNetworkByNameAndOwnerId(name, ownerId string) (*
 
 	allEdges, err := getAllOpenApiOrgVdcNetworks(org.client, queryParameters, nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s' in Owner '%s': %s", name, ownerId, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s' in Owner '%s': %w", name, ownerId, err)
 	}
 
 	return returnSingleOpenApiOrgVdcNetwork(name, allEdges)
@@ -73,7 +73,7 @@ func (vdc *Vdc) GetOpenApiOrgVdcNetworkByName(name string) (*OpenApiOrgVdcNetwor
 
 	allEdges, err := vdc.GetAllOpenApiOrgVdcNetworks(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
 	}
 
 	return returnSingleOpenApiOrgVdcNetwork(name, allEdges)
@@ -86,7 +86,7 @@ func (vdcGroup *VdcGroup) GetOpenApiOrgVdcNetworkByName(name string) (*OpenApiOr
 
 	allEdges, err := vdcGroup.GetAllOpenApiOrgVdcNetworks(queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
 	}
 
 	return returnSingleOpenApiOrgVdcNetwork(name, allEdges)
@@ -166,7 +166,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) Update(OrgVdcNetworkConfig *types.OpenApi
 
 	err = orgVdcNet.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, OrgVdcNetworkConfig, returnEgw.OpenApiOrgVdcNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Org VDC network: %s", err)
+		return nil, fmt.Errorf("error updating Org VDC network: %w", err)
 	}
 
 	return returnEgw, nil
@@ -192,7 +192,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) Delete() error {
 	err = orgVdcNet.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Org VDC network: %s", err)
+		return fmt.Errorf("error deleting Org VDC network: %w", err)
 	}
 
 	return nil
@@ -291,7 +291,7 @@ func returnSingleOpenApiOrgVdcNetwork(name string, allEdges []*OpenApiOrgVdcNetw
 	}
 
 	if len(allEdges) < 1 {
-		return nil, fmt.Errorf("%s: got zero Org VDC networks by name '%s'", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: got zero Org VDC networks by name '%s'", ErrorEntityNotFound, name)
 	}
 
 	return allEdges[0], nil
@@ -316,7 +316,7 @@ func getAllOpenApiOrgVdcNetworks(client *Client, queryParameters url.Values, add
 	default:
 		pageSizeValue, err := strconv.Atoi(pageSizeString)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing pageSize value: %s", err)
+			return nil, fmt.Errorf("error parsing pageSize value: %w", err)
 		}
 		if pageSizeString != "" && pageSizeValue > 32 {
 			queryParameters.Set("pageSize", "32")
@@ -372,7 +372,7 @@ func createOpenApiOrgVdcNetwork(client *Client, OrgVdcNetworkConfig *types.OpenA
 
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, OrgVdcNetworkConfig, returnEgw.OpenApiOrgVdcNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Org VDC network: %s", err)
+		return nil, fmt.Errorf("error creating Org VDC network: %w", err)
 	}
 
 	return returnEgw, nil
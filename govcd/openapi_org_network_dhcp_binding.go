@@ -40,12 +40,12 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) CreateOpenApiOrgVdcNetworkDhcpBinding(dhc
 	// which is not standard, therefore it must be explicitly handled in the code here
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, dhcpBindingConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Org VDC Network DHCP Binding: %s", err)
+		return nil, fmt.Errorf("error creating Org VDC Network DHCP Binding: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for Org VDC Network DHCP Binding to be created: %s", err)
+		return nil, fmt.Errorf("error waiting for Org VDC Network DHCP Binding to be created: %w", err)
 	}
 
 	dhcpBindingId := task.Task.Details
@@ -57,7 +57,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) CreateOpenApiOrgVdcNetworkDhcpBinding(dhc
 	// Get the DHCP binding by ID and return it
 	createdBinding, err := orgVdcNet.GetOpenApiOrgVdcNetworkDhcpBindingById(dhcpBindingId)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving DHCP binding for Org VDC network after creation: %s", err)
+		return nil, fmt.Errorf("error retrieving DHCP binding for Org VDC network after creation: %w", err)
 	}
 
 	return createdBinding, nil
@@ -157,7 +157,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) GetOpenApiOrgVdcNetworkDhcpBindingByName(
 
 	allDhcpBindings, err := orgVdcNet.GetAllOpenApiOrgVdcNetworkDhcpBindings(nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
 	}
 
 	// Bindings do not support name filtering, so we need to filter them manually
@@ -170,7 +170,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) GetOpenApiOrgVdcNetworkDhcpBindingByName(
 	}
 
 	if foundBinding == nil {
-		return nil, fmt.Errorf("%s: could not find NSX-T Org Network Binding by Name %s", ErrorEntityNotFound, name)
+		return nil, fmt.Errorf("%w: could not find NSX-T Org Network Binding by Name %s", ErrorEntityNotFound, name)
 	}
 
 	return foundBinding, nil
@@ -206,13 +206,13 @@ func (dhcpBinding *OpenApiOrgVdcNetworkDhcpBinding) Update(orgVdcNetworkDhcpConf
 	// load latest binding information to fetch Version value which is required for updates
 	err = result.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %s", orgVdcNetworkDhcpConfig.ID, err)
+		return nil, fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %w", orgVdcNetworkDhcpConfig.ID, err)
 	}
 	orgVdcNetworkDhcpConfig.Version = result.OpenApiOrgVdcNetworkDhcpBinding.Version
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, orgVdcNetworkDhcpConfig, result.OpenApiOrgVdcNetworkDhcpBinding, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Org VDC network DHCP configuration with ID '%s': %s", orgVdcNetworkDhcpConfig.ID, err)
+		return nil, fmt.Errorf("error updating Org VDC network DHCP configuration with ID '%s': %w", orgVdcNetworkDhcpConfig.ID, err)
 	}
 
 	return result, nil
@@ -232,12 +232,12 @@ func (dhcpBinding *OpenApiOrgVdcNetworkDhcpBinding) Refresh() error {
 	client := dhcpBinding.client
 	orgVdcNet, err := getOpenApiOrgVdcNetworkById(client, dhcpBinding.ParentOrgVdcNetworkId, nil)
 	if err != nil {
-		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %s", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
+		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %w", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
 	}
 
 	newDhcpBinding, err := orgVdcNet.GetOpenApiOrgVdcNetworkDhcpBindingById(dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID)
 	if err != nil {
-		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %s", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
+		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %w", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
 	}
 
 	// Explicitly reassign the body
@@ -270,7 +270,7 @@ func (dhcpBinding *OpenApiOrgVdcNetworkDhcpBinding) Delete() error {
 	err = dhcpBinding.client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %s", err)
+		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %w", err)
 	}
 
 	return nil
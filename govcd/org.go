@@ -246,7 +246,7 @@ func (org *Org) GetCatalogByName(catalogName string, refresh bool) (*Catalog, er
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying Catalog: %s", err)
+		return nil, fmt.Errorf("error querying Catalog: %w", err)
 	}
 	// This is not an AdminOrg and admin HREF must be removed if it exists
 	href := strings.Replace(vdcQuery.HREF, "/api/admin", "/api", 1)
@@ -262,7 +262,7 @@ func (org *Org) GetCatalogById(catalogId string, refresh bool) (*Catalog, error)
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying Catalog: %s", err)
+		return nil, fmt.Errorf("error querying Catalog: %w", err)
 	}
 
 	// This is not an AdminOrg and admin HREF must be removed if it exists
@@ -324,7 +324,7 @@ func (org *Org) GetVDCByName(vdcName string, refresh bool) (*Vdc, error) {
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying VDC: %s", err)
+		return nil, fmt.Errorf("error querying VDC: %w", err)
 	}
 	// This is not an AdminOrg and admin HREF must be removed if it exists
 	href := strings.Replace(vdcQuery.HREF, "/api/admin", "/api", 1)
@@ -342,7 +342,7 @@ func (org *Org) GetVDCById(vdcId string, refresh bool) (*Vdc, error) {
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying VDC: %s", err)
+		return nil, fmt.Errorf("error querying VDC: %w", err)
 	}
 
 	// This is not an AdminOrg and admin HREF must be removed if it exists
@@ -437,7 +437,7 @@ func (client *Client) queryOrgList(filterFields map[string]string) ([]*types.Que
 
 	results, err := client.cumulativeQuery(queryType, nil, filter)
 	if err != nil {
-		return nil, fmt.Errorf("error querying Orgs %s", err)
+		return nil, fmt.Errorf("error querying Orgs %w", err)
 	}
 
 	return results.Results.OrgRecord, nil
@@ -649,7 +649,7 @@ func queryOrgVdcList(client *Client, filterFields map[string]string) ([]*types.Q
 
 	results, err := client.cumulativeQuery(queryType, nil, filter)
 	if err != nil {
-		return nil, fmt.Errorf("error querying Org VDCs %s", err)
+		return nil, fmt.Errorf("error querying Org VDCs %w", err)
 	}
 
 	if client.IsSysAdmin {
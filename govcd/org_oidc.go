@@ -146,7 +146,7 @@ func oidcExecuteRequest(adminOrg *AdminOrg, method string, payload *types.OrgOAu
 	}
 	endpoint, err := url.Parse(adminOrg.AdminOrg.HREF + "/settings/oauth")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing Organization '%s' OpenID Connect URL: %s", adminOrg.AdminOrg.Name, err)
+		return nil, fmt.Errorf("error parsing Organization '%s' OpenID Connect URL: %w", adminOrg.AdminOrg.Name, err)
 	}
 	if endpoint == nil {
 		return nil, fmt.Errorf("error parsing Organization '%s' OpenID Connect URL: it is nil", adminOrg.AdminOrg.Name)
@@ -185,7 +185,7 @@ func oidcExecuteRequest(adminOrg *AdminOrg, method string, payload *types.OrgOAu
 	switch method {
 	case http.MethodDelete:
 		if err != nil {
-			return nil, fmt.Errorf("error deleting Organization OpenID Connect settings: %s", err)
+			return nil, fmt.Errorf("error deleting Organization OpenID Connect settings: %w", err)
 		}
 		if resp != nil && resp.StatusCode != http.StatusNoContent {
 			return nil, fmt.Errorf("error deleting Organization OpenID Connect settings, expected status code %d - received %d", http.StatusNoContent, resp.StatusCode)
@@ -193,17 +193,17 @@ func oidcExecuteRequest(adminOrg *AdminOrg, method string, payload *types.OrgOAu
 		return nil, nil
 	case http.MethodGet:
 		if err != nil {
-			return nil, fmt.Errorf("error getting Organization OpenID Connect settings: %s", err)
+			return nil, fmt.Errorf("error getting Organization OpenID Connect settings: %w", err)
 		}
 		var result types.OrgOAuthSettings
 		err = decodeBody(types.BodyTypeXML, resp, &result)
 		if err != nil {
-			return nil, fmt.Errorf("error decoding Organization OpenID Connect settings: %s", err)
+			return nil, fmt.Errorf("error decoding Organization OpenID Connect settings: %w", err)
 		}
 		return &result, nil
 	case http.MethodPut:
 		if err != nil {
-			return nil, fmt.Errorf("error setting Organization OpenID Connect settings: %s", err)
+			return nil, fmt.Errorf("error setting Organization OpenID Connect settings: %w", err)
 		}
 		// Note: This branch of the switch should be exactly the same as the GET operation, however there is a bug found in VCD 10.5.1.1:
 		// the PUT call returns a wrong redirect URL.
@@ -226,7 +226,7 @@ func oidcValidateConnection(client *Client, endpoint string) error {
 	}
 	port, err := getEndpointPort(uri)
 	if err != nil {
-		return fmt.Errorf("error getting port number for host '%s': %s", uri.Hostname(), err)
+		return fmt.Errorf("error getting port number for host '%s': %w", uri.Hostname(), err)
 	}
 
 	isSecure := port == 443
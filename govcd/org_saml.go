@@ -110,7 +110,7 @@ func (adminOrg *AdminOrg) SetFederationSettings(settings *types.OrgFederationSet
 func (adminOrg *AdminOrg) UnsetFederationSettings() error {
 	settings, err := adminOrg.GetFederationSettings()
 	if err != nil {
-		return fmt.Errorf("[UnsetFederationSettings] error getting SAML settings for Org %s: %s", adminOrg.AdminOrg.Name, err)
+		return fmt.Errorf("[UnsetFederationSettings] error getting SAML settings for Org %s: %w", adminOrg.AdminOrg.Name, err)
 	}
 
 	settings.SAMLMetadata = ""
@@ -130,7 +130,7 @@ func (adminOrg *AdminOrg) GetServiceProviderSamlMetadata() (*types.VcdSamlMetada
 
 	err = xml.Unmarshal([]byte(metadataText), &metadata)
 	if err != nil {
-		return nil, fmt.Errorf("[GetSamlMetadata] error decoding metadata retrieved from %s: %s", adminOrg.AdminOrg.Name, err)
+		return nil, fmt.Errorf("[GetSamlMetadata] error decoding metadata retrieved from %s: %w", adminOrg.AdminOrg.Name, err)
 	}
 
 	return &metadata, nil
@@ -150,7 +150,7 @@ func (adminOrg *AdminOrg) RetrieveServiceProviderSamlMetadata() (string, error)
 
 	metadataText, err := adminOrg.client.RetrieveRemoteDocument(metadataUrl)
 	if err != nil {
-		return "", fmt.Errorf("[RetrieveRemoteDocument] error retrieving SAML metadata from %s: %s", metadataUrl, err)
+		return "", fmt.Errorf("[RetrieveRemoteDocument] error retrieving SAML metadata from %s: %w", metadataUrl, err)
 	}
 	return string(metadataText), nil
 }
@@ -199,12 +199,12 @@ func (client *Client) RetrieveRemoteDocument(metadataUrl string) ([]byte, error)
 	resp, err := client.Http.Do(request)
 
 	if err != nil {
-		return nil, fmt.Errorf("[RetrieveRemoteDocument] error retrieving metadata from %s: %s", metadataUrl, err)
+		return nil, fmt.Errorf("[RetrieveRemoteDocument] error retrieving metadata from %s: %w", metadataUrl, err)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("[RetrieveRemoteDocument] error reading response body from metadata retrieved from %s: %s", metadataUrl, err)
+		return nil, fmt.Errorf("[RetrieveRemoteDocument] error reading response body from metadata retrieved from %s: %w", metadataUrl, err)
 	}
 
 	util.ProcessResponseOutput("[RetrieveRemoteDocument]", resp, string(body))
@@ -219,7 +219,7 @@ func normalizeServiceProviderSamlMetadata(in string) (string, error) {
 	// Phase 1: Decode the XML, to find possible encoding errors
 	err := xml.Unmarshal([]byte(in), &metadata)
 	if err != nil {
-		return "", fmt.Errorf("[normalizeSamlMetadata] error decoding SAML metadata definition from XML: %s", err)
+		return "", fmt.Errorf("[normalizeSamlMetadata] error decoding SAML metadata definition from XML: %w", err)
 	}
 
 	// Phase 2: Add the namespace definition elements, required to recognize the structure as a valid SAML definition
@@ -232,7 +232,7 @@ func normalizeServiceProviderSamlMetadata(in string) (string, error) {
 	// Phase 3: Convert the data structure to text again. The text now includes the needed namespace definition elements
 	out, err := xml.Marshal(metadata)
 	if err != nil {
-		return "", fmt.Errorf("[normalizeSamlMetadata] error encoding SAML metadata text: %s", err)
+		return "", fmt.Errorf("[normalizeSamlMetadata] error encoding SAML metadata text: %w", err)
 	}
 
 	// Phase 4: Add the namespace elements to the XML text
@@ -281,13 +281,13 @@ func ValidateSamlServiceProviderMetadata(metadataText string) []error {
 	// Check n. 1: encode the string into XML, thus establishing that it is valid syntax
 	err := xml.Unmarshal([]byte(metadataText), &metadata)
 	if err != nil {
-		errors = append(errors, fmt.Errorf("[ValidateSamlMetadata] error decoding XML into SAML metadata structure: %s", err))
+		errors = append(errors, fmt.Errorf("[ValidateSamlMetadata] error decoding XML into SAML metadata structure: %w", err))
 	}
 
 	reNameSpace, err := regexp.Compile(`<(\w+):(\w+)`)
 
 	if err != nil {
-		errors = append(errors, fmt.Errorf("error compiling regular expression: %s", err))
+		errors = append(errors, fmt.Errorf("error compiling regular expression: %w", err))
 		return errors
 	}
 
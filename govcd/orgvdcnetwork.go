@@ -57,7 +57,7 @@ func (orgVdcNet *OrgVDCNetwork) Refresh() error {
 func (orgVdcNet *OrgVDCNetwork) Delete() (Task, error) {
 	err := orgVdcNet.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing network: %s", err)
+		return Task{}, fmt.Errorf("error refreshing network: %w", err)
 	}
 	pathArr := strings.Split(orgVdcNet.OrgVDCNetwork.HREF, "/")
 	apiEndpoint := urlParseRequestURI(orgVdcNet.OrgVDCNetwork.HREF)
@@ -72,7 +72,7 @@ func (orgVdcNet *OrgVDCNetwork) Delete() (Task, error) {
 				time.Sleep(3 * time.Second)
 				continue
 			}
-			return Task{}, fmt.Errorf("error deleting Network: %s", err)
+			return Task{}, fmt.Errorf("error deleting Network: %w", err)
 		}
 		break
 	}
@@ -80,7 +80,7 @@ func (orgVdcNet *OrgVDCNetwork) Delete() (Task, error) {
 	task := NewTask(orgVdcNet.client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
@@ -102,11 +102,11 @@ func RemoveOrgVdcNetworkIfExists(vdc Vdc, networkName string) error {
 	// The network was found. We attempt deletion
 	task, err := network.Delete()
 	if err != nil {
-		return fmt.Errorf("error deleting network '%s' [phase 1]: %s", networkName, err)
+		return fmt.Errorf("error deleting network '%s' [phase 1]: %w", networkName, err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error deleting network '%s' [task]: %s", networkName, err)
+		return fmt.Errorf("error deleting network '%s' [task]: %w", networkName, err)
 	}
 	return nil
 }
@@ -117,7 +117,7 @@ func (vdc *Vdc) CreateOrgVDCNetworkWait(networkConfig *types.OrgVDCNetwork) erro
 
 	task, err := vdc.CreateOrgVDCNetwork(networkConfig)
 	if err != nil {
-		return fmt.Errorf("error creating the network: %s", err)
+		return fmt.Errorf("error creating the network: %w", err)
 	}
 	if task == (Task{}) {
 		return fmt.Errorf("NULL task retrieved after network creation")
@@ -126,7 +126,7 @@ func (vdc *Vdc) CreateOrgVDCNetworkWait(networkConfig *types.OrgVDCNetwork) erro
 	err = task.WaitTaskCompletion()
 	// err = task.WaitInspectTaskCompletion(InspectTask, 10)
 	if err != nil {
-		return fmt.Errorf("error performing task: %s", err)
+		return fmt.Errorf("error performing task: %w", err)
 	}
 	return nil
 }
@@ -142,14 +142,14 @@ func (vdc *Vdc) CreateOrgVDCNetwork(networkConfig *types.OrgVDCNetwork) (Task, e
 			createUrl, err := url.ParseRequestURI(av.HREF)
 
 			if err != nil {
-				return Task{}, fmt.Errorf("error decoding vdc response: %s", err)
+				return Task{}, fmt.Errorf("error decoding vdc response: %w", err)
 			}
 
 			networkConfig.Xmlns = types.XMLNamespaceVCloud
 
 			output, err := xml.MarshalIndent(networkConfig, "  ", "    ")
 			if err != nil {
-				return Task{}, fmt.Errorf("error marshaling OrgVDCNetwork compose: %s", err)
+				return Task{}, fmt.Errorf("error marshaling OrgVDCNetwork compose: %w", err)
 			}
 
 			var resp *http.Response
@@ -164,13 +164,13 @@ func (vdc *Vdc) CreateOrgVDCNetwork(networkConfig *types.OrgVDCNetwork) (Task, e
 						time.Sleep(3 * time.Second)
 						continue
 					}
-					return Task{}, fmt.Errorf("error instantiating a new OrgVDCNetwork: %s", err)
+					return Task{}, fmt.Errorf("error instantiating a new OrgVDCNetwork: %w", err)
 				}
 				break
 			}
 			orgVDCNetwork := NewOrgVDCNetwork(vdc.client)
 			if err = decodeBody(types.BodyTypeXML, resp, orgVDCNetwork.OrgVDCNetwork); err != nil {
-				return Task{}, fmt.Errorf("error decoding orgvdcnetwork response: %s", err)
+				return Task{}, fmt.Errorf("error decoding orgvdcnetwork response: %w", err)
 			}
 			activeTasks := 0
 			// Makes sure that there is only one active task for this network.
@@ -205,7 +205,7 @@ func (vdc *Vdc) GetNetworkList() ([]*types.QueryResultOrgVdcNetworkRecordType, e
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %s", err)
+		return nil, fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %w", err)
 	}
 	return result.Results.OrgVdcNetworkRecord, nil
 }
@@ -221,7 +221,7 @@ func (vdc *Vdc) FindEdgeGatewayNameByNetwork(networkName string) (string, error)
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return "", fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %s", err)
+		return "", fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %w", err)
 	}
 	netList := result.Results.OrgVdcNetworkRecord
 
@@ -311,7 +311,7 @@ func (orgVdcNet *OrgVDCNetwork) UpdateAsync() (Task, error) {
 	if orgVdcNet.OrgVDCNetwork.Configuration.FenceMode == types.FenceModeNAT {
 		edgeGateway, err := orgVdcNet.getEdgeGateway()
 		if err != nil {
-			return Task{}, fmt.Errorf("error retrieving edge gateway for Org VDC network %s : %s", orgVdcNet.OrgVDCNetwork.Name, err)
+			return Task{}, fmt.Errorf("error retrieving edge gateway for Org VDC network %s : %w", orgVdcNet.OrgVDCNetwork.Name, err)
 		}
 		orgVdcNet.OrgVDCNetwork.EdgeGateway = &types.Reference{
 			HREF: edgeGateway.EdgeGateway.HREF,
@@ -24,12 +24,12 @@ func setProductSectionList(client *Client, href string, productSection *types.Pr
 		types.MimeProductSection, "error setting product section: %s", productSection)
 
 	if err != nil {
-		return fmt.Errorf("unable to set product section: %s", err)
+		return fmt.Errorf("unable to set product section: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("task for setting product section failed: %s", err)
+		return fmt.Errorf("task for setting product section failed: %w", err)
 	}
 
 	return nil
@@ -46,7 +46,7 @@ func getProductSectionList(client *Client, href string) (*types.ProductSectionLi
 		types.MimeProductSection, "error retrieving product section : %s", nil, productSection)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve product section: %s", err)
+		return nil, fmt.Errorf("unable to retrieve product section: %w", err)
 	}
 
 	return productSection, nil
@@ -227,10 +227,10 @@ func (vcdClient *VCDClient) CreateProviderVdc(params *types.ProviderVdcCreation)
 	if pvdc.VMWProviderVdc.Tasks == nil {
 		err = pvdc.Refresh()
 		if err != nil {
-			return pvdc, fmt.Errorf("error refreshing provider VDC %s: %s", params.Name, err)
+			return pvdc, fmt.Errorf("error refreshing provider VDC %s: %w", params.Name, err)
 		}
 		if pvdc.VMWProviderVdc.Tasks == nil {
-			return pvdc, fmt.Errorf("provider VDC %s was created, but no completion task was found: %s", params.Name, err)
+			return pvdc, fmt.Errorf("provider VDC %s was created, but no completion task was found: %w", params.Name, err)
 		}
 	}
 	for _, taskInProgress := range pvdc.VMWProviderVdc.Tasks.Task {
@@ -240,7 +240,7 @@ func (vcdClient *VCDClient) CreateProviderVdc(params *types.ProviderVdcCreation)
 		}
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return pvdc, fmt.Errorf("provider VDC %s was created, but it is not ready: %s", params.Name, err)
+			return pvdc, fmt.Errorf("provider VDC %s was created, but it is not ready: %w", params.Name, err)
 		}
 	}
 
@@ -405,7 +405,7 @@ func (pvdc *ProviderVdcExtended) DeleteResourcePools(resourcePools []*ResourcePo
 
 	usedResourcePools, err := pvdc.GetResourcePools()
 	if err != nil {
-		return fmt.Errorf("error retrieving used resource pools: %s", err)
+		return fmt.Errorf("error retrieving used resource pools: %w", err)
 	}
 
 	var items []*types.Reference
@@ -429,7 +429,7 @@ func (pvdc *ProviderVdcExtended) DeleteResourcePools(resourcePools []*ResourcePo
 		if foundUsed.IsEnabled {
 			err = disableResourcePool(pvdc.client, foundUsed.HREF)
 			if err != nil {
-				return fmt.Errorf("error disabling resource pool %s: %s", foundUsed.Name, err)
+				return fmt.Errorf("error disabling resource pool %s: %w", foundUsed.Name, err)
 			}
 		}
 
@@ -469,7 +469,7 @@ func (pvdc *ProviderVdcExtended) GetResourcePools() ([]*types.QueryResultResourc
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("could not get the Resource pool: %s", err)
+		return nil, fmt.Errorf("could not get the Resource pool: %w", err)
 	}
 	return resourcePools.Results.ResourcePoolRecord, nil
 }
@@ -517,7 +517,7 @@ func (pvdc *ProviderVdcExtended) checkProgress(label string) error {
 	for ResourceInProgress(pvdc.VMWProviderVdc.Tasks) {
 		err = pvdc.Refresh()
 		if err != nil {
-			return fmt.Errorf("error %s: %s", label, err)
+			return fmt.Errorf("error %s: %w", label, err)
 		}
 		time.Sleep(200 * time.Millisecond)
 		elapsed = time.Since(start)
@@ -571,7 +571,7 @@ func (pvdc *ProviderVdcExtended) DeleteStorageProfiles(storageProfiles []string)
 	for _, sp := range toBeDeleted {
 		err = disableStorageProfile(pvdc.client, sp.HREF)
 		if err != nil {
-			return fmt.Errorf("error disabling storage profile %s from provider VDC %s: %s", sp.Name, pvdc.VMWProviderVdc.Name, err)
+			return fmt.Errorf("error disabling storage profile %s from provider VDC %s: %w", sp.Name, pvdc.VMWProviderVdc.Name, err)
 		}
 	}
 	input := &types.RemoveStorageProfile{RemoveStorageProfile: toBeDeleted}
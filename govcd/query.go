@@ -87,13 +87,13 @@ func (vdc *Vdc) QueryWithNotEncodedParamsWithApiVersion(params map[string]string
 func getResult(client *Client, request *http.Request) (Results, error) {
 	resp, err := checkResp(client.Http.Do(request))
 	if err != nil {
-		return Results{}, fmt.Errorf("error retrieving query: %s", err)
+		return Results{}, fmt.Errorf("error retrieving query: %w", err)
 	}
 
 	results := NewResults(client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, results.Results); err != nil {
-		return Results{}, fmt.Errorf("error decoding query results: %s", err)
+		return Results{}, fmt.Errorf("error decoding query results: %w", err)
 	}
 
 	return *results, nil
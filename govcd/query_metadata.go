@@ -321,7 +321,7 @@ func (client *Client) queryWithMetadataFields(queryType string, params, notEncod
 
 	fields, err := queryFieldsOnDemand(queryType)
 	if err != nil {
-		return Results{}, fmt.Errorf("[queryWithMetadataFields] %s", err)
+		return Results{}, fmt.Errorf("[queryWithMetadataFields] %w", err)
 	}
 
 	if len(fields) == 0 {
@@ -0,0 +1,170 @@
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the number of API requests that the client sends per second. Up to `burst`
+// requests can be sent at once after a period of inactivity. Requests that exceed the limit wait
+// until they are allowed or until their context is done.
+//
+// The limit is enforced by the HTTP transport of the client (Client.Http), not when requests are
+// built. Therefore it applies to every request sent through Client.Http, including each attempt of
+// a request retried by WithRetryPolicy and requests replayed by WithAutoReauthenticate. Replacing
+// Client.Http or its Transport after NewVCDClient removes the limit.
+//
+// Task polling requests (performed by Task.WaitTaskCompletion and similar functions) share the same
+// limit unless WithTaskPollingRateLimit is used.
+func WithRateLimit(requestsPerSecond float64, burst int) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		bucket, err := newTokenBucket(requestsPerSecond, burst)
+		if err != nil {
+			return fmt.Errorf("error configuring rate limit: %w", err)
+		}
+		vcdClient.Client.rateLimiter = bucket
+		return nil
+	}
+}
+
+// WithTaskPollingRateLimit sets a separate limit of requests per second for task polling so that
+// waiting for many long-running tasks does not consume the limit set by WithRateLimit (and the
+// other way around)
+func WithTaskPollingRateLimit(requestsPerSecond float64, burst int) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		bucket, err := newTokenBucket(requestsPerSecond, burst)
+		if err != nil {
+			return fmt.Errorf("error configuring task polling rate limit: %w", err)
+		}
+		vcdClient.Client.taskPollingRateLimiter = bucket
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of API requests that can be in flight at the same
+// time. A request occupies a slot until response headers are received. Requests that exceed the
+// limit wait for a free slot or until their context is done.
+//
+// Like WithRateLimit, the limit is enforced by the HTTP transport of the client and applies to
+// each attempt of a retried request. A request does not hold its slot while it waits to be retried.
+func WithMaxConcurrentRequests(maxConcurrentRequests int) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if maxConcurrentRequests <= 0 {
+			return fmt.Errorf("maximum number of concurrent requests must be positive, got %d", maxConcurrentRequests)
+		}
+		vcdClient.Client.concurrencyLimiter = make(chan struct{}, maxConcurrentRequests)
+		return nil
+	}
+}
+
+// taskPollingContextKey marks request contexts of task polling requests
+type taskPollingContextKey struct{}
+
+// contextWithTaskPolling marks the context as belonging to a task polling request
+func contextWithTaskPolling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, taskPollingContextKey{}, true)
+}
+
+// isTaskPolling checks if the context belongs to a task polling request
+func isTaskPolling(ctx context.Context) bool {
+	taskPolling, _ := ctx.Value(taskPollingContextKey{}).(bool)
+	return taskPolling
+}
+
+// limitTransport is an http.RoundTripper that applies rate and concurrency limits
+type limitTransport struct {
+	next                   http.RoundTripper
+	rateLimiter            *tokenBucket
+	taskPollingRateLimiter *tokenBucket
+	concurrencyLimiter     chan struct{}
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	rateLimiter := transport.rateLimiter
+	if transport.taskPollingRateLimiter != nil && isTaskPolling(ctx) {
+		rateLimiter = transport.taskPollingRateLimiter
+	}
+	if rateLimiter != nil {
+		if err := rateLimiter.wait(ctx); err != nil {
+			return nil, fmt.Errorf("error waiting for rate limit for %s %s: %w", req.Method, req.URL.String(), err)
+		}
+	}
+
+	if transport.concurrencyLimiter != nil {
+		select {
+		case transport.concurrencyLimiter <- struct{}{}:
+			defer func() { <-transport.concurrencyLimiter }()
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error waiting for a free concurrent request slot for %s %s: %w", req.Method,
+				req.URL.String(), ctx.Err())
+		}
+	}
+
+	return transport.next.RoundTrip(req)
+}
+
+// tokenBucket is a simple token bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens
+	tokens float64 // available tokens. It is negative when there are waiting reservations
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) (*tokenBucket, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("requests per second must be positive, got %f", rate)
+	}
+	if burst <= 0 {
+		return nil, fmt.Errorf("burst must be positive, got %d", burst)
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// wait reserves a token and blocks until it becomes available. The reservation is cancelled if
+// the context is done before that.
+func (bucket *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	bucket.mu.Lock()
+	now := time.Now()
+	bucket.tokens = min(bucket.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*bucket.rate)
+	bucket.last = now
+	bucket.tokens--
+	var delay time.Duration
+	if bucket.tokens < 0 {
+		delay = time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+	}
+	bucket.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.mu.Lock()
+		bucket.tokens++
+		bucket.mu.Unlock()
+		return ctx.Err()
+	}
+}
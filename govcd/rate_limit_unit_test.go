@@ -0,0 +1,137 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// limitTestServer responds with a completed task to every request after `delay`. It records the
+// maximum number of concurrent requests
+func limitTestServer(delay time.Duration, maxConcurrent *int32) *httptest.Server {
+	var concurrent int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&concurrent, 1)
+		defer atomic.AddInt32(&concurrent, -1)
+		for {
+			observed := atomic.LoadInt32(maxConcurrent)
+			if current <= observed || atomic.CompareAndSwapInt32(maxConcurrent, observed, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" name="task" ` +
+			`href="` + "http://" + r.Host + r.URL.Path + `"></Task>`))
+	}))
+}
+
+func limitTestRequest(vcdClient *VCDClient, ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := vcdClient.Client.Http.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestWithRateLimit(t *testing.T) {
+	var maxConcurrent int32
+	server := limitTestServer(0, &maxConcurrent)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRateLimit(20, 2))
+
+	// 2 requests fit into the burst and each of the remaining 4 waits for 50ms
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := limitTestRequest(vcdClient, context.Background(), server.URL+"/api/org"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, took only %s", elapsed)
+	}
+
+	// A request waiting for rate limit must respect context
+	vcdClient = testContextClient(t, server.URL, WithRateLimit(0.1, 1))
+	if err := limitTestRequest(vcdClient, context.Background(), server.URL+"/api/org"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := limitTestRequest(vcdClient, ctx, server.URL+"/api/org")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+}
+
+func TestWithTaskPollingRateLimit(t *testing.T) {
+	var maxConcurrent int32
+	server := limitTestServer(0, &maxConcurrent)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRateLimit(0.1, 1), WithTaskPollingRateLimit(1000, 10))
+
+	// Exhaust the limit of regular API calls
+	if err := limitTestRequest(vcdClient, context.Background(), server.URL+"/api/org"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Task polling uses a separate bucket and must not wait for the regular one
+	task := NewTask(&vcdClient.Client)
+	task.Task.HREF = server.URL + "/api/task/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		if err := task.RefreshCtx(ctx); err != nil {
+			t.Fatalf("unexpected error refreshing task: %s", err)
+		}
+	}
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	var maxConcurrent int32
+	server := limitTestServer(20*time.Millisecond, &maxConcurrent)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limitTestRequest(vcdClient, context.Background(), server.URL+"/api/org"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxConcurrent > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxConcurrent)
+	}
+}
+
+func TestRateLimitOptionValidation(t *testing.T) {
+	vcdClient := &VCDClient{}
+	for _, option := range []VCDClientOption{WithRateLimit(0, 1), WithRateLimit(1, 0),
+		WithTaskPollingRateLimit(-1, 1), WithMaxConcurrentRequests(0)} {
+		if err := option(vcdClient); err == nil {
+			t.Errorf("expected an error for invalid option")
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package govcd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// WithAutoReauthenticate enables automatic re-authentication when the session expires. When an
+// API call fails with HTTP 401 (Unauthorized), the client repeats the authentication method that
+// was last used successfully, updates the token and replays the failed request once.
+//
+// The following authentication methods can be repeated:
+// * Authenticate and GetAuthResponse (user and password, including SAML with ADFS)
+// * SetToken with ApiTokenHeader
+// * SetApiToken and SetApiTokenFromFile
+// * SetServiceAccountApiToken (the rotated refresh token is saved to the file again)
+//
+// A session that was set with SetToken using a bearer token cannot be renewed, because there are
+// no credentials to repeat the authentication with.
+//
+// When many requests fail at the same time, only one of them re-authenticates and the others
+// wait for the new token.
+func WithAutoReauthenticate() VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		vcdClient.Client.reauth = &reauthenticator{vcdClient: vcdClient}
+		return nil
+	}
+}
+
+// reauthenticator repeats the authentication of the client
+type reauthenticator struct {
+	// mu guards the token of the client (VCDToken and VCDAuthHeader) and the fields below
+	mu sync.RWMutex
+	// vcdClient is the client which is re-authenticated
+	vcdClient *VCDClient
+	// authenticate repeats the last successful authentication method on the given client. It is
+	// nil if the method cannot be repeated
+	authenticate func(*VCDClient) error
+	// transport is the HTTP transport that is used for re-authentication. It does not contain the
+	// re-authentication layer itself so that failed authentication does not trigger another one
+	transport http.RoundTripper
+}
+
+// recordAuthentication stores the authentication method that succeeded so that it can be repeated
+// when the session expires. It does nothing unless WithAutoReauthenticate is used.
+func (vcdClient *VCDClient) recordAuthentication(authenticate func(*VCDClient) error) {
+	reauth := vcdClient.Client.reauth
+	if reauth == nil {
+		return
+	}
+	reauth.mu.Lock()
+	defer reauth.mu.Unlock()
+	reauth.authenticate = authenticate
+}
+
+// authorization returns the authorization header name and the token of the client. It is safe to
+// call while the client is being re-authenticated
+func (client *Client) authorization() (string, string) {
+	if client.reauth != nil {
+		client.reauth.mu.RLock()
+		defer client.reauth.mu.RUnlock()
+	}
+	return client.VCDAuthHeader, client.VCDToken
+}
+
+// addAuthorizationHeaders adds the authorization token to request headers
+func addAuthorizationHeaders(header http.Header, authHeader, token string) {
+	header.Add(authHeader, token)
+	// The deprecated authorization token is 32 characters long
+	// The bearer token is 612 characters long
+	if len(token) > 32 {
+		header.Add("Authorization", "bearer "+token)
+		header.Add("X-Vmware-Vcloud-Token-Type", "Bearer")
+	}
+}
+
+// removeAuthorizationHeaders removes headers added by addAuthorizationHeaders
+func removeAuthorizationHeaders(header http.Header, authHeader string) {
+	header.Del(authHeader)
+	header.Del("Authorization")
+	header.Del("X-Vmware-Vcloud-Token-Type")
+}
+
+// reauthenticate repeats the authentication unless the token that was rejected by VCD has already
+// been replaced by another request
+func (reauth *reauthenticator) reauthenticate(rejectedToken string) error {
+	reauth.mu.Lock()
+	defer reauth.mu.Unlock()
+
+	client := &reauth.vcdClient.Client
+	if client.VCDToken != rejectedToken {
+		return nil
+	}
+	if reauth.authenticate == nil {
+		return fmt.Errorf("the authentication method of the client cannot be repeated")
+	}
+
+	// Authentication runs on a copy of the client so that concurrent requests keep using the old
+	// token until the new one is available
+	authClient := *reauth.vcdClient
+	authClient.Client.reauth = nil
+	authClient.Client.Http.Transport = reauth.transport
+	authClient.Client.VCDToken = ""
+	authClient.Client.VCDAuthHeader = ""
+	if err := reauth.authenticate(&authClient); err != nil {
+		return err
+	}
+
+	// Only the token is replaced, because it is the only value read under the lock. The other
+	// values set by the authentication depend on the organization and on the authentication
+	// method, which are the same. They are checked instead of being written, as they are read
+	// without the lock everywhere else
+	if authClient.Client.IsSysAdmin != client.IsSysAdmin ||
+		authClient.Client.UsingBearerToken != client.UsingBearerToken ||
+		authClient.Client.UsingAccessToken != client.UsingAccessToken ||
+		authClient.sessionHREF != reauth.vcdClient.sessionHREF ||
+		authClient.QueryHREF != reauth.vcdClient.QueryHREF {
+		return fmt.Errorf("the re-authenticated session differs from the original one")
+	}
+	client.VCDToken = authClient.Client.VCDToken
+	client.VCDAuthHeader = authClient.Client.VCDAuthHeader
+	return nil
+}
+
+// reauthTransport is an http.RoundTripper that re-authenticates the client and replays requests
+// which failed with HTTP 401
+type reauthTransport struct {
+	next   http.RoundTripper
+	reauth *reauthenticator
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := transport.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Requests without a token (e.g. login requests) and requests which body cannot be replayed
+	// are not handled
+	authHeader, token := transport.reauth.vcdClient.Client.authorization()
+	rejectedToken := req.Header.Get(authHeader)
+	if authHeader == "" || rejectedToken == "" || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, err
+	}
+
+	if rejectedToken == token {
+		util.Logger.Printf("[DEBUG] session expired for %s %s, authenticating again", req.Method, req.URL.String())
+		if reauthErr := transport.reauth.reauthenticate(rejectedToken); reauthErr != nil {
+			util.Logger.Printf("[DEBUG] re-authentication failed: %s", reauthErr)
+			return resp, nil
+		}
+	}
+
+	// The response will not be returned, therefore its body must be consumed and closed so that
+	// the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	newAuthHeader, newToken := transport.reauth.vcdClient.Client.authorization()
+	replayReq := req.Clone(req.Context())
+	removeAuthorizationHeaders(replayReq.Header, authHeader)
+	removeAuthorizationHeaders(replayReq.Header, newAuthHeader)
+	addAuthorizationHeaders(replayReq.Header, newAuthHeader, newToken)
+	if req.GetBody != nil {
+		replayReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("error replaying request body after re-authentication: %w", err)
+		}
+	}
+	return transport.next.RoundTrip(replayReq)
+}
@@ -0,0 +1,187 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// reauthTestServer issues a new bearer token for each API token authentication and accepts only
+// the latest issued token. Calling `expire` invalidates the current token
+func reauthTestServer(t *testing.T, tokenCount *int32) (server *httptest.Server, expire func()) {
+	var mu sync.Mutex
+	validToken := ""
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/oauth/") {
+			if err := r.ParseForm(); err != nil || r.Form.Get("refresh_token") != "api-token" {
+				t.Errorf("unexpected token request %s", r.Form.Encode())
+			}
+			mu.Lock()
+			validToken = fmt.Sprintf("bearer-token-%d", atomic.AddInt32(tokenCount, 1))
+			token := validToken
+			mu.Unlock()
+			w.Header().Set("Content-Type", types.JSONMime)
+			_, _ = fmt.Fprintf(w, `{"access_token":"%s","token_type":"Bearer","expires_in":3600}`, token)
+			return
+		}
+
+		mu.Lock()
+		valid := validToken != "" && r.Header.Get(BearerTokenHeader) == validToken
+		mu.Unlock()
+		if !valid {
+			w.Header().Set("Content-Type", types.JSONMime)
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"minorErrorCode":"UNAUTHORIZED","message":"session expired"}`))
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/org":
+			w.Header().Set("Content-Type", types.MimeOrgList)
+			_, _ = w.Write([]byte(`<OrgList xmlns="http://www.vmware.com/vcloud/v1.5"></OrgList>`))
+		default:
+			w.Header().Set("Content-Type", types.JSONMime)
+			_, _ = w.Write([]byte(`{"name":"role"}`))
+		}
+	}))
+
+	return server, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		validToken = ""
+	}
+}
+
+func TestWithAutoReauthenticate(t *testing.T) {
+	var tokenCount int32
+	server, expire := reauthTestServer(t, &tokenCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithAutoReauthenticate())
+	if _, err := vcdClient.SetApiToken("org", "api-token"); err != nil {
+		t.Fatalf("error authenticating: %s", err)
+	}
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+
+	expire()
+
+	// All requests that fail at the same time must share a single re-authentication
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			role := &types.Role{}
+			if err := vcdClient.Client.OpenApiPutItem("37.0", urlRef, nil, &types.Role{Name: "role"}, role, nil); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			// The session values which are read without the lock are not written by the
+			// re-authentication
+			if vcdClient.Client.IsSysAdmin || vcdClient.QueryHREF.Path != "/api/query" {
+				t.Errorf("unexpected session values %t %s", vcdClient.Client.IsSysAdmin, vcdClient.QueryHREF.Path)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tokenCount != 2 {
+		t.Errorf("expected 2 authentications, got %d", tokenCount)
+	}
+	if vcdClient.Client.VCDToken != "bearer-token-2" {
+		t.Errorf("expected the token of the client to be updated, got %s", vcdClient.Client.VCDToken)
+	}
+}
+
+func TestWithAutoReauthenticate_DifferentSession(t *testing.T) {
+	var tokenCount int32
+	server, expire := reauthTestServer(t, &tokenCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithAutoReauthenticate())
+	if _, err := vcdClient.SetApiToken("org", "api-token"); err != nil {
+		t.Fatalf("error authenticating: %s", err)
+	}
+	// A re-authentication that does not restore the same session is rejected
+	vcdClient.recordAuthentication(func(authClient *VCDClient) error {
+		_, err := authClient.SetApiToken("system", "api-token")
+		return err
+	})
+	expire()
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+	err = vcdClient.Client.OpenApiGetItem("37.0", urlRef, nil, &types.Role{}, nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+	if vcdClient.Client.IsSysAdmin || vcdClient.Client.VCDToken != "bearer-token-1" {
+		t.Errorf("expected the session to be unchanged, got token %s", vcdClient.Client.VCDToken)
+	}
+}
+
+func TestWithAutoReauthenticate_Disabled(t *testing.T) {
+	var tokenCount int32
+	server, expire := reauthTestServer(t, &tokenCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	if _, err := vcdClient.SetApiToken("org", "api-token"); err != nil {
+		t.Fatalf("error authenticating: %s", err)
+	}
+	expire()
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+	err = vcdClient.Client.OpenApiGetItem("37.0", urlRef, nil, &types.Role{}, nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+	if tokenCount != 1 {
+		t.Errorf("expected 1 authentication, got %d", tokenCount)
+	}
+}
+
+func TestWithAutoReauthenticate_BearerToken(t *testing.T) {
+	var tokenCount int32
+	server, expire := reauthTestServer(t, &tokenCount)
+	defer server.Close()
+
+	// Get a valid bearer token first
+	vcdClient := testContextClient(t, server.URL)
+	tokenRefresh, err := vcdClient.GetBearerTokenFromApiToken("org", "api-token")
+	if err != nil {
+		t.Fatalf("error getting bearer token: %s", err)
+	}
+
+	// A session set with a bearer token cannot be renewed
+	vcdClient = testContextClient(t, server.URL, WithAutoReauthenticate())
+	if err := vcdClient.SetToken("org", BearerTokenHeader, tokenRefresh.AccessToken); err != nil {
+		t.Fatalf("error setting token: %s", err)
+	}
+	expire()
+
+	_, err = vcdClient.Client.ExecuteRequest(server.URL+"/api/org", http.MethodGet, "",
+		"error retrieving org list: %s", nil, &types.OrgList{})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+	if tokenCount != 1 {
+		t.Errorf("expected 1 authentication, got %d", tokenCount)
+	}
+}
@@ -0,0 +1,256 @@
+package govcd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy defines how API calls that fail with transient errors are retried. It is enabled
+// with WithRetryPolicy and applies to every HTTP request performed by the client (both XML API
+// and OpenAPI).
+//
+// A request is retried when:
+// * the response has one of the RetryStatusCodes. HTTP 429 (Too Many Requests) is retried for any
+// method, because VCD rejects such requests without processing them. Other status codes are only
+// retried for IdempotentMethods
+// * the response has minor error code BUSY_ENTITY (for any method) unless DisableBusyEntityRetry is
+// set
+// * the connection was refused (for any method) or reset (only for IdempotentMethods) unless
+// DisableConnectionErrorRetry is set
+//
+// Requests with a body are only retried when the body can be replayed (http.Request.GetBody is
+// set). All requests created by this SDK satisfy this, except for file uploads.
+//
+// Zero values are replaced with defaults of DefaultRetryPolicy, therefore one can only override
+// required fields, e.g. RetryPolicy{MaxAttempts: 10}
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one (default 5)
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry (default 1s)
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between attempts (default 30s). A delay requested by the
+	// server using 'Retry-After' header is honored even if it is longer
+	MaxBackoff time.Duration
+	// Multiplier increases the delay after each attempt (default 2)
+	Multiplier float64
+	// Jitter randomizes each delay by the given fraction (default 0.2 meaning +/-20%). Negative
+	// value disables jitter
+	Jitter float64
+	// RetryStatusCodes lists HTTP status codes that are retried (default 429, 503, 504)
+	RetryStatusCodes []int
+	// IdempotentMethods lists HTTP methods that can safely be sent more than once (default GET,
+	// HEAD, OPTIONS, PUT, DELETE)
+	IdempotentMethods []string
+	// DisableBusyEntityRetry disables retries of responses with minor error code BUSY_ENTITY
+	DisableBusyEntityRetry bool
+	// DisableConnectionErrorRetry disables retries of refused and reset connections
+	DisableConnectionErrorRetry bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with default values which retries HTTP 429, 503, 504,
+// BUSY_ENTITY errors and connection errors up to 5 times
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    time.Second,
+		MaxBackoff:        30 * time.Second,
+		Multiplier:        2,
+		Jitter:            0.2,
+		RetryStatusCodes:  []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		IdempotentMethods: []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete},
+	}
+}
+
+// WithRetryPolicy enables retries of API calls that fail with transient errors as defined by
+// the given RetryPolicy. DefaultRetryPolicy() can be used for default behavior.
+func WithRetryPolicy(policy RetryPolicy) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if policy.MaxAttempts < 0 || policy.InitialBackoff < 0 || policy.MaxBackoff < 0 || policy.Multiplier < 0 {
+			return fmt.Errorf("retry policy values cannot be negative: %+v", policy)
+		}
+		withDefaults := policy.withDefaults()
+		vcdClient.Client.retryPolicy = &withDefaults
+		return nil
+	}
+}
+
+// withDefaults returns a copy of RetryPolicy with zero values replaced by defaults
+func (policy RetryPolicy) withDefaults() RetryPolicy {
+	defaults := DefaultRetryPolicy()
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	if policy.Multiplier == 0 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	if policy.Jitter == 0 {
+		policy.Jitter = defaults.Jitter
+	}
+	if policy.RetryStatusCodes == nil {
+		policy.RetryStatusCodes = defaults.RetryStatusCodes
+	}
+	if policy.IdempotentMethods == nil {
+		policy.IdempotentMethods = defaults.IdempotentMethods
+	}
+	return policy
+}
+
+// backoff returns the delay before the given retry (starting from 1)
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(policy.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		delay *= policy.Multiplier
+		if delay >= float64(policy.MaxBackoff) {
+			break
+		}
+	}
+	delay = min(delay, float64(policy.MaxBackoff))
+
+	if policy.Jitter > 0 {
+		delay *= 1 + policy.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(delay)
+}
+
+// retryTransport is an http.RoundTripper that retries requests according to RetryPolicy
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+	logger *slog.Logger
+}
+
+// maxBusyEntityBodySize limits the size of error response bodies that are inspected for
+// BUSY_ENTITY minor error code
+const maxBusyEntityBodySize = 64 * 1024
+
+// RoundTrip implements http.RoundTripper
+func (transport *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		resp, err := transport.next.RoundTrip(attemptReq)
+
+		reason, retryAfter := transport.retryReason(req, resp, err)
+		if reason == "" || attempt >= transport.policy.MaxAttempts || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		delay := max(transport.policy.backoff(attempt), retryAfter)
+		logRetry(req.Context(), transport.logger, req, attempt+1, transport.policy.MaxAttempts, delay, reason)
+		recordRetry(req.Context())
+
+		// The response will not be returned, therefore its body must be consumed and closed so
+		// that the connection can be reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("stopped retrying %s %s after %d attempts (%s): %w", req.Method, req.URL.String(),
+				attempt, reason, req.Context().Err())
+		case <-time.After(delay):
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error replaying request body for retry: %w", err)
+			}
+		}
+	}
+}
+
+// retryReason returns a non-empty reason when a request must be retried together with the delay
+// requested by server in 'Retry-After' header (if any)
+func (transport *retryTransport) retryReason(req *http.Request, resp *http.Response, err error) (string, time.Duration) {
+	idempotent := slices.Contains(transport.policy.IdempotentMethods, req.Method)
+
+	if err != nil {
+		if transport.policy.DisableConnectionErrorRetry || req.Context().Err() != nil {
+			return "", 0
+		}
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return fmt.Sprintf("connection refused: %s", err), 0
+		}
+		if idempotent && isConnectionReset(err) {
+			return fmt.Sprintf("connection reset: %s", err), 0
+		}
+		return "", 0
+	}
+
+	if slices.Contains(transport.policy.RetryStatusCodes, resp.StatusCode) &&
+		(idempotent || resp.StatusCode == http.StatusTooManyRequests) {
+		return fmt.Sprintf("HTTP status %s", resp.Status), parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	if !transport.policy.DisableBusyEntityRetry && resp.StatusCode >= http.StatusBadRequest && isBusyEntityResponse(resp) {
+		return "entity is busy", parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	return "", 0
+}
+
+// isConnectionReset checks if an error signals that an established connection was dropped. The
+// request may have been processed by the server in such case.
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isBusyEntityResponse checks if the error response body contains BUSY_ENTITY minor error code.
+// The body is restored so that it can be read by the caller.
+func isBusyEntityResponse(resp *http.Response) bool {
+	if resp.Body == nil || resp.ContentLength > maxBusyEntityBodySize {
+		return false
+	}
+
+	// Bodies of unknown length (e.g. chunked) can be longer than the inspected part. The rest of
+	// the body is left unread and the original body is still closed by the caller
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBusyEntityBodySize+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(body, []byte(minorErrorCodeBusyEntity))
+}
+
+// parseRetryAfter parses the value of 'Retry-After' header which can either contain a number of
+// seconds or an HTTP date. It returns 0 if the value is empty or invalid.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,224 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// testRetryPolicy retries quickly so that unit tests do not take long
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     10 * time.Millisecond,
+}
+
+// retryTestServer fails the first `failures` requests using given `fail` function and responds
+// with HTTP 200 afterwards. It also verifies that request body is the same for each attempt
+func retryTestServer(t *testing.T, failures int32, fail func(w http.ResponseWriter), requestCount *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(requestCount, 1)
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPut || r.Method == http.MethodPost {
+			if string(body) != `{"name":"role"}` {
+				t.Errorf("request body was not replayed, got '%s'", string(body))
+			}
+		}
+		if count <= failures {
+			fail(w)
+			return
+		}
+		w.Header().Set("Content-Type", types.JSONMime)
+		_, _ = w.Write([]byte(`{"name":"role"}`))
+	}))
+}
+
+func retryTestRequest(t *testing.T, vcdClient *VCDClient, ctx context.Context, method, url string) (*http.Response, error) {
+	var body io.Reader
+	if method == http.MethodPut || method == http.MethodPost {
+		body = strings.NewReader(`{"name":"role"}`)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	resp, err := vcdClient.Client.Http.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestRetryPolicy(t *testing.T) {
+	serviceUnavailable := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	tooManyRequests := func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+	busyEntity := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", types.JSONMime)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"minorErrorCode":"BUSY_ENTITY","message":"entity is busy"}`))
+	}
+	badRequest := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"minorErrorCode":"BAD_REQUEST","message":"invalid"}`))
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		failures         int32
+		fail             func(w http.ResponseWriter)
+		expectedStatus   int
+		expectedRequests int32
+	}{
+		{name: "GetServiceUnavailable", method: http.MethodGet, failures: 2, fail: serviceUnavailable, expectedStatus: http.StatusOK, expectedRequests: 3},
+		{name: "PutServiceUnavailable", method: http.MethodPut, failures: 1, fail: serviceUnavailable, expectedStatus: http.StatusOK, expectedRequests: 2},
+		{name: "PostServiceUnavailableNotIdempotent", method: http.MethodPost, failures: 1, fail: serviceUnavailable, expectedStatus: http.StatusServiceUnavailable, expectedRequests: 1},
+		{name: "PostTooManyRequests", method: http.MethodPost, failures: 2, fail: tooManyRequests, expectedStatus: http.StatusOK, expectedRequests: 3},
+		{name: "PostBusyEntity", method: http.MethodPost, failures: 1, fail: busyEntity, expectedStatus: http.StatusOK, expectedRequests: 2},
+		{name: "GetBadRequest", method: http.MethodGet, failures: 1, fail: badRequest, expectedStatus: http.StatusBadRequest, expectedRequests: 1},
+		{name: "GetAttemptsExhausted", method: http.MethodGet, failures: 5, fail: serviceUnavailable, expectedStatus: http.StatusServiceUnavailable, expectedRequests: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestCount int32
+			server := retryTestServer(t, tt.failures, tt.fail, &requestCount)
+			defer server.Close()
+
+			vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+			resp, err := retryTestRequest(t, vcdClient, context.Background(), tt.method, server.URL+"/cloudapi/1.0.0/roles/")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if requestCount != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requestCount)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_ParsedErrorBody(t *testing.T) {
+	var requestCount int32
+	server := retryTestServer(t, 10, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", types.JSONMime)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"minorErrorCode":"BUSY_ENTITY","message":"entity is busy"}`))
+	}, &requestCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+
+	// The body of the last response must still be available for parsing after it was inspected
+	err = vcdClient.Client.OpenApiGetItem("37.0", urlRef, nil, &types.Role{}, nil)
+	if !errors.Is(err, ErrBusyEntity) {
+		t.Fatalf("expected busy entity error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "entity is busy") {
+		t.Errorf("expected parsed error message, got %s", err)
+	}
+	if requestCount != 3 {
+		t.Errorf("expected 3 requests, got %d", requestCount)
+	}
+}
+
+func TestRetryPolicy_LargeChunkedErrorBody(t *testing.T) {
+	message := strings.Repeat("x", 2*maxBusyEntityBodySize) + " - end of message"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.JSONMime)
+		w.WriteHeader(http.StatusBadRequest)
+		// Flushing before writing the body makes the response chunked, without Content-Length
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(`{"minorErrorCode":"BAD_REQUEST","message":"` + message + `"}`))
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+
+	// The whole body must be available after the first part of it was inspected
+	err = vcdClient.Client.OpenApiGetItem("37.0", urlRef, nil, &types.Role{}, nil)
+	if err == nil || !strings.Contains(err.Error(), message) {
+		t.Errorf("expected the complete error message, got %.200v", err)
+	}
+}
+
+func TestRetryPolicy_ContextCancelled(t *testing.T) {
+	var requestCount int32
+	server := retryTestServer(t, 10, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, &requestCount)
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL, WithRetryPolicy(testRetryPolicy))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := retryTestRequest(t, vcdClient, ctx, http.MethodGet, server.URL+"/api/org")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline error, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("waiting for 'Retry-After' was not interrupted by context")
+	}
+	if requestCount != 1 {
+		t.Errorf("expected 1 request, got %d", requestCount)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Jitter: -1}.withDefaults()
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("retry %d: expected backoff %s, got %s", i+1, want, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(1); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("backoff with jitter out of range: %s", got)
+		}
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("120"); got != 120*time.Second {
+		t.Errorf("expected 120s, got %s", got)
+	}
+	if got := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); got < 59*time.Minute {
+		t.Errorf("expected about 1h, got %s", got)
+	}
+	for _, invalid := range []string{"", "soon", "-5"} {
+		if got := parseRetryAfter(invalid); got != 0 {
+			t.Errorf("expected 0 for '%s', got %s", invalid, got)
+		}
+	}
+}
@@ -44,7 +44,7 @@ func (client *Client) CreateRightsBundle(newRightsBundle *types.RightsBundle) (*
 
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, newRightsBundle, returnBundle.RightsBundle, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating rights bundle: %s", err)
+		return nil, fmt.Errorf("error creating rights bundle: %w", err)
 	}
 
 	return returnBundle, nil
@@ -74,7 +74,7 @@ func (rb *RightsBundle) Update() (*RightsBundle, error) {
 
 	err = rb.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, rb.RightsBundle, returnRightsBundle.RightsBundle, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating rights bundle: %s", err)
+		return nil, fmt.Errorf("error updating rights bundle: %w", err)
 	}
 
 	return returnRightsBundle, nil
@@ -255,7 +255,7 @@ func (rb *RightsBundle) Delete() error {
 	err = rb.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting rights bundle: %s", err)
+		return fmt.Errorf("error deleting rights bundle: %w", err)
 	}
 
 	return nil
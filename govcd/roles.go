@@ -148,7 +148,7 @@ func (adminOrg *AdminOrg) CreateRole(newRole *types.Role) (*Role, error) {
 
 	err = adminOrg.client.OpenApiPostItem(minimumApiVersion, urlRef, nil, newRole, returnRole.Role, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error creating role: %s", err)
+		return nil, fmt.Errorf("error creating role: %w", err)
 	}
 
 	return returnRole, nil
@@ -179,7 +179,7 @@ func (role *Role) Update() (*Role, error) {
 
 	err = role.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, role.Role, returnRole.Role, getTenantContextHeader(role.TenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating role: %s", err)
+		return nil, fmt.Errorf("error updating role: %w", err)
 	}
 
 	return returnRole, nil
@@ -205,7 +205,7 @@ func (role *Role) Delete() error {
 	err = role.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, getTenantContextHeader(role.TenantContext))
 
 	if err != nil {
-		return fmt.Errorf("error deleting role: %s", err)
+		return fmt.Errorf("error deleting role: %w", err)
 	}
 
 	return nil
@@ -273,7 +273,7 @@ func addRightsToRole(client *Client, roleType, name, id, endpoint string, newRig
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, &input, &pages, additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error adding rights to %s %s: %s", roleType, name, err)
+		return fmt.Errorf("error adding rights to %s %s: %w", roleType, name, err)
 	}
 
 	return nil
@@ -317,7 +317,7 @@ func updateRightsInRole(client *Client, roleType, name, id, endpoint string, new
 	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, &input, &pages, additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error updating rights in %s %s: %s", roleType, name, err)
+		return fmt.Errorf("error updating rights in %s %s: %w", roleType, name, err)
 	}
 
 	return nil
@@ -397,7 +397,7 @@ func removeRightsFromRole(client *Client, roleType, name, id, endpoint string, r
 	err = client.OpenApiPutItem(minimumApiVersion, urlRef, nil, &input, &pages, additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error updating rights in %s %s: %s", roleType, name, err)
+		return fmt.Errorf("error updating rights in %s %s: %w", roleType, name, err)
 	}
 
 	return nil
@@ -71,7 +71,7 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(user, pass, org, overrideRptId str
 	if overrideRptId == "" {
 		samlEntityId, err = getSamlEntityId(vcdClient, org)
 		if err != nil {
-			return fmt.Errorf("SAML - error getting vCD SAML Entity ID: %s", err)
+			return fmt.Errorf("SAML - error getting vCD SAML Entity ID: %w", err)
 		}
 	}
 
@@ -80,7 +80,7 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(user, pass, org, overrideRptId str
 	// url.Scheme + "://" + url.Host + "/login/my-org/saml/login/alias/vcd?service=tenant:" + org
 	adfsAuthEndPoint, err := getSamlAdfsServer(vcdClient, org)
 	if err != nil {
-		return fmt.Errorf("SAML - error getting IdP (ADFS): %s", err)
+		return fmt.Errorf("SAML - error getting IdP (ADFS): %w", err)
 	}
 
 	// Step 3 - authenticate to ADFS to receive SIGN token which can be used for vCD authentication
@@ -93,7 +93,7 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(user, pass, org, overrideRptId str
 	// Step 4 - gzip and base64 encode SIGN token so that vCD can understand it
 	base64GzippedSignToken, err := gzipAndBase64Encode(signToken)
 	if err != nil {
-		return fmt.Errorf("SAML - error encoding SIGN token: %s", err)
+		return fmt.Errorf("SAML - error encoding SIGN token: %w", err)
 	}
 	util.Logger.Printf("[DEBUG] SAML got SIGN token from IdP '%s' for entity with ID '%s'",
 		adfsAuthEndPoint, samlEntityId)
@@ -101,13 +101,13 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(user, pass, org, overrideRptId str
 	// Step 5 - authenticate to vCD with SIGN token and receive VCD bearer token in exchange
 	bearerToken, err := authorizeSignToken(vcdClient, base64GzippedSignToken, org)
 	if err != nil {
-		return fmt.Errorf("SAML - error submitting SIGN token to vCD: %s", err)
+		return fmt.Errorf("SAML - error submitting SIGN token to vCD: %w", err)
 	}
 
 	// Step 6 - set regular vCD auth token X-Vcloud-Authorization
 	err = vcdClient.SetToken(org, BearerTokenHeader, bearerToken)
 	if err != nil {
-		return fmt.Errorf("error during token-based authentication: %s", err)
+		return fmt.Errorf("error during token-based authentication: %w", err)
 	}
 
 	return nil
@@ -145,7 +145,7 @@ func getSamlAdfsServer(vcdCli *VCDClient, org string) (string, error) {
 	loginURLString := url.Scheme + "://" + url.Host + "/login/" + org + "/saml/login/alias/vcd"
 	loginURL, err := url.Parse(loginURLString)
 	if err != nil {
-		return "", fmt.Errorf("unable to parse login URL '%s': %s", loginURLString, err)
+		return "", fmt.Errorf("unable to parse login URL '%s': %w", loginURLString, err)
 	}
 	util.Logger.Printf("[DEBUG] SAML looking up IdP (ADFS) host redirect in: %s", loginURL.String())
 
@@ -159,12 +159,12 @@ func getSamlAdfsServer(vcdCli *VCDClient, org string) (string, error) {
 	}
 	httpResponse, err := checkResp(vcdCli.Client.Http.Do(req))
 	if err != nil {
-		return "", fmt.Errorf("SAML - ADFS server query failed: %s", err)
+		return "", fmt.Errorf("SAML - ADFS server query failed: %w", err)
 	}
 
 	err = decodeBody(types.BodyTypeXML, httpResponse, nil)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error decoding body: %s", err)
+		return "", fmt.Errorf("SAML - error decoding body: %w", err)
 	}
 
 	// httpResponse.Location() returns an error if no 'Location' header is present
@@ -217,20 +217,20 @@ func getSamlAuthToken(vcdCli *VCDClient, user, pass, samlEntityId, authEndpoint,
 	// Post to ADFS endpoint "/adfs/services/trust/13/usernamemixed"
 	authEndpointUrl, err := url.Parse(authEndpoint)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error parsing authentication endpoint %s: %s", authEndpoint, err)
+		return "", fmt.Errorf("SAML - error parsing authentication endpoint %s: %w", authEndpoint, err)
 	}
 	req := vcdCli.Client.NewRequest(nil, http.MethodPost, *authEndpointUrl, samlTokenRequestBody)
 	req.Header.Add("Content-Type", types.SoapXML)
 	resp, err := vcdCli.Client.Http.Do(req)
 	resp, err = checkRespWithErrType(types.BodyTypeXML, resp, err, &types.AdfsAuthErrorEnvelope{})
 	if err != nil {
-		return "", fmt.Errorf("SAML - ADFS token request query failed for RPT ID ('%s'): %s",
+		return "", fmt.Errorf("SAML - ADFS token request query failed for RPT ID ('%s'): %w",
 			samlEntityId, err)
 	}
 
 	err = decodeBody(types.BodyTypeXML, resp, &tokenRequestResponse)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error decoding ADFS token request response: %s", err)
+		return "", fmt.Errorf("SAML - error decoding ADFS token request response: %w", err)
 	}
 
 	tokenString := tokenRequestResponse.Body.RequestSecurityTokenResponseCollection.RequestSecurityTokenResponse.RequestedSecurityTokenTxt.Text
@@ -244,7 +244,7 @@ func getSamlAuthToken(vcdCli *VCDClient, user, pass, samlEntityId, authEndpoint,
 func authorizeSignToken(vcdCli *VCDClient, base64GzippedSignToken, org string) (string, error) {
 	url, err := url.Parse(vcdCli.Client.VCDHREF.Scheme + "://" + vcdCli.Client.VCDHREF.Host + "/api/sessions")
 	if err != nil {
-		return "", fmt.Errorf("SAML error - could not parse URL for posting SIGN token: %s", err)
+		return "", fmt.Errorf("SAML error - could not parse URL for posting SIGN token: %w", err)
 	}
 
 	signHeader := http.Header{}
@@ -253,11 +253,11 @@ func authorizeSignToken(vcdCli *VCDClient, base64GzippedSignToken, org string) (
 	req := vcdCli.Client.newRequest(nil, nil, http.MethodPost, *url, nil, vcdCli.Client.APIVersion, signHeader)
 	resp, err := checkResp(vcdCli.Client.Http.Do(req))
 	if err != nil {
-		return "", fmt.Errorf("SAML - error submitting SIGN token for authentication to %s: %s", req.URL.String(), err)
+		return "", fmt.Errorf("SAML - error submitting SIGN token for authentication to %s: %w", req.URL.String(), err)
 	}
 	err = decodeBody(types.BodyTypeXML, resp, nil)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error decoding body SIGN token auth response: %s", err)
+		return "", fmt.Errorf("SAML - error decoding body SIGN token auth response: %w", err)
 	}
 
 	accessToken := resp.Header.Get(BearerTokenHeader)
@@ -314,10 +314,10 @@ func gzipAndBase64Encode(text string) (string, error) {
 	var gzipBuffer bytes.Buffer
 	gz := gzip.NewWriter(&gzipBuffer)
 	if _, err := gz.Write([]byte(text)); err != nil {
-		return "", fmt.Errorf("error writing to gzip buffer: %s", err)
+		return "", fmt.Errorf("error writing to gzip buffer: %w", err)
 	}
 	if err := gz.Close(); err != nil {
-		return "", fmt.Errorf("error closing gzip buffer: %s", err)
+		return "", fmt.Errorf("error closing gzip buffer: %w", err)
 	}
 	base64GzippedToken := base64.StdEncoding.EncodeToString(gzipBuffer.Bytes())
 
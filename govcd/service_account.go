@@ -71,7 +71,7 @@ func (org *Org) GetAllServiceAccounts(queryParams url.Values) ([]*ServiceAccount
 	err = client.OpenApiGetAllItems(apiVersion, urlRef, queryParams, &typeResponses, getTenantContextHeader(tenantContext))
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get service accounts: %s", err)
+		return nil, fmt.Errorf("failed to get service accounts: %w", err)
 	}
 
 	results := make([]*ServiceAccount, len(typeResponses))
@@ -92,7 +92,7 @@ func (org *Org) GetServiceAccountByName(name string) (*ServiceAccount, error) {
 
 	serviceAccounts, err := org.GetAllServiceAccounts(queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error getting service account by name: %s", err)
+		return nil, fmt.Errorf("error getting service account by name: %w", err)
 	}
 
 	serviceAccount, err := oneOrError("name", name, serviceAccounts)
@@ -115,18 +115,18 @@ func (vcdClient *VCDClient) CreateServiceAccount(orgName, name, scope, softwareI
 
 	newSaParams, err := vcdClient.RegisterToken(orgName, saParams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register Service account: %s", err)
+		return nil, fmt.Errorf("failed to register Service account: %w", err)
 	}
 
 	org, err := vcdClient.GetOrgByName(orgName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Org by name: %s", err)
+		return nil, fmt.Errorf("failed to get Org by name: %w", err)
 	}
 
 	serviceAccountID := "urn:vcloud:serviceAccount:" + newSaParams.ClientID
 	serviceAccount, err := org.GetServiceAccountById(serviceAccountID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Service account by ID: %s", err)
+		return nil, fmt.Errorf("failed to get Service account by ID: %w", err)
 	}
 
 	return serviceAccount, nil
@@ -156,7 +156,7 @@ func (sa *ServiceAccount) Update(saConfig *types.ServiceAccount) (*ServiceAccoun
 
 	err = client.OpenApiPutItem(apiVersion, urlRef, nil, saConfig, returnServiceAccount.ServiceAccount, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Service Account: %s", err)
+		return nil, fmt.Errorf("error updating Service Account: %w", err)
 	}
 
 	return returnServiceAccount, nil
@@ -180,13 +180,13 @@ func (sa *ServiceAccount) Authorize() error {
 	endpoint := fmt.Sprintf("%s://%s/oauth/%s/device_authorization", client.VCDHREF.Scheme, client.VCDHREF.Host, userDef)
 	urlRef, err := url.ParseRequestURI(endpoint)
 	if err != nil {
-		return fmt.Errorf("error getting request url from %s: %s", urlRef.String(), err)
+		return fmt.Errorf("error getting request url from %s: %w", urlRef.String(), err)
 	}
 
 	// Not an OpenAPI endpoint so hardcoding the Service Account minimal version
 	err = client.OpenApiPostUrlEncoded("37.0", urlRef, nil, data, &sa.authParams, nil)
 	if err != nil {
-		return fmt.Errorf("error authorizing service account: %s", err)
+		return fmt.Errorf("error authorizing service account: %w", err)
 	}
 
 	return nil
@@ -216,17 +216,17 @@ func (sa *ServiceAccount) Grant() error {
 
 	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
 	if err != nil {
-		return fmt.Errorf("error granting service account: %s", err)
+		return fmt.Errorf("error granting service account: %w", err)
 	}
 
 	tenantContext, err := sa.org.getTenantContext()
 	if err != nil {
-		return fmt.Errorf("error granting service account: %s", err)
+		return fmt.Errorf("error granting service account: %w", err)
 	}
 
 	err = client.OpenApiPostItem(apiVersion, urlRef, nil, userCode, nil, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return fmt.Errorf("error granting service account: %s", err)
+		return fmt.Errorf("error granting service account: %w", err)
 	}
 
 	return nil
@@ -246,7 +246,7 @@ func (sa *ServiceAccount) GetInitialApiToken() (*types.ApiTokenRefresh, error) {
 	}
 	token, err := client.getAccessToken(sa.ServiceAccount.Org.Name, "CreateServiceAccount", data)
 	if err != nil {
-		return nil, fmt.Errorf("error getting initial api token: %s", err)
+		return nil, fmt.Errorf("error getting initial api token: %w", err)
 	}
 	return token, nil
 }
@@ -332,8 +332,14 @@ func (vcdClient *VCDClient) SetServiceAccountApiToken(org, apiTokenFile string)
 
 	err = SaveServiceAccountToFile(apiTokenFile, vcdClient.Client.UserAgent, apiToken)
 	if err != nil {
-		return fmt.Errorf("failed to save service account token to %s: %s", apiTokenFile, err)
+		return fmt.Errorf("failed to save service account token to %s: %w", apiTokenFile, err)
 	}
+
+	// The refresh token of a Service Account can only be used once, therefore re-authentication
+	// must read the token that was saved to the file
+	vcdClient.recordAuthentication(func(authClient *VCDClient) error {
+		return authClient.SetServiceAccountApiToken(org, apiTokenFile)
+	})
 	return nil
 }
 
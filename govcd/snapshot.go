@@ -0,0 +1,64 @@
+package govcd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// createSnapshotAsync is a shared function for both vApp and VM. It triggers snapshot creation and
+// returns the task
+func createSnapshotAsync(client *Client, href, name string, memory, quiesce bool) (Task, error) {
+	if href == "" {
+		return Task{}, fmt.Errorf("href cannot be empty to create snapshot")
+	}
+
+	snapshotParams := &types.CreateSnapshotParams{
+		Xmlns:   types.XMLNamespaceVCloud,
+		Name:    name,
+		Memory:  memory,
+		Quiesce: quiesce,
+	}
+
+	return client.ExecuteTaskRequest(href+"/action/createSnapshot", http.MethodPost,
+		types.MimeCreateSnapshotParams, "error creating snapshot: %s", snapshotParams)
+}
+
+// revertToCurrentSnapshotAsync is a shared function for both vApp and VM. It triggers reverting to
+// the current snapshot and returns the task
+func revertToCurrentSnapshotAsync(client *Client, href string) (Task, error) {
+	if href == "" {
+		return Task{}, fmt.Errorf("href cannot be empty to revert to current snapshot")
+	}
+
+	return client.ExecuteTaskRequest(href+"/action/revertToCurrentSnapshot", http.MethodPost,
+		"", "error reverting to current snapshot: %s", nil)
+}
+
+// removeAllSnapshotsAsync is a shared function for both vApp and VM. It triggers removal of all
+// snapshots and returns the task
+func removeAllSnapshotsAsync(client *Client, href string) (Task, error) {
+	if href == "" {
+		return Task{}, fmt.Errorf("href cannot be empty to remove snapshots")
+	}
+
+	return client.ExecuteTaskRequest(href+"/action/removeAllSnapshots", http.MethodPost,
+		"", "error removing all snapshots: %s", nil)
+}
+
+// getSnapshotSection is a shared function for both vApp and VM
+func getSnapshotSection(client *Client, href string) (*types.SnapshotSection, error) {
+	if href == "" {
+		return nil, fmt.Errorf("href cannot be empty to get snapshot section")
+	}
+
+	snapshotSection := &types.SnapshotSection{}
+	_, err := client.ExecuteRequest(href+"/snapshotSection", http.MethodGet,
+		types.MimeSnapshotSection, "error retrieving snapshot section: %s", nil, snapshotSection)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshotSection, nil
+}
@@ -112,34 +112,34 @@ func (vcdClient *VCDClient) CreateSolutionAddOn(cfg SolutionAddOnConfig) (*Solut
 
 	foundFiles, err := getContentsFromIsoFiles(cfg.IsoFilePath, wantedFiles)
 	if err != nil {
-		return nil, fmt.Errorf("error reading contents of '%s': %s", cfg.IsoFilePath, err)
+		return nil, fmt.Errorf("error reading contents of '%s': %w", cfg.IsoFilePath, err)
 	}
 
 	solutionAddOnEntityRde, err := buildSolutionAddonRdeEntity(foundFiles, "administrator", cfg.CatalogItemId)
 	if err != nil {
-		return nil, fmt.Errorf("error building Solution Add-On RDE: %s", err)
+		return nil, fmt.Errorf("error building Solution Add-On RDE: %w", err)
 	}
 
 	rdeName, err := extractSolutionAddonRdeName(foundFiles)
 	if err != nil {
-		return nil, fmt.Errorf("error finding RDE Entity Name: %s", err)
+		return nil, fmt.Errorf("error finding RDE Entity Name: %w", err)
 	}
 
 	if cfg.AutoTrustCertificate {
 		certificateText, err := extractSolutionAddOnCertificate(foundFiles)
 		if err != nil {
-			return nil, fmt.Errorf("error extracting Certificate from Add-On image: %s", err)
+			return nil, fmt.Errorf("error extracting Certificate from Add-On image: %w", err)
 		}
 		isoFileName := filepath.Base(cfg.IsoFilePath)
 		err = vcdClient.TrustAddOnImageCertificate(certificateText, isoFileName)
 		if err != nil {
-			return nil, fmt.Errorf("certificate trust was request, but it failed: %s", err)
+			return nil, fmt.Errorf("certificate trust was request, but it failed: %w", err)
 		}
 	}
 
 	rdeType, err := vcdClient.GetRdeType(slzAddOnRdeType[0], slzAddOnRdeType[1], slzAddOnRdeType[2])
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving RDE Type for Solution Add-On: %s", err)
+		return nil, fmt.Errorf("error retrieving RDE Type for Solution Add-On: %w", err)
 	}
 
 	unmarshalledRdeEntityJson, err := convertAnyToRdeEntity(solutionAddOnEntityRde)
@@ -156,12 +156,12 @@ func (vcdClient *VCDClient) CreateSolutionAddOn(cfg SolutionAddOnConfig) (*Solut
 
 	createdRdeEntity, err := rdeType.CreateRde(*entityCfg, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating RDE entity: %s", err)
+		return nil, fmt.Errorf("error creating RDE entity: %w", err)
 	}
 
 	err = createdRdeEntity.Resolve()
 	if err != nil {
-		return nil, fmt.Errorf("error resolving Solutions Add-On after creating: %s", err)
+		return nil, fmt.Errorf("error resolving Solutions Add-On after creating: %w", err)
 	}
 
 	result, err := convertRdeEntityToAny[types.SolutionAddOn](createdRdeEntity.DefinedEntity.Entity)
@@ -182,14 +182,14 @@ func (vcdClient *VCDClient) CreateSolutionAddOn(cfg SolutionAddOnConfig) (*Solut
 func (vcdClient *VCDClient) GetAllSolutionAddons(queryParameters url.Values) ([]*SolutionAddOn, error) {
 	allAddons, err := vcdClient.GetAllRdes(slzAddOnRdeType[0], slzAddOnRdeType[1], slzAddOnRdeType[2], queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Solution Add-ons: %s", err)
+		return nil, fmt.Errorf("error retrieving all Solution Add-ons: %w", err)
 	}
 
 	results := make([]*SolutionAddOn, len(allAddons))
 	for index, rde := range allAddons {
 		addon, err := convertRdeEntityToAny[types.SolutionAddOn](rde.DefinedEntity.Entity)
 		if err != nil {
-			return nil, fmt.Errorf("error converting RDE to Solution Add-on: %s", err)
+			return nil, fmt.Errorf("error converting RDE to Solution Add-on: %w", err)
 		}
 
 		results[index] = &SolutionAddOn{
@@ -209,7 +209,7 @@ func (vcdClient *VCDClient) GetSolutionAddonById(id string) (*SolutionAddOn, err
 	}
 	rde, err := getRdeById(&vcdClient.Client, id)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Solution Add-On by ID: %s", err)
+		return nil, fmt.Errorf("error retrieving Solution Add-On by ID: %w", err)
 	}
 
 	result, err := convertRdeEntityToAny[types.SolutionAddOn](rde.DefinedEntity.Entity)
@@ -237,7 +237,7 @@ func (vcdClient *VCDClient) GetSolutionAddonByName(name string) (*SolutionAddOn,
 	queryParams.Add("filter", fmt.Sprintf("name==%s", name))
 	results, err := vcdClient.GetAllSolutionAddons(queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Solution Add-Ons: %s", err)
+		return nil, fmt.Errorf("error retrieving Solution Add-Ons: %w", err)
 	}
 
 	return oneOrError("name", name, results)
@@ -251,7 +251,7 @@ func (s *SolutionAddOn) Update(saoCfg *types.SolutionAddOn) (*SolutionAddOn, err
 
 	newStructure, err := s.vcdClient.GetSolutionAddonById(s.RdeId())
 	if err != nil {
-		return nil, fmt.Errorf("error creating a copy of Solution Add-On: %s", err)
+		return nil, fmt.Errorf("error creating a copy of Solution Add-On: %w", err)
 	}
 
 	newStructure.DefinedEntity.DefinedEntity.Entity = unmarshalledRdeEntityJson
@@ -309,7 +309,7 @@ func (vcdClient *VCDClient) TrustAddOnImageCertificate(certificateText, source s
 		}
 		_, err = vcdClient.Client.AddCertificateToLibrary(&certificateConfig)
 		if err != nil {
-			return fmt.Errorf("error adding certificate '%s' to library: %s", addonCertificateName, err)
+			return fmt.Errorf("error adding certificate '%s' to library: %w", addonCertificateName, err)
 		}
 	}
 
@@ -326,7 +326,7 @@ func getContentsFromIsoFiles(isoFileName string, wanted map[string]isoFileDef) (
 		if elem.nameRegexp == nil {
 			elem.nameRegexp, err = regexp.Compile(elem.namePattern)
 			if err != nil {
-				return nil, fmt.Errorf("error compiling regular expression '%s': %s", elem.namePattern, err)
+				return nil, fmt.Errorf("error compiling regular expression '%s': %w", elem.namePattern, err)
 			}
 		}
 		result[key] = elem
@@ -415,12 +415,12 @@ func buildSolutionAddonRdeEntity(foundFiles map[string]isoFileDef, user, catalog
 
 	jsonManifestText, err := yaml.YAMLToJSON(manifestText)
 	if err != nil {
-		return nil, fmt.Errorf("error converting manifest file '%s' to JSON: %s", foundFiles[manifestKey].foundFileName, err)
+		return nil, fmt.Errorf("error converting manifest file '%s' to JSON: %w", foundFiles[manifestKey].foundFileName, err)
 	}
 	var manifestStruct map[string]any
 	err = json.Unmarshal(jsonManifestText, &manifestStruct)
 	if err != nil {
-		return nil, fmt.Errorf("error encoding manifest file '%s' to JSON: %s", foundFiles[manifestKey].foundFileName, err)
+		return nil, fmt.Errorf("error encoding manifest file '%s' to JSON: %w", foundFiles[manifestKey].foundFileName, err)
 	}
 
 	iconEntry := ""
@@ -454,7 +454,7 @@ func extractSolutionAddOnCertificate(foundFiles map[string]isoFileDef) (string,
 
 	if certificateString == "" {
 		certFilter := wantedFiles[certificateKey].namePattern
-		return "", fmt.Errorf("%s: no certificate file found based on name filter '%s'", ErrorEntityNotFound, certFilter)
+		return "", fmt.Errorf("%w: no certificate file found based on name filter '%s'", ErrorEntityNotFound, certFilter)
 	}
 
 	return certificateString, nil
@@ -465,12 +465,12 @@ func extractSolutionAddonRdeName(foundFiles map[string]isoFileDef) (string, erro
 
 	jsonManifestText, err := yaml.YAMLToJSON(manifestText)
 	if err != nil {
-		return "", fmt.Errorf("error converting manifest file '%s' to JSON: %s", foundFiles[manifestKey].foundFileName, err)
+		return "", fmt.Errorf("error converting manifest file '%s' to JSON: %w", foundFiles[manifestKey].foundFileName, err)
 	}
 	var manifestStruct map[string]any
 	err = json.Unmarshal(jsonManifestText, &manifestStruct)
 	if err != nil {
-		return "", fmt.Errorf("error encoding manifest file '%s' to JSON: %s", foundFiles[manifestKey].foundFileName, err)
+		return "", fmt.Errorf("error encoding manifest file '%s' to JSON: %w", foundFiles[manifestKey].foundFileName, err)
 	}
 
 	vendor, ok := manifestStruct[manifestVendor]
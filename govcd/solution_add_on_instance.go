@@ -52,13 +52,13 @@ func (addon *SolutionAddOn) CreateSolutionAddOnInstance(inputs map[string]interf
 	parentRde := addon.DefinedEntity
 	result, err := parentRde.InvokeBehavior(addOnCreateInstanceBehaviorId, behaviorInvocation)
 	if err != nil {
-		return nil, "", fmt.Errorf("error invoking RDE behavior: %s", err)
+		return nil, "", fmt.Errorf("error invoking RDE behavior: %w", err)
 	}
 
 	// Once the task is done and no error are here, one must find that instance from scratch
 	createdAddOnInstance, err := addon.GetInstanceByName(name)
 	if err != nil {
-		return nil, "", fmt.Errorf("error retrieving Solution Add-On instance '%s' after creation: %s", name, err)
+		return nil, "", fmt.Errorf("error retrieving Solution Add-On instance '%s' after creation: %w", name, err)
 	}
 
 	return createdAddOnInstance, result, nil
@@ -86,7 +86,7 @@ func (addon *SolutionAddOn) GetInstanceByName(name string) (*SolutionAddOnInstan
 
 	addOnInstances, err := vcdClient.GetAllSolutionAddonInstances(queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Solution Add-On Instance with name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving Solution Add-On Instance with name '%s': %w", name, err)
 	}
 
 	return oneOrError("name", name, addOnInstances)
@@ -107,7 +107,7 @@ func (vcdClient *VCDClient) GetSolutionAddonInstanceByName(name string) (*Soluti
 
 	addOnInstances, err := vcdClient.GetAllSolutionAddonInstances(queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Solution Add-On Instance with name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving Solution Add-On Instance with name '%s': %w", name, err)
 	}
 
 	return oneOrError("name", name, addOnInstances)
@@ -117,14 +117,14 @@ func (vcdClient *VCDClient) GetSolutionAddonInstanceByName(name string) (*Soluti
 func (vcdClient *VCDClient) GetAllSolutionAddonInstances(queryParameters url.Values) ([]*SolutionAddOnInstance, error) {
 	allAddonInstances, err := vcdClient.GetAllRdes(slzAddOnInstanceRdeType[0], slzAddOnInstanceRdeType[1], slzAddOnInstanceRdeType[2], queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Solution Add-on Instances: %s", err)
+		return nil, fmt.Errorf("error retrieving all Solution Add-on Instances: %w", err)
 	}
 
 	results := make([]*SolutionAddOnInstance, len(allAddonInstances))
 	for index, rde := range allAddonInstances {
 		addon, err := convertRdeEntityToAny[types.SolutionAddOnInstance](rde.DefinedEntity.Entity)
 		if err != nil {
-			return nil, fmt.Errorf("error converting RDE to Solution Add-on Instance: %s", err)
+			return nil, fmt.Errorf("error converting RDE to Solution Add-on Instance: %w", err)
 		}
 
 		results[index] = &SolutionAddOnInstance{
@@ -141,7 +141,7 @@ func (vcdClient *VCDClient) GetAllSolutionAddonInstances(queryParameters url.Val
 func (vcdClient *VCDClient) GetSolutionAddOnInstanceById(id string) (*SolutionAddOnInstance, error) {
 	addOnInstanceRde, err := getRdeById(&vcdClient.Client, id)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Solution Add-On Instance RDE: %s", err)
+		return nil, fmt.Errorf("error retrieving Solution Add-On Instance RDE: %w", err)
 	}
 
 	addOnInstanceEntity, err := convertRdeEntityToAny[types.SolutionAddOnInstance](addOnInstanceRde.DefinedEntity.Entity)
@@ -174,7 +174,7 @@ func (addonInstance *SolutionAddOnInstance) Delete(deleteInputs map[string]inter
 	parentRde := addonInstance.DefinedEntity
 	result, err := parentRde.InvokeBehavior(addOnInstanceRemovalBehaviorId, behaviorInvocation)
 	if err != nil {
-		return "", fmt.Errorf("error invoking removal of Solution Add-On instance '%s': %s", addonInstance.SolutionAddOnInstance.Name, err)
+		return "", fmt.Errorf("error invoking removal of Solution Add-On instance '%s': %w", addonInstance.SolutionAddOnInstance.Name, err)
 	}
 
 	return result, nil
@@ -207,12 +207,12 @@ func (addOnInstance *SolutionAddOnInstance) ReadCreationInputValues(convertAllVa
 
 	parentAddOn, err := addOnInstance.GetParentSolutionAddOn()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving parent Solution Add-On: %s", err)
+		return nil, fmt.Errorf("error retrieving parent Solution Add-On: %w", err)
 	}
 
 	schemaInputFields, err := parentAddOn.extractInputs()
 	if err != nil {
-		return nil, fmt.Errorf("error extracting inputs from Solution Add-On manifests: %s", err)
+		return nil, fmt.Errorf("error extracting inputs from Solution Add-On manifests: %w", err)
 	}
 
 	// Fields are specified within addOnInstance.SolutionAddOnInstance.Properties but they contain more values
@@ -298,7 +298,7 @@ func (addon *SolutionAddOn) ValidateInputs(userInputs map[string]interface{}, va
 	if len(missingFields) > 0 {
 		fieldInfo, err := printAddonFieldData(msFields)
 		if err != nil {
-			return fmt.Errorf("error processing missing fields '%s' for: %s", addon.DefinedEntity.DefinedEntity.Name, err)
+			return fmt.Errorf("error processing missing fields '%s' for: %w", addon.DefinedEntity.DefinedEntity.Name, err)
 		}
 
 		return fmt.Errorf("%s\n\nERROR: Missing fields '%s' for Solution Add-On '%s'",
@@ -351,7 +351,7 @@ func (addon *SolutionAddOn) ConvertInputTypes(userInputs map[string]interface{})
 				// override string key to match boolean type
 				boolValue, err := strconv.ParseBool(userInputStringValue)
 				if err != nil {
-					return nil, fmt.Errorf("error converting field '%s' to boolean: %s", userInputKey, err)
+					return nil, fmt.Errorf("error converting field '%s' to boolean: %w", userInputKey, err)
 				}
 				userInputsCopy[userInputKey] = boolValue
 			case "Integer":
@@ -359,7 +359,7 @@ func (addon *SolutionAddOn) ConvertInputTypes(userInputs map[string]interface{})
 				// override string key to match integer type
 				intValue, err := strconv.Atoi(userInputStringValue)
 				if err != nil {
-					return nil, fmt.Errorf("error converting field '%s' to integer: %s", userInputKey, err)
+					return nil, fmt.Errorf("error converting field '%s' to integer: %w", userInputKey, err)
 				}
 				userInputsCopy[userInputKey] = intValue
 			default:
@@ -385,7 +385,7 @@ func (addon *SolutionAddOn) extractInputs() ([]*types.SolutionAddOnInputField, e
 	inputFieldMetadata, err := convertAllAddonInputFields(inputValidationSlice)
 	if err != nil {
 		if err != nil {
-			return nil, fmt.Errorf("error converting Solution Add-On input validation metadata: %s", err)
+			return nil, fmt.Errorf("error converting Solution Add-On input validation metadata: %w", err)
 		}
 	}
 
@@ -431,13 +431,13 @@ func convertAllAddonInputFields(allInputFields []any) ([]*types.SolutionAddOnInp
 func convertAddonInputField(field any) (*types.SolutionAddOnInputField, error) {
 	txt, err := json.Marshal(field)
 	if err != nil {
-		return nil, fmt.Errorf("failed marshalling Input Field: %s", err)
+		return nil, fmt.Errorf("failed marshalling Input Field: %w", err)
 	}
 
 	fieldType := types.SolutionAddOnInputField{}
 	err = json.Unmarshal(txt, &fieldType)
 	if err != nil {
-		return nil, fmt.Errorf("failed unmarshalling Input Field to exact type: %s", err)
+		return nil, fmt.Errorf("failed unmarshalling Input Field to exact type: %w", err)
 	}
 
 	return &fieldType, nil
@@ -33,7 +33,7 @@ func (addonInstance *SolutionAddOnInstance) Publishing(scope []string, scopeAll
 	parentRde := addonInstance.DefinedEntity
 	result, err := parentRde.InvokeBehavior(addOnInstancePublishBehaviorId, behaviorInvocation)
 	if err != nil {
-		return "", fmt.Errorf("error invoking publish behavior of Solution Add-On instance '%s': %s", addonInstance.SolutionAddOnInstance.Name, err)
+		return "", fmt.Errorf("error invoking publish behavior of Solution Add-On instance '%s': %w", addonInstance.SolutionAddOnInstance.Name, err)
 	}
 
 	return result, nil
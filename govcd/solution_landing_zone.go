@@ -37,7 +37,7 @@ func (vcdClient *VCDClient) CreateSolutionLandingZone(slzCfg *types.SolutionLand
 	// 1. Check that RDE type exists
 	rdeType, err := vcdClient.GetRdeType(slzRdeType[0], slzRdeType[1], slzRdeType[2])
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving RDE Type for Solution Landing zone: %s", err)
+		return nil, fmt.Errorf("error retrieving RDE Type for Solution Landing zone: %w", err)
 	}
 
 	// 2. Convert more precise structure to fit DefinedEntity.DefinedEntity.Entity
@@ -58,19 +58,19 @@ func (vcdClient *VCDClient) CreateSolutionLandingZone(slzCfg *types.SolutionLand
 	// 4. Create RDE
 	createdRdeEntity, err := rdeType.CreateRde(*entityCfg, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating RDE entity: %s", err)
+		return nil, fmt.Errorf("error creating RDE entity: %w", err)
 	}
 
 	// 5. Resolve RDE
 	err = createdRdeEntity.Resolve()
 	if err != nil {
-		return nil, fmt.Errorf("error resolving Solutions add-on after creating: %s", err)
+		return nil, fmt.Errorf("error resolving Solutions add-on after creating: %w", err)
 	}
 
 	// 6. Reload RDE
 	err = createdRdeEntity.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing RDE after resolving: %s", err)
+		return nil, fmt.Errorf("error refreshing RDE after resolving: %w", err)
 	}
 
 	result, err := convertRdeEntityToAny[types.SolutionLandingZoneType](createdRdeEntity.DefinedEntity.Entity)
@@ -93,7 +93,7 @@ func (vcdClient *VCDClient) CreateSolutionLandingZone(slzCfg *types.SolutionLand
 func (vcdClient *VCDClient) GetAllSolutionLandingZones(queryParameters url.Values) ([]*SolutionLandingZone, error) {
 	allSlzs, err := vcdClient.GetAllRdes(slzRdeType[0], slzRdeType[1], slzRdeType[2], queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all SLZs: %s", err)
+		return nil, fmt.Errorf("error retrieving all SLZs: %w", err)
 	}
 
 	results := make([]*SolutionLandingZone, len(allSlzs))
@@ -101,7 +101,7 @@ func (vcdClient *VCDClient) GetAllSolutionLandingZones(queryParameters url.Value
 
 		slz, err := convertRdeEntityToAny[types.SolutionLandingZoneType](slzRde.DefinedEntity.Entity)
 		if err != nil {
-			return nil, fmt.Errorf("error converting RDE to SLZ: %s", err)
+			return nil, fmt.Errorf("error converting RDE to SLZ: %w", err)
 		}
 
 		results[slzRdeIndex] = &SolutionLandingZone{
@@ -120,7 +120,7 @@ func (vcdClient *VCDClient) GetAllSolutionLandingZones(queryParameters url.Value
 func (vcdClient *VCDClient) GetExactlyOneSolutionLandingZone() (*SolutionLandingZone, error) {
 	allSlzs, err := vcdClient.GetAllSolutionLandingZones(nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all Solution Landing Zones: %s", err)
+		return nil, fmt.Errorf("error retrieving all Solution Landing Zones: %w", err)
 	}
 
 	return oneOrError("rde", strings.Join(slzRdeType[:], ":"), allSlzs)
@@ -136,7 +136,7 @@ func (vcdClient *VCDClient) GetSolutionLandingZoneById(id string) (*SolutionLand
 	}
 	rde, err := getRdeById(&vcdClient.Client, id)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving RDE by ID: %s", err)
+		return nil, fmt.Errorf("error retrieving RDE by ID: %w", err)
 	}
 
 	result, err := convertRdeEntityToAny[types.SolutionLandingZoneType](rde.DefinedEntity.Entity)
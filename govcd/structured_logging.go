@@ -0,0 +1,225 @@
+package govcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// LogLevelTrace is a slog level below slog.LevelDebug. When the handler of the logger set with
+// WithLogger is enabled for this level, API call records also contain request and response
+// headers and bodies
+const LogLevelTrace = slog.LevelDebug - 4
+
+// maxLoggedBodySize limits the size of request and response bodies that are added to log records
+const maxLoggedBodySize = 64 * 1024
+
+// WithLogger sets a structured logger for the client. Every HTTP round trip (including each
+// attempt of a retried request) is logged at slog.LevelDebug with attributes:
+// * method - HTTP method
+// * url - request URL
+// * status - HTTP status code of the response
+// * duration - time until response headers were received
+// * request_id - value of 'X-Vmware-Vcloud-Request-Id' response header
+// * client_request_id - value of 'X-Vmware-Vcloud-Client-Request-Id' request header (see
+// WithVcloudRequestIdFunc)
+// * caller - SDK function that performed the API call
+//
+// Requests that fail without a response are logged at slog.LevelWarn with an 'error' attribute.
+//
+// Headers and bodies are added at LogLevelTrace. Passwords and tokens are hidden the same way as
+// in the log of util.Logger, unless util.LogPasswords is set.
+//
+// The logger is independent of util.Logger which keeps following its own configuration.
+func WithLogger(logger *slog.Logger) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if logger == nil {
+			return fmt.Errorf("logger cannot be nil")
+		}
+		vcdClient.Client.logger = logger
+		return nil
+	}
+}
+
+// loggingTransport is an http.RoundTripper that logs every round trip using structured logger
+type loggingTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !transport.logger.Enabled(ctx, slog.LevelDebug) {
+		return transport.next.RoundTrip(req)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Redacted()),
+	}
+	if clientRequestId := req.Header.Get("X-Vmware-Vcloud-Client-Request-Id"); clientRequestId != "" {
+		attrs = append(attrs, slog.String("client_request_id", clientRequestId))
+	}
+	attrs = append(attrs, slog.String("caller", apiCallerName()))
+
+	trace := transport.logger.Enabled(ctx, LogLevelTrace)
+	var requestBody string
+	if trace {
+		requestBody = loggedRequestBody(req)
+	}
+
+	start := time.Now()
+	resp, err := transport.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		attrs = append(attrs, slog.Duration("duration", duration), slog.String("error", err.Error()))
+		transport.logger.LogAttrs(ctx, slog.LevelWarn, "API call failed", attrs...)
+		return resp, err
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("duration", duration),
+		slog.String("request_id", resp.Header.Get("X-Vmware-Vcloud-Request-Id")),
+	)
+	if trace {
+		attrs = append(attrs,
+			slog.Group("request",
+				slog.Any("header", util.SanitizedHeader(req.Header)),
+				slog.String("body", requestBody)),
+			slog.Group("response",
+				slog.Any("header", util.SanitizedHeader(resp.Header)),
+				slog.String("body", loggedResponseBody(resp))),
+		)
+	}
+	transport.logger.LogAttrs(ctx, slog.LevelDebug, "API call", attrs...)
+
+	return resp, nil
+}
+
+// isLoggedContentType checks if a body with given content type is text that can be logged
+func isLoggedContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == "" || strings.Contains(contentType, "xml") || strings.Contains(contentType, "json") ||
+		strings.HasPrefix(contentType, "text/") || strings.Contains(contentType, "x-www-form-urlencoded")
+}
+
+// loggedRequestBody returns sanitized request body for logging. Only bodies that can be replayed
+// (http.Request.GetBody is set) are read
+func loggedRequestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil || !isLoggedContentType(req.Header.Get("Content-Type")) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	return readLoggedBody(body)
+}
+
+// loggedResponseBody returns sanitized response body for logging. The part of the body that was
+// read is restored so that it can still be consumed by the caller. Bodies of unknown length are
+// not read, because they can be streamed by the server
+func loggedResponseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody || resp.ContentLength < 0 ||
+		!isLoggedContentType(resp.Header.Get("Content-Type")) {
+		return ""
+	}
+	buffer := &bytes.Buffer{}
+	logged := readLoggedBody(io.TeeReader(resp.Body, buffer))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(buffer, resp.Body), resp.Body}
+	return logged
+}
+
+// readLoggedBody reads up to maxLoggedBodySize bytes and returns them sanitized
+func readLoggedBody(reader io.Reader) string {
+	body, err := io.ReadAll(io.LimitReader(reader, maxLoggedBodySize+1))
+	if err != nil {
+		return fmt.Sprintf("[error reading body: %s]", err)
+	}
+	if len(body) > maxLoggedBodySize {
+		return util.SanitizedText(string(body[:maxLoggedBodySize])) + "...[truncated]"
+	}
+	return util.SanitizedText(string(body))
+}
+
+// govcdPackagePath is the import path of this package
+var govcdPackagePath = reflect.TypeOf(Client{}).PkgPath()
+
+// apiPlumbingFiles lists files of this package that contain generic request handling. Their
+// functions are skipped when looking for the function that performed an API call
+var apiPlumbingFiles = []string{
+	"api.go",
+	"api_json.go",
+	"instrumentation.go",
+	"openapi.go",
+	"openapi_generic_inner_entities.go",
+	"openapi_generic_outer_entities.go",
+	"rate_limit.go",
+	"retry.go",
+	"structured_logging.go",
+}
+
+// apiCallerName returns the name of the first function in the call stack that is not a part of
+// HTTP request handling
+func apiCallerName() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isApiPlumbingFrame(frame) {
+			return path.Base(frame.Function)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isApiPlumbingFrame checks if the stack frame belongs to the standard library HTTP client or to
+// generic request handling of this package
+func isApiPlumbingFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, "net/http.") || strings.HasPrefix(frame.Function, "runtime.") {
+		return true
+	}
+	return strings.HasPrefix(frame.Function, govcdPackagePath+".") &&
+		slices.Contains(apiPlumbingFiles, filepath.Base(frame.File))
+}
+
+// logRetry logs a retry of an API call using structured logger if it is set or util.Logger
+// otherwise
+func logRetry(ctx context.Context, logger *slog.Logger, req *http.Request, attempt, maxAttempts int,
+	delay time.Duration, reason string) {
+	if logger == nil {
+		util.Logger.Printf("[DEBUG] retrying %s %s in %s (attempt %d of %d): %s", req.Method, req.URL.String(),
+			delay, attempt, maxAttempts, reason)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "retrying API call",
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Redacted()),
+		slog.Int("attempt", attempt),
+		slog.Int("max_attempts", maxAttempts),
+		slog.Duration("delay", delay),
+		slog.String("reason", reason),
+	)
+}
@@ -0,0 +1,158 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// loggingTestServer responds with a body containing a token and sets request ID header
+func loggingTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.JSONMime)
+		w.Header().Set("X-Vmware-Vcloud-Request-Id", "request-id-3")
+		w.Header().Set("X-Vmware-Vcloud-Access-Token", "secret-access-token")
+		_, _ = w.Write([]byte(`{"name":"role","access_token":"secret-body-token"}`))
+	}))
+}
+
+// loggedRecords parses JSON log records written by slog.JSONHandler
+func loggedRecords(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := make(map[string]any)
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("error parsing log record '%s': %s", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	server := loggingTestServer()
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	vcdClient := testContextClient(t, server.URL, WithLogger(logger))
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointRoles)
+	if err != nil {
+		t.Fatalf("error building endpoint: %s", err)
+	}
+	role := &types.Role{}
+	err = vcdClient.Client.OpenApiGetItem("37.0", urlRef, nil, role, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if role.Name != "role" {
+		t.Errorf("response body was not available to the caller, got role %#v", role)
+	}
+
+	records := loggedRecords(t, buffer)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 log record, got %d: %s", len(records), buffer.String())
+	}
+	record := records[0]
+	expected := map[string]any{
+		"level":      "DEBUG",
+		"msg":        "API call",
+		"method":     http.MethodGet,
+		"url":        urlRef.String(),
+		"status":     float64(http.StatusOK),
+		"request_id": "request-id-3",
+		"caller":     "govcd.TestWithLogger",
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("expected attribute %s=%v, got %v", key, value, record[key])
+		}
+	}
+	if _, ok := record["duration"]; !ok {
+		t.Errorf("expected attribute duration")
+	}
+	if _, ok := record["request"]; ok {
+		t.Errorf("request details must not be logged at debug level")
+	}
+
+	// Nothing is logged when debug level is disabled
+	buffer.Reset()
+	logger = slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	vcdClient = testContextClient(t, server.URL, WithLogger(logger))
+	err = vcdClient.Client.OpenApiGetItem("37.0", urlRef, nil, role, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("expected no log records, got %s", buffer.String())
+	}
+}
+
+func TestWithLogger_Trace(t *testing.T) {
+	server := loggingTestServer()
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: LogLevelTrace}))
+	vcdClient := testContextClient(t, server.URL, WithLogger(logger))
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/cloudapi/1.0.0/users",
+		strings.NewReader(`{"name":"user","password":"secret-password"}`))
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	req.Header.Set("Content-Type", types.JSONMime)
+	req.Header.Set("Authorization", "Bearer secret-bearer-token")
+	req.Header.Set("X-Vmware-Vcloud-Client-Request-Id", "client-request-id-1")
+	resp, err := vcdClient.Client.Http.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil || string(body) != `{"name":"role","access_token":"secret-body-token"}` {
+		t.Errorf("response body was not restored after logging, got '%s' (%v)", body, err)
+	}
+
+	logged := buffer.String()
+	for _, secret := range []string{"secret-password", "secret-bearer-token", "secret-access-token", "secret-body-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log contains sensitive value '%s': %s", secret, logged)
+		}
+	}
+
+	records := loggedRecords(t, buffer)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 log record, got %d: %s", len(records), logged)
+	}
+	if records[0]["client_request_id"] != "client-request-id-1" {
+		t.Errorf("expected client_request_id attribute, got %v", records[0]["client_request_id"])
+	}
+	request, ok := records[0]["request"].(map[string]any)
+	if !ok || !strings.Contains(request["body"].(string), `"name":"user"`) {
+		t.Errorf("expected request body in the log record, got %v", records[0]["request"])
+	}
+	response, ok := records[0]["response"].(map[string]any)
+	if !ok || !strings.Contains(response["body"].(string), `"name":"role"`) {
+		t.Errorf("expected response body in the log record, got %v", records[0]["response"])
+	}
+}
+
+func TestWithLogger_Nil(t *testing.T) {
+	if err := WithLogger(nil)(&VCDClient{}); err == nil {
+		t.Errorf("expected an error for nil logger")
+	}
+}
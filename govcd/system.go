@@ -207,7 +207,7 @@ func CreateAndConfigureEdgeGatewayAsync(vcdClient *VCDClient, orgName, vdcName,
 
 	vdcId, err := getBareEntityUuid(vdc.Vdc.ID)
 	if err != nil {
-		return Task{}, fmt.Errorf("error retrieving ID from Vdc %s: %s", vdcName, err)
+		return Task{}, fmt.Errorf("error retrieving ID from Vdc %s: %w", vdcName, err)
 	}
 	if vdcId == "" {
 		return Task{}, fmt.Errorf("error retrieving ID from Vdc %s - empty ID returned", vdcName)
@@ -259,7 +259,7 @@ func createEdgeGateway(vcdClient *VCDClient, egwc EdgeGatewayCreation, egwConfig
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return EdgeGateway{}, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return EdgeGateway{}, combinedTaskError(task.Task, err)
 	}
 
 	// The edge gateway is created. Now we retrieve it from the server
@@ -332,7 +332,7 @@ func getAdminOrgByHref(vcdClient *Client, href string) (*AdminOrg, error) {
 func GetOrgByName(vcdClient *VCDClient, orgName string) (Org, error) {
 	orgUrl, err := getOrgHREF(vcdClient, orgName)
 	if err != nil {
-		return Org{}, fmt.Errorf("organization '%s' fetch failed: %s", orgName, err)
+		return Org{}, fmt.Errorf("organization '%s' fetch failed: %w", orgName, err)
 	}
 	org := NewOrg(&vcdClient.Client)
 
@@ -5,6 +5,7 @@
 package govcd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -54,6 +55,11 @@ func (task *Task) getErrorMessage(err error) string {
 
 // Refresh retrieves a fresh copy of the task
 func (task *Task) Refresh() error {
+	return task.RefreshCtx(context.Background())
+}
+
+// RefreshCtx is the context aware version of Refresh
+func (task *Task) RefreshCtx(ctx context.Context) error {
 
 	if task.Task == nil {
 		return fmt.Errorf("cannot refresh, Object is empty")
@@ -61,11 +67,12 @@ func (task *Task) Refresh() error {
 
 	refreshUrl := urlParseRequestURI(task.Task.HREF)
 
-	req := task.client.NewRequest(map[string]string{}, http.MethodGet, *refreshUrl, nil)
+	// Task polling requests can have a separate rate limit (see WithTaskPollingRateLimit)
+	req := task.client.NewRequestCtx(contextWithTaskPolling(ctx), map[string]string{}, http.MethodGet, *refreshUrl, nil)
 
 	resp, err := checkResp(task.client.Http.Do(req))
 	if err != nil {
-		return fmt.Errorf("%s: %s", errorRetrievingTask, err)
+		return fmt.Errorf("%s: %w", errorRetrievingTask, err)
 	}
 
 	// Empty struct before a new unmarshal, otherwise we end up with duplicate
@@ -73,7 +80,7 @@ func (task *Task) Refresh() error {
 	task.Task = &types.Task{}
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return fmt.Errorf("error decoding task response: %s", task.getErrorMessage(err))
+		return &messageError{message: "error decoding task response: " + task.getErrorMessage(err), err: err}
 	}
 
 	// The request was successful
@@ -96,20 +103,42 @@ type TaskMonitoringFunc func(*types.Task)
 // Users can define the sleeping duration and an optional callback function for
 // extra monitoring.
 func (task *Task) WaitInspectTaskCompletion(inspectionFunc InspectionFunc, delay time.Duration) error {
+	return task.WaitInspectTaskCompletionCtx(context.Background(), inspectionFunc, delay)
+}
+
+// WaitInspectTaskCompletionCtx is the context aware version of WaitInspectTaskCompletion. It stops
+// waiting and returns an error as soon as the given context is done. Note. The task itself is not
+// cancelled in VCD - use CancelTask for that.
+func (task *Task) WaitInspectTaskCompletionCtx(ctx context.Context, inspectionFunc InspectionFunc, delay time.Duration) error {
 
 	if task.Task == nil {
 		return fmt.Errorf("cannot refresh, Object is empty")
 	}
 
+	ctx, endTaskWait := task.client.getInstrumentation().StartTaskWait(ctx, TaskWait{Task: task.Task})
+	startTime := time.Now()
+	err := task.waitInspectTaskCompletion(ctx, inspectionFunc, delay)
+	endTaskWait(TaskWaitResult{
+		Task:      task.Task,
+		Operation: task.Task.OperationName,
+		Status:    task.Task.Status,
+		Duration:  time.Since(startTime),
+		Err:       err,
+	})
+	return err
+}
+
+// waitInspectTaskCompletion performs the wait of WaitInspectTaskCompletionCtx
+func (task *Task) waitInspectTaskCompletion(ctx context.Context, inspectionFunc InspectionFunc, delay time.Duration) error {
 	taskMonitor := os.Getenv("GOVCD_TASK_MONITOR")
 	howManyTimesRefreshed := 0
 	startTime := time.Now()
 	for {
 		howManyTimesRefreshed++
 		elapsed := time.Since(startTime)
-		err := task.Refresh()
+		err := task.RefreshCtx(ctx)
 		if err != nil {
-			return fmt.Errorf("%s : %s", errorRetrievingTask, err)
+			return fmt.Errorf("%s : %w", errorRetrievingTask, err)
 		}
 
 		// If an inspection function is provided, we pass information about the task processing:
@@ -131,7 +160,7 @@ func (task *Task) WaitInspectTaskCompletion(inspectionFunc InspectionFunc, delay
 				)
 			}
 			if task.Task.Status == "error" {
-				return fmt.Errorf("task did not complete successfully: %s", task.getErrorMessage(err))
+				return newTaskError(task.Task, "task did not complete successfully: "+task.getErrorMessage(err))
 			}
 			return nil
 		}
@@ -163,15 +192,25 @@ func (task *Task) WaitInspectTaskCompletion(inspectionFunc InspectionFunc, delay
 			)
 		}
 
-		// Sleep for a given period and try again.
-		time.Sleep(delay)
+		// Sleep for a given period and try again unless the context is done.
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for task %s: %w", task.Task.HREF, ctx.Err())
+		case <-time.After(delay):
+		}
 	}
 }
 
 // WaitTaskCompletion checks the status of the task every 3 seconds and returns when the
 // task is either completed or failed
 func (task *Task) WaitTaskCompletion() error {
-	return task.WaitInspectTaskCompletion(nil, 3*time.Second)
+	return task.WaitTaskCompletionCtx(context.Background())
+}
+
+// WaitTaskCompletionCtx checks the status of the task every 3 seconds and returns when the task is
+// either completed or failed, or when the given context is done
+func (task *Task) WaitTaskCompletionCtx(ctx context.Context) error {
+	return task.WaitInspectTaskCompletionCtx(ctx, nil, 3*time.Second)
 }
 
 // GetTaskProgress retrieves the task progress as a string
@@ -182,11 +221,11 @@ func (task *Task) GetTaskProgress() (string, error) {
 
 	err := task.Refresh()
 	if err != nil {
-		return "", fmt.Errorf("error retrieving task: %s", err)
+		return "", fmt.Errorf("error retrieving task: %w", err)
 	}
 
 	if task.Task.Status == "error" {
-		return "", fmt.Errorf("task did not complete successfully: %s", task.getErrorMessage(err))
+		return "", newTaskError(task.Task, "task did not complete successfully: "+task.getErrorMessage(err))
 	}
 
 	return strconv.Itoa(task.Task.Progress), nil
@@ -352,7 +391,7 @@ func (client *Client) GetTaskByHREF(taskHref string) (*Task, error) {
 	_, err := client.ExecuteRequest(taskHref, http.MethodGet,
 		"", "error retrieving task: %s", nil, task.Task)
 	if err != nil {
-		return nil, fmt.Errorf("%s : %s", ErrorEntityNotFound, err)
+		return nil, fmt.Errorf("%w : %w", ErrorEntityNotFound, err)
 	}
 
 	return task, nil
@@ -436,7 +475,7 @@ func (client *Client) QueryTaskList(filter map[string]string) ([]*types.QueryRes
 	}
 	results, err := client.cumulativeQuery(taskType, nil, notEncodedParams)
 	if err != nil {
-		return nil, fmt.Errorf("error querying task %s", err)
+		return nil, fmt.Errorf("error querying task %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -487,7 +526,7 @@ func (client *Client) WaitForRouteAdvertisementTasks() error {
 		"name":   name,
 	})
 	if err != nil {
-		return fmt.Errorf("error retrieving all running '%s' tasks: %s", name, err)
+		return fmt.Errorf("error retrieving all running '%s' tasks: %w", name, err)
 	}
 
 	util.Logger.Printf("[TRACE] WaitForRouteAdvertisementTasks got %d unifinished tasks with name='%s'", len(allTasks), name)
@@ -497,7 +536,7 @@ func (client *Client) WaitForRouteAdvertisementTasks() error {
 
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return fmt.Errorf("error waiting for task '%s' of type '%s' to finish: %s", singleQueryTask.HREF, name, err)
+			return fmt.Errorf("error waiting for task '%s' of type '%s' to finish: %w", singleQueryTask.HREF, name, err)
 		}
 	}
 
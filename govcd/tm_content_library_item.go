@@ -63,7 +63,7 @@ func (cl *ContentLibrary) CreateContentLibraryItem(config *types.ContentLibraryI
 	if filepath.Ext(cleanFilePath) == ".ova" {
 		ovaInnerFilesPaths, tmpDir, err := util.Unpack(args.FilePath)
 		if err != nil {
-			return nil, fmt.Errorf("%s. Unpacked files for checking are accessible in: %s", err, tmpDir)
+			return nil, fmt.Errorf("%w. Unpacked files for checking are accessible in: %s", err, tmpDir)
 		}
 		defer func() {
 			err = os.RemoveAll(tmpDir)
@@ -274,7 +274,7 @@ func cleanupContentLibraryItemOnUploadError(cl *ContentLibrary, identifier strin
 		return originalError
 	}
 	if err != nil {
-		return fmt.Errorf("the Content Library Item creation failed with error: %s\nCleanup of stranded Content Library Item also failed: %s", originalError, err)
+		return fmt.Errorf("the Content Library Item creation failed with error: %w\nCleanup of stranded Content Library Item also failed: %w", originalError, err)
 	}
 	err = getContentLibraryItemUploadTask(cli, func(task *Task) error {
 		var innerErr error
@@ -294,7 +294,7 @@ func cleanupContentLibraryItemOnUploadError(cl *ContentLibrary, identifier strin
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("the Content Library Item creation failed with error: %s\nCleanup of stranded Content Library Item also failed: %s", originalError, err)
+		return fmt.Errorf("the Content Library Item creation failed with error: %w\nCleanup of stranded Content Library Item also failed: %w", originalError, err)
 	}
 	return originalError
 }
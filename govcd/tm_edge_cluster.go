@@ -118,12 +118,12 @@ func (vcdClient *VCDClient) TmSyncEdgeClusters() error {
 
 	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error performing %s: %s", labelTmEdgeClusterSync, err)
+		return fmt.Errorf("error performing %s: %w", labelTmEdgeClusterSync, err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error performing %s: %s", labelTmEdgeClusterSync, err)
+		return fmt.Errorf("error performing %s: %w", labelTmEdgeClusterSync, err)
 	}
 
 	return nil
@@ -161,7 +161,7 @@ func (e *TmEdgeCluster) Delete() error {
 
 	_, err := e.Update(e.TmEdgeCluster)
 	if err != nil {
-		return fmt.Errorf("error removing QoS configuration for  %s: %s", labelTmEdgeCluster, err)
+		return fmt.Errorf("error removing QoS configuration for  %s: %w", labelTmEdgeCluster, err)
 	}
 
 	return nil
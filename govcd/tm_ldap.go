@@ -43,7 +43,7 @@ func (vcdClient *VCDClient) TmLdapConfigure(settings *types.TmLdapSettings, trus
 		// an existing one
 		existing, err := vcdClient.TmGetLdapConfiguration()
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving existing LDAP configuration: %s", err)
+			return nil, fmt.Errorf("error retrieving existing LDAP configuration: %w", err)
 		}
 
 		// If SSL is configured and retrieved settings are empty, or the hostname changed, we need to trust the certificate
@@ -51,7 +51,7 @@ func (vcdClient *VCDClient) TmLdapConfigure(settings *types.TmLdapSettings, trus
 		if needsCheckCert {
 			trustedCert, err = trustCertificate(vcdClient, nil, settings.HostName, connResult.TargetProbe.CertificateChain)
 			if err != nil {
-				return nil, fmt.Errorf("could not trust certificate for %s, Connection result: '%s', SSL result: '%s': %s",
+				return nil, fmt.Errorf("could not trust certificate for %s, Connection result: '%s', SSL result: '%s': %w",
 					settings.HostName, connResult.TargetProbe.ConnectionResult, connResult.TargetProbe.SSLResult, err)
 			}
 		}
@@ -63,7 +63,7 @@ func (vcdClient *VCDClient) TmLdapConfigure(settings *types.TmLdapSettings, trus
 		if trustedCert != nil {
 			innerErr := trustedCert.Delete()
 			if innerErr != nil {
-				return nil, fmt.Errorf("%s - Also, cleanup of SSL certificate '%s' failed: %s", err, trustedCert.TrustedCertificate.ID, innerErr)
+				return nil, fmt.Errorf("%w - Also, cleanup of SSL certificate '%s' failed: %w", err, trustedCert.TrustedCertificate.ID, innerErr)
 			}
 		}
 		return nil, err
@@ -97,7 +97,7 @@ func (org *TmOrg) LdapConfigure(settings *types.OrgLdapSettingsType, trustSslCer
 			// an existing one
 			existing, err := org.GetLdapConfiguration()
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving existing LDAP configuration: %s", err)
+				return nil, fmt.Errorf("error retrieving existing LDAP configuration: %w", err)
 			}
 
 			// Pre-condition here: Settings are always LDAP=CUSTOM and CustomOrgLdapSettings is not nil.
@@ -111,7 +111,7 @@ func (org *TmOrg) LdapConfigure(settings *types.OrgLdapSettingsType, trustSslCer
 					OrgName: org.TmOrg.Name,
 				}, settings.CustomOrgLdapSettings.HostName, connResult.TargetProbe.CertificateChain)
 				if err != nil {
-					return nil, fmt.Errorf("could not trust certificate for %s, Connection result: '%s', SSL result: '%s': %s",
+					return nil, fmt.Errorf("could not trust certificate for %s, Connection result: '%s', SSL result: '%s': %w",
 						settings.CustomOrgLdapSettings.HostName, connResult.TargetProbe.ConnectionResult, connResult.TargetProbe.SSLResult, err)
 				}
 			}
@@ -127,7 +127,7 @@ func (org *TmOrg) LdapConfigure(settings *types.OrgLdapSettingsType, trustSslCer
 	if trustedCert != nil {
 		innerErr := trustedCert.Delete()
 		if innerErr != nil {
-			return nil, fmt.Errorf("%s - Also, cleanup of SSL certificate '%s' failed: %s", err, trustedCert.TrustedCertificate.ID, innerErr)
+			return nil, fmt.Errorf("%w - Also, cleanup of SSL certificate '%s' failed: %w", err, trustedCert.TrustedCertificate.ID, innerErr)
 		}
 	}
 	return nil, err
@@ -238,7 +238,7 @@ func ldapExecuteRequest(vcdClient *VCDClient, orgId, method string, payload inte
 	req := vcdClient.Client.newRequest(nil, nil, method, *endpoint, body, "", headers)
 	resp, err := checkJsonResp(vcdClient.Client.Http.Do(req))
 	if err != nil {
-		return nil, fmt.Errorf("error performing %s call to LDAP settings: %s", method, err)
+		return nil, fmt.Errorf("error performing %s call to LDAP settings: %w", method, err)
 	}
 
 	// Other than DELETE with get a body response
@@ -248,14 +248,14 @@ func ldapExecuteRequest(vcdClient *VCDClient, orgId, method string, payload inte
 			var result types.OrgLdapSettingsType
 			err = decodeBody(types.BodyTypeJSON, resp, &result)
 			if err != nil {
-				return nil, fmt.Errorf("error decoding Organization LDAP settings: %s", err)
+				return nil, fmt.Errorf("error decoding Organization LDAP settings: %w", err)
 			}
 			return &result, nil
 		} else {
 			var result types.TmLdapSettings
 			err = decodeBody(types.BodyTypeJSON, resp, &result)
 			if err != nil {
-				return nil, fmt.Errorf("error decoding LDAP settings: %s", err)
+				return nil, fmt.Errorf("error decoding LDAP settings: %w", err)
 			}
 			return &result, nil
 		}
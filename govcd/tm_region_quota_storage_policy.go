@@ -49,7 +49,7 @@ func (vcdClient *VCDClient) CreateRegionQuotaStoragePolicies(regionQuotaId strin
 	// Get all the storage policies from the Region Quota and return them
 	allPolicies, err := vcdClient.GetAllRegionQuotaStoragePolicies(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve all the %s after creation: %s", labelRegionQuotaStoragePolicies, err)
+		return nil, fmt.Errorf("could not retrieve all the %s after creation: %w", labelRegionQuotaStoragePolicies, err)
 	}
 	return allPolicies, nil
 }
@@ -96,7 +96,7 @@ func (regionQuota *RegionQuota) GetStoragePolicyByName(name string) (*RegionQuot
 			return sp, nil
 		}
 	}
-	return nil, fmt.Errorf("unable to find storage policy with name '%s' after lookup: %s", name, ErrorEntityNotFound)
+	return nil, fmt.Errorf("unable to find storage policy with name '%s' after lookup: %w", name, ErrorEntityNotFound)
 }
 
 // GetRegionQuotaStoragePolicyById retrieves a Region Quota Storage Policy by a given ID
@@ -120,7 +120,7 @@ func (rq *RegionQuota) GetStoragePolicyById(id string) (*RegionQuotaStoragePolic
 		return nil, err
 	}
 	if policy.VirtualDatacenterStoragePolicy.VirtualDatacenter.ID != rq.TmVdc.ID {
-		return nil, fmt.Errorf("no %s with ID '%s' found in %s '%s': %s", labelRegionQuotaStoragePolicies, id, labelRegionQuota, rq.TmVdc.ID, ErrorEntityNotFound)
+		return nil, fmt.Errorf("no %s with ID '%s' found in %s '%s': %w", labelRegionQuotaStoragePolicies, id, labelRegionQuota, rq.TmVdc.ID, ErrorEntityNotFound)
 	}
 	return policy, err
 }
@@ -32,7 +32,7 @@ func (g TrustedCertificate) wrap(inner *types.TrustedCertificate) *TrustedCertif
 func (vcdClient *VCDClient) AutoTrustHttpsCertificate(endpoint *url.URL, ctx *TenantContext) (*TrustedCertificate, error) {
 	port, err := getEndpointPort(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error getting port number for host '%s': %s", endpoint.Hostname(), err)
+		return nil, fmt.Errorf("error getting port number for host '%s': %w", endpoint.Hostname(), err)
 	}
 
 	con := types.TestConnection{
@@ -45,7 +45,7 @@ func (vcdClient *VCDClient) AutoTrustHttpsCertificate(endpoint *url.URL, ctx *Te
 
 	res, err := vcdClient.Client.TestConnection(con)
 	if err != nil {
-		return nil, fmt.Errorf("error testing connection for %s: %s", endpoint.Hostname(), err)
+		return nil, fmt.Errorf("error testing connection for %s: %w", endpoint.Hostname(), err)
 	}
 
 	var trustedCert *TrustedCertificate
@@ -53,7 +53,7 @@ func (vcdClient *VCDClient) AutoTrustHttpsCertificate(endpoint *url.URL, ctx *Te
 		if res.TargetProbe.SSLResult == types.UntrustedCertificate {
 			trustedCert, err = trustCertificate(vcdClient, ctx, endpoint.Hostname(), res.TargetProbe.CertificateChain)
 			if err != nil {
-				return nil, fmt.Errorf("could not trust certificate for %s, Connection result: '%s', SSL result: '%s': %s",
+				return nil, fmt.Errorf("could not trust certificate for %s, Connection result: '%s', SSL result: '%s': %w",
 					endpoint.Hostname(), res.TargetProbe.ConnectionResult, res.TargetProbe.SSLResult, err)
 			}
 		} else {
@@ -91,7 +91,7 @@ func trustCertificate(vcdClient *VCDClient, ctx *TenantContext, hostname string,
 
 	trustedCert, err := vcdClient.CreateTrustedCertificate(trust, ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error trusting Certificate %s: %s", trust.Alias, err)
+		return nil, fmt.Errorf("error trusting Certificate %s: %w", trust.Alias, err)
 	}
 
 	util.Logger.Printf("[DEBUG] Certificate trust established ID - %s, Alias - %s",
@@ -112,7 +112,7 @@ func getEndpointPort(u *url.URL) (int, error) {
 
 	port, err := strconv.Atoi(portStr)
 	if err != nil {
-		return 0, fmt.Errorf("error converting port '%s' to int: %s", u.Port(), err)
+		return 0, fmt.Errorf("error converting port '%s' to int: %w", u.Port(), err)
 	}
 
 	return port, nil
@@ -28,16 +28,16 @@ func (vcdClient *VCDClient) AddUIPlugin(pluginPath string, enabled bool) (*UIPlu
 	}
 	uiPluginMetadataPayload, err := getPluginMetadata(pluginPath)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving the metadata for the given plugin %s: %s", pluginPath, err)
+		return nil, fmt.Errorf("error retrieving the metadata for the given plugin %s: %w", pluginPath, err)
 	}
 	uiPluginMetadataPayload.Enabled = enabled
 	uiPluginMetadata, err := createUIPlugin(&vcdClient.Client, uiPluginMetadataPayload)
 	if err != nil {
-		return nil, fmt.Errorf("error creating the UI plugin: %s", err)
+		return nil, fmt.Errorf("error creating the UI plugin: %w", err)
 	}
 	err = uiPluginMetadata.upload(pluginPath)
 	if err != nil {
-		return nil, fmt.Errorf("error uploading the UI plugin: %s", err)
+		return nil, fmt.Errorf("error uploading the UI plugin: %w", err)
 	}
 
 	return uiPluginMetadata, nil
@@ -102,7 +102,7 @@ func (vcdClient *VCDClient) GetUIPluginById(id string) (*UIPlugin, error) {
 // when the UI Plugin with given ID is not found
 func amendUIPluginGetByIdError(id string, err error) error {
 	if err != nil && strings.Contains(err.Error(), "NullPointerException") {
-		return fmt.Errorf("could not find any UI plugin with ID '%s': %s", id, ErrorEntityNotFound)
+		return fmt.Errorf("could not find any UI plugin with ID '%s': %w", id, ErrorEntityNotFound)
 	}
 	return err
 }
@@ -123,7 +123,7 @@ func (vcdClient *VCDClient) GetUIPlugin(vendor, pluginName, version string) (*UI
 		}
 	}
 
-	return nil, fmt.Errorf("could not find any UI plugin with vendor '%s', pluginName '%s' and version '%s': %s", vendor, pluginName, version, ErrorEntityNotFound)
+	return nil, fmt.Errorf("could not find any UI plugin with vendor '%s', pluginName '%s' and version '%s': %w", vendor, pluginName, version, ErrorEntityNotFound)
 }
 
 // GetPublishedTenants gets all the Organization references where the receiver UIPlugin is published.
@@ -148,7 +148,7 @@ func newFileUploadRequest(client *Client, requestUrl string, filePart []byte, of
 
 	parsedRequestURL, err := url.ParseRequestURI(requestUrl)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding vdc response: %s", err)
+		return nil, fmt.Errorf("error decoding vdc response: %w", err)
 	}
 
 	uploadReq := client.NewRequestWitNotEncodedParams(nil, nil, http.MethodPut, *parsedRequestURL, bytes.NewReader(filePart))
@@ -183,11 +183,11 @@ func uploadPartFile(client *Client, part []byte, partDataSize int64, uDetails up
 
 	response, err := checkResp(client.Http.Do(request))
 	if err != nil {
-		return fmt.Errorf("file upload failed. Err: %s", err)
+		return fmt.Errorf("file upload failed. Err: %w", err)
 	}
 	err = response.Body.Close()
 	if err != nil {
-		return fmt.Errorf("file closing failed. Err: %s", err)
+		return fmt.Errorf("file closing failed. Err: %w", err)
 	}
 
 	uDetails.callBack(uDetails.uploadedBytesForCallback+partDataSize, uDetails.allFilesSize)
@@ -240,7 +240,7 @@ func createTaskForVcdImport(client *Client, taskHREF string) (Task, error) {
 	task := NewTask(client)
 
 	if err = decodeBody(types.BodyTypeXML, response, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
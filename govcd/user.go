@@ -227,7 +227,7 @@ func retrieveUserWithTimeout(adminOrg *AdminOrg, userName string) (*OrgUser, err
 	// If the user was not retrieved within the allocated time, we inform the user about the failure
 	// and the time it occurred to get to this point, so that they may try with a longer time
 	if err != nil {
-		return nil, fmt.Errorf("failure to retrieve a new user after %s : %s", elapsed, err)
+		return nil, fmt.Errorf("failure to retrieve a new user after %s : %w", elapsed, err)
 	}
 
 	return newUser, nil
@@ -248,7 +248,7 @@ func (adminOrg *AdminOrg) CreateUser(userConfiguration *types.User) (*OrgUser, e
 
 	userCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing admin org url: %s", err)
+		return nil, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	userCreateHREF.Path += "/users"
 
@@ -352,7 +352,7 @@ func (user *OrgUser) Delete(takeOwnership bool) error {
 
 	userHREF, err := url.ParseRequestURI(user.User.Href)
 	if err != nil {
-		return fmt.Errorf("error getting HREF for user %s : %s", user.User.Name, err)
+		return fmt.Errorf("error getting HREF for user %s : %w", user.User.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for deleting user : %#v and name: %s", userHREF, user.User.Name)
 
@@ -420,7 +420,7 @@ func (user *OrgUser) Update() error {
 
 	userHREF, err := url.ParseRequestURI(user.User.Href)
 	if err != nil {
-		return fmt.Errorf("error getting HREF for user %s : %s", user.User.Name, err)
+		return fmt.Errorf("error getting HREF for user %s : %w", user.User.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for updating user : %#v and name: %s", userHREF, user.User.Name)
 
@@ -510,7 +510,7 @@ func (user *OrgUser) TakeOwnership() error {
 
 	userHREF, err := url.ParseRequestURI(user.User.Href + "/action/takeOwnership")
 	if err != nil {
-		return fmt.Errorf("error getting HREF for user %s : %s", user.User.Name, err)
+		return fmt.Errorf("error getting HREF for user %s : %w", user.User.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for taking ownership from user : %#v and name: %s", userHREF, user.User.Name)
 
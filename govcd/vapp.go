@@ -168,7 +168,7 @@ func (vapp *VApp) AddRawVM(vAppComposition *types.ReComposeVAppParams) (*VM, err
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("VM creation task failed: %s", err)
+		return nil, fmt.Errorf("VM creation task failed: %w", err)
 	}
 
 	// VM task does not return any reference to VM therefore it must be looked up by name after
@@ -181,7 +181,7 @@ func (vapp *VApp) AddRawVM(vAppComposition *types.ReComposeVAppParams) (*VM, err
 
 	vm, err := vapp.GetVMByName(vmName, true)
 	if err != nil {
-		return nil, fmt.Errorf("error finding VM %s in vApp %s after creation: %s", vAppComposition.Name, vapp.VApp.Name, err)
+		return nil, fmt.Errorf("error finding VM %s in vApp %s after creation: %w", vAppComposition.Name, vapp.VApp.Name, err)
 	}
 
 	return vm, nil
@@ -292,7 +292,7 @@ func addNewVMW(vapp *VApp, name string, vappTemplate VAppTemplate,
 func (vapp *VApp) RemoveVM(vm VM) error {
 	err := vapp.Refresh()
 	if err != nil {
-		return fmt.Errorf("error refreshing vApp before removing VM: %s", err)
+		return fmt.Errorf("error refreshing vApp before removing VM: %w", err)
 	}
 	task := NewTask(vapp.client)
 	if vapp.VApp.Tasks != nil {
@@ -303,7 +303,7 @@ func (vapp *VApp) RemoveVM(vm VM) error {
 			if task.Task.Status != "error" && task.Task.Status != "success" {
 				err := task.WaitTaskCompletion()
 				if err != nil {
-					return fmt.Errorf("error performing task: %s", err)
+					return fmt.Errorf("error performing task: %w", err)
 				}
 			}
 		}
@@ -329,7 +329,7 @@ func (vapp *VApp) RemoveVM(vm VM) error {
 
 	err = deleteTask.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error performing removing VM task: %s", err)
+		return fmt.Errorf("error performing removing VM task: %w", err)
 	}
 
 	return nil
@@ -339,7 +339,7 @@ func (vapp *VApp) PowerOn() (Task, error) {
 
 	err := vapp.BlockWhileStatus("UNRESOLVED", vapp.client.MaxRetryTimeout)
 	if err != nil {
-		return Task{}, fmt.Errorf("error powering on vApp: %s", err)
+		return Task{}, fmt.Errorf("error powering on vApp: %w", err)
 	}
 
 	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
@@ -467,7 +467,7 @@ func (vapp *VApp) RunCustomizationScript(computername, script string) (Task, err
 func (vapp *VApp) Customize(computername, script string, changeSid bool) (Task, error) {
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	// Check if VApp Children is populated
@@ -500,7 +500,7 @@ func (vapp *VApp) Customize(computername, script string, changeSid bool) (Task,
 func (vapp *VApp) GetStatus() (string, error) {
 	err := vapp.Refresh()
 	if err != nil {
-		return "", fmt.Errorf("error refreshing vApp: %s", err)
+		return "", fmt.Errorf("error refreshing vApp: %w", err)
 	}
 	// Trying to make this function future-proof:
 	// If a new status is added to a future vCD API and the status map in types.go
@@ -530,7 +530,7 @@ func (vapp *VApp) BlockWhileStatus(unwantedStatus string, timeOutAfterSeconds in
 			currentStatus, err := vapp.GetStatus()
 
 			if err != nil {
-				return fmt.Errorf("could not get vApp status %s", err)
+				return fmt.Errorf("could not get vApp status %w", err)
 			}
 			if currentStatus != unwantedStatus {
 				return nil
@@ -571,7 +571,7 @@ func (vapp *VApp) ChangeCPUCountWithCore(virtualCpuCount int, coresPerSocket *in
 
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	// Check if VApp Children is populated
@@ -613,7 +613,7 @@ func (vapp *VApp) ChangeCPUCountWithCore(virtualCpuCount int, coresPerSocket *in
 func (vapp *VApp) ChangeStorageProfile(name string) (Task, error) {
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) == 0 {
@@ -644,7 +644,7 @@ func (vapp *VApp) ChangeStorageProfile(name string) (Task, error) {
 func (vapp *VApp) ChangeVMName(name string) (Task, error) {
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil {
@@ -667,7 +667,7 @@ func (vapp *VApp) ChangeVMName(name string) (Task, error) {
 func (vapp *VApp) SetOvf(parameters map[string]string) (Task, error) {
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil {
@@ -704,7 +704,7 @@ func (vapp *VApp) SetOvf(parameters map[string]string) (Task, error) {
 func (vapp *VApp) ChangeNetworkConfig(networks []map[string]interface{}, ip string) (Task, error) {
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil {
@@ -764,7 +764,7 @@ func (vapp *VApp) ChangeMemorySize(size int) (Task, error) {
 
 	err := vapp.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	// Check if VApp Children is populated
@@ -822,7 +822,7 @@ func (vapp *VApp) AddRAWNetworkConfig(orgvdcnetworks []*types.OrgVDCNetwork) (Ta
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
 	if err != nil {
-		return Task{}, fmt.Errorf("error getting vApp networks: %s", err)
+		return Task{}, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 	networkConfigurations := vAppNetworkConfig.NetworkConfig
 
@@ -898,7 +898,7 @@ func (vapp *VApp) CreateVappNetwork(newNetworkSettings *VappNetworkSettings, org
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -980,7 +980,7 @@ func (vapp *VApp) AddOrgNetwork(newNetworkSettings *VappNetworkSettings, orgNetw
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -1031,7 +1031,7 @@ func (vapp *VApp) UpdateNetwork(newNetworkSettings *VappNetworkSettings, orgNetw
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -1142,7 +1142,7 @@ func (vapp *VApp) UpdateOrgNetwork(newNetworkSettings *VappNetworkSettings, isFe
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -1236,7 +1236,7 @@ func (vapp *VApp) RemoveNetwork(identifier string) (*types.NetworkConfigSection,
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -1259,7 +1259,7 @@ func (vapp *VApp) RemoveNetworkAsync(identifier string) (Task, error) {
 	for _, networkConfig := range networkConfigurations {
 		networkId, err := GetUuidFromHref(networkConfig.Link.HREF, false)
 		if err != nil {
-			return Task{}, fmt.Errorf("unable to get network ID from HREF: %s", err)
+			return Task{}, fmt.Errorf("unable to get network ID from HREF: %w", err)
 		}
 		if networkId == extractUuid(identifier) || networkConfig.NetworkName == identifier {
 			deleteUrl := vapp.client.VCDHREF.String() + "/network/" + networkId
@@ -1334,7 +1334,7 @@ func (vapp *VApp) RemoveAllNetworks() (Task, error) {
 func (vapp *VApp) SetProductSectionList(productSection *types.ProductSectionList) (*types.ProductSectionList, error) {
 	err := setProductSectionList(vapp.client, vapp.VApp.HREF, productSection)
 	if err != nil {
-		return nil, fmt.Errorf("unable to set vApp product section: %s", err)
+		return nil, fmt.Errorf("unable to set vApp product section: %w", err)
 	}
 
 	return vapp.GetProductSectionList()
@@ -1354,7 +1354,7 @@ func (vapp *VApp) GetVMByName(vmName string, refresh bool) (*VM, error) {
 	if refresh {
 		err := vapp.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -1382,7 +1382,7 @@ func (vapp *VApp) GetVMById(id string, refresh bool) (*VM, error) {
 	if refresh {
 		err := vapp.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -1425,7 +1425,7 @@ func (client *Client) QueryVappList() ([]*types.QueryResultVAppRecordType, error
 	}
 	vappResult, err := client.cumulativeQuery(queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp list : %s", err)
+		return nil, fmt.Errorf("error getting vApp list : %w", err)
 	}
 	vappList = vappResult.Results.VAppRecord
 	if client.IsSysAdmin {
@@ -1492,12 +1492,12 @@ func (vapp *VApp) UpdateNameDescription(newName, newDescription string) error {
 		opType, "error updating vapp: %s", recomposeParams)
 
 	if err != nil {
-		return fmt.Errorf("unable to update vApp: %s", err)
+		return fmt.Errorf("unable to update vApp: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("task for updating vApp failed: %s", err)
+		return fmt.Errorf("task for updating vApp failed: %w", err)
 	}
 	return vapp.Refresh()
 }
@@ -1558,12 +1558,12 @@ func (vapp *VApp) RenewLease(deploymentLeaseInSeconds, storageLeaseInSeconds int
 		types.MimeLeaseSettingSection, "error updating vapp lease : %s", &leaseSettings)
 
 	if err != nil {
-		return fmt.Errorf("unable to update vApp lease: %s", err)
+		return fmt.Errorf("unable to update vApp lease: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("task for updating vApp lease failed: %s", err)
+		return fmt.Errorf("task for updating vApp lease failed: %w", err)
 	}
 	return vapp.Refresh()
 }
@@ -1595,3 +1595,59 @@ func (vapp *VApp) GetLease() (*types.LeaseSettingsSection, error) {
 	}
 	return &leaseSettings, nil
 }
+
+// CreateSnapshotAsync triggers creation of a snapshot for all VMs in the vApp and returns the task.
+// * name - name of the snapshot
+// * memory - whether the snapshot should include the memory of powered on VMs
+// * quiesce - whether the file systems of powered on VMs should be quiesced (requires VMware Tools)
+//
+// Note. Each VM can only have a single snapshot. Creating a new one replaces the existing snapshots.
+func (vapp *VApp) CreateSnapshotAsync(name string, memory, quiesce bool) (Task, error) {
+	return createSnapshotAsync(vapp.client, vapp.VApp.HREF, name, memory, quiesce)
+}
+
+// CreateSnapshot creates a snapshot for all VMs in the vApp and waits until the task is completed
+func (vapp *VApp) CreateSnapshot(name string, memory, quiesce bool) error {
+	task, err := vapp.CreateSnapshotAsync(name, memory, quiesce)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// RevertToCurrentSnapshotAsync triggers reverting all VMs in the vApp to their current snapshot and
+// returns the task
+func (vapp *VApp) RevertToCurrentSnapshotAsync() (Task, error) {
+	return revertToCurrentSnapshotAsync(vapp.client, vapp.VApp.HREF)
+}
+
+// RevertToCurrentSnapshot reverts all VMs in the vApp to their current snapshot and waits until
+// the task is completed
+func (vapp *VApp) RevertToCurrentSnapshot() error {
+	task, err := vapp.RevertToCurrentSnapshotAsync()
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// RemoveAllSnapshotsAsync triggers removal of all snapshots of all VMs in the vApp and returns the
+// task
+func (vapp *VApp) RemoveAllSnapshotsAsync() (Task, error) {
+	return removeAllSnapshotsAsync(vapp.client, vapp.VApp.HREF)
+}
+
+// RemoveAllSnapshots removes all snapshots of all VMs in the vApp and waits until the task is
+// completed
+func (vapp *VApp) RemoveAllSnapshots() error {
+	task, err := vapp.RemoveAllSnapshotsAsync()
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// GetSnapshotSection retrieves the snapshot section of a vApp
+func (vapp *VApp) GetSnapshotSection() (*types.SnapshotSection, error) {
+	return getSnapshotSection(vapp.client, vapp.VApp.HREF)
+}
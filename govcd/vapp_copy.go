@@ -0,0 +1,278 @@
+package govcd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// VAppCopyParams defines how a vApp is cloned or moved to a VDC
+type VAppCopyParams struct {
+	// Description of the new vApp. When empty, the description of the source vApp is used
+	Description string
+	// PowerOn powers on the new vApp once it is created
+	PowerOn bool
+	// StorageProfiles maps the names of the VMs of the source vApp to the storage profiles that
+	// their copies use. The storage profiles must exist in the target VDC. VMs that are not in the
+	// map use the default storage profile of the target VDC
+	StorageProfiles map[string]*types.Reference
+	// Networks maps the names of the vApp networks of the source vApp to the Org VDC networks of
+	// the target VDC they are connected to. The NICs of the VMs connected to a remapped direct
+	// network are reconnected to the new network
+	Networks map[string]*types.Reference
+}
+
+// CloneVApp makes a copy of sourceVApp named name in this VDC, which can belong to another Org,
+// and waits for the task to complete. params is optional and allows to change the storage profile
+// of each VM and the networks the vApp is connected to
+func (vdc *Vdc) CloneVApp(sourceVApp *VApp, name string, params *VAppCopyParams) (*VApp, error) {
+	task, renamedNetworks, err := vdc.copyVAppAsync(sourceVApp, name, params, false)
+	if err != nil {
+		return nil, err
+	}
+	return vdc.waitForVAppCopy(task, renamedNetworks)
+}
+
+// CloneVAppAsync starts the copy of sourceVApp named name in this VDC and returns the task. See
+// CloneVApp for details.
+// Note. The NICs of the VMs connected to a remapped direct network keep the name of the source
+// network until they are reconnected with VApp.RemapVmNetworks
+func (vdc *Vdc) CloneVAppAsync(sourceVApp *VApp, name string, params *VAppCopyParams) (Task, error) {
+	task, _, err := vdc.copyVAppAsync(sourceVApp, name, params, false)
+	return task, err
+}
+
+// MoveVApp moves sourceVApp to this VDC, which can belong to another Org, and waits for the task to
+// complete. The vApp keeps its name and the source vApp is deleted once the copy completes. The
+// source vApp must be powered off. params is optional and allows to change the storage profile of
+// each VM and the networks the vApp is connected to
+func (vdc *Vdc) MoveVApp(sourceVApp *VApp, params *VAppCopyParams) (*VApp, error) {
+	if sourceVApp == nil || sourceVApp.VApp == nil {
+		return nil, fmt.Errorf("source vApp cannot be empty")
+	}
+	task, renamedNetworks, err := vdc.copyVAppAsync(sourceVApp, sourceVApp.VApp.Name, params, true)
+	if err != nil {
+		return nil, err
+	}
+	return vdc.waitForVAppCopy(task, renamedNetworks)
+}
+
+// MoveVAppAsync starts moving sourceVApp to this VDC and returns the task. See MoveVApp for details.
+// Note. The NICs of the VMs connected to a remapped direct network keep the name of the source
+// network until they are reconnected with VApp.RemapVmNetworks
+func (vdc *Vdc) MoveVAppAsync(sourceVApp *VApp, params *VAppCopyParams) (Task, error) {
+	if sourceVApp == nil || sourceVApp.VApp == nil {
+		return Task{}, fmt.Errorf("source vApp cannot be empty")
+	}
+	task, _, err := vdc.copyVAppAsync(sourceVApp, sourceVApp.VApp.Name, params, true)
+	return task, err
+}
+
+// copyVAppAsync sends the cloneVApp request. It returns the task and the names of the direct
+// networks that were renamed by the remapping, which the NICs must be reconnected to
+func (vdc *Vdc) copyVAppAsync(sourceVApp *VApp, name string, params *VAppCopyParams, isSourceDelete bool) (Task, map[string]string, error) {
+	if sourceVApp == nil || sourceVApp.VApp == nil || sourceVApp.VApp.HREF == "" {
+		return Task{}, nil, fmt.Errorf("source vApp cannot be empty")
+	}
+	if name == "" {
+		return Task{}, nil, fmt.Errorf("name of the new vApp cannot be empty")
+	}
+	if params == nil {
+		params = &VAppCopyParams{}
+	}
+	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
+	if err != nil {
+		return Task{}, nil, fmt.Errorf("error getting VDC href: %w", err)
+	}
+	vdcHref.Path += "/action/cloneVApp"
+
+	description := params.Description
+	if description == "" {
+		description = sourceVApp.VApp.Description
+	}
+	cloneParams := &types.CloneVAppParams{
+		Xmlns:          types.XMLNamespaceVCloud,
+		Ovf:            types.XMLNamespaceOVF,
+		Name:           name,
+		Deploy:         params.PowerOn,
+		PowerOn:        params.PowerOn,
+		Description:    description,
+		Source:         &types.Reference{HREF: sourceVApp.VApp.HREF},
+		IsSourceDelete: &isSourceDelete,
+	}
+
+	sourcedVms, err := getSourcedVmInstantiationParams(sourceVApp, params.StorageProfiles)
+	if err != nil {
+		return Task{}, nil, err
+	}
+	cloneParams.SourcedVmInstantiationParams = sourcedVms
+
+	var renamedNetworks map[string]string
+	if len(params.Networks) > 0 {
+		networkConfigSection, err := sourceVApp.GetNetworkConfig()
+		if err != nil {
+			return Task{}, nil, fmt.Errorf("error retrieving network configuration of vApp %s: %w", sourceVApp.VApp.Name, err)
+		}
+		var remappedSection *types.NetworkConfigSection
+		remappedSection, renamedNetworks, err = remapVAppNetworkConfig(networkConfigSection, params.Networks)
+		if err != nil {
+			return Task{}, nil, err
+		}
+		cloneParams.InstantiationParams = &types.InstantiationParams{NetworkConfigSection: remappedSection}
+	}
+
+	vapp := NewVApp(vdc.client)
+	_, err = vdc.client.ExecuteRequest(vdcHref.String(), http.MethodPost,
+		types.MimeCloneVapp, "error cloning vApp: %s", cloneParams, vapp.VApp)
+	if err != nil {
+		return Task{}, nil, err
+	}
+	if vapp.VApp.Tasks == nil || len(vapp.VApp.Tasks.Task) == 0 {
+		return Task{}, nil, fmt.Errorf("no task found after cloning vApp %s", sourceVApp.VApp.Name)
+	}
+
+	task := NewTask(vdc.client)
+	task.Task = vapp.VApp.Tasks.Task[0]
+	return *task, renamedNetworks, nil
+}
+
+// getSourcedVmInstantiationParams returns the overrides of the storage profiles of the VMs of the
+// source vApp
+func getSourcedVmInstantiationParams(sourceVApp *VApp, storageProfiles map[string]*types.Reference) ([]*types.SourcedVmInstantiationParams, error) {
+	if len(storageProfiles) == 0 {
+		return nil, nil
+	}
+	var result []*types.SourcedVmInstantiationParams
+	found := make(map[string]bool)
+	if sourceVApp.VApp.Children != nil {
+		for _, vm := range sourceVApp.VApp.Children.VM {
+			storageProfile := storageProfiles[vm.Name]
+			if storageProfile == nil {
+				continue
+			}
+			found[vm.Name] = true
+			result = append(result, &types.SourcedVmInstantiationParams{
+				Source:         &types.Reference{HREF: vm.HREF, Name: vm.Name},
+				StorageProfile: storageProfile,
+			})
+		}
+	}
+	for vmName := range storageProfiles {
+		if !found[vmName] {
+			return nil, fmt.Errorf("VM %s not found in vApp %s: %w", vmName, sourceVApp.VApp.Name, ErrorEntityNotFound)
+		}
+	}
+	return result, nil
+}
+
+// remapVAppNetworkConfig returns a copy of the network configuration of a vApp in which the
+// networks of the mapping are connected to new parent networks. Direct (bridged) networks must have
+// the name of their parent network, therefore they are renamed: the returned map contains the old
+// and new name of each of them
+func remapVAppNetworkConfig(section *types.NetworkConfigSection, networks map[string]*types.Reference) (*types.NetworkConfigSection, map[string]string, error) {
+	result := &types.NetworkConfigSection{
+		Info:  "Configuration parameters for logical networks",
+		Ovf:   types.XMLNamespaceOVF,
+		Xmlns: types.XMLNamespaceVCloud,
+	}
+	renamedNetworks := make(map[string]string)
+	found := make(map[string]bool)
+	for _, networkConfig := range section.NetworkConfig {
+		if strings.EqualFold(networkConfig.NetworkName, types.NoneNetwork) {
+			continue
+		}
+		remapped := types.VAppNetworkConfiguration{
+			NetworkName:   networkConfig.NetworkName,
+			Description:   networkConfig.Description,
+			Configuration: networkConfig.Configuration,
+		}
+		target := networks[networkConfig.NetworkName]
+		if target != nil {
+			found[networkConfig.NetworkName] = true
+			if networkConfig.Configuration == nil || networkConfig.Configuration.FenceMode == types.FenceModeBridged {
+				if target.Name == "" {
+					return nil, nil, fmt.Errorf("the name of the network replacing %s cannot be empty", networkConfig.NetworkName)
+				}
+				remapped.NetworkName = target.Name
+				remapped.Configuration = &types.NetworkConfiguration{FenceMode: types.FenceModeBridged}
+				if remapped.NetworkName != networkConfig.NetworkName {
+					renamedNetworks[networkConfig.NetworkName] = remapped.NetworkName
+				}
+			} else {
+				configuration := *networkConfig.Configuration
+				remapped.Configuration = &configuration
+			}
+			remapped.Configuration.ParentNetwork = &types.Reference{HREF: target.HREF, Name: target.Name}
+		}
+		result.NetworkConfig = append(result.NetworkConfig, remapped)
+	}
+	for networkName := range networks {
+		if !found[networkName] {
+			return nil, nil, fmt.Errorf("network %s not found in vApp: %w", networkName, ErrorEntityNotFound)
+		}
+	}
+	return result, renamedNetworks, nil
+}
+
+// waitForVAppCopy waits for the cloneVApp task, retrieves the new vApp and reconnects its NICs to
+// the renamed networks
+func (vdc *Vdc) waitForVAppCopy(task Task, renamedNetworks map[string]string) (*VApp, error) {
+	err := task.WaitTaskCompletion()
+	if err != nil {
+		return nil, fmt.Errorf("error performing task: %w", err)
+	}
+	if task.Task.Owner == nil || task.Task.Owner.HREF == "" {
+		return nil, fmt.Errorf("task %s has no owner", task.Task.HREF)
+	}
+	vapp, err := vdc.GetVAppByHref(task.Task.Owner.HREF)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving new vApp: %w", err)
+	}
+	if len(renamedNetworks) > 0 {
+		err = vapp.RemapVmNetworks(renamedNetworks)
+		if err != nil {
+			return vapp, err
+		}
+	}
+	return vapp, nil
+}
+
+// RemapVmNetworks reconnects the NICs of the VMs of the vApp. networks maps the name of the
+// network a NIC is connected to, to the name of the network it must be connected to. NICs connected
+// to other networks are left unchanged
+func (vapp *VApp) RemapVmNetworks(networks map[string]string) error {
+	if vapp.VApp.Children == nil {
+		return nil
+	}
+	for _, child := range vapp.VApp.Children.VM {
+		vm, err := vapp.client.GetVMByHref(child.HREF)
+		if err != nil {
+			return fmt.Errorf("error retrieving VM %s: %w", child.Name, err)
+		}
+		networkConnectionSection, err := vm.GetNetworkConnectionSection()
+		if err != nil {
+			return fmt.Errorf("error retrieving network connection section of VM %s: %w", child.Name, err)
+		}
+		changed := false
+		for _, networkConnection := range networkConnectionSection.NetworkConnection {
+			if newName, ok := networks[networkConnection.Network]; ok {
+				util.Logger.Printf("[TRACE] reconnecting NIC %d of VM %s from network %s to %s\n",
+					networkConnection.NetworkConnectionIndex, child.Name, networkConnection.Network, newName)
+				networkConnection.Network = newName
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		err = vm.UpdateNetworkConnectionSection(networkConnectionSection)
+		if err != nil {
+			return fmt.Errorf("error reconnecting NICs of VM %s: %w", child.Name, err)
+		}
+	}
+	return nil
+}
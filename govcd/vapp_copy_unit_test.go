@@ -0,0 +1,219 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// newTestVAppCopySource returns the VDC 'vdc2', which has the Org VDC network 'net-target', and the
+// vApp 'source' of 'vdc1', which has the VMs 'vm1' and 'vm2', a direct network 'net-source', a
+// routed network 'routed', an isolated network 'isolated' and the network 'none' of VCD
+func newTestVAppCopySource(t *testing.T) (*govcdtest.Server, *Vdc, *VApp) {
+	server, vcdClient := testServerClient(t)
+	if _, err := server.AddVdc("org1", "vdc2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddOrgVdcNetwork("org1", "vdc1", "net-source"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddOrgVdcNetwork("org1", "vdc2", "net-target"); err != nil {
+		t.Fatal(err)
+	}
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetVdc, err := org.GetVDCByName("vdc2", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceNetwork, err := vdc.GetOrgVdcNetworkByName("net-source", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceVApp, err := vdc.CreateRawVApp("source", "source description")
+	if err != nil {
+		t.Fatalf("error creating vApp: %s", err)
+	}
+	parent := &types.Reference{HREF: sourceNetwork.OrgVDCNetwork.HREF, Name: "net-source"}
+	task, err := updateNetworkConfigurations(sourceVApp, []types.VAppNetworkConfiguration{
+		{NetworkName: "net-source", Configuration: &types.NetworkConfiguration{FenceMode: types.FenceModeBridged, ParentNetwork: parent}},
+		{NetworkName: "isolated", Configuration: &types.NetworkConfiguration{FenceMode: types.FenceModeIsolated}},
+		{NetworkName: "routed", Configuration: &types.NetworkConfiguration{FenceMode: types.FenceModeNAT, ParentNetwork: parent}},
+		{NetworkName: types.NoneNetwork, Configuration: &types.NetworkConfiguration{FenceMode: types.FenceModeIsolated}},
+	})
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error adding networks: %s", err)
+	}
+	for _, vmName := range []string{"vm1", "vm2"} {
+		_, err = sourceVApp.AddEmptyVm(&types.RecomposeVAppParamsForEmptyVm{
+			CreateItem: &types.CreateItem{
+				Name: vmName,
+				NetworkConnectionSection: &types.NetworkConnectionSection{
+					NetworkConnection: []*types.NetworkConnection{{
+						Network:                 "net-source",
+						IsConnected:             true,
+						IPAddressAllocationMode: types.IPAllocationModeDHCP,
+					}},
+				},
+				VmSpecSection: &types.VmSpecSection{
+					OsType:           "debian10_64Guest",
+					NumCpus:          addrOf(1),
+					MemoryResourceMb: &types.MemoryResourceMb{Configured: 512},
+					HardwareVersion:  &types.HardwareVersion{Value: "vmx-19"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("error adding VM %s: %s", vmName, err)
+		}
+	}
+	return server, targetVdc, sourceVApp
+}
+
+// testVAppCopyNics returns the names of the networks of the NICs of a VM of a vApp
+func testVAppCopyNics(t *testing.T, vapp *VApp, vmName string) string {
+	t.Helper()
+	vm, err := vapp.GetVMByName(vmName, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	section, err := vm.GetNetworkConnectionSection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var networks []string
+	for _, nic := range section.NetworkConnection {
+		networks = append(networks, nic.Network)
+	}
+	return strings.Join(networks, ",")
+}
+
+func TestVdc_CloneVApp(t *testing.T) {
+	server, vdc, sourceVApp := newTestVAppCopySource(t)
+	targetNetwork, err := vdc.GetOrgVdcNetworkByName("net-target", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &types.Reference{HREF: server.URL + "/api/vdcStorageProfile/sp-1", Name: "gold"}
+	target := &types.Reference{HREF: targetNetwork.OrgVDCNetwork.HREF, Name: "net-target"}
+	vapp, err := vdc.CloneVApp(sourceVApp, "copy", &VAppCopyParams{
+		StorageProfiles: map[string]*types.Reference{"vm1": profile},
+		Networks:        map[string]*types.Reference{"net-source": target, "routed": target},
+	})
+	if err != nil {
+		t.Fatalf("error cloning vApp: %s", err)
+	}
+	if vapp.VApp.Name != "copy" || vapp.VApp.Description != "source description" {
+		t.Errorf("unexpected vApp %s with description '%s'", vapp.VApp.Name, vapp.VApp.Description)
+	}
+	if _, err = vdc.GetVAppByName("copy", true); err != nil {
+		t.Errorf("expected the copy in the target VDC: %s", err)
+	}
+	if err = sourceVApp.Refresh(); err != nil {
+		t.Errorf("expected the source vApp to be kept: %s", err)
+	}
+
+	vm1, err := vapp.GetVMByName("vm1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm2, err := vapp.GetVMByName("vm2", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vm1.VM.StorageProfile == nil || vm1.VM.StorageProfile.Name != "gold" || vm2.VM.StorageProfile != nil {
+		t.Errorf("expected the storage profile of vm1 only to be set, got %#v and %#v", vm1.VM.StorageProfile, vm2.VM.StorageProfile)
+	}
+
+	networks := make(map[string]*types.NetworkConfiguration)
+	for _, networkConfig := range vapp.VApp.NetworkConfigSection.NetworkConfig {
+		networks[networkConfig.NetworkName] = networkConfig.Configuration
+	}
+	if len(networks) != 3 || networks["none"] != nil || networks["net-source"] != nil {
+		t.Fatalf("unexpected networks %v", networks)
+	}
+	if bridged := networks["net-target"]; bridged == nil || bridged.FenceMode != types.FenceModeBridged ||
+		bridged.ParentNetwork == nil || bridged.ParentNetwork.HREF != target.HREF {
+		t.Errorf("unexpected configuration of the direct network %#v", bridged)
+	}
+	if routed := networks["routed"]; routed == nil || routed.FenceMode != types.FenceModeNAT ||
+		routed.ParentNetwork == nil || routed.ParentNetwork.HREF != target.HREF {
+		t.Errorf("unexpected configuration of the routed network %#v", routed)
+	}
+	if isolated := networks["isolated"]; isolated == nil || isolated.ParentNetwork != nil {
+		t.Errorf("unexpected configuration of the isolated network %#v", isolated)
+	}
+
+	// The NICs connected to the renamed direct network are reconnected
+	for _, vmName := range []string{"vm1", "vm2"} {
+		if nics := testVAppCopyNics(t, vapp, vmName); nics != "net-target" {
+			t.Errorf("expected the NIC of %s to be reconnected to net-target, got %s", vmName, nics)
+		}
+	}
+}
+
+func TestVdc_MoveVApp(t *testing.T) {
+	_, vdc, sourceVApp := newTestVAppCopySource(t)
+
+	// Unknown VMs and networks are rejected before the request is sent
+	_, err := vdc.MoveVApp(sourceVApp, &VAppCopyParams{StorageProfiles: map[string]*types.Reference{"vm9": {}}})
+	if !errors.Is(err, ErrorEntityNotFound) || !strings.Contains(err.Error(), "vm9") {
+		t.Errorf("expected an error for an unknown VM, got %v", err)
+	}
+	_, err = vdc.MoveVApp(sourceVApp, &VAppCopyParams{Networks: map[string]*types.Reference{"net9": {Name: "x"}}})
+	if !errors.Is(err, ErrorEntityNotFound) || !strings.Contains(err.Error(), "net9") {
+		t.Errorf("expected an error for an unknown network, got %v", err)
+	}
+	if _, err = vdc.GetVAppByName("source", true); !ContainsNotFound(err) {
+		t.Errorf("expected no copy of the vApp, got %v", err)
+	}
+
+	task, err := vdc.MoveVAppAsync(sourceVApp, &VAppCopyParams{Description: "moved"})
+	if err != nil {
+		t.Fatalf("error moving vApp: %s", err)
+	}
+	if err = task.WaitTaskCompletion(); err != nil {
+		t.Fatalf("error waiting for task: %s", err)
+	}
+	vapp, err := vdc.GetVAppByName("source", true)
+	if err != nil {
+		t.Fatalf("error retrieving moved vApp: %s", err)
+	}
+	if vapp.VApp.Description != "moved" {
+		t.Errorf("unexpected description '%s'", vapp.VApp.Description)
+	}
+	if err = sourceVApp.Refresh(); err == nil {
+		t.Errorf("expected the source vApp to be deleted")
+	}
+
+	// Without overrides, the VMs and the networks are copied as they are
+	vm, err := vapp.GetVMByName("vm1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vm.VM.StorageProfile != nil {
+		t.Errorf("expected no storage profile override, got %#v", vm.VM.StorageProfile)
+	}
+	if len(vapp.VApp.NetworkConfigSection.NetworkConfig) != 4 {
+		t.Errorf("expected the 4 networks of the source vApp, got %#v", vapp.VApp.NetworkConfigSection.NetworkConfig)
+	}
+	if nics := testVAppCopyNics(t, vapp, "vm1"); nics != "net-source" {
+		t.Errorf("expected the NIC to be left unchanged, got %s", nics)
+	}
+}
@@ -22,7 +22,7 @@ func (vapp *VApp) UpdateNetworkFirewallRules(networkId string, firewallRules []*
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	return vapp.GetVappNetworkById(networkId, false)
@@ -71,7 +71,7 @@ func (vapp *VApp) GetVappNetworkById(id string, refresh bool) (*types.VAppNetwor
 	if refresh {
 		err := vapp.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -112,7 +112,7 @@ func (vapp *VApp) GetVappNetworkByName(vappNetworkName string, refresh bool) (*t
 	if refresh {
 		err := vapp.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -155,7 +155,7 @@ func (vapp *VApp) UpdateNetworkNatRules(networkId string, natRules []*types.NatR
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	return vapp.GetVappNetworkById(networkId, false)
@@ -207,7 +207,7 @@ func (vapp *VApp) RemoveAllNetworkNatRules(networkId string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return combinedTaskError(task.Task, err)
 	}
 	return nil
 }
@@ -226,7 +226,7 @@ func (vapp *VApp) RemoveAllNetworkFirewallRules(networkId string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return combinedTaskError(task.Task, err)
 	}
 	return nil
 }
@@ -240,7 +240,7 @@ func (vapp *VApp) UpdateNetworkStaticRouting(networkId string, staticRoutes []*t
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return nil, combinedTaskError(task.Task, err)
 	}
 
 	return vapp.GetVappNetworkById(networkId, false)
@@ -297,7 +297,7 @@ func (vapp *VApp) RemoveAllNetworkStaticRoutes(networkId string) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+		return combinedTaskError(task.Task, err)
 	}
 	return nil
 }
@@ -327,7 +327,7 @@ func queryVappNetworks(client *Client, values map[string]string) ([]*types.Query
 	}
 	results, err := client.cumulativeQuery(vAppNetworkType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving vApp networks %s", err)
+		return nil, fmt.Errorf("error retrieving vApp networks %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -0,0 +1,1253 @@
+package govcd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// VAppSpec is the desired state of a vApp. Vdc.PlanVApp compares it with the vApp of the same name
+// and returns the ordered list of changes that make the vApp match the specification. Settings
+// that are left empty are not managed: the values of the vApp are kept.
+type VAppSpec struct {
+	Name        string
+	Description string
+	// Networks are the names of the Org VDC networks that are connected directly to the vApp. The
+	// NICs of the VMs can only use networks that are connected to the vApp. Networks of the vApp
+	// that are not in the list are kept
+	Networks []string
+	// Metadata entries of the vApp, in the GENERAL domain and of type string. Entries that are not
+	// in the map are kept
+	Metadata map[string]string
+	// VMs of the vApp
+	VMs []VmSpec
+	// RemoveUnlistedVms removes the VMs of the vApp that are not in VMs
+	RemoveUnlistedVms bool
+}
+
+// VmSpec is the desired state of a VM of a vApp
+type VmSpec struct {
+	Name string
+
+	// Template is a reference to a VM of a vApp template, used only when the VM is created. When
+	// nil, an empty VM is created with OsType, HardwareVersion, Cpus and MemoryMb
+	Template        *types.Reference
+	OsType          string           // Used only when an empty VM is created, e.g. "debian10_64Guest"
+	HardwareVersion string           // Used only when an empty VM is created, e.g. "vmx-19"
+	StorageProfile  *types.Reference // Used only when the VM is created. Use VM.Relocate to move existing VMs
+
+	Cpus           int   // Number of virtual CPUs. 0 leaves it unchanged
+	CoresPerSocket int   // 0 leaves it unchanged
+	MemoryMb       int64 // 0 leaves it unchanged
+
+	// Disks are the internal disks of the VM, identified by adapter type, bus and unit number. Disks
+	// of the VM that are not in the list are kept. Disks cannot be shrunk
+	Disks []VmDiskSpec
+	// Nics are the NICs of the VM, in order of NetworkConnectionIndex. When nil, the NICs are not
+	// managed. An empty, non nil, list removes all NICs
+	Nics []VmNicSpec
+	// Customization is the guest customization of the VM. When nil, it is not managed
+	Customization *VmCustomizationSpec
+	// Metadata entries of the VM, in the GENERAL domain and of type string. Entries that are not in
+	// the map are kept
+	Metadata map[string]string
+}
+
+// VmDiskSpec is the desired state of an internal disk of a VM
+type VmDiskSpec struct {
+	AdapterType     string // As in types.DiskSettings, e.g. "5" for paravirtual SCSI, "1" for IDE
+	BusNumber       int
+	UnitNumber      int
+	SizeMb          int64
+	StorageProfile  *types.Reference // Used only when the disk is created. Defaults to the storage profile of the VM
+	ThinProvisioned *bool            // Used only when the disk is created. Defaults to true
+}
+
+// VmNicSpec is the desired state of a NIC of a VM
+type VmNicSpec struct {
+	Network          string // Name of a vApp network, or "none"
+	IpAllocationMode string // One of types.IPAllocationModeDHCP, types.IPAllocationModePool, types.IPAllocationModeManual, types.IPAllocationModeNone
+	IpAddress        string // Required with types.IPAllocationModeManual
+	AdapterType      string // e.g. VMXNET3. When empty, the current adapter type is kept
+	IsPrimary        bool
+	IsConnected      bool
+}
+
+// VmCustomizationSpec is the desired guest customization of a VM
+type VmCustomizationSpec struct {
+	Enabled              bool
+	ComputerName         string
+	Script               string
+	AdminPasswordEnabled bool
+	AdminPasswordAuto    bool
+	AdminPassword        string // Compared only when not empty, as VCD may not return it
+}
+
+// VAppPlanAction is the kind of change made by a VAppPlanStep
+type VAppPlanAction string
+
+const (
+	VAppPlanCreateVApp          VAppPlanAction = "create vApp"
+	VAppPlanUpdateVApp          VAppPlanAction = "update vApp"
+	VAppPlanAddNetwork          VAppPlanAction = "add network"
+	VAppPlanUpdateMetadata      VAppPlanAction = "update metadata"
+	VAppPlanRemoveVm            VAppPlanAction = "remove VM"
+	VAppPlanCreateVm            VAppPlanAction = "create VM"
+	VAppPlanConfigureVm         VAppPlanAction = "configure VM"
+	VAppPlanUpdateCompute       VAppPlanAction = "update CPU and memory"
+	VAppPlanAddDisk             VAppPlanAction = "add disk"
+	VAppPlanResizeDisk          VAppPlanAction = "resize disk"
+	VAppPlanUpdateNics          VAppPlanAction = "update NICs"
+	VAppPlanUpdateCustomization VAppPlanAction = "update guest customization"
+)
+
+// VAppPlanStepStatus is the state of a VAppPlanStep during VAppPlan.Apply
+type VAppPlanStepStatus string
+
+const (
+	VAppPlanStepPending        VAppPlanStepStatus = "pending"
+	VAppPlanStepApplied        VAppPlanStepStatus = "applied"
+	VAppPlanStepFailed         VAppPlanStepStatus = "failed"
+	VAppPlanStepRolledBack     VAppPlanStepStatus = "rolled back"
+	VAppPlanStepRollbackFailed VAppPlanStepStatus = "rollback failed"
+)
+
+// VAppPlanStep is a single change of a VAppPlan
+type VAppPlanStep struct {
+	Action        VAppPlanAction
+	VmName        string // Name of the VM that the step changes. Empty for changes of the vApp
+	Details       string // Human readable description of the change
+	NeedsPowerOff bool   // The VM must be powered off to apply the step
+	Reversible    bool   // The step is undone when a later step fails
+	Status        VAppPlanStepStatus
+	Err           error // Error of the step, when Status is VAppPlanStepFailed or VAppPlanStepRollbackFailed
+
+	// apply makes the change and returns the function that undoes it, or nil
+	apply func(state *vAppApplyState) (func() error, error)
+	undo  func() error
+}
+
+func (step *VAppPlanStep) String() string {
+	result := string(step.Action)
+	if step.VmName != "" {
+		result = fmt.Sprintf("[%s] %s", step.VmName, result)
+	}
+	if step.Details != "" {
+		result += ": " + step.Details
+	}
+	if step.NeedsPowerOff {
+		result += " (needs power off)"
+	}
+	return result
+}
+
+// VAppPlan is the ordered list of changes that make a vApp match a VAppSpec. The changes of the
+// vApp come first, then the removal and creation of VMs, then the changes of each VM. For each VM,
+// the changes that need the VM to be powered off come last, so that it is powered off only once.
+type VAppPlan struct {
+	VAppName string
+	Steps    []*VAppPlanStep
+	// OnStepChange, when set, is called by Apply every time the status of a step changes
+	OnStepChange func(step *VAppPlanStep)
+
+	vdc     *Vdc
+	vapp    *VApp
+	applied bool
+}
+
+// IsEmpty returns true when the vApp already matches the specification
+func (plan *VAppPlan) IsEmpty() bool {
+	return len(plan.Steps) == 0
+}
+
+// NeedsPowerOff returns true when at least a VM must be powered off to apply the plan
+func (plan *VAppPlan) NeedsPowerOff() bool {
+	for _, step := range plan.Steps {
+		if step.NeedsPowerOff {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the plan with one numbered step per line
+func (plan *VAppPlan) String() string {
+	var lines []string
+	for i, step := range plan.Steps {
+		lines = append(lines, fmt.Sprintf("%d. %s", i+1, step))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PlanVApp compares spec with the vApp of the same name in this VDC and returns the plan that
+// makes the vApp match the specification. When the vApp does not exist, the plan creates it.
+// The plan is not applied: use VAppPlan.Apply
+func (vdc *Vdc) PlanVApp(spec VAppSpec) (*VAppPlan, error) {
+	err := validateVAppSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	live := &liveVApp{}
+	vapp, err := vdc.GetVAppByName(spec.Name, true)
+	if err != nil && !ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving vApp %s: %w", spec.Name, err)
+	}
+	if err == nil {
+		live, err = getLiveVApp(vapp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	steps, err := planVAppSteps(spec, live)
+	if err != nil {
+		return nil, err
+	}
+	return &VAppPlan{VAppName: spec.Name, Steps: steps, vdc: vdc, vapp: vapp}, nil
+}
+
+// Apply runs the steps of the plan in order, waiting for the task of each of them. VMs are powered
+// off before the first step that needs it, and powered on again at the end.
+// When a step fails, the steps already applied are undone in reverse order, when they are
+// reversible (see VAppPlanStep.Reversible), and the error of the failed step is returned. The
+// status of each step reports what happened. A plan can be applied only once.
+func (plan *VAppPlan) Apply() (*VApp, error) {
+	if plan.applied {
+		return nil, fmt.Errorf("plan of vApp %s was already applied", plan.VAppName)
+	}
+	plan.applied = true
+
+	state := &vAppApplyState{vdc: plan.vdc, vapp: plan.vapp, poweredOff: make(map[string]bool)}
+	var applied []*VAppPlanStep
+	var applyErr error
+	for _, step := range plan.Steps {
+		util.Logger.Printf("[TRACE] applying plan of vApp %s: %s\n", plan.VAppName, step)
+		var err error
+		if step.NeedsPowerOff && step.VmName != "" {
+			err = state.powerOffVm(step.VmName)
+		}
+		if err == nil {
+			step.undo, err = step.apply(state)
+		}
+		if err != nil {
+			plan.setStatus(step, VAppPlanStepFailed, err)
+			applyErr = fmt.Errorf("error applying '%s' to vApp %s: %w", step, plan.VAppName, err)
+			break
+		}
+		plan.setStatus(step, VAppPlanStepApplied, nil)
+		applied = append(applied, step)
+	}
+
+	var rollbackErrors []string
+	if applyErr != nil {
+		rollbackErrors = plan.rollback(applied)
+	}
+	err := state.restorePowerState()
+	if err != nil {
+		rollbackErrors = append(rollbackErrors, err.Error())
+	}
+
+	if state.vapp != nil {
+		err = state.vapp.Refresh()
+		if err != nil && applyErr == nil {
+			applyErr = fmt.Errorf("error refreshing vApp %s: %w", plan.VAppName, err)
+		}
+	}
+	if applyErr != nil && len(rollbackErrors) > 0 {
+		return state.vapp, fmt.Errorf("%w. Rollback errors: %s", applyErr, strings.Join(rollbackErrors, "; "))
+	}
+	if applyErr == nil && len(rollbackErrors) > 0 {
+		return state.vapp, fmt.Errorf("plan of vApp %s applied, but %s", plan.VAppName, strings.Join(rollbackErrors, "; "))
+	}
+	return state.vapp, applyErr
+}
+
+// rollback undoes the applied steps in reverse order and returns the errors
+func (plan *VAppPlan) rollback(applied []*VAppPlanStep) []string {
+	var rollbackErrors []string
+	for i := len(applied) - 1; i >= 0; i-- {
+		step := applied[i]
+		if step.undo == nil {
+			continue
+		}
+		util.Logger.Printf("[TRACE] rolling back plan of vApp %s: %s\n", plan.VAppName, step)
+		err := step.undo()
+		if err != nil {
+			plan.setStatus(step, VAppPlanStepRollbackFailed, err)
+			rollbackErrors = append(rollbackErrors, fmt.Sprintf("'%s': %s", step, err))
+			continue
+		}
+		plan.setStatus(step, VAppPlanStepRolledBack, nil)
+	}
+	return rollbackErrors
+}
+
+func (plan *VAppPlan) setStatus(step *VAppPlanStep, status VAppPlanStepStatus, err error) {
+	step.Status = status
+	step.Err = err
+	if plan.OnStepChange != nil {
+		plan.OnStepChange(step)
+	}
+}
+
+// validateVAppSpec checks the specification independently of the live vApp
+func validateVAppSpec(spec VAppSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("vApp name cannot be empty")
+	}
+	vmNames := make(map[string]bool)
+	for _, vmSpec := range spec.VMs {
+		if vmSpec.Name == "" {
+			return fmt.Errorf("VM name cannot be empty in vApp %s", spec.Name)
+		}
+		if vmNames[vmSpec.Name] {
+			return fmt.Errorf("VM %s is defined more than once in vApp %s", vmSpec.Name, spec.Name)
+		}
+		vmNames[vmSpec.Name] = true
+		if vmSpec.Cpus < 0 || vmSpec.CoresPerSocket < 0 || vmSpec.MemoryMb < 0 {
+			return fmt.Errorf("VM %s: CPUs, cores per socket and memory cannot be negative", vmSpec.Name)
+		}
+
+		disks := make(map[string]bool)
+		for _, disk := range vmSpec.Disks {
+			if disk.AdapterType == "" || disk.SizeMb <= 0 {
+				return fmt.Errorf("VM %s: disks need an adapter type and a positive size", vmSpec.Name)
+			}
+			if disks[disk.key()] {
+				return fmt.Errorf("VM %s: disk %s is defined more than once", vmSpec.Name, disk.key())
+			}
+			disks[disk.key()] = true
+		}
+
+		primaryNics := 0
+		for i, nic := range vmSpec.Nics {
+			if nic.Network == "" || nic.IpAllocationMode == "" {
+				return fmt.Errorf("VM %s: NIC %d needs a network and an IP allocation mode", vmSpec.Name, i)
+			}
+			if strings.EqualFold(nic.IpAllocationMode, types.IPAllocationModeManual) && nic.IpAddress == "" {
+				return fmt.Errorf("VM %s: NIC %d needs an IP address with allocation mode %s", vmSpec.Name, i, nic.IpAllocationMode)
+			}
+			if nic.IsPrimary {
+				primaryNics++
+			}
+		}
+		if primaryNics > 1 {
+			return fmt.Errorf("VM %s: only one NIC can be primary", vmSpec.Name)
+		}
+	}
+	return nil
+}
+
+func (disk VmDiskSpec) key() string {
+	return fmt.Sprintf("%s:%d:%d", disk.AdapterType, disk.BusNumber, disk.UnitNumber)
+}
+
+// liveVApp is the current state of a vApp, as needed to plan changes
+type liveVApp struct {
+	exists      bool
+	description string
+	networks    []string
+	metadata    map[string]string
+	vmNames     []string
+	vms         map[string]*liveVm
+}
+
+// liveVm is the current state of a VM, as needed to plan changes
+type liveVm struct {
+	vm       *types.Vm
+	metadata map[string]string
+}
+
+// getLiveVApp retrieves the current state of a vApp and its VMs
+func getLiveVApp(vapp *VApp) (*liveVApp, error) {
+	err := vapp.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing vApp %s: %w", vapp.VApp.Name, err)
+	}
+	live := &liveVApp{exists: true, description: vapp.VApp.Description, vms: make(map[string]*liveVm)}
+	if vapp.VApp.NetworkConfigSection != nil {
+		for _, networkConfig := range vapp.VApp.NetworkConfigSection.NetworkConfig {
+			live.networks = append(live.networks, networkConfig.NetworkName)
+		}
+	}
+	live.metadata, err = getStringMetadata(vapp.client, vapp.VApp.HREF, vapp.VApp.Name)
+	if err != nil {
+		return nil, err
+	}
+	if vapp.VApp.Children == nil {
+		return live, nil
+	}
+	for _, child := range vapp.VApp.Children.VM {
+		vm, err := vapp.client.GetVMByHref(child.HREF)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VM %s: %w", child.Name, err)
+		}
+		metadata, err := getStringMetadata(vapp.client, vm.VM.HREF, vm.VM.Name)
+		if err != nil {
+			return nil, err
+		}
+		live.vmNames = append(live.vmNames, vm.VM.Name)
+		live.vms[vm.VM.Name] = &liveVm{vm: vm.VM, metadata: metadata}
+	}
+	return live, nil
+}
+
+// getStringMetadata returns the metadata entries of the GENERAL domain of an entity
+func getStringMetadata(client *Client, href, name string) (map[string]string, error) {
+	metadata, err := getMetadata(client, href, name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving metadata of %s: %w", name, err)
+	}
+	result := make(map[string]string)
+	for _, entry := range metadata.MetadataEntry {
+		if entry.TypedValue == nil || (entry.Domain != nil && entry.Domain.Domain == "SYSTEM") {
+			continue
+		}
+		result[entry.Key] = entry.TypedValue.Value
+	}
+	return result, nil
+}
+
+// planVAppSteps returns the steps that make the live vApp match the specification
+func planVAppSteps(spec VAppSpec, live *liveVApp) ([]*VAppPlanStep, error) {
+	var steps []*VAppPlanStep
+
+	if !live.exists {
+		steps = append(steps, newCreateVAppStep(spec))
+	} else if spec.Description != "" && spec.Description != live.description {
+		steps = append(steps, newUpdateVAppStep(live.description, spec.Description))
+	}
+
+	for _, network := range spec.Networks {
+		if !contains(network, live.networks) {
+			steps = append(steps, newAddNetworkStep(network))
+		}
+	}
+
+	if step := newMetadataStep("", spec.Metadata, live.metadata); step != nil {
+		steps = append(steps, step)
+	}
+
+	if spec.RemoveUnlistedVms {
+		for _, vmName := range live.vmNames {
+			if !vmSpecExists(spec.VMs, vmName) {
+				steps = append(steps, newRemoveVmStep(vmName))
+			}
+		}
+	}
+
+	for _, vmSpec := range spec.VMs {
+		live := live.vms[vmSpec.Name]
+		if live == nil {
+			createStep, err := newCreateVmStep(vmSpec)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, createStep, newConfigureVmStep(vmSpec))
+			continue
+		}
+		vmSteps, err := planVmSteps(vmSpec, live)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, vmSteps...)
+	}
+	return steps, nil
+}
+
+func vmSpecExists(vmSpecs []VmSpec, vmName string) bool {
+	for _, vmSpec := range vmSpecs {
+		if vmSpec.Name == vmName {
+			return true
+		}
+	}
+	return false
+}
+
+// planVmSteps returns the steps that make a VM match its specification. Steps that need the VM
+// to be powered off come last
+func planVmSteps(spec VmSpec, live *liveVm) ([]*VAppPlanStep, error) {
+	var steps []*VAppPlanStep
+
+	if step := newComputeStep(spec, live.vm); step != nil {
+		steps = append(steps, step)
+	}
+
+	for _, disk := range spec.Disks {
+		current := findVmDisk(live.vm, disk)
+		switch {
+		case current == nil:
+			steps = append(steps, newAddDiskStep(spec.Name, disk))
+		case disk.SizeMb < current.SizeMb:
+			return nil, fmt.Errorf("VM %s: disk %s cannot be shrunk from %d MB to %d MB", spec.Name, disk.key(), current.SizeMb, disk.SizeMb)
+		case disk.SizeMb > current.SizeMb:
+			steps = append(steps, newResizeDiskStep(spec.Name, disk, current.SizeMb))
+		}
+	}
+
+	if spec.Nics != nil {
+		if step := newNicsStep(spec.Name, spec.Nics, live.vm.NetworkConnectionSection); step != nil {
+			steps = append(steps, step)
+		}
+	}
+
+	if spec.Customization != nil {
+		if step := newCustomizationStep(spec.Name, *spec.Customization, live.vm.GuestCustomizationSection); step != nil {
+			steps = append(steps, step)
+		}
+	}
+
+	if step := newMetadataStep(spec.Name, spec.Metadata, live.metadata); step != nil {
+		steps = append(steps, step)
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		return !steps[i].NeedsPowerOff && steps[j].NeedsPowerOff
+	})
+	return steps, nil
+}
+
+// findVmDisk returns the internal disk of the VM that has the adapter type, bus and unit number of
+// the disk specification, or nil
+func findVmDisk(vm *types.Vm, disk VmDiskSpec) *types.DiskSettings {
+	if vm.VmSpecSection == nil || vm.VmSpecSection.DiskSection == nil {
+		return nil
+	}
+	for _, diskSettings := range vm.VmSpecSection.DiskSection.DiskSettings {
+		if diskSettings.AdapterType == disk.AdapterType && diskSettings.BusNumber == disk.BusNumber &&
+			diskSettings.UnitNumber == disk.UnitNumber {
+			return diskSettings
+		}
+	}
+	return nil
+}
+
+func newCreateVAppStep(spec VAppSpec) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:     VAppPlanCreateVApp,
+		Details:    spec.Name,
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vapp, err := state.vdc.CreateRawVApp(spec.Name, spec.Description)
+			if err != nil {
+				return nil, err
+			}
+			state.vapp = vapp
+			return func() error {
+				task, err := vapp.Delete()
+				if err != nil {
+					return err
+				}
+				err = task.WaitTaskCompletion()
+				if err != nil {
+					return err
+				}
+				state.vapp = nil
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newUpdateVAppStep(oldDescription, newDescription string) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:     VAppPlanUpdateVApp,
+		Details:    fmt.Sprintf("description '%s' -> '%s'", oldDescription, newDescription),
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vapp := state.vapp
+			err := vapp.UpdateDescription(newDescription)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				return vapp.UpdateDescription(oldDescription)
+			}, nil
+		},
+	}
+}
+
+func newAddNetworkStep(networkName string) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:     VAppPlanAddNetwork,
+		Details:    networkName,
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			orgNetwork, err := state.vdc.GetOrgVdcNetworkByName(networkName, false)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving Org VDC network %s: %w", networkName, err)
+			}
+			vapp := state.vapp
+			err = vapp.Refresh()
+			if err != nil {
+				return nil, err
+			}
+			_, err = vapp.AddOrgNetwork(&VappNetworkSettings{}, orgNetwork.OrgVDCNetwork, false)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				err := vapp.Refresh()
+				if err != nil {
+					return err
+				}
+				_, err = vapp.RemoveNetwork(networkName)
+				return err
+			}, nil
+		},
+	}
+}
+
+// newMetadataStep returns the step that sets the desired metadata entries of the vApp (when
+// vmName is empty) or of a VM, or nil if they already have the desired values
+func newMetadataStep(vmName string, desired, current map[string]string) *VAppPlanStep {
+	changed := make(map[string]string)
+	var keys []string
+	for key, value := range desired {
+		if currentValue, found := current[key]; !found || currentValue != value {
+			changed[key] = value
+			keys = append(keys, key)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	previous := make(map[string]string)
+	var added []string
+	for key := range changed {
+		if currentValue, found := current[key]; found {
+			previous[key] = currentValue
+		} else {
+			added = append(added, key)
+		}
+	}
+
+	return &VAppPlanStep{
+		Action:     VAppPlanUpdateMetadata,
+		VmName:     vmName,
+		Details:    "keys " + strings.Join(keys, ", "),
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			client, href, name, err := state.metadataTarget(vmName)
+			if err != nil {
+				return nil, err
+			}
+			err = mergeMetadataAndWait(client, href, name, stringMetadataValues(changed))
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				if len(previous) > 0 {
+					err := mergeMetadataAndWait(client, href, name, stringMetadataValues(previous))
+					if err != nil {
+						return err
+					}
+				}
+				for _, key := range added {
+					err := deleteMetadataAndWait(client, href, name, key, false)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}, nil
+		},
+	}
+}
+
+func stringMetadataValues(values map[string]string) map[string]types.MetadataValue {
+	result := make(map[string]types.MetadataValue)
+	for key, value := range values {
+		result[key] = types.MetadataValue{
+			TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: value},
+			Domain:     &types.MetadataDomainTag{Visibility: types.MetadataReadWriteVisibility, Domain: "GENERAL"},
+		}
+	}
+	return result
+}
+
+func newRemoveVmStep(vmName string) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:        VAppPlanRemoveVm,
+		VmName:        vmName,
+		NeedsPowerOff: true,
+		Status:        VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(vmName)
+			if err != nil {
+				return nil, err
+			}
+			err = state.vapp.RemoveVM(*vm)
+			if err != nil {
+				return nil, err
+			}
+			// A removed VM is not powered on again
+			delete(state.poweredOff, vmName)
+			return nil, nil
+		},
+	}
+}
+
+func newCreateVmStep(spec VmSpec) (*VAppPlanStep, error) {
+	details := "empty VM"
+	if spec.Template != nil {
+		if spec.Template.HREF == "" {
+			return nil, fmt.Errorf("VM %s: the template reference needs an HREF", spec.Name)
+		}
+		details = "from template " + spec.Template.Name
+	} else if spec.OsType == "" || spec.HardwareVersion == "" || spec.Cpus == 0 || spec.MemoryMb == 0 {
+		return nil, fmt.Errorf("VM %s: OS type, hardware version, CPUs and memory are required to create an empty VM", spec.Name)
+	}
+
+	return &VAppPlanStep{
+		Action:     VAppPlanCreateVm,
+		VmName:     spec.Name,
+		Details:    details,
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vapp := state.vapp
+			err := vapp.Refresh()
+			if err != nil {
+				return nil, err
+			}
+			var nics *types.NetworkConnectionSection
+			if spec.Nics != nil {
+				nics = nicSectionFromSpec(spec.Nics, nil)
+			}
+
+			var vm *VM
+			if spec.Template != nil {
+				vm, err = vapp.AddRawVM(&types.ReComposeVAppParams{
+					Ovf:   types.XMLNamespaceOVF,
+					Xsi:   types.XMLNamespaceXSI,
+					Xmlns: types.XMLNamespaceVCloud,
+					Name:  vapp.VApp.Name,
+					SourcedItem: &types.SourcedCompositionItemParam{
+						Source:              &types.Reference{HREF: spec.Template.HREF, Name: spec.Name},
+						InstantiationParams: &types.InstantiationParams{NetworkConnectionSection: nics},
+						StorageProfile:      spec.StorageProfile,
+					},
+					AllEULAsAccepted: true,
+				})
+			} else {
+				cores := max(spec.CoresPerSocket, 1)
+				vm, err = vapp.AddEmptyVm(&types.RecomposeVAppParamsForEmptyVm{
+					XmlnsVcloud: types.XMLNamespaceVCloud,
+					XmlnsOvf:    types.XMLNamespaceOVF,
+					CreateItem: &types.CreateItem{
+						Name:                     spec.Name,
+						NetworkConnectionSection: nics,
+						StorageProfile:           spec.StorageProfile,
+						VmSpecSection: &types.VmSpecSection{
+							Modified:          addrOf(true),
+							Info:              "Virtual Machine specification",
+							OsType:            spec.OsType,
+							NumCpus:           addrOf(spec.Cpus),
+							NumCoresPerSocket: addrOf(cores),
+							MemoryResourceMb:  &types.MemoryResourceMb{Configured: spec.MemoryMb},
+							HardwareVersion:   &types.HardwareVersion{Value: spec.HardwareVersion},
+						},
+					},
+					AllEULAsAccepted: true,
+				})
+			}
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				return vapp.RemoveVM(*vm)
+			}, nil
+		},
+	}, nil
+}
+
+// newConfigureVmStep returns the step that applies the settings of a new VM that could not be set
+// at creation. As the settings of the new VM are not known in advance, the changes are planned when
+// the step is applied. Undoing the step undoes the changes in reverse order. When one of them fails,
+// the previous ones are undone before returning the error
+func newConfigureVmStep(spec VmSpec) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:     VAppPlanConfigureVm,
+		VmName:     spec.Name,
+		Details:    "apply the settings that differ after creation",
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(spec.Name)
+			if err != nil {
+				return nil, err
+			}
+			metadata, err := getStringMetadata(vm.client, vm.VM.HREF, vm.VM.Name)
+			if err != nil {
+				return nil, err
+			}
+			steps, err := planVmSteps(spec, &liveVm{vm: vm.VM, metadata: metadata})
+			if err != nil {
+				return nil, err
+			}
+
+			var undos []func() error
+			undo := func() error {
+				var undoErrors []string
+				for i := len(undos) - 1; i >= 0; i-- {
+					err := undos[i]()
+					if err != nil {
+						undoErrors = append(undoErrors, err.Error())
+					}
+				}
+				if len(undoErrors) > 0 {
+					return fmt.Errorf("%s", strings.Join(undoErrors, "; "))
+				}
+				return nil
+			}
+			for _, step := range steps {
+				util.Logger.Printf("[TRACE] configuring new VM %s: %s\n", spec.Name, step)
+				stepUndo, err := step.apply(state)
+				if err != nil {
+					err = fmt.Errorf("error applying '%s': %w", step, err)
+					// Apply does not undo a failed step, therefore the changes already made are undone here
+					undoErr := undo()
+					if undoErr != nil {
+						return nil, fmt.Errorf("%w. Rollback errors: %s", err, undoErr)
+					}
+					return nil, err
+				}
+				if stepUndo != nil {
+					undos = append(undos, stepUndo)
+				}
+			}
+			return undo, nil
+		},
+	}
+}
+
+// newComputeStep returns the step that changes CPU and memory of a VM, or nil if they already
+// have the desired values
+func newComputeStep(spec VmSpec, vm *types.Vm) *VAppPlanStep {
+	if vm.VmSpecSection == nil {
+		return nil
+	}
+	currentCpus, currentCores, currentMemory := 0, 0, int64(0)
+	if vm.VmSpecSection.NumCpus != nil {
+		currentCpus = *vm.VmSpecSection.NumCpus
+	}
+	if vm.VmSpecSection.NumCoresPerSocket != nil {
+		currentCores = *vm.VmSpecSection.NumCoresPerSocket
+	}
+	if vm.VmSpecSection.MemoryResourceMb != nil {
+		currentMemory = vm.VmSpecSection.MemoryResourceMb.Configured
+	}
+	cpuHotAdd, memoryHotAdd := false, false
+	if vm.VMCapabilities != nil {
+		cpuHotAdd, memoryHotAdd = vm.VMCapabilities.CPUHotAddEnabled, vm.VMCapabilities.MemoryHotAddEnabled
+	}
+
+	cpus, cores, memory := currentCpus, currentCores, currentMemory
+	var details []string
+	needsPowerOff := false
+	if spec.Cpus != 0 && spec.Cpus != currentCpus {
+		cpus = spec.Cpus
+		details = append(details, fmt.Sprintf("CPUs %d -> %d", currentCpus, cpus))
+		needsPowerOff = needsPowerOff || cpus < currentCpus || !cpuHotAdd
+	}
+	if spec.CoresPerSocket != 0 && spec.CoresPerSocket != currentCores {
+		cores = spec.CoresPerSocket
+		details = append(details, fmt.Sprintf("cores per socket %d -> %d", currentCores, cores))
+		needsPowerOff = true
+	}
+	if spec.MemoryMb != 0 && spec.MemoryMb != currentMemory {
+		memory = spec.MemoryMb
+		details = append(details, fmt.Sprintf("memory %d -> %d MB", currentMemory, memory))
+		needsPowerOff = needsPowerOff || memory < currentMemory || !memoryHotAdd
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &VAppPlanStep{
+		Action:        VAppPlanUpdateCompute,
+		VmName:        spec.Name,
+		Details:       strings.Join(details, ", "),
+		NeedsPowerOff: needsPowerOff,
+		Reversible:    true,
+		Status:        VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(spec.Name)
+			if err != nil {
+				return nil, err
+			}
+			err = updateVmCompute(vm, cpus, cores, memory)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				return updateVmCompute(vm, currentCpus, currentCores, currentMemory)
+			}, nil
+		},
+	}
+}
+
+// updateVmCompute sets CPUs, cores per socket and memory of a VM with a single reconfiguration
+func updateVmCompute(vm *VM, cpus, cores int, memoryMb int64) error {
+	err := vm.Refresh()
+	if err != nil {
+		return fmt.Errorf("error refreshing VM: %w", err)
+	}
+	vmSpecSection := vm.VM.VmSpecSection
+	if vmSpecSection == nil {
+		return fmt.Errorf("VM %s has no VM specification section", vm.VM.Name)
+	}
+	// update treats same values as changes and fails, with no values provided - no changes are made for that section
+	vmSpecSection.DiskSection = nil
+	vmSpecSection.NumCpus = &cpus
+	// has to come together
+	vmSpecSection.NumCoresPerSocket = &cores
+	if vmSpecSection.MemoryResourceMb == nil {
+		vmSpecSection.MemoryResourceMb = &types.MemoryResourceMb{}
+	}
+	vmSpecSection.MemoryResourceMb.Configured = memoryMb
+
+	_, err = vm.UpdateVmSpecSection(vmSpecSection, vm.VM.Description)
+	if err != nil {
+		return fmt.Errorf("error changing CPU and memory: %w", err)
+	}
+	return nil
+}
+
+func newAddDiskStep(vmName string, disk VmDiskSpec) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:  VAppPlanAddDisk,
+		VmName:  vmName,
+		Details: fmt.Sprintf("%s, %d MB", disk.key(), disk.SizeMb),
+		// IDE disks cannot be hot added
+		NeedsPowerOff: disk.AdapterType == "1",
+		Reversible:    true,
+		Status:        VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(vmName)
+			if err != nil {
+				return nil, err
+			}
+			storageProfile := disk.StorageProfile
+			if storageProfile == nil {
+				storageProfile = vm.VM.StorageProfile
+			}
+			thinProvisioned := disk.ThinProvisioned
+			if thinProvisioned == nil {
+				thinProvisioned = addrOf(true)
+			}
+			diskId, err := vm.AddInternalDisk(&types.DiskSettings{
+				SizeMb:            disk.SizeMb,
+				UnitNumber:        disk.UnitNumber,
+				BusNumber:         disk.BusNumber,
+				AdapterType:       disk.AdapterType,
+				ThinProvisioned:   thinProvisioned,
+				StorageProfile:    storageProfile,
+				OverrideVmDefault: disk.StorageProfile != nil,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				return vm.DeleteInternalDisk(diskId)
+			}, nil
+		},
+	}
+}
+
+func newResizeDiskStep(vmName string, disk VmDiskSpec, currentSizeMb int64) *VAppPlanStep {
+	return &VAppPlanStep{
+		Action:        VAppPlanResizeDisk,
+		VmName:        vmName,
+		Details:       fmt.Sprintf("%s, %d -> %d MB", disk.key(), currentSizeMb, disk.SizeMb),
+		NeedsPowerOff: disk.AdapterType == "1",
+		Status:        VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(vmName)
+			if err != nil {
+				return nil, err
+			}
+			current := findVmDisk(vm.VM, disk)
+			if current == nil {
+				return nil, fmt.Errorf("disk %s not found in VM %s: %w", disk.key(), vmName, ErrorEntityNotFound)
+			}
+			current.SizeMb = disk.SizeMb
+			// The size in bytes would conflict with the new size
+			current.VirtualQuantity = nil
+			current.VirtualQuantityUnit = ""
+			_, err = vm.UpdateInternalDisks(vm.VM.VmSpecSection)
+			// Disks cannot be shrunk, therefore the step cannot be undone
+			return nil, err
+		},
+	}
+}
+
+// newNicsStep returns the step that sets the NICs of a VM, or nil if they already match
+func newNicsStep(vmName string, nics []VmNicSpec, current *types.NetworkConnectionSection) *VAppPlanStep {
+	var currentNics []*types.NetworkConnection
+	currentPrimary := 0
+	if current != nil {
+		currentNics = current.NetworkConnection
+		currentPrimary = current.PrimaryNetworkConnectionIndex
+	}
+
+	var details []string
+	needsPowerOff := false
+	if len(nics) < len(currentNics) {
+		details = append(details, fmt.Sprintf("remove %d NICs", len(currentNics)-len(nics)))
+		needsPowerOff = true
+	}
+	if len(nics) > len(currentNics) {
+		details = append(details, fmt.Sprintf("add %d NICs", len(nics)-len(currentNics)))
+	}
+	for i, nic := range nics {
+		if nic.IsPrimary && i != currentPrimary {
+			details = append(details, fmt.Sprintf("primary NIC %d -> %d", currentPrimary, i))
+		}
+		if i >= len(currentNics) {
+			continue
+		}
+		currentNic := currentNics[i]
+		var changes []string
+		if nic.Network != currentNic.Network {
+			changes = append(changes, fmt.Sprintf("network %s -> %s", currentNic.Network, nic.Network))
+		}
+		if !strings.EqualFold(nic.IpAllocationMode, currentNic.IPAddressAllocationMode) {
+			changes = append(changes, fmt.Sprintf("IP allocation mode %s -> %s", currentNic.IPAddressAllocationMode, nic.IpAllocationMode))
+		}
+		if nic.IpAddress != "" && nic.IpAddress != currentNic.IPAddress {
+			changes = append(changes, fmt.Sprintf("IP address %s -> %s", currentNic.IPAddress, nic.IpAddress))
+		}
+		if nic.IsConnected != currentNic.IsConnected {
+			changes = append(changes, fmt.Sprintf("connected %t -> %t", currentNic.IsConnected, nic.IsConnected))
+		}
+		if nic.AdapterType != "" && !strings.EqualFold(nic.AdapterType, currentNic.NetworkAdapterType) {
+			changes = append(changes, fmt.Sprintf("adapter type %s -> %s", currentNic.NetworkAdapterType, nic.AdapterType))
+			needsPowerOff = true
+		}
+		if len(changes) > 0 {
+			details = append(details, fmt.Sprintf("NIC %d %s", i, strings.Join(changes, ", ")))
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &VAppPlanStep{
+		Action:        VAppPlanUpdateNics,
+		VmName:        vmName,
+		Details:       strings.Join(details, "; "),
+		NeedsPowerOff: needsPowerOff,
+		Reversible:    true,
+		Status:        VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(vmName)
+			if err != nil {
+				return nil, err
+			}
+			previous, err := vm.GetNetworkConnectionSection()
+			if err != nil {
+				return nil, err
+			}
+			err = vm.UpdateNetworkConnectionSection(nicSectionFromSpec(nics, previous))
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				return vm.UpdateNetworkConnectionSection(previous)
+			}, nil
+		},
+	}
+}
+
+// nicSectionFromSpec builds the NetworkConnectionSection of the NIC specifications. The MAC address
+// and adapter type of the current NICs are kept when the adapter type does not change
+func nicSectionFromSpec(nics []VmNicSpec, current *types.NetworkConnectionSection) *types.NetworkConnectionSection {
+	section := &types.NetworkConnectionSection{}
+	for i, nic := range nics {
+		connection := &types.NetworkConnection{
+			Network:                 nic.Network,
+			NetworkConnectionIndex:  i,
+			IPAddress:               nic.IpAddress,
+			IsConnected:             nic.IsConnected,
+			IPAddressAllocationMode: nic.IpAllocationMode,
+			NetworkAdapterType:      nic.AdapterType,
+		}
+		if current != nil && i < len(current.NetworkConnection) {
+			currentNic := current.NetworkConnection[i]
+			if nic.AdapterType == "" || strings.EqualFold(nic.AdapterType, currentNic.NetworkAdapterType) {
+				connection.NetworkAdapterType = currentNic.NetworkAdapterType
+				connection.MACAddress = currentNic.MACAddress
+			}
+		}
+		if nic.IsPrimary {
+			section.PrimaryNetworkConnectionIndex = i
+		}
+		section.NetworkConnection = append(section.NetworkConnection, connection)
+	}
+	return section
+}
+
+// newCustomizationStep returns the step that sets the guest customization of a VM, or nil if it
+// already matches
+func newCustomizationStep(vmName string, customization VmCustomizationSpec, current *types.GuestCustomizationSection) *VAppPlanStep {
+	if current == nil {
+		current = &types.GuestCustomizationSection{}
+	}
+	var details []string
+	if customization.Enabled != (current.Enabled != nil && *current.Enabled) {
+		details = append(details, fmt.Sprintf("enabled -> %t", customization.Enabled))
+	}
+	if customization.ComputerName != current.ComputerName {
+		details = append(details, fmt.Sprintf("computer name %s -> %s", current.ComputerName, customization.ComputerName))
+	}
+	if customization.Script != current.CustomizationScript {
+		details = append(details, "script")
+	}
+	if customization.AdminPasswordEnabled != (current.AdminPasswordEnabled != nil && *current.AdminPasswordEnabled) {
+		details = append(details, fmt.Sprintf("admin password enabled -> %t", customization.AdminPasswordEnabled))
+	}
+	if customization.AdminPasswordAuto != (current.AdminPasswordAuto != nil && *current.AdminPasswordAuto) {
+		details = append(details, fmt.Sprintf("admin password auto -> %t", customization.AdminPasswordAuto))
+	}
+	if customization.AdminPassword != "" && customization.AdminPassword != current.AdminPassword {
+		details = append(details, "admin password")
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &VAppPlanStep{
+		Action:     VAppPlanUpdateCustomization,
+		VmName:     vmName,
+		Details:    strings.Join(details, ", "),
+		Reversible: true,
+		Status:     VAppPlanStepPending,
+		apply: func(state *vAppApplyState) (func() error, error) {
+			vm, err := state.getVm(vmName)
+			if err != nil {
+				return nil, err
+			}
+			section, err := vm.GetGuestCustomizationSection()
+			if err != nil {
+				return nil, err
+			}
+			previous := *section
+			section.Enabled = addrOf(customization.Enabled)
+			section.ComputerName = customization.ComputerName
+			section.CustomizationScript = customization.Script
+			section.AdminPasswordEnabled = addrOf(customization.AdminPasswordEnabled)
+			section.AdminPasswordAuto = addrOf(customization.AdminPasswordAuto)
+			if customization.AdminPassword != "" {
+				section.AdminPassword = customization.AdminPassword
+			}
+			_, err = vm.SetGuestCustomizationSection(section)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				_, err := vm.SetGuestCustomizationSection(&previous)
+				return err
+			}, nil
+		},
+	}
+}
+
+// vAppApplyState is the state shared by the steps of a plan while it is applied
+type vAppApplyState struct {
+	vdc  *Vdc
+	vapp *VApp
+	// poweredOff contains the VMs that were powered off by the plan, and must be powered on again
+	poweredOff map[string]bool
+}
+
+// getVm returns the up-to-date VM of the vApp with the given name
+func (state *vAppApplyState) getVm(vmName string) (*VM, error) {
+	if state.vapp == nil {
+		return nil, fmt.Errorf("cannot retrieve VM %s: the vApp does not exist", vmName)
+	}
+	vm, err := state.vapp.GetVMByName(vmName, true)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VM %s: %w", vmName, err)
+	}
+	return vm, nil
+}
+
+// metadataTarget returns the client, HREF and name of the vApp (when vmName is empty) or of a VM
+func (state *vAppApplyState) metadataTarget(vmName string) (*Client, string, string, error) {
+	if vmName == "" {
+		if state.vapp == nil {
+			return nil, "", "", fmt.Errorf("cannot set metadata: the vApp does not exist")
+		}
+		return state.vapp.client, state.vapp.VApp.HREF, state.vapp.VApp.Name, nil
+	}
+	vm, err := state.getVm(vmName)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return vm.client, vm.VM.HREF, vm.VM.Name, nil
+}
+
+// powerOffVm powers off a VM, if it is powered on, and records it to power it on at the end
+func (state *vAppApplyState) powerOffVm(vmName string) error {
+	if state.poweredOff[vmName] {
+		return nil
+	}
+	vm, err := state.getVm(vmName)
+	if err != nil {
+		return err
+	}
+	if types.VAppStatuses[vm.VM.Status] != "POWERED_ON" {
+		return nil
+	}
+	util.Logger.Printf("[TRACE] powering off VM %s\n", vmName)
+	task, err := vm.PowerOff()
+	if err != nil {
+		return fmt.Errorf("error powering off VM %s: %w", vmName, err)
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error powering off VM %s: %w", vmName, err)
+	}
+	state.poweredOff[vmName] = true
+	return nil
+}
+
+// restorePowerState powers on the VMs that were powered off by the plan
+func (state *vAppApplyState) restorePowerState() error {
+	var vmNames []string
+	for vmName := range state.poweredOff {
+		vmNames = append(vmNames, vmName)
+	}
+	sort.Strings(vmNames)
+
+	var powerErrors []string
+	for _, vmName := range vmNames {
+		util.Logger.Printf("[TRACE] powering on VM %s\n", vmName)
+		vm, err := state.getVm(vmName)
+		if err == nil {
+			var task Task
+			task, err = vm.PowerOn()
+			if err == nil {
+				err = task.WaitTaskCompletion()
+			}
+		}
+		if err != nil {
+			powerErrors = append(powerErrors, fmt.Sprintf("error powering on VM %s: %s", vmName, err))
+		}
+	}
+	if len(powerErrors) > 0 {
+		return fmt.Errorf("%s", strings.Join(powerErrors, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// vAppSpecTestLive returns a vApp with a VM web1 with 2 CPUs, 2048 MB, a disk and a NIC
+func vAppSpecTestLive() *liveVApp {
+	return &liveVApp{
+		exists:      true,
+		description: "old",
+		networks:    []string{"net1"},
+		metadata:    map[string]string{"env": "test"},
+		vmNames:     []string{"web1", "old1"},
+		vms: map[string]*liveVm{
+			"web1": {
+				vm: &types.Vm{
+					Name: "web1",
+					VmSpecSection: &types.VmSpecSection{
+						NumCpus:           addrOf(2),
+						NumCoresPerSocket: addrOf(1),
+						MemoryResourceMb:  &types.MemoryResourceMb{Configured: 2048},
+						DiskSection: &types.DiskSection{DiskSettings: []*types.DiskSettings{
+							{DiskId: "2000", AdapterType: "5", BusNumber: 0, UnitNumber: 0, SizeMb: 4096},
+						}},
+					},
+					VMCapabilities: &types.VmCapabilities{CPUHotAddEnabled: true, MemoryHotAddEnabled: true},
+					NetworkConnectionSection: &types.NetworkConnectionSection{
+						NetworkConnection: []*types.NetworkConnection{
+							{Network: "net1", NetworkConnectionIndex: 0, IsConnected: true,
+								IPAddressAllocationMode: types.IPAllocationModePool, NetworkAdapterType: "VMXNET3", MACAddress: "00:50:56:01:02:03"},
+						},
+					},
+				},
+				metadata: map[string]string{},
+			},
+			"old1": {vm: &types.Vm{Name: "old1"}, metadata: map[string]string{}},
+		},
+	}
+}
+
+func TestPlanVAppSteps(t *testing.T) {
+	spec := VAppSpec{
+		Name:              "vapp1",
+		Description:       "new",
+		Networks:          []string{"net1", "net2"},
+		Metadata:          map[string]string{"env": "test", "owner": "me"},
+		RemoveUnlistedVms: true,
+		VMs: []VmSpec{
+			{
+				Name:     "web1",
+				Cpus:     4,
+				MemoryMb: 1024,
+				Disks: []VmDiskSpec{
+					{AdapterType: "5", BusNumber: 0, UnitNumber: 0, SizeMb: 8192},
+					{AdapterType: "5", BusNumber: 0, UnitNumber: 1, SizeMb: 1024},
+				},
+				Nics: []VmNicSpec{
+					{Network: "net1", IpAllocationMode: types.IPAllocationModePool, IsConnected: true, IsPrimary: true},
+					{Network: "net2", IpAllocationMode: types.IPAllocationModeDHCP, IsConnected: true},
+				},
+				Customization: &VmCustomizationSpec{Enabled: true, ComputerName: "web1"},
+				Metadata:      map[string]string{"role": "web"},
+			},
+			{Name: "db1", OsType: "debian10_64Guest", HardwareVersion: "vmx-19", Cpus: 2, MemoryMb: 2048},
+		},
+	}
+
+	steps, err := planVAppSteps(spec, vAppSpecTestLive())
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	expected := []struct {
+		action        VAppPlanAction
+		vmName        string
+		needsPowerOff bool
+	}{
+		{VAppPlanUpdateVApp, "", false},
+		{VAppPlanAddNetwork, "", false},
+		{VAppPlanUpdateMetadata, "", false},
+		{VAppPlanRemoveVm, "old1", true},
+		// Hot changes of web1 come first
+		{VAppPlanResizeDisk, "web1", false},
+		{VAppPlanAddDisk, "web1", false},
+		{VAppPlanUpdateNics, "web1", false},
+		{VAppPlanUpdateCustomization, "web1", false},
+		{VAppPlanUpdateMetadata, "web1", false},
+		// Memory cannot be hot removed
+		{VAppPlanUpdateCompute, "web1", true},
+		{VAppPlanCreateVm, "db1", false},
+		{VAppPlanConfigureVm, "db1", false},
+	}
+	if len(steps) != len(expected) {
+		t.Fatalf("expected %d steps, got %d:\n%s", len(expected), len(steps), (&VAppPlan{Steps: steps}).String())
+	}
+	for i, step := range steps {
+		if step.Action != expected[i].action || step.VmName != expected[i].vmName || step.NeedsPowerOff != expected[i].needsPowerOff {
+			t.Errorf("step %d: expected %s of '%s' (power off %t), got %s", i+1, expected[i].action,
+				expected[i].vmName, expected[i].needsPowerOff, step)
+		}
+		if step.Status != VAppPlanStepPending {
+			t.Errorf("step %d: expected status pending, got %s", i+1, step.Status)
+		}
+	}
+	if !strings.Contains(steps[3].String(), "needs power off") {
+		t.Errorf("expected the power off requirement in '%s'", steps[3])
+	}
+
+	// A vApp that matches the specification has an empty plan. The description is not managed
+	// when it is empty
+	steps, err = planVAppSteps(VAppSpec{
+		Name:     "vapp1",
+		Networks: []string{"net1"},
+		VMs:      []VmSpec{{Name: "web1", Cpus: 2, MemoryMb: 2048, Nics: []VmNicSpec{{Network: "net1", IpAllocationMode: "pool", IsConnected: true, IsPrimary: true}}}},
+	}, vAppSpecTestLive())
+	if err != nil || len(steps) != 0 {
+		t.Errorf("expected an empty plan, got %d steps and error %v", len(steps), err)
+	}
+
+	// A missing vApp is created
+	steps, err = planVAppSteps(VAppSpec{Name: "vapp2"}, &liveVApp{})
+	if err != nil || len(steps) != 1 || steps[0].Action != VAppPlanCreateVApp {
+		t.Errorf("expected the creation of the vApp, got %d steps and error %v", len(steps), err)
+	}
+}
+
+func TestPlanVAppSteps_Errors(t *testing.T) {
+	_, err := planVAppSteps(VAppSpec{Name: "vapp1", VMs: []VmSpec{
+		{Name: "web1", Disks: []VmDiskSpec{{AdapterType: "5", SizeMb: 1024}}},
+	}}, vAppSpecTestLive())
+	if err == nil || !strings.Contains(err.Error(), "cannot be shrunk") {
+		t.Errorf("expected a shrink error, got %v", err)
+	}
+	_, err = planVAppSteps(VAppSpec{Name: "vapp1", VMs: []VmSpec{{Name: "db1", Cpus: 2}}}, vAppSpecTestLive())
+	if err == nil || !strings.Contains(err.Error(), "required to create an empty VM") {
+		t.Errorf("expected an error for an incomplete empty VM, got %v", err)
+	}
+
+	invalid := []struct {
+		spec     VAppSpec
+		expected string
+	}{
+		{VAppSpec{}, "vApp name cannot be empty"},
+		{VAppSpec{Name: "vapp1", VMs: []VmSpec{{Name: "vm1"}, {Name: "vm1"}}}, "more than once"},
+		{VAppSpec{Name: "vapp1", VMs: []VmSpec{{Name: "vm1", Disks: []VmDiskSpec{{AdapterType: "5"}}}}}, "positive size"},
+		{VAppSpec{Name: "vapp1", VMs: []VmSpec{{Name: "vm1", Nics: []VmNicSpec{{Network: "net1", IpAllocationMode: types.IPAllocationModeManual}}}}}, "needs an IP address"},
+		{VAppSpec{Name: "vapp1", VMs: []VmSpec{{Name: "vm1", Nics: []VmNicSpec{
+			{Network: "net1", IpAllocationMode: types.IPAllocationModeDHCP, IsPrimary: true},
+			{Network: "net1", IpAllocationMode: types.IPAllocationModeDHCP, IsPrimary: true},
+		}}}}, "only one NIC can be primary"},
+	}
+	for _, test := range invalid {
+		err = validateVAppSpec(test.spec)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected error containing '%s', got %v", test.expected, err)
+		}
+	}
+}
+
+func TestVAppPlan_ApplyRollback(t *testing.T) {
+	var undone []string
+	newStep := func(name string, fail, reversible bool) *VAppPlanStep {
+		return &VAppPlanStep{
+			Action:     VAppPlanAction(name),
+			Reversible: reversible,
+			Status:     VAppPlanStepPending,
+			apply: func(state *vAppApplyState) (func() error, error) {
+				if fail {
+					return nil, errors.New("failure of " + name)
+				}
+				if !reversible {
+					return nil, nil
+				}
+				return func() error {
+					undone = append(undone, name)
+					if name == "second" {
+						return errors.New("cannot undo " + name)
+					}
+					return nil
+				}, nil
+			},
+		}
+	}
+	plan := &VAppPlan{
+		VAppName: "vapp1",
+		Steps: []*VAppPlanStep{
+			newStep("first", false, true),
+			newStep("second", false, true),
+			newStep("third", false, false),
+			newStep("fourth", true, true),
+			newStep("fifth", false, true),
+		},
+	}
+	var changes []string
+	plan.OnStepChange = func(step *VAppPlanStep) {
+		changes = append(changes, string(step.Action)+" "+string(step.Status))
+	}
+
+	_, err := plan.Apply()
+	if err == nil || !strings.Contains(err.Error(), "failure of fourth") || !strings.Contains(err.Error(), "cannot undo second") {
+		t.Fatalf("expected the step error and the rollback error, got %v", err)
+	}
+	if strings.Join(undone, ",") != "second,first" {
+		t.Errorf("expected the reversible steps to be undone in reverse order, got %v", undone)
+	}
+	expectedStatus := []VAppPlanStepStatus{VAppPlanStepRolledBack, VAppPlanStepRollbackFailed, VAppPlanStepApplied,
+		VAppPlanStepFailed, VAppPlanStepPending}
+	for i, step := range plan.Steps {
+		if step.Status != expectedStatus[i] {
+			t.Errorf("step %s: expected status %s, got %s", step.Action, expectedStatus[i], step.Status)
+		}
+	}
+	if len(changes) != 6 {
+		t.Errorf("expected 6 status changes, got %v", changes)
+	}
+
+	_, err = plan.Apply()
+	if err == nil || !strings.Contains(err.Error(), "already applied") {
+		t.Errorf("expected an error applying the plan twice, got %v", err)
+	}
+}
+
+// vAppSpecTestServer returns a govcdtest server with a VDC 'vdc1' that has an Org VDC network
+// 'net1', and a vApp 'vapp1' with a VM 'vm1' with 2 CPUs and 1024 MB created in it
+func vAppSpecTestServer(t *testing.T) (*Vdc, *VApp) {
+	server := govcdtest.NewServer()
+	t.Cleanup(server.Close)
+	if _, err := server.AddOrg("org1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddVdc("org1", "vdc1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddOrgVdcNetwork("org1", "vdc1", "net1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddUser("org1", "user", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	vcdClient := NewVCDClient(server.Endpoint(), true)
+	if err := vcdClient.Authenticate("user", "password", "org1"); err != nil {
+		t.Fatalf("error authenticating: %s", err)
+	}
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapp, err := vdc.CreateRawVApp("vapp1", "")
+	if err != nil {
+		t.Fatalf("error creating vApp: %s", err)
+	}
+	_, err = vapp.AddEmptyVm(&types.RecomposeVAppParamsForEmptyVm{
+		CreateItem: &types.CreateItem{
+			Name: "vm1",
+			VmSpecSection: &types.VmSpecSection{
+				OsType:            "debian10_64Guest",
+				NumCpus:           addrOf(2),
+				NumCoresPerSocket: addrOf(1),
+				MemoryResourceMb:  &types.MemoryResourceMb{Configured: 1024},
+				HardwareVersion:   &types.HardwareVersion{Value: "vmx-19"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error adding VM: %s", err)
+	}
+	return vdc, vapp
+}
+
+func TestVAppPlanSteps_ApplyUndo(t *testing.T) {
+	vdc, vapp := vAppSpecTestServer(t)
+	state := &vAppApplyState{vdc: vdc, vapp: vapp, poweredOff: map[string]bool{}}
+	vm, err := state.getVm("vm1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Compute
+	step := newComputeStep(VmSpec{Name: "vm1", Cpus: 4, MemoryMb: 2048}, vm.VM)
+	undoCompute, err := step.apply(state)
+	if err != nil {
+		t.Fatalf("error applying %s: %s", step.Details, err)
+	}
+	checkVmCompute := func(cpus int, memoryMb int64) {
+		t.Helper()
+		vm, err := state.getVm("vm1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := vm.VM.VmSpecSection
+		if *spec.NumCpus != cpus || *spec.NumCoresPerSocket != 1 || spec.MemoryResourceMb.Configured != memoryMb {
+			t.Errorf("expected %d CPUs with 1 core per socket and %d MB, got %d CPUs with %d cores per socket and %d MB",
+				cpus, memoryMb, *spec.NumCpus, *spec.NumCoresPerSocket, spec.MemoryResourceMb.Configured)
+		}
+	}
+	checkVmCompute(4, 2048)
+	if err = undoCompute(); err != nil {
+		t.Fatalf("error undoing %s: %s", step.Details, err)
+	}
+	checkVmCompute(2, 1024)
+
+	// Network
+	checkVAppNetworks := func(expected ...string) {
+		t.Helper()
+		if err := vapp.Refresh(); err != nil {
+			t.Fatal(err)
+		}
+		var networks []string
+		for _, config := range vapp.VApp.NetworkConfigSection.NetworkConfig {
+			networks = append(networks, config.NetworkName)
+		}
+		if strings.Join(networks, ",") != strings.Join(expected, ",") {
+			t.Errorf("expected vApp networks %v, got %v", expected, networks)
+		}
+	}
+	step = newAddNetworkStep("net1")
+	undoNetwork, err := step.apply(state)
+	if err != nil {
+		t.Fatalf("error adding network: %s", err)
+	}
+	checkVAppNetworks("net1")
+
+	// NICs, connected to the new vApp network
+	nics := []VmNicSpec{{Network: "net1", IpAllocationMode: types.IPAllocationModeDHCP, IsPrimary: true, IsConnected: true}}
+	step = newNicsStep("vm1", nics, vm.VM.NetworkConnectionSection)
+	undoNics, err := step.apply(state)
+	if err != nil {
+		t.Fatalf("error applying %s: %s", step.Details, err)
+	}
+	section, err := vm.GetNetworkConnectionSection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(section.NetworkConnection) != 1 || section.NetworkConnection[0].Network != "net1" || !section.NetworkConnection[0].IsConnected {
+		t.Errorf("expected a connected NIC on net1, got %#v", section.NetworkConnection)
+	}
+
+	// The network cannot be removed while a NIC uses it, the steps are undone in reverse order
+	if err = undoNetwork(); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("expected an error removing a network in use, got %v", err)
+	}
+	if err = undoNics(); err != nil {
+		t.Fatalf("error undoing %s: %s", step.Details, err)
+	}
+	if section, err = vm.GetNetworkConnectionSection(); err != nil || len(section.NetworkConnection) != 0 {
+		t.Errorf("expected the NIC to be removed, got %v", err)
+	}
+	if err = undoNetwork(); err != nil {
+		t.Fatalf("error removing network: %s", err)
+	}
+	checkVAppNetworks()
+}
@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 	"github.com/vmware/go-vcloud-director/v3/util"
@@ -30,7 +33,7 @@ func NewVAppTemplate(cli *Client) *VAppTemplate {
 func (vdc *Vdc) InstantiateVAppTemplate(template *types.InstantiateVAppTemplateParams) error {
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting vdc href: %s", err)
+		return fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/instantiateVAppTemplate"
 
@@ -47,7 +50,7 @@ func (vdc *Vdc) InstantiateVAppTemplate(template *types.InstantiateVAppTemplateP
 		task.Task = taskItem
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return fmt.Errorf("error performing task: %s", err)
+			return fmt.Errorf("error performing task: %w", err)
 		}
 	}
 	return nil
@@ -135,11 +138,11 @@ func (vAppTemplate *VAppTemplate) Update() (*VAppTemplate, error) {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for task completion after updating vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+		return nil, fmt.Errorf("error waiting for task completion after updating vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
 	}
 	err = vAppTemplate.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+		return nil, fmt.Errorf("error refreshing vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
 	}
 	return vAppTemplate, nil
 }
@@ -193,7 +196,7 @@ func (vAppTemplate *VAppTemplate) Delete() error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error waiting for task completion after deleting vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+		return fmt.Errorf("error waiting for task completion after deleting vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
 	}
 	return nil
 }
@@ -228,7 +231,7 @@ func (vcdClient *VCDClient) QuerySynchronizedVAppTemplateById(vAppTemplateId str
 			";status!=FAILED_CREATION;status!=UNKNOWN;status!=UNRECOGNIZED;status!=UNRESOLVED;status!=LOCAL_COPY_UNAVAILABLE&links=true",
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("[QueryVAppTemplateById] error quering vApp templates with ID %s: %s", vAppTemplateId, err)
+		return nil, fmt.Errorf("[QueryVAppTemplateById] error quering vApp templates with ID %s: %w", vAppTemplateId, err)
 	}
 
 	vAppTemplateRecords := results.Results.VappTemplateRecord
@@ -262,7 +265,7 @@ func (vcdClient *VCDClient) QueryVmInVAppTemplateByHref(vAppTemplateHref, vmName
 			";isVAppTemplate==true;status!=FAILED_CREATION;status!=UNKNOWN;status!=UNRECOGNIZED;status!=UNRESOLVED&links=true;",
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("[QueryVmInVAppTemplateByHref] error quering vApp templates with HREF %s:, VM name: %s: Error: %s", vAppTemplateHref, vmNameInTemplate, err)
+		return nil, fmt.Errorf("[QueryVmInVAppTemplateByHref] error quering vApp templates with HREF %s:, VM name: %s: Error: %w", vAppTemplateHref, vmNameInTemplate, err)
 	}
 
 	vmResults := results.Results.VMRecord
@@ -327,12 +330,12 @@ func (vAppTemplate *VAppTemplate) RenewLease(storageLeaseInSeconds int) error {
 		types.MimeLeaseSettingSection, "error updating vAppTemplate lease : %s", &leaseSettings)
 
 	if err != nil {
-		return fmt.Errorf("unable to update vAppTemplate lease: %s", err)
+		return fmt.Errorf("unable to update vAppTemplate lease: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("task for updating vAppTemplate lease failed: %s", err)
+		return fmt.Errorf("task for updating vAppTemplate lease failed: %w", err)
 	}
 	return vAppTemplate.Refresh()
 }
@@ -384,3 +387,170 @@ func (vAppTemplate *VAppTemplate) GetCatalogItemId() (string, error) {
 
 	return fmt.Sprintf("urn:vcloud:catalogitem:%s", extractUuid(href)), nil
 }
+
+// DownloadOvf downloads the vApp template in OVF format to the directory dir, which is created if
+// it does not exist. See DownloadOvfWithProgress for details
+func (vAppTemplate *VAppTemplate) DownloadOvf(dir string) error {
+	return vAppTemplate.DownloadOvfWithProgress(dir, nil)
+}
+
+// DownloadOvfWithProgress downloads the vApp template in OVF format to the directory dir, which is
+// created if it does not exist. The OVF descriptor is saved with the name given by VCD (usually
+// descriptor.ovf), together with all the files listed in its References section.
+// The size of each file is checked against the descriptor. Files of dir that were partially
+// downloaded by a previous call are resumed. progressCallBack, when not nil, receives the
+// progress of the download of the referenced files.
+func (vAppTemplate *VAppTemplate) DownloadOvfWithProgress(dir string, progressCallBack DownloadProgressFunc) error {
+	_, err := vAppTemplate.downloadOvf(dir, progressCallBack)
+	return err
+}
+
+// DownloadOva downloads the vApp template as an OVA file to ovaPath. See DownloadOvaWithProgress
+// for details
+func (vAppTemplate *VAppTemplate) DownloadOva(ovaPath string) error {
+	return vAppTemplate.DownloadOvaWithProgress(ovaPath, nil)
+}
+
+// DownloadOvaWithProgress downloads the vApp template as an OVA file to ovaPath. The files are
+// first downloaded in OVF format to the directory ovaPath + ".download", which is removed once the
+// OVA is packed. If the download is interrupted, a new call resumes it from the files of that
+// directory. progressCallBack, when not nil, receives the progress of the download.
+func (vAppTemplate *VAppTemplate) DownloadOvaWithProgress(ovaPath string, progressCallBack DownloadProgressFunc) error {
+	downloadDir := ovaPath + ".download"
+	fileNames, err := vAppTemplate.downloadOvf(downloadDir, progressCallBack)
+	if err != nil {
+		return err
+	}
+
+	err = util.Pack(ovaPath, downloadDir, fileNames)
+	if err != nil {
+		return fmt.Errorf("error packing OVA %s: %w", ovaPath, err)
+	}
+	err = os.RemoveAll(downloadDir)
+	if err != nil {
+		util.Logger.Printf("[DEBUG] error removing download directory %s: %s\n", downloadDir, err)
+	}
+	return nil
+}
+
+// downloadOvf downloads the OVF descriptor and the files it references to dir and returns their
+// names, starting with the descriptor
+func (vAppTemplate *VAppTemplate) downloadOvf(dir string, progressCallBack DownloadProgressFunc) ([]string, error) {
+	if vAppTemplate.VAppTemplate == nil || vAppTemplate.VAppTemplate.HREF == "" {
+		return nil, fmt.Errorf("cannot download vApp template without HREF")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("error creating directory %s: %w", dir, err)
+	}
+
+	descriptorHref, err := vAppTemplate.enableDownload()
+	if err != nil {
+		return nil, err
+	}
+	defer vAppTemplate.disableDownload()
+
+	descriptorUrl, err := url.ParseRequestURI(descriptorHref)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OVF descriptor URL %s: %w", descriptorHref, err)
+	}
+	descriptorName := path.Base(descriptorUrl.Path)
+	if path.Ext(descriptorName) != ".ovf" {
+		descriptorName = "descriptor.ovf"
+	}
+	descriptorPath := filepath.Join(dir, descriptorName)
+	// The descriptor is always downloaded again, as it has no size to check against
+	_, err = downloadToFile(vAppTemplate.client, descriptorPath, downloadDetails{href: descriptorHref, expectedSize: -1, totalSize: -1})
+	if err != nil {
+		return nil, fmt.Errorf("error downloading OVF descriptor of vApp template %s: %w", vAppTemplate.VAppTemplate.Name, err)
+	}
+	ovfFileDesc, err := getOvf(descriptorPath)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OVF descriptor of vApp template %s: %w", vAppTemplate.VAppTemplate.Name, err)
+	}
+
+	totalSize := getAllFileSizeSum(&ovfFileDesc)
+	var downloadedBytes int64
+	fileNames := []string{descriptorName}
+	for _, fileItem := range ovfFileDesc.File {
+		// References are relative to the descriptor. They must not point outside the download directory
+		if !filepath.IsLocal(fileItem.HREF) || path.IsAbs(fileItem.HREF) {
+			return nil, fmt.Errorf("OVF descriptor references file %s outside of its directory", fileItem.HREF)
+		}
+
+		parts := []string{fileItem.HREF}
+		partSizes := []int64{int64(fileItem.Size)}
+		if fileItem.ChunkSize != 0 {
+			parts, partSizes = nil, nil
+			for i, chunkPath := range getChunkedFilePaths("", fileItem.HREF, fileItem.Size, fileItem.ChunkSize) {
+				parts = append(parts, chunkPath)
+				partSizes = append(partSizes, int64(min(fileItem.ChunkSize, fileItem.Size-i*fileItem.ChunkSize)))
+			}
+		}
+
+		for i, part := range parts {
+			partUrl := descriptorUrl.ResolveReference(&url.URL{Path: part})
+			size, err := downloadToFile(vAppTemplate.client, filepath.Join(dir, filepath.FromSlash(part)), downloadDetails{
+				href:           partUrl.String(),
+				expectedSize:   partSizes[i],
+				progressOffset: downloadedBytes,
+				totalSize:      totalSize,
+				callBack:       progressCallBack,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error downloading file %s of vApp template %s: %w", part, vAppTemplate.VAppTemplate.Name, err)
+			}
+			downloadedBytes += size
+			fileNames = append(fileNames, part)
+		}
+	}
+	return fileNames, nil
+}
+
+// enableDownload makes VCD prepare the vApp template files for download and returns the URL of the
+// OVF descriptor
+func (vAppTemplate *VAppTemplate) enableDownload() (string, error) {
+	enableLink := vAppTemplate.VAppTemplate.Link.Find(func(link *types.Link) bool {
+		return link != nil && link.Rel == types.RelEnable
+	})
+	if enableLink == nil {
+		return "", fmt.Errorf("no link to enable download found in vApp template %s", vAppTemplate.VAppTemplate.Name)
+	}
+
+	task, err := vAppTemplate.client.ExecuteTaskRequest(enableLink.HREF, http.MethodPost, "",
+		"error enabling download of vApp template: %s", nil)
+	if err != nil {
+		return "", err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return "", fmt.Errorf("error enabling download of vApp template %s: %w", vAppTemplate.VAppTemplate.Name, err)
+	}
+
+	err = vAppTemplate.Refresh()
+	if err != nil {
+		return "", err
+	}
+	downloadLink := vAppTemplate.VAppTemplate.Link.Find(func(link *types.Link) bool {
+		return link != nil && link.Rel == types.RelDownloadDefault
+	})
+	if downloadLink == nil {
+		return "", fmt.Errorf("no download URL found in vApp template %s", vAppTemplate.VAppTemplate.Name)
+	}
+	return downloadLink.HREF, nil
+}
+
+// disableDownload releases the files that VCD prepared for download. Errors are only logged, as the
+// files are released by VCD anyway after some time
+func (vAppTemplate *VAppTemplate) disableDownload() {
+	disableLink := vAppTemplate.VAppTemplate.Link.Find(func(link *types.Link) bool {
+		return link != nil && link.Rel == types.RelDisable
+	})
+	if disableLink == nil {
+		return
+	}
+	err := vAppTemplate.client.ExecuteRequestWithoutResponse(disableLink.HREF, http.MethodPost, "",
+		"error disabling download of vApp template: %s", nil)
+	if err != nil {
+		util.Logger.Printf("[DEBUG] %s\n", err)
+	}
+}
@@ -0,0 +1,112 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+func newTestVAppTemplate(t *testing.T, serverUrl string) *VAppTemplate {
+	vcdClient := testContextClient(t, serverUrl, WithRetryPolicy(testRetryPolicy))
+	vAppTemplate := NewVAppTemplate(&vcdClient.Client)
+	vAppTemplate.VAppTemplate.HREF = serverUrl + "/api/vAppTemplate/vappTemplate-1"
+	if err := vAppTemplate.Refresh(); err != nil {
+		t.Fatalf("error retrieving vApp template: %s", err)
+	}
+	return vAppTemplate
+}
+
+func TestVAppTemplate_DownloadOvf(t *testing.T) {
+	server := newDownloadTestServer(t)
+	vAppTemplate := newTestVAppTemplate(t, server.URL)
+
+	dir := filepath.Join(t.TempDir(), "template")
+	var lastDownloaded, lastTotal int64
+	err := vAppTemplate.DownloadOvfWithProgress(dir, func(bytesDownloaded, totalSize int64) {
+		if bytesDownloaded < lastDownloaded {
+			t.Errorf("progress went back from %d to %d", lastDownloaded, bytesDownloaded)
+		}
+		lastDownloaded, lastTotal = bytesDownloaded, totalSize
+	})
+	if err != nil {
+		t.Fatalf("error downloading OVF: %s", err)
+	}
+	if lastTotal != 100010 || lastDownloaded != lastTotal {
+		t.Errorf("expected progress to reach 100010 bytes, got %d of %d", lastDownloaded, lastTotal)
+	}
+	if !server.disabled {
+		t.Errorf("expected download to be disabled after completion")
+	}
+	for name, content := range server.files {
+		if !strings.HasPrefix(name, "/transfer/1/") {
+			continue
+		}
+		got, err := os.ReadFile(filepath.Join(dir, strings.TrimPrefix(name, "/transfer/1/")))
+		if err != nil {
+			t.Fatalf("error reading downloaded file: %s", err)
+		}
+		if !bytes.Equal(got, content) {
+			t.Errorf("unexpected content of %s", name)
+		}
+	}
+
+	// A file with the wrong size is detected
+	server.Lock()
+	server.files["/transfer/1/disk1.vmdk"] = []byte("too short")
+	server.Unlock()
+	if err = os.Remove(filepath.Join(dir, "disk1.vmdk")); err != nil {
+		t.Fatal(err)
+	}
+	if err = vAppTemplate.DownloadOvf(dir); err == nil || !strings.Contains(err.Error(), "expected 100000") {
+		t.Errorf("expected size mismatch error, got %v", err)
+	}
+}
+
+func TestVAppTemplate_DownloadOva(t *testing.T) {
+	server := newDownloadTestServer(t)
+	vAppTemplate := newTestVAppTemplate(t, server.URL)
+
+	ovaPath := filepath.Join(t.TempDir(), "template.ova")
+	// Part of a previous download is resumed
+	if err := os.MkdirAll(ovaPath+".download", 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ovaPath+".download", "disk1.vmdk"), server.files["/transfer/1/disk1.vmdk"][:1000], 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := vAppTemplate.DownloadOva(ovaPath); err != nil {
+		t.Fatalf("error downloading OVA: %s", err)
+	}
+	if _, err := os.Stat(ovaPath + ".download"); !os.IsNotExist(err) {
+		t.Errorf("expected download directory to be removed")
+	}
+
+	filePaths, dst, err := util.Unpack(ovaPath)
+	if err != nil {
+		t.Fatalf("error unpacking OVA: %s", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dst); err != nil {
+			t.Errorf("error removing %s: %s", dst, err)
+		}
+	}()
+	if len(filePaths) != 5 || filepath.Base(filePaths[0]) != "descriptor.ovf" {
+		t.Fatalf("expected the descriptor and 4 files in the OVA, got %v", filePaths)
+	}
+	for _, filePath := range filePaths {
+		got, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, server.files["/transfer/1/"+filepath.Base(filePath)]) {
+			t.Errorf("unexpected content of %s", filePath)
+		}
+	}
+}
@@ -44,7 +44,7 @@ func (vdc *Vdc) getVdcVAppbyHREF(vappHREF *url.URL) (*VApp, error) {
 func (vdc *Vdc) undeployAllVdcVApps() error {
 	err := vdc.Refresh()
 	if err != nil {
-		return fmt.Errorf("error refreshing vdc: %s", err)
+		return fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, resents := range vdc.Vdc.ResourceEntities {
 		for _, resent := range resents.ResourceEntity {
@@ -55,7 +55,7 @@ func (vdc *Vdc) undeployAllVdcVApps() error {
 				}
 				vapp, err := vdc.getVdcVAppbyHREF(vappHREF)
 				if err != nil {
-					return fmt.Errorf("error retrieving vapp with url: %s and with error %s", vappHREF.Path, err)
+					return fmt.Errorf("error retrieving vapp with url: %s and with error %w", vappHREF.Path, err)
 				}
 				task, err := vapp.Undeploy()
 				if err != nil {
@@ -78,7 +78,7 @@ func (vdc *Vdc) undeployAllVdcVApps() error {
 func (vdc *Vdc) removeAllVdcVApps() error {
 	err := vdc.Refresh()
 	if err != nil {
-		return fmt.Errorf("error refreshing vdc: %s", err)
+		return fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, resents := range vdc.Vdc.ResourceEntities {
 		for _, resent := range resents.ResourceEntity {
@@ -89,15 +89,15 @@ func (vdc *Vdc) removeAllVdcVApps() error {
 				}
 				vapp, err := vdc.getVdcVAppbyHREF(vappHREF)
 				if err != nil {
-					return fmt.Errorf("error retrieving vapp with url: %s and with error %s", vappHREF.Path, err)
+					return fmt.Errorf("error retrieving vapp with url: %s and with error %w", vappHREF.Path, err)
 				}
 				task, err := vapp.Delete()
 				if err != nil {
-					return fmt.Errorf("error deleting vapp: %s", err)
+					return fmt.Errorf("error deleting vapp: %w", err)
 				}
 				err = task.WaitTaskCompletion()
 				if err != nil {
-					return fmt.Errorf("couldn't finish removing vapp %s", err)
+					return fmt.Errorf("couldn't finish removing vapp %w", err)
 				}
 			}
 		}
@@ -138,7 +138,7 @@ func (vdc *Vdc) Delete(force bool, recursive bool) (Task, error) {
 
 	vdcUrl, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing vdc url: %s", err)
+		return Task{}, fmt.Errorf("error parsing vdc url: %w", err)
 	}
 
 	req := vdc.client.NewRequest(map[string]string{
@@ -147,11 +147,11 @@ func (vdc *Vdc) Delete(force bool, recursive bool) (Task, error) {
 	}, http.MethodDelete, *vdcUrl, nil)
 	resp, err := checkResp(vdc.client.Http.Do(req))
 	if err != nil {
-		return Task{}, fmt.Errorf("error deleting vdc: %s", err)
+		return Task{}, fmt.Errorf("error deleting vdc: %w", err)
 	}
 	task := NewTask(vdc.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding task response: %w", err)
 	}
 	if task.Task.Status == "error" {
 		return Task{}, fmt.Errorf("vdc not properly destroyed")
@@ -167,7 +167,7 @@ func (vdc *Vdc) DeleteWait(force bool, recursive bool) error {
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("couldn't finish removing vdc %s", err)
+		return fmt.Errorf("couldn't finish removing vdc %w", err)
 	}
 	return nil
 }
@@ -177,7 +177,7 @@ func (vdc *Vdc) FindVDCNetwork(network string) (OrgVDCNetwork, error) {
 
 	err := vdc.Refresh()
 	if err != nil {
-		return OrgVDCNetwork{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return OrgVDCNetwork{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, an := range vdc.Vdc.AvailableNetworks {
 		for _, reference := range an.Network {
@@ -216,7 +216,7 @@ func (vdc *Vdc) GetOrgVdcNetworkByName(name string, refresh bool) (*OrgVDCNetwor
 	if refresh {
 		err := vdc.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vdc: %s", err)
+			return nil, fmt.Errorf("error refreshing vdc: %w", err)
 		}
 	}
 	for _, an := range vdc.Vdc.AvailableNetworks {
@@ -236,7 +236,7 @@ func (vdc *Vdc) GetOrgVdcNetworkById(id string, refresh bool) (*OrgVDCNetwork, e
 	if refresh {
 		err := vdc.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vdc: %s", err)
+			return nil, fmt.Errorf("error refreshing vdc: %w", err)
 		}
 	}
 	for _, an := range vdc.Vdc.AvailableNetworks {
@@ -268,7 +268,7 @@ func (vdc *Vdc) FindStorageProfileReference(name string) (types.Reference, error
 
 	err := vdc.Refresh()
 	if err != nil {
-		return types.Reference{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return types.Reference{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, sp := range vdc.Vdc.VdcStorageProfiles.VdcStorageProfile {
 		if sp.Name == name {
@@ -284,7 +284,7 @@ func (vdc *Vdc) GetDefaultStorageProfileReference(storageprofiles *types.QueryRe
 
 	err := vdc.Refresh()
 	if err != nil {
-		return types.Reference{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return types.Reference{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, spr := range storageprofiles.OrgVdcStorageProfileRecord {
 		if spr.IsDefaultStorageProfile {
@@ -299,7 +299,7 @@ func (vdc *Vdc) FindEdgeGateway(edgegateway string) (EdgeGateway, error) {
 
 	err := vdc.Refresh()
 	if err != nil {
-		return EdgeGateway{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return EdgeGateway{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, av := range vdc.Vdc.Link {
 		if av.Rel == "edgeGateways" && av.Type == types.MimeQueryRecords {
@@ -418,7 +418,7 @@ func (vdc *Vdc) QueryEdgeGatewayList() ([]*types.QueryResultEdgeGatewayRecordTyp
 func (vdc *Vdc) GetEdgeGatewayRecordsType(refresh bool) (*types.QueryResultEdgeGatewayRecordsType, error) {
 	items, err := vdc.QueryEdgeGatewayList()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving edge gateway list: %s", err)
+		return nil, fmt.Errorf("error retrieving edge gateway list: %w", err)
 	}
 	return &types.QueryResultEdgeGatewayRecordsType{
 		Total:             float64(len(items)),
@@ -432,7 +432,7 @@ func (vdc *Vdc) GetEdgeGatewayRecordsType(refresh bool) (*types.QueryResultEdgeG
 func (vdc *Vdc) GetEdgeGatewayByName(name string, refresh bool) (*EdgeGateway, error) {
 	edgeGatewayList, err := vdc.QueryEdgeGatewayList()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving edge gateways list: %s", err)
+		return nil, fmt.Errorf("error retrieving edge gateways list: %w", err)
 	}
 
 	for _, edge := range edgeGatewayList {
@@ -450,7 +450,7 @@ func (vdc *Vdc) GetEdgeGatewayByName(name string, refresh bool) (*EdgeGateway, e
 func (vdc *Vdc) GetEdgeGatewayById(id string, refresh bool) (*EdgeGateway, error) {
 	edgeGatewayList, err := vdc.QueryEdgeGatewayList()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving edge gateways list: %s", err)
+		return nil, fmt.Errorf("error retrieving edge gateways list: %w", err)
 	}
 
 	for _, edge := range edgeGatewayList {
@@ -490,7 +490,7 @@ func (vdc *Vdc) ComposeRawVApp(name string, description string) error {
 
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting vdc href: %s", err)
+		return fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/composeVApp"
 
@@ -498,12 +498,12 @@ func (vdc *Vdc) ComposeRawVApp(name string, description string) error {
 	task, err := vdc.client.ExecuteTaskRequest(vdcHref.String(), http.MethodPost,
 		types.MimeComposeVappParams, "error instantiating a new vApp:: %s", vcomp)
 	if err != nil {
-		return fmt.Errorf("error executing task request: %s", err)
+		return fmt.Errorf("error executing task request: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error performing task: %s", err)
+		return fmt.Errorf("error performing task: %w", err)
 	}
 
 	return nil
@@ -523,7 +523,7 @@ func (vdc *Vdc) CreateRawVApp(name string, description string) (*VApp, error) {
 
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vdc href: %s", err)
+		return nil, fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/composeVApp"
 
@@ -532,7 +532,7 @@ func (vdc *Vdc) CreateRawVApp(name string, description string) (*VApp, error) {
 	_, err = vdc.client.ExecuteRequest(vdcHref.String(), http.MethodPost,
 		types.MimeComposeVappParams, "error instantiating a new vApp:: %s", vcomp, &vAppContents)
 	if err != nil {
-		return nil, fmt.Errorf("error executing task request: %s", err)
+		return nil, fmt.Errorf("error executing task request: %w", err)
 	}
 
 	if vAppContents.Tasks != nil {
@@ -542,7 +542,7 @@ func (vdc *Vdc) CreateRawVApp(name string, description string) (*VApp, error) {
 				task.Task = innerTask
 				err = task.WaitTaskCompletion()
 				if err != nil {
-					return nil, fmt.Errorf("error performing task: %s", err)
+					return nil, fmt.Errorf("error performing task: %w", err)
 				}
 			}
 		}
@@ -645,7 +645,7 @@ func (vdc *Vdc) ComposeVApp(orgvdcnetworks []*types.OrgVDCNetwork, vapptemplate
 
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error getting vdc href: %s", err)
+		return Task{}, fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/composeVApp"
 
@@ -661,7 +661,7 @@ func (vdc *Vdc) FindVAppByName(vapp string) (VApp, error) {
 
 	err := vdc.Refresh()
 	if err != nil {
-		return VApp{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return VApp{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 
 	for _, resents := range vdc.Vdc.ResourceEntities {
@@ -687,12 +687,12 @@ func (vdc *Vdc) FindVMByName(vapp VApp, vm string) (VM, error) {
 
 	err := vdc.Refresh()
 	if err != nil {
-		return VM{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return VM{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 
 	err = vapp.Refresh()
 	if err != nil {
-		return VM{}, fmt.Errorf("error refreshing vApp: %s", err)
+		return VM{}, fmt.Errorf("error refreshing vApp: %w", err)
 	}
 
 	//vApp Might Not Have Any VMs
@@ -740,7 +740,7 @@ func (vdc *Vdc) QueryVM(vappName, vmName string) (VMRecord, error) {
 		"filter":        "name==" + url.QueryEscape(vmName) + ";containerName==" + url.QueryEscape(vappName),
 		"filterEncoded": "true"})
 	if err != nil {
-		return VMRecord{}, fmt.Errorf("error querying vm %s", err)
+		return VMRecord{}, fmt.Errorf("error querying vm %w", err)
 	}
 
 	vmResults := results.Results.VMRecord
@@ -767,7 +767,7 @@ func (vdc *Vdc) FindVAppByID(vappid string) (VApp, error) {
 
 	err := vdc.Refresh()
 	if err != nil {
-		return VApp{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return VApp{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 
 	urnslice := strings.SplitAfter(vappid, ":")
@@ -848,7 +848,7 @@ func (vdc *Vdc) GetVAppByName(vappName string, refresh bool) (*VApp, error) {
 	if refresh {
 		err := vdc.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing VDC: %s", err)
+			return nil, fmt.Errorf("error refreshing VDC: %w", err)
 		}
 	}
 
@@ -869,7 +869,7 @@ func (vdc *Vdc) GetVAppById(id string, refresh bool) (*VApp, error) {
 	if refresh {
 		err := vdc.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing VDC: %s", err)
+			return nil, fmt.Errorf("error refreshing VDC: %w", err)
 		}
 	}
 
@@ -900,7 +900,7 @@ func (vdc *Vdc) GetVAppByNameOrId(identifier string, refresh bool) (*VApp, error
 func (vdc *Vdc) buildNsxvNetworkServiceEndpointURL(optionalSuffix string) (string, error) {
 	apiEndpoint, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return "", fmt.Errorf("unable to process vDC URL: %s", err)
+		return "", fmt.Errorf("unable to process vDC URL: %w", err)
 	}
 
 	hostname := apiEndpoint.Scheme + "://" + apiEndpoint.Host + "/network/services"
@@ -933,7 +933,7 @@ func (vdc *Vdc) QueryVappVmTemplate(catalogName, vappTemplateName, vmNameInTempl
 			";isVAppTemplate==true;status!=FAILED_CREATION;status!=UNKNOWN;status!=UNRECOGNIZED;status!=UNRESOLVED&links=true;",
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error quering all vApp templates: %s", err)
+		return nil, fmt.Errorf("error quering all vApp templates: %w", err)
 	}
 
 	vmResults := results.Results.VMRecord
@@ -1291,7 +1291,7 @@ func (vdc *Vdc) getParentOrg() (organization, error) {
 func (vdc *Vdc) CreateVappFromTemplate(template *types.InstantiateVAppTemplateParams) (*VApp, error) {
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VDC href: %s", err)
+		return nil, fmt.Errorf("error getting VDC href: %w", err)
 	}
 	vdcHref.Path += "/action/instantiateVAppTemplate"
 
@@ -1312,7 +1312,7 @@ func (vdc *Vdc) CreateVappFromTemplate(template *types.InstantiateVAppTemplatePa
 		task.Task = taskItem
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return nil, fmt.Errorf("error performing task: %s", err)
+			return nil, fmt.Errorf("error performing task: %w", err)
 		}
 	}
 	err = vapp.Refresh()
@@ -1326,7 +1326,7 @@ func (vdc *Vdc) CreateVappFromTemplate(template *types.InstantiateVAppTemplatePa
 func (vdc *Vdc) CloneVapp(sourceVapp *types.CloneVAppParams) (*VApp, error) {
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VDC href: %s", err)
+		return nil, fmt.Errorf("error getting VDC href: %w", err)
 	}
 	vdcHref.Path += "/action/cloneVApp"
 
@@ -1347,7 +1347,7 @@ func (vdc *Vdc) CloneVapp(sourceVapp *types.CloneVAppParams) (*VApp, error) {
 		task.Task = taskItem
 		err = task.WaitTaskCompletion()
 		if err != nil {
-			return nil, fmt.Errorf("error performing task: %s", err)
+			return nil, fmt.Errorf("error performing task: %w", err)
 		}
 	}
 	err = vapp.Refresh()
@@ -1372,7 +1372,7 @@ func (vdc *Vdc) GetHardwareVersion(name string) (*types.VirtualHardwareVersion,
 
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VDC href: %s", err)
+		return nil, fmt.Errorf("error getting VDC href: %w", err)
 	}
 	vdcHref.Path += "/hwv/" + name
 
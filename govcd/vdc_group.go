@@ -195,7 +195,7 @@ func (vdcGroup *VdcGroup) ForceDelete(force bool) error {
 
 	err = vdcGroup.client.OpenApiDeleteItem(minimumApiVersion, urlRef, params, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting VDC group (force %t): %s", force, err)
+		return fmt.Errorf("error deleting VDC group (force %t): %w", force, err)
 	}
 
 	return nil
@@ -408,7 +408,7 @@ func (vdcGroup *VdcGroup) GenericUpdate() (*VdcGroup, error) {
 	err = vdcGroup.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, vdcGroup.VdcGroup,
 		returnVdcGroup.VdcGroup, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC group: %s", err)
+		return nil, fmt.Errorf("error updating VDC group: %w", err)
 	}
 
 	return returnVdcGroup, nil
@@ -438,7 +438,7 @@ func (vdcGroup *VdcGroup) UpdateDfwPolicies(dfwPolicies types.DfwPolicies) (*Vdc
 	err = vdcGroup.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, dfwPolicies,
 		nil, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC group Dfw policies: %s", err)
+		return nil, fmt.Errorf("error updating VDC group Dfw policies: %w", err)
 	}
 
 	adminOrg := vdcGroup.parent.fullObject().(*AdminOrg)
@@ -470,7 +470,7 @@ func (vdcGroup *VdcGroup) UpdateDefaultDfwPolicies(defaultDfwPolicies types.Defa
 	err = vdcGroup.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, defaultDfwPolicies,
 		nil, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC group default DFW policies: %s", err)
+		return nil, fmt.Errorf("error updating VDC group default DFW policies: %w", err)
 	}
 
 	adminOrg := vdcGroup.parent.fullObject().(*AdminOrg)
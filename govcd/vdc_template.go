@@ -70,7 +70,7 @@ func (vcdClient *VCDClient) GetVdcTemplateById(id string) (*VdcTemplate, error)
 	resp, err := vcdClient.Client.executeJsonRequest(href.String(), http.MethodGet, nil, "error getting VDC Template: %s")
 	if err != nil {
 		if strings.Contains(err.Error(), "RESOURCE_NOT_FOUND") || strings.Contains(err.Error(), "not exist") {
-			return nil, fmt.Errorf("%s: %s", ErrorEntityNotFound, err)
+			return nil, fmt.Errorf("%w: %w", ErrorEntityNotFound, err)
 		}
 		return nil, err
 	}
@@ -107,7 +107,7 @@ func (vcdClient *VCDClient) GetVdcTemplateByName(name string) (*VdcTemplate, err
 	}
 	if vcdClient.Client.IsSysAdmin {
 		if len(results.Results.AdminOrgVdcTemplateRecord) == 0 {
-			return nil, fmt.Errorf("could not find any VDC Template with name '%s': %s", name, ErrorEntityNotFound)
+			return nil, fmt.Errorf("could not find any VDC Template with name '%s': %w", name, ErrorEntityNotFound)
 		}
 		if len(results.Results.AdminOrgVdcTemplateRecord) > 1 {
 			return nil, fmt.Errorf("expected one VDC Template with name '%s', but got %d", name, len(results.Results.AdminOrgVdcTemplateRecord))
@@ -115,7 +115,7 @@ func (vcdClient *VCDClient) GetVdcTemplateByName(name string) (*VdcTemplate, err
 		return vcdClient.GetVdcTemplateById(results.Results.AdminOrgVdcTemplateRecord[0].HREF)
 	} else {
 		if len(results.Results.OrgVdcTemplateRecord) == 0 {
-			return nil, fmt.Errorf("could not find any VDC Template with name '%s': %s", name, ErrorEntityNotFound)
+			return nil, fmt.Errorf("could not find any VDC Template with name '%s': %w", name, ErrorEntityNotFound)
 		}
 		if len(results.Results.OrgVdcTemplateRecord) > 1 {
 			return nil, fmt.Errorf("expected one VDC Template with name '%s', but got %d", name, len(results.Results.OrgVdcTemplateRecord))
@@ -246,14 +246,14 @@ func (vdcTemplate *VdcTemplate) InstantiateVdc(vdcName, description, organizatio
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("failed instantiating the VDC Template: %s", err)
+		return nil, fmt.Errorf("failed instantiating the VDC Template: %w", err)
 	}
 	if task.Task.Owner == nil || task.Task.Owner.HREF == "" {
 		return nil, fmt.Errorf("the VDC was instantiated but could not retrieve its ID from the finished task")
 	}
 	vdc, err := getVDCByHref(vdcTemplate.client, task.Task.Owner.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve the VDC from Task's HREF '%s': %s", task.Task.Owner.HREF, err)
+		return nil, fmt.Errorf("could not retrieve the VDC from Task's HREF '%s': %w", task.Task.Owner.HREF, err)
 	}
 	return &Vdc{
 		Vdc:    vdc,
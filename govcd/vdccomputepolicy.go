@@ -152,7 +152,7 @@ func (client *Client) CreateVdcComputePolicy(newVdcComputePolicy *types.VdcCompu
 
 	err = client.OpenApiPostItem(minimumApiVersion, urlRef, nil, newVdcComputePolicy, returnVdcComputePolicy.VdcComputePolicy, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating VDC compute policy: %s", err)
+		return nil, fmt.Errorf("error creating VDC compute policy: %w", err)
 	}
 
 	return returnVdcComputePolicy, nil
@@ -183,7 +183,7 @@ func (vdcComputePolicy *VdcComputePolicy) Update() (*VdcComputePolicy, error) {
 
 	err = vdcComputePolicy.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, vdcComputePolicy.VdcComputePolicy, returnVdcComputePolicy.VdcComputePolicy, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC compute policy: %s", err)
+		return nil, fmt.Errorf("error updating VDC compute policy: %w", err)
 	}
 
 	return returnVdcComputePolicy, nil
@@ -210,7 +210,7 @@ func (vdcComputePolicy *VdcComputePolicy) Delete() error {
 	err = vdcComputePolicy.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting VDC compute policy: %s", err)
+		return fmt.Errorf("error deleting VDC compute policy: %w", err)
 	}
 
 	return nil
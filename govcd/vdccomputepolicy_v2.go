@@ -147,7 +147,7 @@ func (vdcComputePolicy *VdcComputePolicyV2) Update() (*VdcComputePolicyV2, error
 
 	err = vdcComputePolicy.client.OpenApiPutItem(minimumApiVersion, urlRef, nil, vdcComputePolicy.VdcComputePolicyV2, returnVdcComputePolicy.VdcComputePolicyV2, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC Compute Policy: %s", err)
+		return nil, fmt.Errorf("error updating VDC Compute Policy: %w", err)
 	}
 
 	return returnVdcComputePolicy, nil
@@ -173,7 +173,7 @@ func (vdcComputePolicy *VdcComputePolicyV2) Delete() error {
 	err = vdcComputePolicy.client.OpenApiDeleteItem(minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting VDC Compute Policy: %s", err)
+		return fmt.Errorf("error deleting VDC Compute Policy: %w", err)
 	}
 
 	return nil
@@ -238,12 +238,12 @@ func (vdc *AdminVdc) SetAssignedComputePolicies(computePolicyReferences types.Vd
 
 	adminVdcPolicyHREF, err := url.ParseRequestURI(vdc.AdminVdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing VDC URL: %s", err)
+		return nil, fmt.Errorf("error parsing VDC URL: %w", err)
 	}
 
 	vdcId, err := GetUuidFromHref(vdc.AdminVdc.HREF, true)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get vdc ID from HREF: %s", err)
+		return nil, fmt.Errorf("unable to get vdc ID from HREF: %w", err)
 	}
 	adminVdcPolicyHREF.Path = "/api/admin/vdc/" + vdcId + "/computePolicies"
 
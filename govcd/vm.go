@@ -44,7 +44,7 @@ func NewVMRecord(cli *Client) *VMRecord {
 func (vm *VM) GetStatus() (string, error) {
 	err := vm.Refresh()
 	if err != nil {
-		return "", fmt.Errorf("error refreshing VM: %s", err)
+		return "", fmt.Errorf("error refreshing VM: %w", err)
 	}
 	return types.VAppStatuses[vm.VM.Status], nil
 }
@@ -53,7 +53,7 @@ func (vm *VM) GetStatus() (string, error) {
 func (vm *VM) IsDeployed() (bool, error) {
 	err := vm.Refresh()
 	if err != nil {
-		return false, fmt.Errorf("error refreshing VM: %s", err)
+		return false, fmt.Errorf("error refreshing VM: %w", err)
 	}
 	return vm.VM.Deployed, nil
 }
@@ -121,7 +121,7 @@ func (vm *VM) UpdateNetworkConnectionSection(networks *types.NetworkConnectionSe
 	// Retrieve current network configuration so that we are not altering any other internal fields
 	updateNetwork, err := vm.GetNetworkConnectionSection()
 	if err != nil {
-		return fmt.Errorf("cannot read network section for update: %s", err)
+		return fmt.Errorf("cannot read network section for update: %w", err)
 	}
 	updateNetwork.PrimaryNetworkConnectionIndex = networks.PrimaryNetworkConnectionIndex
 	updateNetwork.NetworkConnection = networks.NetworkConnection
@@ -135,7 +135,7 @@ func (vm *VM) UpdateNetworkConnectionSection(networks *types.NetworkConnectionSe
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error waiting for task completion after network update for vm %s: %s", vm.VM.Name, err)
+		return fmt.Errorf("error waiting for task completion after network update for vm %s: %w", vm.VM.Name, err)
 	}
 
 	return nil
@@ -172,7 +172,7 @@ func (vm *VM) PowerOnAndForceCustomization() error {
 	// PowerOnAndForceCustomization only works if the VM was previously un-deployed
 	vmIsDeployed, err := vm.IsDeployed()
 	if err != nil {
-		return fmt.Errorf("unable to check if VM %s is un-deployed forcing customization: %s",
+		return fmt.Errorf("unable to check if VM %s is un-deployed forcing customization: %w",
 			vm.VM.Name, err)
 	}
 
@@ -198,7 +198,7 @@ func (vm *VM) PowerOnAndForceCustomization() error {
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error waiting for task completion after power on with customization %s: %s", vm.VM.Name, err)
+		return fmt.Errorf("error waiting for task completion after power on with customization %s: %w", vm.VM.Name, err)
 	}
 
 	return nil
@@ -232,7 +232,7 @@ func (vm *VM) ChangeCPUCountWithCore(virtualCpuCount int, coresPerSocket *int) (
 
 	err := vm.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	newCpu := &types.OVFItem{
@@ -336,17 +336,17 @@ func (vm *VM) updateNicParameters(networks []map[string]interface{}, networkSect
 func (vm *VM) ChangeNetworkConfig(networks []map[string]interface{}) (Task, error) {
 	err := vm.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	networkSection, err := vm.GetNetworkConnectionSection()
 	if err != nil {
-		return Task{}, fmt.Errorf("could not retrieve network connection for VM: %s", err)
+		return Task{}, fmt.Errorf("could not retrieve network connection for VM: %w", err)
 	}
 
 	err = vm.updateNicParameters(networks, networkSection)
 	if err != nil {
-		return Task{}, fmt.Errorf("failed processing NIC parameters: %s", err)
+		return Task{}, fmt.Errorf("failed processing NIC parameters: %w", err)
 	}
 
 	networkSection.Xmlns = types.XMLNamespaceVCloud
@@ -366,7 +366,7 @@ func (vm *VM) ChangeMemorySize(size int) (Task, error) {
 
 	err := vm.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	newMem := &types.OVFItem{
@@ -438,7 +438,7 @@ func (vm *VM) BlockWhileGuestCustomizationStatus(unwantedStatus string, timeOutA
 		case <-tick.C:
 			currentStatus, err := vm.GetGuestCustomizationStatus()
 			if err != nil {
-				return fmt.Errorf("could not get VM customization status %s", err)
+				return fmt.Errorf("could not get VM customization status %w", err)
 			}
 			if currentStatus != unwantedStatus {
 				return nil
@@ -453,7 +453,7 @@ func (vm *VM) BlockWhileGuestCustomizationStatus(unwantedStatus string, timeOutA
 func (vm *VM) Customize(computerName, script string, changeSid bool) (Task, error) {
 	err := vm.Refresh()
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	vu := &types.GuestCustomizationSection{
@@ -607,18 +607,18 @@ func (vm *VM) HandleInsertMedia(org *Org, catalogName, mediaName string) (Task,
 func (vm *VM) HandleEjectMediaAndAnswer(org *Org, catalogName, mediaName string, answerYes bool) (*VM, error) {
 	task, err := vm.HandleEjectMedia(org, catalogName, mediaName)
 	if err != nil {
-		return nil, fmt.Errorf("error: %s", err)
+		return nil, fmt.Errorf("error: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(answerYes)
 	if err != nil {
-		return nil, fmt.Errorf("error: %s", err)
+		return nil, fmt.Errorf("error: %w", err)
 	}
 
 	for i := 0; i < 10; i++ {
 		err = vm.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error: %s", err)
+			return nil, fmt.Errorf("error: %w", err)
 		}
 		if !isMediaInjected(vm.VM.VirtualHardwareSection.Item) {
 			return vm, nil
@@ -743,7 +743,7 @@ func (vm *VM) GetQuestion() (types.VmPendingQuestion, error) {
 
 	resp, err := vm.client.Http.Do(req)
 	if err != nil {
-		return types.VmPendingQuestion{}, fmt.Errorf("error getting VM question: %s", err)
+		return types.VmPendingQuestion{}, fmt.Errorf("error getting VM question: %w", err)
 	}
 
 	// vCD security feature - on no question return 403 access error
@@ -759,7 +759,7 @@ func (vm *VM) GetQuestion() (types.VmPendingQuestion, error) {
 	question := &types.VmPendingQuestion{}
 
 	if err = decodeBody(types.BodyTypeXML, resp, question); err != nil {
-		return types.VmPendingQuestion{}, fmt.Errorf("error decoding question response: %s", err)
+		return types.VmPendingQuestion{}, fmt.Errorf("error decoding question response: %w", err)
 	}
 
 	// The request was successful
@@ -796,7 +796,7 @@ func (vm *VM) AnswerQuestion(questionId string, choiceId int) error {
 func (vm *VM) ToggleHardwareVirtualization(isEnabled bool) (Task, error) {
 	vmStatus, err := vm.GetStatus()
 	if err != nil {
-		return Task{}, fmt.Errorf("unable to toggle hardware virtualization: %s", err)
+		return Task{}, fmt.Errorf("unable to toggle hardware virtualization: %w", err)
 	}
 	if vmStatus != "POWERED_OFF" && vmStatus != "PARTIALLY_POWERED_OFF" {
 		return Task{}, fmt.Errorf("hardware virtualization can be changed from powered off state, status: %s", vmStatus)
@@ -820,7 +820,7 @@ func (vm *VM) ToggleHardwareVirtualization(isEnabled bool) (Task, error) {
 func (vm *VM) SetProductSectionList(productSection *types.ProductSectionList) (*types.ProductSectionList, error) {
 	err := setProductSectionList(vm.client, vm.VM.HREF, productSection)
 	if err != nil {
-		return nil, fmt.Errorf("unable to set VM product section: %s", err)
+		return nil, fmt.Errorf("unable to set VM product section: %w", err)
 	}
 
 	return vm.GetProductSectionList()
@@ -838,7 +838,7 @@ func (vm *VM) GetProductSectionList() (*types.ProductSectionList, error) {
 func (vm *VM) GetEnvironment() (*types.OvfEnvironment, error) {
 	vmStatus, err := vm.GetStatus()
 	if err != nil {
-		return nil, fmt.Errorf("unable to get OVF environment: %s", err)
+		return nil, fmt.Errorf("unable to get OVF environment: %w", err)
 	}
 
 	if vmStatus != "POWERED_ON" {
@@ -859,7 +859,7 @@ func (vm *VM) GetGuestCustomizationSection() (*types.GuestCustomizationSection,
 		types.MimeGuestCustomizationSection, "error retrieving guest customization section : %s", nil, guestCustomizationSection)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve guest customization section: %s", err)
+		return nil, fmt.Errorf("unable to retrieve guest customization section: %w", err)
 	}
 
 	return guestCustomizationSection, nil
@@ -878,12 +878,12 @@ func (vm *VM) SetGuestCustomizationSection(guestCustomizationSection *types.Gues
 		types.MimeGuestCustomizationSection, "error setting product section: %s", guestCustomizationSection)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to set guest customization section: %s", err)
+		return nil, fmt.Errorf("unable to set guest customization section: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("task for setting guest customization section failed: %s", err)
+		return nil, fmt.Errorf("task for setting guest customization section failed: %w", err)
 	}
 
 	return vm.GetGuestCustomizationSection()
@@ -900,12 +900,12 @@ func (vm *VM) UpgradeHardwareVersion() error {
 		types.AnyXMLMime, "error setting product section: %s", nil)
 
 	if err != nil {
-		return fmt.Errorf("unable to trigger VM Hardware Upgrade: %s", err)
+		return fmt.Errorf("unable to trigger VM Hardware Upgrade: %w", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("task for VM Hardware Upgrade failed: %s", err)
+		return fmt.Errorf("task for VM Hardware Upgrade failed: %w", err)
 	}
 
 	return nil
@@ -927,7 +927,7 @@ func (vm *VM) GetParentVApp() (*VApp, error) {
 			err := vapp.Refresh()
 
 			if err != nil {
-				return nil, fmt.Errorf("could not refresh parent vApp for VM %s: %s", vm.VM.Name, err)
+				return nil, fmt.Errorf("could not refresh parent vApp for VM %s: %w", vm.VM.Name, err)
 			}
 
 			return vapp, nil
@@ -945,12 +945,12 @@ func (vm *VM) GetParentVdc() (*Vdc, error) {
 
 	vapp, err := vm.GetParentVApp()
 	if err != nil {
-		return nil, fmt.Errorf("could not find parent vApp for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not find parent vApp for VM %s: %w", vm.VM.Name, err)
 	}
 
 	vdc, err := vapp.GetParentVDC()
 	if err != nil {
-		return nil, fmt.Errorf("could not find parent vApp for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not find parent vApp for VM %s: %w", vm.VM.Name, err)
 	}
 
 	return &vdc, nil
@@ -962,13 +962,13 @@ func (vm *VM) getEdgeGatewaysForRoutedNics(nicDhcpConfigs []nicDhcpConfig) ([]ni
 	// Lookup parent vDC for VM
 	vdc, err := vm.GetParentVdc()
 	if err != nil {
-		return nil, fmt.Errorf("could not find parent vDC for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not find parent vDC for VM %s: %w", vm.VM.Name, err)
 	}
 
 	for index, nic := range nicDhcpConfigs {
 		edgeGatewayName, err := vm.getEdgeGatewayNameForNic(nic.vmNicIndex)
 		if err != nil && !IsNotFound(err) {
-			return nil, fmt.Errorf("could not validate if NIC %d uses routed network attached to edge gateway: %s",
+			return nil, fmt.Errorf("could not validate if NIC %d uses routed network attached to edge gateway: %w",
 				nic.vmNicIndex, err)
 		}
 
@@ -984,7 +984,7 @@ func (vm *VM) getEdgeGatewaysForRoutedNics(nicDhcpConfigs []nicDhcpConfig) ([]ni
 				continue
 			}
 			if err != nil {
-				return nil, fmt.Errorf("could not lookup edge gateway for routed network on NIC %d: %s",
+				return nil, fmt.Errorf("could not lookup edge gateway for routed network on NIC %d: %w",
 					nic.vmNicIndex, err)
 			}
 
@@ -1072,7 +1072,7 @@ func (vm *VM) WaitForDhcpIpByNicIndexes(nicIndexes []int, maxWaitSeconds int, us
 		// Lookup edge gateways for routed networks and store them
 		nicStates, err = vm.getEdgeGatewaysForRoutedNics(nicStates)
 		if err != nil {
-			return []string{}, false, fmt.Errorf("unable to validate if NICs are attached to edge gateway: %s", err)
+			return []string{}, false, fmt.Errorf("unable to validate if NICs are attached to edge gateway: %w", err)
 		}
 	}
 
@@ -1093,7 +1093,7 @@ func (vm *VM) WaitForDhcpIpByNicIndexes(nicIndexes []int, maxWaitSeconds int, us
 			// Also populate MAC addresses into nicStates structure for later usage.
 			nicStates, err = vm.getIpsMacsByNicIndexes(nicStates)
 			if err != nil {
-				return []string{}, false, fmt.Errorf("could not check IP addresses assigned to VM %s: %s",
+				return []string{}, false, fmt.Errorf("could not check IP addresses assigned to VM %s: %w",
 					vm.VM.Name, err)
 			}
 
@@ -1111,7 +1111,7 @@ func (vm *VM) WaitForDhcpIpByNicIndexes(nicIndexes []int, maxWaitSeconds int, us
 			if useNsxvDhcpLeaseCheck {
 				nicStates, err = vm.getIpsByDhcpLeaseMacs(nicStates)
 				if err != nil {
-					return []string{}, false, fmt.Errorf("could not check MAC leases for VM '%s': %s",
+					return []string{}, false, fmt.Errorf("could not check MAC leases for VM '%s': %w",
 						vm.VM.Name, err)
 				}
 
@@ -1137,7 +1137,7 @@ func (vm *VM) getEdgeGatewayNameForNic(nicIndex int) (string, error) {
 
 	networkConnnectionSection, err := vm.GetNetworkConnectionSection()
 	if err != nil {
-		return "", fmt.Errorf("could not get IP address for NIC %d: %s", nicIndex, err)
+		return "", fmt.Errorf("could not get IP address for NIC %d: %w", nicIndex, err)
 	}
 
 	// Find NIC
@@ -1155,12 +1155,12 @@ func (vm *VM) getEdgeGatewayNameForNic(nicIndex int) (string, error) {
 	// Validate if the VM is attached to routed org vdc network
 	vdc, err := vm.GetParentVdc()
 	if err != nil {
-		return "", fmt.Errorf("could not find parent vDC for VM %s: %s", vm.VM.Name, err)
+		return "", fmt.Errorf("could not find parent vDC for VM %s: %w", vm.VM.Name, err)
 	}
 
 	edgeGatewayName, err := vdc.FindEdgeGatewayNameByNetwork(networkConnection.Network)
 	if err != nil && !IsNotFound(err) {
-		return "", fmt.Errorf("could not find edge gateway name for network %s: %s",
+		return "", fmt.Errorf("could not find edge gateway name for network %s: %w",
 			networkConnection.Network, err)
 	}
 
@@ -1191,7 +1191,7 @@ func (vm *VM) getIpsByDhcpLeaseMacs(nicConfigs []nicDhcpConfig) ([]nicDhcpConfig
 		if dhcpLeaseCache[egw.EdgeGateway.Name] == nil {
 			dhcpLeaseCache[egw.EdgeGateway.Name], err = egw.GetAllNsxvDhcpLeases()
 			if err != nil && !IsNotFound(err) {
-				return nicConfigs, fmt.Errorf("unable to get DHCP leases for edge gateway %s: %s",
+				return nicConfigs, fmt.Errorf("unable to get DHCP leases for edge gateway %s: %w",
 					egw.EdgeGateway.Name, err)
 			}
 		}
@@ -1213,7 +1213,7 @@ func (vm *VM) getIpsByDhcpLeaseMacs(nicConfigs []nicDhcpConfig) ([]nicDhcpConfig
 func (vm *VM) getIpsMacsByNicIndexes(nicConfigs []nicDhcpConfig) ([]nicDhcpConfig, error) {
 	networkConnnectionSection, err := vm.GetNetworkConnectionSection()
 	if err != nil {
-		return nil, fmt.Errorf("could not get IP configuration for VM %s : %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not get IP configuration for VM %s : %w", vm.VM.Name, err)
 	}
 
 	// Find NICs and populate their IPs and MACs
@@ -1235,7 +1235,7 @@ func (vm *VM) getIpsMacsByNicIndexes(nicConfigs []nicDhcpConfig) ([]nicDhcpConfi
 func (vm *VM) AddInternalDisk(diskData *types.DiskSettings) (string, error) {
 	err := vm.Refresh()
 	if err != nil {
-		return "", fmt.Errorf("error refreshing VM: %s", err)
+		return "", fmt.Errorf("error refreshing VM: %w", err)
 	}
 
 	err = vm.validateInternalDiskInput(diskData, vm.VM.Name, vm.VM.ID)
@@ -1310,7 +1310,7 @@ func (vm *VM) GetInternalDiskById(diskId string, refresh bool) (*types.DiskSetti
 	if refresh {
 		err := vm.Refresh()
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing VM: %s", err)
+			return nil, fmt.Errorf("error refreshing VM: %w", err)
 		}
 	}
 
@@ -1333,7 +1333,7 @@ func (vm *VM) GetInternalDiskById(diskId string, refresh bool) (*types.DiskSetti
 func (vm *VM) DeleteInternalDisk(diskId string) error {
 	err := vm.Refresh()
 	if err != nil {
-		return fmt.Errorf("error refreshing VM: %s", err)
+		return fmt.Errorf("error refreshing VM: %w", err)
 	}
 
 	diskSettings := vm.VM.VmSpecSection.DiskSection.DiskSettings
@@ -1360,7 +1360,7 @@ func (vm *VM) DeleteInternalDisk(diskId string) error {
 
 	_, err = vm.UpdateInternalDisks(vmSpecSection)
 	if err != nil {
-		return fmt.Errorf("error deleting VM %s internal disk %s: %s", vm.VM.Name, diskId, err)
+		return fmt.Errorf("error deleting VM %s internal disk %s: %w", vm.VM.Name, diskId, err)
 	}
 
 	return nil
@@ -1674,7 +1674,7 @@ func (client *Client) QueryVmList(filter types.VmQueryFilter) ([]*types.QueryRes
 	}
 	vmResult, err := client.cumulativeQuery(queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VM list : %s", err)
+		return nil, fmt.Errorf("error getting VM list : %w", err)
 	}
 	vmList = vmResult.Results.VMRecord
 	if client.IsSysAdmin {
@@ -1718,7 +1718,7 @@ func queryVmList(filter types.VmQueryFilter, client *Client, filterParent, filte
 	}
 	vmResult, err := client.cumulativeQuery(queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VM list : %s", err)
+		return nil, fmt.Errorf("error getting VM list : %w", err)
 	}
 	vmList = vmResult.Results.VMRecord
 	if client.IsSysAdmin {
@@ -1747,7 +1747,7 @@ func QueryVmList(vmType types.VmQueryFilter, client *Client, searchParams map[st
 	params["filter"] = filterText
 	vmResult, err := client.cumulativeQuery(queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VM list : %s", err)
+		return nil, fmt.Errorf("error getting VM list : %w", err)
 	}
 	vmList = vmResult.Results.VMRecord
 	if client.IsSysAdmin {
@@ -1860,7 +1860,7 @@ func addEmptyVmV10(vapp *VApp, reComposeVAppParams *types.RecomposeVAppParamsFor
 
 	err = vapp.Refresh()
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing vApp: %s", err)
+		return nil, fmt.Errorf("error refreshing vApp: %w", err)
 	}
 
 	//vApp Might Not Have Any VMs
@@ -2063,7 +2063,7 @@ func (vm *VM) ChangeMemory(sizeInMb int64) error {
 
 	_, err := vm.UpdateVmSpecSection(vmSpecSection, description)
 	if err != nil {
-		return fmt.Errorf("error changing memory size: %s", err)
+		return fmt.Errorf("error changing memory size: %w", err)
 	}
 	return nil
 }
@@ -2085,7 +2085,7 @@ func (vm *VM) ChangeCPU(cpus, cpuCores int) error {
 
 	_, err := vm.UpdateVmSpecSection(vmSpecSection, description)
 	if err != nil {
-		return fmt.Errorf("error changing cpu size: %s", err)
+		return fmt.Errorf("error changing cpu size: %w", err)
 	}
 	return nil
 }
@@ -2104,7 +2104,7 @@ func (vm *VM) ChangeCPUAndCoreCount(cpus, cpuCores *int) error {
 
 	_, err := vm.UpdateVmSpecSection(vmSpecSection, description)
 	if err != nil {
-		return fmt.Errorf("error changing CPU size: %s", err)
+		return fmt.Errorf("error changing CPU size: %w", err)
 	}
 	return nil
 }
@@ -2219,12 +2219,12 @@ func (vm *VM) updateExtraConfig(update []*types.ExtraConfigMarshal, wantDelete b
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return nil, fmt.Errorf("error waiting task: %s", err)
+		return nil, fmt.Errorf("error waiting task: %w", err)
 	}
 
 	xtraCfg, err := vm.GetExtraConfig()
 	if err != nil {
-		return nil, fmt.Errorf("got error while retrieving extra config: %s", err)
+		return nil, fmt.Errorf("got error while retrieving extra config: %w", err)
 	}
 
 	return xtraCfg, nil
@@ -2242,3 +2242,56 @@ func convertExtraConfig(source []*types.ExtraConfig) []*types.ExtraConfigMarshal
 
 	return resp
 }
+
+// CreateSnapshotAsync triggers creation of a VM snapshot and returns the task.
+// * name - name of the snapshot
+// * memory - whether the snapshot should include the memory of a powered on VM
+// * quiesce - whether the file system of a powered on VM should be quiesced (requires VMware Tools)
+//
+// Note. A VM can only have a single snapshot. Creating a new one replaces the existing snapshot.
+func (vm *VM) CreateSnapshotAsync(name string, memory, quiesce bool) (Task, error) {
+	return createSnapshotAsync(vm.client, vm.VM.HREF, name, memory, quiesce)
+}
+
+// CreateSnapshot creates a VM snapshot and waits until the task is completed
+func (vm *VM) CreateSnapshot(name string, memory, quiesce bool) error {
+	task, err := vm.CreateSnapshotAsync(name, memory, quiesce)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// RevertToCurrentSnapshotAsync triggers reverting the VM to its current snapshot and returns the task
+func (vm *VM) RevertToCurrentSnapshotAsync() (Task, error) {
+	return revertToCurrentSnapshotAsync(vm.client, vm.VM.HREF)
+}
+
+// RevertToCurrentSnapshot reverts the VM to its current snapshot and waits until the task is completed
+func (vm *VM) RevertToCurrentSnapshot() error {
+	task, err := vm.RevertToCurrentSnapshotAsync()
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// RemoveAllSnapshotsAsync triggers removal of all VM snapshots and returns the task
+func (vm *VM) RemoveAllSnapshotsAsync() (Task, error) {
+	return removeAllSnapshotsAsync(vm.client, vm.VM.HREF)
+}
+
+// RemoveAllSnapshots removes all VM snapshots and waits until the task is completed
+func (vm *VM) RemoveAllSnapshots() error {
+	task, err := vm.RemoveAllSnapshotsAsync()
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// GetSnapshotSection retrieves the snapshot section of a VM. Field `Snapshot` is empty when the VM
+// has no snapshot.
+func (vm *VM) GetSnapshotSection() (*types.SnapshotSection, error) {
+	return getSnapshotSection(vm.client, vm.VM.HREF)
+}
@@ -182,7 +182,7 @@ func validateAffinityRule(client *Client, affinityRuleDef *types.VmAffinityRule,
 	if checkVMs {
 		vmList, err := client.QueryVmList(types.VmQueryFilterOnlyDeployed)
 		if err != nil {
-			return nil, fmt.Errorf("error getting VM list : %s", err)
+			return nil, fmt.Errorf("error getting VM list : %w", err)
 		}
 		for _, vm := range vmList {
 			allVmMap[extractUuid(vm.HREF)] = true
@@ -234,7 +234,7 @@ func (vdc *Vdc) CreateVmAffinityRuleAsync(affinityRuleDef *types.VmAffinityRule)
 	// We validate the input, without a strict check on the VMs
 	affinityRuleDef, err = validateAffinityRule(vdc.client, affinityRuleDef, false)
 	if err != nil {
-		return Task{}, fmt.Errorf("[CreateVmAffinityRuleAsync] %s", err)
+		return Task{}, fmt.Errorf("[CreateVmAffinityRuleAsync] %w", err)
 	}
 
 	affinityRuleDef.Xmlns = types.XMLNamespaceVCloud
@@ -253,7 +253,7 @@ func (vdc *Vdc) CreateVmAffinityRuleAsync(affinityRuleDef *types.VmAffinityRule)
 		if validationErr != nil {
 			// If we get any error from the validation now, it should be an invalid VM,
 			// so we combine the original error with the validation error
-			return Task{}, fmt.Errorf("%s - %s", err, validationErr)
+			return Task{}, fmt.Errorf("%w - %w", err, validationErr)
 		}
 		// If the validation error is nil, we return just the original error
 		return Task{}, err
@@ -279,7 +279,7 @@ func (vdc *Vdc) CreateVmAffinityRule(affinityRuleDef *types.VmAffinityRule) (*Vm
 	// Retrieving the newly created rule using the ID from the task
 	vmAffinityRule, err := vdc.GetVmAffinityRuleById(ruleId)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving VmAffinityRule %s using ID %s: %s", affinityRuleDef.Name, ruleId, err)
+		return nil, fmt.Errorf("error retrieving VmAffinityRule %s using ID %s: %w", affinityRuleDef.Name, ruleId, err)
 	}
 	return vmAffinityRule, nil
 }
@@ -337,7 +337,7 @@ func (vmar *VmAffinityRule) Update() error {
 	// We validate the input, without a strict check on the VMs
 	affinityRuleDef, err = validateAffinityRule(vmar.client, vmar.VmAffinityRule, false)
 	if err != nil {
-		return fmt.Errorf("[Update] %s", err)
+		return fmt.Errorf("[Update] %w", err)
 	}
 	vmar.VmAffinityRule = affinityRuleDef
 
@@ -358,7 +358,7 @@ func (vmar *VmAffinityRule) Update() error {
 		// If we get any error from the validation now, it should be an invalid VM,
 		// so we combine the original error with the validation error
 		if validationErr != nil {
-			return fmt.Errorf("%s - %s", err, validationErr)
+			return fmt.Errorf("%w - %w", err, validationErr)
 		}
 		// If the validation error is nil, we return just the original error
 		return err
@@ -0,0 +1,134 @@
+package govcd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// VmScreenTicket is the parsed form of the ticket returned by VM.AcquireTicket
+type VmScreenTicket struct {
+	Host   string // Address of the host that serves the console
+	Port   int    // Port of the host. It is 0 when the ticket does not specify it
+	VmId   string // Managed object reference of the VM in vCenter (e.g. vm-123)
+	Ticket string // One time ticket that authorizes the console connection
+	Url    string // The ticket as returned by VCD, in the form mks://host/vm-moref/ticket
+}
+
+// AcquireMksTicket retrieves a ticket to open a remote console of the VM with the MKS or WebMKS
+// protocols. The VM must be powered on.
+// Use WebMksUrl to build the URL of the WebMKS websocket from the ticket
+func (vm *VM) AcquireMksTicket() (*types.MksTicket, error) {
+	href, err := vm.screenActionHref(types.RelScreenAcquireMksTicket, "acquireMksTicket")
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := &types.MksTicket{}
+	err = vm.acquireScreenTicket(href, types.MimeMksTicket, ticket)
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring MKS ticket: %w", err)
+	}
+	return ticket, nil
+}
+
+// AcquireTicket retrieves a screen ticket to open a remote console of the VM with the VMRC
+// plugin. The VM must be powered on.
+func (vm *VM) AcquireTicket() (*VmScreenTicket, error) {
+	href, err := vm.screenActionHref(types.RelScreenAcquireTicket, "acquireTicket")
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := &types.ScreenTicket{}
+	err = vm.acquireScreenTicket(href, types.MimeScreenTicket, ticket)
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring screen ticket: %w", err)
+	}
+	return parseScreenTicket(ticket.Value)
+}
+
+// screenActionHref returns the HREF of the screen action with the given link relation. When the
+// VM has no such link, it builds the HREF from the VM HREF, as VCD only lists the link for powered
+// on VMs
+func (vm *VM) screenActionHref(rel, action string) (string, error) {
+	if vm.VM == nil || vm.VM.HREF == "" {
+		return "", fmt.Errorf("cannot acquire a console ticket for a VM without HREF")
+	}
+	link := vm.VM.Link.Find(func(link *types.Link) bool {
+		return link != nil && link.Rel == rel
+	})
+	if link != nil {
+		return link.HREF, nil
+	}
+	return strings.TrimSuffix(vm.VM.HREF, "/") + "/screen/action/" + action, nil
+}
+
+// acquireScreenTicket posts to a screen action, accepting only the media type of the ticket
+func (vm *VM) acquireScreenTicket(href, mediaType string, ticket any) error {
+	ticketUrl, err := url.ParseRequestURI(href)
+	if err != nil {
+		return fmt.Errorf("error parsing HREF %s: %w", href, err)
+	}
+	apiVersion := vm.client.APIVersion
+	req := vm.client.newRequest(nil, nil, http.MethodPost, *ticketUrl, nil, apiVersion,
+		http.Header{"Accept": {mediaType + ";version=" + apiVersion}})
+	resp, err := checkResp(vm.client.Http.Do(req))
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+	return decodeBody(types.BodyTypeXML, resp, ticket)
+}
+
+// parseScreenTicket parses a screen ticket in the form mks://host[:port]/vm-moref/ticket. VCD can
+// return the ticket URL encoded
+func parseScreenTicket(value string) (*VmScreenTicket, error) {
+	value = strings.TrimSpace(value)
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+	ticketUrl, found := strings.CutPrefix(value, "mks://")
+	if !found {
+		return nil, fmt.Errorf("screen ticket '%s' is not an mks:// URL", value)
+	}
+	parts := strings.SplitN(ticketUrl, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("screen ticket '%s' is not in the form mks://host/vm-moref/ticket", value)
+	}
+
+	result := &VmScreenTicket{Host: parts[0], VmId: parts[1], Ticket: parts[2], Url: value}
+	if host, port, err := net.SplitHostPort(parts[0]); err == nil {
+		result.Host = host
+		result.Port, err = strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("screen ticket '%s' has an invalid port: %w", value, err)
+		}
+	}
+	return result, nil
+}
+
+// WebMksUrl returns the URL of the websocket that a WebMKS client (e.g. the wmks.js library) opens
+// to show the remote console of a VM, in the form wss://host/port;ticket
+// The ticket is valid only once and for a short time, therefore the URL must be used right away.
+func WebMksUrl(ticket *types.MksTicket) (string, error) {
+	if ticket == nil {
+		return "", fmt.Errorf("MKS ticket cannot be nil")
+	}
+	if ticket.Host == "" || ticket.Ticket == "" {
+		return "", fmt.Errorf("MKS ticket must have a host and a ticket")
+	}
+	if ticket.Port <= 0 {
+		return "", fmt.Errorf("MKS ticket has an invalid port %d", ticket.Port)
+	}
+	host := ticket.Host
+	if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+		host = "[" + host + "]"
+	}
+	return fmt.Sprintf("wss://%s/%d;%s", host, ticket.Port, ticket.Ticket), nil
+}
@@ -0,0 +1,127 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func TestVM_AcquireConsoleTickets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		accept := r.Header.Get("Accept")
+		switch r.URL.Path {
+		case "/api/vApp/vm-1/screen/action/acquireMksTicket":
+			if accept != types.MimeMksTicket+";version=37.0" {
+				t.Errorf("unexpected Accept header %s", accept)
+			}
+			w.Header().Set("Content-Type", types.MimeMksTicket)
+			_, _ = w.Write([]byte(`<MksTicket xmlns="http://www.vmware.com/vcloud/v1.5">` +
+				`<Host>console.example.com</Host><Vmx>[datastore1] vm1/vm1.vmx</Vmx>` +
+				`<Ticket>cst-ticket</Ticket><Port>902</Port></MksTicket>`))
+		case "/api/vApp/vm-1/screen/action/acquireTicket":
+			if accept != types.MimeScreenTicket+";version=37.0" {
+				t.Errorf("unexpected Accept header %s", accept)
+			}
+			w.Header().Set("Content-Type", types.MimeScreenTicket)
+			_, _ = w.Write([]byte(`<ScreenTicket xmlns="http://www.vmware.com/vcloud/v1.5">` +
+				`mks://esx.example.com%3A902/vm-123/cst%2Fticket</ScreenTicket>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	vcdClient := testContextClient(t, server.URL)
+	vm := NewVM(&vcdClient.Client)
+	vm.VM.HREF = server.URL + "/api/vApp/vm-1"
+	// The link is used when present, otherwise the HREF is built from the VM HREF
+	vm.VM.Link = types.LinkList{{
+		Rel:  types.RelScreenAcquireMksTicket,
+		HREF: server.URL + "/api/vApp/vm-1/screen/action/acquireMksTicket",
+		Type: types.MimeMksTicket,
+	}}
+
+	mksTicket, err := vm.AcquireMksTicket()
+	if err != nil {
+		t.Fatalf("error acquiring MKS ticket: %s", err)
+	}
+	if mksTicket.Host != "console.example.com" || mksTicket.Port != 902 || mksTicket.Ticket != "cst-ticket" {
+		t.Errorf("unexpected MKS ticket %#v", mksTicket)
+	}
+	webMksUrl, err := WebMksUrl(mksTicket)
+	if err != nil {
+		t.Fatalf("error building WebMKS URL: %s", err)
+	}
+	if webMksUrl != "wss://console.example.com/902;cst-ticket" {
+		t.Errorf("unexpected WebMKS URL %s", webMksUrl)
+	}
+
+	screenTicket, err := vm.AcquireTicket()
+	if err != nil {
+		t.Fatalf("error acquiring screen ticket: %s", err)
+	}
+	expected := VmScreenTicket{
+		Host:   "esx.example.com",
+		Port:   902,
+		VmId:   "vm-123",
+		Ticket: "cst/ticket",
+		Url:    "mks://esx.example.com:902/vm-123/cst/ticket",
+	}
+	if *screenTicket != expected {
+		t.Errorf("expected screen ticket %#v, got %#v", expected, *screenTicket)
+	}
+
+	vm.VM.HREF = server.URL + "/api/vApp/vm-2"
+	vm.VM.Link = nil
+	if _, err = vm.AcquireMksTicket(); err == nil {
+		t.Errorf("expected an error for a VM that does not exist")
+	}
+}
+
+func TestWebMksUrl(t *testing.T) {
+	tests := []struct {
+		ticket   *types.MksTicket
+		expected string
+	}{
+		{&types.MksTicket{Host: "10.0.0.1", Port: 443, Ticket: "abc"}, "wss://10.0.0.1/443;abc"},
+		{&types.MksTicket{Host: "fd00::1", Port: 902, Ticket: "abc"}, "wss://[fd00::1]/902;abc"},
+		{&types.MksTicket{Host: "10.0.0.1", Ticket: "abc"}, ""},
+		{&types.MksTicket{Port: 902, Ticket: "abc"}, ""},
+		{nil, ""},
+	}
+	for _, test := range tests {
+		got, err := WebMksUrl(test.ticket)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("expected an error for ticket %#v, got %s", test.ticket, got)
+			}
+			continue
+		}
+		if err != nil || got != test.expected {
+			t.Errorf("expected %s, got %s (error: %v)", test.expected, got, err)
+		}
+	}
+}
+
+func Test_parseScreenTicket(t *testing.T) {
+	for _, value := range []string{"", "https://host/vm-1/ticket", "mks://host/vm-1", "mks://host:abc/vm-1/ticket"} {
+		if _, err := parseScreenTicket(value); err == nil {
+			t.Errorf("expected an error parsing '%s'", value)
+		}
+	}
+	ticket, err := parseScreenTicket(" mks://host/vm-1/ticket\n")
+	if err != nil {
+		t.Fatalf("error parsing screen ticket: %s", err)
+	}
+	if ticket.Host != "host" || ticket.Port != 0 || ticket.VmId != "vm-1" || ticket.Ticket != "ticket" {
+		t.Errorf("unexpected screen ticket %#v", ticket)
+	}
+}
@@ -37,11 +37,11 @@ func (vcdClient *VCDClient) GetVmGroupByNamedVmGroupIdAndProviderVdcUrn(namedVmG
 	id := extractUuid(namedVmGroupId)
 	resourcePools, err := getResourcePools(vcdClient, pvdcUrn)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %s", id, err)
+		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %w", id, err)
 	}
 	filter, err := buildFilterForVmGroups(resourcePools, "namedVmGroupId", id)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %s", id, err)
+		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %w", id, err)
 	}
 	return getVmGroupWithFilter(vcdClient, filter)
 }
@@ -52,11 +52,11 @@ func (vcdClient *VCDClient) GetVmGroupByNamedVmGroupIdAndProviderVdcUrn(namedVmG
 func (vcdClient *VCDClient) GetVmGroupByNameAndProviderVdcUrn(name, pvdcUrn string) (*VmGroup, error) {
 	resourcePools, err := getResourcePools(vcdClient, pvdcUrn)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %s", name, err)
+		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %w", name, err)
 	}
 	filter, err := buildFilterForVmGroups(resourcePools, "vmGroupName", name)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %s", name, err)
+		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %w", name, err)
 	}
 	return getVmGroupWithFilter(vcdClient, filter)
 }
@@ -115,7 +115,7 @@ func (vcdClient *VCDClient) GetLogicalVmGroupById(logicalVmGroupId string) (*Log
 
 	err = vcdClient.Client.OpenApiGetItem(apiVersion, urlRef, nil, result.LogicalVmGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting Logical VM Group: %s", err)
+		return nil, fmt.Errorf("error getting Logical VM Group: %w", err)
 	}
 
 	return result, nil
@@ -142,7 +142,7 @@ func (vcdClient *VCDClient) CreateLogicalVmGroup(logicalVmGroup types.LogicalVmG
 
 	err = vcdClient.Client.OpenApiPostItem(apiVersion, urlRef, nil, logicalVmGroup, result.LogicalVmGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating the Logical VM Group: %s", err)
+		return nil, fmt.Errorf("error creating the Logical VM Group: %w", err)
 	}
 
 	return result, nil
@@ -168,7 +168,7 @@ func (logicalVmGroup *LogicalVmGroup) Delete() error {
 
 	err = logicalVmGroup.client.OpenApiDeleteItem(apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting the Logical VM Group: %s", err)
+		return fmt.Errorf("error deleting the Logical VM Group: %w", err)
 	}
 	return nil
 }
@@ -206,7 +206,7 @@ func getResourcePools(vcdClient *VCDClient, pvdcUrn string) ([]*types.QueryResul
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("could not get the Resource pool: %s", err)
+		return nil, fmt.Errorf("could not get the Resource pool: %w", err)
 	}
 	if len(foundResourcePools.Results.ResourcePoolRecord) == 0 {
 		return nil, ErrorEntityNotFound
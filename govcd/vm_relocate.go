@@ -0,0 +1,310 @@
+package govcd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// RelocateParams defines where VM.Relocate moves a VM and its internal disks. Storage profiles are
+// references to storage profiles of the VDC of the VM, identified by HREF or, when the HREF is
+// empty, by name
+type RelocateParams struct {
+	// StorageProfile is the new default storage profile of the VM. When nil, the VM keeps its
+	// current storage profile
+	StorageProfile *types.Reference
+	// DiskStorageProfiles maps the IDs of internal disks (DiskSettings.DiskId) to the storage
+	// profile they must be moved to. Disks that are not in the map follow the default storage
+	// profile of the VM, unless they already override it
+	DiskStorageProfiles map[string]*types.Reference
+	// Datastore is a hint of the datastore the VM is moved to, identified by HREF. It uses the
+	// relocate action, which is only available to system administrators
+	Datastore *types.Reference
+	// DryRun only validates the relocation: the VM is not changed
+	DryRun bool
+}
+
+// Relocate moves a VM and its internal disks to other storage profiles and, optionally, to another
+// datastore. The request is validated before any change is made:
+// * storage profiles must belong to the VDC of the VM
+// * disk IDs must belong to the VM
+// * for system administrators, storage profiles must be enabled in the provider VDC (see
+// AdminVdc.QueryCompatibleStorageProfiles)
+//
+// After the tasks complete, the VM is refreshed and Relocate returns an error if its storage
+// profiles, or the datastore of its admin query record, don't match the requested ones. With
+// params.DryRun, Relocate returns after the validation
+func (vm *VM) Relocate(params RelocateParams) (*VM, error) {
+	if vm.VM == nil || vm.VM.HREF == "" {
+		return nil, fmt.Errorf("cannot relocate VM, VM HREF is unset")
+	}
+	if params.StorageProfile == nil && len(params.DiskStorageProfiles) == 0 && params.Datastore == nil {
+		return nil, fmt.Errorf("cannot relocate VM %s: no storage profile or datastore given", vm.VM.Name)
+	}
+	if params.Datastore != nil && !vm.client.IsSysAdmin {
+		return nil, fmt.Errorf("cannot relocate VM %s: only system administrators can choose the datastore", vm.VM.Name)
+	}
+
+	err := vm.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VM: %w", err)
+	}
+	params, err = vm.validateRelocation(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.DryRun {
+		return vm, nil
+	}
+
+	if params.Datastore != nil {
+		err = vm.relocateToDatastore(params.Datastore)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.StorageProfile != nil || len(params.DiskStorageProfiles) > 0 {
+		err = vm.relocateStorageProfiles(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = vm.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VM after relocation: %w", err)
+	}
+	err = vm.checkRelocation(params)
+	if err != nil {
+		return vm, err
+	}
+	return vm, nil
+}
+
+// validateRelocation checks the relocation parameters against the VM and its VDC and returns them
+// with the storage profile and datastore references completed with HREF and name
+func (vm *VM) validateRelocation(params RelocateParams) (RelocateParams, error) {
+	result := RelocateParams{DryRun: params.DryRun}
+	if params.Datastore != nil {
+		if params.Datastore.HREF == "" {
+			return result, fmt.Errorf("datastore reference must have HREF")
+		}
+		datastore := &types.Reference{}
+		_, err := vm.client.ExecuteRequest(params.Datastore.HREF, http.MethodGet, "", "error retrieving datastore: %s", nil, datastore)
+		if err != nil {
+			return result, err
+		}
+		result.Datastore = &types.Reference{HREF: params.Datastore.HREF, Name: datastore.Name}
+	}
+	if params.StorageProfile == nil && len(params.DiskStorageProfiles) == 0 {
+		return result, nil
+	}
+
+	vdc, err := vm.GetParentVdc()
+	if err != nil {
+		return result, err
+	}
+	var vdcStorageProfiles []*types.Reference
+	if vdc.Vdc.VdcStorageProfiles != nil {
+		vdcStorageProfiles = vdc.Vdc.VdcStorageProfiles.VdcStorageProfile
+	}
+	findStorageProfile := func(wanted *types.Reference) (*types.Reference, error) {
+		if wanted == nil || (wanted.HREF == "" && wanted.Name == "") {
+			return nil, fmt.Errorf("storage profile reference must have HREF or name")
+		}
+		for _, storageProfile := range vdcStorageProfiles {
+			if (wanted.HREF != "" && equalIds(wanted.HREF, storageProfile.ID, storageProfile.HREF)) ||
+				(wanted.HREF == "" && wanted.Name == storageProfile.Name) {
+				return &types.Reference{HREF: storageProfile.HREF, Name: storageProfile.Name}, nil
+			}
+		}
+		return nil, fmt.Errorf("storage profile %s%s not found in VDC %s: %w", wanted.Name, wanted.HREF, vdc.Vdc.Name, ErrorEntityNotFound)
+	}
+
+	if params.StorageProfile != nil {
+		result.StorageProfile, err = findStorageProfile(params.StorageProfile)
+		if err != nil {
+			return result, err
+		}
+	}
+	if len(params.DiskStorageProfiles) > 0 {
+		result.DiskStorageProfiles = make(map[string]*types.Reference)
+		for diskId, storageProfile := range params.DiskStorageProfiles {
+			if vm.getInternalDiskSettings(diskId) == nil {
+				return result, fmt.Errorf("disk %s not found in VM %s: %w", diskId, vm.VM.Name, ErrorEntityNotFound)
+			}
+			result.DiskStorageProfiles[diskId], err = findStorageProfile(storageProfile)
+			if err != nil {
+				return result, fmt.Errorf("error validating storage profile of disk %s: %w", diskId, err)
+			}
+		}
+	}
+
+	if !vm.client.IsSysAdmin {
+		util.Logger.Printf("[DEBUG] skipping check of compatible storage profiles for VM %s: it requires a system administrator\n", vm.VM.Name)
+		return result, nil
+	}
+	adminVdc := NewAdminVdc(vm.client)
+	_, err = vm.client.ExecuteRequest(getAdminURL(vdc.Vdc.HREF), http.MethodGet,
+		"", "error retrieving admin VDC: %s", nil, adminVdc.AdminVdc)
+	if err != nil {
+		return result, err
+	}
+	if adminVdc.AdminVdc.ProviderVdcReference == nil {
+		return result, fmt.Errorf("VDC %s has no provider VDC reference", vdc.Vdc.Name)
+	}
+	compatibleStorageProfiles, err := adminVdc.QueryCompatibleStorageProfiles()
+	if err != nil {
+		return result, fmt.Errorf("error retrieving compatible storage profiles: %w", err)
+	}
+	isCompatible := func(storageProfile *types.Reference) bool {
+		for _, compatible := range compatibleStorageProfiles {
+			if compatible.Name == storageProfile.Name && compatible.IsEnabled {
+				return true
+			}
+		}
+		return false
+	}
+	targets := []*types.Reference{result.StorageProfile}
+	for _, storageProfile := range result.DiskStorageProfiles {
+		targets = append(targets, storageProfile)
+	}
+	for _, storageProfile := range targets {
+		if storageProfile != nil && !isCompatible(storageProfile) {
+			return result, fmt.Errorf("storage profile %s is not enabled in the provider VDC of VDC %s", storageProfile.Name, vdc.Vdc.Name)
+		}
+	}
+	return result, nil
+}
+
+// getInternalDiskSettings returns the settings of the internal disk with the given ID, or nil
+func (vm *VM) getInternalDiskSettings(diskId string) *types.DiskSettings {
+	if vm.VM.VmSpecSection == nil || vm.VM.VmSpecSection.DiskSection == nil {
+		return nil
+	}
+	for _, diskSettings := range vm.VM.VmSpecSection.DiskSection.DiskSettings {
+		if diskSettings.DiskId == diskId {
+			return diskSettings
+		}
+	}
+	return nil
+}
+
+// relocateToDatastore moves the VM to a datastore with the relocate action
+func (vm *VM) relocateToDatastore(datastore *types.Reference) error {
+	href := strings.TrimSuffix(vm.VM.HREF, "/") + "/action/relocate"
+	link := vm.VM.Link.Find(func(link *types.Link) bool {
+		return link != nil && link.Rel == types.RelRelocate
+	})
+	if link != nil {
+		href = link.HREF
+	}
+
+	task, err := vm.client.ExecuteTaskRequest(href, http.MethodPost, types.MimeRelocateParams,
+		"error relocating VM: %s", &types.RelocateParams{
+			Xmlns:     types.XMLNamespaceVCloud,
+			Datastore: &types.Reference{HREF: datastore.HREF, Name: datastore.Name},
+		})
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error relocating VM %s to datastore %s: %w", vm.VM.Name, datastore.Name, err)
+	}
+	return vm.Refresh()
+}
+
+// relocateStorageProfiles changes the storage profile of the VM and of its disks with a single
+// reconfiguration
+func (vm *VM) relocateStorageProfiles(params RelocateParams) error {
+	vmPayload := &types.Vm{
+		Xmlns:          types.XMLNamespaceVCloud,
+		Ovf:            types.XMLNamespaceOVF,
+		Name:           vm.VM.Name,
+		Description:    vm.VM.Description,
+		StorageProfile: params.StorageProfile,
+	}
+	if len(params.DiskStorageProfiles) > 0 {
+		// The whole section is sent, as disks that are missing would be deleted. It is built from a
+		// copy, so that the VM is not changed when the reconfiguration fails
+		vmSpecSection := *vm.VM.VmSpecSection
+		diskSection := *vmSpecSection.DiskSection
+		diskSection.DiskSettings = make([]*types.DiskSettings, len(vmSpecSection.DiskSection.DiskSettings))
+		for index, diskSettings := range vmSpecSection.DiskSection.DiskSettings {
+			diskSettingsCopy := *diskSettings
+			if storageProfile, ok := params.DiskStorageProfiles[diskSettings.DiskId]; ok {
+				diskSettingsCopy.StorageProfile = storageProfile
+				diskSettingsCopy.OverrideVmDefault = true
+			}
+			diskSection.DiskSettings[index] = &diskSettingsCopy
+		}
+		vmSpecSection.DiskSection = &diskSection
+		vmSpecSection.Modified = addrOf(true)
+		vmPayload.VmSpecSection = &vmSpecSection
+	}
+
+	task, err := vm.client.ExecuteTaskRequestWithApiVersion(vm.VM.HREF+"/action/reconfigureVm",
+		http.MethodPost, types.MimeVM, "error relocating VM: %s", vmPayload,
+		vm.client.GetSpecificApiVersionOnCondition(">=37.1", "37.1"))
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error relocating VM %s: %w", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// checkRelocation returns an error if the storage profiles of the refreshed VM, or its datastore,
+// don't match the requested ones. The datastore is read from the admin query record of the VM,
+// which is only available to system administrators, like the relocation to a datastore itself
+func (vm *VM) checkRelocation(params RelocateParams) error {
+	var mismatches []string
+	if params.Datastore != nil {
+		datastoreName, err := vm.queryDatastoreName()
+		if err != nil {
+			return fmt.Errorf("relocation of VM %s completed, but its datastore cannot be verified: %w", vm.VM.Name, err)
+		}
+		if datastoreName != params.Datastore.Name {
+			mismatches = append(mismatches, fmt.Sprintf("VM is in datastore %s instead of %s", datastoreName, params.Datastore.Name))
+		}
+	}
+	if params.StorageProfile != nil {
+		if vm.VM.StorageProfile == nil || !equalIds(params.StorageProfile.HREF, vm.VM.StorageProfile.ID, vm.VM.StorageProfile.HREF) {
+			mismatches = append(mismatches, fmt.Sprintf("VM is not in storage profile %s", params.StorageProfile.Name))
+		}
+	}
+	for diskId, storageProfile := range params.DiskStorageProfiles {
+		diskSettings := vm.getInternalDiskSettings(diskId)
+		if diskSettings == nil || diskSettings.StorageProfile == nil ||
+			!equalIds(storageProfile.HREF, diskSettings.StorageProfile.ID, diskSettings.StorageProfile.HREF) {
+			mismatches = append(mismatches, fmt.Sprintf("disk %s is not in storage profile %s", diskId, storageProfile.Name))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("relocation of VM %s completed, but %s", vm.VM.Name, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// queryDatastoreName returns the name of the datastore of the VM from its admin query record
+func (vm *VM) queryDatastoreName() (string, error) {
+	results, err := vm.client.QueryWithNotEncodedParams(nil, map[string]string{
+		"type":          types.QtAdminVm,
+		"filter":        "id==" + url.QueryEscape(vm.VM.ID),
+		"filterEncoded": "true",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error querying VM %s: %w", vm.VM.Name, err)
+	}
+	if len(results.Results.AdminVMRecord) != 1 {
+		return "", fmt.Errorf("expected one query record for VM %s, got %d", vm.VM.Name, len(results.Results.AdminVMRecord))
+	}
+	return results.Results.AdminVMRecord[0].DatastoreName, nil
+}
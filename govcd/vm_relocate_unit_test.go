@@ -0,0 +1,189 @@
+//go:build unit || ALL
+
+package govcd
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// newVmRelocateTestVm returns a VM with the internal disks 2000 and 2001 in the storage profile
+// silver, in a VDC with the storage profiles silver, gold and bronze. Bronze is disabled in the
+// provider VDC. The datastores ds1 and ds2 are returned by name
+func newVmRelocateTestVm(t *testing.T) (*govcdtest.Server, *VM, map[string]*types.Reference) {
+	server, vcdClient := testServerClient(t)
+	for _, profile := range []string{"silver", "gold", "bronze"} {
+		if _, err := server.AddVdcStorageProfile("org1", "vdc1", profile, profile != "bronze"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	datastores := make(map[string]*types.Reference)
+	for _, name := range []string{"ds1", "ds2"} {
+		id, err := server.AddDatastore(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		datastores[name] = &types.Reference{HREF: server.URL + "/api/admin/extension/datastore/" + extractUuid(id)}
+	}
+	if err := server.AddUser("org1", "user", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	silver, err := vdc.FindStorageProfileReference("silver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapp, err := vdc.CreateRawVApp("vapp1", "")
+	if err != nil {
+		t.Fatalf("error creating vApp: %s", err)
+	}
+	var disks []*types.DiskSettings
+	for unitNumber, diskId := range []string{"2000", "2001"} {
+		disks = append(disks, &types.DiskSettings{
+			DiskId:         diskId,
+			SizeMb:         1024,
+			UnitNumber:     unitNumber,
+			AdapterType:    "5",
+			StorageProfile: &types.Reference{HREF: silver.HREF, Name: silver.Name},
+		})
+	}
+	vm, err := vapp.AddEmptyVm(&types.RecomposeVAppParamsForEmptyVm{
+		CreateItem: &types.CreateItem{
+			Name: "vm1",
+			VmSpecSection: &types.VmSpecSection{
+				OsType:           "debian10_64Guest",
+				NumCpus:          addrOf(1),
+				MemoryResourceMb: &types.MemoryResourceMb{Configured: 512},
+				HardwareVersion:  &types.HardwareVersion{Value: "vmx-19"},
+				DiskSection:      &types.DiskSection{DiskSettings: disks},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error adding VM: %s", err)
+	}
+	return server, vm, datastores
+}
+
+func TestVM_Relocate(t *testing.T) {
+	server, vm, datastores := newVmRelocateTestVm(t)
+	checkDisk := func(vm *VM, diskId, storageProfile string, overrideVmDefault bool) {
+		t.Helper()
+		diskSettings := vm.getInternalDiskSettings(diskId)
+		if diskSettings == nil || diskSettings.StorageProfile == nil || diskSettings.StorageProfile.Name != storageProfile ||
+			diskSettings.OverrideVmDefault != overrideVmDefault {
+			t.Errorf("expected disk %s in storage profile %s (override %t), got %#v", diskId, storageProfile, overrideVmDefault, diskSettings)
+		}
+	}
+
+	gold := &types.Reference{Name: "gold"}
+	// A dry run validates the request without changing the VM
+	_, err := vm.Relocate(RelocateParams{DiskStorageProfiles: map[string]*types.Reference{"2001": gold}, DryRun: true})
+	if err != nil {
+		t.Fatalf("error validating relocation: %s", err)
+	}
+	if err = vm.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	checkDisk(vm, "2001", "silver", false)
+
+	invalid := []struct {
+		params   RelocateParams
+		expected string
+	}{
+		{RelocateParams{}, "no storage profile or datastore"},
+		{RelocateParams{StorageProfile: &types.Reference{Name: "platinum"}}, "platinum not found"},
+		{RelocateParams{DiskStorageProfiles: map[string]*types.Reference{"3000": gold}}, "disk 3000 not found"},
+		{RelocateParams{StorageProfile: &types.Reference{Name: "bronze"}}, "bronze is not enabled"},
+	}
+	for _, test := range invalid {
+		_, err = vm.Relocate(test.params)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected error containing '%s', got %v", test.expected, err)
+		}
+	}
+	if vm.VM.StorageProfile != nil {
+		t.Errorf("expected no reconfiguration for invalid requests, got storage profile %#v", vm.VM.StorageProfile)
+	}
+
+	silver := vm.getInternalDiskSettings("2001").StorageProfile
+	vm, err = vm.Relocate(RelocateParams{
+		StorageProfile:      gold,
+		DiskStorageProfiles: map[string]*types.Reference{"2001": {HREF: silver.HREF}},
+		Datastore:           datastores["ds2"],
+	})
+	if err != nil {
+		t.Fatalf("error relocating VM: %s", err)
+	}
+	if vm.VM.StorageProfile == nil || vm.VM.StorageProfile.Name != "gold" {
+		t.Errorf("expected the VM to be refreshed, got storage profile %#v", vm.VM.StorageProfile)
+	}
+	checkDisk(vm, "2000", "silver", false)
+	checkDisk(vm, "2001", "silver", true)
+	if datastoreName, err := vm.queryDatastoreName(); err != nil || datastoreName != "ds2" {
+		t.Errorf("expected the VM to be in datastore ds2, got %s (%v)", datastoreName, err)
+	}
+	if vm, err = vm.Relocate(RelocateParams{Datastore: datastores["ds1"]}); err != nil {
+		t.Fatalf("error relocating VM back to ds1: %s", err)
+	}
+
+	// A failed reconfiguration leaves the VM unchanged, and the final check detects disks that
+	// were not moved
+	reconfigurationFails := true
+	server.HandleFunc("POST /api/vApp/{id}/action/reconfigureVm", func(w http.ResponseWriter, r *http.Request) {
+		if reconfigurationFails {
+			govcdtest.WriteError(w, r, http.StatusBadRequest, "BAD_REQUEST", "reconfiguration failed")
+			return
+		}
+		server.WriteTask(w, r, "vappUpdateVm", "")
+	})
+	_, err = vm.Relocate(RelocateParams{DiskStorageProfiles: map[string]*types.Reference{"2000": gold}})
+	if err == nil || !strings.Contains(err.Error(), "reconfiguration failed") {
+		t.Errorf("expected a reconfiguration error, got %v", err)
+	}
+	checkDisk(vm, "2000", "silver", false)
+	reconfigurationFails = false
+	_, err = vm.Relocate(RelocateParams{DiskStorageProfiles: map[string]*types.Reference{"2000": gold}})
+	if err == nil || !strings.Contains(err.Error(), "disk 2000 is not in storage profile gold") {
+		t.Errorf("expected a final state error, got %v", err)
+	}
+	// and a VM that stays in its datastore
+	server.HandleFunc("POST /api/vApp/{id}/action/relocate", func(w http.ResponseWriter, r *http.Request) {
+		server.WriteTask(w, r, "vappRelocateVm", "")
+	})
+	_, err = vm.Relocate(RelocateParams{Datastore: datastores["ds2"]})
+	if err == nil || !strings.Contains(err.Error(), "VM is in datastore ds1 instead of ds2") {
+		t.Errorf("expected a datastore error, got %v", err)
+	}
+
+	// Only system administrators can choose the datastore
+	tenantClient := NewVCDClient(server.Endpoint(), true)
+	if err = tenantClient.Authenticate("user", "password", "org1"); err != nil {
+		t.Fatal(err)
+	}
+	tenantVm, err := tenantClient.Client.GetVMByHref(vm.VM.HREF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tenantVm.Relocate(RelocateParams{Datastore: datastores["ds2"]})
+	if err == nil || !strings.Contains(err.Error(), "system administrators") {
+		t.Errorf("expected an error for a datastore without system administrator, got %v", err)
+	}
+	_, err = tenantVm.Relocate(RelocateParams{StorageProfile: &types.Reference{Name: "platinum"}})
+	if !errors.Is(err, ErrorEntityNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
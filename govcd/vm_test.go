@@ -2491,3 +2491,72 @@ func deployVappWithOrgVdcNetwork(vappName, orgNetworkName string, vdc *Vdc) (*VA
 
 	return vapp, nil
 }
+
+// Test_VmAndVAppSnapshot checks snapshot management for a VM and its parent vApp:
+// * creating a VM snapshot and checking the snapshot section
+// * reverting the VM to its current snapshot
+// * removing all VM snapshots
+// * repeating the cycle at vApp level using Async methods
+func (vcd *TestVCD) Test_VmAndVAppSnapshot(check *C) {
+	vapp, vm := createNsxtVAppAndVm(vcd, check)
+	check.Assert(vapp, NotNil)
+	check.Assert(vm, NotNil)
+
+	// VM level snapshot
+	snapshotSection, err := vm.GetSnapshotSection()
+	check.Assert(err, IsNil)
+	check.Assert(len(snapshotSection.Snapshot), Equals, 0)
+
+	err = vm.CreateSnapshot(check.TestName(), false, false)
+	check.Assert(err, IsNil)
+
+	snapshotSection, err = vm.GetSnapshotSection()
+	check.Assert(err, IsNil)
+	check.Assert(len(snapshotSection.Snapshot), Equals, 1)
+	check.Assert(snapshotSection.Snapshot[0].Created, Not(Equals), "")
+
+	err = vm.RevertToCurrentSnapshot()
+	check.Assert(err, IsNil)
+
+	err = vm.RemoveAllSnapshots()
+	check.Assert(err, IsNil)
+
+	snapshotSection, err = vm.GetSnapshotSection()
+	check.Assert(err, IsNil)
+	check.Assert(len(snapshotSection.Snapshot), Equals, 0)
+
+	// vApp level snapshot
+	task, err := vapp.CreateSnapshotAsync(check.TestName(), false, false)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion()
+	check.Assert(err, IsNil)
+
+	snapshotSection, err = vm.GetSnapshotSection()
+	check.Assert(err, IsNil)
+	check.Assert(len(snapshotSection.Snapshot), Equals, 1)
+
+	task, err = vapp.RevertToCurrentSnapshotAsync()
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion()
+	check.Assert(err, IsNil)
+
+	task, err = vapp.RemoveAllSnapshotsAsync()
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion()
+	check.Assert(err, IsNil)
+
+	snapshotSection, err = vm.GetSnapshotSection()
+	check.Assert(err, IsNil)
+	check.Assert(len(snapshotSection.Snapshot), Equals, 0)
+
+	// Cleanup
+	task, err = vapp.Undeploy()
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion()
+	check.Assert(err, IsNil)
+
+	task, err = vapp.Delete()
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion()
+	check.Assert(err, IsNil)
+}
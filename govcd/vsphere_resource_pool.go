@@ -35,7 +35,7 @@ func (vcenter VCenter) GetAllResourcePools(queryParams url.Values) ([]*ResourceP
 
 	err = client.OpenApiGetAllItems(minimumApiVersion, urlRef, queryParams, &retrieved, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting resource pool list: %s", err)
+		return nil, fmt.Errorf("error getting resource pool list: %w", err)
 	}
 
 	if len(retrieved) == 0 {
@@ -74,7 +74,7 @@ func (rp ResourcePool) GetAvailableHardwareVersions() (*types.OpenApiSupportedHa
 	retrieved := types.OpenApiSupportedHardwareVersions{}
 	err = client.OpenApiGetItem(minimumApiVersion, urlRef, nil, &retrieved, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting resource pool hardware versions: %s", err)
+		return nil, fmt.Errorf("error getting resource pool hardware versions: %w", err)
 	}
 
 	return &retrieved, nil
@@ -108,7 +108,7 @@ func (vcenter VCenter) GetResourcePoolById(id string) (*ResourcePool, error) {
 			return rp, nil
 		}
 	}
-	return nil, fmt.Errorf("no resource pool found with ID '%s' :%s", id, ErrorEntityNotFound)
+	return nil, fmt.Errorf("no resource pool found with ID '%s' :%w", id, ErrorEntityNotFound)
 }
 
 // GetResourcePoolByName retrieves a resource pool by name.
@@ -125,7 +125,7 @@ func (vcenter VCenter) GetResourcePoolByName(name string) (*ResourcePool, error)
 		}
 	}
 	if len(found) == 0 {
-		return nil, fmt.Errorf("no resource pool found with name '%s' :%s", name, ErrorEntityNotFound)
+		return nil, fmt.Errorf("no resource pool found with name '%s' :%w", name, ErrorEntityNotFound)
 	}
 	if len(found) > 1 {
 		var idList []string
@@ -48,7 +48,7 @@ func (vcenter VCenter) GetAllStorageProfiles(resourcePoolId string, queryParams
 	}
 	err = client.OpenApiGetAllItems(minimumApiVersion, urlRef, queryParams, &retrieved, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting storage profile list: %s", err)
+		return nil, fmt.Errorf("error getting storage profile list: %w", err)
 	}
 
 	if len(retrieved) == 0 {
@@ -78,7 +78,7 @@ func (vcenter VCenter) GetStorageProfileById(resourcePoolId, id string) (*Storag
 			return sp, nil
 		}
 	}
-	return nil, fmt.Errorf("no storage profile found with ID '%s': %s", id, err)
+	return nil, fmt.Errorf("no storage profile found with ID '%s': %w", id, err)
 }
 
 // GetStorageProfileByName retrieves a storage profile in the context of a given resource pool
@@ -94,7 +94,7 @@ func (vcenter VCenter) GetStorageProfileByName(resourcePoolId, name string) (*St
 		}
 	}
 	if len(found) == 0 {
-		return nil, fmt.Errorf("no storage profile found with name '%s': %s", name, ErrorEntityNotFound)
+		return nil, fmt.Errorf("no storage profile found with name '%s': %w", name, ErrorEntityNotFound)
 	}
 	if len(found) > 1 {
 		return nil, fmt.Errorf("more than one storage profile found with name '%s'", name)
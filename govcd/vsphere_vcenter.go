@@ -43,7 +43,7 @@ func (vcdClient *VCDClient) CreateVcenter(config *types.VSphereVirtualCenter) (*
 	createVc := func() error {
 		task, err := vcdClient.CreateVcenterAsync(config)
 		if err != nil {
-			return fmt.Errorf("error triggering %s creation task: %s", labelVirtualCenter, err)
+			return fmt.Errorf("error triggering %s creation task: %w", labelVirtualCenter, err)
 		}
 
 		err = task.WaitTaskCompletion()
@@ -64,19 +64,19 @@ func (vcdClient *VCDClient) CreateVcenter(config *types.VSphereVirtualCenter) (*
 				}
 
 				if err != nil {
-					return fmt.Errorf("error retrieving %s by ID after a failed task: %s", labelVirtualCenter, err)
+					return fmt.Errorf("error retrieving %s by ID after a failed task: %w", labelVirtualCenter, err)
 				}
 				// ensure the vCenter is disabled and is ready for deletion
 				if recoveredVc.VSphereVCenter.IsEnabled {
 					recoveredVc.VSphereVCenter.IsEnabled = true
 					_, err = recoveredVc.Update(recoveredVc.VSphereVCenter)
 					if err != nil {
-						return fmt.Errorf("error disabling %s after recovery: %s", labelVirtualCenter, err)
+						return fmt.Errorf("error disabling %s after recovery: %w", labelVirtualCenter, err)
 					}
 				}
 				err = recoveredVc.Delete()
 				if err != nil {
-					return fmt.Errorf("error deleting %s after recovery: %s", labelVirtualCenter, err)
+					return fmt.Errorf("error deleting %s after recovery: %w", labelVirtualCenter, err)
 				}
 				// vCenter cleanup worked, returning original error so that `runWithRetry` acts accordingly
 				return originalError
@@ -84,7 +84,7 @@ func (vcdClient *VCDClient) CreateVcenter(config *types.VSphereVirtualCenter) (*
 		}
 
 		if err != nil {
-			return fmt.Errorf("unable to create %s: %s", labelVirtualCenter, err)
+			return fmt.Errorf("unable to create %s: %w", labelVirtualCenter, err)
 		}
 
 		// vCenter creation succeeded - fetch it and store
@@ -92,7 +92,7 @@ func (vcdClient *VCDClient) CreateVcenter(config *types.VSphereVirtualCenter) (*
 		if task != nil && task.Task != nil && task.Task.Owner != nil && task.Task.Owner.ID != "" {
 			resultVc, err = vcdClient.GetVCenterById(task.Task.Owner.ID)
 			if err != nil {
-				return fmt.Errorf("error retrieving %s after creation: %s", labelVirtualCenter, err)
+				return fmt.Errorf("error retrieving %s after creation: %w", labelVirtualCenter, err)
 			}
 			return nil // This is a signal that no errors occurred and the 'resultVc' is populated with correct value
 		}
@@ -246,7 +246,7 @@ func (v *VCenter) Refresh() error {
 	// newVcenter, err := v.client.GetVCenterById(v.VSphereVCenter.VcId)
 	newVcenter, err := v.client.GetVCenterByName(v.VSphereVCenter.Name) // TODO: TM: use above retrieval by ID
 	if err != nil {
-		return fmt.Errorf("error refreshing vCenter: %s", err)
+		return fmt.Errorf("error refreshing vCenter: %w", err)
 	}
 
 	v.VSphereVCenter = newVcenter.VSphereVCenter
@@ -263,7 +263,7 @@ func (v *VCenter) RefreshVcenter() error {
 func (v *VCenter) refreshVcenter() error {
 	refreshUrl, err := url.JoinPath(v.client.Client.rootVcdHref(), "api", "admin", "extension", "vimServer", extractUuid(v.VSphereVCenter.VcId), "action", "refresh")
 	if err != nil {
-		return fmt.Errorf("error building refresh path: %s", err)
+		return fmt.Errorf("error building refresh path: %w", err)
 	}
 
 	resp, err := v.client.Client.executeJsonRequest(refreshUrl, http.MethodPost, nil, "error triggering vCenter refresh: %s")
@@ -274,11 +274,11 @@ func (v *VCenter) refreshVcenter() error {
 	task := NewTask(&v.client.Client)
 	err = decodeBody(types.BodyTypeJSON, resp, task.Task)
 	if err != nil {
-		return fmt.Errorf("error triggering retrieving task: %s", err)
+		return fmt.Errorf("error triggering retrieving task: %w", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error waiting task completion: %s", err)
+		return fmt.Errorf("error waiting task completion: %w", err)
 	}
 
 	return nil
@@ -294,7 +294,7 @@ func (v *VCenter) RefreshStorageProfiles() error {
 func (v *VCenter) refreshStorageProfiles() error {
 	refreshUrl, err := url.JoinPath(v.client.Client.rootVcdHref(), "api", "admin", "extension", "vimServer", extractUuid(v.VSphereVCenter.VcId), "action", "refreshStorageProfiles")
 	if err != nil {
-		return fmt.Errorf("error building storage policy refresh path: %s", err)
+		return fmt.Errorf("error building storage policy refresh path: %w", err)
 	}
 
 	resp, err := v.client.Client.executeJsonRequest(refreshUrl, http.MethodPost, nil, "error triggering vCenter refresh storage policy: %s")
@@ -305,11 +305,11 @@ func (v *VCenter) refreshStorageProfiles() error {
 	task := NewTask(&v.client.Client)
 	err = decodeBody(types.BodyTypeJSON, resp, task.Task)
 	if err != nil {
-		return fmt.Errorf("error triggering retrieving task: %s", err)
+		return fmt.Errorf("error triggering retrieving task: %w", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf("error waiting task completion: %s", err)
+		return fmt.Errorf("error waiting task completion: %w", err)
 	}
 
 	return nil
@@ -337,7 +337,7 @@ func runWithRetry(runOperation func() error, errRegexp *regexp.Regexp, duration
 		// If time limit is exceeded - return error containing statistics and original error
 		if time.Now().After(endTime) {
 			util.Logger.Printf("[DEBUG] runWithRetry - exceeded time after attempt %d, got error: %s ", count, err)
-			return fmt.Errorf("error attempting to wait until error does not contain '%s' after %f seconds: %s", errRegexp, duration.Seconds(), err)
+			return fmt.Errorf("error attempting to wait until error does not contain '%s' after %f seconds: %w", errRegexp, duration.Seconds(), err)
 		}
 
 		// Sleep and continue
@@ -0,0 +1,207 @@
+// Package govcdotel provides OpenTelemetry traces and metrics for API calls and task waits
+// performed by go-vcloud-director.
+//
+// It is a separate Go module so that the SDK itself does not depend on OpenTelemetry:
+//
+//	instrumentation, err := govcdotel.NewInstrumentation()
+//	if err != nil {
+//		return err
+//	}
+//	vcdClient := govcd.NewVCDClient(*vcdUrl, false, govcd.WithInstrumentation(instrumentation),
+//		govcd.WithVcloudRequestIdFunc(govcd.VcloudRequestIdBuilderFunc))
+//
+// Global OpenTelemetry providers are used unless WithTracerProvider, WithMeterProvider or
+// WithPropagator are specified.
+package govcdotel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+// instrumentationName is the name of the tracer and meter
+const instrumentationName = "github.com/vmware/go-vcloud-director/v3/govcdotel"
+
+// Attribute keys that are not a part of OpenTelemetry semantic conventions
+const (
+	AttributeClientRequestId = attribute.Key("vcd.client_request_id")
+	AttributeRequestId       = attribute.Key("vcd.request_id")
+	AttributeRetries         = attribute.Key("vcd.retries")
+	AttributeTaskHref        = attribute.Key("vcd.task.href")
+	AttributeTaskOperation   = attribute.Key("vcd.task.operation")
+	AttributeTaskStatus      = attribute.Key("vcd.task.status")
+)
+
+// Attribute keys from OpenTelemetry semantic conventions for HTTP clients
+const (
+	attributeHttpMethod     = attribute.Key("http.request.method")
+	attributeHttpStatusCode = attribute.Key("http.response.status_code")
+	attributeUrlFull        = attribute.Key("url.full")
+	attributeUrlTemplate    = attribute.Key("url.template")
+	attributeServerAddress  = attribute.Key("server.address")
+	attributeErrorType      = attribute.Key("error.type")
+)
+
+// Instrumentation implements govcd.Instrumentation using OpenTelemetry. It produces:
+// * a client span for every API call (including all its retries) and a span for every task wait
+// * metric 'vcd.client.request.duration' - histogram of API call durations in seconds
+// * metric 'vcd.client.request.retries' - counter of retried API call attempts
+// * metric 'vcd.client.task.duration' - histogram of task wait durations in seconds
+type Instrumentation struct {
+	tracer           trace.Tracer
+	propagator       propagation.TextMapPropagator
+	requestDuration  metric.Float64Histogram
+	requestRetries   metric.Int64Counter
+	taskWaitDuration metric.Float64Histogram
+}
+
+// config holds settings of NewInstrumentation
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option customizes Instrumentation created by NewInstrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider used to create spans
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = meterProvider
+	}
+}
+
+// WithPropagator sets the propagator which injects trace context into request headers
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// NewInstrumentation creates Instrumentation that can be passed to govcd.WithInstrumentation
+func NewInstrumentation(options ...Option) (*Instrumentation, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+	requestDuration, err := meter.Float64Histogram("vcd.client.request.duration",
+		metric.WithDescription("Duration of API calls to VMware Cloud Director including retries"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request duration histogram: %w", err)
+	}
+	requestRetries, err := meter.Int64Counter("vcd.client.request.retries",
+		metric.WithDescription("Number of retried attempts of API calls to VMware Cloud Director"),
+		metric.WithUnit("{retry}"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request retries counter: %w", err)
+	}
+	taskWaitDuration, err := meter.Float64Histogram("vcd.client.task.duration",
+		metric.WithDescription("Time spent waiting for VMware Cloud Director tasks"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating task duration histogram: %w", err)
+	}
+
+	return &Instrumentation{
+		tracer:           c.tracerProvider.Tracer(instrumentationName),
+		propagator:       c.propagator,
+		requestDuration:  requestDuration,
+		requestRetries:   requestRetries,
+		taskWaitDuration: taskWaitDuration,
+	}, nil
+}
+
+// StartApiCall implements govcd.Instrumentation
+func (instrumentation *Instrumentation) StartApiCall(ctx context.Context, call govcd.ApiCall) (context.Context, func(govcd.ApiCallResult)) {
+	attributes := []attribute.KeyValue{
+		attributeHttpMethod.String(call.Method),
+		attributeUrlTemplate.String(call.EndpointTemplate),
+		attributeServerAddress.String(call.URL.Hostname()),
+	}
+	spanAttributes := append([]attribute.KeyValue{attributeUrlFull.String(call.URL.Redacted())}, attributes...)
+	if call.ClientRequestId != "" {
+		spanAttributes = append(spanAttributes, AttributeClientRequestId.String(call.ClientRequestId))
+	}
+
+	ctx, span := instrumentation.tracer.Start(ctx, call.Method+" "+call.EndpointTemplate,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(spanAttributes...))
+	instrumentation.propagator.Inject(ctx, propagation.HeaderCarrier(call.Header))
+
+	return ctx, func(result govcd.ApiCallResult) {
+		defer span.End()
+
+		if result.Err != nil {
+			attributes = append(attributes, attributeErrorType.String(fmt.Sprintf("%T", result.Err)))
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+		} else {
+			attributes = append(attributes, attributeHttpStatusCode.Int(result.StatusCode))
+			span.SetAttributes(attributeHttpStatusCode.Int(result.StatusCode), AttributeRequestId.String(result.RequestId))
+			if result.StatusCode >= 400 {
+				attributes = append(attributes, attributeErrorType.String(strconv.Itoa(result.StatusCode)))
+				span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(result.StatusCode))
+			}
+		}
+		span.SetAttributes(AttributeRetries.Int(result.Retries))
+
+		metricAttributes := metric.WithAttributes(attributes...)
+		instrumentation.requestDuration.Record(ctx, result.Duration.Seconds(), metricAttributes)
+		if result.Retries > 0 {
+			instrumentation.requestRetries.Add(ctx, int64(result.Retries), metricAttributes)
+		}
+	}
+}
+
+// StartTaskWait implements govcd.Instrumentation
+func (instrumentation *Instrumentation) StartTaskWait(ctx context.Context, wait govcd.TaskWait) (context.Context, func(govcd.TaskWaitResult)) {
+	ctx, span := instrumentation.tracer.Start(ctx, "wait for task",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(AttributeTaskHref.String(wait.Task.HREF)))
+
+	return ctx, func(result govcd.TaskWaitResult) {
+		defer span.End()
+
+		attributes := []attribute.KeyValue{
+			AttributeTaskOperation.String(result.Operation),
+			AttributeTaskStatus.String(result.Status),
+		}
+		span.SetAttributes(attributes...)
+		if result.Operation != "" {
+			span.SetName("wait for task " + result.Operation)
+		}
+		if result.Err != nil {
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+		}
+
+		instrumentation.taskWaitDuration.Record(ctx, result.Duration.Seconds(), metric.WithAttributes(attributes...))
+	}
+}
+
+// Instrumentation must satisfy govcd.Instrumentation
+var _ govcd.Instrumentation = &Instrumentation{}
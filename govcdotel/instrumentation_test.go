@@ -0,0 +1,115 @@
+package govcdotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/govcdotel"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func TestInstrumentation(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Errorf("trace context was not propagated")
+		}
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		w.Header().Set("X-Vmware-Vcloud-Request-Id", "request-id-4")
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" name="task" ` +
+			`operationName="vappDeploy" href="http://` + r.Host + r.URL.Path + `"></Task>`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	metrics := sdkmetric.NewManualReader()
+	instrumentation, err := govcdotel.NewInstrumentation(
+		govcdotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		govcdotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics))),
+		govcdotel.WithPropagator(propagation.TraceContext{}))
+	if err != nil {
+		t.Fatalf("error creating instrumentation: %s", err)
+	}
+
+	vcdUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("error parsing server URL: %s", err)
+	}
+	retryPolicy := govcd.DefaultRetryPolicy()
+	retryPolicy.InitialBackoff = time.Millisecond
+	vcdClient := govcd.NewVCDClient(*vcdUrl, true, govcd.WithInstrumentation(instrumentation),
+		govcd.WithRetryPolicy(retryPolicy), govcd.WithVcloudRequestIdFunc(func() string { return "client-request-id-2" }))
+	task := govcd.NewTask(&vcdClient.Client)
+	task.Task.HREF = server.URL + "/api/task/3c4e6b0c-7a5e-4f5f-a5c8-b8c5de1bb4d6"
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		t.Fatalf("error waiting for task: %s", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected an API call span and a task wait span, got %d spans", len(ended))
+	}
+	apiCall, taskWait := ended[0], ended[1]
+	if apiCall.Name() != "GET /api/task/{id}" || apiCall.Parent().SpanID() != taskWait.SpanContext().SpanID() {
+		t.Errorf("unexpected API call span %s with parent %s", apiCall.Name(), apiCall.Parent().SpanID())
+	}
+	expectedAttributes := map[attribute.Key]string{
+		"http.response.status_code":        "200",
+		"url.template":                     "/api/task/{id}",
+		govcdotel.AttributeRequestId:       "request-id-4",
+		govcdotel.AttributeClientRequestId: "client-request-id-2",
+		govcdotel.AttributeRetries:         "1",
+	}
+	for _, keyValue := range apiCall.Attributes() {
+		expected, found := expectedAttributes[keyValue.Key]
+		if found && keyValue.Value.Emit() != expected {
+			t.Errorf("expected attribute %s to be %s, got %s", keyValue.Key, expected, keyValue.Value.Emit())
+		}
+		delete(expectedAttributes, keyValue.Key)
+	}
+	if len(expectedAttributes) > 0 {
+		t.Errorf("missing API call span attributes %v", expectedAttributes)
+	}
+	if taskWait.Name() != "wait for task vappDeploy" || taskWait.Status().Code == codes.Error {
+		t.Errorf("unexpected task wait span %s with status %v", taskWait.Name(), taskWait.Status())
+	}
+
+	var collected metricdata.ResourceMetrics
+	err = metrics.Collect(context.Background(), &collected)
+	if err != nil {
+		t.Fatalf("error collecting metrics: %s", err)
+	}
+	found := make(map[string]bool)
+	for _, scopeMetrics := range collected.ScopeMetrics {
+		for _, metric := range scopeMetrics.Metrics {
+			found[metric.Name] = true
+			if retries, isSum := metric.Data.(metricdata.Sum[int64]); isSum && retries.DataPoints[0].Value != 1 {
+				t.Errorf("expected 1 retry, got %d", retries.DataPoints[0].Value)
+			}
+		}
+	}
+	for _, name := range []string{"vcd.client.request.duration", "vcd.client.request.retries", "vcd.client.task.duration"} {
+		if !found[name] {
+			t.Errorf("metric %s was not recorded", name)
+		}
+	}
+}
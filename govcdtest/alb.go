@@ -0,0 +1,357 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// albPool is an NSX-T ALB Pool of an Edge Gateway
+type albPool struct {
+	pool *types.NsxtAlbPool
+	egw  *edgeGateway
+}
+
+// albVirtualService is an NSX-T ALB Virtual Service of an Edge Gateway. Service Engine Groups are
+// not modelled, therefore any Service Engine Group ID is accepted
+type albVirtualService struct {
+	virtualService *types.NsxtAlbVirtualService
+	egw            *edgeGateway
+}
+
+func compareAlbPools(a, b *albPool) int {
+	return cmp.Or(compareEdgeGateways(a.egw, b.egw), cmp.Compare(a.pool.Name, b.pool.Name))
+}
+
+func compareAlbVirtualServices(a, b *albVirtualService) int {
+	return cmp.Or(compareEdgeGateways(a.egw, b.egw), cmp.Compare(a.virtualService.Name, b.virtualService.Name))
+}
+
+// copyAlbPool returns a deep copy of the ALB Pool, so that stored state is not shared with the
+// caller
+func copyAlbPool(pool *types.NsxtAlbPool) *types.NsxtAlbPool {
+	body, err := json.Marshal(pool)
+	if err != nil {
+		panic(err)
+	}
+	result := &types.NsxtAlbPool{}
+	if err := json.Unmarshal(body, result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// copyAlbVirtualService returns a deep copy of the ALB Virtual Service, so that stored state is not
+// shared with the caller
+func copyAlbVirtualService(virtualService *types.NsxtAlbVirtualService) *types.NsxtAlbVirtualService {
+	body, err := json.Marshal(virtualService)
+	if err != nil {
+		panic(err)
+	}
+	result := &types.NsxtAlbVirtualService{}
+	if err := json.Unmarshal(body, result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// visibleAlbPool returns the ALB Pool with the given ID or nil if it does not exist or is not
+// visible in the session
+func (server *Server) visibleAlbPool(s *session, id string) *albPool {
+	p := server.albPools[uuidFromId(id)]
+	if p == nil || !s.canAccess(p.egw.vdc.org) {
+		return nil
+	}
+	return p
+}
+
+// visibleAlbVirtualService returns the ALB Virtual Service with the given ID or nil if it does not
+// exist or is not visible in the session
+func (server *Server) visibleAlbVirtualService(s *session, id string) *albVirtualService {
+	vs := server.albVirtualServices[uuidFromId(id)]
+	if vs == nil || !s.canAccess(vs.egw.vdc.org) {
+		return nil
+	}
+	return vs
+}
+
+// albGateway returns the Edge Gateway of an ALB entity. It writes an error and returns nil if the
+// Edge Gateway does not exist or is not visible
+func (server *Server) albGateway(w http.ResponseWriter, r *http.Request, s *session, kind, name string, gatewayRef types.OpenApiReference) *edgeGateway {
+	egw := server.visibleEdgeGateway(s, gatewayRef.ID)
+	if egw == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Edge Gateway '%s' of %s '%s' does not exist", gatewayRef.ID, kind, name)
+	}
+	return egw
+}
+
+// validateAlbPool checks the ALB Pool sent by the client. It writes an error and returns nil if
+// the pool is not valid
+func (server *Server) validateAlbPool(w http.ResponseWriter, r *http.Request, s *session, pool *types.NsxtAlbPool) *albPool {
+	if pool.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Pool name cannot be empty")
+		return nil
+	}
+	egw := server.albGateway(w, r, s, "ALB Pool", pool.Name, pool.GatewayRef)
+	if egw == nil {
+		return nil
+	}
+	pool.GatewayRef = types.OpenApiReference{ID: egw.gateway.ID, Name: egw.gateway.Name}
+	for _, existing := range server.albPools {
+		if existing.egw == egw && existing.pool.Name == pool.Name && existing.pool.ID != pool.ID {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "ALB Pool with name '%s' already exists in Edge Gateway '%s'",
+				pool.Name, egw.gateway.Name)
+			return nil
+		}
+	}
+	return &albPool{pool: copyAlbPool(pool), egw: egw}
+}
+
+// validateAlbVirtualService checks the ALB Virtual Service sent by the client. It writes an error
+// and returns nil if the Virtual Service is not valid
+func (server *Server) validateAlbVirtualService(w http.ResponseWriter, r *http.Request, s *session, virtualService *types.NsxtAlbVirtualService) *albVirtualService {
+	name := virtualService.Name
+	if name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Virtual Service name cannot be empty")
+		return nil
+	}
+	egw := server.albGateway(w, r, s, "ALB Virtual Service", name, virtualService.GatewayRef)
+	if egw == nil {
+		return nil
+	}
+	virtualService.GatewayRef = types.OpenApiReference{ID: egw.gateway.ID, Name: egw.gateway.Name}
+	pool := server.visibleAlbPool(s, virtualService.LoadBalancerPoolRef.ID)
+	if pool == nil || pool.egw != egw {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Pool '%s' of ALB Virtual Service '%s' does not exist in Edge Gateway '%s'",
+			virtualService.LoadBalancerPoolRef.ID, name, egw.gateway.Name)
+		return nil
+	}
+	virtualService.LoadBalancerPoolRef = types.OpenApiReference{ID: pool.pool.ID, Name: pool.pool.Name}
+	if virtualService.ServiceEngineGroupRef.ID == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Virtual Service '%s' must have a Service Engine Group", name)
+		return nil
+	}
+	if virtualService.VirtualIpAddress == "" || len(virtualService.ServicePorts) == 0 {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Virtual Service '%s' must have a virtual IP address and at least one service port", name)
+		return nil
+	}
+	for _, existing := range server.albVirtualServices {
+		if existing.egw == egw && existing.virtualService.Name == name && existing.virtualService.ID != virtualService.ID {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "ALB Virtual Service with name '%s' already exists in Edge Gateway '%s'",
+				name, egw.gateway.Name)
+			return nil
+		}
+	}
+	return &albVirtualService{virtualService: copyAlbVirtualService(virtualService), egw: egw}
+}
+
+func (server *Server) albPoolReference(pool *types.NsxtAlbPool) *types.Reference {
+	return &types.Reference{
+		HREF: server.URL + "/cloudapi/1.0.0/loadBalancer/pools/" + pool.ID,
+		ID:   pool.ID,
+		Type: types.JSONMime,
+		Name: pool.Name,
+	}
+}
+
+func (server *Server) albVirtualServiceReference(virtualService *types.NsxtAlbVirtualService) *types.Reference {
+	return &types.Reference{
+		HREF: server.URL + "/cloudapi/1.0.0/loadBalancer/virtualServices/" + virtualService.ID,
+		ID:   virtualService.ID,
+		Type: types.JSONMime,
+		Name: virtualService.Name,
+	}
+}
+
+func (server *Server) getAlbPoolSummaries(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	var pools []*types.NsxtAlbPool
+	for _, p := range sortedValues(server.albPools, compareAlbPools) {
+		if p.egw == egw {
+			pools = append(pools, p.pool)
+		}
+	}
+	writeOpenApiPage(w, r, pools, func(pool *types.NsxtAlbPool) map[string]string {
+		return map[string]string{"id": pool.ID, "name": pool.Name}
+	})
+}
+
+func (server *Server) getAlbPool(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.visibleAlbPool(s, r.PathValue("id"))
+	if p == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, p.pool)
+}
+
+func (server *Server) createAlbPool(w http.ResponseWriter, r *http.Request, s *session) {
+	pool := &types.NsxtAlbPool{}
+	if !decodeJson(w, r, pool) {
+		return
+	}
+	pool.ID = "urn:vcloud:loadBalancerPool:" + newUuid()
+	p := server.validateAlbPool(w, r, s, pool)
+	if p == nil {
+		return
+	}
+	server.albPools[uuidFromId(pool.ID)] = p
+	writeOpenApiTask(w, server.newTask(s, "createLoadBalancerPool", "Created ALB Pool "+pool.Name, server.albPoolReference(pool)))
+}
+
+func (server *Server) updateAlbPool(w http.ResponseWriter, r *http.Request, s *session) {
+	existing := server.visibleAlbPool(s, r.PathValue("id"))
+	if existing == nil {
+		writeNotFound(w, r)
+		return
+	}
+	pool := &types.NsxtAlbPool{}
+	if !decodeJson(w, r, pool) {
+		return
+	}
+	if pool.ID != existing.pool.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Pool ID '%s' does not match '%s'", pool.ID, existing.pool.ID)
+		return
+	}
+	p := server.validateAlbPool(w, r, s, pool)
+	if p == nil {
+		return
+	}
+	if p.egw != existing.egw {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "the Edge Gateway of ALB Pool '%s' cannot be changed", existing.pool.Name)
+		return
+	}
+	server.albPools[uuidFromId(pool.ID)] = p
+	writeOpenApiTask(w, server.newTask(s, "updateLoadBalancerPool", "Updated ALB Pool "+pool.Name, server.albPoolReference(pool)))
+}
+
+// deleteAlbPool deletes an ALB Pool. Like VCD, pools used by Virtual Services cannot be deleted
+func (server *Server) deleteAlbPool(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.visibleAlbPool(s, r.PathValue("id"))
+	if p == nil {
+		writeNotFound(w, r)
+		return
+	}
+	for _, vs := range server.albVirtualServices {
+		if vs.virtualService.LoadBalancerPoolRef.ID == p.pool.ID {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Pool '%s' is used by ALB Virtual Service '%s'",
+				p.pool.Name, vs.virtualService.Name)
+			return
+		}
+	}
+	delete(server.albPools, uuidFromId(p.pool.ID))
+	writeOpenApiTask(w, server.newTask(s, "deleteLoadBalancerPool", "Deleted ALB Pool "+p.pool.Name, server.albPoolReference(p.pool)))
+}
+
+func (server *Server) getAlbVirtualServiceSummaries(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	var virtualServices []*types.NsxtAlbVirtualService
+	for _, vs := range sortedValues(server.albVirtualServices, compareAlbVirtualServices) {
+		if vs.egw == egw {
+			virtualServices = append(virtualServices, vs.virtualService)
+		}
+	}
+	writeOpenApiPage(w, r, virtualServices, func(virtualService *types.NsxtAlbVirtualService) map[string]string {
+		return map[string]string{"id": virtualService.ID, "name": virtualService.Name}
+	})
+}
+
+func (server *Server) getAlbVirtualService(w http.ResponseWriter, r *http.Request, s *session) {
+	vs := server.visibleAlbVirtualService(s, r.PathValue("id"))
+	if vs == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, vs.virtualService)
+}
+
+func (server *Server) createAlbVirtualService(w http.ResponseWriter, r *http.Request, s *session) {
+	virtualService := &types.NsxtAlbVirtualService{}
+	if !decodeJson(w, r, virtualService) {
+		return
+	}
+	virtualService.ID = "urn:vcloud:loadBalancerVirtualService:" + newUuid()
+	vs := server.validateAlbVirtualService(w, r, s, virtualService)
+	if vs == nil {
+		return
+	}
+	server.albVirtualServices[uuidFromId(virtualService.ID)] = vs
+	writeOpenApiTask(w, server.newTask(s, "createLoadBalancerVirtualService", "Created ALB Virtual Service "+virtualService.Name,
+		server.albVirtualServiceReference(virtualService)))
+}
+
+func (server *Server) updateAlbVirtualService(w http.ResponseWriter, r *http.Request, s *session) {
+	existing := server.visibleAlbVirtualService(s, r.PathValue("id"))
+	if existing == nil {
+		writeNotFound(w, r)
+		return
+	}
+	virtualService := &types.NsxtAlbVirtualService{}
+	if !decodeJson(w, r, virtualService) {
+		return
+	}
+	if virtualService.ID != existing.virtualService.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "ALB Virtual Service ID '%s' does not match '%s'",
+			virtualService.ID, existing.virtualService.ID)
+		return
+	}
+	vs := server.validateAlbVirtualService(w, r, s, virtualService)
+	if vs == nil {
+		return
+	}
+	if vs.egw != existing.egw {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "the Edge Gateway of ALB Virtual Service '%s' cannot be changed",
+			existing.virtualService.Name)
+		return
+	}
+	server.albVirtualServices[uuidFromId(virtualService.ID)] = vs
+	writeOpenApiTask(w, server.newTask(s, "updateLoadBalancerVirtualService", "Updated ALB Virtual Service "+virtualService.Name,
+		server.albVirtualServiceReference(virtualService)))
+}
+
+func (server *Server) deleteAlbVirtualService(w http.ResponseWriter, r *http.Request, s *session) {
+	vs := server.visibleAlbVirtualService(s, r.PathValue("id"))
+	if vs == nil {
+		writeNotFound(w, r)
+		return
+	}
+	delete(server.albVirtualServices, uuidFromId(vs.virtualService.ID))
+	writeOpenApiTask(w, server.newTask(s, "deleteLoadBalancerVirtualService", "Deleted ALB Virtual Service "+vs.virtualService.Name,
+		server.albVirtualServiceReference(vs.virtualService)))
+}
+
+func (server *Server) getAlbSettings(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, egw.albSettings)
+}
+
+// updateAlbSettings replaces the ALB settings of the Edge Gateway. NSX-T ALB Clouds are not
+// modelled, therefore any cloud is accepted
+func (server *Server) updateAlbSettings(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	settings := types.NsxtAlbConfig{}
+	if !decodeJson(w, r, &settings) {
+		return
+	}
+	egw.albSettings = settings
+	writeOpenApiTask(w, server.newTask(s, "updateAlbSettings", "Updated ALB settings of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
@@ -0,0 +1,257 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// appPortProfile is an NSX-T Application Port Profile. SYSTEM profiles are created by NewServer
+// and visible to all orgs, TENANT profiles belong to a VDC or a VDC Group of an org
+type appPortProfile struct {
+	profile *types.NsxtAppPortProfile
+	// org is nil for SYSTEM profiles
+	org *org
+}
+
+func compareAppPortProfiles(a, b *appPortProfile) int {
+	return cmp.Or(cmp.Compare(a.profile.Scope, b.profile.Scope), cmp.Compare(a.profile.Name, b.profile.Name),
+		cmp.Compare(a.profile.ID, b.profile.ID))
+}
+
+// copyAppPortProfile returns a deep copy of the Application Port Profile, so that stored state is
+// not shared with the caller
+func copyAppPortProfile(profile *types.NsxtAppPortProfile) *types.NsxtAppPortProfile {
+	body, err := json.Marshal(profile)
+	if err != nil {
+		panic(err)
+	}
+	result := &types.NsxtAppPortProfile{}
+	if err := json.Unmarshal(body, result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// addSystemProfiles creates the SYSTEM Application Port Profiles and Network Context Profiles
+func (server *Server) addSystemProfiles() {
+	systemPorts := map[string]types.NsxtAppPortProfilePort{
+		"HTTP":    {Protocol: "TCP", DestinationPorts: []string{"80"}},
+		"HTTPS":   {Protocol: "TCP", DestinationPorts: []string{"443"}},
+		"SSH":     {Protocol: "TCP", DestinationPorts: []string{"22"}},
+		"DNS-UDP": {Protocol: "UDP", DestinationPorts: []string{"53"}},
+	}
+	for name, port := range systemPorts {
+		profile := &types.NsxtAppPortProfile{
+			ID:               "urn:vcloud:applicationPortProfile:" + newUuid(),
+			Name:             name,
+			ApplicationPorts: []types.NsxtAppPortProfilePort{port},
+			Scope:            types.ApplicationPortProfileScopeSystem,
+		}
+		server.appPortProfiles[uuidFromId(profile.ID)] = &appPortProfile{profile: profile}
+	}
+	for _, name := range []string{"DNS", "HTTP", "SSL"} {
+		server.networkContextProfiles = append(server.networkContextProfiles, &types.NsxtNetworkContextProfile{
+			ID:    "urn:vcloud:networkContextProfile:" + newUuid(),
+			Name:  name,
+			Scope: types.ApplicationPortProfileScopeSystem,
+			Attributes: []types.NsxtNetworkContextProfileAttributes{
+				{Type: "APP_ID", Values: []string{name}},
+			},
+		})
+	}
+}
+
+// inContext returns true if the Application Port Profile is available in the context of a
+// '_context' filter. SYSTEM profiles are available everywhere, TENANT profiles in their VDC or VDC
+// Group
+func (p *appPortProfile) inContext(id string) bool {
+	return p.profile.Scope == types.ApplicationPortProfileScopeSystem || uuidFromId(p.profile.ContextEntityId) == uuidFromId(id)
+}
+
+// visibleAppPortProfile returns the Application Port Profile with the given ID or nil if it does
+// not exist or is not visible in the session
+func (server *Server) visibleAppPortProfile(s *session, id string) *appPortProfile {
+	p := server.appPortProfiles[uuidFromId(id)]
+	if p == nil || (p.org != nil && !s.canAccess(p.org)) {
+		return nil
+	}
+	return p
+}
+
+// validateAppPortProfile checks the configuration sent by the client. Only TENANT profiles can be
+// created. It writes an error and returns nil if the configuration is not valid
+func (server *Server) validateAppPortProfile(w http.ResponseWriter, r *http.Request, s *session, profile *types.NsxtAppPortProfile) *appPortProfile {
+	if profile.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Application Port Profile name cannot be empty")
+		return nil
+	}
+	if profile.Scope != types.ApplicationPortProfileScopeTenant {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "only %s Application Port Profiles are supported by govcdtest, got '%s'",
+			types.ApplicationPortProfileScopeTenant, profile.Scope)
+		return nil
+	}
+	if len(profile.ApplicationPorts) == 0 {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Application Port Profile '%s' must have at least one port", profile.Name)
+		return nil
+	}
+	var o *org
+	if profile.OrgRef != nil {
+		o = server.orgs[uuidFromId(profile.OrgRef.ID)]
+	}
+	if o == nil || !s.canAccess(o) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "org of Application Port Profile '%s' does not exist", profile.Name)
+		return nil
+	}
+	profile.OrgRef = &types.OpenApiReference{ID: o.urn(), Name: o.name}
+
+	var contextOrg *org
+	switch {
+	case strings.HasPrefix(profile.ContextEntityId, "urn:vcloud:vdc:"):
+		if v := server.vdcs[uuidFromId(profile.ContextEntityId)]; v != nil {
+			contextOrg = v.org
+		}
+	case strings.HasPrefix(profile.ContextEntityId, "urn:vcloud:vdcGroup:"):
+		if g := server.vdcGroups[uuidFromId(profile.ContextEntityId)]; g != nil {
+			contextOrg = g.org
+		}
+	}
+	if contextOrg != o {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "context '%s' of Application Port Profile '%s' is not a VDC or a VDC Group of org '%s'",
+			profile.ContextEntityId, profile.Name, o.name)
+		return nil
+	}
+
+	for _, existing := range server.appPortProfiles {
+		if existing.profile.ID != profile.ID && existing.profile.Name == profile.Name && existing.profile.Scope == profile.Scope &&
+			existing.profile.ContextEntityId == profile.ContextEntityId {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "Application Port Profile with name '%s' already exists", profile.Name)
+			return nil
+		}
+	}
+	return &appPortProfile{profile: copyAppPortProfile(profile), org: o}
+}
+
+func (server *Server) appPortProfileReference(profile *types.NsxtAppPortProfile) *types.Reference {
+	return &types.Reference{
+		HREF: server.URL + "/cloudapi/1.0.0/applicationPortProfiles/" + profile.ID,
+		ID:   profile.ID,
+		Type: types.JSONMime,
+		Name: profile.Name,
+	}
+}
+
+// getAppPortProfiles lists the Application Port Profiles. The '_context' filter returns the
+// SYSTEM profiles and the TENANT profiles of a VDC or a VDC Group
+func (server *Server) getAppPortProfiles(w http.ResponseWriter, r *http.Request, s *session) {
+	f, ok := requestFilter(w, r)
+	if !ok {
+		return
+	}
+	f, context := f.without("_context")
+	var profiles []*types.NsxtAppPortProfile
+	for _, p := range sortedValues(server.appPortProfiles, compareAppPortProfiles) {
+		if (p.org == nil || s.canAccess(p.org)) && (context == "" || p.inContext(context)) {
+			profiles = append(profiles, p.profile)
+		}
+	}
+	writeFilteredOpenApiPage(w, r, profiles, f, func(profile *types.NsxtAppPortProfile) map[string]string {
+		orgId := ""
+		if profile.OrgRef != nil {
+			orgId = profile.OrgRef.ID
+		}
+		return map[string]string{
+			"id":              profile.ID,
+			"name":            profile.Name,
+			"scope":           profile.Scope,
+			"orgRef.id":       orgId,
+			"contextEntityId": profile.ContextEntityId,
+		}
+	})
+}
+
+func (server *Server) getAppPortProfile(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.visibleAppPortProfile(s, r.PathValue("id"))
+	if p == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, p.profile)
+}
+
+func (server *Server) createAppPortProfile(w http.ResponseWriter, r *http.Request, s *session) {
+	profile := &types.NsxtAppPortProfile{}
+	if !decodeJson(w, r, profile) {
+		return
+	}
+	profile.ID = "urn:vcloud:applicationPortProfile:" + newUuid()
+	p := server.validateAppPortProfile(w, r, s, profile)
+	if p == nil {
+		return
+	}
+	server.appPortProfiles[uuidFromId(profile.ID)] = p
+	writeOpenApiTask(w, server.newTask(s, "createApplicationPortProfile", "Created Application Port Profile "+profile.Name,
+		server.appPortProfileReference(profile)))
+}
+
+func (server *Server) updateAppPortProfile(w http.ResponseWriter, r *http.Request, s *session) {
+	existing := server.visibleAppPortProfile(s, r.PathValue("id"))
+	if existing == nil {
+		writeNotFound(w, r)
+		return
+	}
+	if existing.org == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s Application Port Profile '%s' is read-only", existing.profile.Scope, existing.profile.Name)
+		return
+	}
+	profile := &types.NsxtAppPortProfile{}
+	if !decodeJson(w, r, profile) {
+		return
+	}
+	if profile.ID != existing.profile.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Application Port Profile ID '%s' does not match '%s'", profile.ID, existing.profile.ID)
+		return
+	}
+	if profile.ContextEntityId != existing.profile.ContextEntityId {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "the context of Application Port Profile '%s' cannot be changed", existing.profile.Name)
+		return
+	}
+	p := server.validateAppPortProfile(w, r, s, profile)
+	if p == nil {
+		return
+	}
+	server.appPortProfiles[uuidFromId(profile.ID)] = p
+	writeOpenApiTask(w, server.newTask(s, "updateApplicationPortProfile", "Updated Application Port Profile "+profile.Name,
+		server.appPortProfileReference(profile)))
+}
+
+func (server *Server) deleteAppPortProfile(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.visibleAppPortProfile(s, r.PathValue("id"))
+	if p == nil {
+		writeNotFound(w, r)
+		return
+	}
+	if p.org == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s Application Port Profile '%s' is read-only", p.profile.Scope, p.profile.Name)
+		return
+	}
+	delete(server.appPortProfiles, uuidFromId(p.profile.ID))
+	writeOpenApiTask(w, server.newTask(s, "deleteApplicationPortProfile", "Deleted Application Port Profile "+p.profile.Name,
+		server.appPortProfileReference(p.profile)))
+}
+
+// getNetworkContextProfiles lists the SYSTEM Network Context Profiles, which are available in any
+// '_context'
+func (server *Server) getNetworkContextProfiles(w http.ResponseWriter, r *http.Request, s *session) {
+	f, ok := requestFilter(w, r)
+	if !ok {
+		return
+	}
+	f, _ = f.without("_context")
+	writeFilteredOpenApiPage(w, r, server.networkContextProfiles, f, func(profile *types.NsxtNetworkContextProfile) map[string]string {
+		return map[string]string{"id": profile.ID, "name": profile.Name, "scope": profile.Scope}
+	})
+}
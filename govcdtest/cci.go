@@ -0,0 +1,370 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cciPath is the path of the Kubernetes API of the Cloud Consumption Interface (CCI)
+const cciPath = "/cci/kubernetes"
+
+// supervisorNamespaceReadyAfter is the number of retrievals after which a new Supervisor Namespace
+// is ready
+const supervisorNamespaceReadyAfter = 2
+
+// cciProject is a CCI Project with its Supervisor Namespaces
+type cciProject struct {
+	project    *ccitypes.Project
+	org        *org
+	namespaces map[string]*supervisorNamespace
+}
+
+// supervisorNamespace is a Supervisor Namespace, which becomes ready after a few retrievals unless
+// its status was set with SetSupervisorNamespaceStatus
+type supervisorNamespace struct {
+	namespace   *ccitypes.SupervisorNamespace
+	retrievals  int
+	fixedStatus bool
+}
+
+func compareCciProjects(a, b *cciProject) int {
+	return cmp.Compare(a.project.Name, b.project.Name)
+}
+
+func compareSupervisorNamespaces(a, b *supervisorNamespace) int {
+	return cmp.Compare(a.namespace.Name, b.namespace.Name)
+}
+
+// SetSupervisorNamespaceStatus replaces the status of a Supervisor Namespace, for example to make
+// it fail with an error condition. The namespace keeps the given status until it is deleted
+func (server *Server) SetSupervisorNamespaceStatus(projectName, name string, status ccitypes.SupervisorNamespaceStatus) error {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	p := server.cciProjects[projectName]
+	if p == nil {
+		return fmt.Errorf("CCI Project '%s' does not exist", projectName)
+	}
+	ns := p.namespaces[name]
+	if ns == nil {
+		return fmt.Errorf("Supervisor Namespace '%s' does not exist in CCI Project '%s'", name, projectName)
+	}
+	ns.namespace.Status = &status
+	ns.fixedStatus = true
+	return nil
+}
+
+// nextCciResourceVersion returns the resource version of a changed CCI entity. Like in Kubernetes,
+// resource versions are shared by all entities
+func (server *Server) nextCciResourceVersion() string {
+	server.cciResourceVersion++
+	return strconv.Itoa(server.cciResourceVersion)
+}
+
+// writeCciError writes an error as a Kubernetes Status
+func writeCciError(w http.ResponseWriter, status int, reason v1.StatusReason, format string, args ...any) {
+	writeJson(w, status, &v1.Status{
+		TypeMeta: v1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   v1.StatusFailure,
+		Message:  fmt.Sprintf(format, args...),
+		Reason:   reason,
+		Code:     int32(status),
+	})
+}
+
+// writeCciDeleted writes the response of a deletion
+func writeCciDeleted(w http.ResponseWriter) {
+	writeJson(w, http.StatusOK, &v1.Status{
+		TypeMeta: v1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   v1.StatusSuccess,
+	})
+}
+
+// decodeCciEntity decodes the JSON body of the request. It writes an error and returns false if
+// the body is not valid
+func decodeCciEntity(w http.ResponseWriter, r *http.Request, value any) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeCciError(w, http.StatusBadRequest, v1.StatusReasonBadRequest, "error decoding request body: %s", err)
+		return false
+	}
+	return true
+}
+
+// cciListPage returns the start and the end of the page requested with the query parameters
+// 'limit' and 'continue', and the continue token of the next page, which is empty for the last
+// page. It writes an error and returns false if the parameters are not valid
+func cciListPage(w http.ResponseWriter, r *http.Request, total int) (int, int, string, bool) {
+	query := r.URL.Query()
+	for _, param := range []string{"watch", "labelSelector", "fieldSelector"} {
+		if query.Get(param) != "" {
+			writeCciError(w, http.StatusBadRequest, v1.StatusReasonBadRequest, "parameter '%s' is not supported by govcdtest", param)
+			return 0, 0, "", false
+		}
+	}
+	start, end := 0, total
+	if query.Get("continue") != "" {
+		var err error
+		start, err = strconv.Atoi(query.Get("continue"))
+		if err != nil || start < 0 || start > total {
+			writeCciError(w, http.StatusGone, v1.StatusReasonExpired, "continue token '%s' is not valid", query.Get("continue"))
+			return 0, 0, "", false
+		}
+	}
+	if query.Get("limit") != "" {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit < 0 {
+			writeCciError(w, http.StatusBadRequest, v1.StatusReasonBadRequest, "limit '%s' is not valid", query.Get("limit"))
+			return 0, 0, "", false
+		}
+		if limit > 0 {
+			end = min(start+limit, total)
+		}
+	}
+	continueToken := ""
+	if end < total {
+		continueToken = strconv.Itoa(end)
+	}
+	return start, end, continueToken, true
+}
+
+// visibleCciProject returns the CCI Project with the given name or nil if it does not exist or is
+// not visible in the session
+func (server *Server) visibleCciProject(s *session, name string) *cciProject {
+	p := server.cciProjects[name]
+	if p == nil || !s.canAccess(p.org) {
+		return nil
+	}
+	return p
+}
+
+// requestCciProject returns the CCI Project of the request path. It writes an error and returns nil
+// if the Project does not exist or is not visible
+func (server *Server) requestCciProject(w http.ResponseWriter, r *http.Request, s *session, pathValue string) *cciProject {
+	p := server.visibleCciProject(s, r.PathValue(pathValue))
+	if p == nil {
+		writeCciError(w, http.StatusNotFound, v1.StatusReasonNotFound, "projects '%s' not found", r.PathValue(pathValue))
+	}
+	return p
+}
+
+func (server *Server) getCciProjects(w http.ResponseWriter, r *http.Request, s *session) {
+	var projects []*cciProject
+	for _, p := range sortedValues(server.cciProjects, compareCciProjects) {
+		if s.canAccess(p.org) {
+			projects = append(projects, p)
+		}
+	}
+	start, end, continueToken, ok := cciListPage(w, r, len(projects))
+	if !ok {
+		return
+	}
+	list := &ccitypes.ProjectList{
+		TypeMeta: v1.TypeMeta{Kind: ccitypes.ProjectKind + "List", APIVersion: ccitypes.ProjectAPI + "/" + ccitypes.ProjectVersion},
+		ListMeta: v1.ListMeta{ResourceVersion: strconv.Itoa(server.cciResourceVersion), Continue: continueToken},
+		Items:    []ccitypes.Project{},
+	}
+	for _, p := range projects[start:end] {
+		list.Items = append(list.Items, *p.project)
+	}
+	writeJson(w, http.StatusOK, list)
+}
+
+func (server *Server) getCciProject(w http.ResponseWriter, r *http.Request, s *session) {
+	if p := server.requestCciProject(w, r, s, "name"); p != nil {
+		writeJson(w, http.StatusOK, p.project)
+	}
+}
+
+func (server *Server) createCciProject(w http.ResponseWriter, r *http.Request, s *session) {
+	project := &ccitypes.Project{}
+	if !decodeCciEntity(w, r, project) {
+		return
+	}
+	if project.Name == "" {
+		writeCciError(w, http.StatusUnprocessableEntity, v1.StatusReasonInvalid, "metadata.name: Required value")
+		return
+	}
+	if server.cciProjects[project.Name] != nil {
+		writeCciError(w, http.StatusConflict, v1.StatusReasonAlreadyExists, "projects '%s' already exists", project.Name)
+		return
+	}
+	project.ResourceVersion = server.nextCciResourceVersion()
+	project.CreationTimestamp = v1.Now()
+	server.cciProjects[project.Name] = &cciProject{project: project, org: s.org, namespaces: make(map[string]*supervisorNamespace)}
+	writeJson(w, http.StatusCreated, project)
+}
+
+func (server *Server) updateCciProject(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "name")
+	if p == nil {
+		return
+	}
+	project := &ccitypes.Project{}
+	if !decodeCciEntity(w, r, project) {
+		return
+	}
+	if project.Name != p.project.Name {
+		writeCciError(w, http.StatusBadRequest, v1.StatusReasonBadRequest, "name '%s' does not match '%s'", project.Name, p.project.Name)
+		return
+	}
+	if project.ResourceVersion != "" && project.ResourceVersion != p.project.ResourceVersion {
+		writeCciError(w, http.StatusConflict, v1.StatusReasonConflict,
+			"Operation cannot be fulfilled on projects '%s': the object has been modified; please apply your changes to the latest version and try again",
+			project.Name)
+		return
+	}
+	project.CreationTimestamp = p.project.CreationTimestamp
+	project.ResourceVersion = server.nextCciResourceVersion()
+	p.project = project
+	writeJson(w, http.StatusOK, project)
+}
+
+func (server *Server) deleteCciProject(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "name")
+	if p == nil {
+		return
+	}
+	if len(p.namespaces) > 0 {
+		writeCciError(w, http.StatusConflict, v1.StatusReasonConflict, "projects '%s' has %d Supervisor Namespaces", p.project.Name, len(p.namespaces))
+		return
+	}
+	delete(server.cciProjects, p.project.Name)
+	writeCciDeleted(w)
+}
+
+// refreshSupervisorNamespace counts a retrieval of the Supervisor Namespace and makes it ready after
+// supervisorNamespaceReadyAfter retrievals
+func (server *Server) refreshSupervisorNamespace(ns *supervisorNamespace) {
+	ns.retrievals++
+	if ns.fixedStatus || ns.retrievals < supervisorNamespaceReadyAfter {
+		return
+	}
+	ns.namespace.Status = &ccitypes.SupervisorNamespaceStatus{
+		Phase:      "CREATED",
+		Conditions: []ccitypes.SupervisorNamespaceStatusConditions{{Type: "Ready", Status: "True"}},
+	}
+}
+
+func (server *Server) getSupervisorNamespaces(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "project")
+	if p == nil {
+		return
+	}
+	namespaces := sortedValues(p.namespaces, compareSupervisorNamespaces)
+	start, end, continueToken, ok := cciListPage(w, r, len(namespaces))
+	if !ok {
+		return
+	}
+	list := &ccitypes.SupervisorNamespaceList{
+		TypeMeta: v1.TypeMeta{
+			Kind:       ccitypes.SupervisorNamespaceKind + "List",
+			APIVersion: ccitypes.SupervisorNamespaceAPI + "/" + ccitypes.SupervisorNamespaceVersion,
+		},
+		ListMeta: v1.ListMeta{ResourceVersion: strconv.Itoa(server.cciResourceVersion), Continue: continueToken},
+		Items:    []ccitypes.SupervisorNamespace{},
+	}
+	for _, ns := range namespaces[start:end] {
+		server.refreshSupervisorNamespace(ns)
+		list.Items = append(list.Items, *ns.namespace)
+	}
+	writeJson(w, http.StatusOK, list)
+}
+
+func (server *Server) getSupervisorNamespace(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "project")
+	if p == nil {
+		return
+	}
+	ns := p.namespaces[r.PathValue("name")]
+	if ns == nil {
+		writeCciError(w, http.StatusNotFound, v1.StatusReasonNotFound, "supervisornamespaces '%s' not found", r.PathValue("name"))
+		return
+	}
+	server.refreshSupervisorNamespace(ns)
+	writeJson(w, http.StatusOK, ns.namespace)
+}
+
+func (server *Server) createSupervisorNamespace(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "project")
+	if p == nil {
+		return
+	}
+	namespace := &ccitypes.SupervisorNamespace{}
+	if !decodeCciEntity(w, r, namespace) {
+		return
+	}
+	if namespace.Name == "" && namespace.GenerateName == "" {
+		writeCciError(w, http.StatusUnprocessableEntity, v1.StatusReasonInvalid, "metadata.name: Required value: name or generateName is required")
+		return
+	}
+	if namespace.Name == "" {
+		// Like Kubernetes, a random suffix of 5 characters is added to the prefix
+		namespace.Name = namespace.GenerateName + randomHex(5)
+	}
+	if p.namespaces[namespace.Name] != nil {
+		writeCciError(w, http.StatusConflict, v1.StatusReasonAlreadyExists, "supervisornamespaces '%s' already exists", namespace.Name)
+		return
+	}
+	namespace.Namespace = p.project.Name
+	namespace.ResourceVersion = server.nextCciResourceVersion()
+	namespace.CreationTimestamp = v1.Now()
+	namespace.Status = &ccitypes.SupervisorNamespaceStatus{
+		Phase:      "CREATING",
+		Conditions: []ccitypes.SupervisorNamespaceStatusConditions{{Type: "Ready", Status: "False", Reason: "Creating"}},
+	}
+	p.namespaces[namespace.Name] = &supervisorNamespace{namespace: namespace}
+	writeJson(w, http.StatusCreated, namespace)
+}
+
+func (server *Server) updateSupervisorNamespace(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "project")
+	if p == nil {
+		return
+	}
+	ns := p.namespaces[r.PathValue("name")]
+	if ns == nil {
+		writeCciError(w, http.StatusNotFound, v1.StatusReasonNotFound, "supervisornamespaces '%s' not found", r.PathValue("name"))
+		return
+	}
+	namespace := &ccitypes.SupervisorNamespace{}
+	if !decodeCciEntity(w, r, namespace) {
+		return
+	}
+	if namespace.Name != ns.namespace.Name {
+		writeCciError(w, http.StatusBadRequest, v1.StatusReasonBadRequest, "name '%s' does not match '%s'", namespace.Name, ns.namespace.Name)
+		return
+	}
+	if namespace.ResourceVersion != "" && namespace.ResourceVersion != ns.namespace.ResourceVersion {
+		writeCciError(w, http.StatusConflict, v1.StatusReasonConflict,
+			"Operation cannot be fulfilled on supervisornamespaces '%s': the object has been modified; please apply your changes to the latest version and try again",
+			namespace.Name)
+		return
+	}
+	// The status is not changed by updates
+	namespace.Namespace = p.project.Name
+	namespace.CreationTimestamp = ns.namespace.CreationTimestamp
+	namespace.Status = ns.namespace.Status
+	namespace.ResourceVersion = server.nextCciResourceVersion()
+	ns.namespace = namespace
+	writeJson(w, http.StatusOK, namespace)
+}
+
+func (server *Server) deleteSupervisorNamespace(w http.ResponseWriter, r *http.Request, s *session) {
+	p := server.requestCciProject(w, r, s, "project")
+	if p == nil {
+		return
+	}
+	name := r.PathValue("name")
+	if p.namespaces[name] == nil {
+		writeCciError(w, http.StatusNotFound, v1.StatusReasonNotFound, "supervisornamespaces '%s' not found", name)
+		return
+	}
+	delete(p.namespaces, name)
+	writeCciDeleted(w)
+}
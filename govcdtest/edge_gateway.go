@@ -0,0 +1,367 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// edgeGateway is an NSX-T Edge Gateway owned by a VDC
+type edgeGateway struct {
+	gateway *types.OpenAPIEdgeGateway
+	vdc     *vdc
+
+	// firewallRules are the user defined firewall rules, in their order of evaluation
+	firewallRules []firewallRule
+	// firewallVersion is incremented at every change of the firewall rules
+	firewallVersion int
+	natRules        []*types.NsxtNatRule
+	dhcpForwarder   types.NsxtEdgeGatewayDhcpForwarder
+	dns             types.NsxtEdgeGatewayDns
+	slaacProfile    types.NsxtEdgeGatewaySlaacProfile
+	// qos references Gateway QoS Profiles, which are not modelled
+	qos         types.NsxtEdgeGatewayQos
+	albSettings types.NsxtAlbConfig
+
+	staticRoutes       []*types.NsxtEdgeGatewayStaticRoute
+	routeAdvertisement types.RouteAdvertisement
+	bgpConfig          types.EdgeBgpConfig
+	bgpIpPrefixLists   []*types.EdgeBgpIpPrefixList
+	bgpNeighbors       []*types.EdgeBgpNeighbor
+
+	ipSecVpnTunnels []*ipSecVpnTunnel
+	l2VpnTunnels    []*types.NsxtL2VpnTunnel
+}
+
+func compareEdgeGateways(a, b *edgeGateway) int {
+	return cmp.Or(compareVdcs(a.vdc, b.vdc), cmp.Compare(a.gateway.Name, b.gateway.Name))
+}
+
+// copyEdgeGateway returns a deep copy of the Edge Gateway, so that stored state is not shared
+// with the caller
+func copyEdgeGateway(gateway *types.OpenAPIEdgeGateway) *types.OpenAPIEdgeGateway {
+	body, err := json.Marshal(gateway)
+	if err != nil {
+		panic(err)
+	}
+	result := &types.OpenAPIEdgeGateway{}
+	if err := json.Unmarshal(body, result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// visibleEdgeGateway returns the Edge Gateway with the given ID or nil if it does not exist or is
+// not visible in the session
+func (server *Server) visibleEdgeGateway(s *session, id string) *edgeGateway {
+	egw := server.edgeGateways[uuidFromId(id)]
+	if egw == nil || !s.canAccess(egw.vdc.org) {
+		return nil
+	}
+	return egw
+}
+
+// validateEdgeGateway checks the configuration sent by the client and returns the VDC that owns
+// the Edge Gateway. It writes an error and returns nil if the configuration is not valid
+func (server *Server) validateEdgeGateway(w http.ResponseWriter, r *http.Request, gateway *types.OpenAPIEdgeGateway, id string) *vdc {
+	if gateway.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Edge Gateway name cannot be empty")
+		return nil
+	}
+	if len(gateway.EdgeGatewayUplinks) == 0 {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Edge Gateway must have at least one uplink")
+		return nil
+	}
+	ownerRef := gateway.OwnerRef
+	if ownerRef == nil || ownerRef.ID == "" {
+		ownerRef = gateway.OrgVdc
+	}
+	if ownerRef == nil || ownerRef.ID == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Edge Gateway owner must be specified")
+		return nil
+	}
+	v := server.vdcs[uuidFromId(ownerRef.ID)]
+	if v == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "owner '%s' of Edge Gateway does not exist", ownerRef.ID)
+		return nil
+	}
+	for _, existing := range server.edgeGateways {
+		if existing.vdc.org == v.org && existing.gateway.Name == gateway.Name && existing.gateway.ID != id {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "Edge Gateway with name '%s' already exists in org '%s'", gateway.Name, v.org.name)
+			return nil
+		}
+	}
+	return v
+}
+
+// storeEdgeGateway saves the Edge Gateway setting the fields that are computed by VCD
+func (server *Server) storeEdgeGateway(gateway *types.OpenAPIEdgeGateway, v *vdc) {
+	gateway.Status = "REALIZED"
+	gateway.OwnerRef = &types.OpenApiReference{ID: v.urn(), Name: v.name}
+	gateway.OrgVdc = &types.OpenApiReference{ID: v.urn(), Name: v.name}
+	gateway.Org = &types.OpenApiReference{ID: v.org.urn(), Name: v.org.name}
+	if gateway.GatewayBacking == nil {
+		gateway.GatewayBacking = &types.OpenAPIEdgeGatewayBacking{}
+	}
+	if gateway.GatewayBacking.BackingID == "" {
+		gateway.GatewayBacking.BackingID = newUuid()
+	}
+	if gateway.GatewayBacking.GatewayType == "" {
+		gateway.GatewayBacking.GatewayType = "NSXT_BACKED"
+	}
+	// Services of the Edge Gateway, such as the firewall, are kept by updates
+	egw := server.edgeGateways[uuidFromId(gateway.ID)]
+	if egw == nil {
+		egw = &edgeGateway{}
+		server.edgeGateways[uuidFromId(gateway.ID)] = egw
+	}
+	egw.gateway = copyEdgeGateway(gateway)
+	egw.vdc = v
+}
+
+func (server *Server) edgeGatewayReference(gateway *types.OpenAPIEdgeGateway) *types.Reference {
+	return &types.Reference{
+		HREF: server.URL + "/cloudapi/1.0.0/edgeGateways/" + gateway.ID,
+		ID:   gateway.ID,
+		Type: types.JSONMime,
+		Name: gateway.Name,
+	}
+}
+
+func (server *Server) getEdgeGateways(w http.ResponseWriter, r *http.Request, s *session) {
+	var gateways []*types.OpenAPIEdgeGateway
+	for _, egw := range sortedValues(server.edgeGateways, compareEdgeGateways) {
+		if s.canAccess(egw.vdc.org) {
+			gateways = append(gateways, egw.gateway)
+		}
+	}
+	writeOpenApiPage(w, r, gateways, func(gateway *types.OpenAPIEdgeGateway) map[string]string {
+		return map[string]string{
+			"id":                         gateway.ID,
+			"name":                       gateway.Name,
+			"ownerRef.id":                gateway.OwnerRef.ID,
+			"orgVdc.id":                  gateway.OrgVdc.ID,
+			"orgRef.id":                  gateway.Org.ID,
+			"gatewayBacking.gatewayType": gateway.GatewayBacking.GatewayType,
+		}
+	})
+}
+
+func (server *Server) getEdgeGateway(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, egw.gateway)
+}
+
+func (server *Server) createEdgeGateway(w http.ResponseWriter, r *http.Request, s *session) {
+	if !s.isProvider() {
+		writeNotFound(w, r)
+		return
+	}
+	gateway := &types.OpenAPIEdgeGateway{}
+	if !decodeJson(w, r, gateway) {
+		return
+	}
+	gateway.ID = "urn:vcloud:gateway:" + newUuid()
+	v := server.validateEdgeGateway(w, r, gateway, gateway.ID)
+	if v == nil {
+		return
+	}
+	server.storeEdgeGateway(gateway, v)
+	writeOpenApiTask(w, server.newTask(s, "createEdgeGateway", "Created Edge Gateway "+gateway.Name, server.edgeGatewayReference(gateway)))
+}
+
+func (server *Server) updateEdgeGateway(w http.ResponseWriter, r *http.Request, s *session) {
+	if !s.isProvider() {
+		writeNotFound(w, r)
+		return
+	}
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	gateway := &types.OpenAPIEdgeGateway{}
+	if !decodeJson(w, r, gateway) {
+		return
+	}
+	if gateway.ID != egw.gateway.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Edge Gateway ID '%s' does not match '%s'", gateway.ID, egw.gateway.ID)
+		return
+	}
+	v := server.validateEdgeGateway(w, r, gateway, gateway.ID)
+	if v == nil {
+		return
+	}
+	if gateway.GatewayBacking == nil {
+		gateway.GatewayBacking = egw.gateway.GatewayBacking
+	}
+	server.storeEdgeGateway(gateway, v)
+	writeOpenApiTask(w, server.newTask(s, "updateEdgeGateway", "Updated Edge Gateway "+gateway.Name, server.edgeGatewayReference(gateway)))
+}
+
+func (server *Server) deleteEdgeGateway(w http.ResponseWriter, r *http.Request, s *session) {
+	if !s.isProvider() {
+		writeNotFound(w, r)
+		return
+	}
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	delete(server.edgeGateways, uuidFromId(egw.gateway.ID))
+	writeOpenApiTask(w, server.newTask(s, "deleteEdgeGateway", "Deleted Edge Gateway "+egw.gateway.Name, server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getDhcpForwarder(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	forwarder := egw.dhcpForwarder
+	if forwarder.DhcpServers == nil {
+		forwarder.DhcpServers = []string{}
+	}
+	writeJson(w, http.StatusOK, forwarder)
+}
+
+// updateDhcpForwarder replaces the DHCP forwarder of the Edge Gateway. Like VCD, the update must
+// send the current version of the forwarder
+func (server *Server) updateDhcpForwarder(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	forwarder := types.NsxtEdgeGatewayDhcpForwarder{}
+	if !decodeJson(w, r, &forwarder) {
+		return
+	}
+	if forwarder.Version.Version != egw.dhcpForwarder.Version.Version {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "version %d of the DHCP forwarder does not match %d",
+			forwarder.Version.Version, egw.dhcpForwarder.Version.Version)
+		return
+	}
+	if forwarder.Enabled && len(forwarder.DhcpServers) == 0 {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "enabled DHCP forwarder must have at least one DHCP server")
+		return
+	}
+	forwarder.Version.Version++
+	egw.dhcpForwarder = forwarder
+	writeOpenApiTask(w, server.newTask(s, "updateDhcpForwarder", "Updated DHCP forwarder of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getDns(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	dns := egw.dns
+	if dns.Version == nil {
+		dns.Version = &types.VersionField{}
+	}
+	writeJson(w, http.StatusOK, dns)
+}
+
+// updateDns replaces the DNS forwarder of the Edge Gateway and assigns IDs to the forwarder zones
+// which have none. Like VCD, the update must send the current version of the forwarder
+func (server *Server) updateDns(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	dns := types.NsxtEdgeGatewayDns{}
+	if !decodeJson(w, r, &dns) {
+		return
+	}
+	current := types.VersionField{}
+	if egw.dns.Version != nil {
+		current = *egw.dns.Version
+	}
+	if dns.Version == nil || dns.Version.Version != current.Version {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "version of the DNS forwarder does not match %d", current.Version)
+		return
+	}
+	if dns.Enabled && (dns.DefaultForwarderZone == nil || len(dns.DefaultForwarderZone.UpstreamServers) == 0) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "enabled DNS forwarder must have a default zone with upstream servers")
+		return
+	}
+	for _, zone := range append([]*types.NsxtDnsForwarderZoneConfig{dns.DefaultForwarderZone}, dns.ConditionalForwarderZones...) {
+		if zone != nil && zone.ID == "" {
+			zone.ID = newUuid()
+		}
+	}
+	dns.Version = &types.VersionField{Version: current.Version + 1}
+	egw.dns = dns
+	writeOpenApiTask(w, server.newTask(s, "updateDns", "Updated DNS forwarder of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getSlaacProfile(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	profile := egw.slaacProfile
+	if profile.Mode == "" {
+		profile.Mode = "DISABLED"
+	}
+	writeJson(w, http.StatusOK, profile)
+}
+
+func (server *Server) updateSlaacProfile(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	profile := types.NsxtEdgeGatewaySlaacProfile{}
+	if !decodeJson(w, r, &profile) {
+		return
+	}
+	if !slices.Contains([]string{"DISABLED", "SLAAC", "DHCPv6"}, profile.Mode) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid mode '%s' of SLAAC profile", profile.Mode)
+		return
+	}
+	egw.slaacProfile = profile
+	writeOpenApiTask(w, server.newTask(s, "updateSlaacProfile", "Updated SLAAC profile of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getQos(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, egw.qos)
+}
+
+// updateQos replaces the Rate Limiting configuration of the Edge Gateway. Gateway QoS Profiles are
+// not modelled, therefore any profile is accepted
+func (server *Server) updateQos(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	qos := types.NsxtEdgeGatewayQos{}
+	if !decodeJson(w, r, &qos) {
+		return
+	}
+	egw.qos = qos
+	writeOpenApiTask(w, server.newTask(s, "updateQos", "Updated Rate Limiting of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
@@ -0,0 +1,248 @@
+package govcdtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// firewallRule is a user defined firewall rule of an Edge Gateway or a rule of the Distributed
+// Firewall of a VDC Group. Rules are kept as decoded JSON, so that fields which are not known to
+// the SDK are returned as they were sent
+type firewallRule map[string]any
+
+func (rule firewallRule) id() string {
+	id, _ := rule["id"].(string)
+	return id
+}
+
+// version returns the version of the rule, which VCD increments at every change
+func (rule firewallRule) version() int {
+	version, _ := rule["version"].(map[string]any)
+	number, _ := version["version"].(float64)
+	return int(number)
+}
+
+func (rule firewallRule) setVersion(number int) {
+	// Numbers are stored as float64, like the ones decoded from JSON
+	rule["version"] = map[string]any{"version": float64(number)}
+}
+
+// etag returns the ETag of the rule, which changes with its version
+func (rule firewallRule) etag() string {
+	return fmt.Sprintf(`"%d"`, rule.version())
+}
+
+// sameContent returns true if the rules are equal, ignoring their versions
+func (rule firewallRule) sameContent(other firewallRule) bool {
+	withoutVersion := func(r firewallRule) string {
+		content := make(map[string]any, len(r))
+		for key, value := range r {
+			if key != "version" {
+				content[key] = value
+			}
+		}
+		body, err := json.Marshal(content)
+		if err != nil {
+			panic(err)
+		}
+		return string(body)
+	}
+	return withoutVersion(rule) == withoutVersion(other)
+}
+
+// firewallEtag returns the ETag of the list of user defined rules of the Edge Gateway, which changes
+// with every change of the rules
+func (egw *edgeGateway) firewallEtag() string {
+	return fmt.Sprintf(`"%d"`, egw.firewallVersion)
+}
+
+func firewallRuleIndex(rules []firewallRule, id string) int {
+	return slices.IndexFunc(rules, func(rule firewallRule) bool { return rule.id() == id })
+}
+
+// checkIfMatch returns true if the request has no If-Match header or if the header matches the
+// current ETag. Otherwise it writes HTTP 412, like VCD does for changes based on stale entities
+func checkIfMatch(w http.ResponseWriter, r *http.Request, etag string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == etag {
+		return true
+	}
+	writeError(w, r, http.StatusPreconditionFailed, "PRECONDITION_FAILED",
+		"The entity has been modified since it was retrieved: ETag %s does not match %s", ifMatch, etag)
+	return false
+}
+
+// validateFirewallRule checks a rule sent by the client. It writes an error and returns false if the
+// rule is not valid
+func validateFirewallRule(w http.ResponseWriter, r *http.Request, rule firewallRule) bool {
+	if name, _ := rule["name"].(string); name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "firewall rule name cannot be empty")
+		return false
+	}
+	return true
+}
+
+func (server *Server) getFirewallRules(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	server.setEtag(w, egw.firewallEtag())
+	writeJson(w, http.StatusOK, map[string][]firewallRule{
+		"systemRules":      {},
+		"defaultRules":     {},
+		"userDefinedRules": append([]firewallRule{}, egw.firewallRules...),
+	})
+}
+
+// updateFirewallRules replaces the user defined rules
+func (server *Server) updateFirewallRules(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	if !checkIfMatch(w, r, egw.firewallEtag()) {
+		return
+	}
+	payload := struct {
+		UserDefinedRules []firewallRule `json:"userDefinedRules"`
+	}{}
+	if !decodeJson(w, r, &payload) || !replaceFirewallRules(w, r, egw.firewallRules, payload.UserDefinedRules) {
+		return
+	}
+	egw.firewallRules = payload.UserDefinedRules
+	egw.firewallVersion++
+	writeOpenApiTask(w, server.newTask(s, "updateEdgeGatewayFirewall", "Updated firewall rules of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+// replaceFirewallRules prepares the rules which replace the current ones. Rules without ID are
+// created, rules with the ID of a current rule replace it, and current rules which are not sent are
+// deleted. It writes an error and returns false if the rules are not valid
+func replaceFirewallRules(w http.ResponseWriter, r *http.Request, current, rules []firewallRule) bool {
+	ids := make(map[string]bool)
+	for _, rule := range rules {
+		if !validateFirewallRule(w, r, rule) {
+			return false
+		}
+		id := rule.id()
+		if id == "" {
+			continue
+		}
+		if ids[id] || firewallRuleIndex(current, id) < 0 {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "firewall rule '%s' is duplicate or does not exist", id)
+			return false
+		}
+		ids[id] = true
+	}
+
+	for _, rule := range rules {
+		if rule.id() == "" {
+			rule["id"] = newUuid()
+			rule.setVersion(1)
+			continue
+		}
+		existing := current[firewallRuleIndex(current, rule.id())]
+		version := existing.version()
+		if !rule.sameContent(existing) {
+			version++
+		}
+		rule.setVersion(version)
+	}
+	return true
+}
+
+// requestFirewallRule returns the Edge Gateway and the index of the rule of the request path. It
+// writes an error and returns nil if either of them does not exist or is not visible
+func (server *Server) requestFirewallRule(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, int) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	index := firewallRuleIndex(egw.firewallRules, r.PathValue("ruleId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	return egw, index
+}
+
+func (server *Server) getFirewallRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestFirewallRule(w, r, s)
+	if egw == nil {
+		return
+	}
+	rule := egw.firewallRules[index]
+	server.setEtag(w, rule.etag())
+	writeJson(w, http.StatusOK, rule)
+}
+
+func (server *Server) updateFirewallRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestFirewallRule(w, r, s)
+	if egw == nil {
+		return
+	}
+	existing := egw.firewallRules[index]
+	if !checkIfMatch(w, r, existing.etag()) {
+		return
+	}
+	rule := firewallRule{}
+	if !decodeJson(w, r, &rule) || !validateFirewallRule(w, r, rule) {
+		return
+	}
+	if rule.id() != existing.id() {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "firewall rule ID '%s' does not match '%s'", rule.id(), existing.id())
+		return
+	}
+	rule.setVersion(existing.version() + 1)
+	egw.firewallRules[index] = rule
+	egw.firewallVersion++
+	writeOpenApiTask(w, server.newTask(s, "updateEdgeGatewayFirewall", "Updated firewall rule of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) deleteFirewallRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestFirewallRule(w, r, s)
+	if egw == nil {
+		return
+	}
+	if !checkIfMatch(w, r, egw.firewallRules[index].etag()) {
+		return
+	}
+	egw.firewallRules = slices.Delete(egw.firewallRules, index, index+1)
+	egw.firewallVersion++
+	writeOpenApiTask(w, server.newTask(s, "updateEdgeGatewayFirewall", "Deleted firewall rule of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getDistributedFirewallRules(w http.ResponseWriter, r *http.Request, s *session) {
+	g := server.visibleVdcGroup(s, r.PathValue("id"))
+	if g == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, map[string][]firewallRule{"values": append([]firewallRule{}, g.dfwRules...)})
+}
+
+// updateDistributedFirewallRules replaces the rules of the default Distributed Firewall policy
+func (server *Server) updateDistributedFirewallRules(w http.ResponseWriter, r *http.Request, s *session) {
+	g := server.visibleVdcGroup(s, r.PathValue("id"))
+	if g == nil {
+		writeNotFound(w, r)
+		return
+	}
+	payload := struct {
+		Values []firewallRule `json:"values"`
+	}{}
+	if !decodeJson(w, r, &payload) || !replaceFirewallRules(w, r, g.dfwRules, payload.Values) {
+		return
+	}
+	g.dfwRules = payload.Values
+	writeOpenApiTask(w, server.newTask(s, "updateDfwRules", "Updated Distributed Firewall rules of VDC Group "+g.name,
+		server.vdcGroupReference(g)))
+}
@@ -0,0 +1,275 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// firewallGroup is an NSX-T Firewall Group - an IP Set, a static Security Group of Org VDC
+// networks or a dynamic Security Group of VMs - owned by an Edge Gateway or a VDC Group
+type firewallGroup struct {
+	group *types.NsxtFirewallGroup
+	org   *org
+}
+
+func compareFirewallGroups(a, b *firewallGroup) int {
+	return cmp.Or(compareOrgs(a.org, b.org), cmp.Compare(a.group.Name, b.group.Name), cmp.Compare(a.group.ID, b.group.ID))
+}
+
+// copyFirewallGroup returns a deep copy of the Firewall Group, so that stored state is not shared
+// with the caller
+func copyFirewallGroup(group *types.NsxtFirewallGroup) *types.NsxtFirewallGroup {
+	body, err := json.Marshal(group)
+	if err != nil {
+		panic(err)
+	}
+	result := &types.NsxtFirewallGroup{}
+	if err := json.Unmarshal(body, result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// inContext returns true if the Firewall Group is available in the context of a '_context' filter,
+// which is either the owner of the group or an Org VDC network which is a member of the group
+func (g *firewallGroup) inContext(id string) bool {
+	if uuidFromId(g.group.OwnerRef.ID) == uuidFromId(id) {
+		return true
+	}
+	return slices.ContainsFunc(g.group.Members, func(member types.OpenApiReference) bool {
+		return uuidFromId(member.ID) == uuidFromId(id)
+	})
+}
+
+// visibleFirewallGroup returns the Firewall Group with the given ID or nil if it does not exist or
+// is not visible in the session
+func (server *Server) visibleFirewallGroup(s *session, id string) *firewallGroup {
+	g := server.firewallGroups[uuidFromId(id)]
+	if g == nil || !s.canAccess(g.org) {
+		return nil
+	}
+	return g
+}
+
+// firewallGroupOwner returns the reference to the Edge Gateway or the VDC Group with the given ID,
+// the org and the VDCs of the owner. It returns nil if the owner does not exist or is not visible
+func (server *Server) firewallGroupOwner(s *session, id string) (*types.OpenApiReference, *org, []*vdc) {
+	switch {
+	case strings.HasPrefix(id, "urn:vcloud:gateway:"):
+		if egw := server.visibleEdgeGateway(s, id); egw != nil {
+			return &types.OpenApiReference{ID: egw.gateway.ID, Name: egw.gateway.Name}, egw.vdc.org, []*vdc{egw.vdc}
+		}
+	case strings.HasPrefix(id, "urn:vcloud:vdcGroup:"):
+		if g := server.visibleVdcGroup(s, id); g != nil {
+			return &types.OpenApiReference{ID: g.urn(), Name: g.name}, g.org, g.vdcs
+		}
+	}
+	return nil, nil, nil
+}
+
+// validateFirewallGroup checks the configuration sent by the client and sets the fields which are
+// computed by VCD. It writes an error and returns nil if the configuration is not valid
+func (server *Server) validateFirewallGroup(w http.ResponseWriter, r *http.Request, s *session, group *types.NsxtFirewallGroup) *firewallGroup {
+	if group.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Firewall Group name cannot be empty")
+		return nil
+	}
+	// 'type' is deprecated in favor of 'typeValue', but both are returned
+	if group.TypeValue == "" {
+		group.TypeValue = group.Type
+	}
+	group.Type = group.TypeValue
+	if !slices.Contains([]string{types.FirewallGroupTypeIpSet, types.FirewallGroupTypeSecurityGroup, types.FirewallGroupTypeVmCriteria}, group.TypeValue) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid Firewall Group type '%s'", group.TypeValue)
+		return nil
+	}
+
+	ownerRef := group.OwnerRef
+	if ownerRef == nil || ownerRef.ID == "" {
+		ownerRef = group.EdgeGatewayRef
+	}
+	if ownerRef == nil || ownerRef.ID == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Firewall Group owner must be specified")
+		return nil
+	}
+	owner, o, vdcs := server.firewallGroupOwner(s, ownerRef.ID)
+	if owner == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "owner '%s' of Firewall Group does not exist", ownerRef.ID)
+		return nil
+	}
+	group.OwnerRef = owner
+	group.EdgeGatewayRef = nil
+	if strings.HasPrefix(owner.ID, "urn:vcloud:gateway:") {
+		group.EdgeGatewayRef = &types.OpenApiReference{ID: owner.ID, Name: owner.Name}
+	}
+
+	if len(group.Members) > 0 && group.TypeValue != types.FirewallGroupTypeSecurityGroup {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "only Security Groups can have members")
+		return nil
+	}
+	for i, member := range group.Members {
+		n := server.orgVdcNetworks[uuidFromId(member.ID)]
+		if n == nil || !slices.Contains(vdcs, n.vdc) {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "network '%s' is not available to owner '%s' of Firewall Group", member.ID, owner.Name)
+			return nil
+		}
+		group.Members[i] = types.OpenApiReference{ID: n.urn(), Name: n.name}
+	}
+
+	for _, existing := range server.firewallGroups {
+		if existing.group.ID != group.ID && existing.group.Name == group.Name && existing.group.TypeValue == group.TypeValue &&
+			existing.group.OwnerRef.ID == owner.ID {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "Firewall Group with name '%s' already exists in '%s'", group.Name, owner.Name)
+			return nil
+		}
+	}
+	return &firewallGroup{group: copyFirewallGroup(group), org: o}
+}
+
+func (server *Server) firewallGroupReference(group *types.NsxtFirewallGroup) *types.Reference {
+	return &types.Reference{
+		HREF: server.URL + "/cloudapi/1.0.0/firewallGroups/" + group.ID,
+		ID:   group.ID,
+		Type: types.JSONMime,
+		Name: group.Name,
+	}
+}
+
+// getFirewallGroupSummaries lists the Firewall Groups. The '_context' filter returns the groups
+// owned by an Edge Gateway or a VDC Group, or the groups which have an Org VDC network as member
+func (server *Server) getFirewallGroupSummaries(w http.ResponseWriter, r *http.Request, s *session) {
+	f, ok := requestFilter(w, r)
+	if !ok {
+		return
+	}
+	f, context := f.without("_context")
+	var groups []*types.NsxtFirewallGroup
+	for _, g := range sortedValues(server.firewallGroups, compareFirewallGroups) {
+		if s.canAccess(g.org) && (context == "" || g.inContext(context)) {
+			groups = append(groups, g.group)
+		}
+	}
+	writeFilteredOpenApiPage(w, r, groups, f, func(group *types.NsxtFirewallGroup) map[string]string {
+		return map[string]string{
+			"id":          group.ID,
+			"name":        group.Name,
+			"type":        group.Type,
+			"typeValue":   group.TypeValue,
+			"ownerRef.id": group.OwnerRef.ID,
+		}
+	})
+}
+
+func (server *Server) getFirewallGroup(w http.ResponseWriter, r *http.Request, s *session) {
+	g := server.visibleFirewallGroup(s, r.PathValue("id"))
+	if g == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, g.group)
+}
+
+func (server *Server) createFirewallGroup(w http.ResponseWriter, r *http.Request, s *session) {
+	group := &types.NsxtFirewallGroup{}
+	if !decodeJson(w, r, group) {
+		return
+	}
+	group.ID = "urn:vcloud:firewallGroup:" + newUuid()
+	g := server.validateFirewallGroup(w, r, s, group)
+	if g == nil {
+		return
+	}
+	server.firewallGroups[uuidFromId(group.ID)] = g
+	writeOpenApiTask(w, server.newTask(s, "createFirewallGroup", "Created Firewall Group "+group.Name, server.firewallGroupReference(group)))
+}
+
+func (server *Server) updateFirewallGroup(w http.ResponseWriter, r *http.Request, s *session) {
+	existing := server.visibleFirewallGroup(s, r.PathValue("id"))
+	if existing == nil {
+		writeNotFound(w, r)
+		return
+	}
+	group := &types.NsxtFirewallGroup{}
+	if !decodeJson(w, r, group) {
+		return
+	}
+	if group.ID != existing.group.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Firewall Group ID '%s' does not match '%s'", group.ID, existing.group.ID)
+		return
+	}
+	if group.TypeValue == "" && group.Type == "" {
+		group.TypeValue = existing.group.TypeValue
+	}
+	if (group.TypeValue != "" && group.TypeValue != existing.group.TypeValue) || (group.Type != "" && group.Type != existing.group.Type) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "the type of Firewall Group '%s' cannot be changed", existing.group.Name)
+		return
+	}
+	g := server.validateFirewallGroup(w, r, s, group)
+	if g == nil {
+		return
+	}
+	if g.group.OwnerRef.ID != existing.group.OwnerRef.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "the owner of Firewall Group '%s' cannot be changed", existing.group.Name)
+		return
+	}
+	server.firewallGroups[uuidFromId(group.ID)] = g
+	writeOpenApiTask(w, server.newTask(s, "updateFirewallGroup", "Updated Firewall Group "+group.Name, server.firewallGroupReference(group)))
+}
+
+func (server *Server) deleteFirewallGroup(w http.ResponseWriter, r *http.Request, s *session) {
+	g := server.visibleFirewallGroup(s, r.PathValue("id"))
+	if g == nil {
+		writeNotFound(w, r)
+		return
+	}
+	delete(server.firewallGroups, uuidFromId(g.group.ID))
+	writeOpenApiTask(w, server.newTask(s, "deleteFirewallGroup", "Deleted Firewall Group "+g.group.Name, server.firewallGroupReference(g.group)))
+}
+
+// getFirewallGroupVms lists the VMs of a Security Group, which are the VMs with a NIC connected to
+// a vApp network whose parent is a member of the group. Dynamic Security Groups have no VMs, as
+// their criteria are not evaluated
+func (server *Server) getFirewallGroupVms(w http.ResponseWriter, r *http.Request, s *session) {
+	g := server.visibleFirewallGroup(s, r.PathValue("id"))
+	if g == nil {
+		writeNotFound(w, r)
+		return
+	}
+	if g.group.TypeValue == types.FirewallGroupTypeIpSet {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "IP Set '%s' has no associated VMs", g.group.Name)
+		return
+	}
+	isMember := func(n *orgVdcNetwork) bool {
+		return n != nil && slices.ContainsFunc(g.group.Members, func(member types.OpenApiReference) bool { return member.ID == n.urn() })
+	}
+	var vms []*types.NsxtFirewallGroupMemberVms
+	for _, a := range sortedValues(server.vApps, compareVApps) {
+		for _, m := range a.vms {
+			connected := false
+			if m.nics != nil {
+				for _, nic := range m.nics.NetworkConnection {
+					connected = connected || slices.ContainsFunc(a.networks, func(n *vAppNetwork) bool {
+						return n.name == nic.Network && isMember(n.parent)
+					})
+				}
+			}
+			if !connected {
+				continue
+			}
+			vms = append(vms, &types.NsxtFirewallGroupMemberVms{
+				VmRef:   &types.OpenApiReference{ID: m.urn(), Name: m.name},
+				VappRef: &types.OpenApiReference{ID: a.urn(), Name: a.name},
+				VdcRef:  &types.OpenApiReference{ID: a.vdc.urn(), Name: a.vdc.name},
+				OrgRef:  &types.OpenApiReference{ID: a.vdc.org.urn(), Name: a.vdc.org.name},
+			})
+		}
+	}
+	writeOpenApiPage(w, r, vms, func(vm *types.NsxtFirewallGroupMemberVms) map[string]string {
+		return map[string]string{"vmRef.id": vm.VmRef.ID, "vappRef.id": vm.VappRef.ID}
+	})
+}
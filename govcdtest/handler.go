@@ -0,0 +1,89 @@
+package govcdtest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// HandleFunc registers a handler for the requests that match the pattern, which has the syntax of
+// http.ServeMux patterns (e.g. "POST /api/vdc/{id}/action/custom"). Registered handlers take
+// precedence over the endpoints of the server, so that tests can serve endpoints which govcdtest
+// does not implement, or replace the built-in ones.
+//
+// Like the built-in endpoints, handlers are only called for requests of a valid session. Unlike
+// them, they are not called with the state of the server locked, so they can call any method of the
+// server. Handlers that keep their own state must guard it, as requests can be concurrent.
+func (server *Server) HandleFunc(pattern string, handler http.HandlerFunc) {
+	server.handlers.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if server.requestSession(r) == nil {
+			writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "session is not authenticated or has expired")
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// serveHTTP serves the request with a registered handler, if one matches, or with the built-in
+// endpoints
+func (server *Server) serveHTTP(builtIn http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Handler does not set the path values of the request, which ServeHTTP does
+		if _, pattern := server.handlers.Handler(r); pattern != "" {
+			server.handlers.ServeHTTP(w, r)
+			return
+		}
+		builtIn.ServeHTTP(w, r)
+	})
+}
+
+// requestSession returns the session of the request, or nil if it is not authenticated
+func (server *Server) requestSession(r *http.Request) *session {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.sessions[requestToken(r)]
+}
+
+// WriteTask writes the response of an asynchronous operation, with a task that has already
+// completed successfully and can be retrieved by the client. Like VCD, the task is the body of the
+// response for the XML API and is referenced by the Location header for OpenAPI. Details are stored
+// in the task, as VCD does with the ID of the entities created by some OpenAPI endpoints.
+func (server *Server) WriteTask(w http.ResponseWriter, r *http.Request, operationName, details string) {
+	s := server.requestSession(r)
+	if s == nil {
+		writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "session is not authenticated or has expired")
+		return
+	}
+
+	server.mu.Lock()
+	t := server.newTask(s, operationName, operationName, nil)
+	t.Details = details
+	server.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, "/cloudapi/") {
+		writeOpenApiTask(w, t)
+		return
+	}
+	writeTask(w, t)
+}
+
+// WriteXml writes the value as an XML response with the given content type
+func WriteXml(w http.ResponseWriter, status int, contentType string, value any) {
+	writeXml(w, status, contentType, value)
+}
+
+// WriteJson writes the value as a JSON response
+func WriteJson(w http.ResponseWriter, status int, value any) {
+	writeJson(w, status, value)
+}
+
+// WriteError writes an error in the format of the API that was called - types.OpenApiError for
+// OpenAPI and types.Error for the XML API
+func WriteError(w http.ResponseWriter, r *http.Request, status int, minorErrorCode, format string, args ...any) {
+	writeError(w, r, status, minorErrorCode, format, args...)
+}
+
+// WriteNotFound writes the error that VCD returns for entities which do not exist or are not
+// visible
+func WriteNotFound(w http.ResponseWriter, r *http.Request) {
+	writeNotFound(w, r)
+}
@@ -0,0 +1,158 @@
+package govcdtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// copyNatRule returns a deep copy of the NAT rule, so that stored state is not shared with the
+// caller
+func copyNatRule(rule *types.NsxtNatRule) *types.NsxtNatRule {
+	body, err := json.Marshal(rule)
+	if err != nil {
+		panic(err)
+	}
+	result := &types.NsxtNatRule{}
+	if err := json.Unmarshal(body, result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// setNatRuleVersion sets the version of the NAT rule, which is 0 when it is created and is
+// incremented at every update
+func setNatRuleVersion(rule *types.NsxtNatRule, version int) {
+	rule.Version = &struct {
+		Version *int `json:"version,omitempty"`
+	}{Version: &version}
+}
+
+func natRuleIndex(rules []*types.NsxtNatRule, id string) int {
+	return slices.IndexFunc(rules, func(rule *types.NsxtNatRule) bool { return rule.ID == id })
+}
+
+// validateNatRule checks the NAT rule sent by the client and sets the fields which are computed by
+// VCD. It writes an error and returns nil if the rule is not valid
+func (server *Server) validateNatRule(w http.ResponseWriter, r *http.Request, s *session, egw *edgeGateway, rule *types.NsxtNatRule) *types.NsxtNatRule {
+	if rule.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "NAT rule name cannot be empty")
+		return nil
+	}
+	// 'ruleType' is deprecated in favor of 'type', but both are returned
+	if rule.Type == "" {
+		rule.Type = rule.RuleType
+	}
+	rule.RuleType = rule.Type
+	if !slices.Contains([]string{types.NsxtNatRuleTypeDnat, types.NsxtNatRuleTypeNoDnat, types.NsxtNatRuleTypeSnat,
+		types.NsxtNatRuleTypeNoSnat, types.NsxtNatRuleTypeReflexive}, rule.Type) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid type '%s' of NAT rule '%s'", rule.Type, rule.Name)
+		return nil
+	}
+	if rule.ApplicationPortProfile != nil && rule.ApplicationPortProfile.ID != "" {
+		p := server.visibleAppPortProfile(s, rule.ApplicationPortProfile.ID)
+		if p == nil || !p.inContext(egw.vdc.urn()) {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Application Port Profile '%s' of NAT rule '%s' is not available to Edge Gateway '%s'",
+				rule.ApplicationPortProfile.ID, rule.Name, egw.gateway.Name)
+			return nil
+		}
+		rule.ApplicationPortProfile = &types.OpenApiReference{ID: p.profile.ID, Name: p.profile.Name}
+	}
+	return copyNatRule(rule)
+}
+
+// requestNatRule returns the Edge Gateway and the index of the NAT rule of the request path. It
+// writes an error and returns nil if either of them does not exist or is not visible
+func (server *Server) requestNatRule(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, int) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	index := natRuleIndex(egw.natRules, r.PathValue("ruleId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	return egw, index
+}
+
+// getNatRules lists the NAT rules of the Edge Gateway. Like VCD, it does not support filters
+func (server *Server) getNatRules(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeOpenApiPage(w, r, egw.natRules, func(rule *types.NsxtNatRule) map[string]string {
+		return map[string]string{}
+	})
+}
+
+func (server *Server) getNatRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestNatRule(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, egw.natRules[index])
+}
+
+// createNatRule adds a NAT rule to the Edge Gateway. Like VCD, the task is owned by the Edge
+// Gateway, therefore clients find the new rule by listing the rules
+func (server *Server) createNatRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	rule := &types.NsxtNatRule{}
+	if !decodeJson(w, r, rule) {
+		return
+	}
+	rule.ID = newUuid()
+	setNatRuleVersion(rule, 0)
+	stored := server.validateNatRule(w, r, s, egw, rule)
+	if stored == nil {
+		return
+	}
+	egw.natRules = append(egw.natRules, stored)
+	writeOpenApiTask(w, server.newTask(s, "createNatRule", "Created NAT rule "+rule.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) updateNatRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestNatRule(w, r, s)
+	if egw == nil {
+		return
+	}
+	existing := egw.natRules[index]
+	rule := &types.NsxtNatRule{}
+	if !decodeJson(w, r, rule) {
+		return
+	}
+	if rule.ID != existing.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "NAT rule ID '%s' does not match '%s'", rule.ID, existing.ID)
+		return
+	}
+	setNatRuleVersion(rule, *existing.Version.Version+1)
+	stored := server.validateNatRule(w, r, s, egw, rule)
+	if stored == nil {
+		return
+	}
+	egw.natRules[index] = stored
+	writeOpenApiTask(w, server.newTask(s, "updateNatRule", "Updated NAT rule "+rule.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) deleteNatRule(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestNatRule(w, r, s)
+	if egw == nil {
+		return
+	}
+	name := egw.natRules[index].Name
+	egw.natRules = slices.Delete(egw.natRules, index, index+1)
+	writeOpenApiTask(w, server.newTask(s, "deleteNatRule", "Deleted NAT rule "+name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
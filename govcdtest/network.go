@@ -0,0 +1,301 @@
+package govcdtest
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// orgVdcNetwork is an Org VDC network
+type orgVdcNetwork struct {
+	id   string
+	name string
+	vdc  *vdc
+}
+
+func (n *orgVdcNetwork) urn() string {
+	return "urn:vcloud:network:" + n.id
+}
+
+// vAppNetwork is a vApp network, either isolated or connected to an Org VDC network
+type vAppNetwork struct {
+	id        string
+	name      string
+	fenceMode string
+	// parent is the Org VDC network the vApp network is connected to, nil for isolated networks
+	parent *orgVdcNetwork
+	vApp   *vApp
+}
+
+func compareOrgVdcNetworks(a, b *orgVdcNetwork) int {
+	return cmp.Or(compareVdcs(a.vdc, b.vdc), cmp.Compare(a.name, b.name))
+}
+
+// AddOrgVdcNetwork adds an Org VDC network to the given VDC and returns its ID
+func (server *Server) AddOrgVdcNetwork(orgName, vdcName, networkName string) (string, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	v, err := server.vdcByName(orgName, vdcName)
+	if err != nil {
+		return "", err
+	}
+	if networkName == "" {
+		return "", fmt.Errorf("network name cannot be empty")
+	}
+	for _, n := range server.orgVdcNetworks {
+		if n.vdc == v && n.name == networkName {
+			return "", fmt.Errorf("network '%s' already exists in VDC '%s'", networkName, vdcName)
+		}
+	}
+	n := &orgVdcNetwork{id: newUuid(), name: networkName, vdc: v}
+	server.orgVdcNetworks[n.id] = n
+	return n.urn(), nil
+}
+
+func (server *Server) networkHref(id string) string {
+	return server.URL + "/api/network/" + id
+}
+
+// vdcNetworks returns the Org VDC networks of the VDC sorted by name
+func (server *Server) vdcNetworks(v *vdc) []*orgVdcNetwork {
+	var networks []*orgVdcNetwork
+	for _, n := range sortedValues(server.orgVdcNetworks, compareOrgVdcNetworks) {
+		if n.vdc == v {
+			networks = append(networks, n)
+		}
+	}
+	return networks
+}
+
+// openApiOrgVdcNetwork returns the OpenAPI representation of the Org VDC network
+func (server *Server) openApiOrgVdcNetwork(n *orgVdcNetwork) *types.OpenApiOrgVdcNetwork {
+	return &types.OpenApiOrgVdcNetwork{
+		ID:                 n.urn(),
+		Name:               n.name,
+		Status:             "REALIZED",
+		OwnerRef:           &types.OpenApiReference{ID: n.vdc.urn(), Name: n.vdc.name},
+		OrgVdc:             &types.OpenApiReference{ID: n.vdc.urn(), Name: n.vdc.name},
+		NetworkType:        types.OrgVdcNetworkTypeIsolated,
+		OrgVdcIsNsxTBacked: true,
+	}
+}
+
+func (server *Server) getOpenApiOrgVdcNetworks(w http.ResponseWriter, r *http.Request, s *session) {
+	var networks []*types.OpenApiOrgVdcNetwork
+	for _, n := range sortedValues(server.orgVdcNetworks, compareOrgVdcNetworks) {
+		if s.canAccess(n.vdc.org) {
+			networks = append(networks, server.openApiOrgVdcNetwork(n))
+		}
+	}
+	writeOpenApiPage(w, r, networks, func(n *types.OpenApiOrgVdcNetwork) map[string]string {
+		return map[string]string{"id": n.ID, "name": n.Name, "ownerRef.id": n.OwnerRef.ID, "orgVdc.id": n.OrgVdc.ID}
+	})
+}
+
+func (server *Server) getOpenApiOrgVdcNetwork(w http.ResponseWriter, r *http.Request, s *session) {
+	n := server.orgVdcNetworks[uuidFromId(r.PathValue("id"))]
+	if n == nil || !s.canAccess(n.vdc.org) {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, server.openApiOrgVdcNetwork(n))
+}
+
+func (server *Server) getNetwork(w http.ResponseWriter, r *http.Request, s *session) {
+	n := server.orgVdcNetworks[r.PathValue("id")]
+	if n == nil || !s.canAccess(n.vdc.org) {
+		writeNotFound(w, r)
+		return
+	}
+	writeXml(w, http.StatusOK, types.MimeOrgVdcNetwork, &types.OrgVDCNetwork{
+		Xmlns:  types.XMLNamespaceVCloud,
+		HREF:   server.networkHref(n.id),
+		Type:   types.MimeOrgVdcNetwork,
+		ID:     n.urn(),
+		Name:   n.name,
+		Status: "1",
+		Link: []types.Link{
+			{Rel: "up", Type: types.MimeVDC, HREF: server.vdcHref(n.vdc)},
+		},
+		Configuration: &types.NetworkConfiguration{FenceMode: types.FenceModeIsolated},
+	})
+}
+
+// deleteNetwork removes a vApp network from its vApp
+func (server *Server) deleteNetwork(w http.ResponseWriter, r *http.Request, s *session) {
+	for _, a := range server.vApps {
+		for i, n := range a.networks {
+			if n.id != r.PathValue("id") || !s.canAccess(a.vdc.org) {
+				continue
+			}
+			for _, m := range a.vms {
+				if m.nics != nil && slices.ContainsFunc(m.nics.NetworkConnection, func(nic *types.NetworkConnection) bool {
+					return nic.Network == n.name
+				}) {
+					writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "network '%s' is in use by VM '%s'", n.name, m.name)
+					return
+				}
+			}
+			a.networks = slices.Delete(a.networks, i, i+1)
+			writeTask(w, server.newTask(s, "vdcUpdateVappNetworkSection", "Removed network "+n.name, server.vAppReference(a)))
+			return
+		}
+	}
+	writeNotFound(w, r)
+}
+
+// networkConfigSection returns the vApp networks as a NetworkConfigSection
+func (server *Server) networkConfigSection(a *vApp) *types.NetworkConfigSection {
+	href := server.vAppHref(a) + "/networkConfigSection/"
+	section := &types.NetworkConfigSection{
+		Xmlns: types.XMLNamespaceVCloud,
+		Ovf:   types.XMLNamespaceOVF,
+		Info:  "The configuration parameters for logical networks",
+		HREF:  href,
+		Type:  types.MimeNetworkConfigSection,
+		Link:  &types.Link{Rel: "edit", Type: types.MimeNetworkConfigSection, HREF: href},
+	}
+	for _, n := range a.networks {
+		configuration := &types.NetworkConfiguration{FenceMode: n.fenceMode}
+		if n.parent != nil {
+			configuration.ParentNetwork = &types.Reference{HREF: server.networkHref(n.parent.id), ID: n.parent.urn(), Name: n.parent.name}
+		}
+		section.NetworkConfig = append(section.NetworkConfig, types.VAppNetworkConfiguration{
+			HREF:          server.networkHref(n.id),
+			ID:            n.id,
+			NetworkName:   n.name,
+			Link:          &types.Link{Rel: "repair", Type: types.MimeNetwork, HREF: server.URL + "/api/admin/network/" + n.id + "/action/reset"},
+			Configuration: configuration,
+		})
+	}
+	return section
+}
+
+func (server *Server) getNetworkConfigSection(w http.ResponseWriter, r *http.Request, s *session) {
+	a, _ := server.vAppOrVm(s, r.PathValue("id"))
+	if a == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeXml(w, http.StatusOK, types.MimeNetworkConfigSection, server.networkConfigSection(a))
+}
+
+// updateNetworkConfigSection replaces the vApp networks
+func (server *Server) updateNetworkConfigSection(w http.ResponseWriter, r *http.Request, s *session) {
+	a, _ := server.vAppOrVm(s, r.PathValue("id"))
+	if a == nil {
+		writeNotFound(w, r)
+		return
+	}
+	section := &types.NetworkConfigSection{}
+	if !decodeXml(w, r, section) {
+		return
+	}
+	networks, err := server.parseVAppNetworks(section, a, a.vdc)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+		return
+	}
+	a.networks = networks
+	writeTask(w, server.newTask(s, "vdcUpdateVappNetworkSection", "Updated networks of vApp "+a.name, server.vAppReference(a)))
+}
+
+// parseVAppNetworks returns the networks of the vApp defined by a NetworkConfigSection. Only
+// isolated networks and networks connected to Org VDC networks of the VDC v are supported. The
+// networks of the vApp that are kept retain their ID
+func (server *Server) parseVAppNetworks(section *types.NetworkConfigSection, a *vApp, v *vdc) ([]*vAppNetwork, error) {
+	var networks []*vAppNetwork
+	for _, config := range section.NetworkConfig {
+		if config.Configuration == nil {
+			return nil, fmt.Errorf("network '%s' has no configuration", config.NetworkName)
+		}
+		var parent *orgVdcNetwork
+		if config.Configuration.ParentNetwork != nil {
+			parent = server.orgVdcNetworks[uuidFromId(config.Configuration.ParentNetwork.HREF)]
+			if parent == nil || parent.vdc != v {
+				return nil, fmt.Errorf("network '%s' is not available in VDC '%s'", config.Configuration.ParentNetwork.HREF, v.name)
+			}
+		} else if config.Configuration.FenceMode != types.FenceModeIsolated {
+			return nil, fmt.Errorf("network '%s': only isolated networks and networks connected to Org VDC networks are supported by govcdtest", config.NetworkName)
+		}
+		if config.NetworkName == "" || slices.ContainsFunc(networks, func(n *vAppNetwork) bool { return n.name == config.NetworkName }) {
+			return nil, fmt.Errorf("network name '%s' is empty or duplicated", config.NetworkName)
+		}
+		n := &vAppNetwork{id: newUuid(), name: config.NetworkName, fenceMode: config.Configuration.FenceMode, parent: parent, vApp: a}
+		for _, existing := range a.networks {
+			if existing.name == n.name {
+				n.id = existing.id
+			}
+		}
+		networks = append(networks, n)
+	}
+	return networks, nil
+}
+
+// networkConnectionSection returns the NICs of the VM as a NetworkConnectionSection
+func (server *Server) networkConnectionSection(m *vm) *types.NetworkConnectionSection {
+	href := server.vmHref(m) + "/networkConnectionSection/"
+	section := &types.NetworkConnectionSection{
+		Xmlns: types.XMLNamespaceVCloud,
+		Ovf:   types.XMLNamespaceOVF,
+		Info:  "Specifies the available VM network connections",
+		HREF:  href,
+		Type:  types.MimeNetworkConnectionSection,
+		Link:  &types.Link{Rel: "edit", Type: types.MimeNetworkConnectionSection, HREF: href},
+	}
+	if m.nics != nil {
+		section.PrimaryNetworkConnectionIndex = m.nics.PrimaryNetworkConnectionIndex
+		section.NetworkConnection = m.nics.NetworkConnection
+	}
+	return section
+}
+
+func (server *Server) getNetworkConnectionSection(w http.ResponseWriter, r *http.Request, s *session) {
+	_, m := server.vAppOrVm(s, r.PathValue("id"))
+	if m == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeXml(w, http.StatusOK, types.MimeNetworkConnectionSection, server.networkConnectionSection(m))
+}
+
+func (server *Server) updateNetworkConnectionSection(w http.ResponseWriter, r *http.Request, s *session) {
+	_, m := server.vAppOrVm(s, r.PathValue("id"))
+	if m == nil {
+		writeNotFound(w, r)
+		return
+	}
+	section := &types.NetworkConnectionSection{}
+	if !decodeXml(w, r, section) {
+		return
+	}
+	if err := m.vApp.validateNics(section); err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s': %s", m.name, err)
+		return
+	}
+	m.nics = &types.NetworkConnectionSection{
+		PrimaryNetworkConnectionIndex: section.PrimaryNetworkConnectionIndex,
+		NetworkConnection:             section.NetworkConnection,
+	}
+	writeTask(w, server.newTask(s, "vappUpdateVm", "Updated NICs of VM "+m.name, server.vmReference(m)))
+}
+
+// validateNics checks that the NICs are connected to networks of the vApp, or to none
+func (a *vApp) validateNics(section *types.NetworkConnectionSection) error {
+	if len(section.NetworkConnection) > 0 && section.PrimaryNetworkConnectionIndex >= len(section.NetworkConnection) {
+		return fmt.Errorf("primary NIC %d does not exist", section.PrimaryNetworkConnectionIndex)
+	}
+	for _, nic := range section.NetworkConnection {
+		if nic.Network == "" || nic.Network == types.NoneNetwork {
+			continue
+		}
+		if !slices.ContainsFunc(a.networks, func(n *vAppNetwork) bool { return n.name == nic.Network }) {
+			return fmt.Errorf("network '%s' is not a network of vApp '%s'", nic.Network, a.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,473 @@
+package govcdtest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func staticRouteIndex(routes []*types.NsxtEdgeGatewayStaticRoute, id string) int {
+	return slices.IndexFunc(routes, func(route *types.NsxtEdgeGatewayStaticRoute) bool { return route.ID == id })
+}
+
+func bgpIpPrefixListIndex(prefixLists []*types.EdgeBgpIpPrefixList, id string) int {
+	return slices.IndexFunc(prefixLists, func(prefixList *types.EdgeBgpIpPrefixList) bool { return prefixList.ID == id })
+}
+
+func bgpNeighborIndex(neighbors []*types.EdgeBgpNeighbor, id string) int {
+	return slices.IndexFunc(neighbors, func(neighbor *types.EdgeBgpNeighbor) bool { return neighbor.ID == id })
+}
+
+// egwNetwork returns the reference of an Org VDC network available to the Edge Gateway, or nil if
+// the network does not exist or belongs to another VDC
+func (server *Server) egwNetwork(egw *edgeGateway, id string) *types.OpenApiReference {
+	n := server.orgVdcNetworks[uuidFromId(id)]
+	if n == nil || n.vdc != egw.vdc {
+		return nil
+	}
+	return &types.OpenApiReference{ID: n.urn(), Name: n.name}
+}
+
+// routingEdgeGateway returns the Edge Gateway of the request path for BGP and Route
+// Advertisement, which are only available to Edge Gateways with a dedicated uplink. It writes an
+// error and returns nil if the Edge Gateway does not exist, is not visible or has no dedicated
+// uplink
+func (server *Server) routingEdgeGateway(w http.ResponseWriter, r *http.Request, s *session) *edgeGateway {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return nil
+	}
+	if !slices.ContainsFunc(egw.gateway.EdgeGatewayUplinks, func(uplink types.EdgeGatewayUplinks) bool { return uplink.Dedicated }) {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "Edge Gateway '%s' has no dedicated uplink", egw.gateway.Name)
+		return nil
+	}
+	return egw
+}
+
+// validateStaticRoute checks the static route sent by the client and sets the names of the
+// networks of its next hops. It writes an error and returns false if the route is not valid
+func (server *Server) validateStaticRoute(w http.ResponseWriter, r *http.Request, egw *edgeGateway, route *types.NsxtEdgeGatewayStaticRoute) bool {
+	if route.Name == "" || route.NetworkCidr == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "static route name and network CIDR cannot be empty")
+		return false
+	}
+	if len(route.NextHops) == 0 {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "static route '%s' must have at least one next hop", route.Name)
+		return false
+	}
+	for _, nextHop := range route.NextHops {
+		if nextHop.Scope == nil || nextHop.Scope.ScopeType != "NETWORK" {
+			continue
+		}
+		network := server.egwNetwork(egw, nextHop.Scope.ID)
+		if network == nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "network '%s' of static route '%s' is not available to Edge Gateway '%s'",
+				nextHop.Scope.ID, route.Name, egw.gateway.Name)
+			return false
+		}
+		nextHop.Scope.ID, nextHop.Scope.Name = network.ID, network.Name
+	}
+	// Routes created by VCD itself are never sent by clients
+	systemOwned := false
+	route.SystemOwned = &systemOwned
+	return true
+}
+
+// requestStaticRoute returns the Edge Gateway and the index of the static route of the request
+// path. It writes an error and returns nil if either of them does not exist or is not visible
+func (server *Server) requestStaticRoute(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, int) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	index := staticRouteIndex(egw.staticRoutes, r.PathValue("routeId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	return egw, index
+}
+
+// getStaticRoutes lists the static routes of the Edge Gateway. Like VCD, it does not support
+// filters
+func (server *Server) getStaticRoutes(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeOpenApiPage(w, r, egw.staticRoutes, func(route *types.NsxtEdgeGatewayStaticRoute) map[string]string {
+		return map[string]string{}
+	})
+}
+
+func (server *Server) getStaticRoute(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestStaticRoute(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, egw.staticRoutes[index])
+}
+
+// createStaticRoute adds a static route to the Edge Gateway. Like VCD, the task is owned by the
+// Edge Gateway and its details contain the ID of the new route
+func (server *Server) createStaticRoute(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	route := &types.NsxtEdgeGatewayStaticRoute{}
+	if !decodeJson(w, r, route) || !server.validateStaticRoute(w, r, egw, route) {
+		return
+	}
+	route.ID = newUuid()
+	route.Version = "0"
+	egw.staticRoutes = append(egw.staticRoutes, route)
+	task := server.newTask(s, "createStaticRoute", "Created static route "+route.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway))
+	task.Details = route.ID
+	writeOpenApiTask(w, task)
+}
+
+// updateStaticRoute replaces a static route of the Edge Gateway. Like VCD, the update must send
+// the current version of the route
+func (server *Server) updateStaticRoute(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestStaticRoute(w, r, s)
+	if egw == nil {
+		return
+	}
+	existing := egw.staticRoutes[index]
+	route := &types.NsxtEdgeGatewayStaticRoute{}
+	if !decodeJson(w, r, route) {
+		return
+	}
+	if route.ID != existing.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "static route ID '%s' does not match '%s'", route.ID, existing.ID)
+		return
+	}
+	if route.Version != existing.Version {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "version '%s' of static route '%s' does not match '%s'", route.Version, existing.Name, existing.Version)
+		return
+	}
+	if !server.validateStaticRoute(w, r, egw, route) {
+		return
+	}
+	version, _ := strconv.Atoi(existing.Version)
+	route.Version = strconv.Itoa(version + 1)
+	egw.staticRoutes[index] = route
+	writeOpenApiTask(w, server.newTask(s, "updateStaticRoute", "Updated static route "+route.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) deleteStaticRoute(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestStaticRoute(w, r, s)
+	if egw == nil {
+		return
+	}
+	name := egw.staticRoutes[index].Name
+	egw.staticRoutes = slices.Delete(egw.staticRoutes, index, index+1)
+	writeOpenApiTask(w, server.newTask(s, "deleteStaticRoute", "Deleted static route "+name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getRouteAdvertisement(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	advertisement := egw.routeAdvertisement
+	if advertisement.Subnets == nil {
+		advertisement.Subnets = []string{}
+	}
+	writeJson(w, http.StatusOK, advertisement)
+}
+
+func (server *Server) updateRouteAdvertisement(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	advertisement := types.RouteAdvertisement{}
+	if !decodeJson(w, r, &advertisement) {
+		return
+	}
+	egw.routeAdvertisement = advertisement
+	writeOpenApiTask(w, server.newTask(s, "updateRouteAdvertisement", "Updated route advertisement of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getBgpConfig(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, egw.bgpConfig)
+}
+
+// updateBgpConfig replaces the BGP configuration of the Edge Gateway. Like VCD, the update must
+// send the current version of the configuration
+func (server *Server) updateBgpConfig(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	config := types.EdgeBgpConfig{}
+	if !decodeJson(w, r, &config) {
+		return
+	}
+	if config.Version.Version != egw.bgpConfig.Version.Version {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "version %d of the BGP configuration does not match %d",
+			config.Version.Version, egw.bgpConfig.Version.Version)
+		return
+	}
+	if config.Enabled && config.LocalASNumber == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "enabled BGP configuration must have a local AS number")
+		return
+	}
+	config.Version.Version++
+	egw.bgpConfig = config
+	writeOpenApiTask(w, server.newTask(s, "updateBgpConfig", "Updated BGP configuration of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+// validateBgpIpPrefixList checks the BGP IP Prefix List sent by the client. It writes an error and
+// returns false if the list is not valid
+func validateBgpIpPrefixList(w http.ResponseWriter, r *http.Request, egw *edgeGateway, prefixList *types.EdgeBgpIpPrefixList) bool {
+	if prefixList.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "BGP IP Prefix List name cannot be empty")
+		return false
+	}
+	if slices.ContainsFunc(egw.bgpIpPrefixLists, func(existing *types.EdgeBgpIpPrefixList) bool {
+		return existing.Name == prefixList.Name && existing.ID != prefixList.ID
+	}) {
+		writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "BGP IP Prefix List with name '%s' already exists in Edge Gateway '%s'",
+			prefixList.Name, egw.gateway.Name)
+		return false
+	}
+	return true
+}
+
+// requestBgpIpPrefixList returns the Edge Gateway and the index of the BGP IP Prefix List of the
+// request path. It writes an error and returns nil if either of them does not exist or is not
+// visible
+func (server *Server) requestBgpIpPrefixList(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, int) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return nil, 0
+	}
+	index := bgpIpPrefixListIndex(egw.bgpIpPrefixLists, r.PathValue("prefixListId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	return egw, index
+}
+
+// getBgpIpPrefixLists lists the BGP IP Prefix Lists of the Edge Gateway. Like VCD, it does not
+// support filters
+func (server *Server) getBgpIpPrefixLists(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeOpenApiPage(w, r, egw.bgpIpPrefixLists, func(prefixList *types.EdgeBgpIpPrefixList) map[string]string {
+		return map[string]string{}
+	})
+}
+
+func (server *Server) getBgpIpPrefixList(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestBgpIpPrefixList(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, egw.bgpIpPrefixLists[index])
+}
+
+// createBgpIpPrefixList adds a BGP IP Prefix List to the Edge Gateway. Like VCD, the task is owned
+// by the Edge Gateway and its details contain the ID of the new list
+func (server *Server) createBgpIpPrefixList(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	prefixList := &types.EdgeBgpIpPrefixList{}
+	if !decodeJson(w, r, prefixList) {
+		return
+	}
+	prefixList.ID = newUuid()
+	if !validateBgpIpPrefixList(w, r, egw, prefixList) {
+		return
+	}
+	egw.bgpIpPrefixLists = append(egw.bgpIpPrefixLists, prefixList)
+	task := server.newTask(s, "createBgpIpPrefixList", "Created BGP IP Prefix List "+prefixList.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway))
+	task.Details = prefixList.ID
+	writeOpenApiTask(w, task)
+}
+
+func (server *Server) updateBgpIpPrefixList(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestBgpIpPrefixList(w, r, s)
+	if egw == nil {
+		return
+	}
+	prefixList := &types.EdgeBgpIpPrefixList{}
+	if !decodeJson(w, r, prefixList) {
+		return
+	}
+	if prefixList.ID != egw.bgpIpPrefixLists[index].ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "BGP IP Prefix List ID '%s' does not match '%s'", prefixList.ID, egw.bgpIpPrefixLists[index].ID)
+		return
+	}
+	if !validateBgpIpPrefixList(w, r, egw, prefixList) {
+		return
+	}
+	egw.bgpIpPrefixLists[index] = prefixList
+	writeOpenApiTask(w, server.newTask(s, "updateBgpIpPrefixList", "Updated BGP IP Prefix List "+prefixList.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+// deleteBgpIpPrefixList removes a BGP IP Prefix List from the Edge Gateway. Like VCD, lists used
+// by BGP neighbors cannot be deleted
+func (server *Server) deleteBgpIpPrefixList(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestBgpIpPrefixList(w, r, s)
+	if egw == nil {
+		return
+	}
+	prefixList := egw.bgpIpPrefixLists[index]
+	for _, neighbor := range egw.bgpNeighbors {
+		for _, ref := range []*types.OpenApiReference{neighbor.InRoutesFilterRef, neighbor.OutRoutesFilterRef} {
+			if ref != nil && ref.ID == prefixList.ID {
+				writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "BGP IP Prefix List '%s' is used by BGP neighbor '%s'",
+					prefixList.Name, neighbor.NeighborAddress)
+				return
+			}
+		}
+	}
+	egw.bgpIpPrefixLists = slices.Delete(egw.bgpIpPrefixLists, index, index+1)
+	writeOpenApiTask(w, server.newTask(s, "deleteBgpIpPrefixList", "Deleted BGP IP Prefix List "+prefixList.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+// validateBgpNeighbor checks the BGP neighbor sent by the client and sets the names of its route
+// filters, which must be BGP IP Prefix Lists of the Edge Gateway. It writes an error and returns
+// false if the neighbor is not valid
+func validateBgpNeighbor(w http.ResponseWriter, r *http.Request, egw *edgeGateway, neighbor *types.EdgeBgpNeighbor) bool {
+	if neighbor.NeighborAddress == "" || neighbor.RemoteASNumber == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "BGP neighbor address and remote AS number cannot be empty")
+		return false
+	}
+	if slices.ContainsFunc(egw.bgpNeighbors, func(existing *types.EdgeBgpNeighbor) bool {
+		return existing.NeighborAddress == neighbor.NeighborAddress && existing.ID != neighbor.ID
+	}) {
+		writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "BGP neighbor '%s' already exists in Edge Gateway '%s'",
+			neighbor.NeighborAddress, egw.gateway.Name)
+		return false
+	}
+	for _, ref := range []*types.OpenApiReference{neighbor.InRoutesFilterRef, neighbor.OutRoutesFilterRef} {
+		if ref == nil || ref.ID == "" {
+			continue
+		}
+		index := bgpIpPrefixListIndex(egw.bgpIpPrefixLists, ref.ID)
+		if index < 0 {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "BGP IP Prefix List '%s' of BGP neighbor '%s' does not exist in Edge Gateway '%s'",
+				ref.ID, neighbor.NeighborAddress, egw.gateway.Name)
+			return false
+		}
+		ref.Name = egw.bgpIpPrefixLists[index].Name
+	}
+	return true
+}
+
+// requestBgpNeighbor returns the Edge Gateway and the index of the BGP neighbor of the request
+// path. It writes an error and returns nil if either of them does not exist or is not visible
+func (server *Server) requestBgpNeighbor(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, int) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return nil, 0
+	}
+	index := bgpNeighborIndex(egw.bgpNeighbors, r.PathValue("neighborId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	return egw, index
+}
+
+// getBgpNeighbors lists the BGP neighbors of the Edge Gateway. Like VCD, it does not support
+// filters
+func (server *Server) getBgpNeighbors(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeOpenApiPage(w, r, egw.bgpNeighbors, func(neighbor *types.EdgeBgpNeighbor) map[string]string {
+		return map[string]string{}
+	})
+}
+
+func (server *Server) getBgpNeighbor(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestBgpNeighbor(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, egw.bgpNeighbors[index])
+}
+
+// createBgpNeighbor adds a BGP neighbor to the Edge Gateway. Like VCD, the task is owned by the
+// Edge Gateway and its details contain the ID of the new neighbor
+func (server *Server) createBgpNeighbor(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.routingEdgeGateway(w, r, s)
+	if egw == nil {
+		return
+	}
+	neighbor := &types.EdgeBgpNeighbor{}
+	if !decodeJson(w, r, neighbor) {
+		return
+	}
+	neighbor.ID = newUuid()
+	if !validateBgpNeighbor(w, r, egw, neighbor) {
+		return
+	}
+	egw.bgpNeighbors = append(egw.bgpNeighbors, neighbor)
+	task := server.newTask(s, "createBgpNeighbor", "Created BGP neighbor "+neighbor.NeighborAddress+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway))
+	task.Details = neighbor.ID
+	writeOpenApiTask(w, task)
+}
+
+func (server *Server) updateBgpNeighbor(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestBgpNeighbor(w, r, s)
+	if egw == nil {
+		return
+	}
+	neighbor := &types.EdgeBgpNeighbor{}
+	if !decodeJson(w, r, neighbor) {
+		return
+	}
+	if neighbor.ID != egw.bgpNeighbors[index].ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "BGP neighbor ID '%s' does not match '%s'", neighbor.ID, egw.bgpNeighbors[index].ID)
+		return
+	}
+	if !validateBgpNeighbor(w, r, egw, neighbor) {
+		return
+	}
+	egw.bgpNeighbors[index] = neighbor
+	writeOpenApiTask(w, server.newTask(s, "updateBgpNeighbor", "Updated BGP neighbor "+neighbor.NeighborAddress+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) deleteBgpNeighbor(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestBgpNeighbor(w, r, s)
+	if egw == nil {
+		return
+	}
+	address := egw.bgpNeighbors[index].NeighborAddress
+	egw.bgpNeighbors = slices.Delete(egw.bgpNeighbors, index, index+1)
+	writeOpenApiTask(w, server.newTask(s, "deleteBgpNeighbor", "Deleted BGP neighbor "+address+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
@@ -0,0 +1,648 @@
+// Package govcdtest provides an in-memory fake of VMware Cloud Director which can be used to test
+// code built on top of go-vcloud-director without a real VCD installation:
+//
+//	server := govcdtest.NewServer()
+//	defer server.Close()
+//	_ = server.AddUser(govcdtest.SystemOrg, "administrator", "password")
+//	_, _ = server.AddOrg("my-org")
+//	_, _ = server.AddVdc("my-org", "my-vdc")
+//
+//	vcdClient := govcd.NewVCDClient(server.Endpoint(), true)
+//	err := vcdClient.Authenticate("administrator", "password", govcdtest.SystemOrg)
+//
+// Like VCD, the server is only reachable with HTTPS. Its certificate is self-signed, therefore
+// clients must skip its verification (the 'insecure' argument of govcd.NewVCDClient).
+//
+// The server keeps state across calls, so that an entity created through the API can be
+// retrieved, changed and deleted later on. It serves:
+// * /api/versions and /cloudapi/1.0.0/sessions for provider and tenant users
+// * Orgs - /api/org, /api/admin/org and /cloudapi/1.0.0/orgs
+// * VDCs - /api/vdc, /api/admin/vdc and queries of types 'orgVdc' and 'adminOrgVdc'
+// * VDC storage profiles and queries of type 'providerVdcStorageProfile'
+// * Datastores - /api/admin/extension/datastore
+// * Org VDC networks - /api/network and /cloudapi/1.0.0/orgVdcNetworks
+// * NSX-T VDC Groups - /cloudapi/1.0.0/vdcGroups
+// * vApps and VMs - composition of empty vApps, adding empty VMs and removing VMs with
+// recomposition, reconfiguration and relocation of VMs, copy and move of vApps, power operations,
+// deploy, undeploy, deletion and queries of types 'vApp', 'adminVApp', 'vm' and 'adminVM'
+// * isolated vApp networks, vApp networks connected to Org VDC networks and NICs of VMs -
+// networkConfigSection and networkConnectionSection
+// * Tasks - every operation completes immediately with a successful task
+// * NSX-T Edge Gateways - /cloudapi/1.0.0/edgeGateways
+// * user defined firewall rules of NSX-T Edge Gateways, with ETags for the list of rules and for
+// each rule. Changes with a stale If-Match header fail with HTTP 412
+// * NAT rules, DHCP and DNS forwarders, SLAAC profiles and Rate Limiting of NSX-T Edge Gateways.
+// Gateway QoS Profiles are not modelled
+// * static routes, Route Advertisement, BGP configuration, BGP IP Prefix Lists and BGP neighbors of
+// NSX-T Edge Gateways. Like VCD, BGP and Route Advertisement require a dedicated uplink
+// * IPsec VPN tunnels, with their security profiles, and L2 VPN tunnels of NSX-T Edge Gateways
+// * NSX-T ALB settings, Pools and Virtual Services of Edge Gateways. ALB Clouds and Service Engine
+// Groups are not modelled
+// * rules of the default Distributed Firewall policy of NSX-T VDC Groups
+// * NSX-T Firewall Groups - IP Sets and Security Groups of Edge Gateways and VDC Groups. The VMs of
+// a static Security Group are the VMs connected to its member networks through vApp networks
+// * NSX-T Application Port Profiles - SYSTEM ones, such as HTTP and HTTPS, and TENANT ones - and
+// SYSTEM Network Context Profiles, such as DNS
+// * CCI Projects and Supervisor Namespaces - /cci/kubernetes. Supervisor Namespaces become ready at
+// their second retrieval, unless their status is set with Server.SetSupervisorNamespaceStatus
+//
+// Other endpoints return HTTP 404. Like a real VCD, the server returns HTTP 403 for entities that
+// do not exist or are not visible to the tenant of the session.
+//
+// Tests can serve other endpoints, or replace the built-in ones, by registering handlers with
+// Server.HandleFunc. WriteXml, WriteJson, WriteError and Server.WriteTask help to write responses
+// like the ones of VCD.
+package govcdtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// SystemOrg is the name of the provider organization which is created by NewServer
+const SystemOrg = "System"
+
+// defaultApiVersions are the API versions advertised by default
+var defaultApiVersions = []string{"37.0", "37.1", "37.2", "38.0", "38.1", "39.0", "39.1", "40.0"}
+
+// Server is an in-memory fake of VMware Cloud Director API
+type Server struct {
+	// URL is the base URL of the server (e.g. https://127.0.0.1:40000). Use Endpoint to get the
+	// URL for govcd.NewVCDClient
+	URL string
+
+	httpServer  *httptest.Server
+	apiVersions []string
+	// handlers are the handlers registered with HandleFunc
+	handlers *http.ServeMux
+
+	// mu guards all the fields below
+	mu             sync.Mutex
+	sessions       map[string]*session
+	orgs           map[string]*org
+	vdcs           map[string]*vdc
+	orgVdcNetworks map[string]*orgVdcNetwork
+	datastores     map[string]*datastore
+	vApps          map[string]*vApp
+	vms            map[string]*vm
+	tasks          map[string]*task
+	edgeGateways   map[string]*edgeGateway
+	vdcGroups      map[string]*vdcGroup
+	firewallGroups map[string]*firewallGroup
+	cciProjects    map[string]*cciProject
+	// albPools and albVirtualServices are the ALB entities of all Edge Gateways
+	albPools           map[string]*albPool
+	albVirtualServices map[string]*albVirtualService
+	// appPortProfiles and networkContextProfiles include the SYSTEM profiles created by NewServer
+	appPortProfiles        map[string]*appPortProfile
+	networkContextProfiles []*types.NsxtNetworkContextProfile
+	// omitEtags is set with OmitEtags
+	omitEtags bool
+	// cciResourceVersion is the resource version of the last change of a CCI entity
+	cciResourceVersion int
+}
+
+// ServerOption customizes Server created by NewServer
+type ServerOption func(*Server)
+
+// WithApiVersions sets the API versions advertised by /api/versions. The default ones are 37.0 to
+// 40.0
+func WithApiVersions(versions ...string) ServerOption {
+	return func(server *Server) {
+		server.apiVersions = versions
+	}
+}
+
+// NewServer starts a new server with an empty System organization. It must be closed with Close
+// when it is no longer needed.
+func NewServer(options ...ServerOption) *Server {
+	server := &Server{
+		apiVersions:     defaultApiVersions,
+		handlers:        http.NewServeMux(),
+		sessions:        make(map[string]*session),
+		orgs:            make(map[string]*org),
+		vdcs:            make(map[string]*vdc),
+		orgVdcNetworks:  make(map[string]*orgVdcNetwork),
+		datastores:      make(map[string]*datastore),
+		vApps:           make(map[string]*vApp),
+		vms:             make(map[string]*vm),
+		tasks:           make(map[string]*task),
+		edgeGateways:    make(map[string]*edgeGateway),
+		vdcGroups:       make(map[string]*vdcGroup),
+		firewallGroups:  make(map[string]*firewallGroup),
+		cciProjects:     make(map[string]*cciProject),
+		appPortProfiles: make(map[string]*appPortProfile),
+
+		albPools:           make(map[string]*albPool),
+		albVirtualServices: make(map[string]*albVirtualService),
+	}
+	for _, option := range options {
+		option(server)
+	}
+	_, _ = server.AddOrg(SystemOrg)
+	server.addSystemProfiles()
+
+	server.httpServer = httptest.NewTLSServer(server.serveHTTP(server.routes()))
+	server.URL = server.httpServer.URL
+	return server
+}
+
+// Close shuts down the server
+func (server *Server) Close() {
+	server.httpServer.Close()
+}
+
+// Endpoint returns the API endpoint which can be passed to govcd.NewVCDClient
+func (server *Server) Endpoint() url.URL {
+	endpoint, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		panic(fmt.Sprintf("error parsing server URL: %s", err))
+	}
+	return *endpoint
+}
+
+// AddUser adds a user which can authenticate in the given organization. Users of SystemOrg are
+// System Administrators
+func (server *Server) AddUser(orgName, userName, password string) error {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	o := server.orgByName(orgName)
+	if o == nil {
+		return fmt.Errorf("org '%s' does not exist", orgName)
+	}
+	if userName == "" {
+		return fmt.Errorf("user name cannot be empty")
+	}
+	o.users[userName] = password
+	return nil
+}
+
+// OmitEtags makes the server leave out the ETag header from its responses when omit is true, to
+// test clients against VCD installations, or proxies, which do not return it. Requests with an
+// If-Match header are still checked
+func (server *Server) OmitEtags(omit bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.omitEtags = omit
+}
+
+// ExpireSessions invalidates all sessions, so that the next API call of every client fails with
+// HTTP 401
+func (server *Server) ExpireSessions() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	clear(server.sessions)
+}
+
+// session is an authenticated API session
+type session struct {
+	id     string
+	org    *org
+	user   string
+	userId string
+}
+
+// isProvider returns true if the session belongs to a System Administrator
+func (s *session) isProvider() bool {
+	return s.org.name == SystemOrg
+}
+
+// canAccess returns true if entities of the given org are visible in the session
+func (s *session) canAccess(o *org) bool {
+	return s.isProvider() || s.org == o
+}
+
+// handlerFunc handles an authenticated request while the state of the server is locked
+type handlerFunc func(w http.ResponseWriter, r *http.Request, s *session)
+
+// routes returns the handler of all supported endpoints
+func (server *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/versions", server.getVersions)
+	mux.HandleFunc("POST /cloudapi/1.0.0/sessions", server.createSession)
+	mux.HandleFunc("POST /cloudapi/1.0.0/sessions/provider", server.createSession)
+	mux.HandleFunc("GET /cloudapi/1.0.0/sessions/current", server.authenticated(server.getCurrentSession))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/sessions", server.authenticated(server.deleteSession))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/sessions/{id}", server.authenticated(server.deleteSession))
+
+	mux.HandleFunc("GET /api/org", server.authenticated(server.getOrgList))
+	mux.HandleFunc("GET /api/org/{id}", server.authenticated(server.getOrg))
+	mux.HandleFunc("GET /api/admin/org/{id}", server.authenticated(server.getAdminOrg))
+	mux.HandleFunc("GET /cloudapi/1.0.0/orgs/{$}", server.authenticated(server.getOpenApiOrgs))
+	mux.HandleFunc("GET /cloudapi/1.0.0/orgs/{id}", server.authenticated(server.getOpenApiOrg))
+
+	mux.HandleFunc("GET /api/vdc/{id}", server.authenticated(server.getVdc))
+	mux.HandleFunc("GET /api/admin/vdc/{id}", server.authenticated(server.getAdminVdc))
+	mux.HandleFunc("GET /api/query", server.authenticated(server.query))
+	mux.HandleFunc("GET /api/network/{id}", server.authenticated(server.getNetwork))
+	mux.HandleFunc("DELETE /api/network/{id}", server.authenticated(server.deleteNetwork))
+	mux.HandleFunc("GET /api/admin/extension/datastore/{id}", server.authenticated(server.getDatastore))
+	mux.HandleFunc("GET /cloudapi/1.0.0/orgVdcNetworks/{$}", server.authenticated(server.getOpenApiOrgVdcNetworks))
+	mux.HandleFunc("GET /cloudapi/1.0.0/orgVdcNetworks/{id}", server.authenticated(server.getOpenApiOrgVdcNetwork))
+	mux.HandleFunc("GET /cloudapi/1.0.0/vdcGroups/{$}", server.authenticated(server.getVdcGroups))
+	mux.HandleFunc("GET /cloudapi/1.0.0/vdcGroups/{id}", server.authenticated(server.getVdcGroup))
+	mux.HandleFunc("GET /cloudapi/1.0.0/vdcGroups/{id}/dfwPolicies/default/rules", server.authenticated(server.getDistributedFirewallRules))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/vdcGroups/{id}/dfwPolicies/default/rules", server.authenticated(server.updateDistributedFirewallRules))
+
+	mux.HandleFunc("POST /api/vdc/{id}/action/composeVApp", server.authenticated(server.composeVApp))
+	mux.HandleFunc("POST /api/vdc/{id}/action/cloneVApp", server.authenticated(server.cloneVApp))
+	mux.HandleFunc("GET /api/vApp/{id}", server.authenticated(server.getVAppOrVm))
+	mux.HandleFunc("DELETE /api/vApp/{id}", server.authenticated(server.deleteVAppOrVm))
+	mux.HandleFunc("POST /api/vApp/{id}/power/action/{action}", server.authenticated(server.powerAction))
+	mux.HandleFunc("POST /api/vApp/{id}/action/{action}", server.authenticated(server.action))
+	mux.HandleFunc("GET /api/vApp/{id}/networkConfigSection/{$}", server.authenticated(server.getNetworkConfigSection))
+	mux.HandleFunc("PUT /api/vApp/{id}/networkConfigSection/{$}", server.authenticated(server.updateNetworkConfigSection))
+	mux.HandleFunc("GET /api/vApp/{id}/networkConnectionSection/{$}", server.authenticated(server.getNetworkConnectionSection))
+	mux.HandleFunc("PUT /api/vApp/{id}/networkConnectionSection/{$}", server.authenticated(server.updateNetworkConnectionSection))
+
+	mux.HandleFunc("GET /api/task/{id}", server.authenticated(server.getTask))
+
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{$}", server.authenticated(server.getEdgeGateways))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{$}", server.authenticated(server.createEdgeGateway))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}", server.authenticated(server.getEdgeGateway))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}", server.authenticated(server.updateEdgeGateway))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}", server.authenticated(server.deleteEdgeGateway))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/firewall/rules", server.authenticated(server.getFirewallRules))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/firewall/rules", server.authenticated(server.updateFirewallRules))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/firewall/rules/{ruleId}", server.authenticated(server.getFirewallRule))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/firewall/rules/{ruleId}", server.authenticated(server.updateFirewallRule))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/firewall/rules/{ruleId}", server.authenticated(server.deleteFirewallRule))
+
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/nat/rules/{$}", server.authenticated(server.getNatRules))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{id}/nat/rules/{$}", server.authenticated(server.createNatRule))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/nat/rules/{ruleId}", server.authenticated(server.getNatRule))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/nat/rules/{ruleId}", server.authenticated(server.updateNatRule))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/nat/rules/{ruleId}", server.authenticated(server.deleteNatRule))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/dhcpForwarder", server.authenticated(server.getDhcpForwarder))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/dhcpForwarder", server.authenticated(server.updateDhcpForwarder))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/dns", server.authenticated(server.getDns))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/dns", server.authenticated(server.updateDns))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/slaacProfile", server.authenticated(server.getSlaacProfile))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/slaacProfile", server.authenticated(server.updateSlaacProfile))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/qos", server.authenticated(server.getQos))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/qos", server.authenticated(server.updateQos))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/staticRoutes/{$}", server.authenticated(server.getStaticRoutes))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{id}/routing/staticRoutes/{$}", server.authenticated(server.createStaticRoute))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/staticRoutes/{routeId}", server.authenticated(server.getStaticRoute))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/routing/staticRoutes/{routeId}", server.authenticated(server.updateStaticRoute))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/routing/staticRoutes/{routeId}", server.authenticated(server.deleteStaticRoute))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/advertisement", server.authenticated(server.getRouteAdvertisement))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/routing/advertisement", server.authenticated(server.updateRouteAdvertisement))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp", server.authenticated(server.getBgpConfig))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp", server.authenticated(server.updateBgpConfig))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/prefixLists/{$}", server.authenticated(server.getBgpIpPrefixLists))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/prefixLists/{$}", server.authenticated(server.createBgpIpPrefixList))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/prefixLists/{prefixListId}", server.authenticated(server.getBgpIpPrefixList))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/prefixLists/{prefixListId}", server.authenticated(server.updateBgpIpPrefixList))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/prefixLists/{prefixListId}", server.authenticated(server.deleteBgpIpPrefixList))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/neighbors/{$}", server.authenticated(server.getBgpNeighbors))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/neighbors/{$}", server.authenticated(server.createBgpNeighbor))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/neighbors/{neighborId}", server.authenticated(server.getBgpNeighbor))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/neighbors/{neighborId}", server.authenticated(server.updateBgpNeighbor))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/routing/bgp/neighbors/{neighborId}", server.authenticated(server.deleteBgpNeighbor))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{$}", server.authenticated(server.getIpSecVpnTunnels))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{$}", server.authenticated(server.createIpSecVpnTunnel))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{tunnelId}", server.authenticated(server.getIpSecVpnTunnel))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{tunnelId}", server.authenticated(server.updateIpSecVpnTunnel))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{tunnelId}", server.authenticated(server.deleteIpSecVpnTunnel))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{tunnelId}/connectionProperties", server.authenticated(server.getIpSecVpnTunnelConnectionProperties))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/ipsec/tunnels/{tunnelId}/connectionProperties", server.authenticated(server.updateIpSecVpnTunnelConnectionProperties))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/l2vpn/tunnels/{$}", server.authenticated(server.getL2VpnTunnels))
+	mux.HandleFunc("POST /cloudapi/1.0.0/edgeGateways/{id}/l2vpn/tunnels/{$}", server.authenticated(server.createL2VpnTunnel))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/l2vpn/tunnels/{tunnelId}", server.authenticated(server.getL2VpnTunnel))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/l2vpn/tunnels/{tunnelId}", server.authenticated(server.updateL2VpnTunnel))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/edgeGateways/{id}/l2vpn/tunnels/{tunnelId}", server.authenticated(server.deleteL2VpnTunnel))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/loadBalancer", server.authenticated(server.getAlbSettings))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/edgeGateways/{id}/loadBalancer", server.authenticated(server.updateAlbSettings))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/loadBalancer/poolSummaries", server.authenticated(server.getAlbPoolSummaries))
+	mux.HandleFunc("GET /cloudapi/1.0.0/edgeGateways/{id}/loadBalancer/virtualServiceSummaries", server.authenticated(server.getAlbVirtualServiceSummaries))
+	mux.HandleFunc("POST /cloudapi/1.0.0/loadBalancer/pools/{$}", server.authenticated(server.createAlbPool))
+	mux.HandleFunc("GET /cloudapi/1.0.0/loadBalancer/pools/{id}", server.authenticated(server.getAlbPool))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/loadBalancer/pools/{id}", server.authenticated(server.updateAlbPool))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/loadBalancer/pools/{id}", server.authenticated(server.deleteAlbPool))
+	mux.HandleFunc("POST /cloudapi/1.0.0/loadBalancer/virtualServices/{$}", server.authenticated(server.createAlbVirtualService))
+	mux.HandleFunc("GET /cloudapi/1.0.0/loadBalancer/virtualServices/{id}", server.authenticated(server.getAlbVirtualService))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/loadBalancer/virtualServices/{id}", server.authenticated(server.updateAlbVirtualService))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/loadBalancer/virtualServices/{id}", server.authenticated(server.deleteAlbVirtualService))
+
+	mux.HandleFunc("GET /cloudapi/1.0.0/firewallGroups/summaries", server.authenticated(server.getFirewallGroupSummaries))
+	mux.HandleFunc("POST /cloudapi/1.0.0/firewallGroups/{$}", server.authenticated(server.createFirewallGroup))
+	mux.HandleFunc("GET /cloudapi/1.0.0/firewallGroups/{id}", server.authenticated(server.getFirewallGroup))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/firewallGroups/{id}", server.authenticated(server.updateFirewallGroup))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/firewallGroups/{id}", server.authenticated(server.deleteFirewallGroup))
+	mux.HandleFunc("GET /cloudapi/1.0.0/firewallGroups/{id}/associatedVMs", server.authenticated(server.getFirewallGroupVms))
+	mux.HandleFunc("GET /cloudapi/1.0.0/applicationPortProfiles/{$}", server.authenticated(server.getAppPortProfiles))
+	mux.HandleFunc("POST /cloudapi/1.0.0/applicationPortProfiles/{$}", server.authenticated(server.createAppPortProfile))
+	mux.HandleFunc("GET /cloudapi/1.0.0/applicationPortProfiles/{id}", server.authenticated(server.getAppPortProfile))
+	mux.HandleFunc("PUT /cloudapi/1.0.0/applicationPortProfiles/{id}", server.authenticated(server.updateAppPortProfile))
+	mux.HandleFunc("DELETE /cloudapi/1.0.0/applicationPortProfiles/{id}", server.authenticated(server.deleteAppPortProfile))
+	mux.HandleFunc("GET /cloudapi/1.0.0/networkContextProfiles", server.authenticated(server.getNetworkContextProfiles))
+
+	projectsPath := cciPath + ccitypes.ProjectsURL
+	mux.HandleFunc("GET "+projectsPath, server.authenticated(server.getCciProjects))
+	mux.HandleFunc("POST "+projectsPath, server.authenticated(server.createCciProject))
+	mux.HandleFunc("GET "+projectsPath+"/{name}", server.authenticated(server.getCciProject))
+	mux.HandleFunc("PUT "+projectsPath+"/{name}", server.authenticated(server.updateCciProject))
+	mux.HandleFunc("DELETE "+projectsPath+"/{name}", server.authenticated(server.deleteCciProject))
+	namespacesPath := cciPath + fmt.Sprintf(ccitypes.SupervisorNamespacesURL, "{project}")
+	mux.HandleFunc("GET "+namespacesPath, server.authenticated(server.getSupervisorNamespaces))
+	mux.HandleFunc("POST "+namespacesPath, server.authenticated(server.createSupervisorNamespace))
+	mux.HandleFunc("GET "+namespacesPath+"/{name}", server.authenticated(server.getSupervisorNamespace))
+	mux.HandleFunc("PUT "+namespacesPath+"/{name}", server.authenticated(server.updateSupervisorNamespace))
+	mux.HandleFunc("DELETE "+namespacesPath+"/{name}", server.authenticated(server.deleteSupervisorNamespace))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, http.StatusNotFound, "NOT_FOUND", "endpoint %s %s is not supported by govcdtest", r.Method, r.URL.Path)
+	})
+	return mux
+}
+
+// authenticated wraps a handler so that it runs only for requests of a valid session
+func (server *Server) authenticated(handler handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		s := server.sessions[requestToken(r)]
+		if s == nil {
+			writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "session is not authenticated or has expired")
+			return
+		}
+		handler(w, r, s)
+	}
+}
+
+// requestToken returns the session token sent with the request
+func requestToken(r *http.Request) string {
+	if token := r.Header.Get("X-Vmware-Vcloud-Access-Token"); token != "" {
+		return token
+	}
+	if token := r.Header.Get("X-Vcloud-Authorization"); token != "" {
+		return token
+	}
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > len("bearer ") && strings.EqualFold(authorization[:len("bearer ")], "bearer ") {
+		return authorization[len("bearer "):]
+	}
+	return ""
+}
+
+// supportedVersions is the response of /api/versions
+type supportedVersions struct {
+	XMLName     xml.Name      `xml:"SupportedVersions"`
+	Xmlns       string        `xml:"xmlns,attr"`
+	VersionInfo []versionInfo `xml:"VersionInfo"`
+}
+
+type versionInfo struct {
+	Deprecated bool   `xml:"deprecated,attr"`
+	Version    string `xml:"Version"`
+	LoginUrl   string `xml:"LoginUrl"`
+}
+
+func (server *Server) getVersions(w http.ResponseWriter, r *http.Request) {
+	versions := supportedVersions{Xmlns: "http://www.vmware.com/vcloud/versions"}
+	for _, version := range server.apiVersions {
+		versions.VersionInfo = append(versions.VersionInfo, versionInfo{
+			Version:  version,
+			LoginUrl: server.URL + "/cloudapi/1.0.0/sessions",
+		})
+	}
+	writeXml(w, http.StatusOK, "application/*+xml", versions)
+}
+
+func (server *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	userAtOrg, password, ok := r.BasicAuth()
+	userName, orgName, found := strings.Cut(userAtOrg, "@")
+	if !ok || !found {
+		writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "user and org must be specified as 'user@org'")
+		return
+	}
+	o := server.orgByName(orgName)
+	if o == nil || o.users[userName] == "" || o.users[userName] != password {
+		writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid credentials")
+		return
+	}
+	isProviderLogin := strings.HasSuffix(r.URL.Path, "/provider")
+	if isProviderLogin != (o.name == SystemOrg) {
+		writeError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "provider users must use /sessions/provider and tenant users /sessions")
+		return
+	}
+
+	// The token is longer than 32 characters like bearer tokens of a real VCD
+	token := randomHex(64)
+	s := &session{id: newUuid(), org: o, user: userName, userId: "urn:vcloud:user:" + newUuid()}
+	server.sessions[token] = s
+
+	w.Header().Set("X-Vmware-Vcloud-Access-Token", token)
+	w.Header().Set("X-Vmware-Vcloud-Token-Type", "Bearer")
+	writeJson(w, http.StatusOK, server.sessionInfo(s))
+}
+
+func (server *Server) getCurrentSession(w http.ResponseWriter, r *http.Request, s *session) {
+	writeJson(w, http.StatusOK, server.sessionInfo(s))
+}
+
+func (server *Server) deleteSession(w http.ResponseWriter, r *http.Request, s *session) {
+	delete(server.sessions, requestToken(r))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *Server) sessionInfo(s *session) *types.CurrentSessionInfo {
+	role := "Organization Administrator"
+	if s.isProvider() {
+		role = "System Administrator"
+	}
+	return &types.CurrentSessionInfo{
+		ID:                        s.id,
+		User:                      types.OpenApiReference{Name: s.user, ID: s.userId},
+		Org:                       types.OpenApiReference{Name: s.org.name, ID: s.org.urn()},
+		Location:                  server.URL + "/cloudapi/1.0.0/sessions/" + s.id,
+		Roles:                     []string{role},
+		SessionIdleTimeoutMinutes: 30,
+	}
+}
+
+// task is a task together with the org it belongs to
+type task struct {
+	task *types.Task
+	org  *org
+}
+
+// newTask stores a successfully completed task
+func (server *Server) newTask(s *session, operationName, operation string, owner *types.Reference) *types.Task {
+	id := newUuid()
+	now := time.Now().Format(time.RFC3339)
+	t := &types.Task{
+		HREF:          server.URL + "/api/task/" + id,
+		Type:          types.MimeTask,
+		ID:            "urn:vcloud:task:" + id,
+		Name:          "task",
+		Status:        "success",
+		Operation:     operation,
+		OperationName: operationName,
+		StartTime:     now,
+		EndTime:       now,
+		Owner:         owner,
+		User:          &types.Reference{Name: s.user, ID: s.userId},
+		Organization:  &types.Reference{Name: s.org.name, ID: s.org.urn(), HREF: server.URL + "/api/org/" + s.org.id, Type: types.MimeOrg},
+		Progress:      100,
+	}
+	server.tasks[id] = &task{task: t, org: s.org}
+	return t
+}
+
+func (server *Server) getTask(w http.ResponseWriter, r *http.Request, s *session) {
+	t := server.tasks[r.PathValue("id")]
+	if t == nil || !s.canAccess(t.org) {
+		writeNotFound(w, r)
+		return
+	}
+	writeXml(w, http.StatusOK, types.MimeTask, t.task)
+}
+
+// writeXml writes the value as an XML response
+func writeXml(w http.ResponseWriter, status int, contentType string, value any) {
+	body, err := xml.Marshal(value)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error marshalling response: %s", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(body)
+}
+
+// setEtag sets the ETag header of the response, unless ETags are omitted with OmitEtags
+func (server *Server) setEtag(w http.ResponseWriter, etag string) {
+	if !server.omitEtags {
+		w.Header().Set("Etag", etag)
+	}
+}
+
+// writeJson writes the value as a JSON response
+func writeJson(w http.ResponseWriter, status int, value any) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error marshalling response: %s", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", types.JSONMime)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// writeError writes an error in the format of the API that was called - JSON for OpenAPI and XML
+// for the legacy API
+func writeError(w http.ResponseWriter, r *http.Request, status int, minorErrorCode, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if strings.HasPrefix(r.URL.Path, "/cloudapi/") {
+		writeJson(w, status, types.OpenApiError{MinorErrorCode: minorErrorCode, Message: message})
+		return
+	}
+	writeXml(w, status, types.MimeError, types.Error{
+		Message:        message,
+		MajorErrorCode: status,
+		MinorErrorCode: minorErrorCode,
+	})
+}
+
+// writeNotFound writes the error that VCD returns for entities which do not exist or are not
+// visible
+func writeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusForbidden, "ACCESS_TO_RESOURCE_IS_FORBIDDEN",
+		"[ %s ] Either you need some or all of the following rights [Base] to perform operations "+
+			"or the target entity is invalid.", newUuid())
+}
+
+// writeTask writes a task as the response of an asynchronous XML API operation
+func writeTask(w http.ResponseWriter, t *types.Task) {
+	writeXml(w, http.StatusAccepted, types.MimeTask, t)
+}
+
+// writeOpenApiTask writes the response of an asynchronous OpenAPI operation
+func writeOpenApiTask(w http.ResponseWriter, t *types.Task) {
+	w.Header().Set("Location", t.HREF)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// decodeXml decodes the XML body of the request. It writes an error and returns false if the body
+// is not valid
+func decodeXml(w http.ResponseWriter, r *http.Request, value any) bool {
+	if err := xml.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "error decoding request body: %s", err)
+		return false
+	}
+	return true
+}
+
+// decodeJson decodes the JSON body of the request. It writes an error and returns false if the
+// body is not valid
+func decodeJson(w http.ResponseWriter, r *http.Request, value any) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "error decoding request body: %s", err)
+		return false
+	}
+	return true
+}
+
+// sortedValues returns values of the map sorted with the given function, so that responses are
+// stable
+func sortedValues[K comparable, V any](m map[K]V, cmp func(a, b V) int) []V {
+	values := make([]V, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	slices.SortFunc(values, cmp)
+	return values
+}
+
+// newUuid returns a random (version 4) UUID
+func newUuid() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("error generating UUID: %s", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// randomHex returns a random hex string of the given length
+func randomHex(length int) string {
+	b := make([]byte, (length+1)/2)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("error generating token: %s", err))
+	}
+	return hex.EncodeToString(b)[:length]
+}
+
+// uuidFromId returns the UUID part of an URN (e.g. 'urn:vcloud:vdc:<UUID>') or of an HREF
+func uuidFromId(id string) string {
+	id = strings.TrimSuffix(id, "/")
+	if i := strings.LastIndexAny(id, ":/"); i >= 0 {
+		id = id[i+1:]
+	}
+	id = strings.TrimPrefix(id, "vapp-")
+	return strings.TrimPrefix(id, "vm-")
+}
@@ -0,0 +1,935 @@
+package govcdtest_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/ccitypes"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/govcdtest"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTestServer returns a server with an org 'org1' containing 'vdc1', a System Administrator
+// 'admin' and an Org Administrator 'user' of 'org1'
+func newTestServer(t *testing.T) (*govcdtest.Server, string) {
+	server := govcdtest.NewServer()
+	t.Cleanup(server.Close)
+
+	if _, err := server.AddOrg("org1"); err != nil {
+		t.Fatal(err)
+	}
+	vdcId, err := server.AddVdc("org1", "vdc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddUser(govcdtest.SystemOrg, "admin", "password"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddUser("org1", "user", "password"); err != nil {
+		t.Fatal(err)
+	}
+	return server, vdcId
+}
+
+// newTestClient returns a client authenticated in the given org
+func newTestClient(t *testing.T, server *govcdtest.Server, user, org string) *govcd.VCDClient {
+	vcdClient := govcd.NewVCDClient(server.Endpoint(), true)
+	if err := vcdClient.Authenticate(user, "password", org); err != nil {
+		t.Fatalf("error authenticating as %s@%s: %s", user, org, err)
+	}
+	return vcdClient
+}
+
+func TestServer_Authenticate(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	vcdClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	if !vcdClient.Client.IsSysAdmin {
+		t.Errorf("expected System Administrator session")
+	}
+	if vcdClient.Client.APIVersion != "37.0" {
+		t.Errorf("expected API version 37.0, got %s", vcdClient.Client.APIVersion)
+	}
+
+	err := govcd.NewVCDClient(server.Endpoint(), true).Authenticate("admin", "wrong", govcdtest.SystemOrg)
+	if err == nil {
+		t.Errorf("expected an error for invalid credentials")
+	}
+
+	getOrgList := func(vcdClient *govcd.VCDClient) error {
+		_, err := vcdClient.Client.ExecuteRequest(server.URL+"/api/org", http.MethodGet, "",
+			"error retrieving org list: %s", nil, &types.OrgList{})
+		return err
+	}
+	if err = getOrgList(vcdClient); err != nil {
+		t.Errorf("error retrieving org list: %s", err)
+	}
+
+	server.ExpireSessions()
+	if err = getOrgList(vcdClient); !errors.Is(err, govcd.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error after sessions expired, got %v", err)
+	}
+
+	vcdClient = newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	if err := vcdClient.Disconnect(); err != nil {
+		t.Errorf("error disconnecting: %s", err)
+	}
+	if err = getOrgList(vcdClient); !errors.Is(err, govcd.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error after disconnecting, got %v", err)
+	}
+}
+
+func TestServer_Tenancy(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	if _, err := server.AddOrg("org2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddVdc("org2", "vdc2"); err != nil {
+		t.Fatal(err)
+	}
+	networkId, err := server.AddOrgVdcNetwork("org1", "vdc1", "net1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = server.AddOrgVdcNetwork("org1", "vdc1", "net1"); err == nil {
+		t.Errorf("expected an error for duplicate network name")
+	}
+	if _, err = server.AddVdcStorageProfile("org1", "vdc1", "gold", true); err != nil {
+		t.Fatal(err)
+	}
+
+	vcdClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatalf("error retrieving admin org: %s", err)
+	}
+	vdc, err := adminOrg.GetVDCByName("vdc1", true)
+	if err != nil {
+		t.Fatalf("error retrieving VDC from admin org: %s", err)
+	}
+	if vdc.Vdc.ID != vdcId {
+		t.Errorf("expected VDC ID %s, got %s", vdcId, vdc.Vdc.ID)
+	}
+
+	tenantClient := newTestClient(t, server, "user", "org1")
+	if tenantClient.Client.IsSysAdmin {
+		t.Errorf("expected tenant session")
+	}
+	org, err := tenantClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatalf("error retrieving org: %s", err)
+	}
+	vdc, err = org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatalf("error retrieving VDC: %s", err)
+	}
+	network, err := vdc.GetOrgVdcNetworkByName("net1", false)
+	if err != nil {
+		t.Fatalf("error retrieving Org VDC network: %s", err)
+	}
+	if network.OrgVDCNetwork.ID != networkId {
+		t.Errorf("expected network ID %s, got %s", networkId, network.OrgVDCNetwork.ID)
+	}
+	if _, err = vdc.FindStorageProfileReference("gold"); err != nil {
+		t.Errorf("error retrieving storage profile: %s", err)
+	}
+	if _, err = org.GetVDCById(vdcId, false); err != nil {
+		t.Errorf("error retrieving VDC by ID: %s", err)
+	}
+	if _, err = org.GetVDCByName("vdc2", false); !govcd.ContainsNotFound(err) {
+		t.Errorf("expected VDC of another org to be invisible, got %v", err)
+	}
+	if _, err = tenantClient.GetOrgByName("org2"); err == nil {
+		t.Errorf("expected another org to be invisible")
+	}
+}
+
+func TestServer_VAppLifecycle(t *testing.T) {
+	server, _ := newTestServer(t)
+	vcdClient := newTestClient(t, server, "user", "org1")
+
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vapp, err := vdc.CreateRawVApp("vapp1", "description")
+	if err != nil {
+		t.Fatalf("error creating vApp: %s", err)
+	}
+	if _, err = vdc.CreateRawVApp("vapp1", ""); err == nil {
+		t.Errorf("expected an error for duplicate vApp name")
+	}
+	if vapp, err = vdc.GetVAppByName("vapp1", true); err != nil {
+		t.Fatalf("error retrieving vApp: %s", err)
+	}
+	if vapp.VApp.Description != "description" {
+		t.Errorf("expected description to be stored, got '%s'", vapp.VApp.Description)
+	}
+
+	vm, err := vapp.AddEmptyVm(&types.RecomposeVAppParamsForEmptyVm{
+		CreateItem: &types.CreateItem{
+			Name: "vm1",
+			VmSpecSection: &types.VmSpecSection{
+				OsType:           "sles10_64Guest",
+				NumCpus:          addrOf(2),
+				MemoryResourceMb: &types.MemoryResourceMb{Configured: 1024},
+				HardwareVersion:  &types.HardwareVersion{Value: "vmx-14"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error adding VM: %s", err)
+	}
+	if status, _ := vm.GetStatus(); status != "POWERED_OFF" {
+		t.Errorf("expected new VM to be POWERED_OFF, got %s", status)
+	}
+
+	task, err := vapp.PowerOn()
+	if err != nil {
+		t.Fatalf("error powering on vApp: %s", err)
+	}
+	if err = task.WaitTaskCompletion(); err != nil {
+		t.Fatalf("error waiting for task: %s", err)
+	}
+	if status, _ := vm.GetStatus(); status != "POWERED_ON" {
+		t.Errorf("expected VM to be POWERED_ON, got %s", status)
+	}
+
+	results, err := vcdClient.QueryWithNotEncodedParams(nil, map[string]string{
+		"type":   types.QtVm,
+		"filter": "name==vm1;containerName==vapp1",
+	})
+	if err != nil {
+		t.Fatalf("error querying VMs: %s", err)
+	}
+	if len(results.Results.VMRecord) != 1 || results.Results.VMRecord[0].Status != "POWERED_ON" {
+		t.Errorf("expected 1 powered on VM record, got %#v", results.Results.VMRecord)
+	}
+
+	// A deployed VM cannot be removed
+	if err = vapp.RemoveVM(*vm); err == nil {
+		t.Errorf("expected an error removing a deployed VM")
+	}
+	if task, err = vapp.Undeploy(); err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error undeploying vApp: %s", err)
+	}
+	if status, _ := vapp.GetStatus(); status != "POWERED_OFF" {
+		t.Errorf("expected vApp to be POWERED_OFF, got %s", status)
+	}
+	if err = vm.Delete(); err != nil {
+		t.Fatalf("error deleting VM: %s", err)
+	}
+	if err = vapp.Refresh(); err != nil || vapp.VApp.Children != nil {
+		t.Errorf("expected vApp without VMs, got error %v", err)
+	}
+
+	if task, err = vapp.Delete(); err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil {
+		t.Fatalf("error deleting vApp: %s", err)
+	}
+	if _, err = vdc.GetVAppByName("vapp1", true); !govcd.ContainsNotFound(err) {
+		t.Errorf("expected vApp to be deleted, got %v", err)
+	}
+	// Like VCD, the legacy API returns HTTP 403 for entities that do not exist
+	if err = vapp.Refresh(); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected HTTP 403 refreshing deleted vApp, got %v", err)
+	}
+}
+
+func TestServer_EdgeGateway(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	vcdClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edge, err := adminOrg.CreateNsxtEdgeGateway(&types.OpenAPIEdgeGateway{
+		Name:               "edge1",
+		OwnerRef:           &types.OpenApiReference{ID: vdcId},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{UplinkID: "urn:vcloud:network:1", UplinkName: "uplink"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating Edge Gateway: %s", err)
+	}
+	if edge.EdgeGateway.Org == nil || edge.EdgeGateway.Org.Name != "org1" {
+		t.Errorf("expected Edge Gateway to belong to org1, got %#v", edge.EdgeGateway.Org)
+	}
+
+	edge.EdgeGateway.Description = "updated"
+	if _, err = edge.Update(edge.EdgeGateway); err != nil {
+		t.Fatalf("error updating Edge Gateway: %s", err)
+	}
+
+	tenantClient := newTestClient(t, server, "user", "org1")
+	org, err := tenantClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantEdge, err := vdc.GetNsxtEdgeGatewayByName("edge1")
+	if err != nil {
+		t.Fatalf("error retrieving Edge Gateway: %s", err)
+	}
+	if tenantEdge.EdgeGateway.Description != "updated" {
+		t.Errorf("expected updated description, got '%s'", tenantEdge.EdgeGateway.Description)
+	}
+	if err = tenantEdge.Delete(); err == nil {
+		t.Errorf("expected tenant to be unable to delete Edge Gateway")
+	}
+
+	if err = edge.Delete(); err != nil {
+		t.Fatalf("error deleting Edge Gateway: %s", err)
+	}
+	if _, err = vdc.GetNsxtEdgeGatewayByName("edge1"); !govcd.ContainsNotFound(err) {
+		t.Errorf("expected Edge Gateway to be deleted, got %v", err)
+	}
+	if err = edge.Refresh(); !govcd.ContainsNotFound(err) {
+		t.Errorf("expected not found error refreshing deleted Edge Gateway, got %v", err)
+	}
+}
+
+func TestServer_VdcGroupsAndNetworks(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	if _, err := server.AddVdc("org1", "vdc2"); err != nil {
+		t.Fatal(err)
+	}
+	groupId, err := server.AddVdcGroup("org1", "group1", "vdc1", "vdc2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = server.AddVdcGroup("org1", "group2", "missing"); err == nil {
+		t.Errorf("expected an error adding a VDC Group with a missing VDC")
+	}
+	networkId, err := server.AddOrgVdcNetwork("org1", "vdc1", "net1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vcdClient := newTestClient(t, server, "user", "org1")
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdcGroup, err := adminOrg.GetVdcGroupByName("group1")
+	if err != nil {
+		t.Fatalf("error retrieving VDC Group: %s", err)
+	}
+	if vdcGroup.VdcGroup.Id != groupId || len(vdcGroup.VdcGroup.ParticipatingOrgVdcs) != 2 {
+		t.Errorf("unexpected VDC Group %#v", vdcGroup.VdcGroup)
+	}
+	if _, err = adminOrg.GetVdcGroupById(groupId); err != nil {
+		t.Errorf("error retrieving VDC Group by ID: %s", err)
+	}
+
+	vdc, err := adminOrg.GetVDCByName("vdc1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	network, err := vdc.GetOpenApiOrgVdcNetworkByName("net1")
+	if err != nil {
+		t.Fatalf("error retrieving Org VDC network: %s", err)
+	}
+	if network.OpenApiOrgVdcNetwork.ID != networkId || network.OpenApiOrgVdcNetwork.OwnerRef.ID != vdcId {
+		t.Errorf("unexpected Org VDC network %#v", network.OpenApiOrgVdcNetwork)
+	}
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = org.GetOpenApiOrgVdcNetworkById(networkId); err != nil {
+		t.Errorf("error retrieving Org VDC network by ID: %s", err)
+	}
+}
+
+func TestServer_FirewallRules(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	vcdClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edge, err := adminOrg.CreateNsxtEdgeGateway(&types.OpenAPIEdgeGateway{
+		Name:               "edge1",
+		OwnerRef:           &types.OpenApiReference{ID: vdcId},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{UplinkID: "urn:vcloud:network:1", UplinkName: "uplink"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	web, err := edge.CreateNsxtFirewallRule("", &types.NsxtFirewallRule{Name: "web", ActionValue: "ALLOW", IpProtocol: "IPV4", Direction: "IN_OUT"})
+	if err != nil {
+		t.Fatalf("error creating firewall rule: %s", err)
+	}
+	if web.Rule.ID == "" || web.Etag == "" {
+		t.Errorf("expected the rule to have an ID and an ETag, got '%s' and '%s'", web.Rule.ID, web.Etag)
+	}
+	// Rules are kept when the Edge Gateway is updated
+	edge.EdgeGateway.Description = "updated"
+	if edge, err = edge.Update(edge.EdgeGateway); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := web.Update(&types.NsxtFirewallRule{Name: "web", ActionValue: "DROP", IpProtocol: "IPV4", Direction: "IN_OUT"})
+	if err != nil {
+		t.Fatalf("error updating firewall rule: %s", err)
+	}
+	if updated.Etag == web.Etag || updated.Rule.Version == nil || *updated.Rule.Version.Version != 2 {
+		t.Errorf("expected the update to change the ETag and the version, got %#v", updated)
+	}
+	// A change with a stale ETag fails with HTTP 412
+	if _, err = web.Update(updated.Rule); !errors.Is(err, govcd.ErrConflict) {
+		t.Errorf("expected a conflict updating with a stale ETag, got %v", err)
+	}
+	if err = updated.Delete(); err != nil {
+		t.Fatalf("error deleting firewall rule: %s", err)
+	}
+	firewall, err := edge.GetNsxtFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firewall.NsxtFirewallRuleContainer.UserDefinedRules) != 0 {
+		t.Errorf("expected no rules after deletion, got %d", len(firewall.NsxtFirewallRuleContainer.UserDefinedRules))
+	}
+}
+
+func TestServer_FirewallGroupsAndProfiles(t *testing.T) {
+	server, _ := newTestServer(t)
+	groupId, err := server.AddVdcGroup("org1", "group1", "vdc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	networkId, err := server.AddOrgVdcNetwork("org1", "vdc1", "net1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vcdClient := newTestClient(t, server, "user", "org1")
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdcGroup, err := adminOrg.GetVdcGroupById(groupId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Firewall Groups
+	ipSet, err := vdcGroup.CreateNsxtFirewallGroup(&types.NsxtFirewallGroup{
+		Name: "web", TypeValue: types.FirewallGroupTypeIpSet, IpAddresses: []string{"10.0.0.1"}, OwnerRef: &types.OpenApiReference{ID: groupId},
+	})
+	if err != nil {
+		t.Fatalf("error creating IP Set: %s", err)
+	}
+	if !ipSet.IsIpSet() || ipSet.NsxtFirewallGroup.OwnerRef.Name != "group1" {
+		t.Errorf("unexpected IP Set %#v", ipSet.NsxtFirewallGroup)
+	}
+	_, err = vdcGroup.CreateNsxtFirewallGroup(&types.NsxtFirewallGroup{
+		Name: "web", TypeValue: types.FirewallGroupTypeIpSet, OwnerRef: &types.OpenApiReference{ID: groupId},
+	})
+	if err == nil {
+		t.Errorf("expected an error creating a duplicate IP Set")
+	}
+	securityGroup, err := vdcGroup.CreateNsxtFirewallGroup(&types.NsxtFirewallGroup{
+		Name: "app", TypeValue: types.FirewallGroupTypeSecurityGroup, Members: []types.OpenApiReference{{ID: networkId}},
+		OwnerRef: &types.OpenApiReference{ID: groupId},
+	})
+	if err != nil {
+		t.Fatalf("error creating Security Group: %s", err)
+	}
+	if !securityGroup.IsSecurityGroup() || securityGroup.NsxtFirewallGroup.Members[0].Name != "net1" {
+		t.Errorf("unexpected Security Group %#v", securityGroup.NsxtFirewallGroup)
+	}
+	if vms, err := securityGroup.GetAssociatedVms(); err != nil || len(vms) != 0 {
+		t.Errorf("expected no VMs connected to the members of the Security Group, got %v (%v)", vms, err)
+	}
+	found, err := vdcGroup.GetNsxtFirewallGroupByName("web", types.FirewallGroupTypeIpSet)
+	if err != nil || found.NsxtFirewallGroup.ID != ipSet.NsxtFirewallGroup.ID {
+		t.Fatalf("error retrieving IP Set by name: %v", err)
+	}
+	found.NsxtFirewallGroup.IpAddresses = append(found.NsxtFirewallGroup.IpAddresses, "10.0.0.2")
+	if updated, err := found.Update(found.NsxtFirewallGroup); err != nil || len(updated.NsxtFirewallGroup.IpAddresses) != 2 {
+		t.Errorf("error updating IP Set: %v", err)
+	}
+	if err = ipSet.Delete(); err != nil {
+		t.Fatalf("error deleting IP Set: %s", err)
+	}
+	if _, err = vdcGroup.GetNsxtFirewallGroupById(ipSet.NsxtFirewallGroup.ID); err == nil {
+		t.Errorf("expected an error retrieving a deleted IP Set")
+	}
+
+	// Application Port Profiles
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	https, err := vdcGroup.GetNsxtAppPortProfileByName("HTTPS", types.ApplicationPortProfileScopeSystem)
+	if err != nil {
+		t.Fatalf("error retrieving SYSTEM Application Port Profile: %s", err)
+	}
+	if err = https.Delete(); err == nil {
+		t.Errorf("expected an error deleting a SYSTEM Application Port Profile")
+	}
+	profile, err := org.CreateNsxtAppPortProfile(&types.NsxtAppPortProfile{
+		Name:             "custom",
+		ApplicationPorts: []types.NsxtAppPortProfilePort{{Protocol: "TCP", DestinationPorts: []string{"8443"}}},
+		OrgRef:           &types.OpenApiReference{ID: org.Org.ID},
+		ContextEntityId:  groupId,
+		Scope:            types.ApplicationPortProfileScopeTenant,
+	})
+	if err != nil {
+		t.Fatalf("error creating Application Port Profile: %s", err)
+	}
+	custom, err := vdcGroup.GetNsxtAppPortProfileByName("custom", types.ApplicationPortProfileScopeTenant)
+	if err != nil || custom.NsxtAppPortProfile.ID != profile.NsxtAppPortProfile.ID {
+		t.Fatalf("error retrieving Application Port Profile by name: %v", err)
+	}
+	profile.NsxtAppPortProfile.Description = "updated"
+	if _, err = profile.Update(profile.NsxtAppPortProfile); err != nil {
+		t.Errorf("error updating Application Port Profile: %s", err)
+	}
+	if err = profile.Delete(); err != nil {
+		t.Errorf("error deleting Application Port Profile: %s", err)
+	}
+	dns, err := govcd.GetNetworkContextProfilesByNameScopeAndContext(&vcdClient.Client, "DNS", types.ApplicationPortProfileScopeSystem, groupId)
+	if err != nil || dns.Name != "DNS" {
+		t.Errorf("error retrieving Network Context Profile: %v", err)
+	}
+
+	// Distributed Firewall rules
+	_, err = vdcGroup.UpdateDistributedFirewall(&types.DistributedFirewallRules{Values: []*types.DistributedFirewallRule{
+		{Name: "app-dns", ActionValue: "ALLOW", IpProtocol: "IPV4", Direction: "IN_OUT", Enabled: true,
+			SourceFirewallGroups: []types.OpenApiReference{{ID: securityGroup.NsxtFirewallGroup.ID}}, NetworkContextProfiles: []types.OpenApiReference{{ID: dns.ID}}},
+	}})
+	if err != nil {
+		t.Fatalf("error updating Distributed Firewall: %s", err)
+	}
+	firewall, err := vdcGroup.GetDistributedFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := firewall.DistributedFirewallRuleContainer.Values
+	if len(rules) != 1 || rules[0].ID == "" || rules[0].NetworkContextProfiles[0].ID != dns.ID {
+		t.Errorf("unexpected Distributed Firewall rules %#v", rules)
+	}
+}
+
+func TestServer_NatRulesDhcpForwarderAndAlb(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	vcdClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edge, err := adminOrg.CreateNsxtEdgeGateway(&types.OpenAPIEdgeGateway{
+		Name:               "edge1",
+		OwnerRef:           &types.OpenApiReference{ID: vdcId},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{UplinkID: "urn:vcloud:network:1", UplinkName: "uplink"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NAT rules
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	https, err := org.GetNsxtAppPortProfileByName("HTTPS", types.ApplicationPortProfileScopeSystem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dnat, err := edge.CreateNatRule(&types.NsxtNatRule{
+		Name: "web", RuleType: types.NsxtNatRuleTypeDnat, Enabled: true, ExternalAddresses: "203.0.113.10",
+		InternalAddresses: "10.0.0.10", ApplicationPortProfile: &types.OpenApiReference{ID: https.NsxtAppPortProfile.ID},
+	})
+	if err != nil {
+		t.Fatalf("error creating NAT rule: %s", err)
+	}
+	if dnat.NsxtNatRule.ID == "" || dnat.NsxtNatRule.Type != types.NsxtNatRuleTypeDnat || dnat.NsxtNatRule.ApplicationPortProfile.Name != "HTTPS" {
+		t.Errorf("unexpected NAT rule %#v", dnat.NsxtNatRule)
+	}
+	_, err = edge.CreateNatRule(&types.NsxtNatRule{Name: "invalid", RuleType: "FORWARD"})
+	if err == nil {
+		t.Errorf("expected an error creating a NAT rule with an invalid type")
+	}
+	dnat.NsxtNatRule.InternalAddresses = "10.0.0.11"
+	updated, err := dnat.Update(dnat.NsxtNatRule)
+	if err != nil {
+		t.Fatalf("error updating NAT rule: %s", err)
+	}
+	if updated.NsxtNatRule.InternalAddresses != "10.0.0.11" || *updated.NsxtNatRule.Version.Version != 1 {
+		t.Errorf("unexpected updated NAT rule %#v", updated.NsxtNatRule)
+	}
+	if err = updated.Delete(); err != nil {
+		t.Fatalf("error deleting NAT rule: %s", err)
+	}
+	if rules, err := edge.GetAllNatRules(nil); err != nil || len(rules) != 0 {
+		t.Errorf("expected no NAT rules after deletion, got %d (%v)", len(rules), err)
+	}
+
+	// DHCP forwarder
+	forwarder, err := edge.UpdateDhcpForwarder(&types.NsxtEdgeGatewayDhcpForwarder{Enabled: true, DhcpServers: []string{"10.0.0.2"}})
+	if err != nil {
+		t.Fatalf("error updating DHCP forwarder: %s", err)
+	}
+	if !forwarder.Enabled || strings.Join(forwarder.DhcpServers, ",") != "10.0.0.2" || forwarder.Version.Version != 1 {
+		t.Errorf("unexpected DHCP forwarder %#v", forwarder)
+	}
+	if _, err = edge.UpdateDhcpForwarder(&types.NsxtEdgeGatewayDhcpForwarder{Enabled: true}); err == nil {
+		t.Errorf("expected an error enabling the DHCP forwarder without DHCP servers")
+	}
+
+	// ALB
+	pool, err := vcdClient.CreateNsxtAlbPool(&types.NsxtAlbPool{Name: "web-pool", GatewayRef: types.OpenApiReference{ID: edge.EdgeGateway.ID}})
+	if err != nil {
+		t.Fatalf("error creating ALB Pool: %s", err)
+	}
+	if pool.NsxtAlbPool.GatewayRef.Name != "edge1" {
+		t.Errorf("unexpected ALB Pool %#v", pool.NsxtAlbPool)
+	}
+	virtualServiceConfig := &types.NsxtAlbVirtualService{
+		Name:                  "web-vs",
+		Enabled:               addrOf(true),
+		ApplicationProfile:    types.NsxtAlbVirtualServiceApplicationProfile{Type: "HTTP"},
+		GatewayRef:            types.OpenApiReference{ID: edge.EdgeGateway.ID},
+		LoadBalancerPoolRef:   types.OpenApiReference{ID: pool.NsxtAlbPool.ID},
+		ServiceEngineGroupRef: types.OpenApiReference{ID: "urn:vcloud:serviceEngineGroup:1"},
+		ServicePorts:          []types.NsxtAlbVirtualServicePort{{PortStart: addrOf(80)}},
+		VirtualIpAddress:      "203.0.113.20",
+	}
+	virtualService, err := vcdClient.CreateNsxtAlbVirtualService(virtualServiceConfig)
+	if err != nil {
+		t.Fatalf("error creating ALB Virtual Service: %s", err)
+	}
+	if virtualService.NsxtAlbVirtualService.LoadBalancerPoolRef.Name != "web-pool" {
+		t.Errorf("unexpected ALB Virtual Service %#v", virtualService.NsxtAlbVirtualService)
+	}
+	if _, err = vcdClient.CreateNsxtAlbVirtualService(virtualServiceConfig); err == nil {
+		t.Errorf("expected an error creating a duplicate ALB Virtual Service")
+	}
+	found, err := vcdClient.GetAlbVirtualServiceByName(edge.EdgeGateway.ID, "web-vs")
+	if err != nil || found.NsxtAlbVirtualService.ID != virtualService.NsxtAlbVirtualService.ID {
+		t.Errorf("error retrieving ALB Virtual Service by name: %v", err)
+	}
+	if err = pool.Delete(); err == nil {
+		t.Errorf("expected an error deleting an ALB Pool used by a Virtual Service")
+	}
+	if err = virtualService.Delete(); err != nil {
+		t.Fatalf("error deleting ALB Virtual Service: %s", err)
+	}
+	if err = pool.Delete(); err != nil {
+		t.Fatalf("error deleting ALB Pool: %s", err)
+	}
+	if pools, err := vcdClient.GetAllAlbPoolSummaries(edge.EdgeGateway.ID, nil); err != nil || len(pools) != 0 {
+		t.Errorf("expected no ALB Pools after deletion, got %d (%v)", len(pools), err)
+	}
+}
+
+func TestServer_RoutingAndVpn(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	networkId, err := server.AddOrgVdcNetwork("org1", "vdc1", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vcdClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	adminOrg, err := vcdClient.GetAdminOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, err := adminOrg.CreateNsxtEdgeGateway(&types.OpenAPIEdgeGateway{
+		Name:               "shared",
+		OwnerRef:           &types.OpenApiReference{ID: vdcId},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{UplinkID: "urn:vcloud:network:1", UplinkName: "uplink"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = shared.GetBgpConfiguration(); err == nil {
+		t.Errorf("expected an error retrieving BGP configuration of an Edge Gateway without dedicated uplink")
+	}
+	edge, err := adminOrg.CreateNsxtEdgeGateway(&types.OpenAPIEdgeGateway{
+		Name:               "dedicated",
+		OwnerRef:           &types.OpenApiReference{ID: vdcId},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{UplinkID: "urn:vcloud:network:1", UplinkName: "uplink", Dedicated: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Static routes
+	route, err := edge.CreateStaticRoute(&types.NsxtEdgeGatewayStaticRoute{
+		Name: "to-app", NetworkCidr: "172.16.0.0/24",
+		NextHops: []types.NsxtEdgeGatewayStaticRouteNextHops{{IPAddress: "10.0.0.254", AdminDistance: 1,
+			Scope: &types.NsxtEdgeGatewayStaticRouteNextHopScope{ID: networkId, ScopeType: "NETWORK"}}},
+	})
+	if err != nil {
+		t.Fatalf("error creating static route: %s", err)
+	}
+	if route.NsxtEdgeGatewayStaticRoute.NextHops[0].Scope.Name != "app" || *route.NsxtEdgeGatewayStaticRoute.SystemOwned {
+		t.Errorf("unexpected static route %#v", route.NsxtEdgeGatewayStaticRoute)
+	}
+	route.NsxtEdgeGatewayStaticRoute.NetworkCidr = "172.16.1.0/24"
+	route, err = route.Update(route.NsxtEdgeGatewayStaticRoute)
+	if err != nil {
+		t.Fatalf("error updating static route: %s", err)
+	}
+	if route.NsxtEdgeGatewayStaticRoute.Version != "1" {
+		t.Errorf("expected version 1 of the updated static route, got '%s'", route.NsxtEdgeGatewayStaticRoute.Version)
+	}
+	if _, err = shared.CreateStaticRoute(&types.NsxtEdgeGatewayStaticRoute{Name: "no-hops", NetworkCidr: "172.16.0.0/24"}); err == nil {
+		t.Errorf("expected an error creating a static route without next hops")
+	}
+
+	// BGP
+	bgpConfig, err := edge.GetBgpConfiguration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bgpConfig.Enabled = true
+	bgpConfig.LocalASNumber = "65000"
+	bgpConfig, err = edge.UpdateBgpConfiguration(bgpConfig)
+	if err != nil {
+		t.Fatalf("error updating BGP configuration: %s", err)
+	}
+	if !bgpConfig.Enabled || bgpConfig.Version.Version != 1 {
+		t.Errorf("unexpected BGP configuration %#v", bgpConfig)
+	}
+	prefixList, err := edge.CreateBgpIpPrefixList(&types.EdgeBgpIpPrefixList{
+		Name: "pl-in", Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{{Network: "10.0.0.0/8", Action: "PERMIT"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating BGP IP Prefix List: %s", err)
+	}
+	neighbor, err := edge.CreateBgpNeighbor(&types.EdgeBgpNeighbor{
+		NeighborAddress: "192.0.2.1", RemoteASNumber: "65001",
+		InRoutesFilterRef: &types.OpenApiReference{ID: prefixList.EdgeBgpIpPrefixList.ID},
+	})
+	if err != nil {
+		t.Fatalf("error creating BGP neighbor: %s", err)
+	}
+	if neighbor.EdgeBgpNeighbor.InRoutesFilterRef.Name != "pl-in" {
+		t.Errorf("unexpected BGP neighbor %#v", neighbor.EdgeBgpNeighbor)
+	}
+	if err = prefixList.Delete(); err == nil {
+		t.Errorf("expected an error deleting a BGP IP Prefix List used by a neighbor")
+	}
+	if _, err = edge.UpdateNsxtRouteAdvertisement(true, []string{"10.0.0.0/24"}); err != nil {
+		t.Fatalf("error updating route advertisement: %s", err)
+	}
+
+	// DNS forwarder
+	dns, err := edge.GetDnsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dns, err = dns.Update(&types.NsxtEdgeGatewayDns{
+		Enabled: true, Version: dns.NsxtEdgeGatewayDns.Version,
+		DefaultForwarderZone: &types.NsxtDnsForwarderZoneConfig{DisplayName: "default", UpstreamServers: []string{"8.8.8.8"}},
+	})
+	if err != nil {
+		t.Fatalf("error updating DNS forwarder: %s", err)
+	}
+	if dns.NsxtEdgeGatewayDns.DefaultForwarderZone.ID == "" || dns.NsxtEdgeGatewayDns.Version.Version != 1 {
+		t.Errorf("unexpected DNS forwarder %#v", dns.NsxtEdgeGatewayDns)
+	}
+
+	// IPsec VPN tunnels
+	ipSecVpnTunnel, err := edge.CreateIpSecVpnTunnel(&types.NsxtIpSecVpnTunnel{
+		Name: "to-dc", Enabled: true, PreSharedKey: "secret",
+		LocalEndpoint:  types.NsxtIpSecVpnTunnelLocalEndpoint{LocalAddress: "203.0.113.12", LocalNetworks: []string{"10.0.0.0/24"}},
+		RemoteEndpoint: types.NsxtIpSecVpnTunnelRemoteEndpoint{RemoteAddress: "198.51.100.1", RemoteNetworks: []string{"10.1.0.0/24"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating IPsec VPN tunnel: %s", err)
+	}
+	if ipSecVpnTunnel.NsxtIpSecVpn.PreSharedKey != "secret" || ipSecVpnTunnel.NsxtIpSecVpn.SecurityType != "DEFAULT" {
+		t.Errorf("unexpected IPsec VPN tunnel %#v", ipSecVpnTunnel.NsxtIpSecVpn)
+	}
+	allTunnels, err := edge.GetAllIpSecVpnTunnels(nil)
+	if err != nil || len(allTunnels) != 1 || allTunnels[0].NsxtIpSecVpn.PreSharedKey != "" {
+		t.Errorf("expected one IPsec VPN tunnel without pre-shared key in the list, got %d (%v)", len(allTunnels), err)
+	}
+	_, err = ipSecVpnTunnel.UpdateTunnelConnectionProperties(&types.NsxtIpSecVpnTunnelSecurityProfile{
+		IkeConfiguration: types.NsxtIpSecVpnTunnelProfileIkeConfiguration{IkeVersion: "IKE_V2"},
+	})
+	if err != nil {
+		t.Fatalf("error updating connection properties: %s", err)
+	}
+	ipSecVpnTunnel, err = edge.GetIpSecVpnTunnelById(ipSecVpnTunnel.NsxtIpSecVpn.ID)
+	if err != nil || ipSecVpnTunnel.NsxtIpSecVpn.SecurityType != "CUSTOM" {
+		t.Errorf("expected a CUSTOM IPsec VPN tunnel after setting its connection properties (%v)", err)
+	}
+
+	// L2 VPN tunnels
+	l2VpnTunnel, err := edge.CreateL2VpnTunnel(&types.NsxtL2VpnTunnel{
+		Name: "stretch", SessionMode: "SERVER", Enabled: true, LocalEndpointIp: "203.0.113.13", RemoteEndpointIp: "198.51.100.2",
+		ConnectorInitiationMode: "INITIATOR", PreSharedKey: "secret",
+		StretchedNetworks: []types.EdgeL2VpnStretchedNetwork{{NetworkRef: types.OpenApiReference{ID: networkId}, TunnelID: 1}},
+	})
+	if err != nil {
+		t.Fatalf("error creating L2 VPN tunnel: %s", err)
+	}
+	if l2VpnTunnel.NsxtL2VpnTunnel.StretchedNetworks[0].NetworkRef.Name != "app" {
+		t.Errorf("unexpected L2 VPN tunnel %#v", l2VpnTunnel.NsxtL2VpnTunnel)
+	}
+	l2VpnTunnel.NsxtL2VpnTunnel.Enabled = false
+	l2VpnTunnel, err = l2VpnTunnel.Update(l2VpnTunnel.NsxtL2VpnTunnel)
+	if err != nil {
+		t.Fatalf("error updating L2 VPN tunnel: %s", err)
+	}
+	if l2VpnTunnel.NsxtL2VpnTunnel.Enabled || l2VpnTunnel.NsxtL2VpnTunnel.Version.Version != 1 {
+		t.Errorf("unexpected updated L2 VPN tunnel %#v", l2VpnTunnel.NsxtL2VpnTunnel)
+	}
+}
+
+func TestServer_Cci(t *testing.T) {
+	server, _ := newTestServer(t)
+	vcdClient := newTestClient(t, server, "user", "org1")
+
+	project, err := vcdClient.CreateCciProject(&ccitypes.Project{ObjectMeta: v1.ObjectMeta{Name: "project1"}})
+	if err != nil {
+		t.Fatalf("error creating CCI Project: %s", err)
+	}
+	if _, err = vcdClient.CreateCciProject(&ccitypes.Project{ObjectMeta: v1.ObjectMeta{Name: "project1"}}); err == nil {
+		t.Errorf("expected an error creating a duplicate CCI Project")
+	}
+	adminClient := newTestClient(t, server, "admin", govcdtest.SystemOrg)
+	if _, err = adminClient.GetCciProjectByName("project1"); err != nil {
+		t.Errorf("expected System Administrator to see the CCI Project, got %v", err)
+	}
+
+	namespace, err := vcdClient.CreateSupervisorNamespace("project1", &ccitypes.SupervisorNamespace{
+		ObjectMeta: v1.ObjectMeta{GenerateName: "ns-"},
+	})
+	if err != nil {
+		t.Fatalf("error creating Supervisor Namespace: %s", err)
+	}
+	name := namespace.SupervisorNamespace.Name
+	if !strings.HasPrefix(name, "ns-") || len(name) != len("ns-")+5 {
+		t.Errorf("expected a generated name with prefix 'ns-', got '%s'", name)
+	}
+	if ready, _ := namespace.IsReady(); ready {
+		t.Errorf("expected new Supervisor Namespace not to be ready")
+	}
+	// Supervisor Namespaces become ready at their second retrieval
+	for i, expected := range []bool{false, true} {
+		namespace, err = vcdClient.GetSupervisorNamespaceByName("project1", name)
+		if err != nil {
+			t.Fatalf("error retrieving Supervisor Namespace: %s", err)
+		}
+		if ready, _ := namespace.IsReady(); ready != expected {
+			t.Errorf("expected ready %t at retrieval %d, got %t", expected, i+1, ready)
+		}
+	}
+
+	err = server.SetSupervisorNamespaceStatus("project1", name, ccitypes.SupervisorNamespaceStatus{
+		Phase:      "ERROR",
+		Conditions: []ccitypes.SupervisorNamespaceStatusConditions{{Type: "Ready", Status: "False", Reason: "NoCapacity"}},
+	})
+	if err != nil {
+		t.Fatalf("error setting Supervisor Namespace status: %s", err)
+	}
+	namespace, err = vcdClient.GetSupervisorNamespaceByName("project1", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespace.SupervisorNamespace.Status.Phase != "ERROR" {
+		t.Errorf("expected the status set by the test, got %#v", namespace.SupervisorNamespace.Status)
+	}
+	if err = server.SetSupervisorNamespaceStatus("project1", "missing", ccitypes.SupervisorNamespaceStatus{}); err == nil {
+		t.Errorf("expected an error setting the status of a missing Supervisor Namespace")
+	}
+
+	// Like Kubernetes, a Project is deleted only when it has no namespaces
+	if err = project.Delete(); err == nil {
+		t.Errorf("expected an error deleting a CCI Project with a Supervisor Namespace")
+	}
+	if err = namespace.Delete(); err != nil {
+		t.Fatalf("error deleting Supervisor Namespace: %s", err)
+	}
+	if err = project.Delete(); err != nil {
+		t.Fatalf("error deleting CCI Project: %s", err)
+	}
+	if _, err = vcdClient.GetCciProjectByName("project1"); !govcd.ContainsNotFound(err) {
+		t.Errorf("expected CCI Project to be deleted, got %v", err)
+	}
+}
+
+func TestServer_HandleFunc(t *testing.T) {
+	server, vdcId := newTestServer(t)
+	// A registered handler takes precedence over the built-in endpoint
+	server.HandleFunc("GET /api/vdc/{id}", func(w http.ResponseWriter, r *http.Request) {
+		govcdtest.WriteXml(w, http.StatusOK, types.MimeVDC, &types.Vdc{
+			HREF: server.URL + r.URL.Path,
+			ID:   vdcId,
+			Name: "replaced",
+		})
+	})
+	server.HandleFunc("POST /api/vdc/{id}/action/custom", func(w http.ResponseWriter, r *http.Request) {
+		server.WriteTask(w, r, "vdcCustom", r.PathValue("id"))
+	})
+	server.HandleFunc("POST /cloudapi/1.0.0/custom", func(w http.ResponseWriter, r *http.Request) {
+		server.WriteTask(w, r, "custom", "urn:vcloud:custom:1")
+	})
+	vcdClient := newTestClient(t, server, "user", "org1")
+
+	org, err := vcdClient.GetOrgByName("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdc, err := org.GetVDCById(vdcId, false)
+	if err != nil {
+		t.Fatalf("error retrieving VDC: %s", err)
+	}
+	if vdc.Vdc.Name != "replaced" {
+		t.Errorf("expected the VDC of the registered handler, got %s", vdc.Vdc.Name)
+	}
+
+	task, err := vcdClient.Client.ExecuteTaskRequest(vdc.Vdc.HREF+"/action/custom", http.MethodPost, "", "error: %s", nil)
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil || !strings.HasSuffix(vdc.Vdc.HREF, "/"+task.Task.Details) {
+		t.Errorf("expected a completed XML API task, got %#v and error %v", task.Task, err)
+	}
+
+	endpoint, err := vcdClient.Client.OpenApiBuildEndpoint("1.0.0/custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err = vcdClient.Client.OpenApiPostItemAsync(vcdClient.Client.APIVersion, endpoint, nil, map[string]string{})
+	if err == nil {
+		err = task.WaitTaskCompletion()
+	}
+	if err != nil || task.Task.Details != "urn:vcloud:custom:1" {
+		t.Errorf("expected a completed OpenAPI task, got %#v and error %v", task.Task, err)
+	}
+
+	// Registered handlers require a valid session
+	server.ExpireSessions()
+	_, err = vcdClient.Client.ExecuteTaskRequest(vdc.Vdc.HREF+"/action/custom", http.MethodPost, "", "error: %s", nil)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected HTTP 401 for an expired session, got %v", err)
+	}
+}
+
+func addrOf[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,157 @@
+package govcdtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// storageProfile is a storage profile of an Org VDC
+type storageProfile struct {
+	id   string
+	name string
+	// enabled is the state of the storage profile in the provider VDC
+	enabled bool
+}
+
+func (p *storageProfile) urn() string {
+	return "urn:vcloud:vdcstorageProfile:" + p.id
+}
+
+// datastore is a datastore of vCenter, which VMs can be relocated to
+type datastore struct {
+	id   string
+	name string
+}
+
+func (d *datastore) urn() string {
+	return "urn:vcloud:datastore:" + d.id
+}
+
+// AddVdcStorageProfile adds a storage profile to the given VDC and returns its ID. enabled is the
+// state of the storage profile in the provider VDC. Every VDC has its own provider VDC
+func (server *Server) AddVdcStorageProfile(orgName, vdcName, profileName string, enabled bool) (string, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	v, err := server.vdcByName(orgName, vdcName)
+	if err != nil {
+		return "", err
+	}
+	if profileName == "" {
+		return "", fmt.Errorf("storage profile name cannot be empty")
+	}
+	for _, p := range v.storageProfiles {
+		if p.name == profileName {
+			return "", fmt.Errorf("storage profile '%s' already exists in VDC '%s'", profileName, vdcName)
+		}
+	}
+	p := &storageProfile{id: newUuid(), name: profileName, enabled: enabled}
+	v.storageProfiles = append(v.storageProfiles, p)
+	return p.urn(), nil
+}
+
+// AddDatastore adds a datastore and returns its ID
+func (server *Server) AddDatastore(name string) (string, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if name == "" {
+		return "", fmt.Errorf("datastore name cannot be empty")
+	}
+	for _, d := range server.datastores {
+		if d.name == name {
+			return "", fmt.Errorf("datastore '%s' already exists", name)
+		}
+	}
+	d := &datastore{id: newUuid(), name: name}
+	server.datastores[d.id] = d
+	return d.urn(), nil
+}
+
+func (server *Server) storageProfileHref(p *storageProfile) string {
+	return server.URL + "/api/vdcStorageProfile/" + p.id
+}
+
+func (server *Server) providerVdcHref(v *vdc) string {
+	return server.URL + "/api/admin/providervdc/" + v.id
+}
+
+func (server *Server) datastoreHref(d *datastore) string {
+	return server.URL + "/api/admin/extension/datastore/" + d.id
+}
+
+// datastoreXml is the representation of a datastore in the extension API
+type datastoreXml struct {
+	XMLName xml.Name `xml:"Datastore"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	HREF    string   `xml:"href,attr"`
+	ID      string   `xml:"id,attr"`
+	Name    string   `xml:"name,attr"`
+}
+
+func (server *Server) getDatastore(w http.ResponseWriter, r *http.Request, s *session) {
+	d := server.datastores[r.PathValue("id")]
+	if d == nil || !s.isProvider() {
+		writeNotFound(w, r)
+		return
+	}
+	writeXml(w, http.StatusOK, types.AnyXMLMime, &datastoreXml{
+		Xmlns: types.XMLNamespaceExtension,
+		HREF:  server.datastoreHref(d),
+		ID:    d.urn(),
+		Name:  d.name,
+	})
+}
+
+// relocateVm moves a VM to a datastore. Like in VCD, it is only available to System Administrators
+func (server *Server) relocateVm(w http.ResponseWriter, r *http.Request, s *session) {
+	_, m := server.vAppOrVm(s, r.PathValue("id"))
+	if m == nil || !s.isProvider() {
+		writeNotFound(w, r)
+		return
+	}
+	params := &types.RelocateParams{}
+	if !decodeXml(w, r, params) {
+		return
+	}
+	if params.Datastore == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "datastore cannot be empty")
+		return
+	}
+	d := server.datastores[uuidFromId(params.Datastore.HREF)]
+	if d == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "datastore '%s' does not exist", params.Datastore.HREF)
+		return
+	}
+	m.datastore = d
+	writeTask(w, server.newTask(s, "vappRelocateVm", "Relocated VM "+m.name, server.vmReference(m)))
+}
+
+// queryProviderVdcStorageProfiles returns the storage profiles of the provider VDCs. Like in VCD,
+// they are only visible to System Administrators
+func (server *Server) queryProviderVdcStorageProfiles(s *session) []queryRecord {
+	if !s.isProvider() {
+		return nil
+	}
+	var records []queryRecord
+	for _, v := range sortedValues(server.vdcs, compareVdcs) {
+		for _, p := range v.storageProfiles {
+			record := &types.QueryResultProviderVdcStorageProfileRecordType{
+				HREF:            server.URL + "/api/admin/pvdcStorageProfile/" + p.id,
+				Name:            p.name,
+				ProviderVdcHREF: server.providerVdcHref(v),
+				IsEnabled:       p.enabled,
+			}
+			records = append(records, queryRecord{
+				fields: map[string]string{"name": p.name, "providerVdc": record.ProviderVdcHREF},
+				add: func(results *types.QueryResultRecordsType, admin bool) {
+					results.ProviderVdcStorageProfileRecord = append(results.ProviderVdcStorageProfileRecord, record)
+				},
+			})
+		}
+	}
+	return records
+}
@@ -0,0 +1,677 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// org is an organization
+type org struct {
+	id   string
+	name string
+	// users maps user names to passwords
+	users map[string]string
+}
+
+func (o *org) urn() string {
+	return "urn:vcloud:org:" + o.id
+}
+
+// vdc is an Org VDC
+type vdc struct {
+	id              string
+	name            string
+	org             *org
+	storageProfiles []*storageProfile
+}
+
+func (v *vdc) urn() string {
+	return "urn:vcloud:vdc:" + v.id
+}
+
+// AddOrg adds an organization and returns its ID
+func (server *Server) AddOrg(name string) (string, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if name == "" {
+		return "", fmt.Errorf("org name cannot be empty")
+	}
+	if server.orgByName(name) != nil {
+		return "", fmt.Errorf("org '%s' already exists", name)
+	}
+	o := &org{id: newUuid(), name: name, users: make(map[string]string)}
+	server.orgs[o.id] = o
+	return o.urn(), nil
+}
+
+// AddVdc adds an Org VDC to the given organization and returns its ID
+func (server *Server) AddVdc(orgName, vdcName string) (string, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	o := server.orgByName(orgName)
+	if o == nil {
+		return "", fmt.Errorf("org '%s' does not exist", orgName)
+	}
+	if vdcName == "" {
+		return "", fmt.Errorf("VDC name cannot be empty")
+	}
+	for _, v := range server.vdcs {
+		if v.org == o && v.name == vdcName {
+			return "", fmt.Errorf("VDC '%s' already exists in org '%s'", vdcName, orgName)
+		}
+	}
+	v := &vdc{id: newUuid(), name: vdcName, org: o}
+	server.vdcs[v.id] = v
+	return v.urn(), nil
+}
+
+// vdcByName returns the VDC with the given name of the given organization
+func (server *Server) vdcByName(orgName, vdcName string) (*vdc, error) {
+	o := server.orgByName(orgName)
+	if o == nil {
+		return nil, fmt.Errorf("org '%s' does not exist", orgName)
+	}
+	for _, v := range server.orgVdcs(o) {
+		if v.name == vdcName {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("VDC '%s' does not exist in org '%s'", vdcName, orgName)
+}
+
+// orgByName returns the organization with the given name. Like in VCD, names are case-insensitive
+func (server *Server) orgByName(name string) *org {
+	for _, o := range server.orgs {
+		if strings.EqualFold(o.name, name) {
+			return o
+		}
+	}
+	return nil
+}
+
+// visibleOrgs returns organizations visible in the session sorted by name
+func (server *Server) visibleOrgs(s *session) []*org {
+	var orgs []*org
+	for _, o := range sortedValues(server.orgs, compareOrgs) {
+		if s.canAccess(o) {
+			orgs = append(orgs, o)
+		}
+	}
+	return orgs
+}
+
+// orgVdcs returns VDCs of the organization sorted by name
+func (server *Server) orgVdcs(o *org) []*vdc {
+	var vdcs []*vdc
+	for _, v := range sortedValues(server.vdcs, compareVdcs) {
+		if v.org == o {
+			vdcs = append(vdcs, v)
+		}
+	}
+	return vdcs
+}
+
+func compareOrgs(a, b *org) int {
+	return cmp.Compare(a.name, b.name)
+}
+
+func compareVdcs(a, b *vdc) int {
+	return cmp.Or(cmp.Compare(a.org.name, b.org.name), cmp.Compare(a.name, b.name))
+}
+
+func (server *Server) orgHref(o *org) string {
+	return server.URL + "/api/org/" + o.id
+}
+
+func (server *Server) vdcHref(v *vdc) string {
+	return server.URL + "/api/vdc/" + v.id
+}
+
+func (server *Server) getOrgList(w http.ResponseWriter, r *http.Request, s *session) {
+	orgList := &types.OrgList{}
+	for _, o := range server.visibleOrgs(s) {
+		orgList.Org = append(orgList.Org, &types.Org{HREF: server.orgHref(o), Type: types.MimeOrg, Name: o.name})
+	}
+	writeXml(w, http.StatusOK, types.MimeOrgList, orgList)
+}
+
+func (server *Server) getOrg(w http.ResponseWriter, r *http.Request, s *session) {
+	o := server.orgs[r.PathValue("id")]
+	if o == nil || !s.canAccess(o) {
+		writeNotFound(w, r)
+		return
+	}
+	result := &types.Org{
+		HREF:      server.orgHref(o),
+		Type:      types.MimeOrg,
+		ID:        o.urn(),
+		Name:      o.name,
+		FullName:  o.name,
+		IsEnabled: true,
+	}
+	for _, v := range server.orgVdcs(o) {
+		result.Link = append(result.Link, &types.Link{Rel: "down", Type: types.MimeVDC, Name: v.name, HREF: server.vdcHref(v)})
+	}
+	writeXml(w, http.StatusOK, types.MimeOrg, result)
+}
+
+func (server *Server) getAdminOrg(w http.ResponseWriter, r *http.Request, s *session) {
+	o := server.orgs[r.PathValue("id")]
+	if o == nil || !s.canAccess(o) {
+		writeNotFound(w, r)
+		return
+	}
+	result := &types.AdminOrg{
+		Xmlns:     types.XMLNamespaceVCloud,
+		HREF:      server.URL + "/api/admin/org/" + o.id,
+		Type:      types.MimeAdminOrg,
+		ID:        o.urn(),
+		Name:      o.name,
+		FullName:  o.name,
+		IsEnabled: true,
+		Vdcs:      &types.VDCList{},
+		Link: types.LinkList{
+			{Rel: "alternate", Type: types.MimeOrg, HREF: server.orgHref(o)},
+		},
+	}
+	for _, v := range server.orgVdcs(o) {
+		result.Vdcs.Vdcs = append(result.Vdcs.Vdcs, &types.Reference{
+			HREF: server.URL + "/api/admin/vdc/" + v.id,
+			ID:   v.urn(),
+			Type: types.MimeAdminVDC,
+			Name: v.name,
+		})
+	}
+	writeXml(w, http.StatusOK, types.MimeAdminOrg, result)
+}
+
+func (server *Server) openApiOrg(o *org) *types.OpenApiOrg {
+	result := &types.OpenApiOrg{
+		Id:          o.urn(),
+		Name:        o.name,
+		DisplayName: o.name,
+		IsEnabled:   true,
+		OrgVdcCount: len(server.orgVdcs(o)),
+		UserCount:   len(o.users),
+	}
+	for _, a := range server.vApps {
+		if a.vdc.org == o {
+			result.VappCount++
+			for _, m := range a.vms {
+				if m.status == statusPoweredOn {
+					result.RunningVMCount++
+				}
+			}
+		}
+	}
+	return result
+}
+
+func (server *Server) getOpenApiOrgs(w http.ResponseWriter, r *http.Request, s *session) {
+	var orgs []*types.OpenApiOrg
+	for _, o := range server.visibleOrgs(s) {
+		orgs = append(orgs, server.openApiOrg(o))
+	}
+	writeOpenApiPage(w, r, orgs, func(o *types.OpenApiOrg) map[string]string {
+		return map[string]string{"id": o.Id, "name": o.Name, "isEnabled": strconv.FormatBool(o.IsEnabled)}
+	})
+}
+
+func (server *Server) getOpenApiOrg(w http.ResponseWriter, r *http.Request, s *session) {
+	o := server.orgs[uuidFromId(r.PathValue("id"))]
+	if o == nil || !s.canAccess(o) {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, server.openApiOrg(o))
+}
+
+// vdcXml returns the XML representation of the VDC
+func (server *Server) vdcXml(v *vdc) *types.Vdc {
+	result := &types.Vdc{
+		HREF:            server.vdcHref(v),
+		Type:            types.MimeVDC,
+		ID:              v.urn(),
+		Name:            v.name,
+		Status:          1,
+		AllocationModel: "Flex",
+		IsEnabled:       true,
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeOrg, HREF: server.orgHref(v.org)},
+			{Rel: "add", Type: types.MimeComposeVappParams, HREF: server.vdcHref(v) + "/action/composeVApp"},
+		},
+	}
+	resourceEntities := &types.ResourceEntities{}
+	for _, a := range server.vdcVApps(v) {
+		resourceEntities.ResourceEntity = append(resourceEntities.ResourceEntity, &types.ResourceReference{
+			HREF: server.vAppHref(a),
+			ID:   a.urn(),
+			Type: types.MimeVApp,
+			Name: a.name,
+		})
+	}
+	result.ResourceEntities = []*types.ResourceEntities{resourceEntities}
+	availableNetworks := &types.AvailableNetworks{}
+	for _, n := range server.vdcNetworks(v) {
+		availableNetworks.Network = append(availableNetworks.Network, &types.Reference{
+			HREF: server.networkHref(n.id),
+			ID:   n.urn(),
+			Type: types.MimeOrgVdcNetwork,
+			Name: n.name,
+		})
+	}
+	result.AvailableNetworks = []*types.AvailableNetworks{availableNetworks}
+	if len(v.storageProfiles) > 0 {
+		result.VdcStorageProfiles = &types.VdcStorageProfiles{}
+		for _, p := range v.storageProfiles {
+			result.VdcStorageProfiles.VdcStorageProfile = append(result.VdcStorageProfiles.VdcStorageProfile, &types.Reference{
+				HREF: server.storageProfileHref(p),
+				ID:   p.urn(),
+				Type: types.MimeStorageProfile,
+				Name: p.name,
+			})
+		}
+	}
+	return result
+}
+
+func (server *Server) getVdc(w http.ResponseWriter, r *http.Request, s *session) {
+	v := server.vdcs[r.PathValue("id")]
+	if v == nil || !s.canAccess(v.org) {
+		writeNotFound(w, r)
+		return
+	}
+	writeXml(w, http.StatusOK, types.MimeVDC, server.vdcXml(v))
+}
+
+func (server *Server) getAdminVdc(w http.ResponseWriter, r *http.Request, s *session) {
+	v := server.vdcs[r.PathValue("id")]
+	if v == nil || !s.canAccess(v.org) {
+		writeNotFound(w, r)
+		return
+	}
+	result := &types.AdminVdc{Xmlns: types.XMLNamespaceVCloud, Vdc: *server.vdcXml(v)}
+	result.HREF = server.URL + "/api/admin/vdc/" + v.id
+	result.Type = types.MimeAdminVDC
+	if s.isProvider() {
+		result.ProviderVdcReference = &types.Reference{HREF: server.providerVdcHref(v), Type: types.MimeProviderVdc, Name: v.name + "-pvdc"}
+	}
+	writeXml(w, http.StatusOK, types.MimeAdminVDC, result)
+}
+
+// queryResultRecords is the response of /api/query
+type queryResultRecords struct {
+	XMLName xml.Name `xml:"QueryResultRecords"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	types.QueryResultRecordsType
+}
+
+// query handles /api/query for VDCs, vApps and VMs. Filters support only conditions joined with
+// ';' (AND) which compare a field with '=='
+func (server *Server) query(w http.ResponseWriter, r *http.Request, s *session) {
+	params := parseRawQuery(r.URL.RawQuery)
+	queryType := params["type"]
+	if format := params["format"]; format != "" && format != "records" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "query format '%s' is not supported by govcdtest", format)
+		return
+	}
+	filter, err := parseFilter(params["filter"])
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+		return
+	}
+	page, pageSize, err := pageParams(params)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+		return
+	}
+
+	var records []queryRecord
+	switch queryType {
+	case types.QtOrgVdc, types.QtAdminOrgVdc:
+		records = server.queryVdcs(s)
+	case types.QtVapp, types.QtAdminVapp:
+		records = server.queryVApps(s)
+	case types.QtVm, types.QtAdminVm:
+		records = server.queryVms(s)
+	case types.QtProviderVdcStorageProfile:
+		records = server.queryProviderVdcStorageProfiles(s)
+	default:
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "query type '%s' is not supported by govcdtest", queryType)
+		return
+	}
+
+	var matching []queryRecord
+	for _, record := range records {
+		matches, err := filter.matches(record.fields)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+			return
+		}
+		if matches {
+			matching = append(matching, record)
+		}
+	}
+
+	result := &queryResultRecords{Xmlns: types.XMLNamespaceVCloud}
+	result.HREF = server.URL + r.URL.RequestURI()
+	result.Type = types.MimeQueryRecords
+	result.Name = queryType
+	result.Page = page
+	result.PageSize = pageSize
+	result.Total = float64(len(matching))
+	for _, record := range pageOf(matching, page, pageSize) {
+		record.add(&result.QueryResultRecordsType, strings.HasPrefix(queryType, "admin"))
+	}
+	writeXml(w, http.StatusOK, types.MimeQueryRecords, result)
+}
+
+// queryRecord is a single result of a query
+type queryRecord struct {
+	// fields are values which can be used in filters
+	fields map[string]string
+	// add adds the record to results
+	add func(results *types.QueryResultRecordsType, admin bool)
+}
+
+func (server *Server) queryVdcs(s *session) []queryRecord {
+	var records []queryRecord
+	for _, v := range sortedValues(server.vdcs, compareVdcs) {
+		if !s.canAccess(v.org) {
+			continue
+		}
+		record := &types.QueryResultOrgVdcRecordType{
+			HREF:            server.vdcHref(v),
+			Name:            v.name,
+			IsEnabled:       "true",
+			OrgName:         v.org.name,
+			Org:             server.orgHref(v.org),
+			AllocationModel: "Flex",
+			Status:          "READY",
+		}
+		records = append(records, queryRecord{
+			fields: map[string]string{"id": v.urn(), "name": v.name, "org": v.org.urn(), "orgName": v.org.name, "isEnabled": "true"},
+			add: func(results *types.QueryResultRecordsType, admin bool) {
+				if admin {
+					results.OrgVdcAdminRecord = append(results.OrgVdcAdminRecord, record)
+				} else {
+					results.OrgVdcRecord = append(results.OrgVdcRecord, record)
+				}
+			},
+		})
+	}
+	return records
+}
+
+func (server *Server) queryVApps(s *session) []queryRecord {
+	var records []queryRecord
+	for _, a := range sortedValues(server.vApps, compareVApps) {
+		if !s.canAccess(a.vdc.org) {
+			continue
+		}
+		record := &types.QueryResultVAppRecordType{
+			HREF:        server.vAppHref(a),
+			Name:        a.name,
+			Deployed:    a.deployed,
+			Enabled:     true,
+			OwnerName:   a.owner,
+			Status:      types.VAppStatuses[a.status],
+			VdcHREF:     server.vdcHref(a.vdc),
+			VdcName:     a.vdc.name,
+			NumberOfVMs: len(a.vms),
+		}
+		records = append(records, queryRecord{
+			fields: map[string]string{
+				"id": a.urn(), "name": a.name, "vdc": a.vdc.urn(), "vdcName": a.vdc.name, "org": a.vdc.org.urn(),
+				"status": record.Status, "isDeployed": strconv.FormatBool(a.deployed),
+			},
+			add: func(results *types.QueryResultRecordsType, admin bool) {
+				if admin {
+					results.AdminVAppRecord = append(results.AdminVAppRecord, record)
+				} else {
+					results.VAppRecord = append(results.VAppRecord, record)
+				}
+			},
+		})
+	}
+	return records
+}
+
+func (server *Server) queryVms(s *session) []queryRecord {
+	var records []queryRecord
+	for _, a := range sortedValues(server.vApps, compareVApps) {
+		if !s.canAccess(a.vdc.org) {
+			continue
+		}
+		for _, m := range a.vms {
+			record := &types.QueryResultVMRecordType{
+				HREF:          server.vmHref(m),
+				ID:            m.urn(),
+				Name:          m.name,
+				Type:          types.MimeVM,
+				ContainerName: a.name,
+				ContainerID:   server.vAppHref(a),
+				OwnerName:     a.owner,
+				VdcHREF:       server.vdcHref(a.vdc),
+				VdcName:       a.vdc.name,
+				Status:        types.VAppStatuses[m.status],
+				Deployed:      m.deployed,
+			}
+			if m.storageProfile != nil {
+				record.StorageProfileName = m.storageProfile.Name
+			}
+			if m.datastore != nil && s.isProvider() {
+				record.DatastoreName = m.datastore.name
+			}
+			records = append(records, queryRecord{
+				fields: map[string]string{
+					"id": m.urn(), "name": m.name, "container": a.urn(), "containerName": a.name,
+					"vdc": a.vdc.urn(), "vdcName": a.vdc.name, "org": a.vdc.org.urn(), "status": record.Status,
+					"isDeployed": strconv.FormatBool(m.deployed), "isVAppTemplate": "false",
+				},
+				add: func(results *types.QueryResultRecordsType, admin bool) {
+					if admin {
+						results.AdminVMRecord = append(results.AdminVMRecord, record)
+					} else {
+						results.VMRecord = append(results.VMRecord, record)
+					}
+				},
+			})
+		}
+	}
+	return records
+}
+
+// parseRawQuery parses the query string of a request. Parameters which are not URL encoded (e.g.
+// filters sent by govcd) are parsed without changing them, because they may contain characters
+// that are not valid in URL encoding
+func parseRawQuery(rawQuery string) map[string]string {
+	params := make(map[string]string)
+	for _, param := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(param, "=")
+		if key == "" {
+			continue
+		}
+		if key != "filter" {
+			if unescaped, err := url.QueryUnescape(value); err == nil {
+				value = unescaped
+			}
+		}
+		params[key] = value
+	}
+	return params
+}
+
+// filterTerm is a single condition of a FIQL filter
+type filterTerm struct {
+	field string
+	value string
+}
+
+// filter is a list of conditions which must all be true
+type filter []filterTerm
+
+// parseFilter parses a FIQL filter. Only conditions comparing a field with '==' and joined with
+// ';' are supported. Values can be URL encoded
+func parseFilter(rawFilter string) (filter, error) {
+	if rawFilter == "" {
+		return nil, nil
+	}
+	// The whole filter is encoded when it is sent as a regular query parameter
+	if !strings.Contains(rawFilter, "==") {
+		unescaped, err := url.QueryUnescape(rawFilter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter '%s': %s", rawFilter, err)
+		}
+		rawFilter = unescaped
+	}
+	rawFilter = strings.TrimSuffix(strings.TrimPrefix(rawFilter, "("), ")")
+
+	var result filter
+	for _, rawTerm := range strings.Split(rawFilter, ";") {
+		rawTerm = strings.TrimSuffix(strings.TrimPrefix(rawTerm, "("), ")")
+		field, value, found := strings.Cut(rawTerm, "==")
+		if !found || field == "" || strings.ContainsAny(field, ",=!<>") {
+			return nil, fmt.Errorf("filter condition '%s' is not supported by govcdtest", rawTerm)
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		result = append(result, filterTerm{field: field, value: value})
+	}
+	return result, nil
+}
+
+// matches returns true if all conditions of the filter are true for the given fields. Fields
+// containing identifiers ('id', 'org', 'vdc', '*.id', etc.) match both URNs and HREFs of the
+// entity
+func (f filter) matches(fields map[string]string) (bool, error) {
+	for _, term := range f {
+		value, ok := fields[term.field]
+		if !ok {
+			return false, fmt.Errorf("filter field '%s' is not supported by govcdtest", term.field)
+		}
+		if strings.HasPrefix(value, "urn:") {
+			if uuidFromId(value) != uuidFromId(term.value) {
+				return false, nil
+			}
+			continue
+		}
+		if value != term.value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// pageParams returns the page and the page size requested in query parameters
+func pageParams(params map[string]string) (int, int, error) {
+	page, pageSize := 1, 25
+	var err error
+	if params["page"] != "" {
+		page, err = strconv.Atoi(params["page"])
+		if err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("invalid page '%s'", params["page"])
+		}
+	}
+	if params["pageSize"] != "" {
+		pageSize, err = strconv.Atoi(params["pageSize"])
+		if err != nil || pageSize < 1 {
+			return 0, 0, fmt.Errorf("invalid page size '%s'", params["pageSize"])
+		}
+	}
+	return page, min(pageSize, 128), nil
+}
+
+// pageOf returns the items on the given page
+func pageOf[T any](items []T, page, pageSize int) []T {
+	start := (page - 1) * pageSize
+	if start >= len(items) {
+		return nil
+	}
+	return items[start:min(start+pageSize, len(items))]
+}
+
+// requestFilter returns the filter of the request. It writes an error and returns false if the
+// filter is not supported
+func requestFilter(w http.ResponseWriter, r *http.Request) (filter, bool) {
+	f, err := parseFilter(parseRawQuery(r.URL.RawQuery)["filter"])
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+		return nil, false
+	}
+	return f, true
+}
+
+// without returns the filter without the conditions on the given field, and the value of the
+// last of them. It is used for fields which are not fields of the entities, such as '_context'
+func (f filter) without(field string) (filter, string) {
+	var result filter
+	value := ""
+	for _, term := range f {
+		if term.field == field {
+			value = term.value
+			continue
+		}
+		result = append(result, term)
+	}
+	return result, value
+}
+
+// writeOpenApiPage writes a filtered page of OpenAPI entities. The function 'fields' returns the
+// values of an item which can be used in filters
+func writeOpenApiPage[T any](w http.ResponseWriter, r *http.Request, items []T, fields func(T) map[string]string) {
+	f, ok := requestFilter(w, r)
+	if !ok {
+		return
+	}
+	writeFilteredOpenApiPage(w, r, items, f, fields)
+}
+
+// writeFilteredOpenApiPage writes a page of the OpenAPI entities which match the filter f
+func writeFilteredOpenApiPage[T any](w http.ResponseWriter, r *http.Request, items []T, f filter, fields func(T) map[string]string) {
+	page, pageSize, err := pageParams(parseRawQuery(r.URL.RawQuery))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+		return
+	}
+
+	matching := []T{}
+	for _, item := range items {
+		matches, err := f.matches(fields(item))
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+			return
+		}
+		if matches {
+			matching = append(matching, item)
+		}
+	}
+
+	values := pageOf(matching, page, pageSize)
+	if values == nil {
+		values = []T{}
+	}
+	rawValues, err := json.Marshal(values)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "error marshalling values: %s", err)
+		return
+	}
+	writeJson(w, http.StatusOK, &types.OpenApiPages{
+		ResultTotal: len(matching),
+		PageCount:   (len(matching) + pageSize - 1) / pageSize,
+		Page:        page,
+		PageSize:    pageSize,
+		Values:      rawValues,
+	})
+}
@@ -0,0 +1,630 @@
+package govcdtest
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// Statuses of vApps and VMs (see types.VAppStatuses)
+const (
+	statusResolved   = 1
+	statusSuspended  = 3
+	statusPoweredOn  = 4
+	statusPoweredOff = 8
+	statusMixed      = 10
+)
+
+// powerState is the power state shared by vApps and VMs
+type powerState struct {
+	status   int
+	deployed bool
+}
+
+// applyPowerAction changes the power state with one of the actions of '/power/action/{action}'
+// or '/action/{action}' endpoints
+func (state *powerState) applyPowerAction(action string, powerOn bool) error {
+	isRunning := state.status == statusPoweredOn || state.status == statusSuspended || state.status == statusMixed
+	switch action {
+	case "powerOn":
+		state.status, state.deployed = statusPoweredOn, true
+	case "deploy":
+		state.deployed = true
+		if powerOn {
+			state.status = statusPoweredOn
+		} else if state.status == statusResolved {
+			state.status = statusPoweredOff
+		}
+	case "powerOff", "shutdown":
+		if !isRunning {
+			return fmt.Errorf("the requested operation could not be executed since the entity is not running")
+		}
+		state.status = statusPoweredOff
+	case "reboot", "reset":
+		if state.status != statusPoweredOn && state.status != statusMixed {
+			return fmt.Errorf("the requested operation could not be executed since the entity is not powered on")
+		}
+	case "suspend":
+		if state.status != statusPoweredOn && state.status != statusMixed {
+			return fmt.Errorf("the requested operation could not be executed since the entity is not powered on")
+		}
+		state.status = statusSuspended
+	case "undeploy":
+		if !state.deployed {
+			return fmt.Errorf("the requested operation could not be executed since the entity is not deployed")
+		}
+		state.status, state.deployed = statusPoweredOff, false
+	default:
+		return fmt.Errorf("action '%s' is not supported by govcdtest", action)
+	}
+	return nil
+}
+
+// vApp is a vApp with its VMs
+type vApp struct {
+	powerState
+	id          string
+	name        string
+	description string
+	owner       string
+	created     time.Time
+	vdc         *vdc
+	vms         []*vm
+	networks    []*vAppNetwork
+}
+
+func (a *vApp) urn() string {
+	return "urn:vcloud:vapp:" + a.id
+}
+
+// updateStatus computes the power state of the vApp from the state of its VMs
+func (a *vApp) updateStatus() {
+	if len(a.vms) == 0 {
+		return
+	}
+	a.status = a.vms[0].status
+	a.deployed = false
+	for _, m := range a.vms {
+		if m.status != a.status {
+			a.status = statusMixed
+		}
+		a.deployed = a.deployed || m.deployed
+	}
+}
+
+// vm is a VM of a vApp
+type vm struct {
+	powerState
+	id          string
+	name        string
+	description string
+	created     time.Time
+	vApp        *vApp
+	spec        *types.VmSpecSection
+	nics        *types.NetworkConnectionSection
+	// storageProfile is the storage profile requested when the VM was copied or reconfigured, if
+	// any
+	storageProfile *types.Reference
+	datastore      *datastore
+}
+
+func (m *vm) urn() string {
+	return "urn:vcloud:vm:" + m.id
+}
+
+func compareVApps(a, b *vApp) int {
+	return cmp.Or(compareVdcs(a.vdc, b.vdc), cmp.Compare(a.name, b.name))
+}
+
+func (server *Server) vAppHref(a *vApp) string {
+	return server.URL + "/api/vApp/vapp-" + a.id
+}
+
+func (server *Server) vmHref(m *vm) string {
+	return server.URL + "/api/vApp/vm-" + m.id
+}
+
+// vdcVApps returns vApps of the VDC sorted by name
+func (server *Server) vdcVApps(v *vdc) []*vApp {
+	var vApps []*vApp
+	for _, a := range sortedValues(server.vApps, compareVApps) {
+		if a.vdc == v {
+			vApps = append(vApps, a)
+		}
+	}
+	return vApps
+}
+
+// vAppOrVm returns the vApp or the VM identified by a path segment like 'vapp-<UUID>' or
+// 'vm-<UUID>'. Both are nil if the entity does not exist or is not visible in the session
+func (server *Server) vAppOrVm(s *session, id string) (*vApp, *vm) {
+	if vAppId, found := strings.CutPrefix(id, "vapp-"); found {
+		if a := server.vApps[vAppId]; a != nil && s.canAccess(a.vdc.org) {
+			return a, nil
+		}
+	}
+	if vmId, found := strings.CutPrefix(id, "vm-"); found {
+		if m := server.vms[vmId]; m != nil && s.canAccess(m.vApp.vdc.org) {
+			return nil, m
+		}
+	}
+	return nil, nil
+}
+
+func (server *Server) vAppReference(a *vApp) *types.Reference {
+	return &types.Reference{HREF: server.vAppHref(a), ID: a.urn(), Type: types.MimeVApp, Name: a.name}
+}
+
+func (server *Server) vmReference(m *vm) *types.Reference {
+	return &types.Reference{HREF: server.vmHref(m), ID: m.urn(), Type: types.MimeVM, Name: m.name}
+}
+
+// vAppXml returns the XML representation of the vApp
+func (server *Server) vAppXml(a *vApp) *types.VApp {
+	href := server.vAppHref(a)
+	result := &types.VApp{
+		HREF:        href,
+		Type:        types.MimeVApp,
+		ID:          a.urn(),
+		Name:        a.name,
+		Status:      a.status,
+		Deployed:    a.deployed,
+		Description: a.description,
+		DateCreated: a.created.Format(time.RFC3339),
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeVDC, HREF: server.vdcHref(a.vdc)},
+			{Rel: "recompose", Type: types.MimeRecomposeVappParams, HREF: href + "/action/recomposeVApp"},
+			{Rel: "power:powerOn", HREF: href + "/power/action/powerOn"},
+			{Rel: "deploy", Type: types.MimeDeployVappParams, HREF: href + "/action/deploy"},
+		},
+		NetworkConfigSection: server.networkConfigSection(a),
+	}
+	if len(a.vms) > 0 {
+		result.Children = &types.VAppChildren{}
+		for _, m := range a.vms {
+			result.Children.VM = append(result.Children.VM, server.vmXml(m))
+		}
+	}
+	return result
+}
+
+// vmXml returns the XML representation of the VM
+func (server *Server) vmXml(m *vm) *types.Vm {
+	return &types.Vm{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		HREF:        server.vmHref(m),
+		Type:        types.MimeVM,
+		ID:          m.urn(),
+		Name:        m.name,
+		Status:      m.status,
+		Deployed:    m.deployed,
+		Description: m.description,
+		DateCreated: m.created.Format(time.RFC3339),
+		VAppParent:  server.vAppReference(m.vApp),
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeVApp, HREF: server.vAppHref(m.vApp)},
+		},
+		VmSpecSection:            m.spec,
+		NetworkConnectionSection: server.networkConnectionSection(m),
+		StorageProfile:           m.storageProfile,
+	}
+}
+
+func (server *Server) composeVApp(w http.ResponseWriter, r *http.Request, s *session) {
+	v := server.vdcs[r.PathValue("id")]
+	if v == nil || !s.canAccess(v.org) {
+		writeNotFound(w, r)
+		return
+	}
+	params := &types.ComposeVAppParams{}
+	if !decodeXml(w, r, params) {
+		return
+	}
+	if params.SourcedItem != nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "sourced items are not supported by govcdtest")
+		return
+	}
+	if params.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "vApp name cannot be empty")
+		return
+	}
+	for _, existing := range server.vdcVApps(v) {
+		if existing.name == params.Name {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "vApp with name '%s' already exists in VDC '%s'", params.Name, v.name)
+			return
+		}
+	}
+
+	a := &vApp{
+		powerState:  powerState{status: statusResolved},
+		id:          newUuid(),
+		name:        params.Name,
+		description: params.Description,
+		owner:       s.user,
+		created:     time.Now(),
+		vdc:         v,
+	}
+	if params.Deploy || params.PowerOn {
+		_ = a.applyPowerAction("deploy", params.PowerOn)
+	}
+	server.vApps[a.id] = a
+
+	result := server.vAppXml(a)
+	result.Tasks = &types.TasksInProgress{
+		Task: []*types.Task{server.newTask(s, "vdcComposeVapp", "Composed vApp "+a.name, server.vAppReference(a))},
+	}
+	writeXml(w, http.StatusCreated, types.MimeVApp, result)
+}
+
+// cloneVApp copies a vApp with its VMs and networks to a VDC, and deletes the source vApp when
+// IsSourceDelete is set. The networks of InstantiationParams replace the ones of the source vApp,
+// but the NICs of the copied VMs are not changed
+func (server *Server) cloneVApp(w http.ResponseWriter, r *http.Request, s *session) {
+	v := server.vdcs[r.PathValue("id")]
+	if v == nil || !s.canAccess(v.org) {
+		writeNotFound(w, r)
+		return
+	}
+	params := &types.CloneVAppParams{}
+	if !decodeXml(w, r, params) {
+		return
+	}
+	if params.Source == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "source vApp cannot be empty")
+		return
+	}
+	source, _ := server.vAppOrVm(s, uuidPathSegment(params.Source.HREF))
+	if source == nil {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "source vApp '%s' does not exist", params.Source.HREF)
+		return
+	}
+	isSourceDelete := params.IsSourceDelete != nil && *params.IsSourceDelete
+	if isSourceDelete && source.deployed {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "vApp '%s' must be undeployed before it can be moved", source.name)
+		return
+	}
+	if params.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "vApp name cannot be empty")
+		return
+	}
+	for _, existing := range server.vdcVApps(v) {
+		if existing.name == params.Name && existing != source {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "vApp with name '%s' already exists in VDC '%s'", params.Name, v.name)
+			return
+		}
+	}
+
+	a := &vApp{
+		powerState:  powerState{status: statusPoweredOff},
+		id:          newUuid(),
+		name:        params.Name,
+		description: params.Description,
+		owner:       s.user,
+		created:     time.Now(),
+		vdc:         v,
+	}
+	if params.InstantiationParams != nil && params.InstantiationParams.NetworkConfigSection != nil {
+		networks, err := server.parseVAppNetworks(params.InstantiationParams.NetworkConfigSection, a, v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "%s", err)
+			return
+		}
+		a.networks = networks
+	} else {
+		for _, n := range source.networks {
+			a.networks = append(a.networks, &vAppNetwork{id: newUuid(), name: n.name, fenceMode: n.fenceMode, parent: n.parent, vApp: a})
+		}
+	}
+	storageProfiles := make(map[string]*types.Reference)
+	for _, vmParams := range params.SourcedVmInstantiationParams {
+		_, m := server.vAppOrVm(s, uuidPathSegment(vmParams.Source.HREF))
+		if m == nil || m.vApp != source {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s' is not a part of vApp '%s'", vmParams.Source.HREF, source.name)
+			return
+		}
+		storageProfiles[m.id] = vmParams.StorageProfile
+	}
+
+	for _, m := range source.vms {
+		copied := &vm{
+			powerState:     powerState{status: statusPoweredOff},
+			id:             newUuid(),
+			name:           m.name,
+			description:    m.description,
+			created:        time.Now(),
+			vApp:           a,
+			spec:           m.spec,
+			nics:           m.nics,
+			storageProfile: cmp.Or(storageProfiles[m.id], m.storageProfile),
+		}
+		server.vms[copied.id] = copied
+		a.vms = append(a.vms, copied)
+	}
+	if params.Deploy || params.PowerOn {
+		_ = a.applyPowerAction("deploy", params.PowerOn)
+		for _, m := range a.vms {
+			_ = m.applyPowerAction("deploy", params.PowerOn)
+		}
+	}
+	if isSourceDelete {
+		for _, m := range source.vms {
+			delete(server.vms, m.id)
+		}
+		delete(server.vApps, source.id)
+	}
+	server.vApps[a.id] = a
+
+	result := server.vAppXml(a)
+	result.Tasks = &types.TasksInProgress{
+		Task: []*types.Task{server.newTask(s, "vdcCopyVapp", "Copied vApp "+a.name, server.vAppReference(a))},
+	}
+	writeXml(w, http.StatusCreated, types.MimeVApp, result)
+}
+
+func (server *Server) getVAppOrVm(w http.ResponseWriter, r *http.Request, s *session) {
+	a, m := server.vAppOrVm(s, r.PathValue("id"))
+	switch {
+	case a != nil:
+		writeXml(w, http.StatusOK, types.MimeVApp, server.vAppXml(a))
+	case m != nil:
+		writeXml(w, http.StatusOK, types.MimeVM, server.vmXml(m))
+	default:
+		writeNotFound(w, r)
+	}
+}
+
+func (server *Server) deleteVAppOrVm(w http.ResponseWriter, r *http.Request, s *session) {
+	a, m := server.vAppOrVm(s, r.PathValue("id"))
+	switch {
+	case a != nil:
+		if a.deployed {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "vApp '%s' must be undeployed before it can be deleted", a.name)
+			return
+		}
+		for _, child := range a.vms {
+			delete(server.vms, child.id)
+		}
+		delete(server.vApps, a.id)
+		writeTask(w, server.newTask(s, "vdcDeleteVapp", "Deleted vApp "+a.name, server.vAppReference(a)))
+	case m != nil:
+		if m.deployed {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s' must be undeployed before it can be deleted", m.name)
+			return
+		}
+		server.removeVm(m)
+		writeTask(w, server.newTask(s, "vdcDeleteVm", "Deleted VM "+m.name, server.vmReference(m)))
+	default:
+		writeNotFound(w, r)
+	}
+}
+
+// removeVm removes the VM from its vApp
+func (server *Server) removeVm(m *vm) {
+	delete(server.vms, m.id)
+	m.vApp.vms = slices.DeleteFunc(m.vApp.vms, func(child *vm) bool { return child == m })
+	m.vApp.updateStatus()
+}
+
+func (server *Server) powerAction(w http.ResponseWriter, r *http.Request, s *session) {
+	server.changePowerState(w, r, s, r.PathValue("action"), false)
+}
+
+func (server *Server) action(w http.ResponseWriter, r *http.Request, s *session) {
+	action := r.PathValue("action")
+	switch action {
+	case "deploy":
+		params := &types.DeployVAppParams{}
+		if !decodeXml(w, r, params) {
+			return
+		}
+		server.changePowerState(w, r, s, action, params.PowerOn)
+	case "undeploy":
+		server.changePowerState(w, r, s, action, false)
+	case "recomposeVApp":
+		server.recomposeVApp(w, r, s)
+	case "reconfigureVm":
+		server.reconfigureVm(w, r, s)
+	case "relocate":
+		server.relocateVm(w, r, s)
+	default:
+		writeError(w, r, http.StatusNotFound, "NOT_FOUND", "action '%s' is not supported by govcdtest", action)
+	}
+}
+
+// changePowerState applies a power action to a vApp and all its VMs or to a single VM
+func (server *Server) changePowerState(w http.ResponseWriter, r *http.Request, s *session, action string, powerOn bool) {
+	a, m := server.vAppOrVm(s, r.PathValue("id"))
+	switch {
+	case a != nil:
+		if err := a.applyPowerAction(action, powerOn); err != nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "vApp '%s': %s", a.name, err)
+			return
+		}
+		// VMs which are already in the requested state are skipped like in VCD
+		for _, child := range a.vms {
+			_ = child.applyPowerAction(action, powerOn)
+		}
+		a.updateStatus()
+		writeTask(w, server.newTask(s, "vapp"+upperFirst(action), action+" vApp "+a.name, server.vAppReference(a)))
+	case m != nil:
+		if err := m.applyPowerAction(action, powerOn); err != nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s': %s", m.name, err)
+			return
+		}
+		m.vApp.updateStatus()
+		writeTask(w, server.newTask(s, "vapp"+upperFirst(action), action+" VM "+m.name, server.vmReference(m)))
+	default:
+		writeNotFound(w, r)
+	}
+}
+
+// recomposeVAppParams contains the parts of RecomposeVAppParams supported by govcdtest. It
+// accepts both types.ReComposeVAppParams and types.RecomposeVAppParamsForEmptyVm
+type recomposeVAppParams struct {
+	XMLName     xml.Name                             `xml:"RecomposeVAppParams"`
+	Name        string                               `xml:"name,attr"`
+	PowerOn     bool                                 `xml:"powerOn,attr"`
+	Description *string                              `xml:"Description"`
+	SourcedItem []*types.SourcedCompositionItemParam `xml:"SourcedItem"`
+	CreateItem  []*types.CreateItem                  `xml:"CreateItem"`
+	DeleteItem  []*types.DeleteItem                  `xml:"DeleteItem"`
+}
+
+func (server *Server) recomposeVApp(w http.ResponseWriter, r *http.Request, s *session) {
+	a, _ := server.vAppOrVm(s, r.PathValue("id"))
+	if a == nil {
+		writeNotFound(w, r)
+		return
+	}
+	params := &recomposeVAppParams{}
+	if !decodeXml(w, r, params) {
+		return
+	}
+	if len(params.SourcedItem) > 0 {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "sourced items are not supported by govcdtest")
+		return
+	}
+
+	// All changes are validated first, so that a failed request does not change anything
+	if params.Name != "" && params.Name != a.name {
+		for _, existing := range server.vdcVApps(a.vdc) {
+			if existing.name == params.Name {
+				writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "vApp with name '%s' already exists in VDC '%s'", params.Name, a.vdc.name)
+				return
+			}
+		}
+	}
+	var deleted []*vm
+	for _, item := range params.DeleteItem {
+		_, m := server.vAppOrVm(s, uuidPathSegment(item.HREF))
+		if m == nil || m.vApp != a {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s' is not a part of vApp '%s'", item.HREF, a.name)
+			return
+		}
+		if m.deployed {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s' must be undeployed before it can be deleted", m.name)
+			return
+		}
+		deleted = append(deleted, m)
+	}
+	names := make(map[string]bool)
+	for _, m := range a.vms {
+		names[m.name] = !slices.Contains(deleted, m)
+	}
+	for _, item := range params.CreateItem {
+		if item.Name == "" || item.VmSpecSection == nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM name and VmSpecSection are required")
+			return
+		}
+		if names[item.Name] {
+			writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "VM with name '%s' already exists in vApp '%s'", item.Name, a.name)
+			return
+		}
+		if item.NetworkConnectionSection != nil {
+			if err := a.validateNics(item.NetworkConnectionSection); err != nil {
+				writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM '%s': %s", item.Name, err)
+				return
+			}
+		}
+		names[item.Name] = true
+	}
+
+	if params.Name != "" {
+		a.name = params.Name
+	}
+	if params.Description != nil {
+		a.description = *params.Description
+	}
+	for _, m := range deleted {
+		server.removeVm(m)
+	}
+	for _, item := range params.CreateItem {
+		m := &vm{
+			powerState:  powerState{status: statusPoweredOff},
+			id:          newUuid(),
+			name:        item.Name,
+			description: item.Description,
+			created:     time.Now(),
+			vApp:        a,
+			spec:        item.VmSpecSection,
+			nics:        item.NetworkConnectionSection,
+		}
+		if params.PowerOn {
+			_ = m.applyPowerAction("powerOn", false)
+		}
+		server.vms[m.id] = m
+		a.vms = append(a.vms, m)
+	}
+	a.updateStatus()
+
+	writeTask(w, server.newTask(s, "vappUpdateVm", "Updated vApp "+a.name, server.vAppReference(a)))
+}
+
+// reconfigureVm changes the name, the description, the storage profile and the VmSpecSection of a
+// VM. Internal disks are kept when the request has no DiskSection
+func (server *Server) reconfigureVm(w http.ResponseWriter, r *http.Request, s *session) {
+	_, m := server.vAppOrVm(s, r.PathValue("id"))
+	if m == nil {
+		writeNotFound(w, r)
+		return
+	}
+	params := &types.Vm{}
+	if !decodeXml(w, r, params) {
+		return
+	}
+	if params.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "VM name cannot be empty")
+		return
+	}
+	if params.Name != m.name && slices.ContainsFunc(m.vApp.vms, func(child *vm) bool { return child.name == params.Name }) {
+		writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "VM with name '%s' already exists in vApp '%s'", params.Name, m.vApp.name)
+		return
+	}
+	var profile *types.Reference
+	if params.StorageProfile != nil {
+		index := slices.IndexFunc(m.vApp.vdc.storageProfiles, func(p *storageProfile) bool {
+			return p.id == uuidFromId(params.StorageProfile.HREF)
+		})
+		if index < 0 {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "storage profile '%s' does not exist in VDC '%s'", params.StorageProfile.HREF, m.vApp.vdc.name)
+			return
+		}
+		p := m.vApp.vdc.storageProfiles[index]
+		profile = &types.Reference{HREF: server.storageProfileHref(p), ID: p.urn(), Type: types.MimeStorageProfile, Name: p.name}
+	}
+
+	m.name = params.Name
+	if profile != nil {
+		m.storageProfile = profile
+	}
+	m.description = params.Description
+	if params.VmSpecSection != nil {
+		spec := *params.VmSpecSection
+		spec.Modified = nil
+		if spec.DiskSection == nil && m.spec != nil {
+			spec.DiskSection = m.spec.DiskSection
+		}
+		m.spec = &spec
+	}
+	writeTask(w, server.newTask(s, "vappUpdateVm", "Updated VM "+m.name, server.vmReference(m)))
+}
+
+// uuidPathSegment returns the last segment of an HREF (e.g. 'vm-<UUID>')
+func uuidPathSegment(href string) string {
+	return href[strings.LastIndex(href, "/")+1:]
+}
+
+// upperFirst returns the text with the first letter in upper case
+func upperFirst(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
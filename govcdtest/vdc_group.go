@@ -0,0 +1,121 @@
+package govcdtest
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// vdcGroup is an NSX-T VDC Group of an organization
+type vdcGroup struct {
+	id   string
+	name string
+	org  *org
+	vdcs []*vdc
+
+	// dfwRules are the rules of the default Distributed Firewall policy, in their order of
+	// evaluation
+	dfwRules []firewallRule
+}
+
+func (g *vdcGroup) urn() string {
+	return "urn:vcloud:vdcGroup:" + g.id
+}
+
+func compareVdcGroups(a, b *vdcGroup) int {
+	return cmp.Or(compareOrgs(a.org, b.org), cmp.Compare(a.name, b.name))
+}
+
+// AddVdcGroup adds an NSX-T VDC Group with the given VDCs of the organization and returns its ID
+func (server *Server) AddVdcGroup(orgName, groupName string, vdcNames ...string) (string, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	o := server.orgByName(orgName)
+	if o == nil {
+		return "", fmt.Errorf("org '%s' does not exist", orgName)
+	}
+	if groupName == "" {
+		return "", fmt.Errorf("VDC Group name cannot be empty")
+	}
+	if len(vdcNames) == 0 {
+		return "", fmt.Errorf("VDC Group must have at least one VDC")
+	}
+	for _, g := range server.vdcGroups {
+		if g.org == o && g.name == groupName {
+			return "", fmt.Errorf("VDC Group '%s' already exists in org '%s'", groupName, orgName)
+		}
+	}
+	g := &vdcGroup{id: newUuid(), name: groupName, org: o}
+	for _, vdcName := range vdcNames {
+		v, err := server.vdcByName(orgName, vdcName)
+		if err != nil {
+			return "", err
+		}
+		g.vdcs = append(g.vdcs, v)
+	}
+	server.vdcGroups[g.id] = g
+	return g.urn(), nil
+}
+
+// visibleVdcGroup returns the VDC Group with the given ID or nil if it does not exist or is not
+// visible in the session
+func (server *Server) visibleVdcGroup(s *session, id string) *vdcGroup {
+	g := server.vdcGroups[uuidFromId(id)]
+	if g == nil || !s.canAccess(g.org) {
+		return nil
+	}
+	return g
+}
+
+func (server *Server) vdcGroupReference(g *vdcGroup) *types.Reference {
+	return &types.Reference{
+		HREF: server.URL + "/cloudapi/1.0.0/vdcGroups/" + g.urn(),
+		ID:   g.urn(),
+		Type: types.JSONMime,
+		Name: g.name,
+	}
+}
+
+func (server *Server) openApiVdcGroup(g *vdcGroup) *types.VdcGroup {
+	result := &types.VdcGroup{
+		Id:                  g.urn(),
+		Name:                g.name,
+		OrgId:               g.org.urn(),
+		DfwEnabled:          true,
+		NetworkProviderType: "NSX_T",
+		Status:              "REALIZED",
+		Type:                "LOCAL",
+	}
+	for _, v := range g.vdcs {
+		result.ParticipatingOrgVdcs = append(result.ParticipatingOrgVdcs, types.ParticipatingOrgVdcs{
+			OrgRef: types.OpenApiReference{ID: v.org.urn(), Name: v.org.name},
+			VdcRef: types.OpenApiReference{ID: v.urn(), Name: v.name},
+			Status: "REALIZED",
+		})
+	}
+	return result
+}
+
+func (server *Server) getVdcGroups(w http.ResponseWriter, r *http.Request, s *session) {
+	var groups []*types.VdcGroup
+	for _, g := range sortedValues(server.vdcGroups, compareVdcGroups) {
+		if s.canAccess(g.org) {
+			groups = append(groups, server.openApiVdcGroup(g))
+		}
+	}
+	writeOpenApiPage(w, r, groups, func(g *types.VdcGroup) map[string]string {
+		return map[string]string{"id": g.Id, "name": g.Name, "orgId": g.OrgId}
+	})
+}
+
+func (server *Server) getVdcGroup(w http.ResponseWriter, r *http.Request, s *session) {
+	g := server.visibleVdcGroup(s, r.PathValue("id"))
+	if g == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeJson(w, http.StatusOK, server.openApiVdcGroup(g))
+}
@@ -0,0 +1,339 @@
+package govcdtest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// ipSecVpnTunnel is an IPsec VPN tunnel of an Edge Gateway with its security profile, which is nil
+// when the tunnel uses the default one
+type ipSecVpnTunnel struct {
+	tunnel          *types.NsxtIpSecVpnTunnel
+	securityProfile *types.NsxtIpSecVpnTunnelSecurityProfile
+}
+
+// setIpSecVpnTunnelVersion sets the version of the IPsec VPN tunnel, which is 0 when it is created
+// and is incremented at every update
+func setIpSecVpnTunnelVersion(tunnel *types.NsxtIpSecVpnTunnel, version int) {
+	tunnel.Version = &struct {
+		Version *int `json:"version,omitempty"`
+	}{Version: &version}
+}
+
+func ipSecVpnTunnelIndex(tunnels []*ipSecVpnTunnel, id string) int {
+	return slices.IndexFunc(tunnels, func(t *ipSecVpnTunnel) bool { return t.tunnel.ID == id })
+}
+
+func l2VpnTunnelIndex(tunnels []*types.NsxtL2VpnTunnel, id string) int {
+	return slices.IndexFunc(tunnels, func(tunnel *types.NsxtL2VpnTunnel) bool { return tunnel.ID == id })
+}
+
+// validateIpSecVpnTunnel checks the IPsec VPN tunnel sent by the client. It writes an error and
+// returns false if the tunnel is not valid
+func validateIpSecVpnTunnel(w http.ResponseWriter, r *http.Request, egw *edgeGateway, tunnel *types.NsxtIpSecVpnTunnel) bool {
+	if tunnel.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "IPsec VPN tunnel name cannot be empty")
+		return false
+	}
+	if tunnel.LocalEndpoint.LocalAddress == "" || tunnel.RemoteEndpoint.RemoteAddress == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "IPsec VPN tunnel '%s' must have local and remote addresses", tunnel.Name)
+		return false
+	}
+	if tunnel.AuthenticationMode == "" {
+		tunnel.AuthenticationMode = "PSK"
+	}
+	if tunnel.AuthenticationMode == "PSK" && tunnel.PreSharedKey == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "IPsec VPN tunnel '%s' must have a pre-shared key", tunnel.Name)
+		return false
+	}
+	if slices.ContainsFunc(egw.ipSecVpnTunnels, func(existing *ipSecVpnTunnel) bool {
+		return existing.tunnel.Name == tunnel.Name && existing.tunnel.ID != tunnel.ID
+	}) {
+		writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "IPsec VPN tunnel with name '%s' already exists in Edge Gateway '%s'",
+			tunnel.Name, egw.gateway.Name)
+		return false
+	}
+	return true
+}
+
+// requestIpSecVpnTunnel returns the Edge Gateway and the IPsec VPN tunnel of the request path. It
+// writes an error and returns nil if either of them does not exist or is not visible
+func (server *Server) requestIpSecVpnTunnel(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, *ipSecVpnTunnel) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return nil, nil
+	}
+	index := ipSecVpnTunnelIndex(egw.ipSecVpnTunnels, r.PathValue("tunnelId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, nil
+	}
+	return egw, egw.ipSecVpnTunnels[index]
+}
+
+// getIpSecVpnTunnels lists the IPsec VPN tunnels of the Edge Gateway. Like VCD, it does not
+// support filters and leaves out the pre-shared keys, which are only returned by the retrieval of
+// a single tunnel
+func (server *Server) getIpSecVpnTunnels(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	var tunnels []*types.NsxtIpSecVpnTunnel
+	for _, t := range egw.ipSecVpnTunnels {
+		tunnel := *t.tunnel
+		tunnel.PreSharedKey = ""
+		tunnels = append(tunnels, &tunnel)
+	}
+	writeOpenApiPage(w, r, tunnels, func(tunnel *types.NsxtIpSecVpnTunnel) map[string]string {
+		return map[string]string{}
+	})
+}
+
+func (server *Server) getIpSecVpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, t := server.requestIpSecVpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, t.tunnel)
+}
+
+// createIpSecVpnTunnel adds an IPsec VPN tunnel with the default security profile to the Edge
+// Gateway. Like VCD, the task is owned by the Edge Gateway, therefore clients find the new tunnel by
+// listing the tunnels
+func (server *Server) createIpSecVpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	tunnel := &types.NsxtIpSecVpnTunnel{}
+	if !decodeJson(w, r, tunnel) {
+		return
+	}
+	tunnel.ID = newUuid()
+	tunnel.SecurityType = "DEFAULT"
+	setIpSecVpnTunnelVersion(tunnel, 0)
+	if !validateIpSecVpnTunnel(w, r, egw, tunnel) {
+		return
+	}
+	egw.ipSecVpnTunnels = append(egw.ipSecVpnTunnels, &ipSecVpnTunnel{tunnel: tunnel})
+	writeOpenApiTask(w, server.newTask(s, "createIpSecVpnTunnel", "Created IPsec VPN tunnel "+tunnel.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+// updateIpSecVpnTunnel replaces an IPsec VPN tunnel of the Edge Gateway. Its security type can
+// only be changed through its connection properties
+func (server *Server) updateIpSecVpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, t := server.requestIpSecVpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	tunnel := &types.NsxtIpSecVpnTunnel{}
+	if !decodeJson(w, r, tunnel) {
+		return
+	}
+	if tunnel.ID != t.tunnel.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "IPsec VPN tunnel ID '%s' does not match '%s'", tunnel.ID, t.tunnel.ID)
+		return
+	}
+	tunnel.SecurityType = t.tunnel.SecurityType
+	setIpSecVpnTunnelVersion(tunnel, *t.tunnel.Version.Version+1)
+	if !validateIpSecVpnTunnel(w, r, egw, tunnel) {
+		return
+	}
+	t.tunnel = tunnel
+	writeOpenApiTask(w, server.newTask(s, "updateIpSecVpnTunnel", "Updated IPsec VPN tunnel "+tunnel.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) deleteIpSecVpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, t := server.requestIpSecVpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	index := ipSecVpnTunnelIndex(egw.ipSecVpnTunnels, t.tunnel.ID)
+	egw.ipSecVpnTunnels = slices.Delete(egw.ipSecVpnTunnels, index, index+1)
+	writeOpenApiTask(w, server.newTask(s, "deleteIpSecVpnTunnel", "Deleted IPsec VPN tunnel "+t.tunnel.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) getIpSecVpnTunnelConnectionProperties(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, t := server.requestIpSecVpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	if t.securityProfile == nil {
+		writeJson(w, http.StatusOK, &types.NsxtIpSecVpnTunnelSecurityProfile{SecurityType: "DEFAULT"})
+		return
+	}
+	writeJson(w, http.StatusOK, t.securityProfile)
+}
+
+// updateIpSecVpnTunnelConnectionProperties sets the security profile of an IPsec VPN tunnel. Like
+// VCD, a profile with security type DEFAULT restores the default profile, and any other profile
+// makes the security type of the tunnel CUSTOM
+func (server *Server) updateIpSecVpnTunnelConnectionProperties(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, t := server.requestIpSecVpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	profile := &types.NsxtIpSecVpnTunnelSecurityProfile{}
+	if !decodeJson(w, r, profile) {
+		return
+	}
+	if profile.SecurityType == "DEFAULT" {
+		t.securityProfile = nil
+	} else {
+		profile.SecurityType = "CUSTOM"
+		t.securityProfile = profile
+	}
+	t.tunnel.SecurityType = profile.SecurityType
+	writeOpenApiTask(w, server.newTask(s, "updateIpSecVpnTunnelConnectionProperties",
+		"Updated connection properties of IPsec VPN tunnel "+t.tunnel.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+// validateL2VpnTunnel checks the L2 VPN tunnel sent by the client and sets the names of its
+// stretched networks. It writes an error and returns false if the tunnel is not valid
+func (server *Server) validateL2VpnTunnel(w http.ResponseWriter, r *http.Request, egw *edgeGateway, tunnel *types.NsxtL2VpnTunnel) bool {
+	if tunnel.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "L2 VPN tunnel name cannot be empty")
+		return false
+	}
+	if tunnel.SessionMode != "SERVER" && tunnel.SessionMode != "CLIENT" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid session mode '%s' of L2 VPN tunnel '%s'", tunnel.SessionMode, tunnel.Name)
+		return false
+	}
+	if tunnel.LocalEndpointIp == "" || tunnel.RemoteEndpointIp == "" {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "L2 VPN tunnel '%s' must have local and remote endpoint IPs", tunnel.Name)
+		return false
+	}
+	for i, stretched := range tunnel.StretchedNetworks {
+		network := server.egwNetwork(egw, stretched.NetworkRef.ID)
+		if network == nil {
+			writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "network '%s' of L2 VPN tunnel '%s' is not available to Edge Gateway '%s'",
+				stretched.NetworkRef.ID, tunnel.Name, egw.gateway.Name)
+			return false
+		}
+		tunnel.StretchedNetworks[i].NetworkRef = *network
+	}
+	if slices.ContainsFunc(egw.l2VpnTunnels, func(existing *types.NsxtL2VpnTunnel) bool {
+		return existing.Name == tunnel.Name && existing.ID != tunnel.ID
+	}) {
+		writeError(w, r, http.StatusBadRequest, "DUPLICATE_NAME", "L2 VPN tunnel with name '%s' already exists in Edge Gateway '%s'",
+			tunnel.Name, egw.gateway.Name)
+		return false
+	}
+	return true
+}
+
+// requestL2VpnTunnel returns the Edge Gateway and the index of the L2 VPN tunnel of the request
+// path. It writes an error and returns nil if either of them does not exist or is not visible
+func (server *Server) requestL2VpnTunnel(w http.ResponseWriter, r *http.Request, s *session) (*edgeGateway, int) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	index := l2VpnTunnelIndex(egw.l2VpnTunnels, r.PathValue("tunnelId"))
+	if index < 0 {
+		writeNotFound(w, r)
+		return nil, 0
+	}
+	return egw, index
+}
+
+// getL2VpnTunnels lists the L2 VPN tunnels of the Edge Gateway. Like VCD, it does not support
+// filters
+func (server *Server) getL2VpnTunnels(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	writeOpenApiPage(w, r, egw.l2VpnTunnels, func(tunnel *types.NsxtL2VpnTunnel) map[string]string {
+		return map[string]string{}
+	})
+}
+
+func (server *Server) getL2VpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestL2VpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	writeJson(w, http.StatusOK, egw.l2VpnTunnels[index])
+}
+
+// createL2VpnTunnel adds an L2 VPN tunnel to the Edge Gateway. Like VCD, the task is owned by the
+// Edge Gateway and its details contain the ID of the new tunnel
+func (server *Server) createL2VpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw := server.visibleEdgeGateway(s, r.PathValue("id"))
+	if egw == nil {
+		writeNotFound(w, r)
+		return
+	}
+	tunnel := &types.NsxtL2VpnTunnel{}
+	if !decodeJson(w, r, tunnel) {
+		return
+	}
+	tunnel.ID = newUuid()
+	tunnel.Version = types.VersionField{}
+	if !server.validateL2VpnTunnel(w, r, egw, tunnel) {
+		return
+	}
+	egw.l2VpnTunnels = append(egw.l2VpnTunnels, tunnel)
+	task := server.newTask(s, "createL2VpnTunnel", "Created L2 VPN tunnel "+tunnel.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway))
+	task.Details = tunnel.ID
+	writeOpenApiTask(w, task)
+}
+
+// updateL2VpnTunnel replaces an L2 VPN tunnel of the Edge Gateway. Like VCD, the update must send
+// the current version of the tunnel, and the session mode cannot be changed
+func (server *Server) updateL2VpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestL2VpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	existing := egw.l2VpnTunnels[index]
+	tunnel := &types.NsxtL2VpnTunnel{}
+	if !decodeJson(w, r, tunnel) {
+		return
+	}
+	if tunnel.ID != existing.ID {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "L2 VPN tunnel ID '%s' does not match '%s'", tunnel.ID, existing.ID)
+		return
+	}
+	if tunnel.Version.Version != existing.Version.Version {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "version %d of L2 VPN tunnel '%s' does not match %d",
+			tunnel.Version.Version, existing.Name, existing.Version.Version)
+		return
+	}
+	if tunnel.SessionMode != existing.SessionMode {
+		writeError(w, r, http.StatusBadRequest, "BAD_REQUEST", "session mode of L2 VPN tunnel '%s' cannot be changed", existing.Name)
+		return
+	}
+	if !server.validateL2VpnTunnel(w, r, egw, tunnel) {
+		return
+	}
+	tunnel.Version.Version++
+	egw.l2VpnTunnels[index] = tunnel
+	writeOpenApiTask(w, server.newTask(s, "updateL2VpnTunnel", "Updated L2 VPN tunnel "+tunnel.Name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
+
+func (server *Server) deleteL2VpnTunnel(w http.ResponseWriter, r *http.Request, s *session) {
+	egw, index := server.requestL2VpnTunnel(w, r, s)
+	if egw == nil {
+		return
+	}
+	name := egw.l2VpnTunnels[index].Name
+	egw.l2VpnTunnels = slices.Delete(egw.l2VpnTunnels, index, index+1)
+	writeOpenApiTask(w, server.newTask(s, "deleteL2VpnTunnel", "Deleted L2 VPN tunnel "+name+" of Edge Gateway "+egw.gateway.Name,
+		server.edgeGatewayReference(egw.gateway)))
+}
@@ -168,6 +168,16 @@ const (
 	// Mime to instantiate VDC Templates
 	MimeVdcTemplateInstantiate     = "application/vnd.vmware.vcloud.instantiateVdcTemplateParams+xml"
 	MimeVdcTemplateInstantiateType = "application/vnd.vmware.vcloud.orgVdcTemplate+xml"
+	// Mime to create a snapshot of a vApp or VM
+	MimeCreateSnapshotParams = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+	// Mime to retrieve the snapshot section of a vApp or VM
+	MimeSnapshotSection = "application/vnd.vmware.vcloud.snapshotSection+xml"
+	// Mime to retrieve an MKS ticket for the remote console of a VM
+	MimeMksTicket = "application/vnd.vmware.vcloud.mksTicket+xml"
+	// Mime to retrieve a screen ticket for the remote console of a VM
+	MimeScreenTicket = "application/vnd.vmware.vcloud.screenTicket+xml"
+	// Mime to relocate a VM to another datastore
+	MimeRelocateParams = "application/vnd.vmware.vcloud.relocateVmParams+xml"
 )
 
 const (
@@ -927,3 +927,36 @@ type OpenApiUser struct {
 	// original identity source being removed
 	Stranded bool `json:"stranded,omitempty"`
 }
+
+// AuditTrailEvent represents a single event in VCD audit trail (OpenApiEndpointAuditTrail)
+type AuditTrailEvent struct {
+	// EventID is the URN of the event (e.g. urn:vcloud:audit:a1b0cc89-...)
+	EventID string `json:"eventId"`
+	// Description of the event
+	Description string `json:"description,omitempty"`
+	// OperatingOrg is the organization in which the event happened
+	OperatingOrg *OpenApiReference `json:"operatingOrg,omitempty"`
+	// User is the actor that triggered the event
+	User *OpenApiReference `json:"user,omitempty"`
+	// EventEntity is the target entity of the event
+	EventEntity *OpenApiReference `json:"eventEntity,omitempty"`
+	// TaskID is the ID of the task associated with the event (if any)
+	TaskID string `json:"taskId,omitempty"`
+	// TaskCellID is the ID of the cell that handled the task
+	TaskCellID string `json:"taskCellId,omitempty"`
+	// CellID is the ID of the cell that produced the event
+	CellID string `json:"cellId,omitempty"`
+	// EventType is the type of the event (e.g. com/vmware/cloud/event/vapp/create)
+	EventType string `json:"eventType,omitempty"`
+	// ServiceNamespace of the event (e.g. com.vmware.cloud)
+	ServiceNamespace string `json:"serviceNamespace,omitempty"`
+	// EventStatus is one of SUCCESS, FAILURE
+	EventStatus string `json:"eventStatus,omitempty"`
+	// Timestamp of the event in format 2006-01-02T15:04:05.000Z
+	Timestamp string `json:"timestamp,omitempty"`
+	// External is true for events that were not produced by VCD itself
+	External bool `json:"external,omitempty"`
+	// AdditionalProperties contain event details, such as "user.roles", "user.session.id",
+	// "currentContext.user.clientIpAddress"
+	AdditionalProperties map[string]string `json:"additionalProperties,omitempty"`
+}
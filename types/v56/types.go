@@ -2004,6 +2004,20 @@ type SnapshotItem struct {
 	Size      int    `xml:"size,attr,omitempty"`
 }
 
+// CreateSnapshotParams represents parameters to create a snapshot of a VM or of all VMs in a vApp
+// Type: CreateSnapshotParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for creating a snapshot of a vApp or VM
+// Since: 5.1
+type CreateSnapshotParams struct {
+	XMLName     xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns       string   `xml:"xmlns,attr,omitempty"`
+	Name        string   `xml:"name,attr,omitempty"` // Typically used to name or identify the subject of the request
+	Memory      bool     `xml:"memory,attr"`         // True if the snapshot should include the memory of a powered on VM
+	Quiesce     bool     `xml:"quiesce,attr"`        // True if the file system of a powered on VM should be quiesced before taking the snapshot (requires VMware Tools)
+	Description string   `xml:"Description,omitempty"`
+}
+
 // OVFItem is a horrible kludge to process OVF, needs to be fixed with proper types.
 type OVFItem struct {
 	XMLName         xml.Name `xml:"vcloud:Item"`
@@ -2128,6 +2142,20 @@ type CloneVAppParams struct {
 	Source              *Reference                   `xml:"Source"`                        // A reference to a source object such as a vApp or vApp template.
 	IsSourceDelete      *bool                        `xml:"IsSourceDelete"`                // Set to true to delete the source object after the operation completes.
 	SourcedItem         *SourcedCompositionItemParam `xml:"SourcedItem,omitempty"`         // Composition item. One of: vApp vAppTemplate VM.
+	// SourcedVmInstantiationParams overrides the instantiation parameters of the VMs of the source vApp
+	SourcedVmInstantiationParams []*SourcedVmInstantiationParams `xml:"SourcedVmInstantiationParams,omitempty"`
+}
+
+// SourcedVmInstantiationParams overrides the instantiation parameters of a VM of the vApp being
+// cloned
+// Type: SourcedVmInstantiationParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Since: 5.6
+type SourcedVmInstantiationParams struct {
+	Source         *Reference      `xml:"Source"`                   // A reference to the VM of the source vApp.
+	StorageProfile *Reference      `xml:"StorageProfile,omitempty"` // A reference to the storage profile used by the copy of the VM. It must exist in the target VDC.
+	LocalityParams *LocalityParams `xml:"LocalityParams,omitempty"` // Locality parameters of the copy of the VM.
+	ComputePolicy  *ComputePolicy  `xml:"ComputePolicy,omitempty"`  // The compute policy of the copy of the VM. It must exist in the target VDC.
 }
 
 // EdgeGateway represents a gateway.
@@ -2897,7 +2925,8 @@ type QueryResultVMRecordType struct {
 	MaintenanceMode          bool      `xml:"isInMaintenanceMode,attr,omitempty"`
 	AutoNature               bool      `xml:"isAutoNature,attr,omitempty"` //  	True if the parent vApp is a managed vApp
 	StorageProfileName       string    `xml:"storageProfileName,attr,omitempty"`
-	GcStatus                 string    `xml:"gcStatus,attr,omitempty"` // GC status of this VM.
+	DatastoreName            string    `xml:"datastoreName,attr,omitempty"` // Only returned to system administrators (adminVM query)
+	GcStatus                 string    `xml:"gcStatus,attr,omitempty"`      // GC status of this VM.
 	AutoUndeployDate         string    `xml:"autoUndeployDate,attr,omitempty"`
 	AutoDeleteDate           string    `xml:"autoDeleteDate,attr,omitempty"`
 	AutoUndeployNotified     bool      `xml:"isAutoUndeployNotified,attr,omitempty"`
@@ -3312,6 +3341,37 @@ type VmQuestionAnswer struct {
 	QuestionId string   `xml:"QuestionId"`
 }
 
+// MksTicket is the ticket used to open a remote console (MKS or WebMKS) of a VM
+// Reference: vCloud API 37.0 - MksTicketType
+type MksTicket struct {
+	XMLName xml.Name `xml:"MksTicket"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Type    string   `xml:"type,attr,omitempty"`
+	HREF    string   `xml:"href,attr,omitempty"`
+	Link    LinkList `xml:"Link,omitempty"`
+	Host    string   `xml:"Host"`   // Address of the console proxy the client must connect to
+	Vmx     string   `xml:"Vmx"`    // Path of the VM configuration file in the datastore
+	Ticket  string   `xml:"Ticket"` // One time ticket that authorizes the console connection
+	Port    int      `xml:"Port"`   // Port of the console proxy
+}
+
+// ScreenTicket is the ticket used to open a remote console of a VM with the legacy VMRC plugin.
+// Its value is an URL in the form mks://host/vm-moref/ticket
+// Reference: vCloud API 37.0 - ScreenTicketType
+type ScreenTicket struct {
+	XMLName xml.Name `xml:"ScreenTicket"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Value   string   `xml:",chardata"`
+}
+
+// RelocateParams moves a VM to another datastore
+// Reference: vCloud API 37.0 - RelocateParamsType
+type RelocateParams struct {
+	XMLName   xml.Name   `xml:"RelocateParams"`
+	Xmlns     string     `xml:"xmlns,attr,omitempty"`
+	Datastore *Reference `xml:"Datastore"` // Reference to the destination datastore.
+}
+
 // Represents an independent disk record
 // Reference: vCloud API 27.0 - DiskType
 // https://code.vmware.com/apis/287/vcloud#/doc/doc/types/QueryResultDiskRecordType.html
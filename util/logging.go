@@ -168,6 +168,13 @@ func hideSensitive(in string, onScreen bool) string {
 	if !onScreen && LogPasswords {
 		return in
 	}
+	return ScrubbedText(in)
+}
+
+// ScrubbedText returns the text with passwords, tokens, and certificate details masked. Unlike
+// SanitizedText, it ignores LogPasswords, therefore it can be used for data that is stored (e.g.
+// recorded HTTP interactions)
+func ScrubbedText(in string) string {
 	var out string
 
 	// Filters out the below:
@@ -198,15 +205,15 @@ func hideSensitive(in string, onScreen bool) string {
 
 	// Bearer token inside JSON response
 	re8 := regexp.MustCompile(`("access_token":\s*)"[^"]*`)
-	out = re8.ReplaceAllString(out, `${1}*******`)
+	out = re8.ReplaceAllString(out, `${1}"*******`)
 
 	// Token inside JSON response
 	re9 := regexp.MustCompile(`("refresh_token":\s*)"[^"]*`)
-	out = re9.ReplaceAllString(out, `${1}*******`)
+	out = re9.ReplaceAllString(out, `${1}"*******`)
 
 	// API Token inside CSE JSON payloads
 	re10 := regexp.MustCompile(`("apiToken":\s*)"[^"]*`)
-	out = re10.ReplaceAllString(out, `${1}*******`)
+	out = re10.ReplaceAllString(out, `${1}"*******`)
 
 	return out
 }
@@ -244,6 +251,13 @@ func SanitizedHeader(inputHeader http.Header) http.Header {
 	if LogPasswords {
 		return inputHeader
 	}
+	return ScrubbedHeader(inputHeader)
+}
+
+// ScrubbedHeader returns a http.Header with sensitive fields masked. Unlike SanitizedHeader, it
+// ignores LogPasswords, therefore it can be used for data that is stored (e.g. recorded HTTP
+// interactions)
+func ScrubbedHeader(inputHeader http.Header) http.Header {
 	var sensitiveKeys = []string{
 		"Config-Secret",
 		"Authorization",
@@ -255,13 +269,13 @@ func SanitizedHeader(inputHeader http.Header) http.Header {
 		// Explicitly mask only token in SIGN token so that other details are not obfuscated
 		// Header format: SIGN token="`+base64GzippedSignToken+`",org="`+org+`"
 		if (key == "authorization" || key == "Authorization") && len(value) == 1 &&
-			strings.HasPrefix(value[0], "SIGN") && !LogPasswords {
+			strings.HasPrefix(value[0], "SIGN") {
 
 			re := regexp.MustCompile(`(SIGN token=")([^"]*)(.*)`)
 			out := re.ReplaceAllString(value[0], `${1}********${3}"`)
 
-			Logger.Printf("\t%s: %s\n", key, out)
 			// Do not perform any post processing on this header
+			sanitizedHeader[key] = []string{out}
 			continue
 		}
 
@@ -276,6 +290,12 @@ func SanitizedHeader(inputHeader http.Header) http.Header {
 	return sanitizedHeader
 }
 
+// SanitizedText hides passwords, tokens, and certificate details in a request or response body,
+// unless LogPasswords is set
+func SanitizedText(in string) string {
+	return hideSensitive(in, false)
+}
+
 // logSanitizedHeader logs the contents of the header after sanitizing
 func logSanitizedHeader(inputHeader http.Header) {
 	for key, value := range SanitizedHeader(inputHeader) {
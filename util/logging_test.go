@@ -5,6 +5,8 @@
 package util
 
 import (
+	"encoding/json"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -138,3 +140,22 @@ func init() {
 	_ = os.Setenv(envLogSkipTagList, "")
 	_ = os.Setenv(envLogFileName, "")
 }
+
+func TestScrubbedText(t *testing.T) {
+	LogPasswords = true
+	defer func() { LogPasswords = false }()
+
+	in := `{"access_token": "secret1","refresh_token":"secret2","apiToken":"secret3"}`
+	out := ScrubbedText(in)
+	if regexp.MustCompile(`secret\d`).MatchString(out) {
+		t.Errorf("expected secrets to be masked, got %s", out)
+	}
+	if !json.Valid([]byte(out)) {
+		t.Errorf("expected masked JSON to be valid, got %s", out)
+	}
+
+	header := ScrubbedHeader(http.Header{"Authorization": {"Basic c2VjcmV0"}, "Accept": {"application/json"}})
+	if header.Get("Authorization") == "Basic c2VjcmV0" || header.Get("Accept") != "application/json" {
+		t.Errorf("expected only Authorization to be masked, got %v", header)
+	}
+}
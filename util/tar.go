@@ -7,6 +7,7 @@ package util
 import (
 	"archive/tar"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -126,6 +127,69 @@ func Unpack(tarFile string) ([]string, string, error) {
 	}
 }
 
+// Pack creates the tar file tarFile with the given files of baseDir, in the given order. fileNames
+// are relative to baseDir and are used as names of the entries in the archive. An OVA, for
+// instance, requires the OVF descriptor to be the first entry.
+func Pack(tarFile, baseDir string, fileNames []string) (err error) {
+	writer, err := os.Create(filepath.Clean(tarFile))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errClose := writer.Close(); errClose != nil && err == nil {
+			err = errClose
+		}
+		if err != nil {
+			if errRemove := os.Remove(tarFile); errRemove != nil {
+				Logger.Printf("[DEBUG - Pack] error removing incomplete file %s: %s", tarFile, errRemove)
+			}
+		}
+	}()
+
+	tarWriter := tar.NewWriter(writer)
+	for _, fileName := range fileNames {
+		if !filepath.IsLocal(fileName) {
+			return fmt.Errorf("file name %s must be relative to %s", fileName, baseDir)
+		}
+		Logger.Printf("[TRACE] packing file: %s \n", fileName)
+		if err := packFile(tarWriter, baseDir, fileName); err != nil {
+			return err
+		}
+	}
+	return tarWriter.Close()
+}
+
+// packFile adds a single regular file to the tar archive
+func packFile(tarWriter *tar.Writer, baseDir, fileName string) error {
+	file, err := os.Open(filepath.Join(baseDir, fileName))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			Logger.Printf("Error closing file: %s\n", err)
+		}
+	}()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return fmt.Errorf("file %s is not a regular file", fileName)
+	}
+	header, err := tar.FileInfoHeader(fileInfo, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(fileName)
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
 func isExtractedFileValid(file *os.File, expectedFileSize int64) error {
 	if fInfo, err := file.Stat(); err == nil {
 		Logger.Printf("[TRACE] isExtractedFileValid: created file size %#v, size from header %#v.\n", fInfo.Size(), expectedFileSize)
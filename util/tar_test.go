@@ -5,6 +5,8 @@
 package util
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -29,3 +31,49 @@ func TestSanitizedName(t *testing.T) {
 		}
 	}
 }
+
+func TestPack(t *testing.T) {
+	baseDir := t.TempDir()
+	files := map[string]string{
+		"descriptor.ovf": "<Envelope/>",
+		"disk1.vmdk":     "disk content",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tarFile := filepath.Join(t.TempDir(), "template.ova")
+	if err := Pack(tarFile, baseDir, []string{"../disk1.vmdk"}); err == nil {
+		t.Errorf("expected an error for a file outside of the base directory")
+	}
+	if _, err := os.Stat(tarFile); !os.IsNotExist(err) {
+		t.Errorf("expected incomplete tar file to be removed")
+	}
+
+	if err := Pack(tarFile, baseDir, []string{"descriptor.ovf", "disk1.vmdk"}); err != nil {
+		t.Fatalf("error packing files: %s", err)
+	}
+	filePaths, dst, err := Unpack(tarFile)
+	if err != nil {
+		t.Fatalf("error unpacking files: %s", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dst); err != nil {
+			t.Errorf("error removing %s: %s", dst, err)
+		}
+	}()
+	if len(filePaths) != 2 || filepath.Base(filePaths[0]) != "descriptor.ovf" {
+		t.Fatalf("expected descriptor.ovf to be the first of 2 files, got %v", filePaths)
+	}
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != files[filepath.Base(filePath)] {
+			t.Errorf("unexpected content of %s: %s", filePath, content)
+		}
+	}
+}